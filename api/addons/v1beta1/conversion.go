@@ -23,30 +23,105 @@ import (
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 )
 
 func (src *ClusterResourceSet) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*addonsv1.ClusterResourceSet)
 
-	return Convert_v1beta1_ClusterResourceSet_To_v1beta2_ClusterResourceSet(src, dst, nil)
+	if err := Convert_v1beta1_ClusterResourceSet_To_v1beta2_ClusterResourceSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &addonsv1.ClusterResourceSet{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	RestoreResourceRefs(restored.Spec.Resources, dst.Spec.Resources)
+
+	return nil
 }
 
 func (dst *ClusterResourceSet) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*addonsv1.ClusterResourceSet)
 
-	return Convert_v1beta2_ClusterResourceSet_To_v1beta1_ClusterResourceSet(src, dst, nil)
+	if err := Convert_v1beta2_ClusterResourceSet_To_v1beta1_ClusterResourceSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *ClusterResourceSetBinding) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*addonsv1.ClusterResourceSetBinding)
 
-	return Convert_v1beta1_ClusterResourceSetBinding_To_v1beta2_ClusterResourceSetBinding(src, dst, nil)
+	if err := Convert_v1beta1_ClusterResourceSetBinding_To_v1beta2_ClusterResourceSetBinding(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &addonsv1.ClusterResourceSetBinding{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	RestoreResourceBindings(&restored.Spec, &dst.Spec)
+
+	return nil
 }
 
 func (dst *ClusterResourceSetBinding) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*addonsv1.ClusterResourceSetBinding)
 
-	return Convert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(src, dst, nil)
+	if err := Convert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, dst)
+}
+
+// RestoreResourceRefs restores the fields of ResourceRefs which do not exist in older API versions.
+// ResourceRefs are restored only if they still refer to the same resource.
+// NOTE: this func is used also by the conversions of the v1alpha3 and v1alpha4 API versions.
+func RestoreResourceRefs(restored, dst []addonsv1.ResourceRef) {
+	for i := range dst {
+		if i < len(restored) && isSameResource(restored[i], dst[i]) {
+			dst[i] = restored[i]
+		}
+	}
+}
+
+// RestoreResourceBindings restores the fields of ResourceBindings which do not exist in older API versions.
+// ResourceBindings are restored only if they still refer to the same resource.
+// NOTE: this func is used also by the conversions of the v1alpha3 and v1alpha4 API versions.
+func RestoreResourceBindings(restored, dst *addonsv1.ClusterResourceSetBindingSpec) {
+	for _, dstBinding := range dst.Bindings {
+		for _, restoredBinding := range restored.Bindings {
+			if dstBinding == nil || restoredBinding == nil || dstBinding.ClusterResourceSetName != restoredBinding.ClusterResourceSetName {
+				continue
+			}
+			for i := range dstBinding.Resources {
+				if i >= len(restoredBinding.Resources) || !isSameResource(restoredBinding.Resources[i].ResourceRef, dstBinding.Resources[i].ResourceRef) {
+					continue
+				}
+				dstBinding.Resources[i].ResourceRef = restoredBinding.Resources[i].ResourceRef
+				dstBinding.Resources[i].LastError = restoredBinding.Resources[i].LastError
+				dstBinding.Resources[i].Objects = restoredBinding.Resources[i].Objects
+			}
+		}
+	}
+}
+
+func isSameResource(a, b addonsv1.ResourceRef) bool {
+	return a.Name == b.Name && a.Kind == b.Kind
+}
+
+func Convert_v1beta2_ResourceRef_To_v1beta1_ResourceRef(in *addonsv1.ResourceRef, out *ResourceRef, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_ResourceRef_To_v1beta1_ResourceRef(in, out, s)
+}
+
+func Convert_v1beta2_ResourceBinding_To_v1beta1_ResourceBinding(in *addonsv1.ResourceBinding, out *ResourceBinding, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_ResourceBinding_To_v1beta1_ResourceBinding(in, out, s)
 }
 
 func Convert_v1beta2_ClusterResourceSetStatus_To_v1beta1_ClusterResourceSetStatus(in *addonsv1.ClusterResourceSetStatus, out *ClusterResourceSetStatus, s apimachineryconversion.Scope) error {
@@ -114,3 +189,21 @@ func Convert_v1_Condition_To_v1beta1_Condition(in *metav1.Condition, out *cluste
 func Convert_v1beta1_Condition_To_v1_Condition(in *clusterv1beta1.Condition, out *metav1.Condition, s apimachineryconversion.Scope) error {
 	return clusterv1beta1.Convert_v1beta1_Condition_To_v1_Condition(in, out, s)
 }
+
+func Convert_Pointer_v1beta1_ResourceSetBinding_To_Pointer_v1beta2_ResourceSetBinding(in **ResourceSetBinding, out **addonsv1.ResourceSetBinding, s apimachineryconversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = &addonsv1.ResourceSetBinding{}
+	return Convert_v1beta1_ResourceSetBinding_To_v1beta2_ResourceSetBinding(*in, *out, s)
+}
+
+func Convert_Pointer_v1beta2_ResourceSetBinding_To_Pointer_v1beta1_ResourceSetBinding(in **addonsv1.ResourceSetBinding, out **ResourceSetBinding, s apimachineryconversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = &ResourceSetBinding{}
+	return Convert_v1beta2_ResourceSetBinding_To_v1beta1_ResourceSetBinding(*in, *out, s)
+}
@@ -36,6 +36,10 @@ const (
 	// ClusterResourceSetResourcesNotAppliedReason is the reason used when applying at least one of the resources to one of the matching clusters failed.
 	ClusterResourceSetResourcesNotAppliedReason = "NotApplied"
 
+	// ClusterResourceSetResourcesAppliedRenderingFailedReason is the reason used when rendering at least one of the resources
+	// for one of the matching clusters failed, e.g. because of an invalid template.
+	ClusterResourceSetResourcesAppliedRenderingFailedReason = "RenderingFailed"
+
 	// ClusterResourceSetResourcesAppliedWrongSecretTypeReason is the reason used when the Secret's type in the resource list is not supported.
 	ClusterResourceSetResourcesAppliedWrongSecretTypeReason = "WrongSecretType"
 
@@ -68,7 +72,7 @@ type ClusterResourceSetSpec struct {
 	Resources []ResourceRef `json:"resources,omitempty"`
 
 	// strategy is the strategy to be used during applying resources. Defaults to ApplyOnce. This field is immutable.
-	// +kubebuilder:validation:Enum=ApplyOnce;Reconcile
+	// +kubebuilder:validation:Enum=ApplyOnce;Reconcile;ServerSideApply
 	// +optional
 	Strategy string `json:"strategy,omitempty"`
 }
@@ -84,6 +88,17 @@ const (
 	ConfigMapClusterResourceSetResourceKind ClusterResourceSetResourceKind = "ConfigMap"
 )
 
+// ClusterResourceSetResourceFormat is a string representation of a ClusterResourceSet resource format.
+type ClusterResourceSetResourceFormat string
+
+// Define the ClusterResourceSetResourceFormat constants.
+const (
+	RawClusterResourceSetResourceFormat           ClusterResourceSetResourceFormat = "Raw"
+	TemplateClusterResourceSetResourceFormat      ClusterResourceSetResourceFormat = "Template"
+	HelmChartClusterResourceSetResourceFormat     ClusterResourceSetResourceFormat = "HelmChart"
+	KustomizationClusterResourceSetResourceFormat ClusterResourceSetResourceFormat = "Kustomization"
+)
+
 // ResourceRef specifies a resource.
 type ResourceRef struct {
 	// name of the resource that is in the same namespace with ClusterResourceSet object.
@@ -96,6 +111,76 @@ type ResourceRef struct {
 	// +kubebuilder:validation:Enum=Secret;ConfigMap
 	// +required
 	Kind string `json:"kind"`
+
+	// format of the data in the resource. Supported formats are:
+	// - Raw: each key of the resource contains YAML or JSON manifests, which are applied as is.
+	// - Template: each key of the resource contains a Go template, which is rendered for each cluster into
+	//   YAML or JSON manifests. The template can access the Cluster with `{{ .Cluster.Name }}`,
+	//   `{{ .Cluster.Namespace }}`, `{{ .Cluster.Labels }}`, `{{ .Cluster.Annotations }}`,
+	//   `{{ .Cluster.ClusterNetwork }}` and `{{ .Cluster.ControlPlaneEndpoint }}`, and the variables of the
+	//   Cluster topology with `{{ .Variables }}`. Sprig functions are supported.
+	// - HelmChart: the resource contains a Helm chart, which is rendered for each cluster.
+	// - Kustomization: the resource contains a kustomization, which is built for each cluster.
+	// For HelmChart and Kustomization, the files are read from a tar archive stored under a key with
+	// the .tgz or .tar.gz suffix (binaryData for ConfigMaps), or from the keys of the resource.
+	// Defaults to Raw.
+	// +kubebuilder:validation:Enum=Raw;Template;HelmChart;Kustomization
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// helmChart configures how the Helm chart in the resource is rendered.
+	// It can only be set if format is HelmChart.
+	// +optional
+	HelmChart *HelmChartSource `json:"helmChart,omitempty"`
+
+	// kustomization configures how the kustomization in the resource is built.
+	// It can only be set if format is Kustomization.
+	// +optional
+	Kustomization *KustomizationSource `json:"kustomization,omitempty"`
+}
+
+// HelmChartSource configures how a Helm chart is rendered.
+// Dependencies must be included in the charts directory of the chart; hooks and tests are not applied.
+type HelmChartSource struct {
+	// releaseName is the name of the release used to render the chart.
+	// Defaults to the name of the resource.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=53
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// releaseNamespace is the namespace of the release used to render the chart.
+	// Note: The chart templates are responsible for setting the namespace of namespaced objects, e.g.
+	// by using `{{ .Release.Namespace }}`.
+	// Defaults to default.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ReleaseNamespace string `json:"releaseNamespace,omitempty"`
+
+	// valuesTemplate is a Go template rendered for each cluster into a YAML document with the values
+	// used to render the chart, overriding the values.yaml of the chart.
+	// The template can access the same data as resources with the Template format, e.g.
+	// `{{ .Cluster.ClusterNetwork.Pods }}` or `{{ .Variables.cni.mtu }}`.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=102400
+	ValuesTemplate string `json:"valuesTemplate,omitempty"`
+}
+
+// KustomizationSource configures how a kustomization is built.
+type KustomizationSource struct {
+	// path is the path of the directory containing the kustomization to build, relative to the
+	// root of the files in the resource. This allows to build overlays which reference a base in
+	// another directory of the same resource.
+	// Resources and components of the kustomization must be files in the resource; remote sources are not supported.
+	// The kustomization.yaml file of the directory is rendered as a Go template with the same data
+	// as HelmChartSource.valuesTemplate before building, so it can be customized for each cluster.
+	// Defaults to the root of the files in the resource.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Path string `json:"path,omitempty"`
 }
 
 // ClusterResourceSetStrategy is a string representation of a ClusterResourceSet Strategy.
@@ -108,6 +193,11 @@ const (
 	// ClusterResourceSetStrategyReconcile reapplies the resources managed by a ClusterResourceSet
 	// if their normalized hash changes.
 	ClusterResourceSetStrategyReconcile ClusterResourceSetStrategy = "Reconcile"
+	// ClusterResourceSetStrategyServerSideApply applies the resources managed by a ClusterResourceSet using
+	// server-side apply. Resources are re-applied when their normalized hash changes and periodically, to correct
+	// drift in the workload cluster; objects which are removed from the ClusterResourceSet are deleted from the
+	// workload cluster.
+	ClusterResourceSetStrategyServerSideApply ClusterResourceSetStrategy = "ServerSideApply"
 )
 
 // SetTypedStrategy sets the Strategy field to the string representation of ClusterResourceSetStrategy.
@@ -43,6 +43,48 @@ type ResourceBinding struct {
 	// applied is to track if a resource is applied to the cluster or not.
 	// +required
 	Applied bool `json:"applied"`
+
+	// lastError is the error that occurred the last time the resource was applied to the cluster.
+	// It is only set for "ServerSideApply" ClusterResourceSet.spec.strategy.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10240
+	LastError string `json:"lastError,omitempty"`
+
+	// objects is the list of objects applied to the cluster from this resource.
+	// It is only set for "ServerSideApply" ClusterResourceSet.spec.strategy and it is used to delete objects
+	// from the cluster when they are removed from the resource.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	Objects []ResourceBindingObject `json:"objects,omitempty"`
+}
+
+// ResourceBindingObject identifies an object applied to the cluster from a resource.
+type ResourceBindingObject struct {
+	// apiVersion of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	APIVersion string `json:"apiVersion"`
+
+	// kind of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Kind string `json:"kind"`
+
+	// namespace of the object. Empty for cluster-scoped objects.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
 }
 
 // ANCHOR_END: ResourceBinding
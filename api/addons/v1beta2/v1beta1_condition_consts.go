@@ -36,6 +36,9 @@ const (
 	// RetrievingResourceFailedV1Beta1Reason (Severity=Warning) documents at least one of the resources are not successfully retrieved.
 	RetrievingResourceFailedV1Beta1Reason = "RetrievingResourceFailed"
 
+	// RenderingResourceFailedV1Beta1Reason (Severity=Warning) documents at least one of the resources failed to be rendered for one of the matching clusters.
+	RenderingResourceFailedV1Beta1Reason = "RenderingResourceFailed"
+
 	// WrongSecretTypeV1Beta1Reason (Severity=Warning) documents at least one of the Secret's type in the resource list is not supported.
 	WrongSecretTypeV1Beta1Reason = "WrongSecretType"
 )
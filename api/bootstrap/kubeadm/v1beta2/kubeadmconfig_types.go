@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	"fmt"
+	"slices"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -38,6 +39,11 @@ const (
 	Ignition Format = "ignition"
 )
 
+const (
+	// EtcdSnapshotDataKey is the key used to store a chunk of a gzip compressed etcd snapshot in a Secret.
+	EtcdSnapshotDataKey = "snapshot.db.gz"
+)
+
 var (
 	cannotUseWithIgnition                            = fmt.Sprintf("not supported when spec.format is set to: %q", Ignition)
 	conflictingFileSourceMsg                         = "only one of content or contentFrom may be specified for a single file"
@@ -125,6 +131,12 @@ type KubeadmConfigSpec struct {
 	// ignition contains Ignition specific configuration.
 	// +optional
 	Ignition *IgnitionSpec `json:"ignition,omitempty"`
+
+	// etcdRestore configures the restore of the local etcd data directory from a snapshot before kubeadm init runs.
+	// This field is set by the KubeadmControlPlane controller on the first Machine of a control plane being restored
+	// from an etcd snapshot, and it is not intended to be set by users.
+	// +optional
+	EtcdRestore *EtcdRestoreSource `json:"etcdRestore,omitempty"`
 }
 
 // Default defaults a KubeadmConfigSpec.
@@ -156,6 +168,8 @@ func (c *KubeadmConfigSpec) Validate(pathPrefix *field.Path) field.ErrorList {
 	allErrs = append(allErrs, c.validateFiles(pathPrefix)...)
 	allErrs = append(allErrs, c.validateUsers(pathPrefix)...)
 	allErrs = append(allErrs, c.validateIgnition(pathPrefix)...)
+	allErrs = append(allErrs, c.validateEncryptionAlgorithm(pathPrefix)...)
+	allErrs = append(allErrs, c.validateEtcdRestore(pathPrefix)...)
 
 	// Validate JoinConfiguration.
 	if c.JoinConfiguration != nil {
@@ -212,6 +226,52 @@ func (c *KubeadmConfigSpec) Validate(pathPrefix *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// supportedEncryptionAlgorithms are the encryption algorithm types supported both by Cluster API and by kubeadm.
+var supportedEncryptionAlgorithms = []EncryptionAlgorithmType{
+	EncryptionAlgorithmECDSAP256,
+	EncryptionAlgorithmECDSAP384,
+	EncryptionAlgorithmRSA2048,
+	EncryptionAlgorithmRSA3072,
+	EncryptionAlgorithmRSA4096,
+}
+
+// validateEncryptionAlgorithm ensures the encryption algorithm is supported both by Cluster API and by kubeadm.
+func (c *KubeadmConfigSpec) validateEncryptionAlgorithm(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.ClusterConfiguration == nil || c.ClusterConfiguration.EncryptionAlgorithm == "" {
+		return allErrs
+	}
+
+	if !slices.Contains(supportedEncryptionAlgorithms, c.ClusterConfiguration.EncryptionAlgorithm) {
+		allErrs = append(allErrs,
+			field.NotSupported(pathPrefix.Child("clusterConfiguration", "encryptionAlgorithm"), c.ClusterConfiguration.EncryptionAlgorithm, supportedEncryptionAlgorithms),
+		)
+	}
+
+	return allErrs
+}
+
+// validateEtcdRestore ensures the source of the etcd snapshot to restore is set.
+func (c *KubeadmConfigSpec) validateEtcdRestore(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.EtcdRestore == nil {
+		return allErrs
+	}
+
+	if c.EtcdRestore.SnapshotURL == nil {
+		allErrs = append(allErrs,
+			field.Required(
+				pathPrefix.Child("etcdRestore", "snapshotURL"),
+				"must be set",
+			),
+		)
+	}
+
+	return allErrs
+}
+
 func (c *KubeadmConfigSpec) validateFiles(pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -672,6 +732,40 @@ type SecretFileSource struct {
 	Key string `json:"key"`
 }
 
+// EtcdRestoreSource defines the etcd snapshot to restore the local etcd data directory from.
+type EtcdRestoreSource struct {
+	// snapshotURL defines where the Machine downloads the gzip compressed snapshot from before restoring it.
+	// The snapshot is not embedded into the bootstrap data, so its size is not limited.
+	// +required
+	SnapshotURL *EtcdSnapshotURL `json:"snapshotURL,omitempty"`
+}
+
+// EtcdSnapshotURL defines the URL of a gzip compressed etcd snapshot and its checksum.
+type EtcdSnapshotURL struct {
+	// url of the gzip compressed snapshot, e.g. a pre-signed URL of an object store.
+	// The URL must use https, and it must be reachable from the Machine restoring etcd.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+
+	// sha256 is the hex encoded SHA-256 checksum of the gzip compressed snapshot; the Machine does not restore
+	// the snapshot if the checksum of the downloaded snapshot does not match.
+	// +required
+	// +kubebuilder:validation:MinLength=64
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	SHA256 string `json:"sha256"`
+
+	// caBundle is a PEM encoded CA bundle used by the Machine to verify the server certificate of the URL;
+	// if not set, the CAs trusted by the Machine are used.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=51200
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
 // PasswdSource is a union of all possible external source types for passwd data.
 // Only one field may be populated in any given instance. Developers adding new
 // sources of data for target systems should add them here.
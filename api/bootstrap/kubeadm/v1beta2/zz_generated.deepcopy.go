@@ -337,6 +337,46 @@ func (in *Etcd) DeepCopy() *Etcd {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestoreSource) DeepCopyInto(out *EtcdRestoreSource) {
+	*out = *in
+	if in.SnapshotURL != nil {
+		in, out := &in.SnapshotURL, &out.SnapshotURL
+		*out = new(EtcdSnapshotURL)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreSource.
+func (in *EtcdRestoreSource) DeepCopy() *EtcdRestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotURL) DeepCopyInto(out *EtcdSnapshotURL) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotURL.
+func (in *EtcdSnapshotURL) DeepCopy() *EtcdSnapshotURL {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalEtcd) DeepCopyInto(out *ExternalEtcd) {
 	*out = *in
@@ -890,6 +930,11 @@ func (in *KubeadmConfigSpec) DeepCopyInto(out *KubeadmConfigSpec) {
 		*out = new(IgnitionSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EtcdRestore != nil {
+		in, out := &in.EtcdRestore, &out.EtcdRestore
+		*out = new(EtcdRestoreSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigSpec.
@@ -63,6 +63,14 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	if ok {
 		bootstrapv1beta1.RestoreKubeadmConfigSpec(&restored.Spec.KubeadmConfigSpec, &dst.Spec.KubeadmConfigSpec)
+		dst.Spec.CertificateAuthorityRotation = restored.Spec.CertificateAuthorityRotation
+		dst.Status.CertificateAuthorityRotation = restored.Status.CertificateAuthorityRotation
+		dst.Spec.EtcdBackup = restored.Spec.EtcdBackup
+		dst.Spec.EtcdRestore = restored.Spec.EtcdRestore
+		dst.Spec.EtcdDefragmentation = restored.Spec.EtcdDefragmentation
+		dst.Status.EtcdBackup = restored.Status.EtcdBackup
+		dst.Status.EtcdRestore = restored.Status.EtcdRestore
+		dst.Status.EtcdMembers = restored.Status.EtcdMembers
 	}
 
 	// Override restored data with timeouts values already existing in v1beta1 but in other structs.
@@ -123,6 +131,11 @@ func (dst *KubeadmControlPlaneTemplate) ConvertFrom(srcRaw conversion.Hub) error
 	return utilconversion.MarshalData(src, dst)
 }
 
+func Convert_v1beta2_KubeadmControlPlaneSpec_To_v1beta1_KubeadmControlPlaneSpec(in *controlplanev1.KubeadmControlPlaneSpec, out *KubeadmControlPlaneSpec, s apimachineryconversion.Scope) error {
+	// NOTE: certificateAuthorityRotation, etcdBackup and etcdRestore do not exist in v1beta1 and they are preserved on down-conversion by MarshalData.
+	return autoConvert_v1beta2_KubeadmControlPlaneSpec_To_v1beta1_KubeadmControlPlaneSpec(in, out, s)
+}
+
 func Convert_v1beta2_KubeadmControlPlaneStatus_To_v1beta1_KubeadmControlPlaneStatus(in *controlplanev1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta2_KubeadmControlPlaneStatus_To_v1beta1_KubeadmControlPlaneStatus(in, out, s); err != nil {
 		return err
@@ -26,13 +26,21 @@ import (
 )
 
 // RolloutStrategyType defines the rollout strategies for a KubeadmControlPlane.
-// +kubebuilder:validation:Enum=RollingUpdate
+// +kubebuilder:validation:Enum=RollingUpdate;ScaleInFirst
 type RolloutStrategyType string
 
 const (
 	// RollingUpdateStrategyType replaces the old control planes by new one using rolling update
 	// i.e. gradually scale up or down the old control planes and scale up or down the new one.
 	RollingUpdateStrategyType RolloutStrategyType = "RollingUpdate"
+
+	// ScaleInFirstStrategyType replaces the old control planes by new one by first removing an outdated
+	// control plane machine, including its etcd member, and then creating its replacement.
+	// This strategy never creates more control plane machines than spec.replicas, and it is intended for
+	// infrastructures without spare capacity, e.g. bare metal or edge sites.
+	// Before removing a machine, KCP checks that the etcd cluster retains quorum without it; if not, the
+	// rollout is put on hold until the etcd cluster is healthy again.
+	ScaleInFirstStrategyType RolloutStrategyType = "ScaleInFirst"
 )
 
 const (
@@ -69,6 +77,25 @@ const (
 	// ensure it runs last (thus ensuring that kubelet is still working while other pre-terminate hooks run).
 	PreTerminateHookCleanupAnnotation = clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/kcp-cleanup"
 
+	// EtcdSnapshotLabel is the label set on the Secrets storing the etcd snapshots taken by a KubeadmControlPlane;
+	// the value of the label is the name of the snapshot, hashed if longer than 63 characters.
+	EtcdSnapshotLabel = "controlplane.cluster.x-k8s.io/etcd-snapshot"
+
+	// EtcdSnapshotChunksAnnotation is the annotation set on the first Secret storing an etcd snapshot taken by a KubeadmControlPlane;
+	// the value of the annotation is the number of Secrets the snapshot is split across.
+	// NOTE: The first Secret is created after all the other Secrets, so a snapshot is complete only if the first Secret exists.
+	EtcdSnapshotChunksAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-chunks"
+
+	// EtcdSnapshotChunkSizeAnnotation is the annotation set on each Secret storing an etcd snapshot taken by a KubeadmControlPlane;
+	// the value of the annotation is the size in bytes of the chunk of the snapshot stored in the Secret.
+	EtcdSnapshotChunkSizeAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-chunk-size"
+
+	// DefaultEtcdBackupMaxSnapshots defines the default number of etcd snapshots to retain.
+	DefaultEtcdBackupMaxSnapshots = int32(3)
+
+	// DefaultEtcdBackupMaxTotalSizeBytes defines the default maximum total size of the etcd snapshots to retain.
+	DefaultEtcdBackupMaxTotalSizeBytes = int64(128 * 1024 * 1024)
+
 	// DefaultMinHealthyPeriodSeconds defines the default minimum period before we consider a remediation on a
 	// machine unrelated from the previous remediation.
 	DefaultMinHealthyPeriodSeconds = int32(60 * 60)
@@ -131,6 +158,59 @@ const (
 	KubeadmControlPlaneCertificatesAvailableReason = clusterv1.AvailableReason
 )
 
+// KubeadmControlPlane's CertificateAuthorityRotating condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneCertificateAuthorityRotatingCondition is true if a rotation of the certificate authorities
+	// of the cluster is in progress.
+	KubeadmControlPlaneCertificateAuthorityRotatingCondition = "CertificateAuthorityRotating"
+
+	// KubeadmControlPlaneCertificateAuthorityRotatingReason surfaces when a rotation of the certificate authorities
+	// of the cluster is in progress.
+	KubeadmControlPlaneCertificateAuthorityRotatingReason = "CertificateAuthorityRotating"
+
+	// KubeadmControlPlaneCertificateAuthorityNotRotatingReason surfaces when a rotation of the certificate authorities
+	// of the cluster is not in progress.
+	KubeadmControlPlaneCertificateAuthorityNotRotatingReason = "CertificateAuthorityNotRotating"
+
+	// KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason surfaces unexpected failures when
+	// rotating the certificate authorities of the cluster.
+	KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's EtcdBackupSucceeded condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdBackupSucceededCondition is true if the last etcd snapshot was taken and stored successfully.
+	// Note: this condition is set only when spec.etcdBackup is set.
+	KubeadmControlPlaneEtcdBackupSucceededCondition = "EtcdBackupSucceeded"
+
+	// KubeadmControlPlaneEtcdBackupSucceededReason surfaces when the last etcd snapshot was taken and stored successfully.
+	KubeadmControlPlaneEtcdBackupSucceededReason = "Succeeded"
+
+	// KubeadmControlPlaneEtcdBackupFailedReason surfaces when taking or storing the last etcd snapshot failed.
+	KubeadmControlPlaneEtcdBackupFailedReason = "Failed"
+
+	// KubeadmControlPlaneEtcdBackupSizeLimitExceededReason surfaces when the last etcd snapshot was not stored
+	// because it did not fit into spec.etcdBackup.maxTotalSizeBytes together with the retained snapshots, or
+	// when a single etcd snapshot exceeds spec.etcdBackup.maxTotalSizeBytes.
+	KubeadmControlPlaneEtcdBackupSizeLimitExceededReason = "SizeLimitExceeded"
+)
+
+// KubeadmControlPlane's EtcdRestoring condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdRestoringCondition is true if a restore of the etcd cluster from a snapshot is in progress.
+	KubeadmControlPlaneEtcdRestoringCondition = "EtcdRestoring"
+
+	// KubeadmControlPlaneEtcdRestoringReason surfaces when a restore of the etcd cluster from a snapshot is in progress.
+	KubeadmControlPlaneEtcdRestoringReason = "EtcdRestoring"
+
+	// KubeadmControlPlaneEtcdNotRestoringReason surfaces when a restore of the etcd cluster from a snapshot is not in progress.
+	KubeadmControlPlaneEtcdNotRestoringReason = "EtcdNotRestoring"
+
+	// KubeadmControlPlaneEtcdRestoringInternalErrorReason surfaces unexpected failures when restoring
+	// the etcd cluster from a snapshot.
+	KubeadmControlPlaneEtcdRestoringInternalErrorReason = clusterv1.InternalErrorReason
+)
+
 // KubeadmControlPlane's EtcdClusterHealthy condition and corresponding reasons.
 const (
 	// KubeadmControlPlaneEtcdClusterHealthyCondition surfaces issues to etcd cluster hosted on machines managed by this object.
@@ -338,6 +418,10 @@ const (
 	// waits for the control plane Machines to be deleted.
 	KubeadmControlPlaneDeletingWaitingForMachineDeletionReason = "WaitingForMachineDeletion"
 
+	// KubeadmControlPlaneDeletingWaitingForEtcdSnapshotReason surfaces when the KCP deletion
+	// waits for the etcd snapshot being taken to complete before deleting the stored etcd snapshots.
+	KubeadmControlPlaneDeletingWaitingForEtcdSnapshotReason = "WaitingForEtcdSnapshot"
+
 	// KubeadmControlPlaneDeletingDeletionCompletedReason surfaces when the KCP deletion has been completed.
 	// This reason is set right after the `kubeadm.controlplane.cluster.x-k8s.io` finalizer is removed.
 	// This means that the object will go away (i.e. be removed from etcd), except if there are other
@@ -458,6 +542,28 @@ type KubeadmControlPlaneSpec struct {
 	// +optional
 	RolloutAfter *metav1.Time `json:"rolloutAfter,omitempty"`
 
+	// certificateAuthorityRotation is a field to indicate the certificate authorities of the cluster
+	// should be rotated.
+	// +optional
+	CertificateAuthorityRotation *CertificateAuthorityRotation `json:"certificateAuthorityRotation,omitempty"`
+
+	// etcdBackup configures periodic snapshots of the etcd cluster managed by the KubeadmControlPlane.
+	// NOTE: This field cannot be set when using an external etcd.
+	// +optional
+	EtcdBackup *EtcdBackupPolicy `json:"etcdBackup,omitempty"`
+
+	// etcdRestore is a field to indicate the etcd cluster managed by the KubeadmControlPlane should be restored
+	// from a snapshot.
+	// NOTE: This field cannot be set when using an external etcd.
+	// +optional
+	EtcdRestore *EtcdRestore `json:"etcdRestore,omitempty"`
+
+	// etcdDefragmentation configures the defragmentation of the members of the etcd cluster managed by
+	// the KubeadmControlPlane.
+	// NOTE: This field cannot be set when using an external etcd.
+	// +optional
+	EtcdDefragmentation *EtcdDefragmentationPolicy `json:"etcdDefragmentation,omitempty"`
+
 	// rolloutStrategy is the RolloutStrategy to use to replace control plane machines with
 	// new ones.
 	// +optional
@@ -531,11 +637,252 @@ type RolloutBefore struct {
 	CertificatesExpiryDays *int32 `json:"certificatesExpiryDays,omitempty"`
 }
 
+// CertificateAuthorityRotation describes when the certificate authorities of the cluster should be rotated.
+//
+// The rotation covers the cluster CA, the etcd CA, the front-proxy CA and the service account signing keys
+// generated by the KubeadmControlPlane; user provided (external) certificates and keys are not rotated.
+// The rotation is performed in phases, and each phase rolls out all the Machines of the cluster:
+//   - DistributingTrustBundle: the new certificate authorities and service account public key are added to
+//     the trust bundles, while the old ones are still used for signing.
+//   - SwitchingSigningCA: the new certificate authorities and service account key are used for signing,
+//     while the old ones are still trusted.
+//   - RemovingOldCA: the old certificate authorities are removed from the trust bundles; the old service
+//     account public key is still trusted, so service account tokens signed with it remain valid.
+//   - RemovingOldServiceAccountKey: the old service account public key is removed; only control plane
+//     Machines are rolled out in this phase.
+//
+// Control plane Machines are rolled out by the KubeadmControlPlane, while Machines of MachineDeployments
+// are rolled out by setting MachineDeployment's spec.rolloutAfter; all the other Machines of the cluster
+// must be rolled out by the user for the rotation to progress.
+type CertificateAuthorityRotation struct {
+	// rotateAfter is a field to indicate a rotation of the certificate authorities should be performed
+	// after the specified time. A new rotation is started only if the last rotation started before rotateAfter.
+	// Example: In the YAML the time can be specified in the RFC3339 format.
+	// To specify the rotateAfter target as March 9, 2023, at 9 am UTC
+	// use "2023-03-09T09:00:00Z".
+	// +required
+	RotateAfter metav1.Time `json:"rotateAfter"`
+}
+
+// CertificateAuthorityRotationPhase is a phase of the rotation of the certificate authorities.
+// +kubebuilder:validation:Enum=DistributingTrustBundle;SwitchingSigningCA;RemovingOldCA;RemovingOldServiceAccountKey;Completed
+type CertificateAuthorityRotationPhase string
+
+const (
+	// CertificateAuthorityRotationDistributingTrustBundlePhase is the phase where the new certificate authorities are added
+	// to the trust bundles while the old ones are still used for signing, and all the Machines are rolled out.
+	CertificateAuthorityRotationDistributingTrustBundlePhase CertificateAuthorityRotationPhase = "DistributingTrustBundle"
+
+	// CertificateAuthorityRotationSwitchingSigningCAPhase is the phase where the new certificate authorities are used
+	// for signing while the old ones are still trusted, and all the Machines are rolled out.
+	CertificateAuthorityRotationSwitchingSigningCAPhase CertificateAuthorityRotationPhase = "SwitchingSigningCA"
+
+	// CertificateAuthorityRotationRemovingOldCAPhase is the phase where the old certificate authorities are removed
+	// from the trust bundles, and all the Machines are rolled out.
+	CertificateAuthorityRotationRemovingOldCAPhase CertificateAuthorityRotationPhase = "RemovingOldCA"
+
+	// CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase is the phase where the old service account
+	// public key is removed, and the control plane Machines are rolled out.
+	CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase CertificateAuthorityRotationPhase = "RemovingOldServiceAccountKey"
+
+	// CertificateAuthorityRotationCompletedPhase is the phase of a rotation of the certificate authorities that completed.
+	CertificateAuthorityRotationCompletedPhase CertificateAuthorityRotationPhase = "Completed"
+)
+
+// EtcdBackupPolicy describes how periodic snapshots of the etcd cluster managed by the KubeadmControlPlane
+// should be taken and stored.
+//
+// Snapshots are taken through the same connection used by the KubeadmControlPlane to manage etcd members,
+// and only while the control plane is stable, i.e. no rollout, scale up, scale down or remediation in progress.
+type EtcdBackupPolicy struct {
+	// intervalSeconds is the minimum amount of time between two consecutive snapshots.
+	// +required
+	// +kubebuilder:validation:Minimum=300
+	IntervalSeconds int32 `json:"intervalSeconds"`
+
+	// maxSnapshots is the number of snapshots to retain; when a new snapshot is stored, the oldest snapshots
+	// exceeding this number are deleted. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxSnapshots *int32 `json:"maxSnapshots,omitempty"`
+
+	// maxTotalSizeBytes is the maximum total size of the compressed snapshots to retain, i.e. of the new snapshot
+	// plus the newest maxSnapshots-1 snapshots already stored. Before taking a new snapshot, the oldest retained
+	// snapshots are deleted until the new snapshot is expected to fit into this size; if a single snapshot exceeds
+	// this size, or if the new snapshot does not fit, the snapshot is not stored and the EtcdBackupSucceeded
+	// condition is set to False.
+	// Defaults to 128MiB.
+	// NOTE: Snapshots are stored in the management cluster, so this size must fit its etcd storage quota.
+	// +optional
+	// +kubebuilder:validation:Minimum=1048576
+	MaxTotalSizeBytes *int64 `json:"maxTotalSizeBytes,omitempty"`
+
+	// deletionPolicy defines what happens to the stored snapshots when the KubeadmControlPlane is deleted.
+	// Defaults to Delete.
+	// +optional
+	DeletionPolicy EtcdBackupDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// sink defines where the snapshots are stored.
+	// +required
+	Sink EtcdBackupSink `json:"sink"`
+}
+
+// EtcdBackupDeletionPolicy defines what happens to the stored etcd snapshots when the KubeadmControlPlane is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type EtcdBackupDeletionPolicy string
+
+const (
+	// DeleteEtcdBackupDeletionPolicy deletes the stored etcd snapshots when the KubeadmControlPlane is deleted.
+	DeleteEtcdBackupDeletionPolicy EtcdBackupDeletionPolicy = "Delete"
+
+	// RetainEtcdBackupDeletionPolicy retains the stored etcd snapshots when the KubeadmControlPlane is deleted,
+	// so they can be restored after the KubeadmControlPlane is re-created with the same name.
+	// Retained snapshots must be deleted manually, e.g. deleting the Secrets with the
+	// controlplane.cluster.x-k8s.io/etcd-snapshot label.
+	RetainEtcdBackupDeletionPolicy EtcdBackupDeletionPolicy = "Retain"
+)
+
+// EtcdBackupSinkType defines where etcd snapshots are stored.
+// +kubebuilder:validation:Enum=Secret;ObjectStore
+type EtcdBackupSinkType string
+
+const (
+	// SecretEtcdBackupSinkType stores etcd snapshots, gzip compressed, in Secrets in the namespace of the
+	// KubeadmControlPlane. Snapshots exceeding the maximum size of a Secret are split across multiple Secrets.
+	// Secrets are labeled with the controlplane.cluster.x-k8s.io/etcd-snapshot label and they are not owned by
+	// the KubeadmControlPlane; they are deleted when exceeding maxSnapshots, and when the KubeadmControlPlane is
+	// deleted unless deletionPolicy is Retain.
+	SecretEtcdBackupSinkType EtcdBackupSinkType = "Secret"
+
+	// ObjectStoreEtcdBackupSinkType stores etcd snapshots, gzip compressed, in an object store implemented by a
+	// provider, which is reached over HTTPS as defined by the etcd snapshot object store contract.
+	ObjectStoreEtcdBackupSinkType EtcdBackupSinkType = "ObjectStore"
+)
+
+// EtcdBackupSink defines where etcd snapshots are stored.
+//
+// WARNING: Snapshots are not encrypted, and they contain all the data of the workload cluster, including all its
+// Secrets; whoever can read the snapshots can read all the Secrets of the workload cluster. When using the Secret
+// sink, snapshots are stored in plain Secrets of the management cluster, so access to Secrets in the namespace of
+// the KubeadmControlPlane must be restricted accordingly, and encryption at rest should be enabled for the
+// management cluster. When using the ObjectStore sink, the object store must be secured accordingly.
+type EtcdBackupSink struct {
+	// type of the sink, one of "Secret" or "ObjectStore".
+	// +required
+	Type EtcdBackupSinkType `json:"type"`
+
+	// objectStore defines the object store where snapshots are stored.
+	// It must be set if and only if type is ObjectStore.
+	// +optional
+	ObjectStore *EtcdBackupObjectStoreSink `json:"objectStore,omitempty"`
+}
+
+// EtcdBackupObjectStoreSink defines an object store implementing the etcd snapshot object store contract.
+// KCP stores each snapshot with a PUT request to <url>/<namespace>/<KubeadmControlPlane name>/<snapshot name>,
+// reads it with a GET request and deletes it with a DELETE request to the same URL, and it lists the snapshots
+// of a KubeadmControlPlane with a GET request to <url>/<namespace>/<KubeadmControlPlane name>/.
+type EtcdBackupObjectStoreSink struct {
+	// url is the base URL of the object store. The URL must use https.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+
+	// credentialsSecretName is the name of the Secret, in the namespace of the KubeadmControlPlane, storing
+	// the bearer token used to authenticate to the object store under the token key and, optionally, the
+	// PEM encoded CA certificates used to verify the object store under the ca.crt key.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// EtcdRestore describes from which snapshot and when the etcd cluster managed by the KubeadmControlPlane
+// should be restored.
+//
+// Restoring etcd is disruptive, and it is performed in phases:
+//   - DeletingMachines: all the control plane Machines are deleted, without draining Nodes and without removing
+//     etcd members.
+//   - Restoring: a single control plane Machine is created, restoring the etcd data directory from the snapshot
+//     before running kubeadm init.
+//   - ScalingUp: the control plane is scaled up to the desired number of replicas.
+//
+// To prevent data loss, a restore is started only if the snapshot exists and the etcd cluster is known to be
+// not healthy, i.e. the EtcdClusterHealthy condition is False, e.g. because etcd lost quorum.
+type EtcdRestore struct {
+	// snapshotName is the name of the snapshot to restore, e.g. the name reported in
+	// status.etcdBackup.lastSuccessfulSnapshot. Unless snapshotURL is set, the snapshot must be stored in the
+	// etcd backup sink defined in spec.etcdBackup.sink, or in Secrets if spec.etcdBackup is not set; the Machine
+	// restoring etcd downloads the snapshot from KCP using a short-lived URL, so the etcd snapshot server of
+	// KCP must be enabled and reachable from the Machine.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SnapshotName string `json:"snapshotName"`
+
+	// snapshotURL defines where the Machine restoring etcd downloads the gzip compressed snapshot from, e.g. a
+	// pre-signed URL of an object store; the snapshot is verified with the given checksum.
+	// When set, the snapshot is not read from the etcd backup sink.
+	// +optional
+	SnapshotURL *bootstrapv1.EtcdSnapshotURL `json:"snapshotURL,omitempty"`
+
+	// restoreAfter is a field to indicate a restore of the etcd cluster should be performed
+	// after the specified time. A new restore is started only if the last restore started before restoreAfter.
+	// Example: In the YAML the time can be specified in the RFC3339 format.
+	// To specify the restoreAfter target as March 9, 2023, at 9 am UTC
+	// use "2023-03-09T09:00:00Z".
+	// +required
+	RestoreAfter metav1.Time `json:"restoreAfter"`
+}
+
+// EtcdDefragmentationPolicy describes when the members of the etcd cluster managed by the KubeadmControlPlane
+// should be defragmented.
+//
+// Members are defragmented one at a time, and only while the control plane is stable, i.e. no rollout, scale up,
+// scale down or remediation in progress. A member is defragmented when the space of its database which is not in use
+// exceeds fragmentationThresholdPercent, or when the member raised a NOSPACE alarm; after a successful
+// defragmentation, the NOSPACE alarm raised by the member is disarmed.
+type EtcdDefragmentationPolicy struct {
+	// fragmentationThresholdPercent is the percentage of the database size of a member which is not in use
+	// above which the member is defragmented.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	FragmentationThresholdPercent int32 `json:"fragmentationThresholdPercent"`
+
+	// minDBSizeBytes is the minimum database size of a member for it to be defragmented because
+	// of fragmentationThresholdPercent; it prevents from defragmenting members with a small database.
+	// Members which raised a NOSPACE alarm are defragmented regardless of their database size.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinDBSizeBytes *int64 `json:"minDBSizeBytes,omitempty"`
+}
+
+// EtcdRestorePhase is a phase of the restore of the etcd cluster from a snapshot.
+// +kubebuilder:validation:Enum=DeletingMachines;Restoring;ScalingUp;Completed
+type EtcdRestorePhase string
+
+const (
+	// EtcdRestoreDeletingMachinesPhase is the phase where all the control plane Machines are deleted.
+	EtcdRestoreDeletingMachinesPhase EtcdRestorePhase = "DeletingMachines"
+
+	// EtcdRestoreRestoringPhase is the phase where a single control plane Machine is created, restoring
+	// the etcd data directory from the snapshot.
+	EtcdRestoreRestoringPhase EtcdRestorePhase = "Restoring"
+
+	// EtcdRestoreScalingUpPhase is the phase where the control plane is scaled up to the desired number of replicas.
+	EtcdRestoreScalingUpPhase EtcdRestorePhase = "ScalingUp"
+
+	// EtcdRestoreCompletedPhase is the phase of a restore of the etcd cluster that completed.
+	EtcdRestoreCompletedPhase EtcdRestorePhase = "Completed"
+)
+
 // RolloutStrategy describes how to replace existing machines
 // with new ones.
 type RolloutStrategy struct {
-	// type of rollout. Currently the only supported strategy is
-	// "RollingUpdate".
+	// type of rollout. Allowed values are "RollingUpdate" and "ScaleInFirst".
 	// Default is RollingUpdate.
 	// +optional
 	Type RolloutStrategyType `json:"type,omitempty"`
@@ -626,8 +973,8 @@ type MachineNamingStrategy struct {
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 type KubeadmControlPlaneStatus struct {
 	// conditions represents the observations of a KubeadmControlPlane's current state.
-	// Known condition types are Available, CertificatesAvailable, EtcdClusterAvailable, MachinesReady, MachinesUpToDate,
-	// ScalingUp, ScalingDown, Remediating, Deleting, Paused.
+	// Known condition types are Available, CertificatesAvailable, CertificateAuthorityRotating, EtcdClusterAvailable, EtcdBackupSucceeded, EtcdRestoring, MachinesReady,
+	// MachinesUpToDate, ScalingUp, ScalingDown, Remediating, Deleting, Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -681,6 +1028,26 @@ type KubeadmControlPlaneStatus struct {
 	// +optional
 	LastRemediation *LastRemediationStatus `json:"lastRemediation,omitempty"`
 
+	// certificateAuthorityRotation stores info about the last rotation of the certificate authorities.
+	// +optional
+	CertificateAuthorityRotation *CertificateAuthorityRotationStatus `json:"certificateAuthorityRotation,omitempty"`
+
+	// etcdBackup stores info about the etcd snapshots taken by the KubeadmControlPlane.
+	// +optional
+	EtcdBackup *EtcdBackupStatus `json:"etcdBackup,omitempty"`
+
+	// etcdRestore stores info about the last restore of the etcd cluster from a snapshot.
+	// +optional
+	EtcdRestore *EtcdRestoreStatus `json:"etcdRestore,omitempty"`
+
+	// etcdMembers stores info about the members of the etcd cluster managed by the KubeadmControlPlane.
+	// NOTE: This field is reported only when spec.etcdDefragmentation is set.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=100
+	EtcdMembers []EtcdMemberStatus `json:"etcdMembers,omitempty"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *KubeadmControlPlaneDeprecatedStatus `json:"deprecated,omitempty"`
@@ -780,6 +1147,120 @@ type LastRemediationStatus struct {
 	RetryCount int32 `json:"retryCount"`
 }
 
+// CertificateAuthorityRotationStatus stores info about the last rotation of the certificate authorities.
+type CertificateAuthorityRotationStatus struct {
+	// phase is the current phase of the rotation.
+	// +required
+	Phase CertificateAuthorityRotationPhase `json:"phase"`
+
+	// startTime is when the rotation started. It is represented in RFC3339 form and is in UTC.
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// phaseStartTime is when the current phase started; all the Machines created before phaseStartTime
+	// are rolled out before moving to the next phase. It is represented in RFC3339 form and is in UTC.
+	// +required
+	PhaseStartTime metav1.Time `json:"phaseStartTime"`
+
+	// completionTime is when the rotation completed. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// EtcdBackupStatus stores info about the etcd snapshots taken by the KubeadmControlPlane.
+type EtcdBackupStatus struct {
+	// lastSuccessfulSnapshot stores info about the last snapshot successfully stored in the sink.
+	// +optional
+	LastSuccessfulSnapshot *EtcdSnapshot `json:"lastSuccessfulSnapshot,omitempty"`
+
+	// snapshotInProgress stores info about the snapshot being taken, if any.
+	// +optional
+	SnapshotInProgress *EtcdSnapshotProgress `json:"snapshotInProgress,omitempty"`
+}
+
+// EtcdRestoreStatus stores info about the last restore of the etcd cluster from a snapshot.
+type EtcdRestoreStatus struct {
+	// snapshotName is the name of the snapshot being restored.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SnapshotName string `json:"snapshotName"`
+
+	// phase is the current phase of the restore.
+	// +required
+	Phase EtcdRestorePhase `json:"phase"`
+
+	// startTime is when the restore started. It is represented in RFC3339 form and is in UTC.
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// completionTime is when the restore completed. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// EtcdMemberStatus stores info about a member of the etcd cluster.
+type EtcdMemberStatus struct {
+	// name of the member, which is the name of the Node hosting the member.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// dbSizeBytes is the size of the database of the member, as reported by etcd.
+	// +required
+	DBSizeBytes int64 `json:"dbSizeBytes"`
+
+	// dbSizeInUseBytes is the size of the database of the member which is in use, as reported by etcd;
+	// the difference with dbSizeBytes is the space which can be reclaimed by defragmenting the member.
+	// +required
+	DBSizeInUseBytes int64 `json:"dbSizeInUseBytes"`
+
+	// lastDefragmentationTime is when the member was last defragmented by the KubeadmControlPlane.
+	// It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastDefragmentationTime *metav1.Time `json:"lastDefragmentationTime,omitempty"`
+}
+
+// EtcdSnapshot stores info about an etcd snapshot.
+type EtcdSnapshot struct {
+	// name of the snapshot.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// timestamp is when the snapshot was taken. It is represented in RFC3339 form and is in UTC.
+	// +required
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// revision is the etcd revision at the time the snapshot was taken.
+	// +required
+	Revision int64 `json:"revision"`
+
+	// sizeBytes is the size of the compressed snapshot stored in the sink, which is the size counted
+	// against spec.etcdBackup.maxTotalSizeBytes.
+	// +required
+	SizeBytes int64 `json:"sizeBytes"`
+}
+
+// EtcdSnapshotProgress stores info about an etcd snapshot being taken.
+type EtcdSnapshotProgress struct {
+	// name of the snapshot.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// startTime is when the snapshot started. It is represented in RFC3339 form and is in UTC.
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// savedBytes is the size of the part of the snapshot already stored in the sink, before compression.
+	// +optional
+	SavedBytes int64 `json:"savedBytes,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=kubeadmcontrolplanes,shortName=kcp,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
@@ -232,7 +232,20 @@ func (dst *MachineDeployment) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *MachineHealthCheck) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*clusterv1.MachineHealthCheck)
 
-	return Convert_v1beta1_MachineHealthCheck_To_v1beta2_MachineHealthCheck(src, dst, nil)
+	if err := Convert_v1beta1_MachineHealthCheck_To_v1beta2_MachineHealthCheck(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &clusterv1.MachineHealthCheck{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.UnhealthyMachineConditions = restored.Spec.UnhealthyMachineConditions
+	dst.Spec.RemediationRateLimit = restored.Spec.RemediationRateLimit
+	dst.Status.Remediations = restored.Status.Remediations
+
+	return nil
 }
 
 func (dst *MachineHealthCheck) ConvertFrom(srcRaw conversion.Hub) error {
@@ -244,7 +257,8 @@ func (dst *MachineHealthCheck) ConvertFrom(srcRaw conversion.Hub) error {
 	if dst.Spec.RemediationTemplate != nil {
 		dst.Spec.RemediationTemplate.Namespace = src.Namespace
 	}
-	return nil
+
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *MachinePool) ConvertTo(dstRaw conversion.Hub) error {
@@ -281,12 +295,30 @@ func (dst *MachinePool) ConvertFrom(srcRaw conversion.Hub) error {
 
 func (src *MachineDrainRule) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*clusterv1.MachineDrainRule)
-	return Convert_v1beta1_MachineDrainRule_To_v1beta2_MachineDrainRule(src, dst, nil)
+
+	if err := Convert_v1beta1_MachineDrainRule_To_v1beta2_MachineDrainRule(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &clusterv1.MachineDrainRule{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Drain.TimeoutSeconds = restored.Spec.Drain.TimeoutSeconds
+	dst.Spec.Drain.OnTimeout = restored.Spec.Drain.OnTimeout
+
+	return nil
 }
 
 func (dst *MachineDrainRule) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*clusterv1.MachineDrainRule)
-	return Convert_v1beta2_MachineDrainRule_To_v1beta1_MachineDrainRule(src, dst, nil)
+
+	if err := Convert_v1beta2_MachineDrainRule_To_v1beta1_MachineDrainRule(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, dst)
 }
 
 func Convert_v1beta2_ClusterClass_To_v1beta1_ClusterClass(in *clusterv1.ClusterClass, out *ClusterClass, s apimachineryconversion.Scope) error {
@@ -1313,6 +1345,10 @@ func Convert_v1beta2_ClusterClassTemplate_To_v1beta1_LocalObjectTemplate(in *clu
 	return nil
 }
 
+func Convert_v1beta2_MachineDrainRuleDrainConfig_To_v1beta1_MachineDrainRuleDrainConfig(in *clusterv1.MachineDrainRuleDrainConfig, out *MachineDrainRuleDrainConfig, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_MachineDrainRuleDrainConfig_To_v1beta1_MachineDrainRuleDrainConfig(in, out, s)
+}
+
 func convertMachineSpecToContractVersionedObjectReference(src *MachineSpec, dst *clusterv1.MachineSpec) error {
 	infraRef, err := convertToContractVersionedObjectReference(&src.InfrastructureRef)
 	if err != nil {
@@ -134,6 +134,24 @@ const (
 	// RemediateMachineAnnotation request the MachineHealthCheck reconciler to mark a Machine as unhealthy. CAPI builtin remediation will prioritize Machines with the annotation to be remediated.
 	RemediateMachineAnnotation = "cluster.x-k8s.io/remediate-machine"
 
+	// UpdateInProgressAnnotation is set on a Machine by the controller owning the Machine (e.g. MachineSet or KubeadmControlPlane)
+	// when an in-place update of the Machine is started; the annotation is removed by the Machine controller once all
+	// Runtime Extensions implementing the UpdateMachine hook report the update as completed.
+	// Note: this annotation is used only if the InPlaceUpdates feature gate is enabled.
+	UpdateInProgressAnnotation = "in-place-updates.cluster.x-k8s.io/update-in-progress"
+
+	// UpdateNotPossibleAnnotation is set on a Machine by the MachineSet controller when the Machine cannot be updated in-place
+	// to the MachineSet template; Machines with this annotation are replaced by the MachineDeployment rollout, respecting
+	// maxSurge and maxUnavailable, and they are deleted first when the MachineSet is scaled down.
+	// Note: this annotation is used only if the InPlaceUpdates feature gate is enabled.
+	UpdateNotPossibleAnnotation = "in-place-updates.cluster.x-k8s.io/update-not-possible"
+
+	// InPlaceUpdateRevisionsAnnotation is set on a MachineSet by the MachineDeployment controller when the MachineSet is updated
+	// in-place; it records, as a JSON list, the revisions served by the MachineSet before the in-place updates together with
+	// the version, bootstrap and infrastructure of their Machine template, so that they are kept in the rollout history.
+	// Note: this annotation is used only if the InPlaceUpdates feature gate is enabled.
+	InPlaceUpdateRevisionsAnnotation = "in-place-updates.cluster.x-k8s.io/revisions"
+
 	// MachineSetSkipPreflightChecksAnnotation is the annotation used to provide a comma-separated list of
 	// preflight checks that should be skipped during the MachineSet reconciliation.
 	// Supported items are:
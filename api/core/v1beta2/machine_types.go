@@ -153,6 +153,23 @@ const (
 	MachineNotUpToDateReason = "NotUpToDate"
 )
 
+// Machine's Updating condition and corresponding reasons.
+// Note: Updating condition is set by the Machine controller only if the InPlaceUpdates feature gate is enabled.
+const (
+	// MachineUpdatingCondition is true while the Machine is being updated in-place.
+	MachineUpdatingCondition = "Updating"
+
+	// MachineNotUpdatingReason surfaces when the Machine is not being updated in-place.
+	MachineNotUpdatingReason = "NotUpdating"
+
+	// MachineInPlaceUpdatingReason surfaces when the Machine is being updated in-place.
+	MachineInPlaceUpdatingReason = "InPlaceUpdating"
+
+	// MachineInPlaceUpdateFailedReason surfaces when the Machine is being updated in-place,
+	// but the last call to the UpdateMachine hook failed.
+	MachineInPlaceUpdateFailedReason = "InPlaceUpdateFailed"
+)
+
 // Machine's BootstrapConfigReady condition and corresponding reasons.
 // Note: when possible, BootstrapConfigReady condition will use reasons surfaced from the underlying bootstrap config object.
 const (
@@ -276,6 +293,10 @@ const (
 	// defined by a MachineHealthCheck object.
 	MachineHealthCheckUnhealthyNodeReason = "UnhealthyNode"
 
+	// MachineHealthCheckUnhealthyMachineReason surfaces when the machine does not pass the health checks
+	// defined by a MachineHealthCheck object.
+	MachineHealthCheckUnhealthyMachineReason = "UnhealthyMachine"
+
 	// MachineHealthCheckNodeStartupTimeoutReason surfaces when the node hosted on the machine does not appear within
 	// the timeout defined by a MachineHealthCheck object.
 	MachineHealthCheckNodeStartupTimeoutReason = "NodeStartupTimeout"
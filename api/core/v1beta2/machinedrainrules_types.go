@@ -43,6 +43,25 @@ const (
 	MachineDrainRuleDrainBehaviorWaitCompleted MachineDrainRuleDrainBehavior = "WaitCompleted"
 )
 
+// MachineDrainRuleOnTimeoutAction defines the action taken when the drain timeout of a MachineDrainRule is reached.
+// Can be either "Block", "Skip", or "ForceDelete".
+// +kubebuilder:validation:Enum=Block;Skip;ForceDelete
+type MachineDrainRuleOnTimeoutAction string
+
+const (
+	// MachineDrainRuleOnTimeoutActionBlock means the drain keeps waiting for the Pods to be removed
+	// after the timeout is reached; the timeout is only surfaced in the Machine's conditions.
+	MachineDrainRuleOnTimeoutActionBlock MachineDrainRuleOnTimeoutAction = "Block"
+
+	// MachineDrainRuleOnTimeoutActionSkip means the Pods are skipped after the timeout is reached,
+	// i.e. the drain doesn't wait anymore for the Pods to be removed.
+	MachineDrainRuleOnTimeoutActionSkip MachineDrainRuleOnTimeoutAction = "Skip"
+
+	// MachineDrainRuleOnTimeoutActionForceDelete means the Pods are force deleted after the timeout is reached,
+	// i.e. they are deleted without respecting PodDisruptionBudgets and with a grace period of 0.
+	MachineDrainRuleOnTimeoutActionForceDelete MachineDrainRuleOnTimeoutAction = "ForceDelete"
+)
+
 // MachineDrainRuleSpec defines the spec of a MachineDrainRule.
 type MachineDrainRuleSpec struct {
 	// drain configures if and how Pods are drained.
@@ -116,6 +135,8 @@ type MachineDrainRuleSpec struct {
 }
 
 // MachineDrainRuleDrainConfig configures if and how Pods are drained.
+// +kubebuilder:validation:XValidation:rule="!has(self.timeoutSeconds) || self.behavior != 'Skip'",message="timeoutSeconds must not be set if behavior is Skip"
+// +kubebuilder:validation:XValidation:rule="!has(self.onTimeout) || has(self.timeoutSeconds)",message="onTimeout can only be set if timeoutSeconds is set"
 type MachineDrainRuleDrainConfig struct {
 	// behavior defines the drain behavior.
 	// Can be either "Drain", "Skip", or "WaitCompleted".
@@ -138,6 +159,27 @@ type MachineDrainRuleDrainConfig struct {
 	// Valid values for order are from -2147483648 to 2147483647 (inclusive).
 	// +optional
 	Order *int32 `json:"order,omitempty"`
+
+	// timeoutSeconds is the maximum time to wait for the Pods to which this MachineDrainRule applies
+	// to be removed from the Node, measured from the start of the drain of the Node.
+	// After the timeout is reached, the action defined in onTimeout is taken.
+	// timeoutSeconds can only be set if behavior is set to "Drain" or "WaitCompleted".
+	// NOTE: The Machine-wide nodeDrainTimeoutSeconds still applies, so timeoutSeconds is only effective if it
+	// is lower than nodeDrainTimeoutSeconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// onTimeout defines the action taken when timeoutSeconds is reached.
+	// Can be either "Block", "Skip", or "ForceDelete".
+	// "Block" means the drain keeps waiting for the Pods to be removed; the timeout is only reported in the
+	// Machine's conditions.
+	// "Skip" means the drain doesn't wait anymore for the Pods to be removed.
+	// "ForceDelete" means the Pods are deleted with a grace period of 0, without respecting PodDisruptionBudgets.
+	// Pods are force deleted only after all the Pods with a lower order have been removed from the Node.
+	// onTimeout can only be set if timeoutSeconds is set. If onTimeout is not set, "Block" will be used.
+	// +optional
+	OnTimeout MachineDrainRuleOnTimeoutAction `json:"onTimeout,omitempty"`
 }
 
 // MachineDrainRuleMachineSelector defines to which Machines this MachineDrainRule should be applied.
@@ -36,6 +36,10 @@ const (
 	// MachineHealthCheckRemediationAllowedReason is the reason used when the number of unhealthy machine
 	// is within the limits defined by the MachineHealthCheck, and thus remediation is allowed.
 	MachineHealthCheckRemediationAllowedReason = "RemediationAllowed"
+
+	// MachineHealthCheckRemediationRateLimitedReason is the reason used when the remediation of at least one unhealthy
+	// machine is deferred because of the remediationRateLimit defined by the MachineHealthCheck.
+	MachineHealthCheckRemediationRateLimitedReason = "RateLimited"
 )
 
 var (
@@ -68,6 +72,16 @@ type MachineHealthCheckSpec struct {
 	// +kubebuilder:validation:MaxItems=100
 	UnhealthyNodeConditions []UnhealthyNodeCondition `json:"unhealthyNodeConditions,omitempty"`
 
+	// unhealthyMachineConditions contains a list of conditions that determine
+	// whether a machine is considered unhealthy. The conditions are combined in a
+	// logical OR, i.e. if any of the conditions is met, the machine is unhealthy.
+	// Contrary to unhealthyNodeConditions, unhealthyMachineConditions are also evaluated for machines
+	// without a node, e.g. to remediate machines whose infrastructure is not ready.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=100
+	UnhealthyMachineConditions []UnhealthyMachineCondition `json:"unhealthyMachineConditions,omitempty"`
+
 	// maxUnhealthy specifies the maximum number of unhealthy machines allowed.
 	// Any further remediation is only allowed if at most "maxUnhealthy" machines selected by
 	// "selector" are not healthy.
@@ -108,6 +122,13 @@ type MachineHealthCheckSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	NodeStartupTimeoutSeconds *int32 `json:"nodeStartupTimeoutSeconds,omitempty"`
 
+	// remediationRateLimit limits how fast unhealthy machines are remediated over time, e.g. to prevent
+	// continuous replacement of machines when a node problem is flapping.
+	// Contrary to maxUnhealthy and unhealthyRange, which block remediation depending on the number of unhealthy
+	// machines, remediationRateLimit defers remediation of unhealthy machines until the rate limit allows it.
+	// +optional
+	RemediationRateLimit *MachineHealthCheckRemediationRateLimit `json:"remediationRateLimit,omitempty"`
+
 	// remediationTemplate is a reference to a remediation template
 	// provided by an infrastructure provider.
 	//
@@ -118,6 +139,43 @@ type MachineHealthCheckSpec struct {
 	RemediationTemplate *MachineHealthCheckRemediationTemplateReference `json:"remediationTemplate,omitempty"`
 }
 
+// MachineHealthCheckRemediationRateLimit limits how fast unhealthy machines are remediated over time.
+type MachineHealthCheckRemediationRateLimit struct {
+	// maxRemediations is the maximum number of machines which can be remediated within windowSeconds.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	MaxRemediations int32 `json:"maxRemediations"`
+
+	// windowSeconds is the duration of the sliding window in which at most maxRemediations machines can be remediated.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	WindowSeconds int32 `json:"windowSeconds"`
+
+	// backoff configures an exponential backoff between remediations of machines with the same owner, e.g. the
+	// same MachineDeployment or KubeadmControlPlane.
+	// +optional
+	Backoff *MachineHealthCheckRemediationBackoff `json:"backoff,omitempty"`
+}
+
+// MachineHealthCheckRemediationBackoff configures an exponential backoff between remediations of machines
+// with the same owner.
+// +kubebuilder:validation:XValidation:rule="!has(self.maxDelaySeconds) || self.maxDelaySeconds >= self.initialDelaySeconds",message="maxDelaySeconds must be greater than or equal to initialDelaySeconds"
+type MachineHealthCheckRemediationBackoff struct {
+	// initialDelaySeconds is the minimum delay between the remediation of a machine and the next remediation
+	// of a machine with the same owner. The delay is doubled for every further machine with the same owner
+	// remediated within windowSeconds.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	InitialDelaySeconds int32 `json:"initialDelaySeconds"`
+
+	// maxDelaySeconds is the maximum delay between remediations of machines with the same owner.
+	// Defaults to windowSeconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxDelaySeconds *int32 `json:"maxDelaySeconds,omitempty"`
+}
+
 // MachineHealthCheckRemediationTemplateReference is a reference to a remediation template.
 type MachineHealthCheckRemediationTemplateReference struct {
 	// kind of the remediation template.
@@ -195,6 +253,38 @@ type UnhealthyNodeCondition struct {
 
 // ANCHOR_END: UnhealthyNodeCondition
 
+// ANCHOR: UnhealthyMachineCondition
+
+// UnhealthyMachineCondition represents a Machine condition type and value with a timeout
+// specified as a duration. When the named condition has been in the given
+// status for at least the timeout value, a machine is considered unhealthy.
+type UnhealthyMachineCondition struct {
+	// type of Machine condition.
+	// The Ready, Available, HealthCheckSucceeded, OwnerRemediated and ExternallyRemediated conditions
+	// are not allowed, because they are affected by the MachineHealthCheck itself.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=316
+	// +kubebuilder:validation:XValidation:rule="!(self in ['Ready','Available','HealthCheckSucceeded','OwnerRemediated','ExternallyRemediated'])",message="type must not be one of: Ready, Available, HealthCheckSucceeded, OwnerRemediated, ExternallyRemediated"
+	// +required
+	Type string `json:"type"`
+
+	// status of the condition, one of True, False, Unknown.
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	// +required
+	Status metav1.ConditionStatus `json:"status"`
+
+	// timeoutSeconds is the duration that a machine must be in a given status for,
+	// after which the machine is considered unhealthy.
+	// For example, with a value of "3600", the machine must match the status
+	// for at least 1 hour before being considered unhealthy.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+}
+
+// ANCHOR_END: UnhealthyMachineCondition
+
 // ANCHOR: MachineHealthCheckStatus
 
 // MachineHealthCheckStatus defines the observed state of MachineHealthCheck.
@@ -234,11 +324,38 @@ type MachineHealthCheckStatus struct {
 	// +kubebuilder:validation:items:MaxLength=253
 	Targets []string `json:"targets,omitempty"`
 
+	// remediations is the list of remediations triggered by the machine health check within
+	// remediationRateLimit.windowSeconds. It is only set if remediationRateLimit is set.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	Remediations []MachineHealthCheckRemediation `json:"remediations,omitempty"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *MachineHealthCheckDeprecatedStatus `json:"deprecated,omitempty"`
 }
 
+// MachineHealthCheckRemediation is a remediation triggered by a MachineHealthCheck.
+type MachineHealthCheckRemediation struct {
+	// machineName is the name of the remediated machine.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	MachineName string `json:"machineName"`
+
+	// owner is the owner of the remediated machine, in the format <Kind>/<name>, e.g. MachineDeployment/md-0.
+	// Machines which are part of a MachineDeployment are grouped by MachineDeployment.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	Owner string `json:"owner,omitempty"`
+
+	// time is the time at which the remediation was triggered.
+	// +required
+	Time metav1.Time `json:"time"`
+}
+
 // MachineHealthCheckDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
 // See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
 type MachineHealthCheckDeprecatedStatus struct {
@@ -161,6 +161,9 @@ const (
 
 	// UnhealthyNodeConditionV1Beta1Reason is the reason used when a machine's node has one of the MachineHealthCheck's unhealthy conditions.
 	UnhealthyNodeConditionV1Beta1Reason = "UnhealthyNode"
+
+	// UnhealthyMachineConditionV1Beta1Reason is the reason used when a machine has one of the MachineHealthCheck's unhealthy conditions.
+	UnhealthyMachineConditionV1Beta1Reason = "UnhealthyMachine"
 )
 
 const (
@@ -228,6 +231,10 @@ const (
 	// TooManyUnhealthyV1Beta1Reason is the reason used when too many Machines are unhealthy and the MachineHealthCheck is blocked
 	// from making any further remediations.
 	TooManyUnhealthyV1Beta1Reason = "TooManyUnhealthy"
+
+	// RemediationRateLimitedV1Beta1Reason is the reason used when the remediation of at least one unhealthy Machine is deferred
+	// because of the MachineHealthCheck's remediationRateLimit.
+	RemediationRateLimitedV1Beta1Reason = "RemediationRateLimited"
 )
 
 // Conditions and condition Reasons for  MachineDeployments.
@@ -94,6 +94,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckClass":                        schema_cluster_api_api_core_v1beta2_MachineHealthCheckClass(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckDeprecatedStatus":             schema_cluster_api_api_core_v1beta2_MachineHealthCheckDeprecatedStatus(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckList":                         schema_cluster_api_api_core_v1beta2_MachineHealthCheckList(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediation":                  schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediation(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationBackoff":           schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediationBackoff(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationRateLimit":         schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediationRateLimit(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationTemplateReference": schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediationTemplateReference(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckSpec":                         schema_cluster_api_api_core_v1beta2_MachineHealthCheckSpec(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckStatus":                       schema_cluster_api_api_core_v1beta2_MachineHealthCheckStatus(ref),
@@ -136,6 +139,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.PatchSelectorMatchMachinePoolClass":             schema_cluster_api_api_core_v1beta2_PatchSelectorMatchMachinePoolClass(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.RemediationStrategy":                            schema_cluster_api_api_core_v1beta2_RemediationStrategy(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.Topology":                                       schema_cluster_api_api_core_v1beta2_Topology(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.UnhealthyMachineCondition":                      schema_cluster_api_api_core_v1beta2_UnhealthyMachineCondition(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.UnhealthyNodeCondition":                         schema_cluster_api_api_core_v1beta2_UnhealthyNodeCondition(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.ValidationRule":                                 schema_cluster_api_api_core_v1beta2_ValidationRule(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.VariableSchema":                                 schema_cluster_api_api_core_v1beta2_VariableSchema(ref),
@@ -3062,6 +3066,20 @@ func schema_cluster_api_api_core_v1beta2_MachineDrainRuleDrainConfig(ref common.
 							Format:      "int32",
 						},
 					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "timeoutSeconds is the maximum time to wait for the Pods to which this MachineDrainRule applies to be removed from the Node, measured from the start of the drain of the Node. After the timeout is reached, the action defined in onTimeout is taken. timeoutSeconds can only be set if behavior is set to \"Drain\" or \"WaitCompleted\". NOTE: The Machine-wide nodeDrainTimeoutSeconds still applies, so timeoutSeconds is only effective if it is lower than nodeDrainTimeoutSeconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"onTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "onTimeout defines the action taken when timeoutSeconds is reached. Can be either \"Block\", \"Skip\", or \"ForceDelete\". \"Block\" means the drain keeps waiting for the Pods to be removed; the timeout is only reported in the Machine's conditions. \"Skip\" means the drain doesn't wait anymore for the Pods to be removed. \"ForceDelete\" means the Pods are deleted with a grace period of 0, without respecting PodDisruptionBudgets. Pods are force deleted only after all the Pods with a lower order have been removed from the Node. onTimeout can only be set if timeoutSeconds is set. If onTimeout is not set, \"Block\" will be used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"behavior"},
 			},
@@ -3412,6 +3430,110 @@ func schema_cluster_api_api_core_v1beta2_MachineHealthCheckList(ref common.Refer
 	}
 }
 
+func schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineHealthCheckRemediation is a remediation triggered by a MachineHealthCheck.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"machineName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "machineName is the name of the remediated machine.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"owner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "owner is the owner of the remediated machine, in the format <Kind>/<name>, e.g. MachineDeployment/md-0. Machines which are part of a MachineDeployment are grouped by MachineDeployment.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "time is the time at which the remediation was triggered.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"machineName", "time"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediationBackoff(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineHealthCheckRemediationBackoff configures an exponential backoff between remediations of machines with the same owner.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"initialDelaySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "initialDelaySeconds is the minimum delay between the remediation of a machine and the next remediation of a machine with the same owner. The delay is doubled for every further machine with the same owner remediated within windowSeconds.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxDelaySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxDelaySeconds is the maximum delay between remediations of machines with the same owner. Defaults to windowSeconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"initialDelaySeconds"},
+			},
+		},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediationRateLimit(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineHealthCheckRemediationRateLimit limits how fast unhealthy machines are remediated over time.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxRemediations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxRemediations is the maximum number of machines which can be remediated within windowSeconds.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"windowSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "windowSeconds is the duration of the sliding window in which at most maxRemediations machines can be remediated.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"backoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "backoff configures an exponential backoff between remediations of machines with the same owner, e.g. the same MachineDeployment or KubeadmControlPlane.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationBackoff"),
+						},
+					},
+				},
+				Required: []string{"maxRemediations", "windowSeconds"},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationBackoff"},
+	}
+}
+
 func schema_cluster_api_api_core_v1beta2_MachineHealthCheckRemediationTemplateReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3486,6 +3608,20 @@ func schema_cluster_api_api_core_v1beta2_MachineHealthCheckSpec(ref common.Refer
 							},
 						},
 					},
+					"unhealthyMachineConditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "unhealthyMachineConditions contains a list of conditions that determine whether a machine is considered unhealthy. The conditions are combined in a logical OR, i.e. if any of the conditions is met, the machine is unhealthy. Contrary to unhealthyNodeConditions, unhealthyMachineConditions are also evaluated for machines without a node, e.g. to remediate machines whose infrastructure is not ready.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/core/v1beta2.UnhealthyMachineCondition"),
+									},
+								},
+							},
+						},
+					},
 					"maxUnhealthy": {
 						SchemaProps: spec.SchemaProps{
 							Description: "maxUnhealthy specifies the maximum number of unhealthy machines allowed. Any further remediation is only allowed if at most \"maxUnhealthy\" machines selected by \"selector\" are not healthy.\n\nDeprecated: This field is deprecated and is going to be removed in the next apiVersion. Please see https://github.com/kubernetes-sigs/cluster-api/issues/10722 for more details.",
@@ -3506,6 +3642,12 @@ func schema_cluster_api_api_core_v1beta2_MachineHealthCheckSpec(ref common.Refer
 							Format:      "int32",
 						},
 					},
+					"remediationRateLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "remediationRateLimit limits how fast unhealthy machines are remediated over time, e.g. to prevent continuous replacement of machines when a node problem is flapping. Contrary to maxUnhealthy and unhealthyRange, which block remediation depending on the number of unhealthy machines, remediationRateLimit defers remediation of unhealthy machines until the rate limit allows it.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationRateLimit"),
+						},
+					},
 					"remediationTemplate": {
 						SchemaProps: spec.SchemaProps{
 							Description: "remediationTemplate is a reference to a remediation template provided by an infrastructure provider.\n\nThis field is completely optional, when filled, the MachineHealthCheck controller creates a new object from the template referenced and hands off remediation of the machine to a controller that lives outside of Cluster API.",
@@ -3517,7 +3659,7 @@ func schema_cluster_api_api_core_v1beta2_MachineHealthCheckSpec(ref common.Refer
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationTemplateReference", "sigs.k8s.io/cluster-api/api/core/v1beta2.UnhealthyNodeCondition"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationRateLimit", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediationTemplateReference", "sigs.k8s.io/cluster-api/api/core/v1beta2.UnhealthyMachineCondition", "sigs.k8s.io/cluster-api/api/core/v1beta2.UnhealthyNodeCondition"},
 	}
 }
 
@@ -3596,6 +3738,25 @@ func schema_cluster_api_api_core_v1beta2_MachineHealthCheckStatus(ref common.Ref
 							},
 						},
 					},
+					"remediations": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "remediations is the list of remediations triggered by the machine health check within remediationRateLimit.windowSeconds. It is only set if remediationRateLimit is set.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediation"),
+									},
+								},
+							},
+						},
+					},
 					"deprecated": {
 						SchemaProps: spec.SchemaProps{
 							Description: "deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.",
@@ -3606,7 +3767,7 @@ func schema_cluster_api_api_core_v1beta2_MachineHealthCheckStatus(ref common.Ref
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckDeprecatedStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckDeprecatedStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineHealthCheckRemediation"},
 	}
 }
 
@@ -5497,6 +5658,44 @@ func schema_cluster_api_api_core_v1beta2_Topology(ref common.ReferenceCallback)
 	}
 }
 
+func schema_cluster_api_api_core_v1beta2_UnhealthyMachineCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UnhealthyMachineCondition represents a Machine condition type and value with a timeout specified as a duration. When the named condition has been in the given status for at least the timeout value, a machine is considered unhealthy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "type of Machine condition. The Ready, Available, HealthCheckSucceeded, OwnerRemediated and ExternallyRemediated conditions are not allowed, because they are affected by the MachineHealthCheck itself.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "status of the condition, one of True, False, Unknown.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "timeoutSeconds is the duration that a machine must be in a given status for, after which the machine is considered unhealthy. For example, with a value of \"3600\", the machine must match the status for at least 1 hour before being considered unhealthy.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"type", "status", "timeoutSeconds"},
+			},
+		},
+	}
+}
+
 func schema_cluster_api_api_core_v1beta2_UnhealthyNodeCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+// MachineUpdateObjects groups the objects that make up a Machine and that can be updated in-place.
+type MachineUpdateObjects struct {
+	// machine is the Machine object.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+
+	// infrastructureMachine is the InfrastructureMachine object of the Machine.
+	// +required
+	InfrastructureMachine runtime.RawExtension `json:"infrastructureMachine"`
+
+	// bootstrapConfig is the BootstrapConfig object of the Machine.
+	// Note: this field is empty for Machines without a BootstrapConfig.
+	// +optional
+	BootstrapConfig runtime.RawExtension `json:"bootstrapConfig,omitempty"`
+}
+
+// CanUpdateMachineRequest is the request of the CanUpdateMachine hook.
+// +kubebuilder:object:root=true
+type CanUpdateMachineRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// current contains the objects of the Machine as they are today.
+	// +required
+	Current MachineUpdateObjects `json:"current"`
+
+	// desired contains the objects of the Machine as they should be after the update.
+	// +required
+	Desired MachineUpdateObjects `json:"desired"`
+}
+
+var _ ResponseObject = &CanUpdateMachineResponse{}
+
+// CanUpdateMachineResponse is the response of the CanUpdateMachine hook.
+// The patches in the response describe the part of the delta between current and desired
+// objects that the Runtime Extension is able to apply in-place; an empty patch means the Runtime Extension
+// cannot apply any change to the corresponding object.
+// +kubebuilder:object:root=true
+type CanUpdateMachineResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+
+	// machinePatch is the patch the Runtime Extension is able to apply in-place to the Machine.
+	// +optional
+	MachinePatch Patch `json:"machinePatch,omitempty"`
+
+	// infrastructureMachinePatch is the patch the Runtime Extension is able to apply in-place to the InfrastructureMachine.
+	// +optional
+	InfrastructureMachinePatch Patch `json:"infrastructureMachinePatch,omitempty"`
+
+	// bootstrapConfigPatch is the patch the Runtime Extension is able to apply in-place to the BootstrapConfig.
+	// +optional
+	BootstrapConfigPatch Patch `json:"bootstrapConfigPatch,omitempty"`
+}
+
+// Patch is a single patch (JSONPatch or JSONMergePatch) which can include multiple operations.
+type Patch struct {
+	// patchType defines the type of the patch.
+	// One of: "JSONPatch" or "JSONMergePatch".
+	// +optional
+	PatchType PatchType `json:"patchType,omitempty"`
+
+	// patch contains the patch which should be applied to the object.
+	// It must be of the corresponding PatchType.
+	// +optional
+	Patch []byte `json:"patch,omitempty"`
+}
+
+// IsDefined returns true if the Patch contains a patch.
+func (p Patch) IsDefined() bool {
+	return p.PatchType != "" && len(p.Patch) > 0
+}
+
+// CanUpdateMachine is the hook that will be called to determine if the changes to a Machine can be applied in-place.
+func CanUpdateMachine(*CanUpdateMachineRequest, *CanUpdateMachineResponse) {}
+
+// UpdateMachineRequest is the request of the UpdateMachine hook.
+// +kubebuilder:object:root=true
+type UpdateMachineRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// desired contains the objects of the Machine as they should be after the update.
+	// +required
+	Desired MachineUpdateObjects `json:"desired"`
+}
+
+var _ RetryResponseObject = &UpdateMachineResponse{}
+
+// UpdateMachineResponse is the response of the UpdateMachine hook.
+// +kubebuilder:object:root=true
+type UpdateMachineResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// UpdateMachine is the hook that will be called to perform an in-place update of a Machine.
+func UpdateMachine(*UpdateMachineRequest, *UpdateMachineResponse) {}
+
+func init() {
+	catalogBuilder.RegisterHook(CanUpdateMachine, &runtimecatalog.HookMeta{
+		Tags:    []string{"In-Place Update Hooks"},
+		Summary: "Cluster API Runtime will call this hook to determine if changes to a Machine can be applied in-place",
+		Description: "Cluster API Runtime will call this hook when the spec of a Machine, of its InfrastructureMachine or " +
+			"of its BootstrapConfig is going to change, before deciding if the Machine must be replaced.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only if the InPlaceUpdates feature gate is enabled\n" +
+			"- The call's request contains the current and the desired state of the Machine, InfrastructureMachine and BootstrapConfig\n" +
+			"- The response must contain patches for the part of the changes the Runtime Extension can apply in-place; " +
+			"if the patches of all Runtime Extensions together cover all the changes, the Machine is updated in-place " +
+			"instead of being replaced",
+	})
+
+	catalogBuilder.RegisterHook(UpdateMachine, &runtimecatalog.HookMeta{
+		Tags:    []string{"In-Place Update Hooks"},
+		Summary: "Cluster API Runtime will call this hook to update a Machine in-place",
+		Description: "Cluster API Runtime will call this hook after it has been determined that the changes to a Machine " +
+			"can be applied in-place, and after the Machine, InfrastructureMachine and BootstrapConfig objects have been updated.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only if the InPlaceUpdates feature gate is enabled\n" +
+			"- The call's request contains the desired state of the Machine, InfrastructureMachine and BootstrapConfig\n" +
+			"- This is a blocking hook; the hook is called until all Runtime Extensions return a response without " +
+			"retryAfterSeconds, which signals that the update of the Machine is completed",
+	})
+}
@@ -206,6 +206,88 @@ type BeforeClusterDeleteResponse struct {
 // and before the cluster and its underlying objects are deleted.
 func BeforeClusterDelete(*BeforeClusterDeleteRequest, *BeforeClusterDeleteResponse) {}
 
+// AfterMachineReadyRequest is the request of the AfterMachineReady hook.
+// +kubebuilder:object:root=true
+type AfterMachineReadyRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+}
+
+var _ ResponseObject = &AfterMachineReadyResponse{}
+
+// AfterMachineReadyResponse is the response of the AfterMachineReady hook.
+// +kubebuilder:object:root=true
+type AfterMachineReadyResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+}
+
+// AfterMachineReady is the hook that will be called after the Node of a Machine becomes ready for the first time.
+func AfterMachineReady(*AfterMachineReadyRequest, *AfterMachineReadyResponse) {}
+
+// BeforeMachineDrainRequest is the request of the BeforeMachineDrain hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDrainRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDrainResponse{}
+
+// BeforeMachineDrainResponse is the response of the BeforeMachineDrain hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDrainResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDrain is the hook that will be called after a Machine is deleted and before its Node is drained.
+func BeforeMachineDrain(*BeforeMachineDrainRequest, *BeforeMachineDrainResponse) {}
+
+// BeforeMachineDeleteRequest is the request of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDeleteResponse{}
+
+// BeforeMachineDeleteResponse is the response of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDelete is the hook that will be called after the Node of a deleted Machine is drained and
+// before the infrastructure of the Machine is deleted.
+func BeforeMachineDelete(*BeforeMachineDeleteRequest, *BeforeMachineDeleteResponse) {}
+
 func init() {
 	catalogBuilder.RegisterHook(BeforeClusterCreate, &runtimecatalog.HookMeta{
 		Tags:    []string{"Lifecycle Hooks"},
@@ -281,4 +363,40 @@ func init() {
 			"- This is a blocking hook; Runtime Extension implementers can use this hook  to execute " +
 			"tasks before objects of the Cluster are deleted",
 	})
+
+	catalogBuilder.RegisterHook(AfterMachineReady, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after the Node of a Machine becomes ready",
+		Description: "Cluster API Runtime will call this hook after the Node of a Machine becomes ready for the first time.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Machines created after the RuntimeSDK feature gate has been enabled\n" +
+			"- The call's request contains the Machine object\n" +
+			"- This is a non-blocking hook",
+	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDrain, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before the Node of a Machine is drained",
+		Description: "Cluster API Runtime will call this hook after the Machine deletion has been triggered, " +
+			"and immediately before the Node of the Machine is drained.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only if the Node of the Machine is going to be drained and deleted\n" +
+			"- The call's request contains the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the Node of the Machine is drained",
+	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDelete, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before the infrastructure of a Machine is deleted",
+		Description: "Cluster API Runtime will call this hook after the Node of a deleted Machine has been drained, " +
+			"and immediately before the InfrastructureMachine and the BootstrapConfig of the Machine are deleted.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The call's request contains the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the infrastructure of the Machine is deleted",
+	})
 }
@@ -178,6 +178,57 @@ func (in *AfterControlPlaneUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterMachineReadyRequest) DeepCopyInto(out *AfterMachineReadyRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterMachineReadyRequest.
+func (in *AfterMachineReadyRequest) DeepCopy() *AfterMachineReadyRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterMachineReadyRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterMachineReadyRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterMachineReadyResponse) DeepCopyInto(out *AfterMachineReadyResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterMachineReadyResponse.
+func (in *AfterMachineReadyResponse) DeepCopy() *AfterMachineReadyResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterMachineReadyResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterMachineReadyResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BeforeClusterCreateRequest) DeepCopyInto(out *BeforeClusterCreateRequest) {
 	*out = *in
@@ -331,6 +382,108 @@ func (in *BeforeClusterUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDeleteRequest) DeepCopyInto(out *BeforeMachineDeleteRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDeleteRequest.
+func (in *BeforeMachineDeleteRequest) DeepCopy() *BeforeMachineDeleteRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDeleteRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDeleteRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDeleteResponse) DeepCopyInto(out *BeforeMachineDeleteResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDeleteResponse.
+func (in *BeforeMachineDeleteResponse) DeepCopy() *BeforeMachineDeleteResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDeleteResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDeleteResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDrainRequest) DeepCopyInto(out *BeforeMachineDrainRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDrainRequest.
+func (in *BeforeMachineDrainRequest) DeepCopy() *BeforeMachineDrainRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDrainRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDrainRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDrainResponse) DeepCopyInto(out *BeforeMachineDrainResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDrainResponse.
+func (in *BeforeMachineDrainResponse) DeepCopy() *BeforeMachineDrainResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDrainResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDrainResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Builtins) DeepCopyInto(out *Builtins) {
 	*out = *in
@@ -366,6 +519,61 @@ func (in *Builtins) DeepCopy() *Builtins {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanUpdateMachineRequest) DeepCopyInto(out *CanUpdateMachineRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Current.DeepCopyInto(&out.Current)
+	in.Desired.DeepCopyInto(&out.Desired)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanUpdateMachineRequest.
+func (in *CanUpdateMachineRequest) DeepCopy() *CanUpdateMachineRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(CanUpdateMachineRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CanUpdateMachineRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanUpdateMachineResponse) DeepCopyInto(out *CanUpdateMachineResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+	in.MachinePatch.DeepCopyInto(&out.MachinePatch)
+	in.InfrastructureMachinePatch.DeepCopyInto(&out.InfrastructureMachinePatch)
+	in.BootstrapConfigPatch.DeepCopyInto(&out.BootstrapConfigPatch)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanUpdateMachineResponse.
+func (in *CanUpdateMachineResponse) DeepCopy() *CanUpdateMachineResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(CanUpdateMachineResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CanUpdateMachineResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBuiltins) DeepCopyInto(out *ClusterBuiltins) {
 	*out = *in
@@ -975,6 +1183,95 @@ func (in *MachinePoolBuiltins) DeepCopy() *MachinePoolBuiltins {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineUpdateObjects) DeepCopyInto(out *MachineUpdateObjects) {
+	*out = *in
+	in.Machine.DeepCopyInto(&out.Machine)
+	in.InfrastructureMachine.DeepCopyInto(&out.InfrastructureMachine)
+	in.BootstrapConfig.DeepCopyInto(&out.BootstrapConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineUpdateObjects.
+func (in *MachineUpdateObjects) DeepCopy() *MachineUpdateObjects {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineUpdateObjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Patch) DeepCopyInto(out *Patch) {
+	*out = *in
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Patch.
+func (in *Patch) DeepCopy() *Patch {
+	if in == nil {
+		return nil
+	}
+	out := new(Patch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateMachineRequest) DeepCopyInto(out *UpdateMachineRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Desired.DeepCopyInto(&out.Desired)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateMachineRequest.
+func (in *UpdateMachineRequest) DeepCopy() *UpdateMachineRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateMachineRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpdateMachineRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateMachineResponse) DeepCopyInto(out *UpdateMachineResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateMachineResponse.
+func (in *UpdateMachineResponse) DeepCopy() *UpdateMachineResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateMachineResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpdateMachineResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidateTopologyRequest) DeepCopyInto(out *ValidateTopologyRequest) {
 	*out = *in
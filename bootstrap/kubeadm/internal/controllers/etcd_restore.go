@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+const (
+	// etcdRestoreDir is the directory where the restore script and the CA bundle are written on the Machine.
+	// NOTE: The snapshot is not written to this directory, because it is usually a tmpfs; see etcdRestoreStagingDirName.
+	etcdRestoreDir = "/run/cluster-api/etcd-restore"
+
+	// etcdRestoreStagingDirName is the name of the directory, next to the etcd data directory, where the snapshot is
+	// downloaded and restored; using the same filesystem of the data directory keeps the snapshot out of memory and
+	// allows to move the restored data directory in place atomically.
+	etcdRestoreStagingDirName = ".cluster-api-etcd-restore"
+
+	// defaultEtcdDataDir is the data directory used by kubeadm for the local etcd if not otherwise specified.
+	defaultEtcdDataDir = "/var/lib/etcd"
+)
+
+// etcdRestoreScriptTemplate restores the local etcd data directory from a snapshot before kubeadm init runs.
+// The snapshot is restored using etcdutl from the etcd image kubeadm is going to use, so the on-disk format
+// of the data directory matches the etcd version of the cluster; the etcd member is restored with the name and
+// the peer URL kubeadm is going to use for the etcd static Pod.
+// The snapshot is downloaded by the Machine and decompressed while downloading, so only the decompressed snapshot is
+// stored; the checksum of the compressed snapshot is computed while downloading through a named pipe, and the
+// decompressed snapshot is deleted without being restored if the checksum does not match.
+// NOTE: The script requires kubeadm, crictl, ctr (i.e. containerd as container runtime), gunzip, curl, sha256sum
+// and, if the advertise address is not set, ip on the Machine; etcdutl is run from the etcd image. The script fails
+// before changing anything if any of those tools is missing.
+var etcdRestoreScriptTemplate = template.Must(template.New("etcd-restore").Parse(`#!/bin/bash
+# Restores the local etcd data directory from a snapshot; generated by the kubeadm bootstrap provider.
+set -o errexit
+set -o nounset
+set -o pipefail
+
+RESTORE_DIR="{{ .RestoreDir }}"
+DATA_DIR="{{ .DataDir }}"
+STAGING_DIR="$(dirname "${DATA_DIR}")/{{ .StagingDirName }}"
+
+for TOOL in {{ .RequiredTools }}; do
+  if ! command -v "${TOOL}" >/dev/null 2>&1; then
+    echo "etcd restore requires ${TOOL}, but it is not available on this machine" >&2
+    exit 1
+  fi
+done
+
+NODE_NAME="{{ .NodeName }}"
+if [[ -z "${NODE_NAME}" ]]; then
+  NODE_NAME="$(hostname | tr '[:upper:]' '[:lower:]')"
+fi
+
+ADVERTISE_ADDRESS="{{ .AdvertiseAddress }}"
+if [[ -z "${ADVERTISE_ADDRESS}" ]]; then
+  ADVERTISE_ADDRESS="$(ip -o route get 1.1.1.1 2>/dev/null | sed -n 's/.* src \([^ ]*\).*/\1/p')"
+fi
+if [[ -z "${ADVERTISE_ADDRESS}" ]]; then
+  ADVERTISE_ADDRESS="$(ip -o -6 route get 2001:4860:4860::8888 2>/dev/null | sed -n 's/.* src \([^ ]*\).*/\1/p')"
+fi
+if [[ -z "${ADVERTISE_ADDRESS}" ]]; then
+  echo "etcd restore cannot find the address of this machine; set initConfiguration.localAPIEndpoint.advertiseAddress" >&2
+  exit 1
+fi
+PEER_HOST="${ADVERTISE_ADDRESS}"
+if [[ "${PEER_HOST}" == *:* ]]; then
+  PEER_HOST="[${PEER_HOST}]"
+fi
+
+rm -rf "${STAGING_DIR}"
+mkdir -p --mode 0700 "${STAGING_DIR}"
+
+mkfifo "${STAGING_DIR}/snapshot.db.gz"
+sha256sum < "${STAGING_DIR}/snapshot.db.gz" > "${STAGING_DIR}/snapshot.db.gz.sha256" &
+SHA256SUM_PID=$!
+curl --fail --silent --show-error --location --retry 5 {{ if .CABundlePath }}--cacert "{{ .CABundlePath }}" {{ end }}{{ .SnapshotURL }} \
+  | tee "${STAGING_DIR}/snapshot.db.gz" \
+  | gunzip --stdout > "${STAGING_DIR}/snapshot.db"
+wait "${SHA256SUM_PID}"
+read -r SNAPSHOT_SHA256 _ < "${STAGING_DIR}/snapshot.db.gz.sha256"
+rm -f "${STAGING_DIR}/snapshot.db.gz" "${STAGING_DIR}/snapshot.db.gz.sha256"
+if [[ "${SNAPSHOT_SHA256}" != "{{ .SnapshotSHA256 }}" ]]; then
+  rm -rf "${STAGING_DIR}"
+  echo "etcd snapshot downloaded from the snapshot URL does not match the expected sha256 checksum" >&2
+  exit 1
+fi
+
+ETCD_IMAGE="$(kubeadm config images list --config "{{ .KubeadmConfigPath }}" 2>/dev/null | grep '/etcd:')"
+crictl pull "${ETCD_IMAGE}"
+ctr --namespace k8s.io run --rm \
+  --mount "type=bind,src=${STAGING_DIR},dst=${STAGING_DIR},options=rbind:rw" \
+  "${ETCD_IMAGE}" etcd-restore \
+  etcdutl snapshot restore "${STAGING_DIR}/snapshot.db" \
+  --data-dir "${STAGING_DIR}/data" \
+  --name "${NODE_NAME}" \
+  --initial-cluster "${NODE_NAME}=https://${PEER_HOST}:2380" \
+  --initial-advertise-peer-urls "https://${PEER_HOST}:2380"
+rm -f "${STAGING_DIR}/snapshot.db"
+
+rm -rf "${DATA_DIR}"
+mv "${STAGING_DIR}/data" "${DATA_DIR}"
+chmod 0700 "${DATA_DIR}"
+rm -rf "${STAGING_DIR}" "${RESTORE_DIR}"
+`))
+
+// etcdRestoreScriptInput is the input of etcdRestoreScriptTemplate.
+type etcdRestoreScriptInput struct {
+	RestoreDir        string
+	DataDir           string
+	StagingDirName    string
+	NodeName          string
+	AdvertiseAddress  string
+	KubeadmConfigPath string
+	RequiredTools     string
+	// SnapshotURL is quoted for the shell, because it is not validated beyond the https scheme.
+	SnapshotURL    string
+	SnapshotSHA256 string
+	CABundlePath   string
+}
+
+// resolveEtcdRestore returns the files and the commands restoring the local etcd data directory from the snapshot
+// defined in spec.etcdRestore; commands are expected to run after the user provided preKubeadmCommands.
+// NOTE: The snapshot is downloaded by the Machine, so its size is not limited by the size of the bootstrap data.
+func resolveEtcdRestore(cfg *bootstrapv1.KubeadmConfig) ([]bootstrapv1.File, []string, error) {
+	snapshotURL := cfg.Spec.EtcdRestore.SnapshotURL
+	if snapshotURL == nil {
+		return nil, nil, errors.New("spec.etcdRestore.snapshotURL must be set")
+	}
+
+	files := []bootstrapv1.File{}
+	input := etcdRestoreScriptInput{
+		RestoreDir:        etcdRestoreDir,
+		DataDir:           etcdDataDir(cfg.Spec.ClusterConfiguration),
+		KubeadmConfigPath: "/run/kubeadm/kubeadm.yaml",
+		StagingDirName:    etcdRestoreStagingDirName,
+		RequiredTools:     "kubeadm crictl ctr gunzip curl sha256sum",
+		SnapshotURL:       "'" + strings.ReplaceAll(snapshotURL.URL, "'", `'\''`) + "'",
+		SnapshotSHA256:    snapshotURL.SHA256,
+	}
+	if len(snapshotURL.CABundle) > 0 {
+		input.CABundlePath = path.Join(etcdRestoreDir, "ca.crt")
+		files = append(files, bootstrapv1.File{
+			Path:        input.CABundlePath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Encoding:    bootstrapv1.Base64,
+			Content:     base64.StdEncoding.EncodeToString(snapshotURL.CABundle),
+		})
+	}
+	if cfg.Spec.Format == bootstrapv1.Ignition {
+		input.KubeadmConfigPath = "/etc/kubeadm.yml"
+	}
+	if cfg.Spec.InitConfiguration != nil {
+		input.NodeName = cfg.Spec.InitConfiguration.NodeRegistration.Name
+		input.AdvertiseAddress = cfg.Spec.InitConfiguration.LocalAPIEndpoint.AdvertiseAddress
+	}
+	if input.AdvertiseAddress == "" {
+		// NOTE: ip is used to find the address of the Machine.
+		input.RequiredTools += " ip"
+	}
+
+	script := &bytes.Buffer{}
+	if err := etcdRestoreScriptTemplate.Execute(script, input); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate etcd restore script")
+	}
+	scriptPath := path.Join(etcdRestoreDir, "restore.sh")
+	files = append(files, bootstrapv1.File{
+		Path:        scriptPath,
+		Owner:       "root:root",
+		Permissions: "0700",
+		Content:     script.String(),
+	})
+
+	return files, []string{scriptPath}, nil
+}
+
+// etcdRestoreInitConfiguration returns a copy of the InitConfiguration ignoring the kubeadm preflight check failing
+// when the etcd data directory is not empty, which is expected after the data directory has been restored.
+func etcdRestoreInitConfiguration(initConfiguration *bootstrapv1.InitConfiguration, clusterConfiguration *bootstrapv1.ClusterConfiguration) *bootstrapv1.InitConfiguration {
+	ret := initConfiguration.DeepCopy()
+	if ret == nil {
+		ret = &bootstrapv1.InitConfiguration{}
+	}
+	// NOTE: This is the name of the kubeadm DirAvailable preflight check for the etcd data directory.
+	preflightCheck := "DirAvailable-" + strings.ReplaceAll(etcdDataDir(clusterConfiguration), "/", "-")
+	for _, ignored := range ret.NodeRegistration.IgnorePreflightErrors {
+		if strings.EqualFold(ignored, preflightCheck) || strings.EqualFold(ignored, "all") {
+			return ret
+		}
+	}
+	ret.NodeRegistration.IgnorePreflightErrors = append(ret.NodeRegistration.IgnorePreflightErrors, preflightCheck)
+	return ret
+}
+
+// etcdDataDir returns the data directory of the local etcd.
+func etcdDataDir(clusterConfiguration *bootstrapv1.ClusterConfiguration) string {
+	if clusterConfiguration != nil && clusterConfiguration.Etcd.Local != nil && clusterConfiguration.Etcd.Local.DataDir != "" {
+		return clusterConfiguration.Etcd.Local.DataDir
+	}
+	return defaultEtcdDataDir
+}
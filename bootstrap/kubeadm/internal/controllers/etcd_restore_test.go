@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func TestKubeadmConfigReconciler_Reconcile_EtcdRestore(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "validhost", Port: 6443}
+	cluster.Status.Initialization = &clusterv1.ClusterInitializationStatus{InfrastructureProvisioned: true}
+	cluster.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue}}
+
+	// The Cluster is already initialized, but the Machine restoring etcd must run kubeadm init.
+	machine := newControlPlaneMachine(cluster, "control-plane-restore-machine")
+	config := newControlPlaneInitKubeadmConfig(machine.Namespace, "control-plane-restore-cfg")
+	config.Spec.EtcdRestore = &bootstrapv1.EtcdRestoreSource{
+		SnapshotURL: &bootstrapv1.EtcdSnapshotURL{
+			URL:      "https://kcp.example.com/etcd-snapshots/default/kcp/snapshot?token=abc",
+			SHA256:   "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			CABundle: []byte("ca"),
+		},
+	}
+	addKubeadmConfigToMachine(config, machine)
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+	}
+	objects = append(objects, createSecrets(t, cluster, config)...)
+
+	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}).Build()
+	k := &KubeadmConfigReconciler{
+		Client:              myclient,
+		SecretCachingClient: myclient,
+		ClusterCache:        clustercache.NewFakeClusterCache(myclient, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		KubeadmInitLock:     &myInitLocker{},
+	}
+
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cfg, err := getKubeadmConfig(myclient, config.Name, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Status.Initialization).ToNot(BeNil())
+	g.Expect(cfg.Status.Initialization.DataSecretCreated).To(BeTrue())
+	g.Expect(cfg.Status.DataSecretName).ToNot(BeNil())
+	// Restore details must not be persisted in the KubeadmConfig.
+	g.Expect(cfg.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors).To(BeEmpty())
+	g.Expect(cfg.Spec.PreKubeadmCommands).To(BeEmpty())
+
+	s := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: *cfg.Status.DataSecretName}, s)).To(Succeed())
+	value := string(s.Data["value"])
+	g.Expect(value).To(ContainSubstring("kubeadm init"))
+	g.Expect(value).To(ContainSubstring("DirAvailable--var-lib-etcd"))
+	g.Expect(value).To(ContainSubstring("/run/cluster-api/etcd-restore/restore.sh"))
+	g.Expect(value).To(ContainSubstring("/run/cluster-api/etcd-restore/ca.crt"))
+	g.Expect(value).To(ContainSubstring(base64.StdEncoding.EncodeToString([]byte("ca"))))
+	g.Expect(value).To(ContainSubstring("https://kcp.example.com/etcd-snapshots/default/kcp/snapshot?token=abc"))
+	// The snapshot must never be embedded into the bootstrap data.
+	g.Expect(value).ToNot(ContainSubstring("snapshot.db.gz.000"))
+}
+
+func TestResolveEtcdRestore(t *testing.T) {
+	snapshotURL := &bootstrapv1.EtcdSnapshotURL{
+		URL:    "https://example.com/snapshot.db.gz",
+		SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+
+	tests := []struct {
+		name          string
+		config        *bootstrapv1.KubeadmConfig
+		wantErr       bool
+		wantFiles     []string
+		wantScript    []string
+		wantNotScript []string
+		wantCommand   string
+	}{
+		{
+			name: "restore with default values",
+			config: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cfg"},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					EtcdRestore: &bootstrapv1.EtcdRestoreSource{SnapshotURL: snapshotURL},
+				},
+			},
+			wantFiles: []string{
+				"/run/cluster-api/etcd-restore/restore.sh",
+			},
+			wantScript: []string{
+				`DATA_DIR="/var/lib/etcd"`,
+				`NODE_NAME=""`,
+				`ADVERTISE_ADDRESS=""`,
+				`--config "/run/kubeadm/kubeadm.yaml"`,
+				`STAGING_DIR="$(dirname "${DATA_DIR}")/.cluster-api-etcd-restore"`,
+				`for TOOL in kubeadm crictl ctr gunzip curl sha256sum ip; do`,
+				`--retry 5 'https://example.com/snapshot.db.gz' \
+  | tee "${STAGING_DIR}/snapshot.db.gz" \
+  | gunzip --stdout > "${STAGING_DIR}/snapshot.db"`,
+				`if [[ "${SNAPSHOT_SHA256}" != "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef" ]]; then`,
+				`etcdutl snapshot restore "${STAGING_DIR}/snapshot.db"`,
+				`mv "${STAGING_DIR}/data" "${DATA_DIR}"`,
+			},
+			wantNotScript: []string{
+				`--cacert`,
+				`${RESTORE_DIR}/snapshot.db`,
+			},
+			wantCommand: "/run/cluster-api/etcd-restore/restore.sh",
+		},
+		{
+			name: "restore quoting the URL and verifying the server with a CA bundle",
+			config: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cfg"},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					EtcdRestore: &bootstrapv1.EtcdRestoreSource{
+						SnapshotURL: &bootstrapv1.EtcdSnapshotURL{
+							URL:      "https://example.com/snapshot.db.gz?signature='abc'",
+							SHA256:   snapshotURL.SHA256,
+							CABundle: []byte("ca"),
+						},
+					},
+				},
+			},
+			wantFiles: []string{
+				"/run/cluster-api/etcd-restore/ca.crt",
+				"/run/cluster-api/etcd-restore/restore.sh",
+			},
+			wantScript: []string{
+				`--cacert "/run/cluster-api/etcd-restore/ca.crt" 'https://example.com/snapshot.db.gz?signature='\''abc'\''' \`,
+			},
+			wantCommand: "/run/cluster-api/etcd-restore/restore.sh",
+		},
+		{
+			name: "restore with custom data dir, node name, advertise address and ignition",
+			config: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cfg"},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{DataDir: "/data/etcd"}},
+					},
+					InitConfiguration: &bootstrapv1.InitConfiguration{
+						LocalAPIEndpoint: bootstrapv1.APIEndpoint{AdvertiseAddress: "10.0.0.1"},
+						NodeRegistration: bootstrapv1.NodeRegistrationOptions{Name: "node-1"},
+					},
+					Format:      bootstrapv1.Ignition,
+					EtcdRestore: &bootstrapv1.EtcdRestoreSource{SnapshotURL: snapshotURL},
+				},
+			},
+			wantFiles: []string{
+				"/run/cluster-api/etcd-restore/restore.sh",
+			},
+			wantScript: []string{
+				`DATA_DIR="/data/etcd"`,
+				`NODE_NAME="node-1"`,
+				`ADVERTISE_ADDRESS="10.0.0.1"`,
+				`--config "/etc/kubeadm.yml"`,
+				`for TOOL in kubeadm crictl ctr gunzip curl sha256sum; do`,
+			},
+			wantCommand: "/run/cluster-api/etcd-restore/restore.sh",
+		},
+		{
+			name: "fails if the snapshot URL is not set",
+			config: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cfg"},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					EtcdRestore: &bootstrapv1.EtcdRestoreSource{},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			files, commands, err := resolveEtcdRestore(tt.config)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			paths := []string{}
+			for _, f := range files {
+				paths = append(paths, f.Path)
+			}
+			g.Expect(paths).To(Equal(tt.wantFiles))
+			if caBundle := tt.config.Spec.EtcdRestore.SnapshotURL.CABundle; len(caBundle) > 0 {
+				g.Expect(files[0].Encoding).To(Equal(bootstrapv1.Base64))
+				g.Expect(files[0].Content).To(Equal(base64.StdEncoding.EncodeToString(caBundle)))
+			}
+			script := files[len(files)-1].Content
+			for _, s := range tt.wantScript {
+				g.Expect(script).To(ContainSubstring(s))
+			}
+			for _, s := range tt.wantNotScript {
+				g.Expect(script).ToNot(ContainSubstring(s))
+			}
+			g.Expect(commands).To(ConsistOf(tt.wantCommand))
+		})
+	}
+}
+
+func TestEtcdRestoreInitConfiguration(t *testing.T) {
+	tests := []struct {
+		name                  string
+		initConfiguration     *bootstrapv1.InitConfiguration
+		clusterConfiguration  *bootstrapv1.ClusterConfiguration
+		wantPreflightsIgnored []string
+	}{
+		{
+			name:                  "nil InitConfiguration",
+			wantPreflightsIgnored: []string{"DirAvailable--var-lib-etcd"},
+		},
+		{
+			name: "preserves preflight errors ignored by the user",
+			initConfiguration: &bootstrapv1.InitConfiguration{
+				NodeRegistration: bootstrapv1.NodeRegistrationOptions{IgnorePreflightErrors: []string{"NumCPU"}},
+			},
+			wantPreflightsIgnored: []string{"NumCPU", "DirAvailable--var-lib-etcd"},
+		},
+		{
+			name: "uses the etcd data dir from ClusterConfiguration",
+			clusterConfiguration: &bootstrapv1.ClusterConfiguration{
+				Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{DataDir: "/data/etcd"}},
+			},
+			wantPreflightsIgnored: []string{"DirAvailable--data-etcd"},
+		},
+		{
+			name: "does not add the preflight error if all preflight errors are ignored",
+			initConfiguration: &bootstrapv1.InitConfiguration{
+				NodeRegistration: bootstrapv1.NodeRegistrationOptions{IgnorePreflightErrors: []string{"all"}},
+			},
+			wantPreflightsIgnored: []string{"all"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			original := tt.initConfiguration.DeepCopy()
+			got := etcdRestoreInitConfiguration(tt.initConfiguration, tt.clusterConfiguration)
+			g.Expect(got.NodeRegistration.IgnorePreflightErrors).To(Equal(tt.wantPreflightsIgnored))
+			// The original InitConfiguration must not be changed.
+			g.Expect(tt.initConfiguration).To(Equal(original))
+		})
+	}
+}
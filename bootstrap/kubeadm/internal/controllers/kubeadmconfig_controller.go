@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
@@ -362,6 +363,12 @@ func (r *KubeadmConfigReconciler) reconcile(ctx context.Context, scope *Scope, c
 		return r.handleClusterNotInitialized(ctx, scope)
 	}
 
+	// When restoring etcd from a snapshot, the first Machine of the restored control plane runs kubeadm init
+	// even if the Cluster was already initialized.
+	if config.Spec.EtcdRestore != nil && configOwner.IsControlPlaneMachine() {
+		return r.handleClusterNotInitialized(ctx, scope)
+	}
+
 	// Every other case it's a join scenario
 	// Nb. in this case ClusterConfiguration and InitConfiguration should not be defined by users, but in case of misconfigurations, CABPK simply ignore them
 
@@ -554,7 +561,12 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		return ctrl.Result{}, err
 	}
 
-	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(scope.Config.Spec.InitConfiguration, parsedVersion)
+	initConfiguration := scope.Config.Spec.InitConfiguration
+	if scope.Config.Spec.EtcdRestore != nil {
+		initConfiguration = etcdRestoreInitConfiguration(initConfiguration, scope.Config.Spec.ClusterConfiguration)
+	}
+
+	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(initConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal init configuration")
 		return ctrl.Result{}, err
@@ -625,12 +637,29 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		return ctrl.Result{}, err
 	}
 
+	preKubeadmCommands := scope.Config.Spec.PreKubeadmCommands
+	if scope.Config.Spec.EtcdRestore != nil {
+		restoreFiles, restoreCommands, err := resolveEtcdRestore(scope.Config)
+		if err != nil {
+			v1beta1conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableV1Beta1Condition, bootstrapv1.DataSecretGenerationFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			conditions.Set(scope.Config, metav1.Condition{
+				Type:    bootstrapv1.KubeadmConfigDataSecretAvailableCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  bootstrapv1.KubeadmConfigDataSecretNotAvailableReason,
+				Message: "Failed to generate etcd restore commands for spec.etcdRestore",
+			})
+			return ctrl.Result{}, err
+		}
+		files = append(files, restoreFiles...)
+		preKubeadmCommands = append(slices.Clone(preKubeadmCommands), restoreCommands...)
+	}
+
 	controlPlaneInput := &cloudinit.ControlPlaneInput{
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles:     files,
 			NTP:                 scope.Config.Spec.NTP,
 			BootCommands:        scope.Config.Spec.BootCommands,
-			PreKubeadmCommands:  scope.Config.Spec.PreKubeadmCommands,
+			PreKubeadmCommands:  preKubeadmCommands,
 			PostKubeadmCommands: scope.Config.Spec.PostKubeadmCommands,
 			Users:               users,
 			Mounts:              scope.Config.Spec.Mounts,
@@ -505,6 +505,75 @@ func TestKubeadmConfigValidate(t *testing.T) {
 				},
 			},
 		},
+		"valid ECDSA encryption algorithm": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						EncryptionAlgorithm: bootstrapv1.EncryptionAlgorithmECDSAP256,
+					},
+				},
+			},
+		},
+		"invalid Ed25519 encryption algorithm": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						EncryptionAlgorithm: "Ed25519",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid unsupported encryption algorithm": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						EncryptionAlgorithm: "RSA-1024",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"valid etcd restore from a URL": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					EtcdRestore: &bootstrapv1.EtcdRestoreSource{
+						SnapshotURL: &bootstrapv1.EtcdSnapshotURL{
+							URL:    "https://example.com/snapshot.db.gz",
+							SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+						},
+					},
+				},
+			},
+		},
+		"invalid etcd restore without snapshot URL": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					EtcdRestore: &bootstrapv1.EtcdRestoreSource{},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for name, tt := range cases {
@@ -121,6 +121,11 @@ func Convert_upstreamv1beta3_BootstrapToken_To_v1beta2_BootstrapToken(in *Bootst
 
 // Custom conversion from the hub version, CABPK v1beta1, to this API, kubeadm v1beta3.
 
+func Convert_v1beta2_ClusterConfiguration_To_upstreamv1beta3_ClusterConfiguration(in *bootstrapv1.ClusterConfiguration, out *ClusterConfiguration, s apimachineryconversion.Scope) error {
+	// ClusterConfiguration.EncryptionAlgorithm does not exist in kubeadm v1beta3, dropping this info.
+	return autoConvert_v1beta2_ClusterConfiguration_To_upstreamv1beta3_ClusterConfiguration(in, out, s)
+}
+
 func Convert_v1beta2_InitConfiguration_To_upstreamv1beta3_InitConfiguration(in *bootstrapv1.InitConfiguration, out *InitConfiguration, s apimachineryconversion.Scope) error {
 	// InitConfiguration.Timeouts does not exist in kubeadm v1beta3, dropping this info.
 	return autoConvert_v1beta2_InitConfiguration_To_upstreamv1beta3_InitConfiguration(in, out, s)
@@ -87,6 +87,7 @@ func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 func clusterConfigurationFuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 	return []interface{}{
 		spokeClusterConfigurationFuzzer,
+		hubClusterConfigurationFuzzer,
 	}
 }
 
@@ -165,6 +166,12 @@ func spokeJoinControlPlanesFuzzer(obj *JoinControlPlane, c randfill.Continue) {
 // NOTES:
 // - When fields do not exist in kubeadm v1beta4 types, pinning them to avoid cabpk v1beta1 --> kubeadm v1beta4 --> cabpk v1beta1 round trip errors.
 
+func hubClusterConfigurationFuzzer(obj *bootstrapv1.ClusterConfiguration, c randfill.Continue) {
+	c.FillNoCustom(obj)
+
+	obj.EncryptionAlgorithm = ""
+}
+
 func hubControlPlaneComponentFuzzer(obj *bootstrapv1.ControlPlaneComponent, c randfill.Continue) {
 	c.FillNoCustom(obj)
 
@@ -95,7 +95,6 @@ func spokeClusterConfigurationFuzzer(obj *ClusterConfiguration, c randfill.Conti
 	c.FillNoCustom(obj)
 
 	obj.Proxy = Proxy{}
-	obj.EncryptionAlgorithm = ""
 	obj.CACertificateValidityPeriod = nil
 	obj.CertificateValidityPeriod = nil
 
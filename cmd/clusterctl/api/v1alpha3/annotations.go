@@ -41,4 +41,10 @@ const (
 	// `clusterctl move` is invoked, then NO resources for ANY workload cluster will be created on the
 	// destination management cluster until the annotation is removed.
 	BlockMoveAnnotation = "clusterctl.cluster.x-k8s.io/block-move"
+
+	// KubernetesVersionsAnnotation can be placed on the templates referenced by a ClusterClass to document the range
+	// of Kubernetes versions the template can be used with (e.g. ">=1.31.0 <1.34.0").
+	// clusterctl upgrade plan uses it to flag ClusterClasses that lack templates for the target Kubernetes version;
+	// templates without this annotation are assumed to support any Kubernetes version.
+	KubernetesVersionsAnnotation = "clusterctl.cluster.x-k8s.io/kubernetes-versions"
 )
@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
@@ -51,3 +52,9 @@ type Kubeconfig cluster.Kubeconfig
 // Processor defines the methods necessary for creating a specific yaml
 // processor.
 type Processor yaml.Processor
+
+// RolloutRevision describes a revision of a MachineDeployment.
+type RolloutRevision alpha.RolloutRevision
+
+// TopologyPlanOutput defines the changes to Clusters with a managed topology computed by TopologyPlan.
+type TopologyPlanOutput cluster.TopologyPlanOutput
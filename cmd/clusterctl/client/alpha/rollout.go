@@ -41,6 +41,9 @@ type Rollout interface {
 	ObjectRestarter(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectPauser(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectResumer(context.Context, cluster.Proxy, corev1.ObjectReference) error
+	ObjectStatusViewer(context.Context, cluster.Proxy, corev1.ObjectReference) (*RolloutStatus, error)
+	ObjectHistoryViewer(context.Context, cluster.Proxy, corev1.ObjectReference) ([]RolloutRevision, error)
+	ObjectRollbacker(context.Context, cluster.Proxy, corev1.ObjectReference, int64) error
 }
 
 var _ Rollout = &rollout{}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+)
+
+// RolloutRevision describes a revision of a MachineDeployment, i.e. one of its MachineSets.
+type RolloutRevision struct {
+	// Revision is the revision number, read from the RevisionAnnotation of the MachineSet.
+	Revision int64
+
+	// MachineSet is the name of the MachineSet for this revision.
+	MachineSet string
+
+	// Replicas is the number of replicas of the MachineSet.
+	Replicas int32
+
+	// Version is the Kubernetes version of the machines of this revision, if any.
+	Version string
+
+	// InfrastructureTemplate is the name of the infrastructure template of the machines of this revision.
+	InfrastructureTemplate string
+
+	// BootstrapTemplate is the name of the bootstrap config template of the machines of this revision, if any.
+	BootstrapTemplate string
+
+	// Current is true for the revision the MachineDeployment is currently rolling out to.
+	Current bool
+
+	// CreationTimestamp is the creation timestamp of the MachineSet.
+	CreationTimestamp metav1.Time
+}
+
+// ObjectHistoryViewer returns the revisions of the specified cluster-api resource, sorted by revision number.
+// Only MachineDeployments keep a history of revisions.
+func (r *rollout) ObjectHistoryViewer(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) ([]RolloutRevision, error) {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		machineSets, err := getMachineSetsForDeployment(ctx, proxy, deployment)
+		if err != nil {
+			return nil, err
+		}
+
+		currentRevision := deployment.Annotations[clusterv1.RevisionAnnotation]
+		revisions := make([]RolloutRevision, 0, len(machineSets))
+		for _, ms := range machineSets {
+			revision, err := mdutil.Revision(ms)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse revision of MachineSet %s/%s", ms.Namespace, ms.Name)
+			}
+			rolloutRevision := RolloutRevision{
+				Revision:               revision,
+				MachineSet:             ms.Name,
+				Replicas:               ptr.Deref(ms.Status.Replicas, 0),
+				Version:                ptr.Deref(ms.Spec.Template.Spec.Version, ""),
+				InfrastructureTemplate: ms.Spec.Template.Spec.InfrastructureRef.Name,
+				Current:                currentRevision != "" && ms.Annotations[clusterv1.RevisionAnnotation] == currentRevision,
+				CreationTimestamp:      ms.CreationTimestamp,
+			}
+			if ms.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
+				rolloutRevision.BootstrapTemplate = ms.Spec.Template.Spec.Bootstrap.ConfigRef.Name
+			}
+			revisions = append(revisions, rolloutRevision)
+
+			// Add the revisions served by the MachineSet before it has been updated in-place.
+			inPlaceUpdateRevisions, err := mdutil.InPlaceUpdateRevisions(ms)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range inPlaceUpdateRevisions {
+				template := r.MachineTemplate(ms)
+				rolloutRevision := RolloutRevision{
+					Revision:               r.Revision,
+					MachineSet:             ms.Name,
+					Version:                ptr.Deref(template.Spec.Version, ""),
+					InfrastructureTemplate: template.Spec.InfrastructureRef.Name,
+					CreationTimestamp:      ms.CreationTimestamp,
+				}
+				if template.Spec.Bootstrap.ConfigRef != nil {
+					rolloutRevision.BootstrapTemplate = template.Spec.Bootstrap.ConfigRef.Name
+				}
+				revisions = append(revisions, rolloutRevision)
+			}
+		}
+		sort.Slice(revisions, func(i, j int) bool {
+			return revisions[i].Revision < revisions[j].Revision
+		})
+		return revisions, nil
+	case KubeadmControlPlane:
+		return nil, errors.Errorf("KubeadmControlPlane does not keep a history of revisions: %v/%v", ref.Kind, ref.Name) //nolint:revive // KubeadmControlPlane is intentionally capitalized.
+	default:
+		return nil, errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validResourceTypes)
+	}
+}
+
+// getMachineSetsForDeployment returns the MachineSets controlled by the given MachineDeployment.
+func getMachineSetsForDeployment(ctx context.Context, proxy cluster.Proxy, md *clusterv1.MachineDeployment) ([]*clusterv1.MachineSet, error) {
+	c, err := proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msList := &clusterv1.MachineSetList{}
+	if err := c.List(ctx, msList, client.InNamespace(md.Namespace), client.MatchingLabels{clusterv1.MachineDeploymentNameLabel: md.Name}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineSets for MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+
+	machineSets := make([]*clusterv1.MachineSet, 0, len(msList.Items))
+	for i := range msList.Items {
+		ms := &msList.Items[i]
+		if !metav1.IsControlledBy(ms, md) {
+			continue
+		}
+		machineSets = append(machineSets, ms)
+	}
+	return machineSets, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+)
+
+func Test_ObjectHistoryViewer(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		ref     corev1.ObjectReference
+		want    []RolloutRevision
+		wantErr bool
+	}{
+		{
+			name: "machinedeployment history lists the revisions of the machinesets it controls",
+			objs: []client.Object{
+				rolloutHistoryMachineDeployment("3"),
+				rolloutHistoryMachineSet("md-1-ms-3", "3", "v1.31.0", 3),
+				rolloutHistoryMachineSet("md-1-ms-1", "1", "v1.29.0", 0),
+				rolloutHistoryMachineSet("md-1-ms-2", "2", "v1.30.0", 0),
+				// A MachineSet with the same label, but not controlled by the MachineDeployment.
+				&clusterv1.MachineSet{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "MachineSet",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "orphan",
+						Labels: map[string]string{
+							clusterv1.MachineDeploymentNameLabel: "md-1",
+						},
+					},
+				},
+			},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "md-1",
+				Namespace: "default",
+			},
+			want: []RolloutRevision{
+				{Revision: 1, MachineSet: "md-1-ms-1", Version: "v1.29.0", InfrastructureTemplate: "md-1-ms-1-infra", BootstrapTemplate: "md-1-ms-1-bootstrap"},
+				{Revision: 2, MachineSet: "md-1-ms-2", Version: "v1.30.0", InfrastructureTemplate: "md-1-ms-2-infra", BootstrapTemplate: "md-1-ms-2-bootstrap"},
+				{Revision: 3, MachineSet: "md-1-ms-3", Version: "v1.31.0", InfrastructureTemplate: "md-1-ms-3-infra", BootstrapTemplate: "md-1-ms-3-bootstrap", Replicas: 3, Current: true},
+			},
+		},
+		{
+			name: "machinedeployment history lists the revisions served by machinesets before in-place updates",
+			objs: []client.Object{
+				rolloutHistoryMachineDeployment("3"),
+				rolloutHistoryMachineSet("md-1-ms-1", "1", "v1.29.0", 0),
+				rolloutHistoryMachineSetUpdatedInPlace(),
+			},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "md-1",
+				Namespace: "default",
+			},
+			want: []RolloutRevision{
+				{Revision: 1, MachineSet: "md-1-ms-1", Version: "v1.29.0", InfrastructureTemplate: "md-1-ms-1-infra", BootstrapTemplate: "md-1-ms-1-bootstrap"},
+				{Revision: 2, MachineSet: "md-1-ms-2", Version: "v1.30.0", InfrastructureTemplate: "md-1-ms-2-infra", BootstrapTemplate: "md-1-ms-2-bootstrap"},
+				{Revision: 3, MachineSet: "md-1-ms-2", Version: "v1.31.0", InfrastructureTemplate: "md-1-ms-3-infra", BootstrapTemplate: "md-1-ms-3-bootstrap", Replicas: 3, Current: true},
+			},
+		},
+		{
+			name: "machinedeployment without machinesets has no history",
+			objs: []client.Object{
+				rolloutHistoryMachineDeployment("1"),
+			},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "md-1",
+				Namespace: "default",
+			},
+			want: []RolloutRevision{},
+		},
+		{
+			name: "kubeadmcontrolplane history should return error",
+			objs: []client.Object{
+				&controlplanev1.KubeadmControlPlane{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "KubeadmControlPlane",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "kcp",
+					},
+				},
+			},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "kcp",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "history of a machinedeployment that does not exist should return error",
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "md-1",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			got, err := r.ObjectHistoryViewer(context.Background(), proxy, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			for i := range got {
+				got[i].CreationTimestamp = metav1.Time{}
+			}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+// rolloutHistoryMachineDeployment returns the md-1 MachineDeployment at the given revision.
+func rolloutHistoryMachineDeployment(revision string) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "MachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "md-1",
+			UID:       "md-1-uid",
+			Annotations: map[string]string{
+				clusterv1.RevisionAnnotation: revision,
+			},
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "test",
+			Template:    rolloutHistoryMachineTemplate("md-1-ms-"+revision, "v1.31.0"),
+		},
+	}
+}
+
+// rolloutHistoryMachineSet returns a MachineSet controlled by the md-1 MachineDeployment.
+func rolloutHistoryMachineSet(name, revision, version string, replicas int32) *clusterv1.MachineSet {
+	template := rolloutHistoryMachineTemplate(name, version)
+	template.Labels[clusterv1.MachineDeploymentUniqueLabel] = name
+	return &clusterv1.MachineSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "MachineSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels: map[string]string{
+				clusterv1.MachineDeploymentNameLabel: "md-1",
+			},
+			Annotations: map[string]string{
+				clusterv1.RevisionAnnotation: revision,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "MachineDeployment",
+					Name:       "md-1",
+					UID:        "md-1-uid",
+					Controller: ptr.To(true),
+				},
+			},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: "test",
+			Template:    template,
+		},
+		Status: clusterv1.MachineSetStatus{
+			Replicas: ptr.To(replicas),
+		},
+	}
+}
+
+func rolloutHistoryMachineTemplate(name, version string) clusterv1.MachineTemplateSpec {
+	return clusterv1.MachineTemplateSpec{
+		ObjectMeta: clusterv1.ObjectMeta{
+			Labels: map[string]string{
+				clusterv1.MachineDeploymentNameLabel: "md-1",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test",
+			Version:     ptr.To(version),
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &clusterv1.ContractVersionedObjectReference{
+					APIGroup: "bootstrap.cluster.x-k8s.io",
+					Kind:     "KubeadmConfigTemplate",
+					Name:     name + "-bootstrap",
+				},
+			},
+			InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+				APIGroup: "infrastructure.cluster.x-k8s.io",
+				Kind:     "DockerMachineTemplate",
+				Name:     name + "-infra",
+			},
+		},
+	}
+}
+
+// rolloutHistoryMachineSetUpdatedInPlace returns the md-1-ms-2 MachineSet, which served revision 2 and
+// then has been updated in-place to serve revision 3.
+func rolloutHistoryMachineSetUpdatedInPlace() *clusterv1.MachineSet {
+	previous := rolloutHistoryMachineSet("md-1-ms-2", "2", "v1.30.0", 0)
+	ms := rolloutHistoryMachineSet("md-1-ms-2", "3", "v1.31.0", 3)
+	ms.Spec.Template.Spec = rolloutHistoryMachineTemplate("md-1-ms-3", "v1.31.0").Spec
+	if err := mdutil.AddInPlaceUpdateRevision(previous, ms); err != nil {
+		panic(err)
+	}
+	return ms
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+)
+
+// ObjectRollbacker will issue a rollback on the specified cluster-api resource, restoring the machine template
+// of the given revision; if toRevision is 0, the MachineDeployment is rolled back to the previous revision.
+func (r *rollout) ObjectRollbacker(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference, toRevision int64) error {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		if deployment.Spec.Paused {
+			return errors.Errorf("cannot rollback a paused MachineDeployment, resume it first: %v/%v", ref.Kind, ref.Name)
+		}
+		if _, ok := deployment.Labels[clusterv1.ClusterTopologyOwnedLabel]; ok {
+			return errors.Errorf("cannot rollback a MachineDeployment managed by a ClusterClass, change the Cluster topology instead: %v/%v", ref.Kind, ref.Name)
+		}
+		if err := rollbackMachineDeployment(ctx, proxy, deployment, toRevision); err != nil {
+			return err
+		}
+	case KubeadmControlPlane:
+		return errors.Errorf("KubeadmControlPlane does not support rollback: %v/%v", ref.Kind, ref.Name) //nolint:revive // KubeadmControlPlane is intentionally capitalized.
+	default:
+		return errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validResourceTypes)
+	}
+	return nil
+}
+
+// rollbackMachineDeployment restores the machine template of the given revision onto the MachineDeployment.
+func rollbackMachineDeployment(ctx context.Context, proxy cluster.Proxy, md *clusterv1.MachineDeployment, toRevision int64) error {
+	machineSets, err := getMachineSetsForDeployment(ctx, proxy, md)
+	if err != nil {
+		return err
+	}
+	template, err := findMachineTemplateForRevision(md, machineSets, toRevision)
+	if err != nil {
+		return err
+	}
+
+	// The MachineDeploymentUniqueLabel is added by the MachineDeployment controller to the MachineSet template
+	// and it must not be copied back to the MachineDeployment.
+	delete(template.Labels, clusterv1.MachineDeploymentUniqueLabel)
+
+	c, err := proxy.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	patchBase := client.MergeFrom(md.DeepCopy())
+	md.Spec.Template = *template
+	if err := c.Patch(ctx, md, patchBase); err != nil {
+		return errors.Wrapf(err, "failed while patching MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+	return nil
+}
+
+// findMachineTemplateForRevision returns the Machine template of the given revision; if toRevision is 0, it returns
+// the Machine template of the highest revision before the current revision of the MachineDeployment.
+// Revisions are served by MachineSets, or by MachineSets before they have been updated in-place.
+func findMachineTemplateForRevision(md *clusterv1.MachineDeployment, machineSets []*clusterv1.MachineSet, toRevision int64) (*clusterv1.MachineTemplateSpec, error) {
+	currentRevision, err := mdutil.Revision(md)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse revision of MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+
+	var previous *clusterv1.MachineTemplateSpec
+	var previousRevision int64
+	for _, ms := range machineSets {
+		revision, err := mdutil.Revision(ms)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse revision of MachineSet %s/%s", ms.Namespace, ms.Name)
+		}
+		inPlaceUpdateRevisions, err := mdutil.InPlaceUpdateRevisions(ms)
+		if err != nil {
+			return nil, err
+		}
+
+		templates := map[int64]*clusterv1.MachineTemplateSpec{
+			revision: ms.Spec.Template.DeepCopy(),
+		}
+		for _, r := range inPlaceUpdateRevisions {
+			templates[r.Revision] = r.MachineTemplate(ms)
+		}
+		for revision, template := range templates {
+			if toRevision > 0 && revision == toRevision {
+				return template, nil
+			}
+			if toRevision == 0 && revision < currentRevision && revision > previousRevision {
+				previous = template
+				previousRevision = revision
+			}
+		}
+	}
+
+	if toRevision > 0 {
+		return nil, errors.Errorf("unable to find revision %d in the history of MachineDeployment %s/%s", toRevision, md.Namespace, md.Name)
+	}
+	if previous == nil {
+		return nil, errors.Errorf("no previous revision found in the history of MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+	return previous, nil
+}
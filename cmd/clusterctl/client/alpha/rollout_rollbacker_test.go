@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ObjectRollbacker(t *testing.T) {
+	machineSets := []client.Object{
+		rolloutHistoryMachineSet("md-1-ms-1", "1", "v1.29.0", 0),
+		rolloutHistoryMachineSet("md-1-ms-2", "2", "v1.30.0", 0),
+		rolloutHistoryMachineSet("md-1-ms-3", "3", "v1.31.0", 3),
+	}
+	mdRef := corev1.ObjectReference{
+		Kind:      MachineDeployment,
+		Name:      "md-1",
+		Namespace: "default",
+	}
+
+	tests := []struct {
+		name         string
+		objs         []client.Object
+		ref          corev1.ObjectReference
+		toRevision   int64
+		wantTemplate clusterv1.MachineTemplateSpec
+		wantErr      bool
+	}{
+		{
+			name:         "machinedeployment should be rolled back to the previous revision",
+			objs:         append([]client.Object{rolloutHistoryMachineDeployment("3")}, machineSets...),
+			ref:          mdRef,
+			wantTemplate: rolloutHistoryMachineTemplate("md-1-ms-2", "v1.30.0"),
+		},
+		{
+			name:         "machinedeployment should be rolled back to the given revision",
+			objs:         append([]client.Object{rolloutHistoryMachineDeployment("3")}, machineSets...),
+			ref:          mdRef,
+			toRevision:   1,
+			wantTemplate: rolloutHistoryMachineTemplate("md-1-ms-1", "v1.29.0"),
+		},
+		{
+			name:         "machinedeployment should be rolled back to the revision served by a machineset before an in-place update",
+			objs:         []client.Object{rolloutHistoryMachineDeployment("3"), machineSets[0], rolloutHistoryMachineSetUpdatedInPlace()},
+			ref:          mdRef,
+			wantTemplate: rolloutHistoryMachineTemplate("md-1-ms-2", "v1.30.0"),
+		},
+		{
+			name:       "rolling back to a revision that does not exist should return error",
+			objs:       append([]client.Object{rolloutHistoryMachineDeployment("3")}, machineSets...),
+			ref:        mdRef,
+			toRevision: 4,
+			wantErr:    true,
+		},
+		{
+			name:    "rolling back a machinedeployment without previous revisions should return error",
+			objs:    []client.Object{rolloutHistoryMachineDeployment("1"), machineSets[0]},
+			ref:     mdRef,
+			wantErr: true,
+		},
+		{
+			name: "rolling back a paused machinedeployment should return error",
+			objs: func() []client.Object {
+				md := rolloutHistoryMachineDeployment("3")
+				md.Spec.Paused = true
+				return append([]client.Object{md}, machineSets...)
+			}(),
+			ref:     mdRef,
+			wantErr: true,
+		},
+		{
+			name: "rolling back a machinedeployment managed by a cluster class should return error",
+			objs: func() []client.Object {
+				md := rolloutHistoryMachineDeployment("3")
+				md.Labels = map[string]string{clusterv1.ClusterTopologyOwnedLabel: ""}
+				return append([]client.Object{md}, machineSets...)
+			}(),
+			ref:     mdRef,
+			wantErr: true,
+		},
+		{
+			name: "rolling back a kubeadmcontrolplane should return error",
+			objs: []client.Object{
+				&controlplanev1.KubeadmControlPlane{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "KubeadmControlPlane",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "kcp",
+					},
+				},
+			},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "kcp",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			err := r.ObjectRollbacker(context.Background(), proxy, tt.ref, tt.toRevision)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			cl, err := proxy.NewClient(context.Background())
+			g.Expect(err).ToNot(HaveOccurred())
+			md := &clusterv1.MachineDeployment{}
+			g.Expect(cl.Get(context.Background(), client.ObjectKey{Namespace: tt.ref.Namespace, Name: tt.ref.Name}, md)).To(Succeed())
+			g.Expect(md.Spec.Template).To(BeComparableTo(tt.wantTemplate))
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// RolloutStatus describes the progress of the rollout of a cluster-api resource.
+type RolloutStatus struct {
+	// Done is true when the rollout is complete.
+	Done bool
+
+	// Message describes the progress of the rollout.
+	Message string
+
+	// Details reports the messages of the conditions surfacing what the rollout is waiting for, if any.
+	Details []string
+}
+
+// rolloutReplicas is a summary of the replica counters of a resource.
+type rolloutReplicas struct {
+	desired, current, upToDate, available int32
+}
+
+// ObjectStatusViewer returns the status of the rollout of the specified cluster-api resource.
+// An error is returned if the rollout cannot progress, e.g. because the resource is paused or it is being deleted.
+func (r *rollout) ObjectStatusViewer(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) (*RolloutStatus, error) {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		return machineDeploymentRolloutStatus(deployment)
+	case KubeadmControlPlane:
+		kcp, err := getKubeadmControlPlane(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || kcp == nil {
+			return nil, errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		return kubeadmControlPlaneRolloutStatus(kcp)
+	default:
+		return nil, errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validResourceTypes)
+	}
+}
+
+func machineDeploymentRolloutStatus(md *clusterv1.MachineDeployment) (*RolloutStatus, error) {
+	if !md.DeletionTimestamp.IsZero() {
+		return nil, errors.Errorf("MachineDeployment %s/%s is being deleted", md.Namespace, md.Name)
+	}
+	if md.Spec.Paused {
+		return nil, errors.Errorf("rollout of MachineDeployment %s/%s is paused, use \"clusterctl alpha rollout resume\" to resume it", md.Namespace, md.Name)
+	}
+	if err := rolloutInternalError(md, clusterv1.MachineDeploymentRollingOutCondition, clusterv1.MachineDeploymentScalingUpCondition, clusterv1.MachineDeploymentScalingDownCondition); err != nil {
+		return nil, errors.Wrapf(err, "rollout of MachineDeployment %s/%s failed", md.Namespace, md.Name)
+	}
+
+	status := rolloutStatus("MachineDeployment", md.Name, md.Generation, md.Status.ObservedGeneration, rolloutReplicas{
+		desired:   ptr.Deref(md.Spec.Replicas, 0),
+		current:   ptr.Deref(md.Status.Replicas, 0),
+		upToDate:  ptr.Deref(md.Status.UpToDateReplicas, 0),
+		available: ptr.Deref(md.Status.AvailableReplicas, 0),
+	})
+	if !status.Done {
+		status.Details = rolloutDetails(md,
+			clusterv1.MachineDeploymentRollingOutCondition,
+			clusterv1.MachineDeploymentScalingUpCondition,
+			clusterv1.MachineDeploymentScalingDownCondition,
+			clusterv1.MachineDeploymentMachinesReadyCondition,
+			clusterv1.MachineDeploymentRemediatingCondition,
+		)
+	}
+	return status, nil
+}
+
+func kubeadmControlPlaneRolloutStatus(kcp *controlplanev1.KubeadmControlPlane) (*RolloutStatus, error) {
+	if !kcp.DeletionTimestamp.IsZero() {
+		return nil, errors.Errorf("KubeadmControlPlane %s/%s is being deleted", kcp.Namespace, kcp.Name)
+	}
+	if annotations.HasPaused(kcp.GetObjectMeta()) {
+		return nil, errors.Errorf("rollout of KubeadmControlPlane %s/%s is paused, use \"clusterctl alpha rollout resume\" to resume it", kcp.Namespace, kcp.Name)
+	}
+	if err := rolloutInternalError(kcp, controlplanev1.KubeadmControlPlaneRollingOutCondition, controlplanev1.KubeadmControlPlaneScalingUpCondition, controlplanev1.KubeadmControlPlaneScalingDownCondition); err != nil {
+		return nil, errors.Wrapf(err, "rollout of KubeadmControlPlane %s/%s failed", kcp.Namespace, kcp.Name)
+	}
+
+	status := rolloutStatus("KubeadmControlPlane", kcp.Name, kcp.Generation, kcp.Status.ObservedGeneration, rolloutReplicas{
+		desired:   ptr.Deref(kcp.Spec.Replicas, 0),
+		current:   ptr.Deref(kcp.Status.Replicas, 0),
+		upToDate:  ptr.Deref(kcp.Status.UpToDateReplicas, 0),
+		available: ptr.Deref(kcp.Status.AvailableReplicas, 0),
+	})
+	if !status.Done {
+		status.Details = rolloutDetails(kcp,
+			controlplanev1.KubeadmControlPlaneRollingOutCondition,
+			controlplanev1.KubeadmControlPlaneScalingUpCondition,
+			controlplanev1.KubeadmControlPlaneScalingDownCondition,
+			controlplanev1.KubeadmControlPlaneMachinesReadyCondition,
+			controlplanev1.KubeadmControlPlaneRemediatingCondition,
+		)
+	}
+	return status, nil
+}
+
+// rolloutStatus computes the status of a rollout from the replica counters of a resource,
+// using the same progress messages as kubectl rollout status.
+func rolloutStatus(kind, name string, generation, observedGeneration int64, replicas rolloutReplicas) *RolloutStatus {
+	switch {
+	case observedGeneration < generation:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q spec update to be observed...", kind, name)}
+	case replicas.upToDate < replicas.desired:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q rollout to finish: %d out of %d new replicas have been updated...", kind, name, replicas.upToDate, replicas.desired)}
+	case replicas.current > replicas.upToDate:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q rollout to finish: %d old replicas are pending termination...", kind, name, replicas.current-replicas.upToDate)}
+	case replicas.available < replicas.upToDate:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q rollout to finish: %d of %d updated replicas are available...", kind, name, replicas.available, replicas.upToDate)}
+	default:
+		return &RolloutStatus{Done: true, Message: fmt.Sprintf("%s %q successfully rolled out", kind, name)}
+	}
+}
+
+// rolloutInternalError returns an error if any of the given conditions reports that the controller
+// failed to compute it, which means the rollout cannot progress.
+func rolloutInternalError(obj conditions.Getter, conditionTypes ...string) error {
+	for _, conditionType := range conditionTypes {
+		if conditions.GetReason(obj, conditionType) == clusterv1.InternalErrorReason {
+			return errors.Errorf("%s: %s", conditionType, conditions.GetMessage(obj, conditionType))
+		}
+	}
+	return nil
+}
+
+// rolloutDetails returns the messages of the given conditions that are not in their normal state.
+// Normal state is False for RollingOut, ScalingUp, ScalingDown and Remediating, and True for the other conditions.
+func rolloutDetails(obj conditions.Getter, conditionTypes ...string) []string {
+	var details []string
+	for _, conditionType := range conditionTypes {
+		c := conditions.Get(obj, conditionType)
+		if c == nil || c.Message == "" {
+			continue
+		}
+		normalStatus := metav1.ConditionTrue
+		switch conditionType {
+		case clusterv1.RollingOutCondition, clusterv1.ScalingUpCondition, clusterv1.ScalingDownCondition, clusterv1.RemediatingCondition:
+			normalStatus = metav1.ConditionFalse
+		}
+		if c.Status == normalStatus {
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s: %s", conditionType, strings.ReplaceAll(c.Message, "\n", "\n  ")))
+	}
+	return details
+}
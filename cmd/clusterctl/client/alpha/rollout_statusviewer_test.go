@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ObjectStatusViewer(t *testing.T) {
+	machineDeployment := func(paused bool, status clusterv1.MachineDeploymentStatus) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "MachineDeployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "md-1",
+				Generation: 2,
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: ptr.To[int32](3),
+				Paused:   paused,
+			},
+			Status: status,
+		}
+	}
+	mdRef := corev1.ObjectReference{
+		Kind:      MachineDeployment,
+		Name:      "md-1",
+		Namespace: "default",
+	}
+
+	tests := []struct {
+		name        string
+		objs        []client.Object
+		ref         corev1.ObjectReference
+		wantErr     bool
+		wantDone    bool
+		wantMessage string
+		wantDetails []string
+	}{
+		{
+			name: "machinedeployment with spec changes not yet observed",
+			objs: []client.Object{
+				machineDeployment(false, clusterv1.MachineDeploymentStatus{ObservedGeneration: 1}),
+			},
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" spec update to be observed...`,
+		},
+		{
+			name: "machinedeployment with replicas not yet updated reports progress and conditions",
+			objs: []client.Object{
+				machineDeployment(false, clusterv1.MachineDeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           ptr.To[int32](4),
+					UpToDateReplicas:   ptr.To[int32](1),
+					AvailableReplicas:  ptr.To[int32](3),
+					Conditions: []metav1.Condition{
+						{Type: clusterv1.MachineDeploymentRollingOutCondition, Status: metav1.ConditionTrue, Reason: clusterv1.MachineDeploymentRollingOutReason, Message: "Rolling out 2 not up-to-date replicas"},
+						{Type: clusterv1.MachineDeploymentMachinesReadyCondition, Status: metav1.ConditionTrue, Reason: clusterv1.MachineDeploymentMachinesReadyReason, Message: "should not be reported"},
+					},
+				}),
+			},
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" rollout to finish: 1 out of 3 new replicas have been updated...`,
+			wantDetails: []string{"RollingOut: Rolling out 2 not up-to-date replicas"},
+		},
+		{
+			name: "machinedeployment with old replicas pending termination",
+			objs: []client.Object{
+				machineDeployment(false, clusterv1.MachineDeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           ptr.To[int32](4),
+					UpToDateReplicas:   ptr.To[int32](3),
+					AvailableReplicas:  ptr.To[int32](4),
+				}),
+			},
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" rollout to finish: 1 old replicas are pending termination...`,
+		},
+		{
+			name: "machinedeployment with updated replicas not yet available",
+			objs: []client.Object{
+				machineDeployment(false, clusterv1.MachineDeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           ptr.To[int32](3),
+					UpToDateReplicas:   ptr.To[int32](3),
+					AvailableReplicas:  ptr.To[int32](2),
+				}),
+			},
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" rollout to finish: 2 of 3 updated replicas are available...`,
+		},
+		{
+			name: "machinedeployment rolled out",
+			objs: []client.Object{
+				machineDeployment(false, clusterv1.MachineDeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           ptr.To[int32](3),
+					UpToDateReplicas:   ptr.To[int32](3),
+					AvailableReplicas:  ptr.To[int32](3),
+				}),
+			},
+			ref:         mdRef,
+			wantDone:    true,
+			wantMessage: `MachineDeployment "md-1" successfully rolled out`,
+		},
+		{
+			name: "paused machinedeployment should return error",
+			objs: []client.Object{
+				machineDeployment(true, clusterv1.MachineDeploymentStatus{ObservedGeneration: 2}),
+			},
+			ref:     mdRef,
+			wantErr: true,
+		},
+		{
+			name: "machinedeployment failing to compute the rollout should return error",
+			objs: []client.Object{
+				machineDeployment(false, clusterv1.MachineDeploymentStatus{
+					ObservedGeneration: 2,
+					Conditions: []metav1.Condition{
+						{Type: clusterv1.MachineDeploymentRollingOutCondition, Status: metav1.ConditionUnknown, Reason: clusterv1.MachineDeploymentRollingOutInternalErrorReason, Message: "Please check controller logs for errors"},
+					},
+				}),
+			},
+			ref:     mdRef,
+			wantErr: true,
+		},
+		{
+			name: "kubeadmcontrolplane with replicas not yet updated",
+			objs: []client.Object{
+				&controlplanev1.KubeadmControlPlane{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "KubeadmControlPlane",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "kcp",
+					},
+					Spec: controlplanev1.KubeadmControlPlaneSpec{
+						Replicas: ptr.To[int32](3),
+					},
+					Status: controlplanev1.KubeadmControlPlaneStatus{
+						Replicas:          ptr.To[int32](4),
+						UpToDateReplicas:  ptr.To[int32](2),
+						AvailableReplicas: ptr.To[int32](4),
+					},
+				},
+			},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "kcp",
+				Namespace: "default",
+			},
+			wantMessage: `Waiting for KubeadmControlPlane "kcp" rollout to finish: 2 out of 3 new replicas have been updated...`,
+		},
+		{
+			name: "paused kubeadmcontrolplane should return error",
+			objs: []client.Object{
+				&controlplanev1.KubeadmControlPlane{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "KubeadmControlPlane",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "kcp",
+						Annotations: map[string]string{
+							clusterv1.PausedAnnotation: "true",
+						},
+					},
+				},
+			},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "kcp",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid resource type should return error",
+			ref: corev1.ObjectReference{
+				Kind:      "foo",
+				Name:      "bar",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			status, err := r.ObjectStatusViewer(context.Background(), proxy, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(status.Done).To(Equal(tt.wantDone))
+			g.Expect(status.Message).To(Equal(tt.wantMessage))
+			g.Expect(status.Details).To(Equal(tt.wantDetails))
+		})
+	}
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	yamlprocessor "sigs.k8s.io/cluster-api/cmd/clusterctl/client/yamlprocessor"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+// metadataFileName is the name of the file with the provider metadata in a provider repository.
+const metadataFileName = "metadata.yaml"
+
+// ExportBundleOptions carries the options supported by ExportBundle.
+type ExportBundleOptions struct {
+	// Directory where the bundle should be written. If the directory already contains a bundle,
+	// the new provider versions are added to it.
+	Directory string
+
+	// CoreProvider version (e.g. cluster-api:v1.1.5) to add to the bundle. If unspecified, the
+	// cluster-api core provider's latest release is used, together with the kubeadm bootstrap
+	// and control plane providers, unless other providers of those types are specified.
+	CoreProvider string
+
+	// BootstrapProviders and versions (e.g. kubeadm:v1.1.5) to add to the bundle.
+	BootstrapProviders []string
+
+	// ControlPlaneProviders and versions (e.g. kubeadm:v1.1.5) to add to the bundle.
+	ControlPlaneProviders []string
+
+	// InfrastructureProviders and versions (e.g. aws:v0.5.0) to add to the bundle.
+	InfrastructureProviders []string
+
+	// IPAMProviders and versions (e.g. infoblox:v0.0.1) to add to the bundle.
+	IPAMProviders []string
+
+	// RuntimeExtensionProviders and versions (e.g. test:v0.0.1) to add to the bundle.
+	RuntimeExtensionProviders []string
+
+	// AddonProviders and versions (e.g. helm:v0.1.0) to add to the bundle.
+	AddonProviders []string
+
+	// Flavors of the cluster templates to add to the bundle, in addition to the default template.
+	// Each flavor must exist in at least one of the infrastructure providers.
+	Flavors []string
+
+	// ClusterClasses to add to the bundle.
+	// Each cluster class must exist in at least one of the infrastructure providers.
+	ClusterClasses []string
+}
+
+// ExportBundle writes a bundle with everything required to install or upgrade the selected providers
+// in air-gapped environments: components YAML, metadata, cluster templates, cluster classes,
+// the cert-manager components and the list of the required container images.
+func (c *clusterctlClient) ExportBundle(ctx context.Context, options ExportBundleOptions) error {
+	log := logf.Log
+
+	if options.Directory == "" {
+		return errors.New("invalid arguments: please provide a directory for the bundle")
+	}
+	if err := os.MkdirAll(options.Directory, 0750); err != nil {
+		return errors.Wrapf(err, "failed to create the bundle directory %q", options.Directory)
+	}
+
+	// If the directory already contains a bundle, add the new provider versions to it.
+	bundle := &config.Bundle{}
+	if _, err := os.Stat(filepath.Join(options.Directory, config.BundleIndexFileName)); err == nil {
+		if bundle, err = config.ReadBundle(options.Directory); err != nil {
+			return err
+		}
+	}
+
+	// If the core provider is not specified, add the same default providers enforced by init on an empty management cluster.
+	if options.CoreProvider == "" {
+		options.CoreProvider = config.ClusterAPIProviderName
+		if len(options.BootstrapProviders) == 0 {
+			options.BootstrapProviders = append(options.BootstrapProviders, config.KubeadmBootstrapProviderName)
+		}
+		if len(options.ControlPlaneProviders) == 0 {
+			options.ControlPlaneProviders = append(options.ControlPlaneProviders, config.KubeadmControlPlaneProviderName)
+		}
+	}
+
+	exporter := &bundleExporter{
+		client:         c,
+		options:        options,
+		bundle:         bundle,
+		images:         sets.Set[string]{},
+		flavors:        sets.Set[string]{},
+		clusterClasses: sets.Set[string]{},
+	}
+
+	providers := []struct {
+		providerType clusterctlv1.ProviderType
+		providers    []string
+	}{
+		{clusterctlv1.CoreProviderType, []string{options.CoreProvider}},
+		{clusterctlv1.BootstrapProviderType, options.BootstrapProviders},
+		{clusterctlv1.ControlPlaneProviderType, options.ControlPlaneProviders},
+		{clusterctlv1.InfrastructureProviderType, options.InfrastructureProviders},
+		{clusterctlv1.IPAMProviderType, options.IPAMProviders},
+		{clusterctlv1.RuntimeExtensionProviderType, options.RuntimeExtensionProviders},
+		{clusterctlv1.AddonProviderType, options.AddonProviders},
+	}
+	for _, p := range providers {
+		for _, provider := range p.providers {
+			// It is possible to opt-out from bootstrap/control-plane providers using '-' as a provider name (NoopProvider).
+			if provider == NoopProvider {
+				if p.providerType == clusterctlv1.CoreProviderType {
+					return errors.New("the '-' value can not be used for the core provider")
+				}
+				continue
+			}
+			log.Info("Exporting", "provider", provider, "type", p.providerType)
+			if err := exporter.exportProvider(ctx, p.providerType, provider); err != nil {
+				return errors.Wrapf(err, "failed to export the %q provider", provider)
+			}
+		}
+	}
+
+	if missing := sets.New(options.Flavors...).Difference(exporter.flavors); missing.Len() > 0 {
+		return errors.Errorf("failed to find cluster template flavors %s in the infrastructure providers", strings.Join(sets.List(missing), ", "))
+	}
+	if missing := sets.New(options.ClusterClasses...).Difference(exporter.clusterClasses); missing.Len() > 0 {
+		return errors.Errorf("failed to find cluster classes %s in the infrastructure providers", strings.Join(sets.List(missing), ", "))
+	}
+
+	log.Info("Exporting", "provider", "cert-manager")
+	if err := exporter.exportCertManager(ctx); err != nil {
+		return errors.Wrap(err, "failed to export cert-manager")
+	}
+
+	if err := exporter.writeImages(); err != nil {
+		return err
+	}
+	return config.WriteBundle(options.Directory, bundle)
+}
+
+// bundleExporter collects the files required by a bundle.
+type bundleExporter struct {
+	client  *clusterctlClient
+	options ExportBundleOptions
+	bundle  *config.Bundle
+
+	images         sets.Set[string]
+	flavors        sets.Set[string]
+	clusterClasses sets.Set[string]
+}
+
+func (e *bundleExporter) exportProvider(ctx context.Context, providerType clusterctlv1.ProviderType, provider string) error {
+	name, version, err := parseProviderName(provider)
+	if err != nil {
+		return err
+	}
+
+	providerConfig, err := e.client.configClient.Providers().Get(name, providerType)
+	if err != nil {
+		return err
+	}
+
+	repositoryClient, err := e.client.repositoryClientFactory(ctx, RepositoryClientFactoryInput{Provider: providerConfig})
+	if err != nil {
+		return err
+	}
+	if version == "" {
+		version = repositoryClient.DefaultVersion()
+	}
+
+	componentsFile, err := componentsFileName(providerConfig.URL())
+	if err != nil {
+		return err
+	}
+	rawComponents, err := repositoryClient.Components().Raw(ctx, repository.ComponentsOptions{Version: version})
+	if err != nil {
+		return err
+	}
+
+	// Parse the components without processing variables, so it is possible to get the list of images.
+	components, err := repository.NewComponents(repository.ComponentsInput{
+		Provider:     providerConfig,
+		ConfigClient: e.client.configClient,
+		Processor:    yamlprocessor.NewSimpleProcessor(),
+		RawYaml:      rawComponents,
+		Options: repository.ComponentsOptions{
+			Version:             version,
+			SkipTemplateProcess: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if components.Type() != providerType {
+		return errors.Errorf("can't use %q provider as an %q, it is a %q", provider, providerType, components.Type())
+	}
+	e.images.Insert(components.Images()...)
+
+	metadata, err := repositoryClient.Metadata(version).Get(ctx)
+	if err != nil {
+		return err
+	}
+	metadata.SetGroupVersionKind(clusterctlv1.GroupVersion.WithKind("Metadata"))
+	rawMetadata, err := yaml.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the provider metadata")
+	}
+
+	files := map[string][]byte{
+		componentsFile:   rawComponents,
+		metadataFileName: rawMetadata,
+	}
+
+	// Templates and cluster classes are expected to exist for the infrastructure providers only.
+	if providerType == clusterctlv1.InfrastructureProviderType {
+		processor := yamlprocessor.NewSimpleProcessor()
+
+		// The default template is optional, while flavors are expected to exist in at least one of the infrastructure providers.
+		for _, flavor := range append([]string{""}, e.options.Flavors...) {
+			rawTemplate, err := repositoryClient.Templates(version).Raw(ctx, flavor)
+			if err != nil {
+				logf.Log.V(1).Info("Skipping cluster template", "flavor", flavor, "provider", providerConfig.ManifestLabel(), "version", version, "reason", err.Error())
+				continue
+			}
+			files[processor.GetTemplateName(version, flavor)] = rawTemplate
+			if flavor != "" {
+				e.flavors.Insert(flavor)
+			}
+		}
+
+		for _, class := range e.options.ClusterClasses {
+			rawClass, err := repositoryClient.ClusterClasses(version).Raw(ctx, class)
+			if err != nil {
+				logf.Log.V(1).Info("Skipping cluster class", "class", class, "provider", providerConfig.ManifestLabel(), "version", version, "reason", err.Error())
+				continue
+			}
+			files[processor.GetClusterClassTemplateName(version, class)] = rawClass
+			e.clusterClasses.Insert(class)
+		}
+	}
+
+	if err := writeBundleFiles(filepath.Join(e.options.Directory, providerConfig.ManifestLabel(), version), files); err != nil {
+		return err
+	}
+
+	e.bundle.AddProvider(config.BundleProvider{
+		Name:           providerConfig.Name(),
+		Type:           providerConfig.Type(),
+		Version:        version,
+		ComponentsFile: componentsFile,
+	})
+	return nil
+}
+
+func (e *bundleExporter) exportCertManager(ctx context.Context) error {
+	certManagerConfig, err := e.client.configClient.CertManager().Get()
+	if err != nil {
+		return err
+	}
+
+	// Given that cert manager components yaml are stored in a repository like providers components yaml,
+	// we are using the same machinery to retrieve the file by using a fake provider object using
+	// the cert manager repository url.
+	certManagerFakeProvider := config.NewProvider(config.CertManagerBundleLabel, certManagerConfig.URL(), "")
+	repositoryClient, err := e.client.repositoryClientFactory(ctx, RepositoryClientFactoryInput{Provider: certManagerFakeProvider})
+	if err != nil {
+		return err
+	}
+
+	componentsFile, err := componentsFileName(certManagerConfig.URL())
+	if err != nil {
+		return err
+	}
+	rawComponents, err := repositoryClient.Components().Raw(ctx, repository.ComponentsOptions{Version: certManagerConfig.Version()})
+	if err != nil {
+		return err
+	}
+
+	objs, err := utilyaml.ToUnstructured(rawComponents)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse yaml for cert-manager manifest")
+	}
+	images, err := util.InspectImages(objs)
+	if err != nil {
+		return err
+	}
+	e.images.Insert(images...)
+
+	files := map[string][]byte{
+		componentsFile: rawComponents,
+	}
+	if err := writeBundleFiles(filepath.Join(e.options.Directory, config.CertManagerBundleLabel, certManagerConfig.Version()), files); err != nil {
+		return err
+	}
+
+	e.bundle.CertManager = &config.BundleCertManager{
+		Version:        certManagerConfig.Version(),
+		ComponentsFile: componentsFile,
+	}
+	return nil
+}
+
+// writeImages writes the list of images required by the bundle, merging it with the images
+// already listed in the bundle, if any.
+func (e *bundleExporter) writeImages() error {
+	imagesFile := filepath.Join(e.options.Directory, config.BundleImagesFileName)
+	existing, err := os.ReadFile(imagesFile) //nolint:gosec // The bundle directory is provided by the user.
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to read %q", imagesFile)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(existing))
+	for scanner.Scan() {
+		if image := strings.TrimSpace(scanner.Text()); image != "" {
+			e.images.Insert(image)
+		}
+	}
+
+	images := sets.List(e.images)
+	sort.Strings(images)
+	if err := os.WriteFile(imagesFile, []byte(strings.Join(images, "\n")+"\n"), 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", imagesFile)
+	}
+	return nil
+}
+
+// componentsFileName returns the name of the components file from the URL of a repository.
+func componentsFileName(repositoryURL string) (string, error) {
+	u, err := url.Parse(repositoryURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse repository url %q", repositoryURL)
+	}
+	name := path.Base(filepath.ToSlash(u.Path))
+	if name == "" || name == "." || name == "/" {
+		return "", errors.Errorf("failed to get the components file name from repository url %q", repositoryURL)
+	}
+	return name, nil
+}
+
+func writeBundleFiles(directory string, files map[string][]byte) error {
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return errors.Wrapf(err, "failed to create directory %q", directory)
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(directory, name), data, 0600); err != nil {
+			return errors.Wrapf(err, "failed to write %q", filepath.Join(directory, name))
+		}
+	}
+	return nil
+}
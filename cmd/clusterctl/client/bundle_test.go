@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+func Test_clusterctlClient_ExportBundle(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   ExportBundleOptions
+		wantFiles []string
+		wantErr   bool
+	}{
+		{
+			name: "exports default providers, the infrastructure provider, templates and cluster classes",
+			options: ExportBundleOptions{
+				InfrastructureProviders: []string{"infra"},
+				Flavors:                 []string{"dev"},
+				ClusterClasses:          []string{"quick-start"},
+			},
+			wantFiles: []string{
+				"cluster-api/v1.0.0/core-components.yaml",
+				"cluster-api/v1.0.0/metadata.yaml",
+				"bootstrap-kubeadm/v2.0.0/bootstrap-components.yaml",
+				"control-plane-kubeadm/v2.0.0/control-plane-components.yaml",
+				"infrastructure-infra/v3.0.0/infrastructure-components.yaml",
+				"infrastructure-infra/v3.0.0/metadata.yaml",
+				"infrastructure-infra/v3.0.0/cluster-template.yaml",
+				"infrastructure-infra/v3.0.0/cluster-template-dev.yaml",
+				"infrastructure-infra/v3.0.0/clusterclass-quick-start.yaml",
+				"cert-manager/v1.0.0/cert-manager.yaml",
+			},
+		},
+		{
+			name: "exports the selected provider versions",
+			options: ExportBundleOptions{
+				CoreProvider:          "cluster-api:v1.1.0",
+				BootstrapProviders:    []string{NoopProvider},
+				ControlPlaneProviders: []string{NoopProvider},
+			},
+			wantFiles: []string{
+				"cluster-api/v1.1.0/core-components.yaml",
+				"cluster-api/v1.1.0/metadata.yaml",
+				"cert-manager/v1.0.0/cert-manager.yaml",
+			},
+		},
+		{
+			name: "fails if a flavor does not exist in the infrastructure providers",
+			options: ExportBundleOptions{
+				InfrastructureProviders: []string{"infra"},
+				Flavors:                 []string{"does-not-exist"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if a noop core provider is requested",
+			options: ExportBundleOptions{
+				CoreProvider: NoopProvider,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tt.options.Directory = t.TempDir()
+
+			err := fakeBundleClient().ExportBundle(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			for _, f := range tt.wantFiles {
+				g.Expect(filepath.Join(tt.options.Directory, f)).To(BeAnExistingFile())
+			}
+
+			bundle, err := config.ReadBundle(tt.options.Directory)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(bundle.CertManager).To(Equal(&config.BundleCertManager{Version: "v1.0.0", ComponentsFile: "cert-manager.yaml"}))
+
+			images, err := os.ReadFile(filepath.Join(tt.options.Directory, config.BundleImagesFileName)) //nolint:gosec
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(images)).To(ContainSubstring("quay.io/jetstack/cert-manager-controller:v1.0.0"))
+		})
+	}
+}
+
+func Test_clusterctlClient_ExportBundle_AddsToExistingBundle(t *testing.T) {
+	g := NewWithT(t)
+
+	directory := t.TempDir()
+	c := fakeBundleClient()
+
+	g.Expect(c.ExportBundle(ctx, ExportBundleOptions{Directory: directory, InfrastructureProviders: []string{"infra:v3.0.0"}})).To(Succeed())
+	g.Expect(c.ExportBundle(ctx, ExportBundleOptions{Directory: directory, InfrastructureProviders: []string{"infra:v3.1.0"}})).To(Succeed())
+
+	bundle, err := config.ReadBundle(directory)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(bundle.Providers).To(ConsistOf(
+		config.BundleProvider{Name: config.KubeadmBootstrapProviderName, Type: clusterctlv1.BootstrapProviderType, Version: "v2.0.0", ComponentsFile: "bootstrap-components.yaml"},
+		config.BundleProvider{Name: config.ClusterAPIProviderName, Type: clusterctlv1.CoreProviderType, Version: "v1.0.0", ComponentsFile: "core-components.yaml"},
+		config.BundleProvider{Name: config.KubeadmControlPlaneProviderName, Type: clusterctlv1.ControlPlaneProviderType, Version: "v2.0.0", ComponentsFile: "control-plane-components.yaml"},
+		config.BundleProvider{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, Version: "v3.0.0", ComponentsFile: "infrastructure-components.yaml"},
+		config.BundleProvider{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, Version: "v3.1.0", ComponentsFile: "infrastructure-components.yaml"},
+	))
+
+	images, err := os.ReadFile(filepath.Join(directory, config.BundleImagesFileName)) //nolint:gosec
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(images)).To(Equal("quay.io/jetstack/cert-manager-controller:v1.0.0\n" +
+		"registry.k8s.io/cluster-api-aws/cluster-api-aws-controller:v0.5.3\n"))
+}
+
+// fakeBundleClient returns a clusterctl client with repositories for the default providers, an infrastructure
+// provider and cert-manager, using repository urls with the same file names of real provider repositories.
+func fakeBundleClient() *fakeClient {
+	coreProvider := config.NewProvider(config.ClusterAPIProviderName, "https://example.com/releases/latest/core-components.yaml", clusterctlv1.CoreProviderType)
+	bootstrapProvider := config.NewProvider(config.KubeadmBootstrapProviderName, "https://example.com/releases/latest/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType)
+	controlPlaneProvider := config.NewProvider(config.KubeadmControlPlaneProviderName, "https://example.com/releases/latest/control-plane-components.yaml", clusterctlv1.ControlPlaneProviderType)
+	infraProvider := config.NewProvider("infra", "https://example.com/releases/latest/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType)
+	certManager := config.NewProvider(config.CertManagerBundleLabel, "https://example.com/releases/v1.0.0/cert-manager.yaml", "")
+
+	config1 := newFakeConfig(ctx).
+		WithProvider(coreProvider).
+		WithProvider(bootstrapProvider).
+		WithProvider(controlPlaneProvider).
+		WithProvider(infraProvider)
+	config1.fakeReader.WithCertManager(certManager.URL(), "v1.0.0", "")
+
+	metadata := func(major, minor int32) *clusterctlv1.Metadata {
+		return &clusterctlv1.Metadata{
+			ReleaseSeries: []clusterctlv1.ReleaseSeries{
+				{Major: major, Minor: minor, Contract: currentContractVersion},
+			},
+		}
+	}
+
+	return newFakeClient(ctx, config1).
+		WithRepository(newFakeRepository(ctx, coreProvider, config1).
+			WithPaths("root", "components.yaml").
+			WithDefaultVersion("v1.0.0").
+			WithFile("v1.0.0", "components.yaml", componentsYAML("ns1")).
+			WithMetadata("v1.0.0", metadata(1, 0)).
+			WithFile("v1.1.0", "components.yaml", componentsYAML("ns1")).
+			WithMetadata("v1.1.0", metadata(1, 1))).
+		WithRepository(newFakeRepository(ctx, bootstrapProvider, config1).
+			WithPaths("root", "components.yaml").
+			WithDefaultVersion("v2.0.0").
+			WithFile("v2.0.0", "components.yaml", componentsYAML("ns2")).
+			WithMetadata("v2.0.0", metadata(2, 0))).
+		WithRepository(newFakeRepository(ctx, controlPlaneProvider, config1).
+			WithPaths("root", "components.yaml").
+			WithDefaultVersion("v2.0.0").
+			WithFile("v2.0.0", "components.yaml", componentsYAML("ns3")).
+			WithMetadata("v2.0.0", metadata(2, 0))).
+		WithRepository(newFakeRepository(ctx, infraProvider, config1).
+			WithPaths("root", "components.yaml").
+			WithDefaultVersion("v3.0.0").
+			WithFile("v3.0.0", "components.yaml", infraComponentsYAML("ns4")).
+			WithMetadata("v3.0.0", metadata(3, 0)).
+			WithFile("v3.0.0", "cluster-template.yaml", templateYAML("ns4", "test")).
+			WithFile("v3.0.0", "cluster-template-dev.yaml", templateYAML("ns4", "test")).
+			WithFile("v3.0.0", "clusterclass-quick-start.yaml", clusterClassYAML("ns4", "quick-start")).
+			WithFile("v3.1.0", "components.yaml", infraComponentsYAML("ns4")).
+			WithMetadata("v3.1.0", metadata(3, 1))).
+		WithRepository(newFakeRepository(ctx, certManager, config1).
+			WithPaths("root", "cert-manager.yaml").
+			WithDefaultVersion("v1.0.0").
+			WithFile("v1.0.0", "cert-manager.yaml", certManagerYAML()))
+}
+
+func certManagerYAML() []byte {
+	return []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cert-manager
+  namespace: cert-manager
+spec:
+  template:
+    spec:
+      containers:
+      - image: quay.io/jetstack/cert-manager-controller:v1.0.0
+        name: cert-manager-controller
+`)
+}
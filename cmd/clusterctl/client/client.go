@@ -47,6 +47,9 @@ type Client interface {
 	// InitImages returns the list of images required for executing the init command.
 	InitImages(ctx context.Context, options InitOptions) ([]string, error)
 
+	// ExportBundle writes a bundle with everything required to install or upgrade providers in air-gapped environments.
+	ExportBundle(ctx context.Context, options ExportBundleOptions) error
+
 	// GetClusterTemplate returns a workload cluster template.
 	GetClusterTemplate(ctx context.Context, options GetClusterTemplateOptions) (Template, error)
 
@@ -65,6 +68,9 @@ type Client interface {
 	// PlanCertManagerUpgrade returns a CertManagerUpgradePlan.
 	PlanCertManagerUpgrade(ctx context.Context, options PlanUpgradeOptions) (CertManagerUpgradePlan, error)
 
+	// PlanClusterUpgrade returns a set of suggested Kubernetes upgrade plans for the workload clusters.
+	PlanClusterUpgrade(ctx context.Context, options PlanClusterUpgradeOptions) ([]ClusterUpgradePlan, error)
+
 	// ApplyUpgrade executes an upgrade plan.
 	ApplyUpgrade(ctx context.Context, options ApplyUpgradeOptions) error
 
@@ -87,6 +93,14 @@ type AlphaClient interface {
 	RolloutPause(ctx context.Context, options RolloutPauseOptions) error
 	// RolloutResume provides rollout resume of paused cluster-api resources
 	RolloutResume(ctx context.Context, options RolloutResumeOptions) error
+	// RolloutStatus provides rollout status of cluster-api resources
+	RolloutStatus(ctx context.Context, options RolloutStatusOptions) error
+	// RolloutHistory provides rollout history of a cluster-api resource
+	RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error)
+	// RolloutUndo provides rollout undo of cluster-api resources
+	RolloutUndo(ctx context.Context, options RolloutUndoOptions) error
+	// TopologyPlan previews the changes to Clusters with a managed topology caused by changes to ClusterClasses and Clusters
+	TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*TopologyPlanOutput, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
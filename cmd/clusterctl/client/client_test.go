@@ -105,6 +105,10 @@ func (f fakeClient) InitImages(ctx context.Context, options InitOptions) ([]stri
 	return f.internalClient.InitImages(ctx, options)
 }
 
+func (f fakeClient) ExportBundle(ctx context.Context, options ExportBundleOptions) error {
+	return f.internalClient.ExportBundle(ctx, options)
+}
+
 func (f fakeClient) Delete(ctx context.Context, options DeleteOptions) error {
 	return f.internalClient.Delete(ctx, options)
 }
@@ -121,6 +125,10 @@ func (f fakeClient) PlanCertManagerUpgrade(ctx context.Context, options PlanUpgr
 	return f.internalClient.PlanCertManagerUpgrade(ctx, options)
 }
 
+func (f fakeClient) PlanClusterUpgrade(ctx context.Context, options PlanClusterUpgradeOptions) ([]ClusterUpgradePlan, error) {
+	return f.internalClient.PlanClusterUpgrade(ctx, options)
+}
+
 func (f fakeClient) ApplyUpgrade(ctx context.Context, options ApplyUpgradeOptions) error {
 	return f.internalClient.ApplyUpgrade(ctx, options)
 }
@@ -145,6 +153,22 @@ func (f fakeClient) RolloutResume(ctx context.Context, options RolloutResumeOpti
 	return f.internalClient.RolloutResume(ctx, options)
 }
 
+func (f fakeClient) RolloutStatus(ctx context.Context, options RolloutStatusOptions) error {
+	return f.internalClient.RolloutStatus(ctx, options)
+}
+
+func (f fakeClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error) {
+	return f.internalClient.RolloutHistory(ctx, options)
+}
+
+func (f fakeClient) RolloutUndo(ctx context.Context, options RolloutUndoOptions) error {
+	return f.internalClient.RolloutUndo(ctx, options)
+}
+
+func (f fakeClient) TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*TopologyPlanOutput, error) {
+	return f.internalClient.TopologyPlan(ctx, options)
+}
+
 // newFakeClient returns a clusterctl client that allows to execute tests on a set of fake config, fake repositories and fake clusters.
 // you can use WithCluster and WithRepository to prepare for the test case.
 func newFakeClient(ctx context.Context, configClient config.Client) *fakeClient {
@@ -318,6 +342,10 @@ func (f *fakeClusterClient) WorkloadCluster() cluster.WorkloadCluster {
 	return f.internalclient.WorkloadCluster()
 }
 
+func (f *fakeClusterClient) Topology() cluster.TopologyClient {
+	return f.internalclient.Topology()
+}
+
 func (f *fakeClusterClient) WithObjs(objs ...client.Object) *fakeClusterClient {
 	f.fakeProxy.WithObjs(objs...)
 	return f
@@ -497,13 +525,7 @@ type fakeTemplateClient struct {
 }
 
 func (f *fakeTemplateClient) Get(ctx context.Context, flavor, targetNamespace string, skipTemplateProcess bool) (repository.Template, error) {
-	name := "cluster-template"
-	if flavor != "" {
-		name = fmt.Sprintf("%s-%s", name, flavor)
-	}
-	name = fmt.Sprintf("%s.yaml", name)
-
-	content, err := f.fakeRepository.GetFile(ctx, f.version, name)
+	content, err := f.Raw(ctx, flavor)
 	if err != nil {
 		return nil, err
 	}
@@ -516,6 +538,16 @@ func (f *fakeTemplateClient) Get(ctx context.Context, flavor, targetNamespace st
 	})
 }
 
+func (f *fakeTemplateClient) Raw(ctx context.Context, flavor string) ([]byte, error) {
+	name := "cluster-template"
+	if flavor != "" {
+		name = fmt.Sprintf("%s-%s", name, flavor)
+	}
+	name = fmt.Sprintf("%s.yaml", name)
+
+	return f.fakeRepository.GetFile(ctx, f.version, name)
+}
+
 // fakeClusterClassClient provides a super simple TemplateClient (e.g. without support for local overrides).
 type fakeClusterClassClient struct {
 	version               string
@@ -525,8 +557,7 @@ type fakeClusterClassClient struct {
 }
 
 func (f *fakeClusterClassClient) Get(ctx context.Context, class, targetNamespace string, skipTemplateProcess bool) (repository.Template, error) {
-	name := fmt.Sprintf("clusterclass-%s.yaml", class)
-	content, err := f.fakeRepository.GetFile(ctx, f.version, name)
+	content, err := f.Raw(ctx, class)
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +570,11 @@ func (f *fakeClusterClassClient) Get(ctx context.Context, class, targetNamespace
 	})
 }
 
+func (f *fakeClusterClassClient) Raw(ctx context.Context, class string) ([]byte, error) {
+	name := fmt.Sprintf("clusterclass-%s.yaml", class)
+	return f.fakeRepository.GetFile(ctx, f.version, name)
+}
+
 // fakeMetadataClient provides a super simple MetadataClient (e.g. without support for local overrides/embedded metadata).
 type fakeMetadataClient struct {
 	version        string
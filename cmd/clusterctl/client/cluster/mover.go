@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -48,16 +49,46 @@ import (
 // ResourceMutatorFunc holds the type for mutators to be applied on resources during a move operation.
 type ResourceMutatorFunc func(u *unstructured.Unstructured) error
 
+// MoveOptions defines the options for moving Cluster API objects to another management cluster.
+type MoveOptions struct {
+	// DryRun means the move action is a dry run, no real action will be performed.
+	DryRun bool
+
+	// JournalDirectory is a local directory where move records its progress. If the directory contains the journal
+	// of a previous move which failed, the move is resumed from the last group of objects moved successfully.
+	// If empty, the progress of move is not recorded.
+	JournalDirectory string
+
+	// ClusterName, if set, restricts the move to the Cluster with this name and to the objects linked to it.
+	ClusterName string
+
+	// ClusterSelector, if set, restricts the move to the Clusters matching this label selector and to the objects
+	// linked to them.
+	// Note: ClusterClasses, templates and ClusterResourceSets shared with other Clusters in the namespace are copied
+	// to the target management cluster, and they are not deleted from the source management cluster.
+	ClusterSelector string
+
+	// Mutators are applied on all resources being moved.
+	Mutators []ResourceMutatorFunc
+}
+
 // ObjectMover defines methods for moving Cluster API objects to another management cluster.
 type ObjectMover interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
-	Move(ctx context.Context, namespace string, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error
+	Move(ctx context.Context, namespace string, toCluster Client, options MoveOptions) error
+
+	// Rollback rolls back a move which failed before starting to delete objects from the source management cluster,
+	// using the journal recorded in journalDirectory: objects created by move are deleted from the target management
+	// cluster, and Cluster API objects in the source management cluster are resumed.
+	Rollback(ctx context.Context, namespace string, toCluster Client, journalDirectory string) error
 
-	// ToDirectory writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target directory.
-	ToDirectory(ctx context.Context, namespace string, directory string) error
+	// ToDirectory writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to an archive
+	// in a target directory, optionally encrypted.
+	ToDirectory(ctx context.Context, namespace string, directory string, options ArchiveOptions) error
 
-	// FromDirectory reads all the Cluster API objects existing in a configured directory to a target management cluster.
-	FromDirectory(ctx context.Context, toCluster Client, directory string) error
+	// FromDirectory reads all the Cluster API objects existing in the archive in a configured directory to a target management cluster;
+	// the archive is verified before restoring any object.
+	FromDirectory(ctx context.Context, toCluster Client, directory string, options ArchiveOptions) error
 }
 
 // objectMover implements the ObjectMover interface.
@@ -65,21 +96,41 @@ type objectMover struct {
 	fromProxy             Proxy
 	fromProviderInventory InventoryClient
 	dryRun                bool
+
+	// journal records the progress of move; nil if journaling is not enabled.
+	journal *moveJournal
 }
 
 // ensure objectMover implements the ObjectMover interface.
 var _ ObjectMover = &objectMover{}
 
-func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error {
+func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Client, options MoveOptions) error {
 	log := logf.Log
 	log.Info("Performing move...")
-	o.dryRun = dryRun
+	o.dryRun = options.DryRun
 	if o.dryRun {
 		log.Info("********************************************************")
 		log.Info("This is a dry-run move, will not perform any real action")
 		log.Info("********************************************************")
 	}
 
+	if options.JournalDirectory != "" && !o.dryRun {
+		journal, err := loadMoveJournal(options.JournalDirectory, namespace)
+		if err != nil {
+			return err
+		}
+		if journal.exists() {
+			if journal.ClusterName != options.ClusterName || journal.ClusterSelector != options.ClusterSelector {
+				return errors.Errorf("move journal %s has been recorded for Cluster name %q and selector %q, not for Cluster name %q and selector %q",
+					journal.path, journal.ClusterName, journal.ClusterSelector, options.ClusterName, options.ClusterSelector)
+			}
+			log.Info("Resuming move using the journal", "Path", journal.path)
+		}
+		journal.ClusterName = options.ClusterName
+		journal.ClusterSelector = options.ClusterSelector
+		o.journal = journal
+	}
+
 	// checks that all the required providers in place in the target cluster.
 	if !o.dryRun {
 		if err := o.checkTargetProviders(ctx, toCluster.ProviderInventory()); err != nil {
@@ -87,7 +138,7 @@ func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Clie
 		}
 	}
 
-	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	objectGraph, err := o.getObjectGraph(ctx, namespace, options.ClusterName, options.ClusterSelector)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
@@ -98,22 +149,86 @@ func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Clie
 		proxy = toCluster.Proxy()
 	}
 
-	return o.move(ctx, objectGraph, proxy, mutators...)
+	return o.move(ctx, objectGraph, proxy, options.Mutators...)
+}
+
+func (o *objectMover) Rollback(ctx context.Context, namespace string, toCluster Client, journalDirectory string) error {
+	log := logf.Log
+	log.Info("Rolling back move...")
+
+	journal, err := loadMoveJournal(journalDirectory, namespace)
+	if err != nil {
+		return err
+	}
+
+	return o.rollback(ctx, namespace, journal, toCluster.Proxy())
+}
+
+func (o *objectMover) rollback(ctx context.Context, namespace string, journal *moveJournal, toProxy Proxy) error {
+	log := logf.Log
+	journalDirectory := filepath.Dir(journal.path)
+	if !journal.exists() {
+		return errors.Errorf("move journal not found in %s", journalDirectory)
+	}
+	if journal.Deleting {
+		return errors.New("move can't be rolled back because objects have already been deleted from the source cluster; run move again to resume it")
+	}
+
+	// Delete the objects created by move from the target cluster, in reverse order.
+	log.Info("Deleting objects from the target cluster")
+	deleteTargetObjectBackoff := newWriteBackoff()
+	for i := len(journal.Objects) - 1; i >= 0; i-- {
+		objectToDelete := journal.Objects[i]
+		if !objectToDelete.Created {
+			continue
+		}
+		err := retryWithExponentialBackoff(ctx, deleteTargetObjectBackoff, func(ctx context.Context) error {
+			return deleteTargetObject(ctx, toProxy, objectToDelete)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Build the object graph without checking for provisioning to be completed, given that objects
+	// in the source cluster are paused since move started.
+	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
+	if err := objectGraph.getDiscoveryTypes(ctx); err != nil {
+		return errors.Wrap(err, "failed to retrieve discovery types")
+	}
+	if err := objectGraph.Discovery(ctx, namespace); err != nil {
+		return errors.Wrap(err, "failed to discover the object graph")
+	}
+	if err := objectGraph.selectClusters(ctx, namespace, journal.ClusterName, journal.ClusterSelector); err != nil {
+		return errors.Wrap(err, "failed to select Clusters")
+	}
+
+	// Resume the ClusterClasses and the Clusters in the source management cluster, so the controllers start reconciling them again.
+	log.V(1).Info("Resuming the source ClusterClasses")
+	if err := setClusterClassPause(ctx, o.fromProxy, objectGraph.getClusterClasses(), false, false); err != nil {
+		return errors.Wrap(err, "error resuming ClusterClasses")
+	}
+	log.V(1).Info("Resuming the source cluster")
+	if err := setClusterPause(ctx, o.fromProxy, objectGraph.getClusters(), false, false); err != nil {
+		return err
+	}
+
+	return journal.remove()
 }
 
-func (o *objectMover) ToDirectory(ctx context.Context, namespace string, directory string) error {
+func (o *objectMover) ToDirectory(ctx context.Context, namespace string, directory string, options ArchiveOptions) error {
 	log := logf.Log
 	log.Info("Moving to directory...")
 
-	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	objectGraph, err := o.getObjectGraph(ctx, namespace, "", "")
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
 
-	return o.toDirectory(ctx, objectGraph, directory)
+	return o.toDirectory(ctx, objectGraph, directory, options)
 }
 
-func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, directory string) error {
+func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, directory string, options ArchiveOptions) error {
 	log := logf.Log
 	log.Info("Moving from directory...")
 
@@ -126,9 +241,24 @@ func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, direc
 		return errors.Wrap(err, "failed to retrieve discovery types")
 	}
 
-	objs, err := o.filesToObjs(directory)
-	if err != nil {
-		return errors.Wrap(err, "failed to process object files")
+	var objs []unstructured.Unstructured
+	switch {
+	case hasMoveArchive(directory):
+		objs, err = o.archiveToObjs(directory, options)
+		if err != nil {
+			return errors.Wrap(err, "failed to process archive")
+		}
+	case options.decrypt():
+		return errors.Errorf("encrypted archive not found in %s", directory)
+	case !options.AllowUnverifiedFiles:
+		return errors.Errorf("archive not found in %s; object files without an archive, e.g. written by older versions of clusterctl, can be restored only if explicitly allowing unverified files", directory)
+	default:
+		// Directories written by older versions of clusterctl contain one file for each object, without a manifest.
+		log.Info("WARNING: archive not found, restoring object files without verifying them")
+		objs, err = o.filesToObjs(directory)
+		if err != nil {
+			return errors.Wrap(err, "failed to process object files")
+		}
 	}
 
 	for i := range objs {
@@ -153,6 +283,30 @@ func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, direc
 	return o.fromDirectory(ctx, objectGraph, proxy)
 }
 
+// archiveToObjs reads and verifies the archive in the directory, and then it returns the objects in the archive.
+func (o *objectMover) archiveToObjs(dir string, options ArchiveOptions) ([]unstructured.Unstructured, error) {
+	log := logf.Log
+	log.Info(fmt.Sprintf("Restoring archive from %s", dir))
+
+	files, err := readMoveArchive(dir, options)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rawYAMLs := make([][]byte, 0, len(names))
+	for _, name := range names {
+		rawYAMLs = append(rawYAMLs, files[name])
+	}
+
+	return yaml.ToUnstructured(yaml.JoinYaml(rawYAMLs...))
+}
+
 func (o *objectMover) filesToObjs(dir string) ([]unstructured.Unstructured, error) {
 	log := logf.Log
 	log.Info(fmt.Sprintf("Restoring files from %s", dir))
@@ -184,7 +338,7 @@ func (o *objectMover) filesToObjs(dir string) ([]unstructured.Unstructured, erro
 	return objs, nil
 }
 
-func (o *objectMover) getObjectGraph(ctx context.Context, namespace string) (*objectGraph, error) {
+func (o *objectMover) getObjectGraph(ctx context.Context, namespace, clusterName, clusterSelector string) (*objectGraph, error) {
 	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
 
 	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
@@ -200,6 +354,11 @@ func (o *objectMover) getObjectGraph(ctx context.Context, namespace string) (*ob
 		return nil, errors.Wrap(err, "failed to discover the object graph")
 	}
 
+	// If requested, restrict the object graph to the selected Clusters and to the objects linked to them.
+	if err := objectGraph.selectClusters(ctx, namespace, clusterName, clusterSelector); err != nil {
+		return nil, errors.Wrap(err, "failed to select Clusters")
+	}
+
 	// Checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move/toDirectory operation.
 	// This is required because if the infrastructure is provisioned, then we can reasonably assume that the objects we are moving/backing up are
 	// not currently waiting for long-running reconciliation loops, and so we can safely rely on the pause field on the Cluster object
@@ -324,6 +483,11 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	clusterClasses := graph.getClusterClasses()
 	log.Info("Moving Cluster API objects", "ClusterClasses", len(clusterClasses))
 
+	// Save the journal before pausing, so it is possible to rollback also if the move fails before creating any object.
+	if err := o.journal.save(); err != nil {
+		return err
+	}
+
 	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
 	log.V(1).Info("Pausing the source cluster")
 	if err := setClusterPause(ctx, o.fromProxy, clusters, true, o.dryRun); err != nil {
@@ -362,7 +526,19 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	// Create all objects group by group, ensuring all the ownerReferences are re-created.
 	log.Info("Creating objects in the target cluster")
 	for groupIndex := range len(moveSequence.groups) {
-		if err := o.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+		group := moveSequence.getGroup(groupIndex)
+
+		// If resuming a move, skip groups already moved by the previous run.
+		if o.journal.restoreGroup(group) {
+			log.V(1).Info("Skipping objects already created in the target cluster", "Group", groupIndex)
+			continue
+		}
+
+		if err := o.createGroup(ctx, group, toProxy, mutators...); err != nil {
+			// Record the objects created so far, so the move can be resumed or rolled back.
+			return kerrors.NewAggregate([]error{err, o.journal.save()})
+		}
+		if err := o.journal.completeGroup(group); err != nil {
 			return err
 		}
 	}
@@ -373,12 +549,28 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	// Delete all objects group by group in reverse order.
 	log.Info("Deleting objects from the source cluster")
+	if err := o.journal.startDeletion(); err != nil {
+		return err
+	}
 	for groupIndex := len(moveSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
 		if err := o.deleteGroup(ctx, moveSequence.getGroup(groupIndex)); err != nil {
 			return err
 		}
 	}
 
+	// Resume the ClusterClasses which have not been deleted from the source management cluster, e.g. because they are
+	// still used by Clusters not being moved, so the controllers start reconciling them again.
+	log.V(1).Info("Resuming the source ClusterClasses not deleted")
+	sourceClusterClasses := []*node{}
+	for _, clusterClass := range clusterClasses {
+		if clusterClass.shouldNotDelete {
+			sourceClusterClasses = append(sourceClusterClasses, clusterClass)
+		}
+	}
+	if err := setClusterClassPause(ctx, o.fromProxy, sourceClusterClasses, false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming ClusterClasses")
+	}
+
 	// Resume the ClusterClasses in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target ClusterClasses")
 	if err := setClusterClassPause(ctx, toProxy, clusterClasses, false, o.dryRun, mutators...); err != nil {
@@ -387,10 +579,15 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...)
+	if err := setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...); err != nil {
+		return err
+	}
+
+	// The move is completed, so the journal is not required anymore.
+	return o.journal.remove()
 }
 
-func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, directory string) error {
+func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, directory string, options ArchiveOptions) error {
 	log := logf.Log
 
 	clusters := graph.getClusters()
@@ -417,13 +614,17 @@ func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, direc
 	// - then all the MachineSets, then all the Machines, etc.
 	moveSequence := getMoveSequence(graph)
 
-	// Save all objects group by group
-	log.Info(fmt.Sprintf("Saving files to %s", directory))
+	// Save all objects group by group into an archive, and then write the archive to the directory.
+	log.Info(fmt.Sprintf("Saving archive to %s", directory))
+	archive := newMoveArchive()
 	for groupIndex := range len(moveSequence.groups) {
-		if err := o.backupGroup(ctx, moveSequence.getGroup(groupIndex), directory); err != nil {
+		if err := o.backupGroup(ctx, moveSequence.getGroup(groupIndex), archive); err != nil {
 			return err
 		}
 	}
+	if err := archive.write(directory, options); err != nil {
+		return err
+	}
 
 	// Resume the ClusterClasses in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target ClusterClasses")
@@ -884,7 +1085,7 @@ func (o *objectMover) createGroup(ctx context.Context, group moveGroup, toProxy
 	return nil
 }
 
-func (o *objectMover) backupGroup(ctx context.Context, group moveGroup, directory string) error {
+func (o *objectMover) backupGroup(ctx context.Context, group moveGroup, archive *moveArchive) error {
 	backupTargetObjectBackoff := newWriteBackoff()
 	errList := []error{}
 
@@ -892,7 +1093,7 @@ func (o *objectMover) backupGroup(ctx context.Context, group moveGroup, director
 		// Backs-up the Kubernetes object corresponding to the nodeToBackup.
 		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
 		err := retryWithExponentialBackoff(ctx, backupTargetObjectBackoff, func(ctx context.Context) error {
-			return o.backupTargetObject(ctx, nodeToBackup, directory)
+			return o.backupTargetObject(ctx, nodeToBackup, archive)
 		})
 		if err != nil {
 			errList = append(errList, err)
@@ -987,7 +1188,9 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 		existingNamespaces.Insert(obj.GetNamespace())
 	}
 	oldManagedFields := obj.GetManagedFields()
+	created := true
 	if err := cTo.Create(ctx, obj); err != nil {
+		created = false
 		if !apierrors.IsAlreadyExists(err) {
 			return errors.Wrapf(err, "error creating %q %s/%s",
 				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
@@ -1020,6 +1223,7 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 
 	// Stores the newUID assigned to the newly created object.
 	nodeToCreate.newUID = obj.GetUID()
+	o.journal.recordObject(nodeToCreate, obj, created)
 
 	if err := patchTopologyManagedFields(ctx, oldManagedFields, obj, cTo); err != nil {
 		return errors.Wrap(err, "error patching the managed fields")
@@ -1028,7 +1232,7 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 	return nil
 }
 
-func (o *objectMover) backupTargetObject(ctx context.Context, nodeToCreate *node, directory string) error {
+func (o *objectMover) backupTargetObject(ctx context.Context, nodeToCreate *node, archive *moveArchive) error {
 	log := logf.Log
 	log.V(1).Info("Saving", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
 
@@ -1051,31 +1255,13 @@ func (o *objectMover) backupTargetObject(ctx context.Context, nodeToCreate *node
 			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
-	// Get JSON for object and write it into the configured directory
+	// Get JSON for object and add it to the archive
 	byObj, err := obj.MarshalJSON()
 	if err != nil {
 		return err
 	}
 
-	filenameObj := nodeToCreate.getFilename()
-	objectFile := filepath.Join(directory, filenameObj)
-
-	// If file exists, then remove it to be written again
-	_, err = os.Stat(objectFile)
-	if err != nil && !os.IsNotExist(err) {
-		return err
-	}
-	if err == nil {
-		if err := os.Remove(objectFile); err != nil {
-			return err
-		}
-	}
-
-	err = os.WriteFile(objectFile, byObj, 0600)
-	if err != nil {
-		return err
-	}
-
+	archive.add(nodeToCreate.getFilename(), byObj)
 	return nil
 }
 
@@ -1244,6 +1430,60 @@ func (o *objectMover) deleteSourceObject(ctx context.Context, nodeToDelete *node
 	return nil
 }
 
+// deleteTargetObject deletes an object created by move from the target management cluster, taking care of removing all the finalizers so
+// the objects gets immediately deleted (force delete).
+// Note: the object is deleted only if it has the UID recorded in the journal, so objects created after move are preserved.
+func deleteTargetObject(ctx context.Context, toProxy Proxy, objectToDelete moveJournalObject) error {
+	log := logf.Log
+	log.V(1).Info("Deleting", objectToDelete.Kind, objectToDelete.Name, "Namespace", objectToDelete.Namespace)
+
+	cTo, err := toProxy.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	targetObj := &unstructured.Unstructured{}
+	targetObj.SetAPIVersion(objectToDelete.APIVersion)
+	targetObj.SetKind(objectToDelete.Kind)
+	targetObjKey := client.ObjectKey{
+		Namespace: objectToDelete.Namespace,
+		Name:      objectToDelete.Name,
+	}
+
+	if err := cTo.Get(ctx, targetObjKey, targetObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			// If the object is already deleted, move on.
+			log.V(5).Info("Object already deleted, skipping delete for", objectToDelete.Kind, objectToDelete.Name, "Namespace", objectToDelete.Namespace)
+			return nil
+		}
+		return errors.Wrapf(err, "error reading %q %s/%s",
+			targetObj.GroupVersionKind(), targetObj.GetNamespace(), targetObj.GetName())
+	}
+
+	if targetObj.GetUID() != objectToDelete.TargetUID {
+		log.V(5).Info("Object has been re-created after move, skipping delete for", objectToDelete.Kind, objectToDelete.Name, "Namespace", objectToDelete.Namespace)
+		return nil
+	}
+
+	if err := cTo.Patch(ctx, targetObj, addDeleteForMoveAnnotationPatch); err != nil {
+		return errors.Wrapf(err, "error adding delete-for-move annotation to %q %s/%s",
+			targetObj.GroupVersionKind(), targetObj.GetNamespace(), targetObj.GetName())
+	}
+
+	if err := cTo.Delete(ctx, targetObj, client.Preconditions{UID: &objectToDelete.TargetUID}); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error deleting %q %s/%s",
+			targetObj.GroupVersionKind(), targetObj.GetNamespace(), targetObj.GetName())
+	}
+
+	if len(targetObj.GetFinalizers()) > 0 {
+		if err := cTo.Patch(ctx, targetObj, removeFinalizersPatch); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error removing finalizers from %q %s/%s",
+				targetObj.GroupVersionKind(), targetObj.GetNamespace(), targetObj.GetName())
+		}
+	}
+	return nil
+}
+
 // checkTargetProviders checks that all the providers installed in the source cluster exists in the target cluster as well (with a version >= of the current version).
 func (o *objectMover) checkTargetProviders(ctx context.Context, toInventory InventoryClient) error {
 	if o.dryRun {
@@ -0,0 +1,389 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// moveArchiveFileName is the name of the archive written by move in the target directory.
+	moveArchiveFileName = "clusterctl-move.tar.gz"
+
+	// moveEncryptedArchiveFileName is the name of the age encrypted archive written by move in the target directory.
+	moveEncryptedArchiveFileName = moveArchiveFileName + ".age"
+
+	// moveArchiveManifestFileName is the name of the manifest in the archive.
+	moveArchiveManifestFileName = "manifest.json"
+
+	// moveArchiveVersion is the version of the archive format.
+	moveArchiveVersion = "v1"
+)
+
+// scrypt work factors (log2 of the scrypt N parameter) used for deriving a key from a passphrase.
+// The work factor is recorded in the encrypted archive, and archives with a work factor outside of the bounds are refused,
+// so a crafted archive can't force unbounded memory or CPU usage when decrypting.
+// NOTE: those are variables so they can be lowered in unit tests.
+var (
+	scryptWorkFactor    = 18
+	scryptMinWorkFactor = 15
+	scryptMaxWorkFactor = 22
+)
+
+// ArchiveOptions defines the options for encrypting and decrypting the archive written by move to a directory.
+// If no option is set, the archive is not encrypted.
+type ArchiveOptions struct {
+	// PublicKey contains one or more age recipients, one per line; if set, the archive is encrypted so it can be decrypted
+	// with the corresponding identities.
+	PublicKey []byte
+
+	// PrivateKey contains one or more age identities, one per line, used to decrypt the archive.
+	PrivateKey []byte
+
+	// Passphrase, if set, is used to encrypt the archive, or to decrypt it.
+	// NOTE: An archive can't be encrypted both with PublicKey and Passphrase.
+	Passphrase []byte
+
+	// AllowUnverifiedFiles allows to read directories without an archive, e.g. written by older versions of clusterctl;
+	// in this case objects are read without being verified.
+	AllowUnverifiedFiles bool
+}
+
+func (a ArchiveOptions) encrypt() bool {
+	return len(a.PublicKey) > 0 || len(a.Passphrase) > 0
+}
+
+func (a ArchiveOptions) decrypt() bool {
+	return len(a.PrivateKey) > 0 || len(a.Passphrase) > 0
+}
+
+// moveArchiveManifest lists the files in the archive together with their hash, so it is possible
+// to detect tampered or incomplete archives.
+type moveArchiveManifest struct {
+	// Version of the archive format.
+	Version string `json:"version"`
+
+	// Files in the archive, sorted by name.
+	Files []moveArchiveManifestFile `json:"files"`
+}
+
+// moveArchiveManifestFile describes a file in the archive.
+type moveArchiveManifestFile struct {
+	// Name of the file.
+	Name string `json:"name"`
+
+	// SHA256 is the hex encoded SHA-256 hash of the file content.
+	SHA256 string `json:"sha256"`
+}
+
+// moveArchive collects the files to be written in the archive.
+type moveArchive struct {
+	files map[string][]byte
+}
+
+func newMoveArchive() *moveArchive {
+	return &moveArchive{files: map[string][]byte{}}
+}
+
+// add adds a file to the archive, replacing a file with the same name if any.
+func (a *moveArchive) add(name string, data []byte) {
+	a.files[name] = data
+}
+
+// write writes the archive in the directory, encrypting it if required.
+func (a *moveArchive) write(directory string, options ArchiveOptions) error {
+	names := make([]string, 0, len(a.files))
+	for name := range a.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest := moveArchiveManifest{Version: moveArchiveVersion}
+	for _, name := range names {
+		manifest.Files = append(manifest.Files, moveArchiveManifestFile{Name: name, SHA256: sha256Hex(a.files[name])})
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal archive manifest")
+	}
+
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	writeFile := func(name string, data []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(data)
+		return err
+	}
+	if err := writeFile(moveArchiveManifestFileName, manifestData); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	for _, name := range names {
+		if err := writeFile(name, a.files[name]); err != nil {
+			return errors.Wrap(err, "failed to write archive")
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+
+	data := buf.Bytes()
+	fileName, staleFileName := moveArchiveFileName, moveEncryptedArchiveFileName
+	if options.encrypt() {
+		if data, err = encryptArchive(data, options); err != nil {
+			return err
+		}
+		fileName, staleFileName = moveEncryptedArchiveFileName, moveArchiveFileName
+	}
+
+	// Remove the archive written by a previous move to the same directory, if any, so it can't be restored by mistake.
+	if err := os.Remove(filepath.Join(directory, staleFileName)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove archive %s", staleFileName)
+	}
+
+	// Write to a temporary file first, so the archive is never left partially written.
+	path := filepath.Join(directory, fileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write archive %s", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(err, "failed to write archive %s", path)
+	}
+	return nil
+}
+
+// hasMoveArchive returns true if the directory contains an archive written by move.
+func hasMoveArchive(directory string) bool {
+	for _, fileName := range []string{moveArchiveFileName, moveEncryptedArchiveFileName} {
+		if _, err := os.Stat(filepath.Join(directory, fileName)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// readMoveArchive reads the archive written by move in the directory, decrypting it if required, and returns the files
+// in the archive; an error is returned if the archive does not match its manifest, i.e. if it has been tampered or is incomplete.
+// NOTE: the content of the archive must be considered as authenticated only if the archive is encrypted.
+func readMoveArchive(directory string, options ArchiveOptions) (map[string][]byte, error) {
+	encryptedPath := filepath.Join(directory, moveEncryptedArchiveFileName)
+	plainPath := filepath.Join(directory, moveArchiveFileName)
+
+	var data []byte
+	var err error
+	switch {
+	case options.decrypt():
+		if data, err = os.ReadFile(encryptedPath); err != nil {
+			if os.IsNotExist(err) {
+				return nil, errors.Errorf("encrypted archive %s not found", encryptedPath)
+			}
+			return nil, errors.Wrapf(err, "failed to read archive %s", encryptedPath)
+		}
+		if data, err = decryptArchive(data, options); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := os.Stat(encryptedPath); err == nil {
+			return nil, errors.Errorf("archive %s is encrypted, a private key or a passphrase is required for decrypting it", encryptedPath)
+		}
+		if data, err = os.ReadFile(plainPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to read archive %s", plainPath)
+		}
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read archive")
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	files := map[string][]byte{}
+	var manifestData []byte
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read archive")
+		}
+		if header.Typeflag != tar.TypeReg || header.Name != filepath.Base(header.Name) || strings.HasPrefix(header.Name, ".") {
+			return nil, errors.Errorf("invalid file %q in archive", header.Name)
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read file %q from archive", header.Name)
+		}
+		if header.Name == moveArchiveManifestFileName {
+			manifestData = content
+			continue
+		}
+		if _, ok := files[header.Name]; ok {
+			return nil, errors.Errorf("duplicated file %q in archive", header.Name)
+		}
+		files[header.Name] = content
+	}
+
+	if manifestData == nil {
+		return nil, errors.New("manifest not found in archive")
+	}
+	manifest := &moveArchiveManifest{}
+	if err := json.Unmarshal(manifestData, manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to parse archive manifest")
+	}
+	if manifest.Version != moveArchiveVersion {
+		return nil, errors.Errorf("unsupported archive version %q", manifest.Version)
+	}
+
+	// Verify all the files in the manifest are in the archive with the expected hash, and that there are no other files.
+	listed := sets.Set[string]{}
+	for _, f := range manifest.Files {
+		content, ok := files[f.Name]
+		if !ok {
+			return nil, errors.Errorf("file %q listed in the archive manifest not found, the archive is incomplete", f.Name)
+		}
+		if sha256Hex(content) != f.SHA256 {
+			return nil, errors.Errorf("hash of file %q does not match the archive manifest, the archive has been tampered", f.Name)
+		}
+		listed.Insert(f.Name)
+	}
+	for name := range files {
+		if !listed.Has(name) {
+			return nil, errors.Errorf("file %q not listed in the archive manifest, the archive has been tampered", name)
+		}
+	}
+	return files, nil
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// encryptArchive encrypts the archive with age (https://age-encryption.org), either for the age recipients in the public key
+// or for the passphrase.
+func encryptArchive(data []byte, options ArchiveOptions) ([]byte, error) {
+	var recipients []age.Recipient
+	switch {
+	case len(options.PublicKey) > 0 && len(options.Passphrase) > 0:
+		return nil, errors.New("archive can't be encrypted both with a public key and a passphrase")
+	case len(options.PublicKey) > 0:
+		var err error
+		if recipients, err = age.ParseRecipients(bytes.NewReader(options.PublicKey)); err != nil {
+			return nil, errors.Wrap(err, "failed to parse public key")
+		}
+	default:
+		recipient, err := age.NewScryptRecipient(string(options.Passphrase))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to use passphrase")
+		}
+		recipient.SetWorkFactor(scryptWorkFactor)
+		recipients = append(recipients, recipient)
+	}
+
+	buf := &bytes.Buffer{}
+	writer, err := age.Encrypt(buf, recipients...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt archive")
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt archive")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt archive")
+	}
+	return buf.Bytes(), nil
+}
+
+// decryptArchive decrypts an archive encrypted by encryptArchive, using the age identities in the private key or the passphrase.
+func decryptArchive(data []byte, options ArchiveOptions) ([]byte, error) {
+	var identities []age.Identity
+	var noMatchErr error
+	switch {
+	case len(options.PrivateKey) > 0:
+		var err error
+		if identities, err = age.ParseIdentities(bytes.NewReader(options.PrivateKey)); err != nil {
+			return nil, errors.Wrap(err, "failed to parse private key")
+		}
+		noMatchErr = errors.New("failed to decrypt archive, the private key does not match the public key used for encrypting it")
+	default:
+		identity, err := age.NewScryptIdentity(string(options.Passphrase))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to use passphrase")
+		}
+		identity.SetMaxWorkFactor(scryptMaxWorkFactor)
+		identities = append(identities, &boundedScryptIdentity{identity: identity})
+		noMatchErr = errors.New("failed to decrypt archive, wrong passphrase or archive not encrypted with a passphrase")
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, noMatchErr
+		}
+		return nil, errors.Wrap(err, "invalid encrypted archive")
+	}
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New("failed to decrypt archive, the archive has been tampered")
+	}
+	return plain, nil
+}
+
+// boundedScryptIdentity is an age scrypt identity which refuses work factors lower than scryptMinWorkFactor;
+// work factors higher than scryptMaxWorkFactor are refused by the age scrypt identity itself.
+type boundedScryptIdentity struct {
+	identity *age.ScryptIdentity
+}
+
+func (i *boundedScryptIdentity) Unwrap(stanzas []*age.Stanza) ([]byte, error) {
+	for _, s := range stanzas {
+		if s.Type != "scrypt" || len(s.Args) != 2 {
+			continue
+		}
+		logN, err := strconv.Atoi(s.Args[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid scrypt work factor")
+		}
+		if logN < scryptMinWorkFactor {
+			return nil, errors.Errorf("scrypt work factor %d is lower than the minimum %d", logN, scryptMinWorkFactor)
+		}
+	}
+	return i.identity.Unwrap(stanzas)
+}
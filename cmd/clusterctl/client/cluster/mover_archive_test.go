@@ -0,0 +1,316 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_moveArchive(t *testing.T) {
+	// Lower the cost of deriving keys from passphrases.
+	defer func(workFactor, minWorkFactor int) {
+		scryptWorkFactor, scryptMinWorkFactor = workFactor, minWorkFactor
+	}(scryptWorkFactor, scryptMinWorkFactor)
+	scryptWorkFactor, scryptMinWorkFactor = 10, 10
+
+	files := map[string][]byte{
+		"Cluster_ns1_cluster1.yaml": []byte(`{"kind":"Cluster"}`),
+		"Secret_ns1_cluster1-ca":    []byte(`{"kind":"Secret"}`),
+	}
+	newArchive := func() *moveArchive {
+		archive := newMoveArchive()
+		for name, data := range files {
+			archive.add(name, data)
+		}
+		return archive
+	}
+
+	publicKey, privateKey := generateAgeKeys(t)
+	_, otherPrivateKey := generateAgeKeys(t)
+
+	t.Run("plain archive", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(newArchive().write(dir, ArchiveOptions{})).To(Succeed())
+		g.Expect(hasMoveArchive(dir)).To(BeTrue())
+		g.Expect(filepath.Join(dir, moveArchiveFileName)).To(BeAnExistingFile())
+
+		got, err := readMoveArchive(dir, ArchiveOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(Equal(files))
+
+		// A plain archive can't be read when expecting an encrypted one.
+		_, err = readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("secret")})
+		g.Expect(err).To(MatchError(ContainSubstring("encrypted archive")))
+	})
+
+	t.Run("archive encrypted with a passphrase", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(newArchive().write(dir, ArchiveOptions{Passphrase: []byte("secret")})).To(Succeed())
+		g.Expect(filepath.Join(dir, moveEncryptedArchiveFileName)).To(BeAnExistingFile())
+		g.Expect(filepath.Join(dir, moveArchiveFileName)).ToNot(BeAnExistingFile())
+
+		got, err := readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("secret")})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(Equal(files))
+
+		_, err = readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("wrong")})
+		g.Expect(err).To(MatchError(ContainSubstring("wrong passphrase")))
+
+		_, err = readMoveArchive(dir, ArchiveOptions{})
+		g.Expect(err).To(MatchError(ContainSubstring("is encrypted")))
+
+		_, err = readMoveArchive(dir, ArchiveOptions{PrivateKey: privateKey})
+		g.Expect(err).To(MatchError(ContainSubstring("private key does not match")))
+	})
+
+	t.Run("archive encrypted with a public key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(newArchive().write(dir, ArchiveOptions{PublicKey: publicKey})).To(Succeed())
+
+		got, err := readMoveArchive(dir, ArchiveOptions{PrivateKey: privateKey})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(Equal(files))
+
+		_, err = readMoveArchive(dir, ArchiveOptions{PrivateKey: otherPrivateKey})
+		g.Expect(err).To(MatchError(ContainSubstring("private key does not match")))
+
+		_, err = readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("secret")})
+		g.Expect(err).To(MatchError(ContainSubstring("archive not encrypted with a passphrase")))
+	})
+
+	t.Run("archive can't be encrypted both with a public key and a passphrase", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(newArchive().write(dir, ArchiveOptions{PublicKey: publicKey, Passphrase: []byte("secret")})).
+			To(MatchError(ContainSubstring("both with a public key and a passphrase")))
+	})
+
+	t.Run("archive encrypted with a scrypt work factor out of bounds", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(newArchive().write(dir, ArchiveOptions{Passphrase: []byte("secret")})).To(Succeed())
+
+		scryptMinWorkFactor = 11
+		defer func() { scryptMinWorkFactor = 10 }()
+		_, err := readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("secret")})
+		g.Expect(err).To(MatchError(ContainSubstring("lower than the minimum")))
+
+		// The work factor is checked before deriving the key, so a crafted archive can't force unbounded memory or CPU usage.
+		scryptMinWorkFactor = 10
+		path := filepath.Join(dir, moveEncryptedArchiveFileName)
+		data, err := os.ReadFile(path) //nolint:gosec // No security issue: unit test.
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(bytes.Count(data, []byte(" 10\n"))).To(Equal(1))
+		data = bytes.Replace(data, []byte(" 10\n"), []byte(" 40\n"), 1)
+		g.Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+		_, err = readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("secret")})
+		g.Expect(err).To(MatchError(ContainSubstring("scrypt work factor too large")))
+	})
+
+	t.Run("corrupted encrypted archive", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(os.WriteFile(filepath.Join(dir, moveEncryptedArchiveFileName), []byte("age-encryption.org/v1\n-> scrypt\n"), 0600)).To(Succeed())
+
+		_, err := readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("secret")})
+		g.Expect(err).To(MatchError(ContainSubstring("invalid encrypted archive")))
+	})
+
+	t.Run("tampered encrypted archive", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(newArchive().write(dir, ArchiveOptions{Passphrase: []byte("secret")})).To(Succeed())
+
+		path := filepath.Join(dir, moveEncryptedArchiveFileName)
+		data, err := os.ReadFile(path) //nolint:gosec // No security issue: unit test.
+		g.Expect(err).ToNot(HaveOccurred())
+		data[len(data)-1] ^= 0xff
+		g.Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+
+		_, err = readMoveArchive(dir, ArchiveOptions{Passphrase: []byte("secret")})
+		g.Expect(err).To(MatchError(ContainSubstring("has been tampered")))
+	})
+
+	t.Run("tampered file", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeTestArchive(t, dir, manifestFor(files), map[string][]byte{
+			"Cluster_ns1_cluster1.yaml": []byte(`{"kind":"Cluster","spec":{}}`),
+			"Secret_ns1_cluster1-ca":    files["Secret_ns1_cluster1-ca"],
+		})
+
+		_, err := readMoveArchive(dir, ArchiveOptions{})
+		g.Expect(err).To(MatchError(ContainSubstring("does not match the archive manifest")))
+	})
+
+	t.Run("incomplete archive", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeTestArchive(t, dir, manifestFor(files), map[string][]byte{
+			"Cluster_ns1_cluster1.yaml": files["Cluster_ns1_cluster1.yaml"],
+		})
+
+		_, err := readMoveArchive(dir, ArchiveOptions{})
+		g.Expect(err).To(MatchError(ContainSubstring("archive is incomplete")))
+	})
+
+	t.Run("file not listed in the manifest", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeTestArchive(t, dir, manifestFor(files), map[string][]byte{
+			"Cluster_ns1_cluster1.yaml": files["Cluster_ns1_cluster1.yaml"],
+			"Secret_ns1_cluster1-ca":    files["Secret_ns1_cluster1-ca"],
+			"Secret_ns1_other":          []byte(`{"kind":"Secret"}`),
+		})
+
+		_, err := readMoveArchive(dir, ArchiveOptions{})
+		g.Expect(err).To(MatchError(ContainSubstring("not listed in the archive manifest")))
+	})
+
+	t.Run("file outside of the archive", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeTestArchive(t, dir, manifestFor(files), map[string][]byte{
+			"../Cluster_ns1_cluster1.yaml": files["Cluster_ns1_cluster1.yaml"],
+		})
+
+		_, err := readMoveArchive(dir, ArchiveOptions{})
+		g.Expect(err).To(MatchError(ContainSubstring("invalid file")))
+	})
+}
+
+func Test_objectMover_toDirectory_encrypted(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	objs := test.NewFakeCluster("ns1", "cluster1").Objs()
+	graph := getObjectGraphWithObjs(objs)
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+	publicKey, privateKey := generateAgeKeys(t)
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	dir := t.TempDir()
+	g.Expect(mover.toDirectory(ctx, graph, dir, ArchiveOptions{PublicKey: publicKey})).To(Succeed())
+
+	// Kubeconfig and CA secrets are not written in plain text.
+	entries, err := os.ReadDir(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+	g.Expect(entries[0].Name()).To(Equal(moveEncryptedArchiveFileName))
+
+	restored, err := mover.archiveToObjs(dir, ArchiveOptions{PrivateKey: privateKey})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restored).To(HaveLen(len(graph.getMoveNodes())))
+}
+
+func Test_objectMover_FromDirectory_unverifiedFiles(t *testing.T) {
+	g := NewWithT(t)
+
+	// Directories written by older versions of clusterctl contain one file for each object, without an archive.
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "Cluster_ns1_cluster1.yaml"), []byte(`{"kind":"Cluster"}`), 0600)).To(Succeed())
+
+	mover := objectMover{
+		fromProxy: getFakeProxyWithCRDs(),
+	}
+	err := mover.FromDirectory(context.Background(), nil, dir, ArchiveOptions{})
+	g.Expect(err).To(MatchError(ContainSubstring("can be restored only if explicitly allowing unverified files")))
+}
+
+func generateAgeKeys(t *testing.T) (publicKey []byte, privateKey []byte) {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []byte(identity.Recipient().String() + "\n"), []byte("# created: test\n" + identity.String() + "\n")
+}
+
+func manifestFor(files map[string][]byte) []byte {
+	archive := newMoveArchive()
+	for name, data := range files {
+		archive.add(name, data)
+	}
+	manifest := moveArchiveManifest{Version: moveArchiveVersion}
+	for name, data := range archive.files {
+		manifest.Files = append(manifest.Files, moveArchiveManifestFile{Name: name, SHA256: sha256Hex(data)})
+	}
+	data, _ := json.Marshal(manifest)
+	return data
+}
+
+// writeTestArchive writes a plain archive with the given manifest and files, without checking they are consistent.
+func writeTestArchive(t *testing.T, dir string, manifest []byte, files map[string][]byte) {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	write := func(name string, data []byte) {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(moveArchiveManifestFileName, manifest)
+	for name, data := range files {
+		write(name, data)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, moveArchiveFileName), buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// moveJournalFileName is the name of the file where move records its progress in the journal directory.
+const moveJournalFileName = "clusterctl-move-journal.yaml"
+
+// moveJournal records the progress of a move operation, so an interrupted move can be resumed or rolled back.
+// Note: all the methods of moveJournal are no-op if the journal is nil, i.e. if journaling is not enabled.
+type moveJournal struct {
+	// path of the journal file.
+	path string
+
+	// Namespace is the namespace of the objects being moved.
+	Namespace string `json:"namespace"`
+
+	// ClusterName and ClusterSelector are the filters restricting the move to a subset of the Clusters, if any.
+	ClusterName     string `json:"clusterName,omitempty"`
+	ClusterSelector string `json:"clusterSelector,omitempty"`
+
+	// Deleting is true if move started to delete objects from the source cluster; after this point, the move
+	// can only be resumed and not rolled back anymore.
+	Deleting bool `json:"deleting,omitempty"`
+
+	// Objects are the objects created or updated in the target cluster, in the order they have been processed.
+	Objects []moveJournalObject `json:"objects,omitempty"`
+}
+
+// moveJournalObject records an object processed by move in the target cluster.
+type moveJournalObject struct {
+	// SourceUID is the UID of the object in the source cluster.
+	SourceUID types.UID `json:"sourceUID"`
+
+	// APIVersion, Kind, Namespace and Name identify the object in the target cluster.
+	// Note: Namespace and Name can differ from the source object if mutators are used.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+
+	// TargetUID is the UID of the object in the target cluster.
+	TargetUID types.UID `json:"targetUID"`
+
+	// Created is true if the object has been created by move; objects existing already in the target cluster,
+	// e.g. global objects, are not deleted on rollback.
+	Created bool `json:"created,omitempty"`
+
+	// Completed is true if all the objects in the same move group have been processed successfully.
+	Completed bool `json:"completed,omitempty"`
+}
+
+// loadMoveJournal reads the journal from the journal directory, or returns a new journal if the directory does not
+// contain a journal yet.
+func loadMoveJournal(directory, namespace string) (*moveJournal, error) {
+	journal := &moveJournal{
+		path:      filepath.Join(directory, moveJournalFileName),
+		Namespace: namespace,
+	}
+
+	data, err := os.ReadFile(journal.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return journal, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read move journal %s", journal.path)
+	}
+	if err := yaml.Unmarshal(data, journal); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse move journal %s", journal.path)
+	}
+	if journal.Namespace != namespace {
+		return nil, errors.Errorf("move journal %s has been recorded for namespace %q, not for namespace %q", journal.path, journal.Namespace, namespace)
+	}
+	return journal, nil
+}
+
+// exists returns true if the journal has already been saved in the journal directory.
+func (j *moveJournal) exists() bool {
+	if j == nil {
+		return false
+	}
+	_, err := os.Stat(j.path)
+	return err == nil
+}
+
+// save writes the journal in the journal directory.
+// Note: the journal is written to a temporary file first, so the journal is never left partially written.
+func (j *moveJournal) save() error {
+	if j == nil {
+		return nil
+	}
+	data, err := yaml.Marshal(j)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal move journal")
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write move journal %s", tmp)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return errors.Wrapf(err, "failed to write move journal %s", j.path)
+	}
+	return nil
+}
+
+// remove deletes the journal from the journal directory.
+func (j *moveJournal) remove() error {
+	if j == nil {
+		return nil
+	}
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove move journal %s", j.path)
+	}
+	return nil
+}
+
+// recordObject records an object processed in the target cluster.
+func (j *moveJournal) recordObject(n *node, obj *unstructured.Unstructured, created bool) {
+	if j == nil {
+		return
+	}
+	o := moveJournalObject{
+		SourceUID:  n.identity.UID,
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		TargetUID:  obj.GetUID(),
+		Created:    created,
+	}
+	for i := range j.Objects {
+		if j.Objects[i].SourceUID == o.SourceUID {
+			// Preserve Created if the object has been created by a previous run of move.
+			o.Created = o.Created || j.Objects[i].Created
+			j.Objects[i] = o
+			return
+		}
+	}
+	j.Objects = append(j.Objects, o)
+}
+
+// completeGroup marks all the objects in a group as completed and saves the journal.
+func (j *moveJournal) completeGroup(group moveGroup) error {
+	if j == nil {
+		return nil
+	}
+	for _, n := range group {
+		if o := j.getObject(n.identity.UID); o != nil {
+			o.Completed = true
+		}
+	}
+	return j.save()
+}
+
+// restoreGroup returns true if all the objects in a group have been already moved by a previous run of move;
+// in this case the newUID of the nodes is restored from the journal, so the owner references of the objects
+// in the following groups can be rebuilt.
+func (j *moveJournal) restoreGroup(group moveGroup) bool {
+	if j == nil {
+		return false
+	}
+	for _, n := range group {
+		if o := j.getObject(n.identity.UID); o == nil || !o.Completed {
+			return false
+		}
+	}
+	for _, n := range group {
+		n.newUID = j.getObject(n.identity.UID).TargetUID
+	}
+	return true
+}
+
+// startDeletion records that move is starting to delete objects from the source cluster and saves the journal.
+func (j *moveJournal) startDeletion() error {
+	if j == nil {
+		return nil
+	}
+	j.Deleting = true
+	return j.save()
+}
+
+func (j *moveJournal) getObject(sourceUID types.UID) *moveJournalObject {
+	for i := range j.Objects {
+		if j.Objects[i].SourceUID == sourceUID {
+			return &j.Objects[i]
+		}
+	}
+	return nil
+}
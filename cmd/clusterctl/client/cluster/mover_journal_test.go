@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_moveJournal(t *testing.T) {
+	newNode := func(uid string) *node {
+		return &node{identity: corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: uid, UID: types.UID(uid)}}
+	}
+	newTargetObj := func(n *node, uid string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(n.identity.APIVersion)
+		obj.SetKind(n.identity.Kind)
+		obj.SetNamespace("target-ns")
+		obj.SetName(n.identity.Name)
+		obj.SetUID(types.UID(uid))
+		return obj
+	}
+
+	t.Run("journal is saved and loaded", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		journal, err := loadMoveJournal(dir, "ns1")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(journal.exists()).To(BeFalse())
+
+		a, b := newNode("a"), newNode("b")
+		journal.recordObject(a, newTargetObj(a, "target-a"), true)
+		journal.recordObject(b, newTargetObj(b, "target-b"), false)
+		g.Expect(journal.completeGroup(moveGroup{a, b})).To(Succeed())
+		g.Expect(journal.exists()).To(BeTrue())
+
+		loaded, err := loadMoveJournal(dir, "ns1")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(loaded.Objects).To(Equal([]moveJournalObject{
+			{SourceUID: "a", APIVersion: "v1", Kind: "Secret", Namespace: "target-ns", Name: "a", TargetUID: "target-a", Created: true, Completed: true},
+			{SourceUID: "b", APIVersion: "v1", Kind: "Secret", Namespace: "target-ns", Name: "b", TargetUID: "target-b", Completed: true},
+		}))
+
+		g.Expect(loaded.remove()).To(Succeed())
+		g.Expect(loaded.exists()).To(BeFalse())
+	})
+
+	t.Run("journal for another namespace is rejected", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		journal, err := loadMoveJournal(dir, "ns1")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(journal.save()).To(Succeed())
+
+		_, err = loadMoveJournal(dir, "ns2")
+		g.Expect(err).To(MatchError(ContainSubstring(`has been recorded for namespace "ns1"`)))
+	})
+
+	t.Run("objects created by a previous run are still recorded as created", func(t *testing.T) {
+		g := NewWithT(t)
+
+		journal, err := loadMoveJournal(t.TempDir(), "ns1")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		a := newNode("a")
+		journal.recordObject(a, newTargetObj(a, "target-a"), true)
+		journal.recordObject(a, newTargetObj(a, "target-a"), false)
+		g.Expect(journal.Objects).To(HaveLen(1))
+		g.Expect(journal.Objects[0].Created).To(BeTrue())
+	})
+
+	t.Run("only completed groups are restored", func(t *testing.T) {
+		g := NewWithT(t)
+
+		journal, err := loadMoveJournal(t.TempDir(), "ns1")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		a, b, c := newNode("a"), newNode("b"), newNode("c")
+		journal.recordObject(a, newTargetObj(a, "target-a"), true)
+		g.Expect(journal.completeGroup(moveGroup{a})).To(Succeed())
+		journal.recordObject(b, newTargetObj(b, "target-b"), true)
+
+		g.Expect(journal.restoreGroup(moveGroup{a})).To(BeTrue())
+		g.Expect(a.newUID).To(Equal(types.UID("target-a")))
+		g.Expect(journal.restoreGroup(moveGroup{b})).To(BeFalse())
+		g.Expect(journal.restoreGroup(moveGroup{a, c})).To(BeFalse())
+		g.Expect(c.newUID).To(BeEmpty())
+	})
+
+	t.Run("a nil journal is a no-op", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var journal *moveJournal
+		a := newNode("a")
+		journal.recordObject(a, newTargetObj(a, "target-a"), true)
+		g.Expect(journal.completeGroup(moveGroup{a})).To(Succeed())
+		g.Expect(journal.restoreGroup(moveGroup{a})).To(BeFalse())
+		g.Expect(journal.startDeletion()).To(Succeed())
+		g.Expect(journal.remove()).To(Succeed())
+		g.Expect(journal.exists()).To(BeFalse())
+	})
+}
@@ -18,7 +18,6 @@ package cluster
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -753,10 +752,10 @@ func Test_objectMover_backupTargetObject(t *testing.T) {
 				fromProxy: graph.proxy,
 			}
 
-			dir := t.TempDir()
+			archive := newMoveArchive()
 
 			for _, node := range graph.uidToNode {
-				err := mover.backupTargetObject(ctx, node, dir)
+				err := mover.backupTargetObject(ctx, node, archive)
 				if tt.wantErr {
 					g.Expect(err).To(HaveOccurred())
 					return
@@ -764,7 +763,7 @@ func Test_objectMover_backupTargetObject(t *testing.T) {
 
 				g.Expect(err).ToNot(HaveOccurred())
 
-				// objects are stored and serialized correctly in the temporary directory
+				// objects are stored and serialized correctly in the archive
 				expectedFilename := node.getFilename()
 				expectedFileContents, ok := tt.files[expectedFilename]
 				if !ok {
@@ -772,41 +771,20 @@ func Test_objectMover_backupTargetObject(t *testing.T) {
 				}
 				expectedFileContents = fixFilesGVS(expectedFileContents)
 
-				path := filepath.Join(dir, expectedFilename)
-				fileContents, err := os.ReadFile(path) //nolint:gosec
-				if err != nil {
-					g.Expect(err).ToNot(HaveOccurred())
-					return
-				}
-
-				firstFileStat, err := os.Stat(path)
-				if err != nil {
-					g.Expect(err).ToNot(HaveOccurred())
-					return
-				}
-
-				fmt.Printf("Actual file content %v\n", string(fileContents))
-				g.Expect(string(fileContents)).To(Equal(expectedFileContents))
-
-				// Add delay so we ensure the file ModTime of updated files is different from old ones in the original files
-				time.Sleep(time.Millisecond * 50)
+				g.Expect(archive.files).To(HaveKey(expectedFilename))
+				g.Expect(string(archive.files[expectedFilename])).To(Equal(expectedFileContents))
+				files := len(archive.files)
 
 				// Running backupTargetObject should override any existing files since it represents a new toDirectory
-				err = mover.backupTargetObject(ctx, node, dir)
+				err = mover.backupTargetObject(ctx, node, archive)
 				if tt.wantErr {
 					g.Expect(err).To(HaveOccurred())
 					return
 				}
 
 				g.Expect(err).ToNot(HaveOccurred())
-
-				secondFileStat, err := os.Stat(path)
-				if err != nil {
-					g.Expect(err).ToNot(HaveOccurred())
-					return
-				}
-
-				g.Expect(firstFileStat.ModTime()).To(BeTemporally("<", secondFileStat.ModTime()))
+				g.Expect(archive.files).To(HaveLen(files))
+				g.Expect(string(archive.files[expectedFilename])).To(Equal(expectedFileContents))
 			}
 		})
 	}
@@ -946,7 +924,7 @@ func Test_objectMover_toDirectory(t *testing.T) {
 
 			dir := t.TempDir()
 
-			err := mover.toDirectory(ctx, graph, dir)
+			err := mover.toDirectory(ctx, graph, dir, ArchiveOptions{})
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -954,6 +932,10 @@ func Test_objectMover_toDirectory(t *testing.T) {
 
 			g.Expect(err).ToNot(HaveOccurred())
 
+			// the archive is written in the temporary directory
+			files, err := readMoveArchive(dir, ArchiveOptions{})
+			g.Expect(err).ToNot(HaveOccurred())
+
 			// check that the objects are stored in the temporary directory but not deleted from the source cluster
 			csFrom, err := graph.proxy.NewClient(ctx)
 			g.Expect(err).ToNot(HaveOccurred())
@@ -973,19 +955,9 @@ func Test_objectMover_toDirectory(t *testing.T) {
 				err := csFrom.Get(ctx, key, oFrom)
 				g.Expect(err).ToNot(HaveOccurred())
 
-				// objects are stored in the temporary directory with the expected filename
-				files, err := os.ReadDir(dir)
-				g.Expect(err).ToNot(HaveOccurred())
-
+				// objects are stored in the archive with the expected filename
 				expectedFilename := node.getFilename()
-				found := false
-				for _, f := range files {
-					if strings.Contains(f.Name(), expectedFilename) {
-						found = true
-					}
-				}
-
-				if !found {
+				if _, ok := files[expectedFilename]; !ok {
 					missingFiles = append(missingFiles, expectedFilename)
 				}
 			}
@@ -2497,3 +2469,218 @@ func Test_applyMutators(t *testing.T) {
 		})
 	}
 }
+
+func Test_objectMover_move_withJournal(t *testing.T) {
+	objs := test.NewFakeCluster("ns1", "cluster1").
+		WithMachines(
+			test.NewFakeMachine("m1"),
+		).Objs()
+
+	t.Run("journal is removed when move completes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		graph := getObjectGraphWithObjs(objs)
+		g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+		g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+		toProxy := getFakeProxyWithCRDs()
+
+		journal, err := loadMoveJournal(t.TempDir(), "")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		mover := objectMover{
+			fromProxy: graph.proxy,
+			journal:   journal,
+		}
+		g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+		g.Expect(journal.exists()).To(BeFalse())
+	})
+
+	t.Run("move resumes from the last group moved successfully", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		graph := getObjectGraphWithObjs(objs)
+		g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+		g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+		toProxy := getFakeProxyWithCRDs()
+
+		csTo, err := toProxy.NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// Record the first group, the Cluster, as already moved by a previous run.
+		journal, err := loadMoveJournal(t.TempDir(), "")
+		g.Expect(err).ToNot(HaveOccurred())
+		firstGroup := getMoveSequence(graph).getGroup(0)
+		g.Expect(firstGroup).To(HaveLen(1))
+		cluster := &unstructured.Unstructured{}
+		cluster.SetAPIVersion(firstGroup[0].identity.APIVersion)
+		cluster.SetKind(firstGroup[0].identity.Kind)
+		cluster.SetNamespace(firstGroup[0].identity.Namespace)
+		cluster.SetName(firstGroup[0].identity.Name)
+		cluster.SetUID("target-uid")
+		g.Expect(csTo.Create(ctx, cluster)).To(Succeed())
+		cluster.SetAnnotations(map[string]string{"moved-by": "previous-run"})
+		g.Expect(csTo.Update(ctx, cluster)).To(Succeed())
+		journal.recordObject(firstGroup[0], cluster, true)
+		g.Expect(journal.completeGroup(firstGroup)).To(Succeed())
+
+		mover := objectMover{
+			fromProxy: graph.proxy,
+			journal:   journal,
+		}
+		g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+
+		// The Cluster is not moved again.
+		movedCluster := &clusterv1.Cluster{}
+		g.Expect(csTo.Get(ctx, client.ObjectKeyFromObject(cluster), movedCluster)).To(Succeed())
+		g.Expect(movedCluster.Annotations).To(HaveKeyWithValue("moved-by", "previous-run"))
+
+		// Objects owned by the Cluster are using the UID recorded in the journal.
+		machine := &clusterv1.Machine{}
+		g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "m1"}, machine)).To(Succeed())
+		g.Expect(machine.OwnerReferences).To(ContainElement(HaveField("UID", cluster.GetUID())))
+	})
+}
+
+func Test_objectMover_move_withClusterSelection(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	// cluster1 and cluster2 share the same ClusterClass.
+	objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster1").WithTopologyClass("class1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster2").WithTopologyClass("class1").Objs()...)
+
+	graph := getObjectGraphWithObjs(objs)
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+	g.Expect(graph.selectClusters(ctx, "ns1", "cluster1", "")).To(Succeed())
+
+	toProxy := getFakeProxyWithCRDs()
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	csTo, err := toProxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The selected Cluster is moved.
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, &clusterv1.Cluster{})).To(Succeed())
+	err = csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, &clusterv1.Cluster{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// The remaining Cluster is not moved nor paused.
+	cluster2 := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster2"}, cluster2)).To(Succeed())
+	g.Expect(cluster2.Spec.Paused).To(BeFalse())
+	err = csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster2"}, &clusterv1.Cluster{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// The shared ClusterClass is copied, and it is resumed in the source cluster.
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "class1"}, &clusterv1.ClusterClass{})).To(Succeed())
+	class1 := &clusterv1.ClusterClass{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "class1"}, class1)).To(Succeed())
+	g.Expect(class1.Annotations).ToNot(HaveKey(clusterv1.PausedAnnotation))
+}
+
+func Test_objectMover_rollback(t *testing.T) {
+	objs := test.NewFakeCluster("ns1", "cluster1").
+		WithMachines(
+			test.NewFakeMachine("m1"),
+		).Objs()
+
+	t.Run("deletes objects created in the target cluster and resumes the source cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		graph := getObjectGraphWithObjs(objs)
+		g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+		g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+		toProxy := getFakeProxyWithCRDs()
+
+		journal, err := loadMoveJournal(t.TempDir(), "")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		mover := objectMover{
+			fromProxy:             graph.proxy,
+			fromProviderInventory: graph.providerInventory,
+			journal:               journal,
+		}
+
+		// Simulate a move which failed after creating the first two groups in the target cluster.
+		g.Expect(setClusterPause(ctx, graph.proxy, graph.getClusters(), true, false)).To(Succeed())
+		moveSequence := getMoveSequence(graph)
+		for i := range 2 {
+			g.Expect(mover.createGroup(ctx, moveSequence.getGroup(i), toProxy)).To(Succeed())
+			g.Expect(journal.completeGroup(moveSequence.getGroup(i))).To(Succeed())
+		}
+
+		csTo, err := toProxy.NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "m1"}, &clusterv1.Machine{})).To(Succeed())
+
+		g.Expect(mover.rollback(ctx, "", journal, toProxy)).To(Succeed())
+
+		// Objects are deleted from the target cluster.
+		for _, o := range journal.Objects {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(o.APIVersion)
+			obj.SetKind(o.Kind)
+			err := csTo.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, obj)
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s %s/%s not deleted from the target cluster", o.Kind, o.Namespace, o.Name)
+		}
+
+		// The source cluster is resumed.
+		csFrom, err := graph.proxy.NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		cluster := &clusterv1.Cluster{}
+		g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, cluster)).To(Succeed())
+		g.Expect(cluster.Spec.Paused).To(BeFalse())
+
+		// The journal is removed.
+		g.Expect(journal.exists()).To(BeFalse())
+	})
+
+	t.Run("fails if objects have been deleted from the source cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		journal, err := loadMoveJournal(t.TempDir(), "")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(journal.startDeletion()).To(Succeed())
+
+		mover := objectMover{
+			fromProxy: getFakeProxyWithCRDs(),
+		}
+		err = mover.rollback(ctx, "", journal, getFakeProxyWithCRDs())
+		g.Expect(err).To(MatchError(ContainSubstring("objects have already been deleted from the source cluster")))
+	})
+
+	t.Run("fails if the journal does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		journal, err := loadMoveJournal(t.TempDir(), "")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		mover := objectMover{
+			fromProxy: getFakeProxyWithCRDs(),
+		}
+		err = mover.rollback(ctx, "", journal, getFakeProxyWithCRDs())
+		g.Expect(err).To(MatchError(ContainSubstring("move journal not found")))
+	})
+}
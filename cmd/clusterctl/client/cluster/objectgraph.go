@@ -28,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -138,6 +139,10 @@ func (n *node) isSoftOwnedBy(other *node) bool {
 	return ok
 }
 
+func (n *node) isCluster() bool {
+	return n.identity.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("Cluster").GroupKind()
+}
+
 func (n *node) getFilename() string {
 	return n.identity.Kind + "_" + n.identity.Namespace + "_" + n.identity.Name + ".yaml"
 }
@@ -854,3 +859,156 @@ func (o *objectGraph) setShouldNotDeleteHierarchy(node *node) {
 		}
 	}
 }
+
+// selectClusters restricts the object graph to the subgraph reachable from the Clusters in the namespace matching the
+// given name and label selector; if both are empty, the object graph is not changed.
+// The subgraph includes:
+//   - the objects belonging to the selected Clusters, which are moved.
+//   - the objects shared with the selected Clusters, e.g. ClusterClasses and their templates or ClusterResourceSets;
+//     those objects are copied, and not deleted from the source cluster, if still referenced by the remaining Clusters.
+//   - global objects, which are never deleted from the source cluster.
+func (o *objectGraph) selectClusters(ctx context.Context, namespace, clusterName, clusterSelector string) error {
+	if clusterName == "" && clusterSelector == "" {
+		return nil
+	}
+
+	log := logf.Log
+
+	selectors := []client.ListOption{client.InNamespace(namespace)}
+	if clusterSelector != "" {
+		selector, err := labels.Parse(clusterSelector)
+		if err != nil {
+			return errors.Wrapf(err, "invalid Cluster selector %q", clusterSelector)
+		}
+		selectors = append(selectors, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	discoveryBackoff := newReadBackoff()
+	clusterList := &clusterv1.ClusterList{}
+	if err := retryWithExponentialBackoff(ctx, discoveryBackoff, func(ctx context.Context) error {
+		return getObjList(ctx, o.proxy, nil, selectors, clusterList)
+	}); err != nil {
+		return err
+	}
+
+	selected := map[*node]empty{}
+	for _, cluster := range clusterList.Items {
+		if clusterName != "" && cluster.Name != clusterName {
+			continue
+		}
+		if n, ok := o.uidToNode[cluster.UID]; ok {
+			selected[n] = empty{}
+		}
+	}
+	if len(selected) == 0 {
+		return errors.Errorf("no Clusters matching name %q and selector %q found in namespace %q", clusterName, clusterSelector, namespace)
+	}
+
+	// belongsToOtherClusters returns true if a node belongs to at least one Cluster which is not selected.
+	belongsToOtherClusters := func(n *node) bool {
+		for tenant := range n.tenant {
+			if _, ok := selected[tenant]; !ok && tenant.isCluster() {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Include the objects belonging to the selected Clusters and global objects.
+	included := map[*node]empty{}
+	queue := []*node{}
+	for _, n := range o.getNodes() {
+		include := n.isGlobal || n.isGlobalHierarchy
+		for tenant := range n.tenant {
+			if _, ok := selected[tenant]; ok {
+				include = true
+			}
+		}
+		if include {
+			included[n] = empty{}
+			queue = append(queue, n)
+		}
+	}
+
+	// Include the owners of the included objects, e.g. the ClusterClass of a Cluster or the ClusterResourceSet of
+	// a ClusterResourceSetBinding; those objects are shared with other Clusters, and they are included together with the
+	// objects they own which do not belong to other Clusters, e.g. the templates of a ClusterClass.
+	shared := map[*node]empty{}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		_, isShared := shared[n]
+		for _, other := range o.getNodes() {
+			if _, ok := included[other]; ok || other.isCluster() {
+				continue
+			}
+			if n.isOwnedBy(other) || n.isSoftOwnedBy(other) || (isShared && other.isOwnedBy(n) && !belongsToOtherClusters(other)) {
+				included[other] = empty{}
+				shared[other] = empty{}
+				queue = append(queue, other)
+			}
+		}
+	}
+
+	// Ensure shared objects still referenced by objects not included, e.g. a ClusterClass used by the remaining Clusters,
+	// are not deleted, as well as the shared objects they own.
+	for _, n := range o.getNodes() {
+		if _, ok := included[n]; !ok {
+			continue
+		}
+		if belongsToOtherClusters(n) {
+			o.setShouldNotDeleteShared(n, shared)
+			continue
+		}
+		if _, ok := shared[n]; !ok {
+			continue
+		}
+		for _, other := range o.getNodes() {
+			if _, ok := included[other]; ok {
+				continue
+			}
+			if other.isOwnedBy(n) || other.isSoftOwnedBy(n) {
+				o.setShouldNotDeleteShared(n, shared)
+				break
+			}
+		}
+	}
+
+	// Remove all the objects not included from the object graph, as well as the links to them.
+	for _, n := range o.getNodes() {
+		if _, ok := included[n]; !ok {
+			log.V(5).Info("Excluding object from move (not linked with the selected Clusters)", "kind", n.identity.Kind, "name", n.identity.Name, "namespace", n.identity.Namespace)
+			delete(o.uidToNode, n.identity.UID)
+		}
+	}
+	for _, n := range o.getNodes() {
+		for owner := range n.owners {
+			if _, ok := included[owner]; !ok {
+				delete(n.owners, owner)
+			}
+		}
+		for owner := range n.softOwners {
+			if _, ok := included[owner]; !ok {
+				delete(n.softOwners, owner)
+			}
+		}
+		for tenant := range n.tenant {
+			if _, ok := included[tenant]; !ok {
+				delete(n.tenant, tenant)
+			}
+		}
+	}
+
+	log.V(1).Info("Selected Clusters", "count", len(selected), "objects", len(o.uidToNode))
+	return nil
+}
+
+// setShouldNotDeleteShared sets should not delete for a node and for the shared objects it owns.
+func (o *objectGraph) setShouldNotDeleteShared(node *node, shared map[*node]empty) {
+	node.shouldNotDelete = true
+	for other := range shared {
+		if other.isOwnedBy(node) && !other.shouldNotDelete {
+			o.setShouldNotDeleteShared(other, shared)
+		}
+	}
+}
@@ -2774,3 +2774,146 @@ func deduplicateObjects(objs []client.Object) []client.Object {
 	}
 	return res
 }
+
+func Test_objectGraph_selectClusters(t *testing.T) {
+	// cluster1 and cluster2 use class1 and crs1, cluster3 uses class2.
+	objs := func() []client.Object {
+		objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+		objs = append(objs, test.NewFakeClusterClass("ns1", "class2").Objs()...)
+		objs = append(objs, test.NewFakeCluster("ns1", "cluster1").WithTopologyClass("class1").Objs()...)
+		objs = append(objs, test.NewFakeCluster("ns1", "cluster2").WithTopologyClass("class1").Objs()...)
+		objs = append(objs, test.NewFakeCluster("ns1", "cluster3").WithTopologyClass("class2").Objs()...)
+		objs = append(objs, test.NewFakeClusterResourceSet("ns1", "crs1").
+			WithSecret("resource-s1").
+			ApplyToCluster(test.SelectClusterObj(objs, "ns1", "cluster1")).
+			ApplyToCluster(test.SelectClusterObj(objs, "ns1", "cluster2")).
+			Objs()...)
+		for _, o := range objs {
+			if o.GetObjectKind().GroupVersionKind().Kind != "Cluster" {
+				continue
+			}
+			switch o.GetName() {
+			case "cluster1", "cluster2":
+				o.SetLabels(map[string]string{"env": "dev"})
+			case "cluster3":
+				o.SetLabels(map[string]string{"env": "prod"})
+			}
+		}
+		return objs
+	}
+
+	tests := []struct {
+		name                string
+		clusterName         string
+		clusterSelector     string
+		wantObjs            []string
+		wantShouldNotDelete []string
+		wantErr             bool
+	}{
+		{
+			name:        "select a Cluster by name; shared objects are not deleted",
+			clusterName: "cluster1",
+			wantObjs: []string{
+				"Cluster/cluster1",
+				"GenericInfrastructureCluster/cluster1",
+				"Secret/cluster1-ca",
+				"Secret/cluster1-kubeconfig",
+				"ClusterClass/class1",
+				"GenericInfrastructureClusterTemplate/class1",
+				"GenericControlPlaneTemplate/class1",
+				"ClusterResourceSet/crs1",
+				"ClusterResourceSetBinding/cluster1",
+				"Secret/resource-s1",
+			},
+			wantShouldNotDelete: []string{
+				"ClusterClass/class1",
+				"GenericInfrastructureClusterTemplate/class1",
+				"GenericControlPlaneTemplate/class1",
+				"ClusterResourceSet/crs1",
+				"Secret/resource-s1",
+			},
+		},
+		{
+			name:            "select Clusters by label; objects not shared with remaining Clusters are moved",
+			clusterSelector: "env=dev",
+			wantObjs: []string{
+				"Cluster/cluster1",
+				"GenericInfrastructureCluster/cluster1",
+				"Secret/cluster1-ca",
+				"Secret/cluster1-kubeconfig",
+				"Cluster/cluster2",
+				"GenericInfrastructureCluster/cluster2",
+				"Secret/cluster2-ca",
+				"Secret/cluster2-kubeconfig",
+				"ClusterClass/class1",
+				"GenericInfrastructureClusterTemplate/class1",
+				"GenericControlPlaneTemplate/class1",
+				"ClusterResourceSet/crs1",
+				"ClusterResourceSetBinding/cluster1",
+				"ClusterResourceSetBinding/cluster2",
+				"Secret/resource-s1",
+			},
+			wantShouldNotDelete: []string{},
+		},
+		{
+			name:            "select Clusters by name and label",
+			clusterName:     "cluster3",
+			clusterSelector: "env=prod",
+			wantObjs: []string{
+				"Cluster/cluster3",
+				"GenericInfrastructureCluster/cluster3",
+				"Secret/cluster3-ca",
+				"Secret/cluster3-kubeconfig",
+				"ClusterClass/class2",
+				"GenericInfrastructureClusterTemplate/class2",
+				"GenericControlPlaneTemplate/class2",
+			},
+			wantShouldNotDelete: []string{},
+		},
+		{
+			name:            "fails if no Clusters are matching",
+			clusterName:     "cluster3",
+			clusterSelector: "env=dev",
+			wantErr:         true,
+		},
+		{
+			name:            "fails if the selector is not valid",
+			clusterSelector: "env==,",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			graph := getObjectGraphWithObjs(objs())
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+			g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+
+			err := graph.selectClusters(ctx, "ns1", tt.clusterName, tt.clusterSelector)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotObjs := []string{}
+			gotShouldNotDelete := []string{}
+			for _, n := range graph.getMoveNodes() {
+				key := n.identity.Kind + "/" + n.identity.Name
+				gotObjs = append(gotObjs, key)
+				if n.shouldNotDelete {
+					gotShouldNotDelete = append(gotShouldNotDelete, key)
+				}
+			}
+			g.Expect(gotObjs).To(ConsistOf(tt.wantObjs))
+			g.Expect(gotShouldNotDelete).To(ConsistOf(tt.wantShouldNotDelete))
+
+			// All the nodes must be included in the move sequence, i.e. there are no links to nodes removed from the graph.
+			moveSequence := getMoveSequence(graph)
+			g.Expect(moveSequence.nodesMap).To(HaveLen(len(tt.wantObjs)))
+		})
+	}
+}
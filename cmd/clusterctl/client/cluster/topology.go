@@ -0,0 +1,1060 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	"sigs.k8s.io/cluster-api/exp/topology/scope"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/clusterclass"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	topologycluster "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
+	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
+	"sigs.k8s.io/cluster-api/internal/util/compare"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/contract"
+)
+
+// TopologyChangeType defines the type of change that would be applied to an object of a Cluster topology.
+type TopologyChangeType string
+
+const (
+	// TopologyObjectCreated is used for objects that would be created.
+	TopologyObjectCreated TopologyChangeType = "Created"
+
+	// TopologyObjectModified is used for objects that would be modified in place.
+	TopologyObjectModified TopologyChangeType = "Modified"
+
+	// TopologyObjectRotated is used for templates whose spec changes; the topology controller replaces
+	// them with a new template with a new name, which usually triggers a rollout of the Machines using them.
+	TopologyObjectRotated TopologyChangeType = "Rotated"
+
+	// TopologyObjectDeleted is used for objects that would be deleted.
+	TopologyObjectDeleted TopologyChangeType = "Deleted"
+)
+
+// TopologyPlanInput defines the input for the Plan function.
+type TopologyPlanInput struct {
+	// Objs are the new or modified objects, e.g. ClusterClasses, templates, Clusters or ExtensionConfigs
+	// pointing to a local Runtime Extension.
+	Objs []*unstructured.Unstructured
+
+	// Snapshot are the objects of the management cluster to be used instead of reading them from the
+	// management cluster, e.g. read from a file.
+	// If nil, the objects are read from the management cluster.
+	Snapshot []*unstructured.Unstructured
+
+	// TargetClusterName is the name of the Cluster to preview changes for.
+	// If empty, changes are computed for all the Clusters affected by the objects in input.
+	TargetClusterName string
+
+	// TargetNamespace is the namespace used for objects in input without a namespace and for TargetClusterName.
+	TargetNamespace string
+
+	// UseManagementClusterExtensions allows calling the Runtime Extensions registered by the ExtensionConfigs
+	// of the management cluster, e.g. to compute external patches.
+	// If false, only ExtensionConfigs in input are used.
+	UseManagementClusterExtensions bool
+}
+
+// TopologyPlanOutput defines the output of the Plan function.
+type TopologyPlanOutput struct {
+	// ClusterClasses are the ClusterClasses affected by the objects in input.
+	ClusterClasses []client.ObjectKey
+
+	// Clusters are the plans for the Clusters affected by the objects in input.
+	Clusters []*TopologyClusterPlan
+}
+
+// TopologyClusterPlan describes the changes that would be applied to a Cluster with a managed topology.
+type TopologyClusterPlan struct {
+	// Cluster is the Cluster the plan is for.
+	Cluster client.ObjectKey
+
+	// Changes are the changes that would be applied to the objects of the Cluster topology.
+	Changes []TopologyObjectChange
+
+	// Rollouts are the control plane and MachineDeployments whose Machines would be rolled out.
+	Rollouts []TopologyMachineRollout
+}
+
+// TopologyObjectChange describes the change that would be applied to an object of a Cluster topology.
+type TopologyObjectChange struct {
+	// Object is the object being changed.
+	Object corev1.ObjectReference
+
+	// Type is the type of change.
+	Type TopologyChangeType
+
+	// Diff is the diff between the current and the desired labels, annotations and spec of the object.
+	// It is empty for objects being created or deleted.
+	Diff string
+}
+
+// TopologyMachineRollout describes a control plane or a MachineDeployment whose Machines would be rolled out.
+type TopologyMachineRollout struct {
+	// Owner is the control plane or the MachineDeployment owning the Machines.
+	Owner corev1.ObjectReference
+
+	// Machines are the names of the Machines that would be replaced.
+	Machines []string
+
+	// Reasons are the changes triggering the rollout.
+	Reasons []string
+}
+
+// TopologyClient has methods to preview the effects of changes to ClusterClasses and Clusters with a managed topology.
+type TopologyClient interface {
+	// Plan computes the changes that would be applied to the Clusters with a managed topology if the objects in input
+	// were applied to the management cluster.
+	// NOTE: Plan never changes the management cluster; all the operations are executed against an in-memory copy of it.
+	Plan(ctx context.Context, in *TopologyPlanInput) (*TopologyPlanOutput, error)
+}
+
+// topologyClient implements TopologyClient.
+type topologyClient struct {
+	proxy Proxy
+}
+
+// ensure topologyClient implements TopologyClient.
+var _ TopologyClient = &topologyClient{}
+
+// newTopologyClient returns a topologyClient.
+func newTopologyClient(proxy Proxy) *topologyClient {
+	return &topologyClient{
+		proxy: proxy,
+	}
+}
+
+// topologyPlanScheme is the scheme used by the in-memory copy of the management cluster;
+// objects of types not included in the scheme are handled as unstructured.
+var topologyPlanScheme = runtime.NewScheme()
+
+func init() {
+	_ = corev1.AddToScheme(topologyPlanScheme)
+	_ = apiextensionsv1.AddToScheme(topologyPlanScheme)
+	_ = clusterv1.AddToScheme(topologyPlanScheme)
+	_ = runtimev1.AddToScheme(topologyPlanScheme)
+}
+
+// topologyPlanObjectKey identifies an object independently of its API version.
+type topologyPlanObjectKey struct {
+	groupKind schema.GroupKind
+	namespace string
+	name      string
+}
+
+func topologyPlanKeyFor(obj *unstructured.Unstructured) topologyPlanObjectKey {
+	return topologyPlanObjectKey{
+		groupKind: obj.GroupVersionKind().GroupKind(),
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+	}
+}
+
+var (
+	clusterGroupKind         = clusterv1.GroupVersion.WithKind("Cluster").GroupKind()
+	clusterClassGroupKind    = clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind()
+	extensionConfigGroupKind = runtimev1.GroupVersion.WithKind("ExtensionConfig").GroupKind()
+	crdGroupKind             = apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition").GroupKind()
+	namespaceGroupKind       = corev1.SchemeGroupVersion.WithKind("Namespace").GroupKind()
+)
+
+func (t *topologyClient) Plan(ctx context.Context, in *TopologyPlanInput) (*TopologyPlanOutput, error) {
+	log := logf.Log
+
+	if len(in.Objs) == 0 {
+		return nil, errors.New("at least one object is required to compute a topology plan")
+	}
+	inputs, err := prepareTopologyPlanInputs(in.Objs, in.TargetNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	state := in.Snapshot
+	if state == nil {
+		log.Info("Reading Cluster API objects from the management cluster")
+		if state, err = t.readState(ctx); err != nil {
+			return nil, err
+		}
+	}
+	objs, err := mergeTopologyPlanObjects(state, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(topologyPlanScheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&clusterv1.ClusterClass{}).
+		Build()
+
+	runtimeClient, err := newTopologyPlanRuntimeClient(ctx, c, inputs, in.UseManagementClusterExtensions)
+	if err != nil {
+		return nil, err
+	}
+	featureGates, err := topologyPlanFeatureGates(runtimeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterClasses, err := affectedClusterClasses(ctx, c, inputs)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := affectedClusters(ctx, c, inputs, clusterClasses)
+	if err != nil {
+		return nil, err
+	}
+	if in.TargetClusterName != "" {
+		clusters, err = filterTargetCluster(clusters, in.TargetClusterName, in.TargetNamespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Reconcile the ClusterClasses in input, so their status reflects the changes, and the ClusterClasses used by the
+	// affected Clusters which are not reconciled yet, e.g. because they are read from a snapshot without status.
+	toReconcile := sets.New[client.ObjectKey](clusterClasses...)
+	for _, cluster := range clusters {
+		toReconcile.Insert(cluster.GetClassKey())
+	}
+	for _, key := range sortedObjectKeys(toReconcile) {
+		if err := reconcileTopologyPlanClusterClass(ctx, c, runtimeClient, featureGates, key, inputs); err != nil {
+			return nil, err
+		}
+	}
+
+	out := &TopologyPlanOutput{
+		ClusterClasses: clusterClasses,
+	}
+	stateKeys := sets.New[topologyPlanObjectKey](keysFor(state)...)
+	r := &topologycluster.Reconciler{
+		Client:        c,
+		RuntimeClient: runtimeClient,
+		FeatureGates:  featureGates,
+	}
+	for _, cluster := range clusters {
+		log.V(1).Info("Computing the desired state", "Cluster", klog.KObj(cluster))
+		s, err := r.ComputeDesiredState(ctx, cluster)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute the desired state of Cluster %s", klog.KObj(cluster))
+		}
+		newCluster := !stateKeys.Has(topologyPlanObjectKey{groupKind: clusterGroupKind, namespace: cluster.Namespace, name: cluster.Name})
+		plan, err := computeTopologyClusterPlan(ctx, c, s, newCluster)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute the plan for Cluster %s", klog.KObj(cluster))
+		}
+		out.Clusters = append(out.Clusters, plan)
+	}
+	return out, nil
+}
+
+// prepareTopologyPlanInputs validates the objects in input and defaults their namespace.
+func prepareTopologyPlanInputs(objs []*unstructured.Unstructured, namespace string) ([]*unstructured.Unstructured, error) {
+	inputs := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		obj = obj.DeepCopy()
+		if obj.GetName() == "" {
+			return nil, errors.Errorf("invalid object of kind %s: name must be set", obj.GetKind())
+		}
+		if err := validateTopologyPlanObjectVersion(obj); err != nil {
+			return nil, err
+		}
+		if obj.GetNamespace() == "" && !isClusterScoped(obj) {
+			obj.SetNamespace(namespace)
+		}
+		inputs = append(inputs, obj)
+	}
+	return inputs, nil
+}
+
+// validateTopologyPlanObjectVersion checks that Cluster API objects use the API version used by the topology controller.
+func validateTopologyPlanObjectVersion(obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	switch gvk.Group {
+	case clusterv1.GroupVersion.Group, runtimev1.GroupVersion.Group:
+		if gvk.Version != clusterv1.GroupVersion.Version {
+			return errors.Errorf("invalid object %s %s: only %s objects are supported", gvk.Kind, klog.KObj(obj), schema.GroupVersion{Group: gvk.Group, Version: clusterv1.GroupVersion.Version})
+		}
+	}
+	return nil
+}
+
+func isClusterScoped(obj *unstructured.Unstructured) bool {
+	switch obj.GroupVersionKind().GroupKind() {
+	case extensionConfigGroupKind, crdGroupKind, namespaceGroupKind:
+		return true
+	}
+	return false
+}
+
+// readState reads the CRDs of the Cluster API providers, all the objects of those CRDs and the Namespaces from
+// the management cluster.
+func (t *topologyClient) readState(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := getCRDList(ctx, t.proxy, crdList); err != nil {
+		return nil, err
+	}
+
+	state := []*unstructured.Unstructured{}
+	for i := range crdList.Items {
+		crd := &crdList.Items[i]
+		obj, err := toTopologyPlanUnstructured(crd)
+		if err != nil {
+			return nil, err
+		}
+		state = append(state, obj)
+
+		for _, version := range crd.Spec.Versions {
+			if !version.Storage {
+				continue
+			}
+			typeMeta := metav1.TypeMeta{
+				Kind:       crd.Spec.Names.Kind,
+				APIVersion: metav1.GroupVersion{Group: crd.Spec.Group, Version: version.Name}.String(),
+			}
+			objList := new(unstructured.UnstructuredList)
+			if err := getObjList(ctx, t.proxy, &typeMeta, nil, objList); err != nil {
+				return nil, err
+			}
+			for i := range objList.Items {
+				state = append(state, &objList.Items[i])
+			}
+		}
+	}
+
+	namespaceList := new(unstructured.UnstructuredList)
+	if err := getObjList(ctx, t.proxy, &metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"}, nil, namespaceList); err != nil {
+		return nil, err
+	}
+	for i := range namespaceList.Items {
+		state = append(state, &namespaceList.Items[i])
+	}
+	return state, nil
+}
+
+// mergeTopologyPlanObjects merges the objects in input into the state of the management cluster, and it adds
+// the CRDs and the Namespaces required by the topology controller when they are not part of the state,
+// e.g. because the state is read from a snapshot with only a subset of the objects of the management cluster.
+func mergeTopologyPlanObjects(state, inputs []*unstructured.Unstructured) ([]client.Object, error) {
+	objs := map[topologyPlanObjectKey]*unstructured.Unstructured{}
+	var keys []topologyPlanObjectKey
+	add := func(obj *unstructured.Unstructured) {
+		key := topologyPlanKeyFor(obj)
+		if _, ok := objs[key]; !ok {
+			keys = append(keys, key)
+		}
+		objs[key] = obj
+	}
+
+	for _, obj := range state {
+		if err := validateTopologyPlanObjectVersion(obj); err != nil {
+			return nil, errors.Wrap(err, "invalid management cluster state")
+		}
+		add(obj.DeepCopy())
+	}
+	for _, obj := range inputs {
+		obj = obj.DeepCopy()
+		// Drop the status of ClusterClasses in input so they are reconciled again.
+		if obj.GroupVersionKind().GroupKind() == clusterClassGroupKind {
+			unstructured.RemoveNestedField(obj.Object, "status")
+		}
+		add(obj)
+	}
+
+	crds := sets.Set[string]{}
+	namespaces := sets.Set[string]{}
+	for _, obj := range objs {
+		switch obj.GroupVersionKind().GroupKind() {
+		case crdGroupKind:
+			crds.Insert(obj.GetName())
+		case namespaceGroupKind:
+			namespaces.Insert(obj.GetName())
+		}
+	}
+	for _, key := range keys {
+		obj := objs[key]
+		if ns := obj.GetNamespace(); ns != "" && !namespaces.Has(ns) {
+			namespace := &unstructured.Unstructured{}
+			namespace.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+			namespace.SetName(ns)
+			namespaces.Insert(ns)
+			add(namespace)
+		}
+
+		gvk := obj.GroupVersionKind()
+		if gvk.Group == "" || gvk.Group == apiextensionsv1.GroupName {
+			continue
+		}
+		// NOTE: By contract, objects created from templates have the kind of the template without the Template suffix.
+		kinds := []string{gvk.Kind}
+		if kind, ok := strings.CutSuffix(gvk.Kind, "Template"); ok {
+			kinds = append(kinds, kind)
+		}
+		for _, kind := range kinds {
+			if !crds.Has(contract.CalculateCRDName(gvk.Group, kind)) {
+				crd := topologyPlanCRD(gvk.GroupVersion().WithKind(kind))
+				crds.Insert(crd.GetName())
+				add(crd)
+			}
+		}
+	}
+
+	out := make([]client.Object, 0, len(keys))
+	for _, key := range keys {
+		obj := objs[key]
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+		out = append(out, obj)
+	}
+	return out, nil
+}
+
+// topologyPlanCRD returns the metadata of a CRD for the given kind, with the label declaring
+// the Cluster API contract satisfied by the version of the kind.
+func topologyPlanCRD(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	crd.SetName(contract.CalculateCRDName(gvk.Group, gvk.Kind))
+	crd.SetLabels(map[string]string{
+		fmt.Sprintf("%s/%s", clusterv1.GroupVersion.Group, clusterv1.GroupVersion.Version): gvk.Version,
+	})
+	return crd
+}
+
+// topologyPlanRuntimeClient is a runtime client that calls the patch and variable discovery hooks
+// of the Runtime Extensions, but it never calls lifecycle hooks, because a preview must not have side effects.
+type topologyPlanRuntimeClient struct {
+	runtimeclient.Client
+
+	// hasExtensions is true if at least one ExtensionConfig is registered.
+	hasExtensions bool
+}
+
+func (c *topologyPlanRuntimeClient) CallAllExtensions(_ context.Context, _ runtimecatalog.Hook, _ metav1.Object, _ runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject) error {
+	response.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+	return nil
+}
+
+// newTopologyPlanRuntimeClient returns a runtime client with the ExtensionConfigs in input registered.
+// ExtensionConfigs in input are discovered, so they can be used to point to a local Runtime Extension.
+// Other ExtensionConfigs are registered, with the handlers discovered by the Runtime SDK controller, only if
+// useManagementClusterExtensions is true, because computing a plan must not call Runtime Extensions
+// running in the management cluster unless explicitly requested.
+func newTopologyPlanRuntimeClient(ctx context.Context, c client.Client, inputs []*unstructured.Unstructured, useManagementClusterExtensions bool) (*topologyPlanRuntimeClient, error) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	runtimeClient := internalruntimeclient.New(internalruntimeclient.Options{
+		Catalog:  catalog,
+		Registry: runtimeregistry.New(),
+		Client:   c,
+	})
+
+	extensionConfigList := &runtimev1.ExtensionConfigList{}
+	if err := c.List(ctx, extensionConfigList); err != nil {
+		return nil, errors.Wrap(err, "failed to list ExtensionConfigs")
+	}
+
+	discover := sets.Set[string]{}
+	for _, obj := range inputs {
+		if obj.GroupVersionKind().GroupKind() == extensionConfigGroupKind {
+			discover.Insert(obj.GetName())
+		}
+	}
+	extensionConfigs := &runtimev1.ExtensionConfigList{}
+	for i := range extensionConfigList.Items {
+		extensionConfig := &extensionConfigList.Items[i]
+		if !discover.Has(extensionConfig.Name) {
+			if useManagementClusterExtensions {
+				extensionConfigs.Items = append(extensionConfigs.Items, *extensionConfig)
+			}
+			continue
+		}
+		discovered, err := runtimeClient.Discover(ctx, extensionConfig)
+		if err != nil {
+			return nil, err
+		}
+		extensionConfigs.Items = append(extensionConfigs.Items, *discovered)
+	}
+	if err := runtimeClient.WarmUp(extensionConfigs); err != nil {
+		return nil, err
+	}
+	return &topologyPlanRuntimeClient{Client: runtimeClient, hasExtensions: len(extensionConfigs.Items) > 0}, nil
+}
+
+// topologyPlanFeatureGates returns the feature gates to be used when computing a plan.
+// The RuntimeSDK feature gate is enabled if there is at least one ExtensionConfig registered.
+// NOTE: The feature gates are a copy of feature.Gates, so the process-wide feature gates are never changed.
+func topologyPlanFeatureGates(runtimeClient *topologyPlanRuntimeClient) (featuregate.FeatureGate, error) {
+	featureGates := feature.MutableGates.DeepCopy()
+	if runtimeClient.hasExtensions && !featureGates.Enabled(feature.RuntimeSDK) {
+		if err := featureGates.Set(fmt.Sprintf("%s=true", feature.RuntimeSDK)); err != nil {
+			return nil, errors.Wrapf(err, "failed to enable the %s feature gate", feature.RuntimeSDK)
+		}
+	}
+	return featureGates, nil
+}
+
+// affectedClusterClasses returns the ClusterClasses in input and the ClusterClasses referencing templates in input.
+func affectedClusterClasses(ctx context.Context, c client.Client, inputs []*unstructured.Unstructured) ([]client.ObjectKey, error) {
+	inputKeys := sets.New[topologyPlanObjectKey](keysFor(inputs)...)
+
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := c.List(ctx, clusterClassList); err != nil {
+		return nil, errors.Wrap(err, "failed to list ClusterClasses")
+	}
+
+	affected := sets.Set[client.ObjectKey]{}
+	for i := range clusterClassList.Items {
+		clusterClass := &clusterClassList.Items[i]
+		if inputKeys.Has(topologyPlanObjectKey{groupKind: clusterClassGroupKind, namespace: clusterClass.Namespace, name: clusterClass.Name}) {
+			affected.Insert(client.ObjectKeyFromObject(clusterClass))
+			continue
+		}
+		for _, ref := range clusterClassTemplateRefs(clusterClass) {
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse apiVersion of a template referenced by ClusterClass %s", klog.KObj(clusterClass))
+			}
+			if inputKeys.Has(topologyPlanObjectKey{groupKind: gv.WithKind(ref.Kind).GroupKind(), namespace: clusterClass.Namespace, name: ref.Name}) {
+				affected.Insert(client.ObjectKeyFromObject(clusterClass))
+				break
+			}
+		}
+	}
+	return sortedObjectKeys(affected), nil
+}
+
+// clusterClassTemplateRefs returns all the references to templates of a ClusterClass.
+func clusterClassTemplateRefs(clusterClass *clusterv1.ClusterClass) []*clusterv1.ClusterClassTemplateReference {
+	refs := []*clusterv1.ClusterClassTemplateReference{}
+	if clusterClass.Spec.Infrastructure.Ref != nil {
+		refs = append(refs, clusterClass.Spec.Infrastructure.Ref)
+	}
+	if clusterClass.Spec.ControlPlane.Ref != nil {
+		refs = append(refs, clusterClass.Spec.ControlPlane.Ref)
+	}
+	if clusterClass.Spec.ControlPlane.MachineInfrastructure != nil && clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref != nil {
+		refs = append(refs, clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
+	}
+	for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
+		if mdClass.Template.Bootstrap.Ref != nil {
+			refs = append(refs, mdClass.Template.Bootstrap.Ref)
+		}
+		if mdClass.Template.Infrastructure.Ref != nil {
+			refs = append(refs, mdClass.Template.Infrastructure.Ref)
+		}
+	}
+	for _, mpClass := range clusterClass.Spec.Workers.MachinePools {
+		if mpClass.Template.Bootstrap.Ref != nil {
+			refs = append(refs, mpClass.Template.Bootstrap.Ref)
+		}
+		if mpClass.Template.Infrastructure.Ref != nil {
+			refs = append(refs, mpClass.Template.Infrastructure.Ref)
+		}
+	}
+	return refs
+}
+
+// affectedClusters returns the Clusters with a managed topology which are in input or use one of the affected ClusterClasses.
+func affectedClusters(ctx context.Context, c client.Client, inputs []*unstructured.Unstructured, clusterClasses []client.ObjectKey) ([]*clusterv1.Cluster, error) {
+	inputKeys := sets.New[topologyPlanObjectKey](keysFor(inputs)...)
+	affectedClusterClasses := sets.New[client.ObjectKey](clusterClasses...)
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	clusters := []*clusterv1.Cluster{}
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.Spec.Topology == nil || !cluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if inputKeys.Has(topologyPlanObjectKey{groupKind: clusterGroupKind, namespace: cluster.Namespace, name: cluster.Name}) ||
+			affectedClusterClasses.Has(cluster.GetClassKey()) {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return client.ObjectKeyFromObject(clusters[i]).String() < client.ObjectKeyFromObject(clusters[j]).String()
+	})
+	return clusters, nil
+}
+
+func filterTargetCluster(clusters []*clusterv1.Cluster, name, namespace string) ([]*clusterv1.Cluster, error) {
+	for _, cluster := range clusters {
+		if cluster.Name == name && cluster.Namespace == namespace {
+			return []*clusterv1.Cluster{cluster}, nil
+		}
+	}
+	return nil, errors.Errorf("Cluster %s/%s is not affected by the changes in input", namespace, name)
+}
+
+// reconcileTopologyPlanClusterClass runs the ClusterClass controller until the ClusterClass is reconciled, so the
+// topology controller can use it.
+func reconcileTopologyPlanClusterClass(ctx context.Context, c client.Client, runtimeClient *topologyPlanRuntimeClient, featureGates featuregate.FeatureGate, key client.ObjectKey, inputs []*unstructured.Unstructured) error {
+	clusterClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, key, clusterClass); err != nil {
+		return errors.Wrapf(err, "failed to get ClusterClass %s", key)
+	}
+
+	inputKeys := sets.New[topologyPlanObjectKey](keysFor(inputs)...)
+	if !inputKeys.Has(topologyPlanObjectKey{groupKind: clusterClassGroupKind, namespace: key.Namespace, name: key.Name}) && isClusterClassReconciled(clusterClass) {
+		return nil
+	}
+
+	r := &clusterclass.Reconciler{
+		Client:        c,
+		RuntimeClient: runtimeClient,
+		FeatureGates:  featureGates,
+	}
+	// NOTE: The first reconcile only sets the Paused condition.
+	for range 2 {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			return errors.Wrapf(err, "failed to reconcile ClusterClass %s", key)
+		}
+		if err := c.Get(ctx, key, clusterClass); err != nil {
+			return errors.Wrapf(err, "failed to get ClusterClass %s", key)
+		}
+		if isClusterClassReconciled(clusterClass) {
+			break
+		}
+	}
+
+	if conditions.IsFalse(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) {
+		return errors.Errorf("ClusterClass %s is not valid: %s", key, conditions.GetMessage(clusterClass, clusterv1.ClusterClassVariablesReadyCondition))
+	}
+	return nil
+}
+
+func isClusterClassReconciled(clusterClass *clusterv1.ClusterClass) bool {
+	return clusterClass.Status.ObservedGeneration == clusterClass.Generation &&
+		conditions.Has(clusterClass, clusterv1.ClusterClassVariablesReadyCondition)
+}
+
+// computeTopologyClusterPlan compares the current and the desired state of a Cluster topology.
+func computeTopologyClusterPlan(ctx context.Context, c client.Client, s *scope.Scope, newCluster bool) (*TopologyClusterPlan, error) {
+	plan := &TopologyClusterPlan{
+		Cluster: client.ObjectKeyFromObject(s.Current.Cluster),
+	}
+	current, desired := s.Current, s.Desired
+
+	if newCluster {
+		plan.addChange(s.Current.Cluster, TopologyObjectCreated, "")
+	} else if err := plan.compare(s.Current.Cluster, desired.Cluster, false); err != nil {
+		return nil, err
+	}
+	if err := plan.compare(current.InfrastructureCluster, desired.InfrastructureCluster, false); err != nil {
+		return nil, err
+	}
+
+	// Control plane.
+	if err := plan.compare(current.ControlPlane.Object, desired.ControlPlane.Object, false); err != nil {
+		return nil, err
+	}
+	if err := plan.compare(current.ControlPlane.InfrastructureMachineTemplate, desired.ControlPlane.InfrastructureMachineTemplate, true); err != nil {
+		return nil, err
+	}
+	if err := plan.compare(current.ControlPlane.MachineHealthCheck, desired.ControlPlane.MachineHealthCheck, false); err != nil {
+		return nil, err
+	}
+	if err := plan.addControlPlaneRollout(ctx, c, s); err != nil {
+		return nil, err
+	}
+
+	// MachineDeployments.
+	for _, name := range sortedTopologyNames(current.MachineDeployments, desired.MachineDeployments) {
+		currentMD, desiredMD := current.MachineDeployments[name], desired.MachineDeployments[name]
+		if currentMD == nil {
+			currentMD = &scope.MachineDeploymentState{}
+		}
+		if desiredMD == nil {
+			desiredMD = &scope.MachineDeploymentState{}
+		}
+		if err := plan.compare(currentMD.Object, desiredMD.Object, false); err != nil {
+			return nil, err
+		}
+		if err := plan.compare(currentMD.BootstrapTemplate, desiredMD.BootstrapTemplate, true); err != nil {
+			return nil, err
+		}
+		if err := plan.compare(currentMD.InfrastructureMachineTemplate, desiredMD.InfrastructureMachineTemplate, true); err != nil {
+			return nil, err
+		}
+		if err := plan.compare(currentMD.MachineHealthCheck, desiredMD.MachineHealthCheck, false); err != nil {
+			return nil, err
+		}
+		if err := plan.addMachineDeploymentRollout(ctx, c, currentMD, desiredMD); err != nil {
+			return nil, err
+		}
+	}
+
+	// MachinePools.
+	for _, name := range sortedTopologyNames(current.MachinePools, desired.MachinePools) {
+		currentMP, desiredMP := current.MachinePools[name], desired.MachinePools[name]
+		if currentMP == nil {
+			currentMP = &scope.MachinePoolState{}
+		}
+		if desiredMP == nil {
+			desiredMP = &scope.MachinePoolState{}
+		}
+		if err := plan.compare(currentMP.Object, desiredMP.Object, false); err != nil {
+			return nil, err
+		}
+		if err := plan.compare(currentMP.BootstrapObject, desiredMP.BootstrapObject, false); err != nil {
+			return nil, err
+		}
+		if err := plan.compare(currentMP.InfrastructureMachinePoolObject, desiredMP.InfrastructureMachinePoolObject, false); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// compare compares the current and the desired state of an object and records the change, if any.
+// The desired state is applied on top of the current state before comparing, so fields not managed by
+// the topology controller, e.g. fields defaulted by the API server, are not reported as changes.
+func (p *TopologyClusterPlan) compare(current, desired client.Object, isTemplate bool) error {
+	currentIsNil, desiredIsNil := isNilObject(current), isNilObject(desired)
+	switch {
+	case currentIsNil && desiredIsNil:
+		return nil
+	case currentIsNil:
+		p.addChange(desired, TopologyObjectCreated, "")
+		return nil
+	case desiredIsNil:
+		p.addChange(current, TopologyObjectDeleted, "")
+		return nil
+	}
+
+	currentObj, err := toTopologyPlanUnstructured(current)
+	if err != nil {
+		return err
+	}
+	desiredObj, err := toTopologyPlanUnstructured(desired)
+	if err != nil {
+		return err
+	}
+	mergedObj := currentObj.DeepCopy()
+	mergeTopologyPlanMap(mergedObj.Object, desiredObj.Object)
+
+	currentFields, mergedFields := topologyPlanFields(currentObj), topologyPlanFields(mergedObj)
+	equal, diff, err := compare.Diff(currentFields, mergedFields)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compare %s %s", currentObj.GetKind(), klog.KObj(currentObj))
+	}
+	if equal {
+		return nil
+	}
+
+	changeType := TopologyObjectModified
+	if isTemplate && !reflect.DeepEqual(currentFields["spec"], mergedFields["spec"]) {
+		changeType = TopologyObjectRotated
+	}
+	p.addChange(currentObj, changeType, diff)
+	return nil
+}
+
+func (p *TopologyClusterPlan) addChange(obj client.Object, changeType TopologyChangeType, diff string) {
+	u, err := toTopologyPlanUnstructured(obj)
+	if err != nil {
+		return
+	}
+	p.Changes = append(p.Changes, TopologyObjectChange{
+		Object: corev1.ObjectReference{
+			APIVersion: u.GetAPIVersion(),
+			Kind:       u.GetKind(),
+			Namespace:  u.GetNamespace(),
+			Name:       u.GetName(),
+		},
+		Type: changeType,
+		Diff: diff,
+	})
+}
+
+// hasChange returns the type of change recorded for an object, if any.
+func (p *TopologyClusterPlan) hasChange(obj client.Object, changeType TopologyChangeType) bool {
+	if isNilObject(obj) {
+		return false
+	}
+	for _, change := range p.Changes {
+		if change.Type == changeType && change.Object.Name == obj.GetName() && change.Object.Namespace == obj.GetNamespace() &&
+			change.Object.Kind == obj.GetObjectKind().GroupVersionKind().Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// addControlPlaneRollout records the rollout of the control plane Machines if the control plane
+// infrastructure machine template is rotated, or if the version or the KubeadmConfigSpec changes.
+func (p *TopologyClusterPlan) addControlPlaneRollout(ctx context.Context, c client.Client, s *scope.Scope) error {
+	current, desired := s.Current.ControlPlane.Object, s.Desired.ControlPlane.Object
+	if current == nil || desired == nil {
+		return nil
+	}
+
+	reasons := []string{}
+	if p.hasChange(s.Current.ControlPlane.InfrastructureMachineTemplate, TopologyObjectRotated) {
+		reasons = append(reasons, fmt.Sprintf("%s %s changed", s.Current.ControlPlane.InfrastructureMachineTemplate.GetKind(), s.Current.ControlPlane.InfrastructureMachineTemplate.GetName()))
+	}
+
+	currentObj, err := toTopologyPlanUnstructured(current)
+	if err != nil {
+		return err
+	}
+	desiredObj, err := toTopologyPlanUnstructured(desired)
+	if err != nil {
+		return err
+	}
+	merged := currentObj.DeepCopy()
+	mergeTopologyPlanMap(merged.Object, desiredObj.Object)
+
+	currentVersion, _, _ := unstructured.NestedString(currentObj.Object, "spec", "version")
+	desiredVersion, _, _ := unstructured.NestedString(merged.Object, "spec", "version")
+	if currentVersion != desiredVersion {
+		reasons = append(reasons, fmt.Sprintf("Version %s, %s required", currentVersion, desiredVersion))
+	}
+	if current.GetKind() == "KubeadmControlPlane" {
+		currentSpec, _, _ := unstructured.NestedFieldNoCopy(currentObj.Object, "spec", "kubeadmConfigSpec")
+		desiredSpec, _, _ := unstructured.NestedFieldNoCopy(merged.Object, "spec", "kubeadmConfigSpec")
+		if !reflect.DeepEqual(currentSpec, desiredSpec) {
+			reasons = append(reasons, "KubeadmConfigSpec changed")
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	machines, err := topologyPlanMachines(ctx, c, client.MatchingLabels{
+		clusterv1.ClusterNameLabel:         s.Current.Cluster.Name,
+		clusterv1.MachineControlPlaneLabel: "",
+	}, current.GetNamespace())
+	if err != nil {
+		return err
+	}
+	p.Rollouts = append(p.Rollouts, TopologyMachineRollout{
+		Owner: corev1.ObjectReference{
+			APIVersion: current.GetAPIVersion(),
+			Kind:       current.GetKind(),
+			Namespace:  current.GetNamespace(),
+			Name:       current.GetName(),
+		},
+		Machines: machines,
+		Reasons:  reasons,
+	})
+	return nil
+}
+
+// addMachineDeploymentRollout records the rollout of the Machines of a MachineDeployment if its templates are
+// rotated or if its machine template is not up-to-date anymore.
+func (p *TopologyClusterPlan) addMachineDeploymentRollout(ctx context.Context, c client.Client, current, desired *scope.MachineDeploymentState) error {
+	if current.Object == nil || desired.Object == nil {
+		return nil
+	}
+
+	reasons := []string{}
+	for _, template := range []*unstructured.Unstructured{current.BootstrapTemplate, current.InfrastructureMachineTemplate} {
+		if p.hasChange(template, TopologyObjectRotated) {
+			reasons = append(reasons, fmt.Sprintf("%s %s changed", template.GetKind(), template.GetName()))
+		}
+	}
+
+	currentObj, err := toTopologyPlanUnstructured(current.Object)
+	if err != nil {
+		return err
+	}
+	desiredObj, err := toTopologyPlanUnstructured(desired.Object)
+	if err != nil {
+		return err
+	}
+	mergedObj := currentObj.DeepCopy()
+	mergeTopologyPlanMap(mergedObj.Object, desiredObj.Object)
+	merged := &clusterv1.MachineDeployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(mergedObj.Object, merged); err != nil {
+		return errors.Wrapf(err, "failed to convert MachineDeployment %s", klog.KObj(current.Object))
+	}
+	if upToDate, _, conditionMessages := mdutil.MachineTemplateUpToDate(&current.Object.Spec.Template, &merged.Spec.Template); !upToDate {
+		reasons = append(reasons, conditionMessages...)
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	machines, err := topologyPlanMachines(ctx, c, client.MatchingLabels{
+		clusterv1.ClusterNameLabel:           current.Object.Spec.ClusterName,
+		clusterv1.MachineDeploymentNameLabel: current.Object.Name,
+	}, current.Object.Namespace)
+	if err != nil {
+		return err
+	}
+	p.Rollouts = append(p.Rollouts, TopologyMachineRollout{
+		Owner: corev1.ObjectReference{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "MachineDeployment",
+			Namespace:  current.Object.Namespace,
+			Name:       current.Object.Name,
+		},
+		Machines: machines,
+		Reasons:  reasons,
+	})
+	return nil
+}
+
+func topologyPlanMachines(ctx context.Context, c client.Client, selector client.MatchingLabels, namespace string) ([]string, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := c.List(ctx, machineList, client.InNamespace(namespace), selector); err != nil {
+		return nil, errors.Wrap(err, "failed to list Machines")
+	}
+	machines := make([]string, 0, len(machineList.Items))
+	for _, m := range machineList.Items {
+		machines = append(machines, m.Name)
+	}
+	sort.Strings(machines)
+	return machines, nil
+}
+
+// toTopologyPlanUnstructured converts an object to unstructured, going through JSON so values
+// have the same types independently of the source object.
+func toTopologyPlanUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		gvks, _, err := topologyPlanScheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get GroupVersionKind of %T", obj)
+		}
+		gvk = gvks[0]
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s %s", gvk.Kind, klog.KObj(obj))
+	}
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(raw, &u.Object); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s %s", gvk.Kind, klog.KObj(obj))
+	}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// mergeTopologyPlanMap recursively applies the values of src on top of dst; lists are replaced.
+func mergeTopologyPlanMap(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			if dstMap, ok := dst[k].(map[string]interface{}); ok {
+				mergeTopologyPlanMap(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// topologyPlanFields returns the fields of an object which are compared to compute a plan: labels, annotations and spec.
+func topologyPlanFields(obj *unstructured.Unstructured) map[string]interface{} {
+	labels, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "metadata", "labels")
+	annotations, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "metadata", "annotations")
+	fields := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      labels,
+			"annotations": annotations,
+		},
+		"spec": obj.Object["spec"],
+	}
+	pruneTopologyPlanMap(fields)
+	return fields
+}
+
+// pruneTopologyPlanMap removes nil values and empty maps, which are not meaningful when computing a plan.
+func pruneTopologyPlanMap(m map[string]interface{}) {
+	for k, v := range m {
+		if nested, ok := v.(map[string]interface{}); ok {
+			pruneTopologyPlanMap(nested)
+			if len(nested) == 0 {
+				delete(m, k)
+			}
+			continue
+		}
+		if v == nil {
+			delete(m, k)
+		}
+	}
+}
+
+func isNilObject(obj client.Object) bool {
+	if obj == nil {
+		return true
+	}
+	v := reflect.ValueOf(obj)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+func keysFor(objs []*unstructured.Unstructured) []topologyPlanObjectKey {
+	keys := make([]topologyPlanObjectKey, 0, len(objs))
+	for _, obj := range objs {
+		keys = append(keys, topologyPlanKeyFor(obj))
+	}
+	return keys
+}
+
+func sortedObjectKeys(keys sets.Set[client.ObjectKey]) []client.ObjectKey {
+	out := keys.UnsortedList()
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].String() < out[j].String()
+	})
+	return out
+}
+
+func sortedTopologyNames[T any](current, desired map[string]T) []string {
+	names := sets.Set[string]{}
+	for name := range current {
+		names.Insert(name)
+	}
+	for name := range desired {
+		names.Insert(name)
+	}
+	return sets.List(names)
+}
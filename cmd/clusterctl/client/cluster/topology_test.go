@@ -0,0 +1,356 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	"sigs.k8s.io/cluster-api/exp/topology/scope"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func Test_topologyClient_Plan(t *testing.T) {
+	infrastructureClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra-cluster-template").Build()
+	controlPlaneTemplate := builder.ControlPlaneTemplate(metav1.NamespaceDefault, "control-plane-template").Build()
+	controlPlaneInfrastructureMachineTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "control-plane-infra-machine-template").Build()
+	workerInfrastructureMachineTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "worker-infra-machine-template").Build()
+	workerBootstrapTemplate := builder.BootstrapTemplate(metav1.NamespaceDefault, "worker-bootstrap-template").Build()
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithInfrastructureClusterTemplate(infrastructureClusterTemplate).
+		WithControlPlaneTemplate(controlPlaneTemplate).
+		WithControlPlaneInfrastructureMachineTemplate(controlPlaneInfrastructureMachineTemplate).
+		WithWorkerMachineDeploymentClasses(*builder.MachineDeploymentClass("workers").
+			WithInfrastructureTemplate(workerInfrastructureMachineTemplate).
+			WithBootstrapTemplate(workerBootstrapTemplate).
+			Build()).
+		Build()
+	newCluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+		WithTopology(builder.ClusterTopology().
+			WithClass("class1").
+			WithVersion("v1.33.0").
+			WithMachineDeployment(builder.MachineDeploymentTopology("md1").WithClass("workers").WithReplicas(3).Build()).
+			Build()).
+		Build()
+	clusterWithoutTopology := builder.Cluster(metav1.NamespaceDefault, "cluster2").Build()
+
+	snapshot := toTopologyPlanTestObjects(t,
+		infrastructureClusterTemplate,
+		controlPlaneTemplate,
+		controlPlaneInfrastructureMachineTemplate,
+		workerInfrastructureMachineTemplate,
+		workerBootstrapTemplate,
+		clusterClass,
+	)
+
+	tests := []struct {
+		name               string
+		in                 *TopologyPlanInput
+		wantClusterClasses []client.ObjectKey
+		wantClusters       []client.ObjectKey
+		wantChanges        map[string]TopologyChangeType
+		wantErr            string
+	}{
+		{
+			name:    "fails without objects in input",
+			in:      &TopologyPlanInput{Snapshot: snapshot},
+			wantErr: "at least one object is required",
+		},
+		{
+			name: "fails with objects using an older API version",
+			in: &TopologyPlanInput{
+				Objs: []*unstructured.Unstructured{{Object: map[string]interface{}{
+					"apiVersion": "cluster.x-k8s.io/v1beta1",
+					"kind":       "Cluster",
+					"metadata":   map[string]interface{}{"name": "cluster1"},
+				}}},
+				Snapshot: snapshot,
+			},
+			wantErr: "only cluster.x-k8s.io/v1beta2 objects are supported",
+		},
+		{
+			name: "computes the objects to be created for a new Cluster",
+			in: &TopologyPlanInput{
+				Objs:            toTopologyPlanTestObjects(t, newCluster),
+				Snapshot:        snapshot,
+				TargetNamespace: metav1.NamespaceDefault,
+			},
+			wantClusters: []client.ObjectKey{{Namespace: metav1.NamespaceDefault, Name: "cluster1"}},
+			wantChanges: map[string]TopologyChangeType{
+				"Cluster":                                        TopologyObjectCreated,
+				builder.GenericInfrastructureClusterKind:         TopologyObjectCreated,
+				builder.GenericControlPlaneKind:                  TopologyObjectCreated,
+				builder.GenericInfrastructureMachineTemplateKind: TopologyObjectCreated,
+				builder.GenericBootstrapConfigTemplateKind:       TopologyObjectCreated,
+				"MachineDeployment":                              TopologyObjectCreated,
+			},
+		},
+		{
+			name: "ignores Clusters without a managed topology",
+			in: &TopologyPlanInput{
+				Objs:            toTopologyPlanTestObjects(t, clusterWithoutTopology),
+				Snapshot:        snapshot,
+				TargetNamespace: metav1.NamespaceDefault,
+			},
+		},
+		{
+			name: "reports the ClusterClasses using a modified template",
+			in: &TopologyPlanInput{
+				Objs:            toTopologyPlanTestObjects(t, workerBootstrapTemplate),
+				Snapshot:        append(snapshot, toTopologyPlanTestObjects(t, newCluster)...),
+				TargetNamespace: metav1.NamespaceDefault,
+			},
+			wantClusterClasses: []client.ObjectKey{{Namespace: metav1.NamespaceDefault, Name: "class1"}},
+			wantClusters:       []client.ObjectKey{{Namespace: metav1.NamespaceDefault, Name: "cluster1"}},
+		},
+		{
+			name: "fails if the target Cluster is not affected by the changes",
+			in: &TopologyPlanInput{
+				Objs:              toTopologyPlanTestObjects(t, newCluster),
+				Snapshot:          snapshot,
+				TargetClusterName: "another-cluster",
+				TargetNamespace:   metav1.NamespaceDefault,
+			},
+			wantErr: "Cluster default/another-cluster is not affected by the changes in input",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			out, err := newTopologyClient(nil).Plan(ctx, tt.in)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(out.ClusterClasses).To(ConsistOf(tt.wantClusterClasses))
+			clusters := []client.ObjectKey{}
+			for _, cluster := range out.Clusters {
+				clusters = append(clusters, cluster.Cluster)
+			}
+			g.Expect(clusters).To(ConsistOf(tt.wantClusters))
+
+			if tt.wantChanges != nil {
+				changes := map[string]TopologyChangeType{}
+				for _, change := range out.Clusters[0].Changes {
+					changes[change.Object.Kind] = change.Type
+				}
+				g.Expect(changes).To(Equal(tt.wantChanges))
+			}
+		})
+	}
+}
+
+func Test_computeTopologyClusterPlan(t *testing.T) {
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+	infrastructureMachineTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md1-infra").
+		WithSpecFields(map[string]interface{}{"spec.template.spec.size": "small"}).
+		Build()
+	bootstrapTemplate := builder.BootstrapTemplate(metav1.NamespaceDefault, "md1-bootstrap").Build()
+	machineDeployment := builder.MachineDeployment(metav1.NamespaceDefault, "md1").
+		WithClusterName("cluster1").
+		WithVersion("v1.32.0").
+		WithInfrastructureTemplate(infrastructureMachineTemplate).
+		WithBootstrapTemplate(bootstrapTemplate).
+		Build()
+	machine := builder.Machine(metav1.NamespaceDefault, "md1-machine1").
+		WithLabels(map[string]string{
+			clusterv1.ClusterNameLabel:           "cluster1",
+			clusterv1.MachineDeploymentNameLabel: "md1",
+		}).
+		Build()
+
+	modifiedInfrastructureMachineTemplate := infrastructureMachineTemplate.DeepCopy()
+	g := NewWithT(t)
+	g.Expect(unstructured.SetNestedField(modifiedInfrastructureMachineTemplate.Object, "large", "spec", "template", "spec", "size")).To(Succeed())
+	upgradedMachineDeployment := machineDeployment.DeepCopy()
+	upgradedMachineDeployment.Spec.Template.Spec.Version = ptr.To("v1.33.0")
+	labeledBootstrapTemplate := bootstrapTemplate.DeepCopy()
+	labeledBootstrapTemplate.SetLabels(map[string]string{"foo": "bar"})
+
+	tests := []struct {
+		name         string
+		current      *scope.MachineDeploymentState
+		desired      *scope.MachineDeploymentState
+		wantChanges  []TopologyChangeType
+		wantRollout  bool
+		wantReasons  []string
+		wantMachines []string
+	}{
+		{
+			name:    "no changes",
+			current: &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+			desired: &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+		},
+		{
+			name:         "rotates the template with spec changes and rolls out the Machines",
+			current:      &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+			desired:      &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: modifiedInfrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+			wantChanges:  []TopologyChangeType{TopologyObjectRotated},
+			wantRollout:  true,
+			wantReasons:  []string{builder.GenericInfrastructureMachineTemplateKind + " md1-infra changed"},
+			wantMachines: []string{"md1-machine1"},
+		},
+		{
+			name:        "modifies the template with only metadata changes without rolling out the Machines",
+			current:     &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+			desired:     &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: labeledBootstrapTemplate},
+			wantChanges: []TopologyChangeType{TopologyObjectModified},
+		},
+		{
+			name:         "modifies the MachineDeployment and rolls out the Machines on version changes",
+			current:      &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+			desired:      &scope.MachineDeploymentState{Object: upgradedMachineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+			wantChanges:  []TopologyChangeType{TopologyObjectModified},
+			wantRollout:  true,
+			wantReasons:  []string{"Version v1.32.0, v1.33.0 required"},
+			wantMachines: []string{"md1-machine1"},
+		},
+		{
+			name:        "deletes the MachineDeployment and its templates",
+			current:     &scope.MachineDeploymentState{Object: machineDeployment, InfrastructureMachineTemplate: infrastructureMachineTemplate, BootstrapTemplate: bootstrapTemplate},
+			desired:     nil,
+			wantChanges: []TopologyChangeType{TopologyObjectDeleted, TopologyObjectDeleted, TopologyObjectDeleted},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			c := fake.NewClientBuilder().WithScheme(topologyPlanScheme).WithObjects(machine).Build()
+			s := scope.New(cluster)
+			s.Current.ControlPlane = &scope.ControlPlaneState{}
+			s.Current.MachineDeployments = scope.MachineDeploymentsStateMap{"md1": tt.current}
+			s.Desired = &scope.ClusterState{Cluster: cluster, ControlPlane: &scope.ControlPlaneState{}, MachineDeployments: scope.MachineDeploymentsStateMap{}}
+			if tt.desired != nil {
+				s.Desired.MachineDeployments["md1"] = tt.desired
+			}
+
+			plan, err := computeTopologyClusterPlan(ctx, c, s, false)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			changes := []TopologyChangeType{}
+			for _, change := range plan.Changes {
+				changes = append(changes, change.Type)
+				if change.Type == TopologyObjectModified || change.Type == TopologyObjectRotated {
+					g.Expect(change.Diff).ToNot(BeEmpty())
+				}
+			}
+			g.Expect(changes).To(ConsistOf(tt.wantChanges))
+
+			if !tt.wantRollout {
+				g.Expect(plan.Rollouts).To(BeEmpty())
+				return
+			}
+			g.Expect(plan.Rollouts).To(HaveLen(1))
+			g.Expect(plan.Rollouts[0].Owner.Name).To(Equal("md1"))
+			g.Expect(plan.Rollouts[0].Reasons).To(Equal(tt.wantReasons))
+			g.Expect(plan.Rollouts[0].Machines).To(Equal(tt.wantMachines))
+		})
+	}
+}
+
+func Test_newTopologyPlanRuntimeClient(t *testing.T) {
+	extensionConfig := &runtimev1.ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "extension1",
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			ClientConfig: runtimev1.ClientConfig{
+				URL: ptr.To("https://extension1.example.com"),
+			},
+		},
+		Status: runtimev1.ExtensionConfigStatus{
+			Handlers: []runtimev1.ExtensionHandler{
+				{
+					Name: "generate-patches",
+					RequestHook: runtimev1.GroupVersionHook{
+						APIVersion: runtimehooksv1.GroupVersion.String(),
+						Hook:       "GeneratePatches",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                           string
+		useManagementClusterExtensions bool
+		wantExtensions                 bool
+	}{
+		{
+			name:                           "ExtensionConfigs of the management cluster are not used by default",
+			useManagementClusterExtensions: false,
+			wantExtensions:                 false,
+		},
+		{
+			name:                           "ExtensionConfigs of the management cluster are used if requested",
+			useManagementClusterExtensions: true,
+			wantExtensions:                 true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(topologyPlanScheme).WithObjects(extensionConfig.DeepCopy()).Build()
+
+			runtimeClient, err := newTopologyPlanRuntimeClient(context.Background(), c, nil, tt.useManagementClusterExtensions)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(runtimeClient.hasExtensions).To(Equal(tt.wantExtensions))
+
+			featureGates, err := topologyPlanFeatureGates(runtimeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(featureGates.Enabled(feature.RuntimeSDK)).To(Equal(tt.wantExtensions))
+
+			// The process-wide feature gates must never be changed.
+			g.Expect(feature.Gates.Enabled(feature.RuntimeSDK)).To(BeFalse())
+		})
+	}
+}
+
+func toTopologyPlanTestObjects(t *testing.T, objs ...client.Object) []*unstructured.Unstructured {
+	t.Helper()
+
+	out := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		u, err := toTopologyPlanUnstructured(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, u)
+	}
+	return out
+}
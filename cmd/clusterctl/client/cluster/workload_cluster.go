@@ -18,10 +18,16 @@ package cluster
 
 import (
 	"context"
+	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	utilkubeconfig "sigs.k8s.io/cluster-api/util/kubeconfig"
 )
 
@@ -29,6 +35,25 @@ import (
 type WorkloadCluster interface {
 	// GetKubeconfig returns the kubeconfig of the workload cluster.
 	GetKubeconfig(ctx context.Context, workloadClusterName string, namespace string) (string, error)
+
+	// GetUserKubeconfig returns a kubeconfig for the workload cluster with a client certificate
+	// signed by the cluster CA for the given user, groups and TTL.
+	GetUserKubeconfig(ctx context.Context, workloadClusterName string, namespace string, options UserKubeconfigOptions) (string, error)
+}
+
+// UserKubeconfigOptions defines the user of the kubeconfig generated by GetUserKubeconfig.
+type UserKubeconfigOptions struct {
+	// User is the name of the user, set as CommonName of the client certificate.
+	User string
+
+	// Groups are the groups of the user, set as Organization of the client certificate.
+	Groups []string
+
+	// TTL is the lifespan of the client certificate.
+	TTL time.Duration
+
+	// AllowSystemMasters allows the system:masters group in Groups.
+	AllowSystemMasters bool
 }
 
 // workloadCluster implements WorkloadCluster.
@@ -59,3 +84,65 @@ func (p *workloadCluster) GetKubeconfig(ctx context.Context, workloadClusterName
 	}
 	return string(dataBytes), nil
 }
+
+func (p *workloadCluster) GetUserKubeconfig(ctx context.Context, workloadClusterName string, namespace string, options UserKubeconfigOptions) (string, error) {
+	cs, err := p.proxy.NewClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	cluster := &clusterv1.Cluster{}
+	key := client.ObjectKey{
+		Namespace: namespace,
+		Name:      workloadClusterName,
+	}
+	if err := cs.Get(ctx, key, cluster); err != nil {
+		return "", errors.Wrapf(err, "failed to get Cluster %s/%s", namespace, workloadClusterName)
+	}
+	if !cluster.Spec.ControlPlaneEndpoint.IsValid() {
+		return "", errors.Errorf("control plane endpoint of Cluster %s/%s is not set yet", namespace, workloadClusterName)
+	}
+
+	server, err := url.JoinPath("https://", cluster.Spec.ControlPlaneEndpoint.String())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to compute the server URL of Cluster %s/%s", namespace, workloadClusterName)
+	}
+
+	encryptionAlgorithm, err := getKeyEncryptionAlgorithm(ctx, cs, cluster)
+	if err != nil {
+		return "", err
+	}
+
+	dataBytes, err := utilkubeconfig.GenerateForUser(ctx, cs, key, server, utilkubeconfig.User{
+		Name:               options.User,
+		Groups:             options.Groups,
+		TTL:                options.TTL,
+		AllowSystemMasters: options.AllowSystemMasters,
+	}, utilkubeconfig.WithEncryptionAlgorithm(encryptionAlgorithm))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to generate kubeconfig for user %q of Cluster %s/%s", options.User, namespace, workloadClusterName)
+	}
+	return string(dataBytes), nil
+}
+
+// getKeyEncryptionAlgorithm returns the encryption algorithm configured for the keys and certificates of the Cluster.
+// The encryption algorithm is read from the KubeadmControlPlane of the Cluster; an empty value, i.e. the default
+// encryption algorithm, is returned for other control plane providers.
+func getKeyEncryptionAlgorithm(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) (bootstrapv1.EncryptionAlgorithmType, error) {
+	ref := cluster.Spec.ControlPlaneRef
+	if ref == nil || ref.APIGroup != controlplanev1.GroupVersion.Group || ref.Kind != "KubeadmControlPlane" {
+		return "", nil
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, kcp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get KubeadmControlPlane %s/%s", cluster.Namespace, ref.Name)
+	}
+	if kcp.Spec.KubeadmConfigSpec.ClusterConfiguration == nil {
+		return "", nil
+	}
+	return kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.EncryptionAlgorithm, nil
+}
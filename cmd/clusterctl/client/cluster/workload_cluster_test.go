@@ -18,14 +18,23 @@ package cluster
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/secret"
 )
 
@@ -100,3 +109,153 @@ users:
 		})
 	}
 }
+
+func Test_WorkloadCluster_GetUserKubeconfig(t *testing.T) {
+	caCertificates := secret.Certificates{&secret.Certificate{Purpose: secret.ClusterCA}}
+	NewWithT(t).Expect(caCertificates.Generate()).To(Succeed())
+	caSecret := caCertificates[0].AsSecret(client.ObjectKey{Name: "test1", Namespace: "test"}, metav1.OwnerReference{})
+
+	caCert, err := certs.DecodeCertPEM(caCertificates[0].KeyPair.Cert)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	cluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1",
+			Namespace: "test",
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "test-cluster-api", Port: 6443},
+		},
+	}
+	clusterWithoutEndpoint := cluster.DeepCopy()
+	clusterWithoutEndpoint.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{}
+
+	clusterWithKCP := cluster.DeepCopy()
+	clusterWithKCP.Spec.ControlPlaneRef = &clusterv1.ContractVersionedObjectReference{
+		APIGroup: controlplanev1.GroupVersion.Group,
+		Kind:     "KubeadmControlPlane",
+		Name:     "test1",
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: controlplanev1.GroupVersion.String(),
+			Kind:       "KubeadmControlPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1",
+			Namespace: "test",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					EncryptionAlgorithm: bootstrapv1.EncryptionAlgorithmECDSAP256,
+				},
+			},
+		},
+	}
+
+	options := UserKubeconfigOptions{
+		User:   "jane",
+		Groups: []string{"devs"},
+		TTL:    time.Hour,
+	}
+
+	tests := []struct {
+		name        string
+		options     UserKubeconfigOptions
+		expectErr   bool
+		expectECDSA bool
+		proxy       Proxy
+	}{
+		{
+			name:      "return a kubeconfig signed by the cluster CA",
+			options:   options,
+			expectErr: false,
+			proxy:     test.NewFakeProxy().WithObjs(cluster, caSecret),
+		},
+		{
+			name:        "return a kubeconfig with a key using the encryption algorithm of the KubeadmControlPlane",
+			options:     options,
+			expectErr:   false,
+			expectECDSA: true,
+			proxy:       test.NewFakeProxy().WithObjs(clusterWithKCP, kcp, caSecret),
+		},
+		{
+			name: "return a kubeconfig for a member of system:masters if explicitly allowed",
+			options: UserKubeconfigOptions{
+				User:               "jane",
+				Groups:             []string{"devs", "system:masters"},
+				TTL:                time.Hour,
+				AllowSystemMasters: true,
+			},
+			expectErr: false,
+			proxy:     test.NewFakeProxy().WithObjs(cluster, caSecret),
+		},
+		{
+			name: "return error for a member of system:masters if not explicitly allowed",
+			options: UserKubeconfigOptions{
+				User:   "jane",
+				Groups: []string{"devs", "system:masters"},
+				TTL:    time.Hour,
+			},
+			expectErr: true,
+			proxy:     test.NewFakeProxy().WithObjs(cluster, caSecret),
+		},
+		{
+			name:      "return error if cannot find the cluster",
+			options:   options,
+			expectErr: true,
+			proxy:     test.NewFakeProxy().WithObjs(caSecret),
+		},
+		{
+			name:      "return error if the cluster does not have a control plane endpoint",
+			options:   options,
+			expectErr: true,
+			proxy:     test.NewFakeProxy().WithObjs(clusterWithoutEndpoint, caSecret),
+		},
+		{
+			name:      "return error if cannot find the cluster CA",
+			options:   options,
+			expectErr: true,
+			proxy:     test.NewFakeProxy().WithObjs(cluster),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			wc := newWorkloadCluster(tt.proxy)
+			data, err := wc.GetUserKubeconfig(ctx, "test1", "test", tt.options)
+
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			restConfig, err := clientcmd.RESTConfigFromKubeConfig([]byte(data))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(restConfig.Host).To(Equal("https://test-cluster-api:6443"))
+
+			cert, err := certs.DecodeCertPEM(restConfig.CertData)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(cert.CheckSignatureFrom(caCert)).To(Succeed())
+			g.Expect(cert.Subject.CommonName).To(Equal("jane"))
+			g.Expect(cert.Subject.Organization).To(ConsistOf(tt.options.Groups))
+			g.Expect(cert.ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageClientAuth))
+			g.Expect(cert.NotAfter).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			if tt.expectECDSA {
+				g.Expect(cert.PublicKey).To(BeAssignableToTypeOf(&ecdsa.PublicKey{}))
+			} else {
+				g.Expect(cert.PublicKey).To(BeAssignableToTypeOf(&rsa.PublicKey{}))
+			}
+		})
+	}
+}
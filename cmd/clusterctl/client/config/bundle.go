@@ -0,0 +1,258 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+const (
+	// BundleIndexFileName is the name of the file describing the content of a provider bundle.
+	BundleIndexFileName = "bundle.yaml"
+
+	// BundleImagesFileName is the name of the file listing the container images required by a provider bundle.
+	BundleImagesFileName = "images.txt"
+
+	// CertManagerBundleLabel is the name of the directory storing the cert-manager components in a provider bundle.
+	CertManagerBundleLabel = "cert-manager"
+)
+
+// Bundle describes the content of a provider bundle, a directory containing everything required to install
+// or upgrade providers in air-gapped environments.
+// Files in a bundle are organized according to the layout of local repositories, {bundle}/{provider-label}/{version}/{file}.
+type Bundle struct {
+	// Providers is the list of provider versions stored in the bundle.
+	Providers []BundleProvider `json:"providers,omitempty"`
+
+	// CertManager is the cert-manager version stored in the bundle, if any.
+	CertManager *BundleCertManager `json:"certManager,omitempty"`
+}
+
+// BundleProvider describes a provider version stored in a bundle.
+type BundleProvider struct {
+	// Name of the provider.
+	Name string `json:"name"`
+
+	// Type of the provider.
+	Type clusterctlv1.ProviderType `json:"type"`
+
+	// Version of the provider.
+	Version string `json:"version"`
+
+	// ComponentsFile is the name of the file with the provider components.
+	ComponentsFile string `json:"componentsFile"`
+}
+
+// ManifestLabel returns the name of the directory storing the provider in a bundle.
+func (p BundleProvider) ManifestLabel() string {
+	return clusterctlv1.ManifestLabel(p.Name, p.Type)
+}
+
+// BundleCertManager describes the cert-manager version stored in a bundle.
+type BundleCertManager struct {
+	// Version of cert-manager.
+	Version string `json:"version"`
+
+	// ComponentsFile is the name of the file with the cert-manager components.
+	ComponentsFile string `json:"componentsFile"`
+}
+
+// ReadBundle reads the index of the bundle stored in the given directory.
+func ReadBundle(directory string) (*Bundle, error) {
+	data, err := os.ReadFile(filepath.Join(directory, BundleIndexFileName)) //nolint:gosec // The bundle directory is provided by the user.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the index of the bundle %q", directory)
+	}
+	bundle := &Bundle{}
+	if err := yaml.UnmarshalStrict(data, bundle); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the index of the bundle %q", directory)
+	}
+	for _, p := range bundle.Providers {
+		if p.Name == "" || p.Type == "" || p.Version == "" || p.ComponentsFile == "" {
+			return nil, errors.Errorf("invalid index of the bundle %q: name, type, version and componentsFile must be set for all the providers", directory)
+		}
+	}
+	if bundle.CertManager != nil && (bundle.CertManager.Version == "" || bundle.CertManager.ComponentsFile == "") {
+		return nil, errors.Errorf("invalid index of the bundle %q: version and componentsFile must be set for cert-manager", directory)
+	}
+	return bundle, nil
+}
+
+// WriteBundle writes the index of the bundle stored in the given directory.
+func WriteBundle(directory string, bundle *Bundle) error {
+	sort.Slice(bundle.Providers, func(i, j int) bool {
+		if bundle.Providers[i].ManifestLabel() != bundle.Providers[j].ManifestLabel() {
+			return bundle.Providers[i].ManifestLabel() < bundle.Providers[j].ManifestLabel()
+		}
+		return bundle.Providers[i].Version < bundle.Providers[j].Version
+	})
+	data, err := yaml.Marshal(bundle)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the bundle index")
+	}
+	if err := os.WriteFile(filepath.Join(directory, BundleIndexFileName), data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the index of the bundle %q", directory)
+	}
+	return nil
+}
+
+// AddProvider adds a provider version to the bundle, replacing the existing entry for the same version, if any.
+func (b *Bundle) AddProvider(provider BundleProvider) {
+	for i, p := range b.Providers {
+		if p.Name == provider.Name && p.Type == provider.Type && p.Version == provider.Version {
+			b.Providers[i] = provider
+			return
+		}
+	}
+	b.Providers = append(b.Providers, provider)
+}
+
+// latestProviders returns the latest version stored in the bundle for each provider.
+func (b *Bundle) latestProviders() ([]BundleProvider, error) {
+	latest := map[string]BundleProvider{}
+	latestVersion := map[string]*version.Version{}
+	for _, p := range b.Providers {
+		v, err := version.ParseSemantic(p.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid version %q for provider %s in the bundle index", p.Version, p.ManifestLabel())
+		}
+		if current, ok := latestVersion[p.ManifestLabel()]; ok && !current.LessThan(v) {
+			continue
+		}
+		latest[p.ManifestLabel()] = p
+		latestVersion[p.ManifestLabel()] = v
+	}
+
+	providers := make([]BundleProvider, 0, len(latest))
+	for _, p := range latest {
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].ManifestLabel() < providers[j].ManifestLabel()
+	})
+	return providers, nil
+}
+
+// bundleReader is a Reader that overrides the repositories of the providers and of cert-manager with the
+// local repositories stored in a bundle; if an image repository is set, it also overrides the repository
+// of all the images.
+type bundleReader struct {
+	Reader
+
+	directory       string
+	imageRepository string
+	bundle          *Bundle
+}
+
+var _ Reader = &bundleReader{}
+
+func newBundleReader(reader Reader, directory, imageRepository string) (*bundleReader, error) {
+	absDirectory, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the absolute path of the bundle %q", directory)
+	}
+	bundle, err := ReadBundle(absDirectory)
+	if err != nil {
+		return nil, err
+	}
+	return &bundleReader{
+		Reader:          reader,
+		directory:       absDirectory,
+		imageRepository: imageRepository,
+		bundle:          bundle,
+	}, nil
+}
+
+// UnmarshalKey reads a configuration value from the underlying reader, applies the overrides
+// derived from the bundle and then unmarshals it into the provided value object.
+func (r *bundleReader) UnmarshalKey(key string, value interface{}) error {
+	var overridden interface{}
+	switch key {
+	case ProvidersConfigKey:
+		providers := []configProvider{}
+		if err := r.Reader.UnmarshalKey(key, &providers); err != nil {
+			return err
+		}
+		bundleProviders, err := r.bundle.latestProviders()
+		if err != nil {
+			return err
+		}
+		for _, b := range bundleProviders {
+			provider := configProvider{
+				Name: b.Name,
+				URL:  filepath.Join(r.directory, b.ManifestLabel(), b.Version, b.ComponentsFile),
+				Type: b.Type,
+			}
+			override := false
+			for i := range providers {
+				if providers[i].Name == provider.Name && providers[i].Type == provider.Type {
+					providers[i] = provider
+					override = true
+				}
+			}
+			if !override {
+				providers = append(providers, provider)
+			}
+		}
+		overridden = providers
+	case CertManagerConfigKey:
+		if r.bundle.CertManager == nil {
+			return r.Reader.UnmarshalKey(key, value)
+		}
+		certManager := &configCertManager{}
+		if err := r.Reader.UnmarshalKey(key, &certManager); err != nil {
+			return err
+		}
+		if certManager == nil {
+			certManager = &configCertManager{}
+		}
+		certManager.URL = filepath.Join(r.directory, CertManagerBundleLabel, r.bundle.CertManager.Version, r.bundle.CertManager.ComponentsFile)
+		certManager.Version = r.bundle.CertManager.Version
+		overridden = certManager
+	case imagesConfigKey:
+		if r.imageRepository == "" {
+			return r.Reader.UnmarshalKey(key, value)
+		}
+		meta := map[string]imageMeta{}
+		if err := r.Reader.UnmarshalKey(key, &meta); err != nil {
+			return err
+		}
+		if meta == nil {
+			meta = map[string]imageMeta{}
+		}
+		allMeta := meta[allImageConfig]
+		allMeta.Repository = r.imageRepository
+		meta[allImageConfig] = allMeta
+		overridden = meta
+	default:
+		return r.Reader.UnmarshalKey(key, value)
+	}
+
+	data, err := yaml.Marshal(overridden)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize %q after applying the bundle overrides", key)
+	}
+	return yaml.Unmarshal(data, value)
+}
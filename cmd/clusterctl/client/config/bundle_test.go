@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func TestReadBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   string
+		wantErr bool
+	}{
+		{
+			name: "valid bundle",
+			index: `providers:
+- name: cluster-api
+  type: CoreProvider
+  version: v1.0.0
+  componentsFile: core-components.yaml
+certManager:
+  version: v1.0.0
+  componentsFile: cert-manager.yaml
+`,
+		},
+		{
+			name: "fails if a provider has no components file",
+			index: `providers:
+- name: cluster-api
+  type: CoreProvider
+  version: v1.0.0
+`,
+			wantErr: true,
+		},
+		{
+			name: "fails if cert-manager has no version",
+			index: `certManager:
+  componentsFile: cert-manager.yaml
+`,
+			wantErr: true,
+		},
+		{
+			name:    "fails for unknown fields",
+			index:   `foo: bar`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir := t.TempDir()
+			g.Expect(os.WriteFile(filepath.Join(dir, BundleIndexFileName), []byte(tt.index), 0600)).To(Succeed())
+
+			_, err := ReadBundle(dir)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestBundleReader(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	bundle := &Bundle{
+		CertManager: &BundleCertManager{Version: "v1.0.0", ComponentsFile: "cert-manager.yaml"},
+	}
+	bundle.AddProvider(BundleProvider{Name: ClusterAPIProviderName, Type: clusterctlv1.CoreProviderType, Version: "v1.0.0", ComponentsFile: "core-components.yaml"})
+	bundle.AddProvider(BundleProvider{Name: ClusterAPIProviderName, Type: clusterctlv1.CoreProviderType, Version: "v1.10.0", ComponentsFile: "core-components.yaml"})
+	bundle.AddProvider(BundleProvider{Name: ClusterAPIProviderName, Type: clusterctlv1.CoreProviderType, Version: "v1.9.0", ComponentsFile: "core-components.yaml"})
+	bundle.AddProvider(BundleProvider{Name: "foo", Type: clusterctlv1.InfrastructureProviderType, Version: "v0.1.0", ComponentsFile: "infrastructure-components.yaml"})
+	g.Expect(WriteBundle(dir, bundle)).To(Succeed())
+
+	reader := test.NewFakeReader().
+		WithProvider("bar", clusterctlv1.InfrastructureProviderType, "https://example.com/bar/latest/infrastructure-components.yaml").
+		WithCertManager("https://example.com/cert-manager.yaml", "v0.1.0", "5m").
+		WithImageMeta(CertManagerImageComponent, "", "v9.9.9")
+
+	client, err := newConfigClient(context.Background(), "", InjectReader(reader), InjectBundle(dir, "registry.example.com/capi"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Providers in the bundle are read from the bundle, using the latest version; other providers are not changed.
+	provider, err := client.Providers().Get(ClusterAPIProviderName, clusterctlv1.CoreProviderType)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(provider.URL()).To(Equal(filepath.Join(dir, "cluster-api", "v1.10.0", "core-components.yaml")))
+
+	provider, err = client.Providers().Get("foo", clusterctlv1.InfrastructureProviderType)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(provider.URL()).To(Equal(filepath.Join(dir, "infrastructure-foo", "v0.1.0", "infrastructure-components.yaml")))
+
+	provider, err = client.Providers().Get("bar", clusterctlv1.InfrastructureProviderType)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(provider.URL()).To(Equal("https://example.com/bar/latest/infrastructure-components.yaml"))
+
+	// cert-manager is read from the bundle, preserving the other settings.
+	certManager, err := client.CertManager().Get()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(certManager).To(Equal(NewCertManager(filepath.Join(dir, "cert-manager", "v1.0.0", "cert-manager.yaml"), "v1.0.0", "5m")))
+
+	// All the images are pulled from the image repository, preserving the other image overrides.
+	image, err := client.ImageMeta().AlterImage(CertManagerImageComponent, "quay.io/jetstack/cert-manager-controller:v1.0.0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(image).To(Equal("registry.example.com/capi/cert-manager-controller:v9.9.9"))
+
+	image, err = client.ImageMeta().AlterImage("infrastructure-foo", "registry.k8s.io/foo/foo-controller:v0.1.0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(image).To(Equal("registry.example.com/capi/foo-controller:v0.1.0"))
+}
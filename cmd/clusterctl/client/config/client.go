@@ -45,6 +45,9 @@ type Client interface {
 // configClient implements Client.
 type configClient struct {
 	reader Reader
+
+	bundleDirectory       string
+	bundleImageRepository string
 }
 
 // ensure configClient implements Client.
@@ -76,6 +79,15 @@ func InjectReader(reader Reader) Option {
 	}
 }
 
+// InjectBundle instructs clusterctl to read providers and cert-manager from the bundle stored in the given directory
+// instead of from their remote repositories; if imageRepository is not empty, it is used for all the images.
+func InjectBundle(directory, imageRepository string) Option {
+	return func(c *configClient) {
+		c.bundleDirectory = directory
+		c.bundleImageRepository = imageRepository
+	}
+}
+
 // New returns a Client for interacting with the clusterctl configuration.
 func New(ctx context.Context, path string, options ...Option) (Client, error) {
 	return newConfigClient(ctx, path, options...)
@@ -98,6 +110,13 @@ func newConfigClient(ctx context.Context, path string, options ...Option) (*conf
 		}
 	}
 
+	// if a bundle is configured, read providers and cert-manager from it.
+	if client.bundleDirectory != "" {
+		if client.reader, err = newBundleReader(client.reader, client.bundleDirectory, client.bundleImageRepository); err != nil {
+			return nil, err
+		}
+	}
+
 	return client, nil
 }
 
@@ -21,6 +21,8 @@ const (
 	GitHubTokenVariable = "github-token"
 	// GitLabAccessTokenVariable defines a variable hosting the GitLab access token. This can be used with Personal and Project access tokens.
 	GitLabAccessTokenVariable = "gitlab-access-token"
+	// DockerConfigVariable defines a variable hosting the directory of the docker config file used to authenticate to OCI registries.
+	DockerConfigVariable = "DOCKER_CONFIG"
 )
 
 // VariablesClient has methods to work with environment variables and with variables defined in the clusterctl configuration file.
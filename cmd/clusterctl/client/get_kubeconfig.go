@@ -18,10 +18,16 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 )
 
+// DefaultUserKubeconfigTTL is the default lifespan of the client certificate of a kubeconfig generated for a user.
+const DefaultUserKubeconfigTTL = 24 * time.Hour
+
 // GetKubeconfigOptions carries all the options supported by GetKubeconfig.
 type GetKubeconfigOptions struct {
 	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
@@ -33,9 +39,30 @@ type GetKubeconfigOptions struct {
 
 	// WorkloadClusterName is the name of the workload cluster.
 	WorkloadClusterName string
+
+	// User is the name of the user for which a kubeconfig with a client certificate signed by the cluster CA
+	// should be generated. If empty, the admin kubeconfig of the workload cluster is returned.
+	User string
+
+	// Groups are the groups of User.
+	Groups []string
+
+	// TTL is the lifespan of the client certificate generated for User. If zero, DefaultUserKubeconfigTTL is used.
+	TTL time.Duration
+
+	// AllowSystemMasters allows the system:masters group in Groups; members of this group have full access
+	// to the workload cluster, bypassing RBAC.
+	AllowSystemMasters bool
 }
 
 func (c *clusterctlClient) GetKubeconfig(ctx context.Context, options GetKubeconfigOptions) (string, error) {
+	if options.User == "" && (len(options.Groups) > 0 || options.TTL != 0 || options.AllowSystemMasters) {
+		return "", errors.New("groups, TTL and allowing system:masters can be specified only when generating a kubeconfig for a user")
+	}
+	if options.TTL < 0 {
+		return "", errors.New("TTL must not be negative")
+	}
+
 	// gets access to the management cluster
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -58,5 +85,16 @@ func (c *clusterctlClient) GetKubeconfig(ctx context.Context, options GetKubecon
 		options.Namespace = currentNamespace
 	}
 
+	if options.User != "" {
+		if options.TTL == 0 {
+			options.TTL = DefaultUserKubeconfigTTL
+		}
+		return clusterClient.WorkloadCluster().GetUserKubeconfig(ctx, options.WorkloadClusterName, options.Namespace, cluster.UserKubeconfigOptions{
+			User:               options.User,
+			Groups:             options.Groups,
+			TTL:                options.TTL,
+			AllowSystemMasters: options.AllowSystemMasters,
+		})
+	}
 	return clusterClient.WorkloadCluster().GetKubeconfig(ctx, options.WorkloadClusterName, options.Namespace)
 }
@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -54,6 +55,24 @@ func Test_clusterctlClient_GetKubeconfig(t *testing.T) {
 			options:   GetKubeconfigOptions{Kubeconfig: Kubeconfig(kubeconfig)},
 			expectErr: true,
 		},
+		{
+			name:      "returns error if groups are specified without a user",
+			client:    badClient,
+			options:   GetKubeconfigOptions{Kubeconfig: Kubeconfig(kubeconfig), Namespace: "default", Groups: []string{"devs"}},
+			expectErr: true,
+		},
+		{
+			name:      "returns error if system:masters is allowed without a user",
+			client:    badClient,
+			options:   GetKubeconfigOptions{Kubeconfig: Kubeconfig(kubeconfig), Namespace: "default", AllowSystemMasters: true},
+			expectErr: true,
+		},
+		{
+			name:      "returns error if TTL is negative",
+			client:    badClient,
+			options:   GetKubeconfigOptions{Kubeconfig: Kubeconfig(kubeconfig), Namespace: "default", User: "jane", TTL: -time.Hour},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
 	"context"
 	"os"
 
@@ -49,8 +50,42 @@ type MoveOptions struct {
 	// ToDirectory save configuration to directory.
 	ToDirectory string
 
+	// EncryptionPublicKeyFile is the path to a file containing one or more age recipients used for encrypting the archive
+	// written to ToDirectory.
+	EncryptionPublicKeyFile string
+
+	// DecryptionPrivateKeyFile is the path to a file containing one or more age identities used for decrypting the archive
+	// read from FromDirectory.
+	DecryptionPrivateKeyFile string
+
+	// PassphraseFile is the path to a file containing a passphrase used for encrypting the archive written to ToDirectory,
+	// or for decrypting the archive read from FromDirectory.
+	PassphraseFile string
+
+	// AllowUnverifiedFiles allows to read a FromDirectory without an archive, e.g. written by older versions of clusterctl;
+	// in this case objects are restored without being verified.
+	AllowUnverifiedFiles bool
+
 	// DryRun means the move action is a dry run, no real action will be performed.
 	DryRun bool
+
+	// JournalDirectory is a local directory where move records its progress. If the directory contains the journal
+	// of a previous move which failed, the move is resumed from the last group of objects moved successfully.
+	JournalDirectory string
+
+	// ClusterName, if set, restricts the move to the Cluster with this name and to the objects linked to it.
+	ClusterName string
+
+	// ClusterSelector, if set, restricts the move to the Clusters matching this label selector and to the objects
+	// linked to them.
+	// Note: ClusterClasses, templates and ClusterResourceSets shared with other Clusters in the namespace are copied
+	// to the target management cluster, and they are not deleted from the source management cluster.
+	ClusterSelector string
+
+	// Rollback rolls back a move which failed, using the journal in JournalDirectory: objects created by move are
+	// deleted from the target management cluster and Cluster API objects in the source management cluster are resumed.
+	// Note: A move can't be rolled back if objects have already been deleted from the source management cluster.
+	Rollback bool
 }
 
 func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error {
@@ -66,6 +101,36 @@ func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error
 		return errors.Errorf("at least one of FromDirectory, ToDirectory and ToKubeconfig must be set")
 	}
 
+	if options.EncryptionPublicKeyFile != "" && options.ToDirectory == "" {
+		return errors.Errorf("EncryptionPublicKeyFile can only be used with ToDirectory")
+	}
+	if options.DecryptionPrivateKeyFile != "" && options.FromDirectory == "" {
+		return errors.Errorf("DecryptionPrivateKeyFile can only be used with FromDirectory")
+	}
+	if options.PassphraseFile != "" && options.FromDirectory == "" && options.ToDirectory == "" {
+		return errors.Errorf("PassphraseFile can only be used with FromDirectory or ToDirectory")
+	}
+	if options.EncryptionPublicKeyFile != "" && options.PassphraseFile != "" {
+		return errors.Errorf("can't set both EncryptionPublicKeyFile and PassphraseFile")
+	}
+	if options.AllowUnverifiedFiles && options.FromDirectory == "" {
+		return errors.Errorf("AllowUnverifiedFiles can only be used with FromDirectory")
+	}
+
+	if (options.ClusterName != "" || options.ClusterSelector != "") && (options.FromDirectory != "" || options.ToDirectory != "") {
+		return errors.Errorf("ClusterName and ClusterSelector can't be used with FromDirectory or ToDirectory")
+	}
+
+	if options.Rollback {
+		if options.JournalDirectory == "" {
+			return errors.Errorf("JournalDirectory must be set when using Rollback")
+		}
+		if options.FromDirectory != "" || options.ToDirectory != "" || options.DryRun {
+			return errors.Errorf("Rollback can't be used with FromDirectory, ToDirectory or DryRun")
+		}
+		return c.rollback(ctx, options)
+	}
+
 	if options.ToDirectory != "" {
 		return c.toDirectory(ctx, options)
 	} else if options.FromDirectory != "" {
@@ -99,7 +164,38 @@ func (c *clusterctlClient) move(ctx context.Context, options MoveOptions) error
 		}
 	}
 
-	return fromCluster.ObjectMover().Move(ctx, options.Namespace, toCluster, options.DryRun, options.ExperimentalResourceMutators...)
+	return fromCluster.ObjectMover().Move(ctx, options.Namespace, toCluster, cluster.MoveOptions{
+		DryRun:           options.DryRun,
+		JournalDirectory: options.JournalDirectory,
+		ClusterName:      options.ClusterName,
+		ClusterSelector:  options.ClusterSelector,
+		Mutators:         options.ExperimentalResourceMutators,
+	})
+}
+
+func (c *clusterctlClient) rollback(ctx context.Context, options MoveOptions) error {
+	// Get the client for interacting with the source management cluster.
+	fromCluster, err := c.getClusterClient(ctx, options.FromKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	// Get the client for interacting with the target management cluster.
+	toCluster, err := c.getClusterClient(ctx, options.ToKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	return fromCluster.ObjectMover().Rollback(ctx, options.Namespace, toCluster, options.JournalDirectory)
 }
 
 func (c *clusterctlClient) fromDirectory(ctx context.Context, options MoveOptions) error {
@@ -112,7 +208,12 @@ func (c *clusterctlClient) fromDirectory(ctx context.Context, options MoveOption
 		return err
 	}
 
-	return toCluster.ObjectMover().FromDirectory(ctx, toCluster, options.FromDirectory)
+	archiveOptions, err := getArchiveOptions(options)
+	if err != nil {
+		return err
+	}
+
+	return toCluster.ObjectMover().FromDirectory(ctx, toCluster, options.FromDirectory, archiveOptions)
 }
 
 func (c *clusterctlClient) toDirectory(ctx context.Context, options MoveOptions) error {
@@ -134,7 +235,45 @@ func (c *clusterctlClient) toDirectory(ctx context.Context, options MoveOptions)
 		return err
 	}
 
-	return fromCluster.ObjectMover().ToDirectory(ctx, options.Namespace, options.ToDirectory)
+	archiveOptions, err := getArchiveOptions(options)
+	if err != nil {
+		return err
+	}
+
+	return fromCluster.ObjectMover().ToDirectory(ctx, options.Namespace, options.ToDirectory, archiveOptions)
+}
+
+// getArchiveOptions reads the keys and the passphrase for encrypting or decrypting the archive from the files in MoveOptions.
+func getArchiveOptions(options MoveOptions) (cluster.ArchiveOptions, error) {
+	archiveOptions := cluster.ArchiveOptions{
+		AllowUnverifiedFiles: options.AllowUnverifiedFiles,
+	}
+	if options.EncryptionPublicKeyFile != "" {
+		publicKey, err := os.ReadFile(options.EncryptionPublicKeyFile)
+		if err != nil {
+			return archiveOptions, errors.Wrapf(err, "failed to read public key from %s", options.EncryptionPublicKeyFile)
+		}
+		archiveOptions.PublicKey = publicKey
+	}
+	if options.DecryptionPrivateKeyFile != "" {
+		privateKey, err := os.ReadFile(options.DecryptionPrivateKeyFile)
+		if err != nil {
+			return archiveOptions, errors.Wrapf(err, "failed to read private key from %s", options.DecryptionPrivateKeyFile)
+		}
+		archiveOptions.PrivateKey = privateKey
+	}
+	if options.PassphraseFile != "" {
+		passphrase, err := os.ReadFile(options.PassphraseFile)
+		if err != nil {
+			return archiveOptions, errors.Wrapf(err, "failed to read passphrase from %s", options.PassphraseFile)
+		}
+		passphrase = bytes.TrimRight(passphrase, "\r\n")
+		if len(passphrase) == 0 {
+			return archiveOptions, errors.Errorf("passphrase in %s is empty", options.PassphraseFile)
+		}
+		archiveOptions.Passphrase = passphrase
+	}
+	return archiveOptions, nil
 }
 
 func (c *clusterctlClient) getClusterClient(ctx context.Context, kubeconfig Kubeconfig) (cluster.Client, error) {
@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -107,6 +109,66 @@ func Test_clusterctlClient_Move(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "does not return error if moving a subset of the Clusters",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					ClusterName:     "cluster1",
+					ClusterSelector: "env=dev",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "does not return error if rolling back with a journal directory",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:     Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalDirectory: "/var/cache/journal",
+					Rollback:         true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if rolling back without a journal directory",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Rollback:       true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if rolling back with dryRun",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:     Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalDirectory: "/var/cache/journal",
+					Rollback:         true,
+					DryRun:           true,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "does not return an error if dryRun but neither FromDirectory, ToDirectory, or ToKubeconfig is set",
 			fields: fields{
@@ -183,6 +245,63 @@ func Test_clusterctlClient_ToDirectory(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "returns an error if using a private key",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:           Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToDirectory:              dir,
+					DecryptionPrivateKeyFile: "private-key.pem",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if moving a subset of the Clusters",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToDirectory:    dir,
+					ClusterName:    "cluster1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if using both a public key and a passphrase",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:          Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToDirectory:             dir,
+					EncryptionPublicKeyFile: "public-key.txt",
+					PassphraseFile:          "passphrase",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if allowing unverified files",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:       Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToDirectory:          dir,
+					AllowUnverifiedFiles: true,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -297,11 +416,15 @@ type fakeObjectMover struct {
 	fromDirectoryErr error
 }
 
-func (f *fakeObjectMover) Move(_ context.Context, _ string, _ cluster.Client, _ bool, _ ...cluster.ResourceMutatorFunc) error {
+func (f *fakeObjectMover) Move(_ context.Context, _ string, _ cluster.Client, _ cluster.MoveOptions) error {
 	return f.moveErr
 }
 
-func (f *fakeObjectMover) ToDirectory(_ context.Context, _ string, _ string) error {
+func (f *fakeObjectMover) Rollback(_ context.Context, _ string, _ cluster.Client, _ string) error {
+	return f.moveErr
+}
+
+func (f *fakeObjectMover) ToDirectory(_ context.Context, _ string, _ string, _ cluster.ArchiveOptions) error {
 	return f.toDirectoryErr
 }
 
@@ -309,10 +432,32 @@ func (f *fakeObjectMover) Backup(_ context.Context, _ string, _ string) error {
 	return f.toDirectoryErr
 }
 
-func (f *fakeObjectMover) FromDirectory(_ context.Context, _ cluster.Client, _ string) error {
+func (f *fakeObjectMover) FromDirectory(_ context.Context, _ cluster.Client, _ string, _ cluster.ArchiveOptions) error {
 	return f.fromDirectoryErr
 }
 
 func (f *fakeObjectMover) Restore(_ context.Context, _ cluster.Client, _ string) error {
 	return f.fromDirectoryErr
 }
+
+func Test_getArchiveOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	passphraseFile := filepath.Join(dir, "passphrase")
+	g.Expect(os.WriteFile(passphraseFile, []byte("secret\n"), 0600)).To(Succeed())
+	publicKeyFile := filepath.Join(dir, "public-key.pem")
+	g.Expect(os.WriteFile(publicKeyFile, []byte("public-key"), 0600)).To(Succeed())
+
+	archiveOptions, err := getArchiveOptions(MoveOptions{PassphraseFile: passphraseFile, EncryptionPublicKeyFile: publicKeyFile})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(archiveOptions).To(Equal(cluster.ArchiveOptions{PublicKey: []byte("public-key"), Passphrase: []byte("secret")}))
+
+	emptyPassphraseFile := filepath.Join(dir, "empty")
+	g.Expect(os.WriteFile(emptyPassphraseFile, []byte("\n"), 0600)).To(Succeed())
+	_, err = getArchiveOptions(MoveOptions{PassphraseFile: emptyPassphraseFile})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = getArchiveOptions(MoveOptions{DecryptionPrivateKeyFile: filepath.Join(dir, "does-not-exist")})
+	g.Expect(err).To(HaveOccurred())
+}
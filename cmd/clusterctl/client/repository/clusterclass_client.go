@@ -30,6 +30,9 @@ import (
 // Templates are yaml files to be used for creating a guest cluster.
 type ClusterClassClient interface {
 	Get(ctx context.Context, name, targetNamespace string, skipTemplateProcess bool) (Template, error)
+
+	// Raw returns the cluster class template with the given name, as it is stored in the provider repository.
+	Raw(ctx context.Context, name string) ([]byte, error)
 }
 
 type clusterClassClient struct {
@@ -60,12 +63,28 @@ func newClusterClassClient(input ClusterClassClientInput) *clusterClassClient {
 }
 
 func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace string, skipTemplateProcess bool) (Template, error) {
-	log := logf.Log
-
 	if targetNamespace == "" {
 		return nil, errors.New("invalid arguments: please provide a targetNamespace")
 	}
 
+	rawArtifact, err := cc.Raw(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTemplate(TemplateInput{
+		rawArtifact,
+		cc.configVariablesClient,
+		cc.processor,
+		targetNamespace,
+		skipTemplateProcess,
+	})
+}
+
+// Raw returns the cluster class template with the given name, reading the local override file if it exists.
+func (cc *clusterClassClient) Raw(ctx context.Context, name string) ([]byte, error) {
+	log := logf.Log
+
 	version := cc.version
 	filename := cc.processor.GetClusterClassTemplateName(version, name)
 
@@ -89,12 +108,5 @@ func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace str
 	} else {
 		log.V(1).Info("Using", "override", filename, "provider", cc.provider.ManifestLabel(), "version", version)
 	}
-
-	return NewTemplate(TemplateInput{
-		rawArtifact,
-		cc.configVariablesClient,
-		cc.processor,
-		targetNamespace,
-		skipTemplateProcess,
-	})
+	return rawArtifact, nil
 }
@@ -0,0 +1,664 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	ociScheme = "oci"
+
+	// ociTitleAnnotation is the layer annotation storing the name of the file in the layer, as set by oras and
+	// other tools used to push OCI artifacts.
+	ociTitleAnnotation = "org.opencontainers.image.title"
+
+	ociManifestMediaType       = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType    = "application/vnd.docker.distribution.manifest.v2+json"
+	ociTagsPageSize            = 1000
+	ociRequestTimeout          = 30 * time.Second
+	dockerHubRegistry          = "docker.io"
+	dockerHubRegistryAPIHost   = "registry-1.docker.io"
+	dockerHubConfigKey         = "https://index.docker.io/v1/"
+	dockerConfigFileName       = "config.json"
+	dockerConfigDefaultDirName = ".docker"
+
+	// dockerCredentialHelperPrefix is the prefix of the name of the binaries implementing docker credential helpers.
+	dockerCredentialHelperPrefix = "docker-credential-"
+	// dockerCredentialHelperNotFound is the message returned by docker credential helpers when there are no credentials for a registry.
+	dockerCredentialHelperNotFound = "credentials not found in native keychain"
+	// dockerCredentialHelperIdentityTokenUsername is the username returned by docker credential helpers for identity tokens.
+	dockerCredentialHelperIdentityTokenUsername = "<token>"
+)
+
+// ociRepository provides support for providers distributed as OCI artifacts.
+//
+// Each provider version is an OCI artifact tagged with the version, and each file of the release
+// (components YAML, metadata.yaml, templates) is stored in a layer annotated with the file name
+// using the org.opencontainers.image.title annotation, e.g. as done by `oras push`.
+// The URL of the repository must adhere to the following layout:
+// oci://{registry}/{repository}/{latest|version}/{components.yaml}
+//
+// Concrete example:
+// oci://registry.example.com/capi/infrastructure-foo/v0.4.7/infrastructure-components.yaml
+// registry: registry.example.com
+// repository: capi/infrastructure-foo
+// version: v0.4.7
+// components.yaml: infrastructure-components.yaml
+//
+// Credentials for the registry are read from the docker config file, $DOCKER_CONFIG/config.json
+// or $HOME/.docker/config.json, or from the credential helpers configured in it (credHelpers and credsStore).
+// Registries on localhost are accessed using plain HTTP.
+type ociRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	scheme                string
+	registry              string
+	repository            string
+	defaultVersion        string
+	componentsPath        string
+
+	credentials *ociCredentials
+	tokens      map[string]string
+	manifests   map[string]*ociManifest
+}
+
+var _ Repository = &ociRepository{}
+
+// ociCredentials are the credentials used to authenticate to a registry.
+type ociCredentials struct {
+	username      string
+	password      string
+	identityToken string
+}
+
+// ociManifest mirrors the fields of an OCI image manifest used by clusterctl.
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// ociDescriptor mirrors the fields of an OCI content descriptor used by clusterctl.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// NewOCIRepository returns an ociRepository implementation.
+func NewOCIRepository(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...ociRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	urlSplit := strings.Split(strings.Trim(rURL.Path, "/"), "/")
+	if rURL.Scheme != ociScheme || rURL.Host == "" || len(urlSplit) < 3 {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}/{latest|version}/{components.yaml}")
+	}
+
+	repo := &ociRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		scheme:                ociRegistryScheme(rURL.Host),
+		registry:              rURL.Host,
+		repository:            strings.Join(urlSplit[:len(urlSplit)-2], "/"),
+		defaultVersion:        urlSplit[len(urlSplit)-2],
+		componentsPath:        urlSplit[len(urlSplit)-1],
+		tokens:                map[string]string{},
+		manifests:             map[string]*ociManifest{},
+	}
+
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if repo.credentials == nil {
+		if repo.credentials, err = ociCredentialsFromDockerConfig(ctx, configVariablesClient, repo.registry); err != nil {
+			return nil, err
+		}
+	}
+
+	if repo.defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(ctx, repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest release")
+		}
+	}
+	return repo, nil
+}
+
+type ociRepositoryOption func(*ociRepository)
+
+func injectOCIHTTPClient(client *http.Client) ociRepositoryOption {
+	return func(r *ociRepository) {
+		r.httpClient = client
+	}
+}
+
+func injectOCICredentials(username, password string) ociRepositoryOption {
+	return func(r *ociRepository) {
+		r.credentials = &ociCredentials{username: username, password: password}
+	}
+}
+
+// DefaultVersion returns defaultVersion field of ociRepository struct.
+func (r *ociRepository) DefaultVersion() string {
+	return r.defaultVersion
+}
+
+// RootPath returns the empty string as it is not applicable to OCI repositories.
+func (r *ociRepository) RootPath() string {
+	return ""
+}
+
+// ComponentsPath returns componentsPath field of ociRepository struct.
+func (r *ociRepository) ComponentsPath() string {
+	return r.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in the repository, derived from the tags of the repository.
+func (r *ociRepository) GetVersions(ctx context.Context) ([]string, error) {
+	cacheID := fmt.Sprintf("%s/%s", r.registry, r.repository)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	versions := []string{}
+	next := fmt.Sprintf("%s/tags/list?n=%d", r.repositoryURL(), ociTagsPageSize)
+	for next != "" {
+		response, err := r.get(ctx, next, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list tags of %q", cacheID)
+		}
+		tags := struct {
+			Tags []string `json:"tags"`
+		}{}
+		err = json.NewDecoder(response.Body).Decode(&tags)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode tags of %q", cacheID)
+		}
+
+		for _, tag := range tags.Tags {
+			// discard tags that are not a valid semantic versions (the user can point explicitly to such versions)
+			if _, err := version.ParseSemantic(tag); err != nil {
+				continue
+			}
+			versions = append(versions, tag)
+		}
+
+		if next, err = r.nextPage(response); err != nil {
+			return nil, err
+		}
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version, reading it from the layer annotated with the file name.
+func (r *ociRepository) GetFile(ctx context.Context, version, path string) ([]byte, error) {
+	log := logf.Log
+
+	if version == "" {
+		version = r.defaultVersion
+	}
+
+	cacheID := fmt.Sprintf("%s/%s:%s/%s", r.registry, r.repository, version, path)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	manifest, err := r.getManifest(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.Annotations[ociTitleAnnotation] != path {
+			continue
+		}
+
+		log.V(5).Info("Fetching", "file", path, "digest", layer.Digest, "repository", fmt.Sprintf("%s/%s", r.registry, r.repository), "version", version)
+		content, err := r.getBlob(ctx, layer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get file %q with version %q from %s/%s", path, version, r.registry, r.repository)
+		}
+		cacheFiles[cacheID] = content
+		return content, nil
+	}
+	return nil, errors.Errorf("failed to get file %q with version %q from %s/%s: no layer is annotated with %s=%s", path, version, r.registry, r.repository, ociTitleAnnotation, path)
+}
+
+func (r *ociRepository) getManifest(ctx context.Context, version string) (*ociManifest, error) {
+	if manifest, ok := r.manifests[version]; ok {
+		return manifest, nil
+	}
+
+	response, err := r.get(ctx, fmt.Sprintf("%s/manifests/%s", r.repositoryURL(), version), strings.Join([]string{ociManifestMediaType, dockerManifestMediaType}, ", "))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the manifest for version %q from %s/%s", version, r.registry, r.repository)
+	}
+	defer response.Body.Close()
+
+	manifest := &ociManifest{}
+	if err := json.NewDecoder(response.Body).Decode(manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the manifest for version %q from %s/%s", version, r.registry, r.repository)
+	}
+	r.manifests[version] = manifest
+	return manifest, nil
+}
+
+func (r *ociRepository) getBlob(ctx context.Context, layer ociDescriptor) ([]byte, error) {
+	algorithm, expected, ok := strings.Cut(layer.Digest, ":")
+	if !ok || algorithm != "sha256" {
+		return nil, errors.Errorf("unsupported digest %q", layer.Digest)
+	}
+
+	response, err := r.get(ctx, fmt.Sprintf("%s/blobs/%s", r.repositoryURL(), layer.Digest), "")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read blob %q", layer.Digest)
+	}
+
+	// Verify the content matches the digest in the manifest, so a tampered or corrupted blob is never used.
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != expected {
+		return nil, errors.Errorf("digest mismatch for blob %q", layer.Digest)
+	}
+	return content, nil
+}
+
+// get executes a GET request against the registry, authenticating if required by the registry.
+// In case of success, the caller is responsible for closing the response body.
+func (r *ociRepository) get(ctx context.Context, target, accept string) (*http.Response, error) {
+	response, err := r.do(ctx, target, accept, r.tokens[r.repository])
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		_ = response.Body.Close()
+
+		authorization, err := r.authorize(ctx, challenge)
+		if err != nil {
+			return nil, err
+		}
+		r.tokens[r.repository] = authorization
+		if response, err = r.do(ctx, target, accept, authorization); err != nil {
+			return nil, err
+		}
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		_ = response.Body.Close()
+		return nil, errors.Errorf("failed to get %q: unauthorized access, please check your credentials in the docker config file", target)
+	case http.StatusNotFound:
+		_ = response.Body.Close()
+		return nil, errors.Wrapf(errNotFound, "failed to get %q", target)
+	default:
+		_ = response.Body.Close()
+		return nil, errors.Errorf("failed to get %q, got %d", target, response.StatusCode)
+	}
+}
+
+func (r *ociRepository) do(ctx context.Context, target, accept, authorization string) (*http.Response, error) {
+	timeoutctx, cancel := context.WithTimeoutCause(ctx, ociRequestTimeout, errors.New("http request timeout expired"))
+	request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, target, http.NoBody)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to get %q: failed to create request", target)
+	}
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to get %q", target)
+	}
+	response.Body = &cancelOnCloseReader{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// authorize returns the value of the Authorization header answering the given authentication challenge,
+// as defined by the token authentication specification of the distribution API.
+func (r *ociRepository) authorize(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseOCIChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if r.credentials == nil || r.credentials.username == "" {
+			return "", errors.Errorf("registry %q requires authentication, please add credentials to the docker config file", r.registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(r.credentials.username+":"+r.credentials.password)), nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", errors.Errorf("invalid authentication challenge from registry %q: %q", r.registry, challenge)
+		}
+		// Credentials are sent to the realm, so it must be accessed using HTTPS, unless the registry itself is
+		// accessed using plain HTTP.
+		if realm.Scheme != httpsScheme && (realm.Scheme != "http" || r.scheme != "http") {
+			return "", errors.Errorf("invalid authentication challenge from registry %q: the token realm %q must use https", r.registry, params["realm"])
+		}
+		query := realm.Query()
+		if service := params["service"]; service != "" {
+			query.Set("service", service)
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = fmt.Sprintf("repository:%s:pull", r.repository)
+		}
+		query.Set("scope", scope)
+		realm.RawQuery = query.Encode()
+
+		timeoutctx, cancel := context.WithTimeoutCause(ctx, ociRequestTimeout, errors.New("http request timeout expired"))
+		defer cancel()
+		request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, realm.String(), http.NoBody)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get a token from %q: failed to create request", realm.Host)
+		}
+		if r.credentials != nil {
+			switch {
+			case r.credentials.identityToken != "":
+				request.SetBasicAuth(dockerCredentialHelperIdentityTokenUsername, r.credentials.identityToken)
+			case r.credentials.username != "":
+				request.SetBasicAuth(r.credentials.username, r.credentials.password)
+			}
+		}
+
+		response, err := r.httpClient.Do(request)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get a token from %q", realm.Host)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return "", errors.Errorf("failed to get a token from %q, got %d: please check your credentials in the docker config file", realm.Host, response.StatusCode)
+		}
+
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+			return "", errors.Wrapf(err, "failed to decode the token from %q", realm.Host)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return "", errors.Errorf("failed to get a token from %q: the response does not contain a token", realm.Host)
+		}
+		return "Bearer " + token.Token, nil
+	default:
+		return "", errors.Errorf("unsupported authentication challenge from registry %q: %q", r.registry, challenge)
+	}
+}
+
+// nextPage returns the URL of the next page of a paginated response, if any, as defined by the Link header.
+func (r *ociRepository) nextPage(response *http.Response) (string, error) {
+	link := response.Header.Get("Link")
+	if link == "" {
+		return "", nil
+	}
+	start, end := strings.Index(link, "<"), strings.Index(link, ">")
+	if start < 0 || end < start || !strings.Contains(link[end:], `rel="next"`) {
+		return "", nil
+	}
+	next, err := url.Parse(link[start+1 : end])
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid Link header %q", link)
+	}
+	base, err := url.Parse(r.repositoryURL())
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(next).String(), nil
+}
+
+func (r *ociRepository) repositoryURL() string {
+	host := r.registry
+	if host == dockerHubRegistry {
+		host = dockerHubRegistryAPIHost
+	}
+	return fmt.Sprintf("%s://%s/v2/%s", r.scheme, host, r.repository)
+}
+
+// cancelOnCloseReader cancels the context of a request when the response body is closed.
+type cancelOnCloseReader struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnCloseReader) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// ociRegistryScheme returns the scheme used to access a registry; registries on localhost are accessed using plain HTTP.
+func ociRegistryScheme(registry string) string {
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return "http"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+	return httpsScheme
+}
+
+// parseOCIChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth.example.com/token",service="registry.example.com".
+func parseOCIChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
+
+// ociCredentialsFromDockerConfig returns the credentials for a registry stored in the docker config file, if any.
+// If a credential helper is configured for the registry, either in credHelpers or in credsStore, the credentials
+// are read from the credential helper, falling back to the auths section of the docker config file only if
+// the credentials store does not have credentials for the registry.
+func ociCredentialsFromDockerConfig(ctx context.Context, configVariablesClient config.VariablesClient, registry string) (*ociCredentials, error) {
+	dir, err := configVariablesClient.Get(config.DockerConfigVariable)
+	if err != nil || dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil //nolint:nilerr // Without a home directory there is no docker config file, so anonymous access is used.
+		}
+		dir = filepath.Join(home, dockerConfigDefaultDirName)
+	}
+
+	path := filepath.Join(dir, dockerConfigFileName)
+	data, err := os.ReadFile(path) //nolint:gosec // The path of the docker config file is provided by the user.
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read the docker config file %q", path)
+	}
+
+	dockerConfig := struct {
+		Auths map[string]struct {
+			Auth          string `json:"auth"`
+			Username      string `json:"username"`
+			Password      string `json:"password"`
+			IdentityToken string `json:"identitytoken"`
+		} `json:"auths"`
+		CredsStore  string            `json:"credsStore"`
+		CredHelpers map[string]string `json:"credHelpers"`
+	}{}
+	if err := json.Unmarshal(data, &dockerConfig); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the docker config file %q", path)
+	}
+
+	matches := func(key string) bool {
+		return dockerConfigRegistry(key) == registry || (registry == dockerHubRegistry && key == dockerHubConfigKey)
+	}
+
+	// Credential helpers configured for a specific registry take precedence over everything else.
+	for key, helper := range dockerConfig.CredHelpers {
+		if !matches(key) {
+			continue
+		}
+		credentials, err := ociCredentialsFromDockerCredentialHelper(ctx, helper, registry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the credentials for %q from the credential helper configured in the docker config file %q", registry, path)
+		}
+		return credentials, nil
+	}
+
+	if dockerConfig.CredsStore != "" {
+		credentials, err := ociCredentialsFromDockerCredentialHelper(ctx, dockerConfig.CredsStore, registry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the credentials for %q from the credentials store configured in the docker config file %q", registry, path)
+		}
+		if credentials != nil {
+			return credentials, nil
+		}
+	}
+
+	for key, auth := range dockerConfig.Auths {
+		if !matches(key) {
+			continue
+		}
+
+		credentials := &ociCredentials{
+			username:      auth.Username,
+			password:      auth.Password,
+			identityToken: auth.IdentityToken,
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode the credentials for %q in the docker config file %q", key, path)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, errors.Errorf("invalid credentials for %q in the docker config file %q", key, path)
+			}
+			credentials.username, credentials.password = username, password
+		}
+		return credentials, nil
+	}
+	return nil, nil
+}
+
+// runDockerCredentialHelper runs the get command of a docker credential helper for the given server URL, and
+// returns its output; it is a variable so it can be replaced in tests.
+var runDockerCredentialHelper = func(ctx context.Context, helper, serverURL string) ([]byte, error) {
+	stdout := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, dockerCredentialHelperPrefix+helper, "get") //nolint:gosec // The credential helper is configured by the user in the docker config file.
+	cmd.Stdin = strings.NewReader(serverURL)
+	cmd.Stdout = stdout
+	err := cmd.Run()
+	return bytes.TrimSpace(stdout.Bytes()), err
+}
+
+// ociCredentialsFromDockerCredentialHelper returns the credentials for a registry stored in a docker credential helper,
+// if any. An error is returned if the credential helper cannot be run, so users are not silently falling back to
+// anonymous access.
+func ociCredentialsFromDockerCredentialHelper(ctx context.Context, helper, registry string) (*ociCredentials, error) {
+	serverURL := registry
+	if registry == dockerHubRegistry {
+		serverURL = dockerHubConfigKey
+	}
+
+	output, err := runDockerCredentialHelper(ctx, helper, serverURL)
+	if err != nil {
+		if string(output) == dockerCredentialHelperNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to run %s%s", dockerCredentialHelperPrefix, helper)
+	}
+
+	credentials := struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}{}
+	if err := json.Unmarshal(output, &credentials); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the output of %s%s", dockerCredentialHelperPrefix, helper)
+	}
+	if credentials.Username == dockerCredentialHelperIdentityTokenUsername {
+		return &ociCredentials{identityToken: credentials.Secret}, nil
+	}
+	return &ociCredentials{username: credentials.Username, password: credentials.Secret}, nil
+}
+
+// dockerConfigRegistry returns the registry host of a key of the auths section of the docker config file,
+// which could be a host or an URL, e.g. https://registry.example.com/v1/.
+func dockerConfigRegistry(key string) string {
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, _, _ := strings.Cut(key, "/")
+	return host
+}
@@ -0,0 +1,485 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+// fakeOCIRegistry is a minimal stand-in for an OCI registry, implementing the subset of the distribution API
+// used by clusterctl and the token authentication flow.
+type fakeOCIRegistry struct {
+	server *httptest.Server
+
+	repository string
+	// artifacts maps tags to files stored in the artifact.
+	artifacts map[string]map[string][]byte
+	// corrupted lists the files for which the registry returns content not matching the digest.
+	corrupted map[string]bool
+
+	username string
+	password string
+	token    string
+}
+
+func newFakeOCIRegistry(t *testing.T, repository string) *fakeOCIRegistry {
+	t.Helper()
+
+	r := &fakeOCIRegistry{
+		repository: repository,
+		artifacts:  map[string]map[string][]byte{},
+		corrupted:  map[string]bool{},
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// WithAuth requires clients to get a token using the given credentials.
+func (r *fakeOCIRegistry) WithAuth(username, password string) *fakeOCIRegistry {
+	r.username = username
+	r.password = password
+	r.token = "fake-token"
+	return r
+}
+
+func (r *fakeOCIRegistry) WithFile(tag, name string, content []byte) *fakeOCIRegistry {
+	if _, ok := r.artifacts[tag]; !ok {
+		r.artifacts[tag] = map[string][]byte{}
+	}
+	r.artifacts[tag][name] = content
+	return r
+}
+
+func (r *fakeOCIRegistry) Host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+func (r *fakeOCIRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		username, password, ok := req.BasicAuth()
+		if !ok || username != r.username || password != r.password || req.URL.Query().Get("scope") != fmt.Sprintf("repository:%s:pull", r.repository) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": r.token})
+		return
+	}
+
+	if r.token != "" && req.Header.Get("Authorization") != "Bearer "+r.token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake",scope="repository:%s:pull"`, r.server.URL, r.repository))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	prefix := fmt.Sprintf("/v2/%s/", r.repository)
+	if !strings.HasPrefix(req.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	kind, reference, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, prefix), "/")
+
+	switch kind {
+	case "tags":
+		tags := []string{}
+		for tag := range r.artifacts {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		// Return tags one per page, so pagination is exercised.
+		page := 0
+		if last := req.URL.Query().Get("last"); last != "" {
+			_, _ = fmt.Sscanf(last, "%d", &page)
+		}
+		if page+1 < len(tags) {
+			w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=1&last=%d>; rel="next"`, r.repository, page+1))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": r.repository, "tags": tags[page : page+1]})
+	case "manifests":
+		files, ok := r.artifacts[reference]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		manifest := ociManifest{}
+		for name, content := range files {
+			manifest.Layers = append(manifest.Layers, ociDescriptor{
+				MediaType:   "application/yaml",
+				Digest:      fakeOCIDigest(content),
+				Size:        int64(len(content)),
+				Annotations: map[string]string{ociTitleAnnotation: name},
+			})
+		}
+		w.Header().Set("Content-Type", ociManifestMediaType)
+		_ = json.NewEncoder(w).Encode(manifest)
+	case "blobs":
+		for _, files := range r.artifacts {
+			for name, content := range files {
+				if fakeOCIDigest(content) != reference {
+					continue
+				}
+				if r.corrupted[name] {
+					content = append(content, []byte("corrupted")...)
+				}
+				_, _ = w.Write(content)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func fakeOCIDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func Test_ociRepository_newOCIRepository(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		wantRegistry       string
+		wantRepository     string
+		wantDefaultVersion string
+		wantComponentsPath string
+		wantScheme         string
+		wantErr            bool
+	}{
+		{
+			name:               "can create a new OCI repository",
+			url:                "oci://registry.example.com/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml",
+			wantRegistry:       "registry.example.com",
+			wantRepository:     "capi/infrastructure-foo",
+			wantDefaultVersion: "v1.0.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+			wantScheme:         "https",
+		},
+		{
+			name:               "uses plain HTTP for registries on localhost",
+			url:                "oci://localhost:5000/infrastructure-foo/v1.0.0/infrastructure-components.yaml",
+			wantRegistry:       "localhost:5000",
+			wantRepository:     "infrastructure-foo",
+			wantDefaultVersion: "v1.0.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+			wantScheme:         "http",
+		},
+		{
+			name:    "fails if the repository is missing",
+			url:     "oci://registry.example.com/v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "fails if the registry is missing",
+			url:     "oci:///capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			providerConfig := config.NewProvider("foo", tt.url, clusterctlv1.InfrastructureProviderType)
+			configVariablesClient := test.NewFakeVariableClient().WithVar(config.DockerConfigVariable, t.TempDir())
+
+			got, err := NewOCIRepository(context.Background(), providerConfig, configVariablesClient)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			repo := got.(*ociRepository)
+			g.Expect(repo.registry).To(Equal(tt.wantRegistry))
+			g.Expect(repo.repository).To(Equal(tt.wantRepository))
+			g.Expect(repo.DefaultVersion()).To(Equal(tt.wantDefaultVersion))
+			g.Expect(repo.ComponentsPath()).To(Equal(tt.wantComponentsPath))
+			g.Expect(repo.scheme).To(Equal(tt.wantScheme))
+		})
+	}
+}
+
+func Test_ociRepository_GetVersionsAndGetFile(t *testing.T) {
+	g := NewWithT(t)
+
+	registry := newFakeOCIRegistry(t, "capi/infrastructure-foo").
+		WithAuth("user", "secret").
+		WithFile("v1.0.0", "infrastructure-components.yaml", []byte("components v1.0.0")).
+		WithFile("v1.0.0", "metadata.yaml", []byte("metadata v1.0.0")).
+		WithFile("v1.1.0", "infrastructure-components.yaml", []byte("components v1.1.0")).
+		WithFile("v1.1.0", "cluster-template.yaml", []byte("template v1.1.0")).
+		WithFile("not-a-version", "infrastructure-components.yaml", []byte("components"))
+	registry.corrupted["corrupted.yaml"] = true
+	registry.WithFile("v1.1.0", "corrupted.yaml", []byte("corrupted"))
+
+	// Write credentials to a docker config file.
+	dockerConfigDir := t.TempDir()
+	dockerConfig := fmt.Sprintf(`{"auths": {%q: {"auth": %q}}}`, registry.Host(), base64.StdEncoding.EncodeToString([]byte("user:secret")))
+	g.Expect(os.WriteFile(filepath.Join(dockerConfigDir, "config.json"), []byte(dockerConfig), 0600)).To(Succeed())
+	configVariablesClient := test.NewFakeVariableClient().WithVar(config.DockerConfigVariable, dockerConfigDir)
+
+	providerConfig := config.NewProvider("foo", fmt.Sprintf("oci://%s/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml", registry.Host()), clusterctlv1.InfrastructureProviderType)
+	repo, err := NewOCIRepository(context.Background(), providerConfig, configVariablesClient)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	versions, err := repo.GetVersions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(versions).To(ConsistOf("v1.0.0", "v1.1.0"))
+
+	content, err := repo.GetFile(context.Background(), "", "infrastructure-components.yaml")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal("components v1.0.0"))
+
+	content, err = repo.GetFile(context.Background(), "v1.1.0", "cluster-template.yaml")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal("template v1.1.0"))
+
+	// Files not in the artifact, unknown versions and blobs not matching the digest are reported as errors.
+	_, err = repo.GetFile(context.Background(), "v1.1.0", "metadata.yaml")
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = repo.GetFile(context.Background(), "v9.9.9", "metadata.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring(errNotFound.Error())))
+
+	_, err = repo.GetFile(context.Background(), "v1.1.0", "corrupted.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("digest mismatch")))
+}
+
+func Test_ociRepository_Unauthorized(t *testing.T) {
+	g := NewWithT(t)
+
+	registry := newFakeOCIRegistry(t, "infrastructure-foo").
+		WithAuth("user", "secret").
+		WithFile("v1.0.0", "infrastructure-components.yaml", []byte("components v1.0.0"))
+
+	providerConfig := config.NewProvider("foo", fmt.Sprintf("oci://%s/infrastructure-foo/v1.0.0/infrastructure-components.yaml", registry.Host()), clusterctlv1.InfrastructureProviderType)
+	configVariablesClient := test.NewFakeVariableClient().WithVar(config.DockerConfigVariable, t.TempDir())
+
+	repo, err := NewOCIRepository(context.Background(), providerConfig, configVariablesClient, injectOCICredentials("user", "wrong"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = repo.GetFile(context.Background(), "", "infrastructure-components.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("please check your credentials")))
+}
+
+func Test_ociRepository_newOCIRepository_Latest(t *testing.T) {
+	g := NewWithT(t)
+
+	metadata := func(minor int) []byte {
+		return []byte(fmt.Sprintf(`apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3
+kind: Metadata
+releaseSeries:
+- major: 1
+  minor: %d
+  contract: v1beta2
+`, minor))
+	}
+	registry := newFakeOCIRegistry(t, "infrastructure-bar").
+		WithFile("v1.0.0", "metadata.yaml", metadata(0)).
+		WithFile("v1.2.0", "metadata.yaml", metadata(2)).
+		WithFile("v1.10.0", "metadata.yaml", metadata(10))
+
+	providerConfig := config.NewProvider("bar", fmt.Sprintf("oci://%s/infrastructure-bar/latest/infrastructure-components.yaml", registry.Host()), clusterctlv1.InfrastructureProviderType)
+	configVariablesClient := test.NewFakeVariableClient().WithVar(config.DockerConfigVariable, t.TempDir())
+
+	repo, err := NewOCIRepository(context.Background(), providerConfig, configVariablesClient, injectOCIHTTPClient(registry.server.Client()))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.DefaultVersion()).To(Equal("v1.10.0"))
+}
+
+func Test_ociRepository_authorize(t *testing.T) {
+	tests := []struct {
+		name      string
+		scheme    string
+		challenge string
+		wantErr   bool
+	}{
+		{
+			name:      "refuses a plain HTTP token realm for a registry accessed using HTTPS",
+			scheme:    "https",
+			challenge: `Bearer realm="http://auth.example.com/token",service="registry.example.com"`,
+			wantErr:   true,
+		},
+		{
+			name:      "refuses a token realm with an unsupported scheme",
+			scheme:    "http",
+			challenge: `Bearer realm="ftp://auth.example.com/token",service="registry.example.com"`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			repo := &ociRepository{
+				httpClient: &http.Client{
+					Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+						return nil, errors.New("credentials must not be sent to the token realm")
+					}),
+				},
+				scheme:      tt.scheme,
+				registry:    "registry.example.com",
+				repository:  "infrastructure-foo",
+				credentials: &ociCredentials{username: "user", password: "secret"},
+			}
+
+			_, err := repo.authorize(context.Background(), tt.challenge)
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(ContainSubstring("must use https")))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_ociCredentialsFromDockerConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		registry string
+		// helpers maps the names of the credential helpers to the output they return for registry.example.com.
+		helpers map[string]string
+		want    *ociCredentials
+		wantErr bool
+	}{
+		{
+			name:     "reads credentials from auth",
+			config:   `{"auths": {"registry.example.com": {"auth": "dXNlcjpzZWNyZXQ="}}}`,
+			registry: "registry.example.com",
+			want:     &ociCredentials{username: "user", password: "secret"},
+		},
+		{
+			name:     "reads credentials from username, password and identity token with URL keys",
+			config:   `{"auths": {"https://registry.example.com/v1/": {"username": "user", "password": "secret", "identitytoken": "token"}}}`,
+			registry: "registry.example.com",
+			want:     &ociCredentials{username: "user", password: "secret", identityToken: "token"},
+		},
+		{
+			name:     "reads credentials for docker hub",
+			config:   `{"auths": {"https://index.docker.io/v1/": {"auth": "dXNlcjpzZWNyZXQ="}}}`,
+			registry: "docker.io",
+			want:     &ociCredentials{username: "user", password: "secret"},
+		},
+		{
+			name:     "returns no credentials for other registries",
+			config:   `{"auths": {"registry.example.com": {"auth": "dXNlcjpzZWNyZXQ="}}}`,
+			registry: "other.example.com",
+			want:     nil,
+		},
+		{
+			name:     "fails for invalid auth",
+			config:   `{"auths": {"registry.example.com": {"auth": "invalid"}}}`,
+			registry: "registry.example.com",
+			wantErr:  true,
+		},
+		{
+			name:     "reads credentials from the credential helper configured for the registry",
+			config:   `{"auths": {"registry.example.com": {}}, "credsStore": "desktop", "credHelpers": {"registry.example.com": "ecr-login"}}`,
+			registry: "registry.example.com",
+			helpers: map[string]string{
+				"ecr-login": `{"ServerURL": "registry.example.com", "Username": "AWS", "Secret": "secret"}`,
+				"desktop":   `{"ServerURL": "registry.example.com", "Username": "user", "Secret": "other"}`,
+			},
+			want: &ociCredentials{username: "AWS", password: "secret"},
+		},
+		{
+			name:     "reads an identity token from the credentials store",
+			config:   `{"auths": {"registry.example.com": {}}, "credsStore": "desktop"}`,
+			registry: "registry.example.com",
+			helpers: map[string]string{
+				"desktop": `{"ServerURL": "registry.example.com", "Username": "<token>", "Secret": "token"}`,
+			},
+			want: &ociCredentials{identityToken: "token"},
+		},
+		{
+			name:     "falls back to auth if the credentials store does not have credentials for the registry",
+			config:   `{"auths": {"registry.example.com": {"auth": "dXNlcjpzZWNyZXQ="}}, "credsStore": "desktop"}`,
+			registry: "registry.example.com",
+			helpers: map[string]string{
+				"desktop": "credentials not found in native keychain",
+			},
+			want: &ociCredentials{username: "user", password: "secret"},
+		},
+		{
+			name:     "fails if the credential helper configured for the registry cannot be run",
+			config:   `{"credHelpers": {"registry.example.com": "gcloud"}}`,
+			registry: "registry.example.com",
+			wantErr:  true,
+		},
+		{
+			name:     "fails if the credentials store cannot be run",
+			config:   `{"credsStore": "desktop"}`,
+			registry: "registry.example.com",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			defer func(f func(context.Context, string, string) ([]byte, error)) { runDockerCredentialHelper = f }(runDockerCredentialHelper)
+			runDockerCredentialHelper = func(_ context.Context, helper, serverURL string) ([]byte, error) {
+				output, ok := tt.helpers[helper]
+				if !ok {
+					return nil, errors.Errorf("executable file docker-credential-%s not found", helper)
+				}
+				if serverURL != "registry.example.com" || output == "credentials not found in native keychain" {
+					return []byte("credentials not found in native keychain"), errors.New("exit status 1")
+				}
+				return []byte(output), nil
+			}
+
+			dir := t.TempDir()
+			g.Expect(os.WriteFile(filepath.Join(dir, "config.json"), []byte(tt.config), 0600)).To(Succeed())
+
+			got, err := ociCredentialsFromDockerConfig(context.Background(), test.NewFakeVariableClient().WithVar(config.DockerConfigVariable, dir), tt.registry)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
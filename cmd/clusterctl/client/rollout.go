@@ -20,13 +20,20 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 )
 
+// rolloutStatusInterval is the interval between two checks of the status of a rollout.
+var rolloutStatusInterval = 5 * time.Second
+
 // RolloutRestartOptions carries the options supported by RolloutRestart.
 type RolloutRestartOptions struct {
 	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
@@ -69,6 +76,58 @@ type RolloutResumeOptions struct {
 	Namespace string
 }
 
+// RolloutStatusOptions carries the options supported by RolloutStatus.
+type RolloutStatusOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resources for the rollout command
+	Resources []string
+
+	// Namespace where the resource(s) live. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+
+	// Watch waits for the rollout to complete; if false, the current status is reported and
+	// RolloutStatus returns immediately.
+	Watch bool
+
+	// Timeout is the maximum time to wait for the rollout to complete. If zero, RolloutStatus waits indefinitely.
+	Timeout time.Duration
+}
+
+// RolloutHistoryOptions carries the options supported by RolloutHistory.
+type RolloutHistoryOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resource for the rollout command
+	Resource string
+
+	// Namespace where the resource lives. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+}
+
+// RolloutUndoOptions carries the options supported by RolloutUndo.
+type RolloutUndoOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resources for the rollout command
+	Resources []string
+
+	// Namespace where the resource(s) live. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+
+	// ToRevision is the revision to rollback to. If zero, resources are rolled back to the previous revision.
+	ToRevision int64
+}
+
 func (c *clusterctlClient) RolloutRestart(ctx context.Context, options RolloutRestartOptions) error {
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -120,6 +179,97 @@ func (c *clusterctlClient) RolloutResume(ctx context.Context, options RolloutRes
 	return nil
 }
 
+func (c *clusterctlClient) RolloutStatus(ctx context.Context, options RolloutStatusOptions) error {
+	log := logf.Log
+
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return err
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, options.Resources)
+	if err != nil {
+		return err
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	for _, ref := range objRefs {
+		lastMessage := ""
+		var lastErr error
+		err := wait.PollUntilContextCancel(ctx, rolloutStatusInterval, true, func(ctx context.Context) (bool, error) {
+			status, err := c.alphaClient.Rollout().ObjectStatusViewer(ctx, clusterClient.Proxy(), ref)
+			if err != nil {
+				lastErr = err
+				return false, err
+			}
+			if status.Message != lastMessage {
+				log.Info(status.Message)
+				for _, detail := range status.Details {
+					log.Info("  " + detail)
+				}
+				lastMessage = status.Message
+			}
+			return status.Done || !options.Watch, nil
+		})
+		if err != nil {
+			if lastErr != nil {
+				return lastErr
+			}
+			return errors.Wrapf(err, "timed out waiting for the rollout of %s/%s to complete", ref.Kind, ref.Name)
+		}
+	}
+	return nil
+}
+
+func (c *clusterctlClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+	var resources []string
+	if options.Resource != "" {
+		resources = append(resources, options.Resource)
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	alphaRevisions, err := c.alphaClient.Rollout().ObjectHistoryViewer(ctx, clusterClient.Proxy(), objRefs[0])
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]RolloutRevision, 0, len(alphaRevisions))
+	for _, r := range alphaRevisions {
+		revisions = append(revisions, RolloutRevision(r))
+	}
+	return revisions, nil
+}
+
+func (c *clusterctlClient) RolloutUndo(ctx context.Context, options RolloutUndoOptions) error {
+	if options.ToRevision < 0 {
+		return errors.Errorf("invalid revision %d, revision must be a positive number", options.ToRevision)
+	}
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return err
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, options.Resources)
+	if err != nil {
+		return err
+	}
+	for _, ref := range objRefs {
+		if err := c.alphaClient.Rollout().ObjectRollbacker(ctx, clusterClient.Proxy(), ref, options.ToRevision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getObjectRefs(clusterClient cluster.Client, namespace string, resources []string) ([]corev1.ObjectReference, error) {
 	// If the option specifying the Namespace is empty, try to detect it.
 	if namespace == "" {
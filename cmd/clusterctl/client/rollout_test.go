@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -354,3 +355,164 @@ func Test_clusterctlClient_RolloutResume(t *testing.T) {
 		})
 	}
 }
+
+func Test_clusterctlClient_RolloutStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RolloutStatusOptions
+		wantErr bool
+	}{
+		{
+			name: "return an error if machinedeployment is not found",
+			options: RolloutStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resources:  []string{"machinedeployment/foo"},
+				Namespace:  "default",
+				Watch:      true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "return error if unknown resource specified",
+			options: RolloutStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resources:  []string{"foo/bar"},
+				Namespace:  "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "do not return error if all machinedeployments are rolled out",
+			options: RolloutStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resources:  []string{"machinedeployment/md-1", "machinedeployment/md-2"},
+				Namespace:  "default",
+				Watch:      true,
+				Timeout:    time.Minute,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			err := fakeClientForRollout().RolloutStatus(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func Test_clusterctlClient_RolloutHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RolloutHistoryOptions
+		wantErr bool
+	}{
+		{
+			name: "return an error if machinedeployment is not found",
+			options: RolloutHistoryOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resource:   "machinedeployment/foo",
+				Namespace:  "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "return error if no resource specified",
+			options: RolloutHistoryOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Namespace:  "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "do not return error if machinedeployment found",
+			options: RolloutHistoryOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resource:   "machinedeployment/md-1",
+				Namespace:  "default",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			_, err := fakeClientForRollout().RolloutHistory(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func Test_clusterctlClient_RolloutUndo(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RolloutUndoOptions
+		wantErr bool
+	}{
+		{
+			name: "return an error if machinedeployment is not found",
+			options: RolloutUndoOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resources:  []string{"machinedeployment/foo"},
+				Namespace:  "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "return error if no resource specified",
+			options: RolloutUndoOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Namespace:  "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "return error if the revision is negative",
+			options: RolloutUndoOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resources:  []string{"machinedeployment/md-1"},
+				Namespace:  "default",
+				ToRevision: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "return error if machinedeployment has no previous revision",
+			options: RolloutUndoOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resources:  []string{"machinedeployment/md-1"},
+				Namespace:  "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			err := fakeClientForRollout().RolloutUndo(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
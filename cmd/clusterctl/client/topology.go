@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// TopologyPlanOptions carries the options supported by TopologyPlan.
+type TopologyPlanOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Objs are the new or modified objects, e.g. ClusterClasses, templates, Clusters or ExtensionConfigs
+	// pointing to a local Runtime Extension.
+	Objs []*unstructured.Unstructured
+
+	// Snapshot are the objects of the management cluster to be used instead of reading them from the
+	// management cluster. If nil, the objects are read from the management cluster.
+	Snapshot []*unstructured.Unstructured
+
+	// Cluster is the name of the Cluster to preview changes for. If empty, changes are computed
+	// for all the Clusters affected by the objects in input.
+	Cluster string
+
+	// Namespace used for objects without a namespace and for Cluster. If unspecified, the current namespace
+	// will be used when reading from the management cluster, the default namespace when using a snapshot.
+	Namespace string
+
+	// UseManagementClusterExtensions allows calling the Runtime Extensions registered by the ExtensionConfigs
+	// of the management cluster. If false, only ExtensionConfigs in Objs are used.
+	UseManagementClusterExtensions bool
+}
+
+// TopologyPlan computes the changes that would be applied to the Clusters with a managed topology
+// if the objects in input were applied to the management cluster.
+func (c *clusterctlClient) TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*TopologyPlanOutput, error) {
+	if len(options.Objs) == 0 {
+		return nil, errors.New("at least one object is required to compute a topology plan")
+	}
+
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Snapshot == nil {
+		// Ensure this command only runs against management clusters with the current Cluster API contract.
+		if err := clusterClient.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+			return nil, err
+		}
+
+		// If the option specifying the Namespace is empty, try to detect it.
+		if options.Namespace == "" {
+			currentNamespace, err := clusterClient.Proxy().CurrentNamespace()
+			if err != nil {
+				return nil, err
+			}
+			options.Namespace = currentNamespace
+		}
+	}
+	if options.Namespace == "" {
+		options.Namespace = "default"
+	}
+
+	out, err := clusterClient.Topology().Plan(ctx, &cluster.TopologyPlanInput{
+		Objs:                           options.Objs,
+		Snapshot:                       options.Snapshot,
+		TargetClusterName:              options.Cluster,
+		TargetNamespace:                options.Namespace,
+		UseManagementClusterExtensions: options.UseManagementClusterExtensions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return (*TopologyPlanOutput)(out), nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/contract"
+)
+
+var (
+	// GroupVersionClusterDescription is the group version of the ClusterDescription schema.
+	// NOTE: The schema is consumed by tools scraping the output of clusterctl describe cluster, and
+	// breaking changes require a new version.
+	GroupVersionClusterDescription = schema.GroupVersion{Group: "describe.clusterctl.cluster.x-k8s.io", Version: "v1alpha1"}
+)
+
+// ClusterDescriptionKind is the kind of the ClusterDescription schema.
+const ClusterDescriptionKind = "ClusterDescription"
+
+// ClusterDescription is a machine readable representation of an ObjectTree.
+type ClusterDescription struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// root is the description of the root object of the tree, usually the Cluster, including all its children.
+	Root ObjectDescription `json:"root"`
+}
+
+// ObjectDescription is a machine readable representation of an object in the ObjectTree.
+type ObjectDescription struct {
+	// apiVersion of the object.
+	// Note: virtual and group objects are using the virtual.cluster.x-k8s.io group.
+	APIVersion string `json:"apiVersion"`
+
+	// kind of the object.
+	Kind string `json:"kind"`
+
+	// namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object.
+	Name string `json:"name"`
+
+	// metaName is the name that the presentation layer uses for the object, e.g. ControlPlane for a KubeadmControlPlane.
+	MetaName string `json:"metaName,omitempty"`
+
+	// virtual is true if the object does not correspond to any real object, e.g. Workers.
+	Virtual bool `json:"virtual,omitempty"`
+
+	// deleted is true if the object is being deleted.
+	Deleted bool `json:"deleted,omitempty"`
+
+	// grouping is true if children of the object with the same state are grouped.
+	Grouping bool `json:"grouping,omitempty"`
+
+	// group is set if the object represents a group of sibling objects with the same state, e.g. a group of Machines.
+	Group *GroupDescription `json:"group,omitempty"`
+
+	// contract is the Cluster API contract the object abides to, e.g. ControlPlane.
+	Contract string `json:"contract,omitempty"`
+
+	// contractVersion is the version of the Cluster API contract the object abides to, e.g. v1beta2.
+	ContractVersion string `json:"contractVersion,omitempty"`
+
+	// replicas are the replica counters of the object, if the object has replicas, e.g. a MachineDeployment.
+	Replicas *ReplicasDescription `json:"replicas,omitempty"`
+
+	// showConditions is true if all the conditions of the object have been requested with --show-conditions.
+	ShowConditions bool `json:"showConditions,omitempty"`
+
+	// conditions of the object.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// v1beta1Conditions of the object; only set when the tree is using v1beta1 conditions.
+	//
+	// Deprecated: This field will be removed when v1beta1 will be dropped.
+	V1Beta1Conditions clusterv1.Conditions `json:"v1beta1Conditions,omitempty"`
+
+	// children of the object, sorted by z-order and then by kind and name.
+	Children []ObjectDescription `json:"children,omitempty"`
+}
+
+// GroupDescription describes a group of sibling objects with the same state.
+type GroupDescription struct {
+	// items are the names of the objects in the group.
+	Items []string `json:"items"`
+
+	// available is the number of available objects in the group.
+	Available int `json:"available"`
+
+	// ready is the number of ready objects in the group.
+	Ready int `json:"ready"`
+
+	// upToDate is the number of up-to-date objects in the group.
+	UpToDate int `json:"upToDate"`
+}
+
+// ReplicasDescription describes the replica counters of an object.
+// Note: for a Cluster, counters are the sum of control plane and worker counters.
+type ReplicasDescription struct {
+	// desired is the desired number of replicas.
+	Desired *int32 `json:"desired,omitempty"`
+
+	// current is the current number of replicas.
+	Current *int32 `json:"current,omitempty"`
+
+	// available is the number of available replicas.
+	Available *int32 `json:"available,omitempty"`
+
+	// ready is the number of ready replicas.
+	Ready *int32 `json:"ready,omitempty"`
+
+	// upToDate is the number of up-to-date replicas.
+	UpToDate *int32 `json:"upToDate,omitempty"`
+}
+
+// NewClusterDescription returns a machine readable representation of an ObjectTree.
+func NewClusterDescription(tree *ObjectTree) *ClusterDescription {
+	return &ClusterDescription{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersionClusterDescription.String(),
+			Kind:       ClusterDescriptionKind,
+		},
+		Root: tree.describeObject(tree.GetRoot()),
+	}
+}
+
+// describeObject returns the description of an object, and recursively of all the object's children.
+func (od ObjectTree) describeObject(obj client.Object) ObjectDescription {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	d := ObjectDescription{
+		APIVersion:      gvk.GroupVersion().String(),
+		Kind:            gvk.Kind,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		MetaName:        GetMetaName(obj),
+		Virtual:         IsVirtualObject(obj),
+		Deleted:         !obj.GetDeletionTimestamp().IsZero(),
+		Grouping:        IsGroupingObject(obj),
+		Contract:        GetObjectContract(obj),
+		ContractVersion: GetObjectContractVersion(obj),
+		Replicas:        describeReplicas(obj),
+		ShowConditions:  IsShowConditionsObject(obj),
+		Conditions:      GetConditions(obj),
+	}
+
+	if IsGroupObject(obj) {
+		d.Group = &GroupDescription{
+			Items:     strings.Split(GetGroupItems(obj), GroupItemsSeparator),
+			Available: GetGroupItemsAvailableCounter(obj),
+			Ready:     GetGroupItemsReadyCounter(obj),
+			UpToDate:  GetGroupItemsUpToDateCounter(obj),
+		}
+	}
+
+	if od.options.V1Beta1 {
+		if getter := objToGetter(obj); getter != nil {
+			d.V1Beta1Conditions = getter.GetV1Beta1Conditions()
+		}
+	}
+
+	children := od.GetObjectsByParent(obj.GetUID())
+	sortObjects(children)
+	for _, child := range children {
+		d.Children = append(d.Children, od.describeObject(child))
+	}
+	return d
+}
+
+// sortObjects sorts objects by z-order, from highest to lowest, and then by kind and name, so the order
+// of the children in the description is stable.
+func sortObjects(objs []client.Object) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		if GetZOrder(objs[i]) != GetZOrder(objs[j]) {
+			return GetZOrder(objs[i]) > GetZOrder(objs[j])
+		}
+		kindI, kindJ := objs[i].GetObjectKind().GroupVersionKind().Kind, objs[j].GetObjectKind().GroupVersionKind().Kind
+		if kindI != kindJ {
+			return kindI < kindJ
+		}
+		return objs[i].GetName() < objs[j].GetName()
+	})
+}
+
+// describeReplicas returns the replica counters of an object, if any.
+func describeReplicas(obj client.Object) *ReplicasDescription {
+	switch obj := obj.(type) {
+	case *clusterv1.Cluster:
+		cp := ptr.Deref(obj.Status.ControlPlane, clusterv1.ClusterControlPlaneStatus{})
+		w := ptr.Deref(obj.Status.Workers, clusterv1.WorkersStatus{})
+		return newReplicasDescription(
+			sumReplicas(cp.DesiredReplicas, w.DesiredReplicas),
+			sumReplicas(cp.Replicas, w.Replicas),
+			sumReplicas(cp.AvailableReplicas, w.AvailableReplicas),
+			sumReplicas(cp.ReadyReplicas, w.ReadyReplicas),
+			sumReplicas(cp.UpToDateReplicas, w.UpToDateReplicas),
+		)
+	case *clusterv1.MachineDeployment:
+		return newReplicasDescription(obj.Spec.Replicas, obj.Status.Replicas, obj.Status.AvailableReplicas, obj.Status.ReadyReplicas, obj.Status.UpToDateReplicas)
+	case *clusterv1.MachineSet:
+		return newReplicasDescription(obj.Spec.Replicas, obj.Status.Replicas, obj.Status.AvailableReplicas, obj.Status.ReadyReplicas, obj.Status.UpToDateReplicas)
+	case *unstructured.Unstructured:
+		if GetObjectContract(obj) != "ControlPlane" {
+			return nil
+		}
+		get := func(field *contract.Int32) *int32 {
+			if v, err := field.Get(obj); err == nil {
+				return v
+			}
+			return nil
+		}
+		return newReplicasDescription(
+			get(contract.ControlPlane().Replicas()),
+			get(contract.ControlPlane().StatusReplicas()),
+			get(contract.ControlPlane().AvailableReplicas()),
+			get(contract.ControlPlane().ReadyReplicas()),
+			get(contract.ControlPlane().UpToDateReplicas(GetObjectContractVersion(obj))),
+		)
+	}
+	return nil
+}
+
+func newReplicasDescription(desired, current, available, ready, upToDate *int32) *ReplicasDescription {
+	if desired == nil && current == nil && available == nil && ready == nil && upToDate == nil {
+		return nil
+	}
+	return &ReplicasDescription{
+		Desired:   desired,
+		Current:   current,
+		Available: available,
+		Ready:     ready,
+		UpToDate:  upToDate,
+	}
+}
+
+func sumReplicas(a, b *int32) *int32 {
+	if a == nil && b == nil {
+		return nil
+	}
+	return ptr.To(ptr.Deref(a, 0) + ptr.Deref(b, 0))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func Test_NewClusterDescription(t *testing.T) {
+	g := NewWithT(t)
+
+	ready := metav1.Condition{Type: clusterv1.ReadyCondition, Status: metav1.ConditionTrue, Reason: "Ready"}
+
+	cluster := fakeCluster("my-cluster",
+		withClusterCondition(metav1.Condition{Type: clusterv1.AvailableCondition, Status: metav1.ConditionTrue, Reason: "Available"}),
+	)
+	cluster.Status.ControlPlane = &clusterv1.ClusterControlPlaneStatus{DesiredReplicas: ptr.To[int32](3), Replicas: ptr.To[int32](3)}
+	cluster.Status.Workers = &clusterv1.WorkersStatus{DesiredReplicas: ptr.To[int32](2), Replicas: ptr.To[int32](1)}
+
+	controlPlane := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "controlplane.cluster.x-k8s.io/v1beta2",
+		"kind":       "KubeadmControlPlane",
+		"metadata": map[string]interface{}{
+			"namespace": "ns",
+			"name":      "my-control-plane",
+			"uid":       "my-control-plane",
+			"annotations": map[string]interface{}{
+				ObjectContractAnnotation:        "ControlPlane",
+				ObjectContractVersionAnnotation: "v1beta2",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+		},
+		"status": map[string]interface{}{
+			"replicas":      int64(3),
+			"readyReplicas": int64(2),
+		},
+	}}
+
+	workers := VirtualObject("ns", "WorkerGroup", "Workers")
+
+	objectTree := NewObjectTree(cluster, ObjectTreeOptions{Grouping: true})
+	objectTree.Add(cluster, controlPlane, ObjectMetaName("ControlPlane"), GroupingObject(true))
+	objectTree.Add(cluster, workers)
+	objectTree.Add(controlPlane, fakeMachine("machine-2", withMachineCondition(ready)))
+	objectTree.Add(controlPlane, fakeMachine("machine-1", withMachineCondition(ready)))
+	objectTree.Add(workers, fakeMachine("machine-4", withMachineCondition(ready)), ZOrder(1))
+	objectTree.Add(workers, fakeMachine("machine-3"))
+
+	description := NewClusterDescription(objectTree)
+	g.Expect(description.APIVersion).To(Equal("describe.clusterctl.cluster.x-k8s.io/v1alpha1"))
+	g.Expect(description.Kind).To(Equal("ClusterDescription"))
+
+	root := description.Root
+	g.Expect(root.Kind).To(Equal("Cluster"))
+	g.Expect(root.Name).To(Equal("my-cluster"))
+	g.Expect(root.Replicas).To(Equal(&ReplicasDescription{Desired: ptr.To[int32](5), Current: ptr.To[int32](4)}))
+	g.Expect(root.Conditions).To(HaveLen(1))
+	g.Expect(root.Children).To(HaveLen(2))
+
+	// Children are sorted by kind and name.
+	cp := root.Children[0]
+	g.Expect(cp.Kind).To(Equal("KubeadmControlPlane"))
+	g.Expect(cp.MetaName).To(Equal("ControlPlane"))
+	g.Expect(cp.Grouping).To(BeTrue())
+	g.Expect(cp.Contract).To(Equal("ControlPlane"))
+	g.Expect(cp.ContractVersion).To(Equal("v1beta2"))
+	g.Expect(cp.Replicas).To(Equal(&ReplicasDescription{Desired: ptr.To[int32](3), Current: ptr.To[int32](3), Ready: ptr.To[int32](2)}))
+
+	// Machines with the same state are grouped.
+	g.Expect(cp.Children).To(HaveLen(1))
+	group := cp.Children[0]
+	g.Expect(group.APIVersion).To(Equal(GroupVersionVirtualObject.String()))
+	g.Expect(group.Kind).To(Equal("MachineGroup"))
+	g.Expect(group.Group).To(Equal(&GroupDescription{Items: []string{"machine-1", "machine-2"}, Ready: 2}))
+
+	w := root.Children[1]
+	g.Expect(w.Kind).To(Equal("WorkerGroup"))
+	g.Expect(w.Virtual).To(BeTrue())
+
+	// Children are sorted by z-order before name.
+	g.Expect(w.Children).To(HaveLen(2))
+	g.Expect(w.Children[0].Name).To(Equal("machine-4"))
+	g.Expect(w.Children[1].Name).To(Equal("machine-3"))
+	g.Expect(w.Children[1].Conditions).To(BeEmpty())
+
+	// The description must be serializable.
+	_, err := yaml.Marshal(description)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func Test_NewClusterDescription_V1Beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := fakeCluster("my-cluster",
+		withClusterV1Beta1Condition(&clusterv1.Condition{Type: clusterv1.ReadyV1Beta1Condition, Status: "True"}),
+	)
+	cluster.UID = types.UID("my-cluster")
+
+	description := NewClusterDescription(NewObjectTree(cluster, ObjectTreeOptions{V1Beta1: true}))
+	g.Expect(description.Root.V1Beta1Conditions).To(HaveLen(1))
+	g.Expect(description.Root.Children).To(BeEmpty())
+	g.Expect(description.Root.Replicas).To(BeNil())
+
+	description = NewClusterDescription(NewObjectTree(cluster, ObjectTreeOptions{}))
+	g.Expect(description.Root.V1Beta1Conditions).To(BeEmpty())
+}
@@ -0,0 +1,412 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/topology/check"
+)
+
+// PlanClusterUpgradeOptions carries the options supported by PlanClusterUpgrade.
+type PlanClusterUpgradeOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty, default discovery rules apply.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the Clusters are located. If unspecified, Clusters in all the namespaces are inspected.
+	Namespace string
+
+	// ClusterName limits the plan to the Cluster with the given name. If unspecified, all the Clusters are inspected.
+	ClusterName string
+
+	// KubernetesVersion the Clusters should be upgraded to (e.g. v1.33.1).
+	KubernetesVersion string
+}
+
+// ClusterUpgradePlan defines the steps required to upgrade a Cluster to a target Kubernetes version,
+// together with the issues preventing the upgrade to complete safely.
+type ClusterUpgradePlan struct {
+	// Namespace of the Cluster.
+	Namespace string
+
+	// Name of the Cluster.
+	Name string
+
+	// ClusterClass used by the Cluster, in the form namespace/name; empty if the Cluster is not using a managed topology.
+	ClusterClass string
+
+	// CurrentVersion is the Kubernetes version of the control plane.
+	CurrentVersion string
+
+	// TargetVersion is the Kubernetes version the Cluster should be upgraded to.
+	TargetVersion string
+
+	// UpgradePath is the list of versions the control plane must go through to reach the target version,
+	// given that the minor version can only be increased by one at a time. Intermediate versions only
+	// define major and minor (e.g. v1.32.x), the latest patch release should be used.
+	UpgradePath []string
+
+	// Issues lists the objects that would block the upgrade or fail the MachineSet preflight checks
+	// while upgrading the Cluster.
+	Issues []string
+}
+
+// PlanClusterUpgrade returns an upgrade plan for each Cluster in the management cluster, which proposes a safe
+// path to the target Kubernetes version according to the Kubernetes and kubeadm version skew policies.
+func (c *clusterctlClient) PlanClusterUpgrade(ctx context.Context, options PlanClusterUpgradeOptions) ([]ClusterUpgradePlan, error) {
+	targetVersion, err := semver.ParseTolerant(options.KubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Kubernetes version %q", options.KubernetesVersion)
+	}
+
+	// Get the client for interacting with the management cluster.
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := clusterClient.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+		return nil, err
+	}
+
+	proxyClient, err := clusterClient.Proxy().NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := proxyClient.List(ctx, clusterList, client.InNamespace(options.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	plans := []ClusterUpgradePlan{}
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if options.ClusterName != "" && cluster.Name != options.ClusterName {
+			continue
+		}
+		plan, err := planClusterUpgrade(ctx, proxyClient, cluster, targetVersion)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, *plan)
+	}
+	if options.ClusterName != "" && len(plans) == 0 {
+		return nil, errors.Errorf("Cluster %q not found", options.ClusterName)
+	}
+
+	sort.Slice(plans, func(i, j int) bool {
+		if plans[i].Namespace != plans[j].Namespace {
+			return plans[i].Namespace < plans[j].Namespace
+		}
+		return plans[i].Name < plans[j].Name
+	})
+	return plans, nil
+}
+
+// planClusterUpgrade computes the upgrade plan for a single Cluster.
+func planClusterUpgrade(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, targetVersion semver.Version) (*ClusterUpgradePlan, error) {
+	plan := &ClusterUpgradePlan{
+		Namespace:     cluster.Namespace,
+		Name:          cluster.Name,
+		TargetVersion: "v" + targetVersion.String(),
+	}
+	if cluster.Spec.Topology != nil {
+		plan.ClusterClass = cluster.GetClassKey().String()
+	}
+
+	currentVersion, err := getClusterControlPlaneVersion(ctx, c, cluster, plan)
+	if err != nil {
+		return nil, err
+	}
+	if currentVersion == nil {
+		return plan, nil
+	}
+	plan.CurrentVersion = "v" + currentVersion.String()
+
+	path, err := check.UpgradePath(*currentVersion, targetVersion)
+	if err != nil {
+		plan.Issues = append(plan.Issues, err.Error())
+		return plan, nil
+	}
+	for _, step := range path {
+		plan.UpgradePath = append(plan.UpgradePath, formatUpgradeStep(step, path))
+	}
+	if len(path) == 0 {
+		return plan, nil
+	}
+
+	if err := checkWorkerVersions(ctx, c, cluster, *currentVersion, path, plan); err != nil {
+		return nil, err
+	}
+	if err := checkMachineSetPreflightChecks(ctx, c, cluster, path, plan); err != nil {
+		return nil, err
+	}
+	if cluster.Spec.Topology != nil {
+		if err := checkClusterClassTemplates(ctx, c, cluster, targetVersion, plan); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// getClusterControlPlaneVersion returns the version of the control plane of a Cluster, falling back to the topology
+// version if the Cluster has no control plane yet; nil is returned if the version cannot be determined.
+func getClusterControlPlaneVersion(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, plan *ClusterUpgradePlan) (*semver.Version, error) {
+	var controlPlaneVersion string
+	if cluster.Spec.ControlPlaneRef != nil {
+		controlPlane, err := external.GetObjectFromContractVersionedRef(ctx, c, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+		if err != nil && !apierrors.IsNotFound(errors.Cause(err)) {
+			return nil, errors.Wrapf(err, "failed to get control plane for Cluster %s", klog.KObj(cluster))
+		}
+		if err == nil {
+			if v, err := contract.ControlPlane().Version().Get(controlPlane); err == nil {
+				controlPlaneVersion = *v
+			}
+		}
+	}
+
+	if cluster.Spec.Topology != nil {
+		if controlPlaneVersion != "" && controlPlaneVersion != cluster.Spec.Topology.Version {
+			plan.Issues = append(plan.Issues, fmt.Sprintf("control plane version %s is not yet the same as the Cluster topology version %s: wait for the ongoing upgrade to complete", controlPlaneVersion, cluster.Spec.Topology.Version))
+		}
+		if controlPlaneVersion == "" {
+			controlPlaneVersion = cluster.Spec.Topology.Version
+		}
+	}
+
+	if controlPlaneVersion == "" {
+		plan.Issues = append(plan.Issues, "unable to determine the Kubernetes version of the control plane")
+		return nil, nil
+	}
+	v, err := semver.ParseTolerant(controlPlaneVersion)
+	if err != nil {
+		plan.Issues = append(plan.Issues, fmt.Sprintf("invalid control plane version %q", controlPlaneVersion))
+		return nil, nil //nolint:nilerr // The invalid version is reported as an issue in the plan.
+	}
+	return &v, nil
+}
+
+// checkWorkerVersions checks that MachineDeployments and MachinePools are not blocking the upgrade of the control plane.
+func checkWorkerVersions(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, currentVersion semver.Version, path []semver.Version, plan *ClusterUpgradePlan) error {
+	type worker struct {
+		kind, name      string
+		topologyManaged bool
+		version         *string
+	}
+	workers := []worker{}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := c.List(ctx, machineDeployments, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return errors.Wrapf(err, "failed to list MachineDeployments for Cluster %s", klog.KObj(cluster))
+	}
+	for _, md := range machineDeployments.Items {
+		_, topologyManaged := md.Labels[clusterv1.ClusterTopologyOwnedLabel]
+		workers = append(workers, worker{kind: "MachineDeployment", name: md.Name, topologyManaged: topologyManaged, version: md.Spec.Template.Spec.Version})
+	}
+
+	machinePools := &clusterv1.MachinePoolList{}
+	if err := c.List(ctx, machinePools, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return errors.Wrapf(err, "failed to list MachinePools for Cluster %s", klog.KObj(cluster))
+	}
+	for _, mp := range machinePools.Items {
+		_, topologyManaged := mp.Labels[clusterv1.ClusterTopologyOwnedLabel]
+		workers = append(workers, worker{kind: "MachinePool", name: mp.Name, topologyManaged: topologyManaged, version: mp.Spec.Template.Spec.Version})
+	}
+
+	sort.Slice(workers, func(i, j int) bool {
+		if workers[i].kind != workers[j].kind {
+			return workers[i].kind < workers[j].kind
+		}
+		return workers[i].name < workers[j].name
+	})
+
+	for _, w := range workers {
+		if w.version == nil {
+			continue
+		}
+		v, err := semver.ParseTolerant(*w.version)
+		if err != nil {
+			plan.Issues = append(plan.Issues, fmt.Sprintf("%s %s has an invalid version %q", w.kind, w.name, *w.version))
+			continue
+		}
+
+		// Workers managed by the topology controller are upgraded after the control plane, but the
+		// topology version cannot be increased until they are on the current control plane version.
+		if w.topologyManaged {
+			if !v.EQ(currentVersion) {
+				plan.Issues = append(plan.Issues, fmt.Sprintf("%s %s (%s) is not yet on the control plane version v%s: the Cluster topology version can't be changed until it is", w.kind, w.name, *w.version, currentVersion))
+			}
+			continue
+		}
+
+		// Other workers must be upgraded by the user, and they must stay within the Kubernetes version skew
+		// policy at each step of the control plane upgrade.
+		for _, step := range path {
+			if err := check.KubernetesVersionSkew(step, v); err != nil {
+				plan.Issues = append(plan.Issues, fmt.Sprintf("%s %s (%s) must be upgraded before upgrading the control plane to %s: %v", w.kind, w.name, *w.version, formatUpgradeStep(step, path), err))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkMachineSetPreflightChecks flags MachineSets which would fail the KubernetesVersionSkew or KubeadmVersionSkew
+// preflight checks during the upgrade, thus not being able to create new Machines.
+func checkMachineSetPreflightChecks(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, path []semver.Version, plan *ClusterUpgradePlan) error {
+	machineSets := &clusterv1.MachineSetList{}
+	if err := c.List(ctx, machineSets, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return errors.Wrapf(err, "failed to list MachineSets for Cluster %s", klog.KObj(cluster))
+	}
+	sort.Slice(machineSets.Items, func(i, j int) bool {
+		return machineSets.Items[i].Name < machineSets.Items[j].Name
+	})
+
+	for i := range machineSets.Items {
+		ms := &machineSets.Items[i]
+		if !ms.DeletionTimestamp.IsZero() || ms.Spec.Template.Spec.Version == nil {
+			continue
+		}
+		msVersion := *ms.Spec.Template.Spec.Version
+		v, err := semver.ParseTolerant(msVersion)
+		if err != nil {
+			plan.Issues = append(plan.Issues, fmt.Sprintf("MachineSet %s has an invalid version %q", ms.Name, msVersion))
+			continue
+		}
+
+		skipped := sets.Set[clusterv1.MachineSetPreflightCheck]{}
+		for _, s := range strings.Split(ms.Annotations[clusterv1.MachineSetSkipPreflightChecksAnnotation], ",") {
+			skipped.Insert(clusterv1.MachineSetPreflightCheck(strings.TrimSpace(s)))
+		}
+		shouldRun := func(preflightCheck clusterv1.MachineSetPreflightCheck) bool {
+			return !skipped.Has(clusterv1.MachineSetPreflightCheckAll) && !skipped.Has(preflightCheck)
+		}
+
+		bootstrapConfigRef := ms.Spec.Template.Spec.Bootstrap.ConfigRef
+		kubeadmBootstrapProviderUsed := bootstrapConfigRef != nil &&
+			bootstrapConfigRef.Kind == "KubeadmConfigTemplate" &&
+			bootstrapConfigRef.APIGroup == bootstrapv1.GroupVersion.Group
+
+		for _, step := range path {
+			var failedCheck clusterv1.MachineSetPreflightCheck
+			var err error
+			if shouldRun(clusterv1.MachineSetPreflightCheckKubernetesVersionSkew) {
+				if err = check.KubernetesVersionSkew(step, v); err != nil {
+					failedCheck = clusterv1.MachineSetPreflightCheckKubernetesVersionSkew
+				}
+			}
+			if err == nil && kubeadmBootstrapProviderUsed && shouldRun(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew) {
+				if err = check.KubeadmVersionSkew(step, v); err != nil {
+					failedCheck = clusterv1.MachineSetPreflightCheckKubeadmVersionSkew
+				}
+			}
+			if err != nil {
+				plan.Issues = append(plan.Issues, fmt.Sprintf("MachineSet %s (%s) would fail the %q preflight check once the control plane is at %s: it can't create Machines until it is replaced by a MachineSet with a compatible version", ms.Name, msVersion, failedCheck, formatUpgradeStep(step, path)))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkClusterClassTemplates flags the templates of the ClusterClass used by a Cluster that do not exist or that
+// do not support the target Kubernetes version, according to the KubernetesVersionsAnnotation.
+func checkClusterClassTemplates(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, targetVersion semver.Version, plan *ClusterUpgradePlan) error {
+	classKey := cluster.GetClassKey()
+	clusterClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, classKey, clusterClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			plan.Issues = append(plan.Issues, fmt.Sprintf("ClusterClass %s does not exist", classKey))
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get ClusterClass %s", classKey)
+	}
+
+	refs := []*clusterv1.ClusterClassTemplateReference{
+		clusterClass.Spec.Infrastructure.Ref,
+		clusterClass.Spec.ControlPlane.Ref,
+	}
+	if clusterClass.Spec.ControlPlane.MachineInfrastructure != nil {
+		refs = append(refs, clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
+	}
+	for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
+		refs = append(refs, mdClass.Template.Bootstrap.Ref, mdClass.Template.Infrastructure.Ref)
+	}
+	for _, mpClass := range clusterClass.Spec.Workers.MachinePools {
+		refs = append(refs, mpClass.Template.Bootstrap.Ref, mpClass.Template.Infrastructure.Ref)
+	}
+
+	checked := sets.Set[string]{}
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+		if checked.Has(key) {
+			continue
+		}
+		checked.Insert(key)
+
+		template, err := external.Get(ctx, c, ref.ToObjectReference(clusterClass.Namespace))
+		if err != nil {
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				plan.Issues = append(plan.Issues, fmt.Sprintf("ClusterClass %s references %s %s which does not exist", classKey, ref.Kind, ref.Name))
+				continue
+			}
+			return errors.Wrapf(err, "failed to get %s %s referenced by ClusterClass %s", ref.Kind, ref.Name, classKey)
+		}
+
+		versions, ok := template.GetAnnotations()[clusterctlv1.KubernetesVersionsAnnotation]
+		if !ok {
+			continue
+		}
+		versionRange, err := semver.ParseRange(versions)
+		if err != nil {
+			plan.Issues = append(plan.Issues, fmt.Sprintf("%s %s referenced by ClusterClass %s has an invalid %s annotation %q", ref.Kind, ref.Name, classKey, clusterctlv1.KubernetesVersionsAnnotation, versions))
+			continue
+		}
+		if !versionRange(targetVersion) {
+			plan.Issues = append(plan.Issues, fmt.Sprintf("ClusterClass %s has no template for Kubernetes v%s: %s %s only supports %q", classKey, targetVersion, ref.Kind, ref.Name, versions))
+		}
+	}
+	return nil
+}
+
+// formatUpgradeStep returns a step of the upgrade path in the same format used by ClusterUpgradePlan.UpgradePath.
+func formatUpgradeStep(step semver.Version, path []semver.Version) string {
+	if step.EQ(path[len(path)-1]) {
+		return "v" + step.String()
+	}
+	return fmt.Sprintf("v%d.%d.x", step.Major, step.Minor)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	fakecontrolplane "sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test/providers/controlplane"
+	fakeinfrastructure "sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test/providers/infrastructure"
+)
+
+func Test_clusterctlClient_PlanClusterUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	objs := []client.Object{}
+	for _, crd := range test.FakeCRDList() {
+		objs = append(objs, crd)
+	}
+
+	// cluster1 is not using a managed topology, and it has workers which must be upgraded by the user.
+	objs = append(objs,
+		fakeUpgradeCluster("cluster1", "cp1", nil),
+		fakeUpgradeControlPlane("cp1", "v1.30.2"),
+		fakeUpgradeMachineDeployment("cluster1", "md-old", "v1.27.0", false),
+		fakeUpgradeMachineDeployment("cluster1", "md-current", "v1.30.2", false),
+		fakeUpgradeMachineSet("cluster1", "ms-kubeadm", "v1.30.2", nil),
+		fakeUpgradeMachineSet("cluster1", "ms-kubeadm-skipped", "v1.30.2", map[string]string{
+			clusterv1.MachineSetSkipPreflightChecksAnnotation: string(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew),
+		}),
+	)
+
+	// cluster2 is using a managed topology, its ClusterClass lacks templates for the target version.
+	objs = append(objs,
+		fakeUpgradeCluster("cluster2", "cp2", &clusterv1.Topology{
+			ClassRef: clusterv1.ClusterClassRef{Name: "class1"},
+			Version:  "v1.30.2",
+		}),
+		fakeUpgradeControlPlane("cp2", "v1.30.2"),
+		fakeUpgradeMachineDeployment("cluster2", "md-topology", "v1.29.0", true),
+		&clusterv1.ClusterClass{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "class1"},
+			Spec: clusterv1.ClusterClassSpec{
+				Infrastructure: clusterv1.InfrastructureClass{ClusterClassTemplate: clusterv1.ClusterClassTemplate{
+					Ref: &clusterv1.ClusterClassTemplateReference{APIVersion: fakeinfrastructure.GroupVersion.String(), Kind: "GenericInfrastructureClusterTemplate", Name: "infra-template"},
+				}},
+				ControlPlane: clusterv1.ControlPlaneClass{ClusterClassTemplate: clusterv1.ClusterClassTemplate{
+					Ref: &clusterv1.ClusterClassTemplateReference{APIVersion: fakecontrolplane.GroupVersion.String(), Kind: "GenericControlPlaneTemplate", Name: "cp-template"},
+				}},
+			},
+		},
+		fakeUpgradeInfrastructureClusterTemplate("infra-template", map[string]string{
+			clusterctlv1.KubernetesVersionsAnnotation: ">=1.28.0 <1.33.0",
+		}),
+	)
+
+	// cluster3 is already at the target version.
+	objs = append(objs,
+		fakeUpgradeCluster("cluster3", "cp3", nil),
+		fakeUpgradeControlPlane("cp3", "v1.33.1"),
+	)
+
+	configClient := newFakeConfig(ctx)
+	clusterClient := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}, configClient).WithObjs(objs...)
+	c := newFakeClient(ctx, configClient).WithCluster(clusterClient)
+
+	t.Run("plan upgrades for all the Clusters", func(t *testing.T) {
+		g := NewWithT(t)
+
+		plans, err := c.PlanClusterUpgrade(ctx, PlanClusterUpgradeOptions{
+			Kubeconfig:        Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			KubernetesVersion: "v1.33.1",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(plans).To(HaveLen(3))
+
+		g.Expect(plans[0].Name).To(Equal("cluster1"))
+		g.Expect(plans[0].ClusterClass).To(BeEmpty())
+		g.Expect(plans[0].CurrentVersion).To(Equal("v1.30.2"))
+		g.Expect(plans[0].UpgradePath).To(Equal([]string{"v1.31.x", "v1.32.x", "v1.33.1"}))
+		g.Expect(plans[0].Issues).To(ConsistOf(
+			ContainSubstring("MachineDeployment md-old (v1.27.0) must be upgraded before upgrading the control plane to v1.31.x"),
+			ContainSubstring(`MachineSet ms-kubeadm (v1.30.2) would fail the "KubeadmVersionSkew" preflight check once the control plane is at v1.31.x`),
+		))
+
+		g.Expect(plans[1].Name).To(Equal("cluster2"))
+		g.Expect(plans[1].ClusterClass).To(Equal("ns1/class1"))
+		g.Expect(plans[1].UpgradePath).To(Equal([]string{"v1.31.x", "v1.32.x", "v1.33.1"}))
+		g.Expect(plans[1].Issues).To(ConsistOf(
+			ContainSubstring("MachineDeployment md-topology (v1.29.0) is not yet on the control plane version v1.30.2"),
+			ContainSubstring("ClusterClass ns1/class1 references GenericControlPlaneTemplate cp-template which does not exist"),
+			ContainSubstring("ClusterClass ns1/class1 has no template for Kubernetes v1.33.1: GenericInfrastructureClusterTemplate infra-template"),
+		))
+
+		g.Expect(plans[2].Name).To(Equal("cluster3"))
+		g.Expect(plans[2].UpgradePath).To(BeEmpty())
+		g.Expect(plans[2].Issues).To(BeEmpty())
+	})
+
+	t.Run("plan upgrade for a single Cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		plans, err := c.PlanClusterUpgrade(ctx, PlanClusterUpgradeOptions{
+			Kubeconfig:        Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			Namespace:         "ns1",
+			ClusterName:       "cluster3",
+			KubernetesVersion: "v1.32.0",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(plans).To(HaveLen(1))
+		g.Expect(plans[0].UpgradePath).To(BeEmpty())
+		g.Expect(plans[0].Issues).To(ConsistOf(ContainSubstring("version cannot be decreased")))
+	})
+
+	t.Run("fail if the Cluster does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := c.PlanClusterUpgrade(ctx, PlanClusterUpgradeOptions{
+			Kubeconfig:        Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			ClusterName:       "does-not-exist",
+			KubernetesVersion: "v1.33.1",
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("fail if the Kubernetes version is not valid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := c.PlanClusterUpgrade(ctx, PlanClusterUpgradeOptions{
+			Kubeconfig:        Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			KubernetesVersion: "latest",
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func fakeUpgradeCluster(name, controlPlaneName string, topology *clusterv1.Topology) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &clusterv1.ContractVersionedObjectReference{
+				APIGroup: fakecontrolplane.GroupVersion.Group,
+				Kind:     "GenericControlPlane",
+				Name:     controlPlaneName,
+			},
+			Topology: topology,
+		},
+	}
+}
+
+func fakeUpgradeInfrastructureClusterTemplate(name string, annotations map[string]string) *unstructured.Unstructured {
+	template := &unstructured.Unstructured{}
+	template.SetAPIVersion(fakeinfrastructure.GroupVersion.String())
+	template.SetKind("GenericInfrastructureClusterTemplate")
+	template.SetNamespace("ns1")
+	template.SetName(name)
+	template.SetAnnotations(annotations)
+	return template
+}
+
+func fakeUpgradeControlPlane(name, version string) *fakecontrolplane.GenericControlPlane {
+	return &fakecontrolplane.GenericControlPlane{
+		TypeMeta:   metav1.TypeMeta{APIVersion: fakecontrolplane.GroupVersion.String(), Kind: "GenericControlPlane"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Spec:       fakecontrolplane.GenericControlPlaneSpec{Version: version},
+	}
+}
+
+func fakeUpgradeMachineDeployment(clusterName, name, version string, topologyManaged bool) *clusterv1.MachineDeployment {
+	md := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "MachineDeployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name, Labels: map[string]string{
+			clusterv1.ClusterNameLabel: clusterName,
+		}},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: clusterName,
+			Template: clusterv1.MachineTemplateSpec{Spec: clusterv1.MachineSpec{
+				ClusterName: clusterName,
+				Version:     ptr.To(version),
+			}},
+		},
+	}
+	if topologyManaged {
+		md.Labels[clusterv1.ClusterTopologyOwnedLabel] = ""
+	}
+	return md
+}
+
+func fakeUpgradeMachineSet(clusterName, name, version string, annotations map[string]string) *clusterv1.MachineSet {
+	return &clusterv1.MachineSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "MachineSet"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name, Annotations: annotations, Labels: map[string]string{
+			clusterv1.ClusterNameLabel: clusterName,
+		}},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: clusterName,
+			Template: clusterv1.MachineTemplateSpec{Spec: clusterv1.MachineSpec{
+				ClusterName: clusterName,
+				Version:     ptr.To(version),
+				Bootstrap: clusterv1.Bootstrap{ConfigRef: &clusterv1.ContractVersionedObjectReference{
+					APIGroup: bootstrapv1.GroupVersion.Group,
+					Kind:     "KubeadmConfigTemplate",
+					Name:     name,
+				}},
+			}},
+		},
+	}
+}
@@ -17,19 +17,46 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
 	cmdtree "sigs.k8s.io/cluster-api/internal/util/tree"
 )
 
+const (
+	// DescribeClusterOutputText is an option used to print the cluster description as a tree.
+	DescribeClusterOutputText = "text"
+	// DescribeClusterOutputJSON is an option used to print the cluster description in json format.
+	DescribeClusterOutputJSON = "json"
+	// DescribeClusterOutputYaml is an option used to print the cluster description in yaml format.
+	DescribeClusterOutputYaml = "yaml"
+
+	// clearScreen is the escape sequence used to clear the terminal before re-rendering the text output in watch mode.
+	clearScreen = "\033[H\033[2J"
+)
+
+var (
+	// DescribeClusterOutputs is a list of valid describe cluster outputs.
+	DescribeClusterOutputs = []string{DescribeClusterOutputText, DescribeClusterOutputJSON, DescribeClusterOutputYaml}
+)
+
 type describeClusterOptions struct {
 	kubeconfig              string
 	kubeconfigContext       string
@@ -43,6 +70,9 @@ type describeClusterOptions struct {
 	disableGrouping         bool
 	v1beta2                 bool
 	color                   bool
+	output                  string
+	watch                   bool
+	watchInterval           time.Duration
 }
 
 var dc = &describeClusterOptions{}
@@ -71,7 +101,13 @@ var describeClusterClusterCmd = &cobra.Command{
 
 		# Describe the cluster named test-1 showing the MachineInfrastructure and BootstrapConfig objects
 		# also when their status is the same as the status of the corresponding machine object.
-		clusterctl describe cluster test-1 --echo`),
+		clusterctl describe cluster test-1 --echo
+
+		# Describe the cluster named test-1 in yaml format, e.g. for consumption by other tools.
+		clusterctl describe cluster test-1 -o yaml
+
+		# Describe the cluster named test-1, and re-render the description every time it changes.
+		clusterctl describe cluster test-1 --watch`),
 
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -114,6 +150,12 @@ func init() {
 	_ = describeClusterClusterCmd.Flags().MarkDeprecated("v1beta2",
 		"this field will be removed when v1beta1 will be dropped.")
 	describeClusterClusterCmd.Flags().BoolVarP(&dc.color, "color", "c", false, "Enable or disable color output; if not set color is enabled by default only if using tty. The flag is overridden by the NO_COLOR env variable if set.")
+	describeClusterClusterCmd.Flags().StringVarP(&dc.output, "output", "o", DescribeClusterOutputText,
+		fmt.Sprintf("Output format. Valid values: %v.", DescribeClusterOutputs))
+	describeClusterClusterCmd.Flags().BoolVarP(&dc.watch, "watch", "w", false,
+		"Watch the cluster, and print the cluster description again every time it changes.")
+	describeClusterClusterCmd.Flags().DurationVar(&dc.watchInterval, "watch-interval", 5*time.Second,
+		"Interval between checks for changes when using --watch.")
 
 	// completions
 	describeClusterClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -128,6 +170,13 @@ func init() {
 }
 
 func runDescribeCluster(cmd *cobra.Command, name string) error {
+	if !slices.Contains(DescribeClusterOutputs, dc.output) {
+		return errors.Errorf("invalid output format %q, valid values: %v", dc.output, DescribeClusterOutputs)
+	}
+	if dc.watch && dc.watchInterval <= 0 {
+		return errors.New("--watch-interval must be greater than 0")
+	}
+
 	ctx := context.Background()
 
 	c, err := client.New(ctx, cfgFile)
@@ -135,33 +184,106 @@ func runDescribeCluster(cmd *cobra.Command, name string) error {
 		return err
 	}
 
-	tree, err := c.DescribeCluster(ctx, client.DescribeClusterOptions{
-		Kubeconfig:              client.Kubeconfig{Path: dc.kubeconfig, Context: dc.kubeconfigContext},
-		Namespace:               dc.namespace,
-		ClusterName:             name,
-		ShowOtherConditions:     dc.showOtherConditions,
-		ShowClusterResourceSets: dc.showClusterResourceSets,
-		ShowTemplates:           dc.showTemplates,
-		ShowMachineSets:         dc.showMachineSets,
-		AddTemplateVirtualNode:  true,
-		Echo:                    dc.echo,
-		Grouping:                dc.grouping && !dc.disableGrouping,
-		V1Beta1:                 !dc.v1beta2,
-	})
-	if err != nil {
-		return err
-	}
-
 	if cmd.Flags().Changed("color") {
 		color.NoColor = !dc.color
 	}
 
-	switch dc.v1beta2 {
-	case true:
-		cmdtree.PrintObjectTree(tree, os.Stdout)
-	default:
-		cmdtree.PrintObjectTreeV1Beta1(tree)
+	describe := func() ([]byte, error) {
+		tree, err := c.DescribeCluster(ctx, client.DescribeClusterOptions{
+			Kubeconfig:              client.Kubeconfig{Path: dc.kubeconfig, Context: dc.kubeconfigContext},
+			Namespace:               dc.namespace,
+			ClusterName:             name,
+			ShowOtherConditions:     dc.showOtherConditions,
+			ShowClusterResourceSets: dc.showClusterResourceSets,
+			ShowTemplates:           dc.showTemplates,
+			ShowMachineSets:         dc.showMachineSets,
+			AddTemplateVirtualNode:  true,
+			Echo:                    dc.echo,
+			Grouping:                dc.grouping && !dc.disableGrouping,
+			V1Beta1:                 !dc.v1beta2,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		out := &bytes.Buffer{}
+		if err := printClusterDescription(out, tree, dc.output, dc.v1beta2); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
 	}
 
+	if !dc.watch {
+		out, err := describe()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	return watchClusterDescription(ctx, os.Stdout, describe, dc.output, dc.watchInterval)
+}
+
+// printClusterDescription prints the cluster description in the given output format.
+func printClusterDescription(w io.Writer, objectTree *tree.ObjectTree, output string, v1beta2 bool) error {
+	switch output {
+	case DescribeClusterOutputJSON:
+		out, err := json.MarshalIndent(tree.NewClusterDescription(objectTree), "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal cluster description to json")
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case DescribeClusterOutputYaml:
+		out, err := yaml.Marshal(tree.NewClusterDescription(objectTree))
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal cluster description to yaml")
+		}
+		_, err = w.Write(out)
+		return err
+	}
+
+	if v1beta2 {
+		cmdtree.PrintObjectTree(objectTree, w)
+		return nil
+	}
+	cmdtree.PrintObjectTreeV1Beta1(objectTree, w)
 	return nil
 }
+
+// watchClusterDescription describes the cluster at every interval, and prints the cluster description
+// every time it changes until the context is cancelled.
+// When using the text output, the screen is cleared before printing; when using json or yaml output,
+// each description is printed as a new document, so the output can be consumed as a stream.
+func watchClusterDescription(ctx context.Context, w io.Writer, describe func() ([]byte, error), output string, interval time.Duration) error {
+	var last []byte
+	for {
+		out, err := describe()
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(out, last) {
+			prefix := ""
+			switch {
+			case output == DescribeClusterOutputText:
+				prefix = clearScreen
+			case output == DescribeClusterOutputYaml && last != nil:
+				prefix = "---\n"
+			}
+			if _, err := fmt.Fprintf(w, "%s%s", prefix, out); err != nil {
+				return err
+			}
+			last = out
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+)
+
+func Test_printClusterDescription(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-cluster",
+			UID:       types.UID("my-cluster"),
+		},
+	}
+	objectTree := tree.NewObjectTree(cluster, tree.ObjectTreeOptions{})
+
+	t.Run("prints json", func(t *testing.T) {
+		g := NewWithT(t)
+
+		buf := &bytes.Buffer{}
+		g.Expect(printClusterDescription(buf, objectTree, DescribeClusterOutputJSON, true)).To(Succeed())
+
+		description := &tree.ClusterDescription{}
+		g.Expect(json.Unmarshal(buf.Bytes(), description)).To(Succeed())
+		g.Expect(description.APIVersion).To(Equal(tree.GroupVersionClusterDescription.String()))
+		g.Expect(description.Kind).To(Equal(tree.ClusterDescriptionKind))
+		g.Expect(description.Root.Name).To(Equal("my-cluster"))
+	})
+
+	t.Run("prints yaml", func(t *testing.T) {
+		g := NewWithT(t)
+
+		buf := &bytes.Buffer{}
+		g.Expect(printClusterDescription(buf, objectTree, DescribeClusterOutputYaml, true)).To(Succeed())
+
+		description := &tree.ClusterDescription{}
+		g.Expect(yaml.Unmarshal(buf.Bytes(), description)).To(Succeed())
+		g.Expect(description.Root.Kind).To(Equal("Cluster"))
+	})
+
+	t.Run("prints text", func(t *testing.T) {
+		g := NewWithT(t)
+
+		buf := &bytes.Buffer{}
+		g.Expect(printClusterDescription(buf, objectTree, DescribeClusterOutputText, true)).To(Succeed())
+		g.Expect(buf.String()).To(ContainSubstring("my-cluster"))
+	})
+}
+
+func Test_watchClusterDescription(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		descriptions []string
+		want         string
+	}{
+		{
+			name:         "yaml descriptions are printed as separate documents only when changed",
+			output:       DescribeClusterOutputYaml,
+			descriptions: []string{"a: 1\n", "a: 1\n", "a: 2\n"},
+			want:         "a: 1\n---\na: 2\n",
+		},
+		{
+			name:         "json descriptions are printed only when changed",
+			output:       DescribeClusterOutputJSON,
+			descriptions: []string{"{}\n", "{\"a\":1}\n", "{\"a\":1}\n"},
+			want:         "{}\n{\"a\":1}\n",
+		},
+		{
+			name:         "text descriptions clear the screen",
+			output:       DescribeClusterOutputText,
+			descriptions: []string{"tree\n", "tree\n", "new tree\n"},
+			want:         clearScreen + "tree\n" + clearScreen + "new tree\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			calls := 0
+			describe := func() ([]byte, error) {
+				out := tt.descriptions[calls]
+				calls++
+				if calls == len(tt.descriptions) {
+					cancel()
+				}
+				return []byte(out), nil
+			}
+
+			buf := &bytes.Buffer{}
+			g.Expect(watchClusterDescription(ctx, buf, describe, tt.output, time.Millisecond)).To(Succeed())
+			g.Expect(calls).To(Equal(len(tt.descriptions)))
+			g.Expect(buf.String()).To(Equal(tt.want))
+		})
+	}
+}
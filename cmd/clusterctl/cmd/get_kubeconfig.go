@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -29,9 +30,13 @@ import (
 )
 
 type getKubeconfigOptions struct {
-	kubeconfig        string
-	kubeconfigContext string
-	namespace         string
+	kubeconfig         string
+	kubeconfigContext  string
+	namespace          string
+	user               string
+	groups             []string
+	ttl                time.Duration
+	allowSystemMasters bool
 }
 
 var gk = &getKubeconfigOptions{}
@@ -40,14 +45,22 @@ var getKubeconfigCmd = &cobra.Command{
 	Use:   "kubeconfig NAME",
 	Short: "Gets the kubeconfig file for accessing a workload cluster",
 	Long: templates.LongDesc(`
-		Gets the kubeconfig file for accessing a workload cluster`),
+		Gets the kubeconfig file for accessing a workload cluster.
+
+		By default the admin kubeconfig of the workload cluster is returned. When --user is specified,
+		a new kubeconfig is generated with a client certificate signed by the cluster CA for the given user
+		and groups, valid for the given TTL; the permissions of the user must be granted in the workload cluster
+		using RBAC, and the client certificate cannot be revoked before it expires.`),
 
 	Example: templates.Examples(`
 		# Get the workload cluster's kubeconfig.
 		clusterctl get kubeconfig <name of workload cluster>
 
 		# Get the workload cluster's kubeconfig in a particular namespace.
-		clusterctl get kubeconfig <name of workload cluster> --namespace foo`),
+		clusterctl get kubeconfig <name of workload cluster> --namespace foo
+
+		# Get a kubeconfig for the user jane, member of the devs group, valid for 8 hours.
+		clusterctl get kubeconfig <name of workload cluster> --user jane --group devs --ttl 8h`),
 
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -67,6 +80,14 @@ func init() {
 		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
 	getKubeconfigCmd.Flags().StringVar(&gk.kubeconfigContext, "kubeconfig-context", "",
 		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	getKubeconfigCmd.Flags().StringVar(&gk.user, "user", "",
+		"Name of the user to generate a kubeconfig for, with a client certificate signed by the cluster CA. If unspecified, the admin kubeconfig is returned.")
+	getKubeconfigCmd.Flags().StringSliceVar(&gk.groups, "group", nil,
+		"Group of the user to generate a kubeconfig for. Can be repeated. Requires --user.")
+	getKubeconfigCmd.Flags().DurationVar(&gk.ttl, "ttl", 0,
+		fmt.Sprintf("Lifespan of the client certificate of the user to generate a kubeconfig for. Requires --user. If unspecified, %s is used.", client.DefaultUserKubeconfigTTL))
+	getKubeconfigCmd.Flags().BoolVar(&gk.allowSystemMasters, "allow-system-masters", false,
+		"Allow the system:masters group, which grants full access to the workload cluster bypassing RBAC, in --group. Requires --user.")
 
 	// completions
 	getKubeconfigCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -92,6 +113,10 @@ func runGetKubeconfig(workloadClusterName string) error {
 		Kubeconfig:          client.Kubeconfig{Path: gk.kubeconfig, Context: gk.kubeconfigContext},
 		WorkloadClusterName: workloadClusterName,
 		Namespace:           gk.namespace,
+		User:                gk.user,
+		Groups:              gk.groups,
+		TTL:                 gk.ttl,
+		AllowSystemMasters:  gk.allowSystemMasters,
 	}
 
 	out, err := c.GetKubeconfig(ctx, options)
@@ -40,6 +40,8 @@ type initOptions struct {
 	validate                  bool
 	waitProviders             bool
 	waitProviderTimeout       int
+	bundle                    string
+	imageRepository           string
 }
 
 var initOpts = &initOptions{}
@@ -83,7 +85,11 @@ var initCmd = &cobra.Command{
 		clusterctl init --infrastructure=aws,vsphere
 
 		# Initialize a management cluster with a custom target namespace for the provider resources.
-		clusterctl init --infrastructure aws --target-namespace foo`),
+		clusterctl init --infrastructure aws --target-namespace foo
+
+		# Initialize a management cluster in an air-gapped environment, using a bundle created with
+		# 'clusterctl init export-bundle' and pulling images from a private registry.
+		clusterctl init --infrastructure aws --bundle ./bundle --image-repository registry.example.com/capi`),
 	Args: cobra.NoArgs,
 	RunE: func(*cobra.Command, []string) error {
 		return runInit()
@@ -118,14 +124,25 @@ func init() {
 	initCmd.Flags().BoolVar(&initOpts.validate, "validate", true,
 		"If true, clusterctl will validate that the deployments will succeed on the management cluster.")
 
+	addBundleFlags(initCmd)
+
 	initCmd.AddCommand(initListImagesCmd)
+	initCmd.AddCommand(initExportBundleCmd)
 	RootCmd.AddCommand(initCmd)
 }
 
+// addBundleFlags adds the flags for reading providers from a bundle to an init command.
+func addBundleFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&initOpts.bundle, "bundle", "",
+		"Path to a bundle created with 'clusterctl init export-bundle'. If set, providers and cert-manager are read from the bundle instead of from their repositories.")
+	cmd.Flags().StringVar(&initOpts.imageRepository, "image-repository", "",
+		"The container registry to pull all the images from, e.g. a private registry where the images of the bundle have been mirrored. It can only be used in combination with --bundle.")
+}
+
 func runInit() error {
 	ctx := context.Background()
 
-	c, err := client.New(ctx, cfgFile)
+	c, err := newClientWithBundle(ctx, initOpts.bundle, initOpts.imageRepository)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+type initExportBundleOptions struct {
+	directory      string
+	flavors        []string
+	clusterClasses []string
+}
+
+var initExportBundleOpts = &initExportBundleOptions{}
+
+var initExportBundleCmd = &cobra.Command{
+	Use:   "export-bundle",
+	Short: "Exports a bundle for initializing or upgrading a management cluster in air-gapped environments",
+	Long: templates.LongDesc(`
+		Exports a bundle for initializing or upgrading a management cluster in air-gapped environments.
+
+		The bundle is a directory containing the components YAML, the metadata, the cluster templates
+		and the cluster classes of the selected providers, the cert-manager components and the list of
+		the required container images (images.txt). If the directory already contains a bundle, the
+		selected provider versions are added to it.
+
+		The bundle can then be used with 'clusterctl init --bundle' and 'clusterctl upgrade --bundle';
+		use --image-repository to pull the images from the registry the images listed in images.txt
+		have been mirrored to.
+
+		See https://cluster-api.sigs.k8s.io for more details.`),
+
+	Example: templates.Examples(`
+		# Exports a bundle with the latest release of the Cluster API core provider, of the kubeadm providers
+		# and of the given infrastructure provider.
+		clusterctl init export-bundle --infrastructure aws --directory ./bundle
+
+		# Exports a bundle with specific provider versions, the default cluster template, the given
+		# cluster template flavors and the given cluster classes.
+		clusterctl init export-bundle --core cluster-api:v1.10.0 --infrastructure docker:v1.10.0 \
+			--flavor development --cluster-class quick-start --directory ./bundle
+
+		# Initializes a management cluster from the bundle, pulling images from a private registry.
+		clusterctl init --infrastructure aws --bundle ./bundle --image-repository registry.example.com/capi`),
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runInitExportBundle()
+	},
+}
+
+func init() {
+	initExportBundleCmd.Flags().StringVarP(&initExportBundleOpts.directory, "directory", "d", "",
+		"The directory where the bundle should be written.")
+	initExportBundleCmd.Flags().StringSliceVarP(&initExportBundleOpts.flavors, "flavor", "f", nil,
+		"Cluster template flavors to add to the bundle in addition to the default template.")
+	initExportBundleCmd.Flags().StringSliceVar(&initExportBundleOpts.clusterClasses, "cluster-class", nil,
+		"Cluster classes to add to the bundle.")
+	_ = initExportBundleCmd.MarkFlagRequired("directory")
+}
+
+func runInitExportBundle() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	return c.ExportBundle(ctx, client.ExportBundleOptions{
+		Directory:                 initExportBundleOpts.directory,
+		CoreProvider:              initOpts.coreProvider,
+		BootstrapProviders:        initOpts.bootstrapProviders,
+		ControlPlaneProviders:     initOpts.controlPlaneProviders,
+		InfrastructureProviders:   initOpts.infrastructureProviders,
+		IPAMProviders:             initOpts.ipamProviders,
+		RuntimeExtensionProviders: initOpts.runtimeExtensionProviders,
+		AddonProviders:            initOpts.addonProviders,
+		Flavors:                   initExportBundleOpts.flavors,
+		ClusterClasses:            initExportBundleOpts.clusterClasses,
+	})
+}
@@ -49,10 +49,14 @@ var initListImagesCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	addBundleFlags(initListImagesCmd)
+}
+
 func runInitListImages() error {
 	ctx := context.Background()
 
-	c, err := client.New(ctx, cfgFile)
+	c, err := newClientWithBundle(ctx, initOpts.bundle, initOpts.imageRepository)
 	if err != nil {
 		return err
 	}
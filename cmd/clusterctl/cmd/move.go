@@ -40,7 +40,15 @@ type moveOptions struct {
 	namespace             string
 	fromDirectory         string
 	toDirectory           string
+	encryptionPublicKey   string
+	decryptionPrivateKey  string
+	passphraseFile        string
+	allowUnverifiedFiles  bool
 	dryRun                bool
+	journalDirectory      string
+	rollback              bool
+	clusterName           string
+	clusterSelector       string
 	hideAPIWarnings       string
 }
 
@@ -59,11 +67,29 @@ var moveCmd = &cobra.Command{
 		Move Cluster API objects and all dependencies between management clusters.
 		clusterctl move --to-kubeconfig=target-kubeconfig.yaml
 
+		Move only the Cluster named my-cluster and its dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster my-cluster
+
+		Move only the Clusters with the label env=dev and their dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --selector env=dev
+
 		Write Cluster API objects and all dependencies from a management cluster to directory.
 		clusterctl move --to-directory /tmp/backup-directory
 
 		Read Cluster API objects and all dependencies from a directory into a management cluster.
 		clusterctl move --from-directory /tmp/backup-directory
+
+		Write Cluster API objects and all dependencies from a management cluster to an archive encrypted with a public key.
+		clusterctl move --to-directory /tmp/backup-directory --encryption-public-key public-key.pem
+
+		Read Cluster API objects and all dependencies from an encrypted archive into a management cluster.
+		clusterctl move --from-directory /tmp/backup-directory --decryption-private-key private-key.pem
+
+		Move Cluster API objects recording the progress in a journal; if the move fails, run the same command again to resume it.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --journal-dir /tmp/move-journal
+
+		Roll back a move which failed, deleting the objects created in the destination management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --journal-dir /tmp/move-journal --rollback
 	`),
 	Args: cobra.NoArgs,
 	RunE: func(*cobra.Command, []string) error {
@@ -88,12 +114,39 @@ func init() {
 		"Write Cluster API objects and all dependencies from a management cluster to directory.")
 	moveCmd.Flags().StringVar(&mo.fromDirectory, "from-directory", "",
 		"Read Cluster API objects and all dependencies from a directory into a management cluster.")
+	moveCmd.Flags().StringVar(&mo.encryptionPublicKey, "encryption-public-key", "",
+		"Path to a file containing one or more age recipients used for encrypting the archive written by --to-directory.")
+	moveCmd.Flags().StringVar(&mo.decryptionPrivateKey, "decryption-private-key", "",
+		"Path to a file containing one or more age identities used for decrypting the archive read by --from-directory.")
+	moveCmd.Flags().StringVar(&mo.passphraseFile, "passphrase-file", "",
+		"Path to a file containing a passphrase used for encrypting the archive written by --to-directory, or for decrypting the archive read by --from-directory.")
+	moveCmd.Flags().BoolVar(&mo.allowUnverifiedFiles, "allow-unverified-files", false,
+		"Allow --from-directory to read a directory without an archive, e.g. written by older versions of clusterctl, restoring objects without verifying them.")
+	moveCmd.Flags().StringVar(&mo.journalDirectory, "journal-dir", "",
+		"Record the progress of the move in a journal in this directory. If the directory contains the journal of a move which failed, the move is resumed from the last group of objects moved successfully.")
+	moveCmd.Flags().BoolVar(&mo.rollback, "rollback", false,
+		"Roll back a move which failed using the journal in --journal-dir: objects created in the destination management cluster are deleted and the source cluster is resumed.")
+	moveCmd.Flags().StringVar(&mo.clusterName, "cluster", "",
+		"Move only the Cluster with this name and its dependencies. ClusterClasses, templates and ClusterResourceSets still used by other Clusters are copied and not deleted from the source management cluster.")
+	moveCmd.Flags().StringVarP(&mo.clusterSelector, "selector", "l", "",
+		"Move only the Clusters matching this label selector (e.g. env=dev) and their dependencies. ClusterClasses, templates and ClusterResourceSets still used by other Clusters are copied and not deleted from the source management cluster.")
 	moveCmd.Flags().StringVar(&mo.hideAPIWarnings, "hide-api-warnings", "default",
 		"Set of API server warnings to hide. Valid sets are \"default\" (includes metadata.finalizer warnings), \"all\" , and \"none\".")
 
 	moveCmd.MarkFlagsMutuallyExclusive("to-directory", "to-kubeconfig")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "to-directory")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("encryption-public-key", "from-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("decryption-private-key", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("encryption-public-key", "passphrase-file")
+	moveCmd.MarkFlagsMutuallyExclusive("allow-unverified-files", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("journal-dir", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("journal-dir", "from-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("rollback", "dry-run")
+	moveCmd.MarkFlagsMutuallyExclusive("cluster", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("cluster", "from-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("selector", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("selector", "from-directory")
 
 	RootCmd.AddCommand(moveCmd)
 }
@@ -108,6 +161,23 @@ func runMove() error {
 		return errors.New("please specify a target cluster using the --to-kubeconfig flag when not using --dry-run, --to-directory or --from-directory")
 	}
 
+	if mo.encryptionPublicKey != "" && mo.toDirectory == "" {
+		return errors.New("--encryption-public-key can only be used with --to-directory")
+	}
+	if mo.decryptionPrivateKey != "" && mo.fromDirectory == "" {
+		return errors.New("--decryption-private-key can only be used with --from-directory")
+	}
+	if mo.passphraseFile != "" && mo.toDirectory == "" && mo.fromDirectory == "" {
+		return errors.New("--passphrase-file can only be used with --to-directory or --from-directory")
+	}
+	if mo.allowUnverifiedFiles && mo.fromDirectory == "" {
+		return errors.New("--allow-unverified-files can only be used with --from-directory")
+	}
+
+	if mo.rollback && mo.journalDirectory == "" {
+		return errors.New("please specify the journal of the move to roll back using the --journal-dir flag")
+	}
+
 	configClient, err := config.New(ctx, cfgFile)
 	if err != nil {
 		return err
@@ -162,11 +232,19 @@ func runMove() error {
 	}
 
 	return c.Move(ctx, client.MoveOptions{
-		FromKubeconfig: client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
-		ToKubeconfig:   client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
-		FromDirectory:  mo.fromDirectory,
-		ToDirectory:    mo.toDirectory,
-		Namespace:      mo.namespace,
-		DryRun:         mo.dryRun,
+		FromKubeconfig:           client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
+		ToKubeconfig:             client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
+		FromDirectory:            mo.fromDirectory,
+		ToDirectory:              mo.toDirectory,
+		EncryptionPublicKeyFile:  mo.encryptionPublicKey,
+		DecryptionPrivateKeyFile: mo.decryptionPrivateKey,
+		PassphraseFile:           mo.passphraseFile,
+		AllowUnverifiedFiles:     mo.allowUnverifiedFiles,
+		Namespace:                mo.namespace,
+		DryRun:                   mo.dryRun,
+		JournalDirectory:         mo.journalDirectory,
+		Rollback:                 mo.rollback,
+		ClusterName:              mo.clusterName,
+		ClusterSelector:          mo.clusterSelector,
 	})
 }
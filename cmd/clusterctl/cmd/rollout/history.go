@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+// historyOptions is the start of the data required to perform the operation.
+type historyOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+}
+
+var historyOpt = &historyOptions{}
+
+var (
+	historyLong = templates.LongDesc(`
+		Show the rollout history of a cluster-api resource.
+
+	        Each revision of a MachineDeployment is a MachineSet; the revision number is read from the revision annotation
+	        of the MachineSet. Currently only MachineDeployments support rollout history.`)
+
+	historyExample = templates.Examples(`
+		# Show the rollout history of a machinedeployment
+		clusterctl alpha rollout history machinedeployment/my-md-0`)
+)
+
+// NewCmdRolloutHistory returns a Command instance for 'rollout history' sub command.
+func NewCmdRolloutHistory(cfgFile string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "history RESOURCE",
+		DisableFlagsInUseLine: true,
+		Short:                 "Show the rollout history of a cluster-api resource",
+		Long:                  historyLong,
+		Example:               historyExample,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runHistory(cfgFile, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&historyOpt.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	cmd.Flags().StringVar(&historyOpt.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	cmd.Flags().StringVarP(&historyOpt.namespace, "namespace", "n", "", "Namespace where the resource resides. If unspecified, the defult namespace will be used.")
+
+	return cmd
+}
+
+func runHistory(cfgFile, resource string) error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := c.RolloutHistory(ctx, client.RolloutHistoryOptions{
+		Kubeconfig: client.Kubeconfig{Path: historyOpt.kubeconfig, Context: historyOpt.kubeconfigContext},
+		Namespace:  historyOpt.namespace,
+		Resource:   resource,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(revisions) == 0 {
+		fmt.Println("No rollout history found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tMACHINESET\tREPLICAS\tVERSION\tINFRASTRUCTURE TEMPLATE\tBOOTSTRAP TEMPLATE\tAGE")
+	for _, r := range revisions {
+		revision := fmt.Sprintf("%d", r.Revision)
+		if r.Current {
+			revision += " (current)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			revision, r.MachineSet, r.Replicas, r.Version, r.InfrastructureTemplate, r.BootstrapTemplate,
+			duration.HumanDuration(time.Since(r.CreationTimestamp.Time)))
+	}
+	return w.Flush()
+}
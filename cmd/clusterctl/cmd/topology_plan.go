@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+type topologyPlanOptions struct {
+	kubeconfig                     string
+	kubeconfigContext              string
+	files                          []string
+	snapshots                      []string
+	cluster                        string
+	namespace                      string
+	useManagementClusterExtensions bool
+}
+
+var tp = &topologyPlanOptions{}
+
+var topologyPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Preview the changes to ClusterClass based clusters caused by changes to ClusterClasses and Clusters",
+	Long: templates.LongDesc(`
+		The topology plan command computes the changes that would be applied to ClusterClass based clusters
+		if new or modified ClusterClasses, templates or Clusters were applied to the management cluster.
+
+		The command runs the same logic of the topology controller, including inline and external patches, against
+		an in-memory copy of the management cluster, read from the management cluster or from one or more snapshot files.
+		External patches can be computed using a Runtime Extension running locally by passing in input an ExtensionConfig
+		pointing to it. Runtime Extensions registered in the management cluster are called only if
+		--use-management-cluster-extensions is set.
+
+		For each affected Cluster the command prints the diff of the objects that would be created, modified,
+		rotated or deleted, and the Machines that would be rolled out.
+
+		NOTE: The management cluster is never changed.`),
+
+	Example: templates.Examples(`
+		# Preview the changes to all the Clusters using a modified ClusterClass.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml
+
+		# Preview the changes to a single Cluster caused by a modified ClusterClass and a modified template.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml -f modified-template.yaml --cluster my-cluster -n foo
+
+		# Preview the changes using a snapshot of the management cluster instead of reading from the management cluster.
+		clusterctl alpha topology plan -f modified-cluster.yaml --snapshot management-cluster-objects.yaml
+
+		# Preview the changes computing external patches with a Runtime Extension running locally.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml -f local-extension-config.yaml
+
+		# Preview the changes computing external patches with the Runtime Extensions registered in the management cluster.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml --use-management-cluster-extensions`),
+
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runTopologyPlan()
+	},
+}
+
+func init() {
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig for the management cluster. If unspecified, default discovery rules apply.")
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	topologyPlanCmd.Flags().StringArrayVarP(&tp.files, "file", "f", nil,
+		"Path to a file with new or modified ClusterClasses, templates, Clusters or ExtensionConfigs. Can be repeated.")
+	topologyPlanCmd.Flags().StringArrayVar(&tp.snapshots, "snapshot", nil,
+		"Path to a file with the objects of the management cluster, to be used instead of reading from the management cluster. Can be repeated.")
+	topologyPlanCmd.Flags().StringVarP(&tp.cluster, "cluster", "c", "",
+		"Name of the Cluster to preview changes for. If unspecified, changes are computed for all the affected Clusters.")
+	topologyPlanCmd.Flags().StringVarP(&tp.namespace, "namespace", "n", "",
+		"Namespace used for objects without a namespace and for the Cluster. If unspecified, the current namespace will be used.")
+
+	topologyPlanCmd.Flags().BoolVar(&tp.useManagementClusterExtensions, "use-management-cluster-extensions", false,
+		"Call the Runtime Extensions registered in the management cluster, e.g. to compute external patches. If unspecified, only the ExtensionConfigs passed in input are used.")
+
+	_ = topologyPlanCmd.MarkFlagRequired("file")
+}
+
+func runTopologyPlan() error {
+	ctx := context.Background()
+
+	objs, err := readTopologyPlanFiles(tp.files)
+	if err != nil {
+		return err
+	}
+	var snapshot []*unstructured.Unstructured
+	if len(tp.snapshots) > 0 {
+		if snapshot, err = readTopologyPlanFiles(tp.snapshots); err != nil {
+			return err
+		}
+	}
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	out, err := c.TopologyPlan(ctx, client.TopologyPlanOptions{
+		Kubeconfig:                     client.Kubeconfig{Path: tp.kubeconfig, Context: tp.kubeconfigContext},
+		Objs:                           objs,
+		Snapshot:                       snapshot,
+		Cluster:                        tp.cluster,
+		Namespace:                      tp.namespace,
+		UseManagementClusterExtensions: tp.useManagementClusterExtensions,
+	})
+	if err != nil {
+		return err
+	}
+	return printTopologyPlan(os.Stdout, out)
+}
+
+// readTopologyPlanFiles reads the objects from the given files; Lists, e.g. the output of kubectl get -o yaml, are flattened.
+func readTopologyPlanFiles(files []string) ([]*unstructured.Unstructured, error) {
+	objs := []*unstructured.Unstructured{}
+	for _, f := range files {
+		raw, err := os.ReadFile(f) //nolint:gosec
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %q", f)
+		}
+		items, err := utilyaml.ToUnstructured(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", f)
+		}
+		for i := range items {
+			if !items[i].IsList() {
+				objs = append(objs, &items[i])
+				continue
+			}
+			if err := items[i].EachListItem(func(o runtime.Object) error {
+				objs = append(objs, o.(*unstructured.Unstructured))
+				return nil
+			}); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse %q", f)
+			}
+		}
+	}
+	return objs, nil
+}
+
+func printTopologyPlan(w io.Writer, out *client.TopologyPlanOutput) error {
+	if len(out.ClusterClasses) > 0 {
+		fmt.Fprintln(w, "The following ClusterClasses will be affected by the changes:")
+		for _, cc := range out.ClusterClasses {
+			fmt.Fprintf(w, " * %s\n", cc)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(out.Clusters) == 0 {
+		fmt.Fprintln(w, "No Clusters will be affected by the changes.")
+		return nil
+	}
+	fmt.Fprintln(w, "The following Clusters will be affected by the changes:")
+	for _, cluster := range out.Clusters {
+		fmt.Fprintf(w, " * %s\n", cluster.Cluster)
+	}
+
+	for _, cluster := range out.Clusters {
+		fmt.Fprintf(w, "\nChanges for Cluster %q:\n", cluster.Cluster)
+		if len(cluster.Changes) == 0 {
+			fmt.Fprintln(w, "No changes detected.")
+			continue
+		}
+
+		tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(tw, "  KIND\tNAMESPACE\tNAME\tCHANGE")
+		for _, change := range cluster.Changes {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", change.Object.Kind, change.Object.Namespace, change.Object.Name, change.Type)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		for _, change := range cluster.Changes {
+			if change.Diff == "" {
+				continue
+			}
+			fmt.Fprintf(w, "\n%s %s/%s:\n", change.Object.Kind, change.Object.Namespace, change.Object.Name)
+			fmt.Fprintln(w, indentTopologyPlanDiff(change.Diff))
+		}
+
+		for _, rollout := range cluster.Rollouts {
+			fmt.Fprintf(w, "\nMachines of %s %s/%s will be rolled out:\n", rollout.Owner.Kind, rollout.Owner.Namespace, rollout.Owner.Name)
+			for _, reason := range rollout.Reasons {
+				fmt.Fprintf(w, "  reason: %s\n", reason)
+			}
+			for _, machine := range rollout.Machines {
+				fmt.Fprintf(w, "  * %s\n", machine)
+			}
+		}
+	}
+	return nil
+}
+
+func indentTopologyPlanDiff(s string) string {
+	return "  " + strings.ReplaceAll(s, "\n", "\n  ")
+}
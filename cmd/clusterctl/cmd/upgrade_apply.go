@@ -41,6 +41,8 @@ type upgradeApplyOptions struct {
 	waitProviders                    bool
 	waitProviderTimeout              int
 	enableCRDStorageVersionMigration bool
+	bundle                           string
+	imageRepository                  string
 }
 
 var ua = &upgradeApplyOptions{}
@@ -61,7 +63,10 @@ var upgradeApplyCmd = &cobra.Command{
 		clusterctl upgrade apply --contract v1beta2
 
 		# Upgrades only the aws provider to the v2.0.1 version.
-		clusterctl upgrade apply --infrastructure aws:v2.0.1`),
+		clusterctl upgrade apply --infrastructure aws:v2.0.1
+
+		# Upgrades the aws provider to the v2.0.1 version stored in a bundle, pulling images from a private registry.
+		clusterctl upgrade apply --infrastructure aws:v2.0.1 --bundle ./bundle --image-repository registry.example.com/capi`),
 	Args: cobra.NoArgs,
 	RunE: func(*cobra.Command, []string) error {
 		return runUpgradeApply()
@@ -96,6 +101,10 @@ func init() {
 		"Wait timeout per provider upgrade in seconds. This value is ignored if --wait-providers is false")
 	upgradeApplyCmd.Flags().BoolVar(&ua.enableCRDStorageVersionMigration, "enable-crd-storage-version-migration", false,
 		"Enable CRD storage version migration")
+	upgradeApplyCmd.Flags().StringVar(&ua.bundle, "bundle", "",
+		"Path to a bundle created with 'clusterctl init export-bundle'. If set, providers and cert-manager are read from the bundle instead of from their repositories.")
+	upgradeApplyCmd.Flags().StringVar(&ua.imageRepository, "image-repository", "",
+		"The container registry to pull all the images from, e.g. a private registry where the images of the bundle have been mirrored. It can only be used in combination with --bundle.")
 	_ = upgradeApplyCmd.Flags().MarkDeprecated("enable-crd-storage-version-migration",
 		"Storage version migration during upgrades has been deprecated and will be removed in Cluster API v1.13")
 }
@@ -103,7 +112,7 @@ func init() {
 func runUpgradeApply() error {
 	ctx := context.Background()
 
-	c, err := client.New(ctx, cfgFile)
+	c, err := newClientWithBundle(ctx, ua.bundle, ua.imageRepository)
 	if err != nil {
 		return err
 	}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -32,6 +34,10 @@ import (
 type upgradePlanOptions struct {
 	kubeconfig        string
 	kubeconfigContext string
+	kubernetesVersion string
+	namespace         string
+	clusterName       string
+	bundle            string
 }
 
 var up = &upgradePlanOptions{}
@@ -48,11 +54,23 @@ var upgradePlanCmd = &cobra.Command{
 
 		Then, for each provider, the following upgrade options are provided:
 		- The latest patch release for the current Cluster API contract version.
-		- The latest patch release for the next Cluster API contract version, if available.`),
+		- The latest patch release for the next Cluster API contract version, if available.
+
+		When the --kubernetes-version flag is set, the upgrade plan command instead inspects the workload clusters
+		and proposes a safe path for upgrading them to the given Kubernetes version, one minor version at a time.
+		The plan reports MachineDeployments and MachinePools which are blocking the upgrade, MachineSets which would
+		fail the KubernetesVersionSkew or KubeadmVersionSkew preflight checks, and ClusterClasses which lack templates
+		for the target Kubernetes version.`),
 
 	Example: templates.Examples(`
 		# Gets the recommended target versions for upgrading Cluster API providers.
-		clusterctl upgrade plan`),
+		clusterctl upgrade plan
+
+		# Gets the upgrade path to Kubernetes v1.33.1 for all the workload clusters.
+		clusterctl upgrade plan --kubernetes-version v1.33.1
+
+		# Gets the upgrade path to Kubernetes v1.33.1 for a single workload cluster.
+		clusterctl upgrade plan --kubernetes-version v1.33.1 --namespace foo --cluster my-cluster`),
 
 	RunE: func(*cobra.Command, []string) error {
 		return runUpgradePlan()
@@ -64,16 +82,31 @@ func init() {
 		"Path to the kubeconfig file to use for accessing the management cluster. If empty, default discovery rules apply.")
 	upgradePlanCmd.Flags().StringVar(&up.kubeconfigContext, "kubeconfig-context", "",
 		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	upgradePlanCmd.Flags().StringVar(&up.kubernetesVersion, "kubernetes-version", "",
+		"Kubernetes version the workload clusters should be upgraded to. If set, an upgrade plan for the workload clusters is provided instead of the one for the providers.")
+	upgradePlanCmd.Flags().StringVarP(&up.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are located. If unspecified, the workload clusters in all the namespaces are inspected.")
+	upgradePlanCmd.Flags().StringVar(&up.clusterName, "cluster", "",
+		"The name of the workload cluster to inspect. If unspecified, all the workload clusters are inspected.")
+	upgradePlanCmd.Flags().StringVar(&up.bundle, "bundle", "",
+		"Path to a bundle created with 'clusterctl init export-bundle'. If set, the target versions are read from the bundle instead of from the provider repositories.")
 }
 
 func runUpgradePlan() error {
 	ctx := context.Background()
 
-	c, err := client.New(ctx, cfgFile)
+	c, err := newClientWithBundle(ctx, up.bundle, "")
 	if err != nil {
 		return err
 	}
 
+	if up.kubernetesVersion != "" {
+		return runClusterUpgradePlan(ctx, c)
+	}
+	if up.namespace != "" || up.clusterName != "" {
+		return errors.New("--namespace and --cluster can only be used together with --kubernetes-version")
+	}
+
 	certManUpgradePlan, err := c.PlanCertManagerUpgrade(ctx, client.PlanUpgradeOptions{
 		Kubeconfig: client.Kubeconfig{Path: up.kubeconfig, Context: up.kubeconfigContext},
 	})
@@ -142,3 +175,65 @@ func runUpgradePlan() error {
 
 	return nil
 }
+
+func runClusterUpgradePlan(ctx context.Context, c client.Client) error {
+	upgradePlans, err := c.PlanClusterUpgrade(ctx, client.PlanClusterUpgradeOptions{
+		Kubeconfig:        client.Kubeconfig{Path: up.kubeconfig, Context: up.kubeconfigContext},
+		Namespace:         up.namespace,
+		ClusterName:       up.clusterName,
+		KubernetesVersion: up.kubernetesVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(upgradePlans) == 0 {
+		fmt.Println("There are no workload clusters in the management cluster.")
+		return nil
+	}
+
+	fmt.Println("")
+	fmt.Printf("Upgrade path to Kubernetes %s for the workload clusters:\n", upgradePlans[0].TargetVersion)
+	fmt.Println("")
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tCLUSTERCLASS\tCURRENT VERSION\tUPGRADE PATH")
+	for _, plan := range upgradePlans {
+		path := "Already up to date"
+		if len(plan.UpgradePath) > 0 {
+			path = strings.Join(plan.UpgradePath, " -> ")
+		}
+		if plan.CurrentVersion == "" {
+			path = "Unknown"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", plan.Namespace, plan.Name, prettifyEmpty(plan.ClusterClass), prettifyEmpty(plan.CurrentVersion), path)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Println("")
+
+	issuesFound := false
+	for _, plan := range upgradePlans {
+		if len(plan.Issues) == 0 {
+			continue
+		}
+		issuesFound = true
+		fmt.Printf("Cluster %s/%s can't be safely upgraded yet:\n", plan.Namespace, plan.Name)
+		for _, issue := range plan.Issues {
+			fmt.Printf("- %s\n", issue)
+		}
+		fmt.Println("")
+	}
+	if !issuesFound {
+		fmt.Println("No issues found, the workload clusters can be upgraded following the upgrade path.")
+		fmt.Println("")
+	}
+	return nil
+}
+
+func prettifyEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -30,8 +31,25 @@ import (
 	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 )
 
+// newClientWithBundle returns a clusterctl client reading providers and cert-manager from the given bundle, if any.
+func newClientWithBundle(ctx context.Context, bundle, imageRepository string) (client.Client, error) {
+	if bundle == "" {
+		if imageRepository != "" {
+			return nil, errors.New("the --image-repository flag can only be used in combination with --bundle")
+		}
+		return client.New(ctx, cfgFile)
+	}
+
+	configClient, err := config.New(ctx, cfgFile, config.InjectBundle(bundle, imageRepository))
+	if err != nil {
+		return nil, err
+	}
+	return client.New(ctx, cfgFile, client.InjectConfig(configClient))
+}
+
 // printYamlOutput prints the yaml content of a generated template to stdout or to a local file if specified.
 func printYamlOutput(printer client.YamlPrinter, outputFile string) error {
 	yaml, err := printer.Yaml()
@@ -29,6 +29,7 @@ type GenericMachineTemplate struct {
 // GenericControlPlaneSpec contains a generic control plane spec.
 type GenericControlPlaneSpec struct {
 	MachineTemplate GenericMachineTemplate `json:"machineTemplate"`
+	Version         string                 `json:"version,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -81,6 +81,9 @@ type MachineReconciler struct {
 
 	AdditionalSyncMachineLabels      []*regexp.Regexp
 	AdditionalSyncMachineAnnotations []*regexp.Regexp
+
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
 }
 
 func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -92,6 +95,7 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		RemoteConditionsGracePeriod:      r.RemoteConditionsGracePeriod,
 		AdditionalSyncMachineLabels:      r.AdditionalSyncMachineLabels,
 		AdditionalSyncMachineAnnotations: r.AdditionalSyncMachineAnnotations,
+		RuntimeClient:                    r.RuntimeClient,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -105,6 +109,9 @@ type MachineSetReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
 }
 
 func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -114,6 +121,7 @@ func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		ClusterCache:     r.ClusterCache,
 		PreflightChecks:  r.PreflightChecks,
 		WatchFilterValue: r.WatchFilterValue,
+		RuntimeClient:    r.RuntimeClient,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -124,6 +132,9 @@ type MachineDeploymentReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
 }
 
 func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -131,6 +142,7 @@ func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		APIReader:        r.APIReader,
 		WatchFilterValue: r.WatchFilterValue,
+		RuntimeClient:    r.RuntimeClient,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -23,9 +23,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	kubeadmcontrolplanecontrollers "sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/controllers"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 )
 
 // KubeadmControlPlaneReconciler reconciles a KubeadmControlPlane object.
@@ -34,6 +36,9 @@ type KubeadmControlPlaneReconciler struct {
 	SecretCachingClient client.Client
 	ClusterCache        clustercache.ClusterCache
 
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
+
 	EtcdDialTimeout time.Duration
 	EtcdCallTimeout time.Duration
 
@@ -41,6 +46,16 @@ type KubeadmControlPlaneReconciler struct {
 	WatchFilterValue string
 
 	RemoteConditionsGracePeriod time.Duration
+
+	// EtcdSnapshotServer, if set, serves the etcd snapshots stored by KCP to the Machines restoring etcd.
+	EtcdSnapshotServer webhook.Server
+
+	// EtcdSnapshotServerURL is the URL the EtcdSnapshotServer is reachable at from the Machines of workload clusters.
+	EtcdSnapshotServerURL string
+
+	// EtcdSnapshotServerCAFile is the path of the PEM encoded CA bundle used by Machines to verify the EtcdSnapshotServer;
+	// if empty, the CAs trusted by the Machines are used.
+	EtcdSnapshotServerCAFile string
 }
 
 // SetupWithManager sets up the reconciler with the Manager.
@@ -49,9 +64,13 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 		Client:                      r.Client,
 		SecretCachingClient:         r.SecretCachingClient,
 		ClusterCache:                r.ClusterCache,
+		RuntimeClient:               r.RuntimeClient,
 		EtcdDialTimeout:             r.EtcdDialTimeout,
 		EtcdCallTimeout:             r.EtcdCallTimeout,
 		WatchFilterValue:            r.WatchFilterValue,
 		RemoteConditionsGracePeriod: r.RemoteConditionsGracePeriod,
+		EtcdSnapshotServer:          r.EtcdSnapshotServer,
+		EtcdSnapshotServerURL:       r.EtcdSnapshotServerURL,
+		EtcdSnapshotServerCAFile:    r.EtcdSnapshotServerCAFile,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -18,9 +18,11 @@ package internal
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -29,8 +31,11 @@ import (
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/util/cache"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/secret"
 )
@@ -41,7 +46,7 @@ type ManagementCluster interface {
 
 	GetMachinesForCluster(ctx context.Context, cluster *clusterv1.Cluster, filters ...collections.Func) (collections.Machines, error)
 	GetMachinePoolsForCluster(ctx context.Context, cluster *clusterv1.Cluster) (*clusterv1.MachinePoolList, error)
-	GetWorkloadCluster(ctx context.Context, clusterKey client.ObjectKey) (WorkloadCluster, error)
+	GetWorkloadCluster(ctx context.Context, clusterKey client.ObjectKey, keyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (WorkloadCluster, error)
 }
 
 // Management holds operations on the management cluster.
@@ -51,6 +56,21 @@ type Management struct {
 	ClusterCache        clustercache.ClusterCache
 	EtcdDialTimeout     time.Duration
 	EtcdCallTimeout     time.Duration
+
+	etcdClientKeysOnce sync.Once
+	etcdClientKeys     cache.Cache[etcdClientCertificatePrivateKeyEntry]
+}
+
+// etcdClientCertificatePrivateKeyEntry is a private key used for the etcd client certificates of a cluster.
+type etcdClientCertificatePrivateKeyEntry struct {
+	clusterKey             client.ObjectKey
+	keyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+	privateKey             crypto.Signer
+}
+
+// Key returns the cache key of an etcdClientCertificatePrivateKeyEntry.
+func (e etcdClientCertificatePrivateKeyEntry) Key() string {
+	return fmt.Sprintf("%s/%s", e.clusterKey, e.keyEncryptionAlgorithm)
 }
 
 // RemoteClusterConnectionError represents a failure to connect to a remote cluster.
@@ -96,7 +116,7 @@ func (m *Management) GetMachinePoolsForCluster(ctx context.Context, cluster *clu
 
 // GetWorkloadCluster builds a cluster object.
 // The cluster comes with an etcd client generator to connect to any etcd pod living on a managed machine.
-func (m *Management) GetWorkloadCluster(ctx context.Context, clusterKey client.ObjectKey) (WorkloadCluster, error) {
+func (m *Management) GetWorkloadCluster(ctx context.Context, clusterKey client.ObjectKey, keyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (WorkloadCluster, error) {
 	// TODO(chuckha): Inject this dependency.
 	// TODO(chuckha): memoize this function. The workload client only exists as long as a reconciliation loop.
 	restConfig, err := m.ClusterCache.GetRESTConfig(ctx, clusterKey)
@@ -124,7 +144,7 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, clusterKey client.O
 	// TODO: consider if we can detect if we are using external etcd in a more explicit way (e.g. looking at the config instead of deriving from the existing certificates)
 	var clientCert tls.Certificate
 	if keyData != nil {
-		clientKey, err := m.ClusterCache.GetClientCertificatePrivateKey(ctx, clusterKey)
+		clientKey, err := m.getEtcdClientCertificatePrivateKey(ctx, clusterKey, keyEncryptionAlgorithm)
 		if err != nil {
 			return nil, err
 		}
@@ -156,6 +176,36 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, clusterKey client.O
 	}, nil
 }
 
+// getEtcdClientCertificatePrivateKey returns the private key to be used for the etcd client certificate.
+// For RSA-2048, which is the default, the private key cached by the ClusterCache is re-used, thus avoiding to
+// generate a new RSA key at every reconcile; for all the other encryption algorithms the private key is generated
+// once per cluster and encryption algorithm, and then cached for cache.DefaultTTL.
+func (m *Management) getEtcdClientCertificatePrivateKey(ctx context.Context, clusterKey client.ObjectKey, keyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (crypto.Signer, error) {
+	if keyEncryptionAlgorithm == "" || keyEncryptionAlgorithm == bootstrapv1.EncryptionAlgorithmRSA2048 {
+		clientKey, err := m.ClusterCache.GetClientCertificatePrivateKey(ctx, clusterKey)
+		if err != nil {
+			return nil, err
+		}
+		return clientKey, nil
+	}
+
+	m.etcdClientKeysOnce.Do(func() {
+		m.etcdClientKeys = cache.New[etcdClientCertificatePrivateKeyEntry](cache.DefaultTTL)
+	})
+	entry := etcdClientCertificatePrivateKeyEntry{clusterKey: clusterKey, keyEncryptionAlgorithm: keyEncryptionAlgorithm}
+	if cached, ok := m.etcdClientKeys.Has(entry.Key()); ok {
+		return cached.privateKey, nil
+	}
+
+	clientKey, err := certs.NewSigner(keyEncryptionAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	entry.privateKey = clientKey
+	m.etcdClientKeys.Add(entry)
+	return clientKey, nil
+}
+
 func (m *Management) getEtcdCAKeyPair(ctx context.Context, clusterKey client.ObjectKey) ([]byte, []byte, error) {
 	etcdCASecret := &corev1.Secret{}
 	etcdCAObjectKey := client.ObjectKey{
@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/remote"
@@ -157,10 +158,11 @@ func TestGetWorkloadCluster(t *testing.T) {
 	}
 
 	tests := []struct {
-		name       string
-		clusterKey client.ObjectKey
-		objs       []client.Object
-		expectErr  bool
+		name                   string
+		clusterKey             client.ObjectKey
+		keyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+		objs                   []client.Object
+		expectErr              bool
 	}{
 		{
 			name:       "returns a workload cluster",
@@ -168,6 +170,13 @@ func TestGetWorkloadCluster(t *testing.T) {
 			objs:       []client.Object{etcdSecret.DeepCopy(), kubeconfigSecret.DeepCopy()},
 			expectErr:  false,
 		},
+		{
+			name:                   "returns a workload cluster using an ECDSA key for the etcd client certificate",
+			clusterKey:             clusterKey,
+			keyEncryptionAlgorithm: bootstrapv1.EncryptionAlgorithmECDSAP256,
+			objs:                   []client.Object{etcdSecret.DeepCopy(), kubeconfigSecret.DeepCopy()},
+			expectErr:              false,
+		},
 		{
 			name:       "returns error if cannot get rest.Config from kubeconfigSecret",
 			clusterKey: clusterKey,
@@ -239,7 +248,7 @@ func TestGetWorkloadCluster(t *testing.T) {
 			})
 			g.Expect(err).ToNot(HaveOccurred())
 
-			workloadCluster, err := m.GetWorkloadCluster(ctx, tt.clusterKey)
+			workloadCluster, err := m.GetWorkloadCluster(ctx, tt.clusterKey, tt.keyEncryptionAlgorithm)
 			if tt.expectErr {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(workloadCluster).To(BeNil())
@@ -251,6 +260,31 @@ func TestGetWorkloadCluster(t *testing.T) {
 	}
 }
 
+func TestGetEtcdClientCertificatePrivateKey(t *testing.T) {
+	g := NewWithT(t)
+
+	m := &Management{}
+	cluster1 := client.ObjectKey{Namespace: "default", Name: "cluster1"}
+	cluster2 := client.ObjectKey{Namespace: "default", Name: "cluster2"}
+
+	key, err := m.getEtcdClientCertificatePrivateKey(ctx, cluster1, bootstrapv1.EncryptionAlgorithmECDSAP256)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The private key is re-used for the same cluster and encryption algorithm.
+	sameKey, err := m.getEtcdClientCertificatePrivateKey(ctx, cluster1, bootstrapv1.EncryptionAlgorithmECDSAP256)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sameKey).To(BeIdenticalTo(key))
+
+	// A different private key is used for other clusters or encryption algorithms.
+	otherClusterKey, err := m.getEtcdClientCertificatePrivateKey(ctx, cluster2, bootstrapv1.EncryptionAlgorithmECDSAP256)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(otherClusterKey).ToNot(BeIdenticalTo(key))
+
+	otherAlgorithmKey, err := m.getEtcdClientCertificatePrivateKey(ctx, cluster1, bootstrapv1.EncryptionAlgorithmECDSAP384)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(otherAlgorithmKey).ToNot(BeIdenticalTo(key))
+}
+
 func getTestCACert(key *rsa.PrivateKey) (*x509.Certificate, error) {
 	cfg := certs.Config{
 		CommonName: "kubernetes",
@@ -74,6 +74,9 @@ type ControlPlane struct {
 	EtcdMembers                       []*etcd.Member
 	EtcdMembersAndMachinesAreMatching bool
 
+	// EtcdAlarms is the list of alarms read while computing reconcileControlPlaneConditions.
+	EtcdAlarms []etcd.MemberAlarm
+
 	managementCluster ManagementCluster
 	workloadCluster   WorkloadCluster
 
@@ -259,6 +262,18 @@ func (c *ControlPlane) MachinesNeedingRollout() (collections.Machines, map[strin
 	return c.machinesNotUptoDate.Filter(collections.Not(collections.HasDeletionTimestamp)), c.machinesNotUptoDateLogMessages
 }
 
+// MachinesEligibleForInPlaceUpdate returns the machines needing rollout which could be updated in-place, i.e.
+// machines that are not scheduled for replacement because of rolloutBefore, rolloutAfter or because of
+// a rotation of the certificate authorities of the cluster.
+func (c *ControlPlane) MachinesEligibleForInPlaceUpdate() collections.Machines {
+	machines, _ := c.MachinesNeedingRollout()
+	return machines.Filter(
+		collections.Not(collections.ShouldRolloutBefore(&c.reconciliationTime, c.KCP.Spec.RolloutBefore)),
+		collections.Not(collections.ShouldRolloutAfter(&c.reconciliationTime, c.KCP.Spec.RolloutAfter)),
+		collections.Not(collections.ShouldRolloutAfter(&c.reconciliationTime, certificateAuthorityRotationPhaseStartTime(c.KCP))),
+	)
+}
+
 // NotUpToDateMachines return a list of machines that are not up to date with the control
 // plane's configuration.
 func (c *ControlPlane) NotUpToDateMachines() (collections.Machines, map[string][]string) {
@@ -312,6 +327,14 @@ func (c *ControlPlane) IsEtcdManaged() bool {
 	return c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration == nil || c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External == nil
 }
 
+// GetKeyEncryptionAlgorithm returns the encryption algorithm to be used when generating keys and certificates.
+func (c *ControlPlane) GetKeyEncryptionAlgorithm() bootstrapv1.EncryptionAlgorithmType {
+	if c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration == nil {
+		return ""
+	}
+	return c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.EncryptionAlgorithm
+}
+
 // UnhealthyMachinesWithUnhealthyControlPlaneComponents returns all unhealthy control plane machines that
 // have unhealthy control plane components.
 // It differs from UnhealthyMachinesByHealthCheck which checks `MachineHealthCheck` conditions.
@@ -386,7 +409,7 @@ func (c *ControlPlane) GetWorkloadCluster(ctx context.Context) (WorkloadCluster,
 		return c.workloadCluster, nil
 	}
 
-	workloadCluster, err := c.managementCluster.GetWorkloadCluster(ctx, client.ObjectKeyFromObject(c.Cluster))
+	workloadCluster, err := c.managementCluster.GetWorkloadCluster(ctx, client.ObjectKeyFromObject(c.Cluster), c.GetKeyEncryptionAlgorithm())
 	if err != nil {
 		return nil, err
 	}
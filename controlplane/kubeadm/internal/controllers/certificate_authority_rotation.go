@@ -0,0 +1,613 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	clog "sigs.k8s.io/cluster-api/util/log"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// certificateAuthorityRotationSecretSuffix is the suffix of the Secrets storing the previous and the new
+	// key pair of a certificate authority while it is rotated.
+	certificateAuthorityRotationSecretSuffix = "-rotation"
+
+	// previousCrtDataName is the key used to store the previous certificate of a certificate authority
+	// in a rotation Secret.
+	previousCrtDataName = "previous.crt"
+
+	// previousKeyDataName is the key used to store the previous private key of a certificate authority
+	// in a rotation Secret.
+	previousKeyDataName = "previous.key"
+)
+
+// rotatableCertificateAuthorities are the certificate authorities that can be rotated by KCP.
+// NOTE: The service account key pair is rotated like a certificate authority, with the public key in place of the
+// certificate; kube-apiserver trusts all the public keys in sa.pub, while it signs tokens with sa.key.
+var rotatableCertificateAuthorities = []secret.Purpose{secret.ClusterCA, secret.FrontProxyCA, secret.EtcdCA, secret.ServiceAccount}
+
+// reconcileCertificateAuthorityRotation drives the rotation of the certificate authorities of the cluster.
+// Each phase of the rotation changes the content of the certificate authority Secrets, and then waits for all the
+// Machines of the cluster to be rolled out, so they pick up the new content; control plane Machines are rolled out by
+// KCP itself (see UpToDate), while Machines of MachineDeployments are rolled out by setting MachineDeployment's
+// spec.rolloutAfter.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	rotation := kcp.Status.CertificateAuthorityRotation
+	if rotation == nil || rotation.Phase == controlplanev1.CertificateAuthorityRotationCompletedPhase {
+		if !shouldStartCertificateAuthorityRotation(kcp, time.Now()) {
+			setCertificateAuthorityNotRotatingCondition(kcp, "")
+			return nil
+		}
+
+		if kcp.Status.Initialization == nil || !kcp.Status.Initialization.ControlPlaneInitialized {
+			setCertificateAuthorityNotRotatingCondition(kcp, "Waiting for the control plane to be initialized")
+			return nil
+		}
+
+		unsupportedReason, err := r.certificateAuthorityRotationUnsupportedReason(ctx, controlPlane)
+		if err != nil {
+			setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+			return err
+		}
+		if unsupportedReason != "" {
+			setCertificateAuthorityNotRotatingCondition(kcp, unsupportedReason)
+			return nil
+		}
+
+		purposes, err := r.createCertificateAuthorityRotationSecrets(ctx, controlPlane)
+		if err != nil {
+			setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+			return err
+		}
+		if len(purposes) == 0 {
+			setCertificateAuthorityNotRotatingCondition(kcp, "There are no certificate authorities managed by KubeadmControlPlane to be rotated")
+			return nil
+		}
+
+		log.Info(fmt.Sprintf("Starting rotation of certificate authorities %v", purposes))
+		if err := r.startCertificateAuthorityRotationPhase(ctx, controlPlane, controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase); err != nil {
+			setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+			return err
+		}
+	} else {
+		pendingMachines, err := r.machinesPendingCertificateAuthorityRotation(ctx, controlPlane)
+		if err != nil {
+			setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+			return err
+		}
+
+		// Move to the next phase when all the Machines have been rolled out.
+		if len(pendingMachines) == 0 {
+			nextPhase, err := r.nextCertificateAuthorityRotationPhase(ctx, controlPlane)
+			if err != nil {
+				setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+				return err
+			}
+			if nextPhase == controlplanev1.CertificateAuthorityRotationCompletedPhase {
+				if err := r.completeCertificateAuthorityRotation(ctx, controlPlane); err != nil {
+					setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+					return err
+				}
+				log.Info("Rotation of certificate authorities completed")
+				setCertificateAuthorityNotRotatingCondition(kcp, "")
+				return nil
+			}
+			if err := r.startCertificateAuthorityRotationPhase(ctx, controlPlane, nextPhase); err != nil {
+				setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+				return err
+			}
+		}
+	}
+
+	// Ensure the certificate authority Secrets, the kubeconfig, the cluster-info ConfigMap and the MachineDeployments
+	// are consistent with the current phase of the rotation.
+	// NOTE: This is repeated at every reconcile, so the rotation recovers from failures.
+	if err := r.reconcileCertificateAuthorityRotationPhase(ctx, controlPlane); err != nil {
+		setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+		return err
+	}
+
+	pendingMachines, err := r.machinesPendingCertificateAuthorityRotation(ctx, controlPlane)
+	if err != nil {
+		setCertificateAuthorityRotatingInternalErrorCondition(kcp)
+		return err
+	}
+
+	message := fmt.Sprintf("Phase %s in progress", kcp.Status.CertificateAuthorityRotation.Phase)
+	if len(pendingMachines) > 0 {
+		message += fmt.Sprintf(", waiting for Machines to be rolled out: %s", clog.StringListToString(pendingMachines.Names()))
+	}
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+		Message: message,
+	})
+	return nil
+}
+
+// shouldStartCertificateAuthorityRotation returns true if spec.certificateAuthorityRotation.rotateAfter expired
+// and no rotation started after it.
+func shouldStartCertificateAuthorityRotation(kcp *controlplanev1.KubeadmControlPlane, now time.Time) bool {
+	if kcp.Spec.CertificateAuthorityRotation == nil {
+		return false
+	}
+	rotateAfter := kcp.Spec.CertificateAuthorityRotation.RotateAfter
+	if !rotateAfter.Time.Before(now) {
+		return false
+	}
+	rotation := kcp.Status.CertificateAuthorityRotation
+	return rotation == nil || rotation.StartTime.Before(&rotateAfter)
+}
+
+// nextCertificateAuthorityRotationPhase returns the phase following the current phase of the rotation.
+// NOTE: The old service account public key is removed in a separate phase after RemovingOldCA, so service account tokens
+// signed with the old key remain valid while the Machines are rolled out to remove the old certificate authorities.
+func (r *KubeadmControlPlaneReconciler) nextCertificateAuthorityRotationPhase(ctx context.Context, controlPlane *internal.ControlPlane) (controlplanev1.CertificateAuthorityRotationPhase, error) {
+	switch controlPlane.KCP.Status.CertificateAuthorityRotation.Phase {
+	case controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase:
+		return controlplanev1.CertificateAuthorityRotationSwitchingSigningCAPhase, nil
+	case controlplanev1.CertificateAuthorityRotationSwitchingSigningCAPhase:
+		return controlplanev1.CertificateAuthorityRotationRemovingOldCAPhase, nil
+	case controlplanev1.CertificateAuthorityRotationRemovingOldCAPhase:
+		rotationSecret := &corev1.Secret{}
+		rotationSecretKey := client.ObjectKey{Namespace: controlPlane.Cluster.Namespace, Name: certificateAuthorityRotationSecretName(controlPlane.Cluster.Name, secret.ServiceAccount)}
+		if err := r.Client.Get(ctx, rotationSecretKey, rotationSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The service account key is not being rotated.
+				return controlplanev1.CertificateAuthorityRotationCompletedPhase, nil
+			}
+			return "", errors.Wrapf(err, "failed to get %s Secret", rotationSecretKey.Name)
+		}
+		return controlplanev1.CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase, nil
+	default:
+		return controlplanev1.CertificateAuthorityRotationCompletedPhase, nil
+	}
+}
+
+// createCertificateAuthorityRotationSecrets creates a rotation Secret, storing the current and a newly generated key pair,
+// for each certificate authority to be rotated, and returns the list of certificate authorities to be rotated.
+// NOTE: Only the certificate authorities generated by KCP are rotated; user provided certificate authorities are not.
+func (r *KubeadmControlPlaneReconciler) createCertificateAuthorityRotationSecrets(ctx context.Context, controlPlane *internal.ControlPlane) ([]secret.Purpose, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	clusterName := util.ObjectKey(controlPlane.Cluster)
+	purposes := []secret.Purpose{}
+	for _, purpose := range rotatableCertificateAuthorities {
+		if purpose == secret.EtcdCA && !controlPlane.IsEtcdManaged() {
+			continue
+		}
+
+		caSecret, err := secret.GetFromNamespacedName(ctx, r.Client, clusterName, purpose)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %s Secret", purpose)
+		}
+		if !util.IsControlledBy(caSecret, controlPlane.KCP) {
+			continue
+		}
+		purposes = append(purposes, purpose)
+
+		// If the rotation Secret already exists, e.g. because a previous attempt to start the rotation failed, re-use it.
+		rotationSecret := &corev1.Secret{}
+		rotationSecretKey := client.ObjectKey{Namespace: clusterName.Namespace, Name: certificateAuthorityRotationSecretName(clusterName.Name, purpose)}
+		if err := r.Client.Get(ctx, rotationSecretKey, rotationSecret); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get %s Secret", rotationSecretKey.Name)
+		}
+
+		newCertificate := &secret.Certificate{Purpose: purpose, EncryptionAlgorithm: controlPlane.GetKeyEncryptionAlgorithm()}
+		if err := newCertificate.Generate(); err != nil {
+			return nil, errors.Wrapf(err, "failed to generate new %s certificate authority", purpose)
+		}
+
+		rotationSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rotationSecretKey.Namespace,
+				Name:      rotationSecretKey.Name,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: clusterName.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(controlPlane.KCP, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind)),
+				},
+			},
+			Data: map[string][]byte{
+				secret.TLSCrtDataName: newCertificate.KeyPair.Cert,
+				secret.TLSKeyDataName: newCertificate.KeyPair.Key,
+				previousCrtDataName:   caSecret.Data[secret.TLSCrtDataName],
+				previousKeyDataName:   caSecret.Data[secret.TLSKeyDataName],
+			},
+			Type: clusterv1.ClusterSecretType,
+		}
+		if err := r.Client.Create(ctx, rotationSecret); err != nil {
+			return nil, errors.Wrapf(err, "failed to create %s Secret", rotationSecretKey.Name)
+		}
+		log.Info(fmt.Sprintf("Generated new %s certificate authority", purpose), "Secret", klog.KObj(rotationSecret))
+	}
+	return purposes, nil
+}
+
+// startCertificateAuthorityRotationPhase updates the certificate authority Secrets and the cluster-info ConfigMap
+// for the given phase, and then records the phase in the KCP status.
+func (r *KubeadmControlPlaneReconciler) startCertificateAuthorityRotationPhase(ctx context.Context, controlPlane *internal.ControlPlane, phase controlplanev1.CertificateAuthorityRotationPhase) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Secrets and cluster-info must be updated before recording the new phase, so all the Machines created after
+	// phaseStartTime are guaranteed to pick up the certificate authorities for the new phase.
+	caData, err := r.reconcileCertificateAuthoritySecrets(ctx, controlPlane, phase)
+	if err != nil {
+		return err
+	}
+
+	if clusterCAData, ok := caData[secret.ClusterCA]; ok {
+		workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to create client to workload cluster")
+		}
+		if err := workloadCluster.UpdateClusterInfoCertificateAuthority(ctx, clusterCAData); err != nil {
+			return err
+		}
+	}
+
+	// Note: phaseStartTime is rounded up to the next second, because Machine's creationTimestamp has second precision.
+	now := time.Now()
+	phaseStartTime := metav1.NewTime(now.Truncate(time.Second).Add(time.Second))
+
+	kcp := controlPlane.KCP
+	if kcp.Status.CertificateAuthorityRotation == nil || kcp.Status.CertificateAuthorityRotation.Phase == controlplanev1.CertificateAuthorityRotationCompletedPhase {
+		kcp.Status.CertificateAuthorityRotation = &controlplanev1.CertificateAuthorityRotationStatus{
+			StartTime: metav1.NewTime(now),
+		}
+	}
+	kcp.Status.CertificateAuthorityRotation.Phase = phase
+	kcp.Status.CertificateAuthorityRotation.PhaseStartTime = phaseStartTime
+	kcp.Status.CertificateAuthorityRotation.CompletionTime = nil
+
+	log.Info(fmt.Sprintf("Rotation of certificate authorities moved to phase %s", phase))
+	return nil
+}
+
+// completeCertificateAuthorityRotation deletes the rotation Secrets, so the previous certificate authorities
+// are not stored anymore, and records the completion of the rotation in the KCP status.
+func (r *KubeadmControlPlaneReconciler) completeCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	clusterName := util.ObjectKey(controlPlane.Cluster)
+	for _, purpose := range rotatableCertificateAuthorities {
+		rotationSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: clusterName.Namespace,
+				Name:      certificateAuthorityRotationSecretName(clusterName.Name, purpose),
+			},
+		}
+		if err := r.Client.Delete(ctx, rotationSecret); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete %s Secret", rotationSecret.Name)
+		}
+	}
+
+	now := metav1.Now()
+	controlPlane.KCP.Status.CertificateAuthorityRotation.Phase = controlplanev1.CertificateAuthorityRotationCompletedPhase
+	controlPlane.KCP.Status.CertificateAuthorityRotation.CompletionTime = &now
+	return nil
+}
+
+// reconcileCertificateAuthorityRotationPhase ensures that the certificate authority Secrets, the kubeconfig Secret
+// and the MachineDeployments are consistent with the current phase of the rotation.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthorityRotationPhase(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	rotation := controlPlane.KCP.Status.CertificateAuthorityRotation
+
+	caData, err := r.reconcileCertificateAuthoritySecrets(ctx, controlPlane, rotation.Phase)
+	if err != nil {
+		return err
+	}
+
+	if clusterCAData, ok := caData[secret.ClusterCA]; ok {
+		if err := r.reconcileKubeconfigCertificateAuthority(ctx, controlPlane, clusterCAData); err != nil {
+			return err
+		}
+	}
+
+	// Only kube-apiserver uses the service account public keys, so worker Machines are not rolled out
+	// when removing the old service account public key.
+	if rotation.Phase == controlplanev1.CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase {
+		return nil
+	}
+	return r.rolloutMachineDeploymentsForCertificateAuthorityRotation(ctx, controlPlane, rotation.PhaseStartTime)
+}
+
+// reconcileCertificateAuthoritySecrets ensures the certificate authority Secrets have the content expected for the given phase,
+// and returns the expected certificate data for each certificate authority being rotated.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthoritySecrets(ctx context.Context, controlPlane *internal.ControlPlane, phase controlplanev1.CertificateAuthorityRotationPhase) (map[secret.Purpose][]byte, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	caData := map[secret.Purpose][]byte{}
+	clusterName := util.ObjectKey(controlPlane.Cluster)
+	for _, purpose := range rotatableCertificateAuthorities {
+		rotationSecret := &corev1.Secret{}
+		rotationSecretKey := client.ObjectKey{Namespace: clusterName.Namespace, Name: certificateAuthorityRotationSecretName(clusterName.Name, purpose)}
+		if err := r.Client.Get(ctx, rotationSecretKey, rotationSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The certificate authority is not being rotated.
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get %s Secret", rotationSecretKey.Name)
+		}
+
+		caSecret, err := secret.GetFromNamespacedName(ctx, r.Client, clusterName, purpose)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %s Secret", purpose)
+		}
+
+		crt, key := certificateAuthorityDataForPhase(rotationSecret, purpose, phase)
+		caData[purpose] = crt
+		if bytes.Equal(caSecret.Data[secret.TLSCrtDataName], crt) && bytes.Equal(caSecret.Data[secret.TLSKeyDataName], key) {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(caSecret, r.Client)
+		if err != nil {
+			return nil, err
+		}
+		caSecret.Data[secret.TLSCrtDataName] = crt
+		caSecret.Data[secret.TLSKeyDataName] = key
+		if err := patchHelper.Patch(ctx, caSecret); err != nil {
+			return nil, errors.Wrapf(err, "failed to update %s Secret", purpose)
+		}
+		log.Info(fmt.Sprintf("Updated %s certificate authority for phase %s", purpose, phase), "Secret", klog.KObj(caSecret))
+	}
+	return caData, nil
+}
+
+// certificateAuthorityDataForPhase returns the certificate and the key a certificate authority Secret must have in a phase of the rotation.
+// NOTE: The first certificate in the bundle must always be the certificate matching the key, because it is the one used for signing.
+// NOTE: The old service account public key is trusted until the RemovingOldServiceAccountKey phase.
+func certificateAuthorityDataForPhase(rotationSecret *corev1.Secret, purpose secret.Purpose, phase controlplanev1.CertificateAuthorityRotationPhase) ([]byte, []byte) {
+	newCrt, newKey := rotationSecret.Data[secret.TLSCrtDataName], rotationSecret.Data[secret.TLSKeyDataName]
+	previousCrt, previousKey := rotationSecret.Data[previousCrtDataName], rotationSecret.Data[previousKeyDataName]
+
+	switch phase {
+	case controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase:
+		return concatPEM(previousCrt, newCrt), previousKey
+	case controlplanev1.CertificateAuthorityRotationSwitchingSigningCAPhase:
+		return concatPEM(newCrt, previousCrt), newKey
+	case controlplanev1.CertificateAuthorityRotationRemovingOldCAPhase:
+		if purpose == secret.ServiceAccount {
+			return concatPEM(newCrt, previousCrt), newKey
+		}
+		return newCrt, newKey
+	default:
+		return newCrt, newKey
+	}
+}
+
+// concatPEM concatenates PEM encoded data, ensuring each block ends with a newline.
+func concatPEM(data ...[]byte) []byte {
+	out := []byte{}
+	for _, d := range data {
+		out = append(out, bytes.TrimSpace(d)...)
+		out = append(out, '\n')
+	}
+	return out
+}
+
+// reconcileKubeconfigCertificateAuthority regenerates the kubeconfig Secret if it doesn't trust the given certificate authority data.
+// NOTE: Regenerating the kubeconfig also ensures the client certificate is signed by the certificate authority currently used for signing.
+func (r *KubeadmControlPlaneReconciler) reconcileKubeconfigCertificateAuthority(ctx context.Context, controlPlane *internal.ControlPlane, caData []byte) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	configSecret, err := secret.GetFromNamespacedName(ctx, r.SecretCachingClient, util.ObjectKey(controlPlane.Cluster), secret.Kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve kubeconfig Secret")
+	}
+
+	// Only regenerate owned Secrets; user provided kubeconfig must be updated by users.
+	if !util.IsControlledBy(configSecret, controlPlane.KCP) {
+		return nil
+	}
+
+	config, err := clientcmd.Load(configSecret.Data[secret.KubeconfigDataName])
+	if err != nil {
+		return errors.Wrap(err, "failed to parse kubeconfig Secret")
+	}
+	upToDate := len(config.Clusters) > 0
+	for _, cluster := range config.Clusters {
+		if !bytes.Equal(cluster.CertificateAuthorityData, caData) {
+			upToDate = false
+		}
+	}
+	if upToDate {
+		return nil
+	}
+
+	log.Info("Regenerating kubeconfig Secret for certificate authorities rotation")
+	if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret, kubeconfig.WithEncryptionAlgorithm(controlPlane.GetKeyEncryptionAlgorithm())); err != nil {
+		return errors.Wrap(err, "failed to regenerate kubeconfig")
+	}
+	return nil
+}
+
+// rolloutMachineDeploymentsForCertificateAuthorityRotation sets spec.rolloutAfter on the MachineDeployments of the cluster,
+// so all the Machines created before the current phase of the rotation started are rolled out.
+func (r *KubeadmControlPlaneReconciler) rolloutMachineDeploymentsForCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane, phaseStartTime metav1.Time) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, machineDeployments,
+		client.InNamespace(controlPlane.Cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: controlPlane.Cluster.Name},
+	); err != nil {
+		return errors.Wrap(err, "failed to list MachineDeployments")
+	}
+
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if md.Spec.RolloutAfter != nil && !md.Spec.RolloutAfter.Before(&phaseStartTime) {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(md, r.Client)
+		if err != nil {
+			return err
+		}
+		md.Spec.RolloutAfter = phaseStartTime.DeepCopy()
+		if err := patchHelper.Patch(ctx, md); err != nil {
+			return errors.Wrapf(err, "failed to set spec.rolloutAfter on MachineDeployment %s", klog.KObj(md))
+		}
+		log.Info("Triggered rollout of MachineDeployment for certificate authorities rotation", "MachineDeployment", klog.KObj(md))
+	}
+	return nil
+}
+
+// machinesPendingCertificateAuthorityRotation returns the Machines of the cluster which are not yet rolled out for the current phase
+// of the rotation, i.e. Machines created before the phase started, or Machines which don't have a Node yet.
+// NOTE: Only control plane Machines are rolled out in the RemovingOldServiceAccountKey phase.
+func (r *KubeadmControlPlaneReconciler) machinesPendingCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) (collections.Machines, error) {
+	rotation := controlPlane.KCP.Status.CertificateAuthorityRotation
+	phaseStartTime := rotation.PhaseStartTime
+
+	machines, err := r.managementCluster.GetMachinesForCluster(ctx, controlPlane.Cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list Machines")
+	}
+	if rotation.Phase == controlplanev1.CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase {
+		machines = machines.Filter(collections.ControlPlaneMachines(controlPlane.Cluster.Name))
+	}
+	return machines.Filter(func(machine *clusterv1.Machine) bool {
+		return machine.CreationTimestamp.Before(&phaseStartTime) || machine.Status.NodeRef == nil
+	}), nil
+}
+
+// certificateAuthorityRotationUnsupportedReason returns the reason why a rotation of the certificate authorities cannot
+// be started, if any.
+// The rotation waits for all the Machines of the cluster to be rolled out in each phase, and KCP can only trigger the
+// rollout of control plane Machines and of Machines of MachineDeployments which are not paused; any other Machine
+// would never be rolled out, and the rotation would never complete.
+func (r *KubeadmControlPlaneReconciler) certificateAuthorityRotationUnsupportedReason(ctx context.Context, controlPlane *internal.ControlPlane) (string, error) {
+	cluster := controlPlane.Cluster
+
+	machinePools, err := r.managementCluster.GetMachinePoolsForCluster(ctx, cluster)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list MachinePools")
+	}
+	if len(machinePools.Items) > 0 {
+		return "Rotating certificate authorities is not supported for Clusters with MachinePools", nil
+	}
+
+	machines, err := r.managementCluster.GetMachinesForCluster(ctx, cluster)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list Machines")
+	}
+	standaloneMachines := machines.Filter(func(machine *clusterv1.Machine) bool {
+		if util.IsControlPlaneMachine(machine) {
+			return false
+		}
+		ref := metav1.GetControllerOf(machine)
+		return ref == nil || ref.Kind != "MachineSet"
+	})
+	if len(standaloneMachines) > 0 {
+		return fmt.Sprintf("Rotating certificate authorities is not supported for Clusters with Machines not owned by a MachineSet: %s",
+			clog.StringListToString(standaloneMachines.Names())), nil
+	}
+
+	machineSets := &clusterv1.MachineSetList{}
+	if err := r.Client.List(ctx, machineSets,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	); err != nil {
+		return "", errors.Wrap(err, "failed to list MachineSets")
+	}
+	standaloneMachineSets := []*clusterv1.MachineSet{}
+	for i := range machineSets.Items {
+		ms := &machineSets.Items[i]
+		if ref := metav1.GetControllerOf(ms); ref == nil || ref.Kind != "MachineDeployment" {
+			standaloneMachineSets = append(standaloneMachineSets, ms)
+		}
+	}
+	if len(standaloneMachineSets) > 0 {
+		return fmt.Sprintf("Rotating certificate authorities is not supported for Clusters with MachineSets not owned by a MachineDeployment: %s",
+			clog.ObjNamesString(standaloneMachineSets)), nil
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, machineDeployments,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	); err != nil {
+		return "", errors.Wrap(err, "failed to list MachineDeployments")
+	}
+	pausedMachineDeployments := []*clusterv1.MachineDeployment{}
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if md.Spec.Paused || annotations.HasPaused(md) {
+			pausedMachineDeployments = append(pausedMachineDeployments, md)
+		}
+	}
+	if len(pausedMachineDeployments) > 0 {
+		return fmt.Sprintf("Rotating certificate authorities is not supported while MachineDeployments are paused: %s",
+			clog.ObjNamesString(pausedMachineDeployments)), nil
+	}
+
+	return "", nil
+}
+
+// certificateAuthorityRotationSecretName returns the name of the rotation Secret for a certificate authority.
+func certificateAuthorityRotationSecretName(clusterName string, purpose secret.Purpose) string {
+	return secret.Name(clusterName, purpose) + certificateAuthorityRotationSecretSuffix
+}
+
+func setCertificateAuthorityNotRotatingCondition(kcp *controlplanev1.KubeadmControlPlane, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityNotRotatingReason,
+		Message: message,
+	})
+}
+
+func setCertificateAuthorityRotatingInternalErrorCondition(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
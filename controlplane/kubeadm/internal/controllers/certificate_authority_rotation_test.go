@@ -0,0 +1,539 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestShouldStartCertificateAuthorityRotation(t *testing.T) {
+	now := time.Now()
+	rotateAfter := metav1.NewTime(now.Add(-time.Hour))
+
+	tests := []struct {
+		name     string
+		spec     *controlplanev1.CertificateAuthorityRotation
+		status   *controlplanev1.CertificateAuthorityRotationStatus
+		expected bool
+	}{
+		{
+			name:     "no rotation requested",
+			expected: false,
+		},
+		{
+			name:     "rotateAfter not expired yet",
+			spec:     &controlplanev1.CertificateAuthorityRotation{RotateAfter: metav1.NewTime(now.Add(time.Hour))},
+			expected: false,
+		},
+		{
+			name:     "rotateAfter expired and no previous rotation",
+			spec:     &controlplanev1.CertificateAuthorityRotation{RotateAfter: rotateAfter},
+			expected: true,
+		},
+		{
+			name: "rotateAfter expired and previous rotation started before rotateAfter",
+			spec: &controlplanev1.CertificateAuthorityRotation{RotateAfter: rotateAfter},
+			status: &controlplanev1.CertificateAuthorityRotationStatus{
+				Phase:     controlplanev1.CertificateAuthorityRotationCompletedPhase,
+				StartTime: metav1.NewTime(now.Add(-2 * time.Hour)),
+			},
+			expected: true,
+		},
+		{
+			name: "rotateAfter expired and previous rotation started after rotateAfter",
+			spec: &controlplanev1.CertificateAuthorityRotation{RotateAfter: rotateAfter},
+			status: &controlplanev1.CertificateAuthorityRotationStatus{
+				Phase:     controlplanev1.CertificateAuthorityRotationCompletedPhase,
+				StartTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+			},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec:   controlplanev1.KubeadmControlPlaneSpec{CertificateAuthorityRotation: tt.spec},
+				Status: controlplanev1.KubeadmControlPlaneStatus{CertificateAuthorityRotation: tt.status},
+			}
+			g.Expect(shouldStartCertificateAuthorityRotation(kcp, now)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestCertificateAuthorityDataForPhase(t *testing.T) {
+	rotationSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			secret.TLSCrtDataName: []byte("new-crt\n"),
+			secret.TLSKeyDataName: []byte("new-key\n"),
+			previousCrtDataName:   []byte("previous-crt"),
+			previousKeyDataName:   []byte("previous-key\n"),
+		},
+	}
+
+	tests := []struct {
+		purpose     secret.Purpose
+		phase       controlplanev1.CertificateAuthorityRotationPhase
+		expectedCrt string
+		expectedKey string
+	}{
+		{
+			purpose:     secret.ClusterCA,
+			phase:       controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase,
+			expectedCrt: "previous-crt\nnew-crt\n",
+			expectedKey: "previous-key\n",
+		},
+		{
+			purpose:     secret.ClusterCA,
+			phase:       controlplanev1.CertificateAuthorityRotationSwitchingSigningCAPhase,
+			expectedCrt: "new-crt\nprevious-crt\n",
+			expectedKey: "new-key\n",
+		},
+		{
+			purpose:     secret.ClusterCA,
+			phase:       controlplanev1.CertificateAuthorityRotationRemovingOldCAPhase,
+			expectedCrt: "new-crt\n",
+			expectedKey: "new-key\n",
+		},
+		{
+			purpose:     secret.ClusterCA,
+			phase:       controlplanev1.CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase,
+			expectedCrt: "new-crt\n",
+			expectedKey: "new-key\n",
+		},
+		{
+			purpose:     secret.ServiceAccount,
+			phase:       controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase,
+			expectedCrt: "previous-crt\nnew-crt\n",
+			expectedKey: "previous-key\n",
+		},
+		{
+			purpose:     secret.ServiceAccount,
+			phase:       controlplanev1.CertificateAuthorityRotationSwitchingSigningCAPhase,
+			expectedCrt: "new-crt\nprevious-crt\n",
+			expectedKey: "new-key\n",
+		},
+		{
+			purpose:     secret.ServiceAccount,
+			phase:       controlplanev1.CertificateAuthorityRotationRemovingOldCAPhase,
+			expectedCrt: "new-crt\nprevious-crt\n",
+			expectedKey: "new-key\n",
+		},
+		{
+			purpose:     secret.ServiceAccount,
+			phase:       controlplanev1.CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase,
+			expectedCrt: "new-crt\n",
+			expectedKey: "new-key\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.purpose, tt.phase), func(t *testing.T) {
+			g := NewWithT(t)
+
+			crt, key := certificateAuthorityDataForPhase(rotationSecret, tt.purpose, tt.phase)
+			g.Expect(string(crt)).To(Equal(tt.expectedCrt))
+			g.Expect(string(key)).To(Equal(tt.expectedKey))
+		})
+	}
+}
+
+func TestReconcileCertificateAuthorityRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "test.local", Port: 8443},
+		},
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "kcp-uid",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.31.0",
+			CertificateAuthorityRotation: &controlplanev1.CertificateAuthorityRotation{
+				RotateAfter: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Initialization: &controlplanev1.KubeadmControlPlaneInitializationStatus{ControlPlaneInitialized: true},
+		},
+	}
+	controllerRef := *metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind))
+	clusterName := client.ObjectKeyFromObject(cluster)
+
+	objs := []client.Object{kcp.DeepCopy()}
+	certificates := secret.NewCertificatesForInitialControlPlane(nil)
+	g.Expect(certificates.Generate()).To(Succeed())
+	for _, c := range certificates {
+		objs = append(objs, c.AsSecret(clusterName, controllerRef))
+	}
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "md",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+		},
+	}
+	objs = append(objs, md)
+
+	fakeClient := newFakeClient(objs...)
+	g.Expect(kubeconfig.CreateSecretWithOwner(ctx, fakeClient, clusterName, "test.local:8443", controllerRef)).To(Succeed())
+
+	controlPlaneLabels := map[string]string{
+		clusterv1.ClusterNameLabel:         cluster.Name,
+		clusterv1.MachineControlPlaneLabel: "",
+	}
+	workloadCluster := &fakeWorkloadCluster{}
+	managementCluster := &fakeManagementCluster{
+		Machines: collections.FromMachines(&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "old",
+				Labels:            controlPlaneLabels,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Status: clusterv1.MachineStatus{NodeRef: &clusterv1.MachineNodeReference{Name: "old"}},
+		}),
+		MachinePools: &clusterv1.MachinePoolList{},
+		Workload:     workloadCluster,
+	}
+	r := &KubeadmControlPlaneReconciler{
+		Client:              fakeClient,
+		SecretCachingClient: fakeClient,
+		managementCluster:   managementCluster,
+		recorder:            record.NewFakeRecorder(32),
+	}
+	controlPlane := &internal.ControlPlane{
+		KCP:     kcp,
+		Cluster: cluster,
+	}
+	controlPlane.InjectTestManagementCluster(managementCluster)
+
+	previous := map[secret.Purpose]*corev1.Secret{}
+	for _, purpose := range []secret.Purpose{secret.ClusterCA, secret.FrontProxyCA, secret.EtcdCA, secret.ServiceAccount} {
+		s, err := secret.GetFromNamespacedName(ctx, fakeClient, clusterName, purpose)
+		g.Expect(err).ToNot(HaveOccurred())
+		previous[purpose] = s
+	}
+
+	getSecret := func(name string) *corev1.Secret {
+		s := &corev1.Secret{}
+		g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, s)).To(Succeed())
+		return s
+	}
+	rolloutMachines := func() {
+		managementCluster.Machines = collections.FromMachines(
+			&clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "new",
+					Labels:            controlPlaneLabels,
+					CreationTimestamp: kcp.Status.CertificateAuthorityRotation.PhaseStartTime,
+				},
+				Status: clusterv1.MachineStatus{NodeRef: &clusterv1.MachineNodeReference{Name: "new"}},
+			},
+			&clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "new-worker",
+					Labels:            map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+					CreationTimestamp: kcp.Status.CertificateAuthorityRotation.PhaseStartTime,
+				},
+				Status: clusterv1.MachineStatus{NodeRef: &clusterv1.MachineNodeReference{Name: "new-worker"}},
+			},
+		)
+	}
+	expectKubeconfigCAData := func(caData []byte) {
+		kubeconfigSecret := getSecret(secret.Name(cluster.Name, secret.Kubeconfig))
+		config, err := clientcmd.Load(kubeconfigSecret.Data[secret.KubeconfigDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config.Clusters[cluster.Name].CertificateAuthorityData).To(Equal(caData))
+	}
+
+	// Start the rotation.
+	g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificateAuthorityRotation).ToNot(BeNil())
+	g.Expect(kcp.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase))
+	g.Expect(conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(BeTrue())
+
+	rotationSecrets := map[secret.Purpose]*corev1.Secret{}
+	for _, purpose := range []secret.Purpose{secret.ClusterCA, secret.FrontProxyCA, secret.EtcdCA, secret.ServiceAccount} {
+		rotationSecret := getSecret(certificateAuthorityRotationSecretName(cluster.Name, purpose))
+		g.Expect(rotationSecret.Data[previousCrtDataName]).To(Equal(previous[purpose].Data[secret.TLSCrtDataName]))
+		g.Expect(rotationSecret.Data[previousKeyDataName]).To(Equal(previous[purpose].Data[secret.TLSKeyDataName]))
+		rotationSecrets[purpose] = rotationSecret
+
+		caSecret := getSecret(secret.Name(cluster.Name, purpose))
+		g.Expect(caSecret.Data[secret.TLSCrtDataName]).To(Equal(concatPEM(previous[purpose].Data[secret.TLSCrtDataName], rotationSecret.Data[secret.TLSCrtDataName])))
+		g.Expect(caSecret.Data[secret.TLSKeyDataName]).To(Equal(previous[purpose].Data[secret.TLSKeyDataName]))
+	}
+	caData := getSecret(secret.Name(cluster.Name, secret.ClusterCA)).Data[secret.TLSCrtDataName]
+	g.Expect(workloadCluster.clusterInfoCertificateAuthority).To(Equal(caData))
+	expectKubeconfigCAData(caData)
+
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(md), md)).To(Succeed())
+	g.Expect(md.Spec.RolloutAfter).ToNot(BeNil())
+	g.Expect(md.Spec.RolloutAfter.Time).To(BeTemporally("==", kcp.Status.CertificateAuthorityRotation.PhaseStartTime.Time))
+
+	// The rotation does not progress until all the Machines are rolled out.
+	g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase))
+	g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(ContainSubstring("old"))
+
+	// Switch the signing CA.
+	rolloutMachines()
+	g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.CertificateAuthorityRotationSwitchingSigningCAPhase))
+	for purpose, rotationSecret := range rotationSecrets {
+		caSecret := getSecret(secret.Name(cluster.Name, purpose))
+		g.Expect(caSecret.Data[secret.TLSCrtDataName]).To(Equal(concatPEM(rotationSecret.Data[secret.TLSCrtDataName], previous[purpose].Data[secret.TLSCrtDataName])))
+		g.Expect(caSecret.Data[secret.TLSKeyDataName]).To(Equal(rotationSecret.Data[secret.TLSKeyDataName]))
+	}
+	caData = getSecret(secret.Name(cluster.Name, secret.ClusterCA)).Data[secret.TLSCrtDataName]
+	g.Expect(workloadCluster.clusterInfoCertificateAuthority).To(Equal(caData))
+	expectKubeconfigCAData(caData)
+
+	// Remove the old CA.
+	rolloutMachines()
+	g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.CertificateAuthorityRotationRemovingOldCAPhase))
+	for purpose, rotationSecret := range rotationSecrets {
+		caSecret := getSecret(secret.Name(cluster.Name, purpose))
+		if purpose == secret.ServiceAccount {
+			// The old service account public key is still trusted.
+			g.Expect(caSecret.Data[secret.TLSCrtDataName]).To(Equal(concatPEM(rotationSecret.Data[secret.TLSCrtDataName], previous[purpose].Data[secret.TLSCrtDataName])))
+		} else {
+			g.Expect(caSecret.Data[secret.TLSCrtDataName]).To(Equal(rotationSecret.Data[secret.TLSCrtDataName]))
+		}
+		g.Expect(caSecret.Data[secret.TLSKeyDataName]).To(Equal(rotationSecret.Data[secret.TLSKeyDataName]))
+	}
+	caData = getSecret(secret.Name(cluster.Name, secret.ClusterCA)).Data[secret.TLSCrtDataName]
+	g.Expect(workloadCluster.clusterInfoCertificateAuthority).To(Equal(caData))
+	expectKubeconfigCAData(caData)
+
+	// Remove the old service account public key.
+	removingOldCAPhaseStartTime := kcp.Status.CertificateAuthorityRotation.PhaseStartTime
+	rolloutMachines()
+	g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.CertificateAuthorityRotationRemovingOldServiceAccountKeyPhase))
+	saSecret := getSecret(secret.Name(cluster.Name, secret.ServiceAccount))
+	g.Expect(saSecret.Data[secret.TLSCrtDataName]).To(Equal(rotationSecrets[secret.ServiceAccount].Data[secret.TLSCrtDataName]))
+	g.Expect(saSecret.Data[secret.TLSKeyDataName]).To(Equal(rotationSecrets[secret.ServiceAccount].Data[secret.TLSKeyDataName]))
+
+	// Worker Machines are not rolled out when removing the old service account public key.
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(md), md)).To(Succeed())
+	g.Expect(md.Spec.RolloutAfter.Time).To(BeTemporally("==", removingOldCAPhaseStartTime.Time))
+	machines := managementCluster.Machines
+	managementCluster.Machines = collections.FromMachines(
+		&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "old",
+				Labels:            controlPlaneLabels,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Status: clusterv1.MachineStatus{NodeRef: &clusterv1.MachineNodeReference{Name: "old"}},
+		},
+		&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "old-worker",
+				Labels:            map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Status: clusterv1.MachineStatus{NodeRef: &clusterv1.MachineNodeReference{Name: "old-worker"}},
+		},
+	)
+	pendingMachines, err := r.machinesPendingCertificateAuthorityRotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pendingMachines.Names()).To(ConsistOf("old"))
+	managementCluster.Machines = machines
+
+	// Complete the rotation.
+	rolloutMachines()
+	g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.CertificateAuthorityRotationCompletedPhase))
+	g.Expect(kcp.Status.CertificateAuthorityRotation.CompletionTime).ToNot(BeNil())
+	g.Expect(conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(BeTrue())
+	for purpose := range rotationSecrets {
+		g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: certificateAuthorityRotationSecretName(cluster.Name, purpose)}, &corev1.Secret{})).ToNot(Succeed())
+	}
+
+	// A new rotation is not started until rotateAfter is changed.
+	g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.CertificateAuthorityRotationCompletedPhase))
+}
+
+func TestReconcileCertificateAuthorityRotationNotSupported(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	clusterLabels := map[string]string{clusterv1.ClusterNameLabel: cluster.Name}
+	controlPlaneMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cp",
+			Labels: map[string]string{clusterv1.ClusterNameLabel: cluster.Name, clusterv1.MachineControlPlaneLabel: ""},
+		},
+	}
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "md",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    clusterLabels,
+			UID:       "md-uid",
+		},
+	}
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "ms",
+			Namespace:       metav1.NamespaceDefault,
+			Labels:          clusterLabels,
+			UID:             "ms-uid",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(md, clusterv1.GroupVersion.WithKind("MachineDeployment"))},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		machinePools    []clusterv1.MachinePool
+		machines        []*clusterv1.Machine
+		objs            []client.Object
+		expectedMessage string
+	}{
+		{
+			name:            "Cluster with MachinePools",
+			machinePools:    []clusterv1.MachinePool{{ObjectMeta: metav1.ObjectMeta{Name: "mp"}}},
+			expectedMessage: "Rotating certificate authorities is not supported for Clusters with MachinePools",
+		},
+		{
+			name: "Cluster with standalone Machines",
+			machines: []*clusterv1.Machine{
+				controlPlaneMachine,
+				{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Labels: clusterLabels}},
+			},
+			expectedMessage: "Rotating certificate authorities is not supported for Clusters with Machines not owned by a MachineSet: standalone",
+		},
+		{
+			name: "Cluster with MachineSets not owned by a MachineDeployment",
+			objs: []client.Object{
+				md,
+				ms,
+				&clusterv1.MachineSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "standalone",
+						Namespace: metav1.NamespaceDefault,
+						Labels:    clusterLabels,
+					},
+				},
+			},
+			expectedMessage: "Rotating certificate authorities is not supported for Clusters with MachineSets not owned by a MachineDeployment: standalone",
+		},
+		{
+			name: "Cluster with paused MachineDeployments",
+			objs: []client.Object{
+				md,
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "paused",
+						Namespace: metav1.NamespaceDefault,
+						Labels:    clusterLabels,
+					},
+					Spec: clusterv1.MachineDeploymentSpec{Paused: true},
+				},
+			},
+			expectedMessage: "Rotating certificate authorities is not supported while MachineDeployments are paused: paused",
+		},
+		{
+			name: "Cluster with MachineDeployments paused by annotation",
+			objs: []client.Object{
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "paused",
+						Namespace:   metav1.NamespaceDefault,
+						Labels:      clusterLabels,
+						Annotations: map[string]string{clusterv1.PausedAnnotation: ""},
+					},
+				},
+			},
+			expectedMessage: "Rotating certificate authorities is not supported while MachineDeployments are paused: paused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					CertificateAuthorityRotation: &controlplanev1.CertificateAuthorityRotation{
+						RotateAfter: metav1.NewTime(time.Now().Add(-time.Hour)),
+					},
+				},
+				Status: controlplanev1.KubeadmControlPlaneStatus{
+					Initialization: &controlplanev1.KubeadmControlPlaneInitializationStatus{ControlPlaneInitialized: true},
+				},
+			}
+
+			r := &KubeadmControlPlaneReconciler{
+				Client: newFakeClient(tt.objs...),
+				managementCluster: &fakeManagementCluster{
+					MachinePools: &clusterv1.MachinePoolList{Items: tt.machinePools},
+					Machines:     collections.FromMachines(tt.machines...),
+				},
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP:     kcp,
+				Cluster: cluster,
+			}
+
+			g.Expect(r.reconcileCertificateAuthorityRotation(ctx, controlPlane)).To(Succeed())
+			g.Expect(kcp.Status.CertificateAuthorityRotation).To(BeNil())
+			g.Expect(conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(BeTrue())
+			g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(tt.expectedMessage))
+		})
+	}
+}
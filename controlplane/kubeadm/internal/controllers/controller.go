@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
 	"time"
@@ -39,12 +40,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
@@ -67,12 +70,15 @@ const (
 )
 
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
-// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=runtime.cluster.x-k8s.io,resources=extensionconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // KubeadmControlPlaneReconciler reconciles a KubeadmControlPlane object.
 type KubeadmControlPlaneReconciler struct {
@@ -82,6 +88,9 @@ type KubeadmControlPlaneReconciler struct {
 	recorder            record.EventRecorder
 	ClusterCache        clustercache.ClusterCache
 
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
+
 	EtcdDialTimeout time.Duration
 	EtcdCallTimeout time.Duration
 
@@ -90,9 +99,25 @@ type KubeadmControlPlaneReconciler struct {
 
 	RemoteConditionsGracePeriod time.Duration
 
+	// EtcdSnapshotServer, if set, serves the etcd snapshots stored by KCP to the Machines restoring etcd.
+	EtcdSnapshotServer webhook.Server
+
+	// EtcdSnapshotServerURL is the URL the EtcdSnapshotServer is reachable at from the Machines of workload clusters.
+	EtcdSnapshotServerURL string
+
+	// EtcdSnapshotServerCAFile is the path of the PEM encoded CA bundle used by Machines to verify the EtcdSnapshotServer;
+	// if empty, the CAs trusted by the Machines are used.
+	EtcdSnapshotServerCAFile string
+
 	managementCluster         internal.ManagementCluster
 	managementClusterUncached internal.ManagementCluster
 	ssaCache                  ssa.Cache
+
+	// etcdSnapshots tracks the etcd snapshots being taken in background.
+	etcdSnapshots etcdSnapshotTracker
+
+	// etcdDefragmentations tracks the defragmentations of etcd members running in background.
+	etcdDefragmentations etcdDefragmentationTracker
 }
 
 func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -108,6 +133,20 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 			"RemoteConditionsGracePeriod must not be < 2m")
 	}
 
+	if feature.Gates.Enabled(feature.InPlaceUpdates) && feature.Gates.Enabled(feature.RuntimeSDK) && r.RuntimeClient == nil {
+		return errors.New("RuntimeClient must not be nil when the InPlaceUpdates and RuntimeSDK feature gates are enabled")
+	}
+
+	if r.EtcdSnapshotServer != nil {
+		if !strings.HasPrefix(r.EtcdSnapshotServerURL, "https://") {
+			return errors.New("EtcdSnapshotServerURL must be an https URL when EtcdSnapshotServer is set")
+		}
+		r.EtcdSnapshotServer.Register(etcdSnapshotServerPath, http.HandlerFunc(r.serveEtcdSnapshot))
+		if err := mgr.Add(r.EtcdSnapshotServer); err != nil {
+			return errors.Wrap(err, "failed to add etcd snapshot server to the manager")
+		}
+	}
+
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "kubeadmcontrolplane")
 	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&controlplanev1.KubeadmControlPlane{}).
@@ -257,6 +296,18 @@ func (r *KubeadmControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.
 			if conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneControlPlaneComponentsHealthyCondition) {
 				res = ctrl.Result{RequeueAfter: 20 * time.Second}
 			}
+
+			// Make KCP requeue while a certificate authority rotation is in progress, so the rollout of all the Machines
+			// in the Cluster can be tracked without watching them.
+			if conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition) {
+				res = ctrl.Result{RequeueAfter: 20 * time.Second}
+			}
+
+			// Make KCP requeue while a restore of etcd is in progress, so the deletion and the provisioning of
+			// control plane Machines can be tracked also when the workload cluster is not reachable.
+			if conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition) {
+				res = ctrl.Result{RequeueAfter: 20 * time.Second}
+			}
 		}
 	}()
 
@@ -345,6 +396,9 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.KubeadmControlPlaneAvailableCondition,
 			controlplanev1.KubeadmControlPlaneInitializedCondition,
 			controlplanev1.KubeadmControlPlaneCertificatesAvailableCondition,
+			controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+			controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition,
+			controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
 			controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition,
 			controlplanev1.KubeadmControlPlaneControlPlaneComponentsHealthyCondition,
 			controlplanev1.KubeadmControlPlaneMachinesReadyCondition,
@@ -425,10 +479,22 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return result, err
 	}
 
+	// Rotate certificate authorities if requested; this must happen before computing machines needing rollout,
+	// because a rotation in progress requires control plane machines to be rolled out.
+	if err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile certificate authority rotation")
+	}
+
 	if err := r.syncMachines(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to sync Machines")
 	}
 
+	// Restore etcd from a snapshot if requested; this must happen before any operation requiring a working etcd cluster,
+	// because while restoring all the control plane machines are deleted and etcd is not available.
+	if result, err := r.reconcileEtcdRestore(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Aggregate the operational state of all the machines; while aggregating we are adding the
 	// source ref (reason@machine/name) so the problem can be easily tracked down to its source machine.
 	v1beta1conditions.SetAggregate(controlPlane.KCP, controlplanev1.MachinesReadyV1Beta1Condition, controlPlane.Machines.ConditionGetters(), v1beta1conditions.AddSourceRef())
@@ -461,6 +527,11 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	machinesNeedingRollout, machinesNeedingRolloutLogMessages := controlPlane.MachinesNeedingRollout()
 	switch {
 	case len(machinesNeedingRollout) > 0:
+		// Wait for the defragmentation of an etcd member running in background to complete, because rolling out
+		// Machines while a member does not serve requests might lead to lose etcd quorum.
+		if result := r.waitForRunningEtcdDefragmentation(ctx, controlPlane); !result.IsZero() {
+			return result, nil
+		}
 		var allMessages []string
 		for machine, messages := range machinesNeedingRolloutLogMessages {
 			allMessages = append(allMessages, fmt.Sprintf("Machine %s needs rollout: %s", machine, strings.Join(messages, ",")))
@@ -495,6 +566,11 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return r.scaleUpControlPlane(ctx, controlPlane)
 	// We are scaling down
 	case numMachines > desiredReplicas:
+		// Wait for the defragmentation of an etcd member running in background to complete, because removing
+		// a member while another member does not serve requests might lead to lose etcd quorum.
+		if result := r.waitForRunningEtcdDefragmentation(ctx, controlPlane); !result.IsZero() {
+			return result, nil
+		}
 		log.Info("Scaling down control plane", "desired", desiredReplicas, "existing", numMachines)
 		// The last parameter (i.e. machines needing to be rolled out) should always be empty here.
 		return r.scaleDownControlPlane(ctx, controlPlane, collections.Machines{})
@@ -525,7 +601,21 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	if err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+
+	// Defragment an etcd member if an etcd defragmentation policy is configured and a member has to be defragmented.
+	// Note: This is done at the end of the reconcile, so members are defragmented only when the control plane is stable.
+	defragmentationResult, defragmentationErr := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+
+	// Take a snapshot of etcd if an etcd backup policy is configured and the next snapshot is due.
+	// Note: This is done at the end of the reconcile, so snapshots are taken only when the control plane is stable,
+	// and they never delay other operations; snapshots are taken also while a member is being defragmented, otherwise
+	// every defragmentation would delay the next snapshot.
+	backupResult, backupErr := r.reconcileEtcdBackup(ctx, controlPlane)
+
+	if err := kerrors.NewAggregate([]error{defragmentationErr, backupErr}); err != nil {
+		return ctrl.Result{}, err
+	}
+	return util.LowestNonZeroResult(defragmentationResult, backupResult), nil
 }
 
 // reconcileClusterCertificates ensures that all the cluster certificates exists and
@@ -579,8 +669,21 @@ func (r *KubeadmControlPlaneReconciler) reconcileDelete(ctx context.Context, con
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Reconcile KubeadmControlPlane deletion")
 
-	// If no control plane machines remain, remove the finalizer
+	// If no control plane machines remain, delete the etcd snapshots and remove the finalizer
 	if len(controlPlane.Machines) == 0 {
+		if result, err := r.reconcileEtcdBackupDelete(ctx, controlPlane.KCP); err != nil || !result.IsZero() {
+			controlPlane.DeletingReason = controlplanev1.KubeadmControlPlaneDeletingInternalErrorReason
+			controlPlane.DeletingMessage = "Please check controller logs for errors"
+			if err == nil {
+				controlPlane.DeletingReason = controlplanev1.KubeadmControlPlaneDeletingWaitingForEtcdSnapshotReason
+				controlPlane.DeletingMessage = "Waiting for the etcd snapshot being taken to complete"
+			}
+			return result, err
+		}
+
+		// Stop tracking the etcd defragmentation running in background, if any; it completes or times out on its own.
+		r.etcdDefragmentations.Delete(controlPlane.KCP)
+
 		controlPlane.DeletingReason = controlplanev1.KubeadmControlPlaneDeletingDeletionCompletedReason
 		controlPlane.DeletingMessage = "Deletion completed"
 
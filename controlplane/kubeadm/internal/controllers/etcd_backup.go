@@ -0,0 +1,785 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+)
+
+const (
+	// etcdSnapshotTimeout is how long KCP waits at most for an etcd snapshot to be taken and stored.
+	etcdSnapshotTimeout = 30 * time.Minute
+
+	// etcdSnapshotProgressInterval is how often KCP reports the progress of an etcd snapshot being taken.
+	etcdSnapshotProgressInterval = 10 * time.Second
+
+	// etcdSnapshotTimestampFormat is the format of the timestamp used in etcd snapshot names;
+	// it ensures that sorting snapshot names alphabetically sorts them from the oldest to the newest.
+	etcdSnapshotTimestampFormat = "20060102150405"
+
+	// etcdSnapshotSecretChunkSize is the maximum size of the data stored in each etcd snapshot Secret;
+	// it leaves room for metadata within the 1MiB size limit of a Secret.
+	etcdSnapshotSecretChunkSize = 768 * 1024
+)
+
+// etcdSnapshotTracker tracks the etcd snapshots being taken in background, by KubeadmControlPlane.
+// NOTE: The zero value is ready to use.
+type etcdSnapshotTracker struct {
+	lock      sync.Mutex
+	snapshots map[types.NamespacedName]*etcdSnapshotOperation
+}
+
+// Get returns the etcd snapshot being taken for the KubeadmControlPlane, if any.
+func (t *etcdSnapshotTracker) Get(kcp *controlplanev1.KubeadmControlPlane) *etcdSnapshotOperation {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.snapshots[client.ObjectKeyFromObject(kcp)]
+}
+
+// Set tracks an etcd snapshot being taken for the KubeadmControlPlane.
+func (t *etcdSnapshotTracker) Set(kcp *controlplanev1.KubeadmControlPlane, operation *etcdSnapshotOperation) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.snapshots == nil {
+		t.snapshots = map[types.NamespacedName]*etcdSnapshotOperation{}
+	}
+	t.snapshots[client.ObjectKeyFromObject(kcp)] = operation
+}
+
+// Delete stops tracking the etcd snapshot taken for the KubeadmControlPlane.
+func (t *etcdSnapshotTracker) Delete(kcp *controlplanev1.KubeadmControlPlane) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.snapshots, client.ObjectKeyFromObject(kcp))
+}
+
+// etcdSnapshotOperation is an etcd snapshot being taken in background.
+type etcdSnapshotOperation struct {
+	name      string
+	startTime metav1.Time
+
+	// savedBytes is the number of bytes of the snapshot already read from etcd.
+	savedBytes atomic.Int64
+
+	// maxSizeBytes is the maximum size of the compressed snapshot, i.e. the space left by the retained snapshots.
+	maxSizeBytes int64
+
+	// done is closed when the snapshot completes; revision, sizeBytes and err must be read only after done is closed.
+	done     chan struct{}
+	revision int64
+	err      error
+
+	// sizeBytes is the size of the compressed snapshot stored in the sink.
+	sizeBytes int64
+
+	// reported is true once the outcome of the snapshot has been reported in the KubeadmControlPlane status.
+	reported bool
+}
+
+// Done returns true if the snapshot completed, either successfully or not.
+func (o *etcdSnapshotOperation) Done() bool {
+	select {
+	case <-o.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// reconcileEtcdBackup takes a snapshot of the etcd cluster when the next snapshot is due according to
+// the etcd backup policy, stores it in the sink and deletes the snapshots exceeding maxSnapshots; the oldest
+// snapshots are also deleted before taking a snapshot when the new snapshot would not fit into maxTotalSizeBytes.
+// Snapshots are taken in background, and their progress is reported in status.etcdBackup.snapshotInProgress;
+// the outcome of the last snapshot is reported in the EtcdBackupSucceeded condition.
+// NOTE: This func is expected to be called only when the control plane is stable, i.e. no rollout, scale up,
+// scale down or remediation in progress.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdBackup(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	policy := kcp.Spec.EtcdBackup
+	if policy == nil || !controlPlane.IsEtcdManaged() {
+		if conditions.Has(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition) {
+			conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition)
+		}
+		return ctrl.Result{}, nil
+	}
+	sink, err := r.etcdSnapshotSink(ctx, kcp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	maxSnapshots := int(ptr.Deref(policy.MaxSnapshots, controlplanev1.DefaultEtcdBackupMaxSnapshots))
+
+	if kcp.Status.EtcdBackup == nil {
+		kcp.Status.EtcdBackup = &controlplanev1.EtcdBackupStatus{}
+	}
+	status := kcp.Status.EtcdBackup
+
+	now := time.Now().UTC()
+	interval := time.Duration(policy.IntervalSeconds) * time.Second
+	var next time.Time
+	if status.LastSuccessfulSnapshot != nil {
+		next = status.LastSuccessfulSnapshot.Timestamp.Add(interval)
+	}
+
+	// NOTE: Completed snapshots are tracked until the next snapshot is started, so a failed snapshot is retried
+	// only after interval.
+	lastOperation := r.etcdSnapshots.Get(kcp)
+	if operation := lastOperation; operation != nil {
+		if !operation.Done() {
+			status.SnapshotInProgress = &controlplanev1.EtcdSnapshotProgress{
+				Name:       operation.name,
+				StartTime:  operation.startTime,
+				SavedBytes: operation.savedBytes.Load(),
+			}
+			return ctrl.Result{RequeueAfter: etcdSnapshotProgressInterval}, nil
+		}
+
+		status.SnapshotInProgress = nil
+		next = operation.startTime.Add(interval)
+		if !operation.reported {
+			if operation.err != nil {
+				log.Error(operation.err, fmt.Sprintf("Failed to save etcd snapshot %s", operation.name))
+				setEtcdBackupFailedCondition(kcp, operation.name, operation.err)
+			} else {
+				status.LastSuccessfulSnapshot = &controlplanev1.EtcdSnapshot{
+					Name:      operation.name,
+					Timestamp: operation.startTime,
+					Revision:  operation.revision,
+					SizeBytes: operation.sizeBytes,
+				}
+				log.Info(fmt.Sprintf("Saved etcd snapshot %s", operation.name), "revision", operation.revision, "sizeBytes", operation.sizeBytes)
+				conditions.Set(kcp, metav1.Condition{
+					Type:    controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition,
+					Status:  metav1.ConditionTrue,
+					Reason:  controlplanev1.KubeadmControlPlaneEtcdBackupSucceededReason,
+					Message: fmt.Sprintf("Saved etcd snapshot %s", operation.name),
+				})
+
+				if err := deleteExpiredEtcdSnapshots(ctx, sink, kcp, maxSnapshots); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			operation.reported = true
+		}
+	}
+
+	if now.Before(next) {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	// Delete the snapshots which have not been completely stored, e.g. because the controller restarted while
+	// taking them, and compute how much space is left for the new snapshot.
+	snapshots, err := sink.List(ctx, kcp)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list etcd snapshots")
+	}
+	completeSnapshots := []storedEtcdSnapshot{}
+	errs := []error{}
+	for _, snapshot := range snapshots {
+		if snapshot.complete {
+			completeSnapshots = append(completeSnapshots, snapshot)
+			continue
+		}
+		if err := sink.Delete(ctx, kcp, snapshot.name); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to delete incomplete etcd snapshot %s", snapshot.name))
+			continue
+		}
+		log.Info(fmt.Sprintf("Deleted incomplete etcd snapshot %s", snapshot.name))
+	}
+	if len(errs) > 0 {
+		return ctrl.Result{}, kerrors.NewAggregate(errs)
+	}
+	status.SnapshotInProgress = nil
+
+	// Snapshot names end with a timestamp, so sorting them alphabetically sorts them from the oldest to the newest;
+	// the newest maxSnapshots-1 snapshots are retained together with the new snapshot.
+	sort.Slice(completeSnapshots, func(i, j int) bool { return completeSnapshots[i].name < completeSnapshots[j].name })
+	retainedSnapshots := completeSnapshots[max(0, len(completeSnapshots)-(maxSnapshots-1)):]
+
+	// The new snapshot is expected to be as big as the newest snapshot, or bigger than the space which was left for
+	// the last snapshot if it did not fit into it.
+	maxTotalSizeBytes := ptr.Deref(policy.MaxTotalSizeBytes, controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)
+	expectedSizeBytes := int64(1)
+	if len(completeSnapshots) > 0 {
+		expectedSizeBytes = max(expectedSizeBytes, completeSnapshots[len(completeSnapshots)-1].sizeBytes)
+	}
+	if lastOperation != nil && errors.Is(lastOperation.err, errEtcdSnapshotSizeLimitExceeded) {
+		expectedSizeBytes = max(expectedSizeBytes, lastOperation.maxSizeBytes+1)
+	}
+	if expectedSizeBytes > maxTotalSizeBytes {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdBackupSizeLimitExceededReason,
+			Message: "Etcd snapshot not taken: a single etcd snapshot exceeds spec.etcdBackup.maxTotalSizeBytes",
+		})
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	// Delete the oldest retained snapshots until the new snapshot fits into maxTotalSizeBytes, e.g. because
+	// maxTotalSizeBytes has been lowered or because the etcd database grew.
+	retainedSizeBytes := int64(0)
+	for _, snapshot := range retainedSnapshots {
+		retainedSizeBytes += snapshot.sizeBytes
+	}
+	for len(retainedSnapshots) > 0 && retainedSizeBytes+expectedSizeBytes > maxTotalSizeBytes {
+		snapshot := retainedSnapshots[0]
+		if err := sink.Delete(ctx, kcp, snapshot.name); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete etcd snapshot %s", snapshot.name)
+		}
+		log.Info(fmt.Sprintf("Deleted etcd snapshot %s to make room for a new snapshot within spec.etcdBackup.maxTotalSizeBytes", snapshot.name))
+		retainedSizeBytes -= snapshot.sizeBytes
+		retainedSnapshots = retainedSnapshots[1:]
+	}
+	maxSizeBytes := maxTotalSizeBytes - retainedSizeBytes
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create client to workload cluster")
+	}
+
+	operation := &etcdSnapshotOperation{
+		name:         fmt.Sprintf("%s-etcd-%s", kcp.Name, now.Format(etcdSnapshotTimestampFormat)),
+		startTime:    metav1.NewTime(now),
+		maxSizeBytes: maxSizeBytes,
+		done:         make(chan struct{}),
+	}
+	r.etcdSnapshots.Set(kcp, operation)
+	// NOTE: The snapshot goroutine uses a copy of the KubeadmControlPlane, because kcp is modified by the reconcile.
+	kcpCopy := kcp.DeepCopy()
+	go func() {
+		defer close(operation.done)
+		// The snapshot must not be canceled when the reconcile completes.
+		ctx, cancel := context.WithTimeoutCause(context.WithoutCancel(ctx), etcdSnapshotTimeout, errors.New("etcd snapshot timeout expired"))
+		defer cancel()
+		operation.revision, operation.sizeBytes, operation.err = saveEtcdSnapshot(ctx, workloadCluster, sink, kcpCopy, operation, maxSizeBytes)
+	}()
+	log.Info(fmt.Sprintf("Taking etcd snapshot %s", operation.name))
+
+	status.SnapshotInProgress = &controlplanev1.EtcdSnapshotProgress{
+		Name:      operation.name,
+		StartTime: operation.startTime,
+	}
+	return ctrl.Result{RequeueAfter: etcdSnapshotProgressInterval}, nil
+}
+
+// reconcileEtcdBackupDelete deletes the etcd snapshots stored for a KubeadmControlPlane being deleted, unless
+// the etcd backup deletion policy is Retain; snapshots which have not been completely stored are always deleted.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdBackupDelete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Wait for the snapshot being taken to complete, so it is not stored after the snapshots are deleted.
+	if operation := r.etcdSnapshots.Get(kcp); operation != nil {
+		if !operation.Done() {
+			log.Info(fmt.Sprintf("Waiting for etcd snapshot %s to complete", operation.name))
+			return ctrl.Result{RequeueAfter: etcdSnapshotProgressInterval}, nil
+		}
+		r.etcdSnapshots.Delete(kcp)
+	}
+
+	retain := kcp.Spec.EtcdBackup != nil && kcp.Spec.EtcdBackup.DeletionPolicy == controlplanev1.RetainEtcdBackupDeletionPolicy
+	sink, err := r.etcdSnapshotSink(ctx, kcp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	snapshots, err := sink.List(ctx, kcp)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list etcd snapshots")
+	}
+	errs := []error{}
+	for _, snapshot := range snapshots {
+		if retain && snapshot.complete {
+			continue
+		}
+		if err := sink.Delete(ctx, kcp, snapshot.name); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to delete etcd snapshot %s", snapshot.name))
+			continue
+		}
+		log.Info(fmt.Sprintf("Deleted etcd snapshot %s", snapshot.name))
+	}
+	return ctrl.Result{}, kerrors.NewAggregate(errs)
+}
+
+// setEtcdBackupFailedCondition sets the EtcdBackupSucceeded condition to False, surfacing why the snapshot failed.
+func setEtcdBackupFailedCondition(kcp *controlplanev1.KubeadmControlPlane, name string, err error) {
+	if errors.Is(err, errEtcdSnapshotSizeLimitExceeded) {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdBackupSizeLimitExceededReason,
+			Message: fmt.Sprintf("Etcd snapshot %s not saved: retained etcd snapshots would exceed spec.etcdBackup.maxTotalSizeBytes", name),
+		})
+		return
+	}
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdBackupFailedReason,
+		Message: fmt.Sprintf("Failed to save etcd snapshot %s: %v", name, err),
+	})
+}
+
+// saveEtcdSnapshot streams an etcd snapshot from the workload cluster to the sink, and returns the revision of the snapshot
+// and the size of the compressed snapshot stored in the sink. The snapshot fails if its compressed size exceeds maxSizeBytes.
+func saveEtcdSnapshot(ctx context.Context, workloadCluster internal.WorkloadCluster, sink etcdSnapshotSink, kcp *controlplanev1.KubeadmControlPlane, operation *etcdSnapshotOperation, maxSizeBytes int64) (int64, int64, error) {
+	type snapshotResult struct {
+		revision int64
+		err      error
+	}
+
+	reader, writer := io.Pipe()
+	resultCh := make(chan snapshotResult, 1)
+	go func() {
+		revision, err := workloadCluster.SaveEtcdSnapshot(ctx, &countingWriter{w: writer, n: &operation.savedBytes})
+		// Closing the writer signals the sink that the snapshot is complete, or that it failed.
+		_ = writer.CloseWithError(err)
+		resultCh <- snapshotResult{revision: revision, err: err}
+	}()
+
+	sizeBytes, saveErr := sink.Save(ctx, kcp, operation.name, reader, maxSizeBytes)
+	// Closing the reader unblocks the snapshot goroutine in case the sink returned before reading all the data.
+	_ = reader.CloseWithError(errors.New("etcd snapshot sink closed"))
+	result := <-resultCh
+
+	if result.err != nil || saveErr != nil {
+		return 0, 0, kerrors.NewAggregate([]error{result.err, saveErr})
+	}
+	return result.revision, sizeBytes, nil
+}
+
+// deleteExpiredEtcdSnapshots deletes the oldest snapshots stored in the sink exceeding maxSnapshots.
+func deleteExpiredEtcdSnapshots(ctx context.Context, sink etcdSnapshotSink, kcp *controlplanev1.KubeadmControlPlane, maxSnapshots int) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	snapshots, err := sink.List(ctx, kcp)
+	if err != nil {
+		return errors.Wrap(err, "failed to list etcd snapshots")
+	}
+	names := []string{}
+	for _, snapshot := range snapshots {
+		names = append(names, snapshot.name)
+	}
+	if len(names) <= maxSnapshots {
+		return nil
+	}
+
+	// Snapshot names end with a timestamp, so sorting them alphabetically sorts them from the oldest to the newest.
+	sort.Strings(names)
+	errs := []error{}
+	for _, name := range names[:len(names)-maxSnapshots] {
+		if err := sink.Delete(ctx, kcp, name); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to delete etcd snapshot %s", name))
+			continue
+		}
+		log.Info(fmt.Sprintf("Deleted etcd snapshot %s", name))
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// countingWriter is an io.Writer counting the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// errEtcdSnapshotSizeLimitExceeded is returned when storing an etcd snapshot would exceed the maximum total size
+// of the retained snapshots.
+var errEtcdSnapshotSizeLimitExceeded = errors.New("etcd snapshot size limit exceeded")
+
+// errEtcdSnapshotNotFound is returned when opening an etcd snapshot which does not exist or which is not complete.
+var errEtcdSnapshotNotFound = errors.New("etcd snapshot not found")
+
+// etcdSnapshotSink stores the etcd snapshots taken by a KubeadmControlPlane.
+type etcdSnapshotSink interface {
+	// Save stores the snapshot read from data, gzip compressed, and returns the size of the compressed snapshot;
+	// if the compressed snapshot exceeds maxSizeBytes, errEtcdSnapshotSizeLimitExceeded is returned.
+	// A snapshot which is not saved successfully is not left behind.
+	Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string, data io.Reader, maxSizeBytes int64) (int64, error)
+
+	// List returns the snapshots stored for the KubeadmControlPlane, including the ones not completely stored.
+	// NOTE: List must not read the data of the snapshots.
+	List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]storedEtcdSnapshot, error)
+
+	// Open returns a reader streaming the gzip compressed snapshot with the given name, as it was stored by Save;
+	// if the snapshot does not exist or it is not complete, errEtcdSnapshotNotFound is returned.
+	Open(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) (io.ReadCloser, error)
+
+	// Delete deletes the snapshot with the given name; deleting a snapshot which does not exist is not an error.
+	Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error
+}
+
+// etcdSnapshotSink returns the sink storing the etcd snapshots of the KubeadmControlPlane as defined in
+// spec.etcdBackup.sink; if spec.etcdBackup is not set, e.g. because it was removed, snapshots are assumed to be
+// stored in Secrets.
+func (r *KubeadmControlPlaneReconciler) etcdSnapshotSink(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) (etcdSnapshotSink, error) {
+	if kcp.Spec.EtcdBackup == nil {
+		return &secretEtcdSnapshotSink{Client: r.Client}, nil
+	}
+
+	switch sink := kcp.Spec.EtcdBackup.Sink; sink.Type {
+	case controlplanev1.SecretEtcdBackupSinkType:
+		return &secretEtcdSnapshotSink{Client: r.Client}, nil
+	case controlplanev1.ObjectStoreEtcdBackupSinkType:
+		if sink.ObjectStore == nil {
+			return nil, errors.New("failed to create etcd snapshot sink: spec.etcdBackup.sink.objectStore must be set when type is ObjectStore")
+		}
+		return newObjectStoreEtcdSnapshotSink(ctx, r.Client, kcp.Namespace, sink.ObjectStore)
+	default:
+		return nil, errors.Errorf("failed to create etcd snapshot sink: unknown type %q", sink.Type)
+	}
+}
+
+// storedEtcdSnapshot is an etcd snapshot stored in the sink.
+type storedEtcdSnapshot struct {
+	name string
+
+	// complete is true if all the chunks of the snapshot are stored.
+	complete bool
+
+	// sizeBytes is the size of the compressed snapshot.
+	sizeBytes int64
+}
+
+// secretEtcdSnapshotSink stores etcd snapshots, gzip compressed, in Secrets in the namespace of the KubeadmControlPlane.
+// Snapshots exceeding etcdSnapshotSecretChunkSize are split across multiple Secrets, named <snapshot name>-<index>.
+// NOTE: Secrets do not have the cluster name label, so they are not loaded in the cache of the cluster Secrets.
+// NOTE: Secrets are not owned by the KubeadmControlPlane, so snapshots can be retained and restored after
+// the KubeadmControlPlane is deleted and re-created with the same name.
+type secretEtcdSnapshotSink struct {
+	Client client.Client
+}
+
+// Save stores the snapshot read from data in one or more Secrets, and returns the size of the compressed snapshot;
+// if the compressed snapshot exceeds maxSizeBytes, errEtcdSnapshotSizeLimitExceeded is returned.
+// The snapshot is compressed and stored while it is read, so at most two chunks are kept in memory: the first chunk,
+// which is created last with the number of chunks, and the chunk being filled.
+func (s *secretEtcdSnapshotSink) Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string, data io.Reader, maxSizeBytes int64) (int64, error) {
+	chunkWriter := &etcdSnapshotChunkWriter{ctx: ctx, client: s.Client, kcp: kcp, name: name, maxSizeBytes: maxSizeBytes}
+	gzipWriter := gzip.NewWriter(chunkWriter)
+	_, err := io.Copy(gzipWriter, data)
+	if err == nil {
+		err = gzipWriter.Close()
+	}
+	if err == nil {
+		err = chunkWriter.Close()
+	}
+	if err != nil {
+		// Do not leave an incomplete snapshot behind.
+		return 0, kerrors.NewAggregate([]error{
+			errors.Wrap(err, "failed to store etcd snapshot"),
+			s.Delete(ctx, kcp, name),
+		})
+	}
+	return chunkWriter.written, nil
+}
+
+// List returns the snapshots stored in Secrets for the KubeadmControlPlane, including the ones not completely stored.
+// A snapshot is complete if its first Secret, which is created last, exists and the number of Secrets matches
+// the number of chunks in its EtcdSnapshotChunksAnnotation.
+// NOTE: Only the metadata of the Secrets is read, and the size of each chunk is read from its EtcdSnapshotChunkSizeAnnotation.
+func (s *secretEtcdSnapshotSink) List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]storedEtcdSnapshot, error) {
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := s.Client.List(ctx, secrets,
+		client.InNamespace(kcp.Namespace),
+		client.MatchingLabels{clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(kcp.Name)},
+		client.HasLabels{controlplanev1.EtcdSnapshotLabel},
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd snapshot Secrets")
+	}
+
+	type snapshotChunks struct {
+		chunks         int
+		expectedChunks int
+		sizeBytes      int64
+	}
+	snapshots := map[string]*snapshotChunks{}
+	for i := range secrets.Items {
+		snapshotSecret := &secrets.Items[i]
+		j := strings.LastIndex(snapshotSecret.Name, "-")
+		if j < 0 {
+			continue
+		}
+		name := snapshotSecret.Name[:j]
+		if snapshots[name] == nil {
+			snapshots[name] = &snapshotChunks{}
+		}
+		sizeBytes, err := s.chunkSize(ctx, snapshotSecret)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[name].chunks++
+		snapshots[name].sizeBytes += sizeBytes
+		if snapshotSecret.Name[j+1:] == "0" {
+			if chunks, err := strconv.Atoi(snapshotSecret.Annotations[controlplanev1.EtcdSnapshotChunksAnnotation]); err == nil {
+				snapshots[name].expectedChunks = chunks
+			}
+		}
+	}
+
+	ret := make([]storedEtcdSnapshot, 0, len(snapshots))
+	for name, snapshot := range snapshots {
+		ret = append(ret, storedEtcdSnapshot{
+			name:      name,
+			complete:  snapshot.expectedChunks > 0 && snapshot.chunks == snapshot.expectedChunks,
+			sizeBytes: snapshot.sizeBytes,
+		})
+	}
+	return ret, nil
+}
+
+// chunkSize returns the size of the chunk stored in a Secret from its EtcdSnapshotChunkSizeAnnotation; if the
+// annotation is not set or not valid, the size is computed reading the Secret.
+func (s *secretEtcdSnapshotSink) chunkSize(ctx context.Context, snapshotSecret *metav1.PartialObjectMetadata) (int64, error) {
+	if sizeBytes, err := strconv.ParseInt(snapshotSecret.Annotations[controlplanev1.EtcdSnapshotChunkSizeAnnotation], 10, 64); err == nil && sizeBytes >= 0 {
+		return sizeBytes, nil
+	}
+
+	chunk := &corev1.Secret{}
+	if err := s.Client.Get(ctx, client.ObjectKeyFromObject(snapshotSecret), chunk); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, errors.Wrapf(err, "failed to get Secret %s", snapshotSecret.Name)
+	}
+	return int64(len(chunk.Data[bootstrapv1.EtcdSnapshotDataKey])), nil
+}
+
+// Open returns a reader concatenating the chunks of the snapshot stored in Secrets; chunks are read one at a time
+// while the reader is consumed, so at most one chunk is kept in memory.
+func (s *secretEtcdSnapshotSink) Open(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) (io.ReadCloser, error) {
+	firstChunk, err := s.getChunk(ctx, kcp, name, 0)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := strconv.Atoi(firstChunk.Annotations[controlplanev1.EtcdSnapshotChunksAnnotation])
+	if err != nil || chunks < 1 {
+		return nil, errors.Wrapf(errEtcdSnapshotNotFound, "Secret %s has an invalid %s annotation", firstChunk.Name, controlplanev1.EtcdSnapshotChunksAnnotation)
+	}
+	return &etcdSnapshotChunkReader{
+		ctx:     ctx,
+		sink:    s,
+		kcp:     kcp,
+		name:    name,
+		chunks:  chunks,
+		next:    1,
+		current: bytes.NewReader(firstChunk.Data[bootstrapv1.EtcdSnapshotDataKey]),
+	}, nil
+}
+
+// getChunk returns the Secret storing the chunk of a snapshot with the given index; if the Secret does not exist or
+// it does not belong to the snapshot, errEtcdSnapshotNotFound is returned.
+func (s *secretEtcdSnapshotSink) getChunk(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string, index int) (*corev1.Secret, error) {
+	chunk := &corev1.Secret{}
+	chunkKey := client.ObjectKey{Namespace: kcp.Namespace, Name: fmt.Sprintf("%s-%d", name, index)}
+	if err := s.Client.Get(ctx, chunkKey, chunk); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(errEtcdSnapshotNotFound, "Secret %s does not exist", chunkKey.Name)
+		}
+		return nil, errors.Wrapf(err, "failed to get Secret %s", chunkKey.Name)
+	}
+	if chunk.Labels[clusterv1.MachineControlPlaneNameLabel] != format.MustFormatValue(kcp.Name) ||
+		chunk.Labels[controlplanev1.EtcdSnapshotLabel] != format.MustFormatValue(name) {
+		return nil, errors.Wrapf(errEtcdSnapshotNotFound, "Secret %s does not store a chunk of etcd snapshot %s", chunk.Name, name)
+	}
+	if _, ok := chunk.Data[bootstrapv1.EtcdSnapshotDataKey]; !ok {
+		return nil, errors.Wrapf(errEtcdSnapshotNotFound, "Secret %s does not have the %s key", chunk.Name, bootstrapv1.EtcdSnapshotDataKey)
+	}
+	return chunk, nil
+}
+
+// etcdSnapshotChunkReader is an io.ReadCloser reading the chunks of a snapshot stored in Secrets, in order.
+type etcdSnapshotChunkReader struct {
+	ctx  context.Context
+	sink *secretEtcdSnapshotSink
+	kcp  *controlplanev1.KubeadmControlPlane
+	name string
+
+	chunks  int
+	next    int
+	current *bytes.Reader
+}
+
+func (r *etcdSnapshotChunkReader) Read(p []byte) (int, error) {
+	for r.current.Len() == 0 {
+		if r.next >= r.chunks {
+			return 0, io.EOF
+		}
+		chunk, err := r.sink.getChunk(r.ctx, r.kcp, r.name, r.next)
+		if err != nil {
+			return 0, err
+		}
+		r.current = bytes.NewReader(chunk.Data[bootstrapv1.EtcdSnapshotDataKey])
+		r.next++
+	}
+	return r.current.Read(p)
+}
+
+func (r *etcdSnapshotChunkReader) Close() error {
+	return nil
+}
+
+// etcdSnapshotChunkWriter is an io.Writer storing the data written to it in Secrets of at most etcdSnapshotSecretChunkSize,
+// named <snapshot name>-<index>.
+type etcdSnapshotChunkWriter struct {
+	ctx    context.Context
+	client client.Client
+	kcp    *controlplanev1.KubeadmControlPlane
+	name   string
+
+	// maxSizeBytes is the maximum size of the data written.
+	maxSizeBytes int64
+	written      int64
+
+	firstChunk []byte
+	chunk      bytes.Buffer
+	chunks     int
+}
+
+func (w *etcdSnapshotChunkWriter) Write(p []byte) (int, error) {
+	if w.written+int64(len(p)) > w.maxSizeBytes {
+		return 0, errEtcdSnapshotSizeLimitExceeded
+	}
+	w.written += int64(len(p))
+
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), etcdSnapshotSecretChunkSize-w.chunk.Len())
+		w.chunk.Write(p[:n])
+		p = p[n:]
+		written += n
+		if w.chunk.Len() == etcdSnapshotSecretChunkSize {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// flush stores the chunk being filled; the first chunk is kept in memory until Close.
+func (w *etcdSnapshotChunkWriter) flush() error {
+	if w.chunks == 0 {
+		w.firstChunk = bytes.Clone(w.chunk.Bytes())
+	} else if err := w.createChunk(w.chunks, w.chunk.Bytes(), nil); err != nil {
+		return err
+	}
+	w.chunk.Reset()
+	w.chunks++
+	return nil
+}
+
+// Close stores the last chunk and then the first chunk, annotated with the number of chunks.
+func (w *etcdSnapshotChunkWriter) Close() error {
+	if w.chunk.Len() > 0 || w.chunks == 0 {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	return w.createChunk(0, w.firstChunk, map[string]string{
+		controlplanev1.EtcdSnapshotChunksAnnotation: strconv.Itoa(w.chunks),
+	})
+}
+
+func (w *etcdSnapshotChunkWriter) createChunk(index int, data []byte, annotations map[string]string) error {
+	annotations = maps.Clone(annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[controlplanev1.EtcdSnapshotChunkSizeAnnotation] = strconv.Itoa(len(data))
+
+	snapshotSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", w.name, index),
+			Namespace: w.kcp.Namespace,
+			Labels: map[string]string{
+				clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(w.kcp.Name),
+				controlplanev1.EtcdSnapshotLabel:       format.MustFormatValue(w.name),
+			},
+			Annotations: annotations,
+		},
+		Data: map[string][]byte{
+			bootstrapv1.EtcdSnapshotDataKey: data,
+		},
+		Type: clusterv1.ClusterSecretType,
+	}
+	if err := w.client.Create(w.ctx, snapshotSecret); err != nil {
+		return errors.Wrapf(err, "failed to create Secret %s", snapshotSecret.Name)
+	}
+	return nil
+}
+
+// Delete deletes all the Secrets storing the snapshot with the given name.
+func (s *secretEtcdSnapshotSink) Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error {
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := s.Client.List(ctx, secrets,
+		client.InNamespace(kcp.Namespace),
+		client.MatchingLabels{
+			clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(kcp.Name),
+			controlplanev1.EtcdSnapshotLabel:       format.MustFormatValue(name),
+		},
+	); err != nil {
+		return errors.Wrap(err, "failed to list etcd snapshot Secrets")
+	}
+
+	errs := []error{}
+	for i := range secrets.Items {
+		snapshotSecret := &secrets.Items[i]
+		// NOTE: The items of a metadata list do not have a GroupVersionKind, which is required to delete them.
+		snapshotSecret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		if err := s.Client.Delete(ctx, snapshotSecret); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete Secret %s", snapshotSecret.Name))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+const (
+	// objectStoreTokenKey is the key of the bearer token in the Secret storing the credentials of an object store.
+	objectStoreTokenKey = "token"
+
+	// objectStoreCAKey is the key of the CA certificates in the Secret storing the credentials of an object store.
+	objectStoreCAKey = "ca.crt"
+
+	// objectStoreRequestTimeout is how long KCP waits at most for an object store to list or delete snapshots.
+	// NOTE: Saving a snapshot is bounded by etcdSnapshotTimeout instead.
+	objectStoreRequestTimeout = 30 * time.Second
+
+	// objectStoreMaxErrorBodySize is the maximum number of bytes of an error response reported in errors.
+	objectStoreMaxErrorBodySize = 1024
+)
+
+// objectStoreSnapshotList is the response of an object store to a request listing the snapshots of a KubeadmControlPlane.
+type objectStoreSnapshotList struct {
+	Snapshots []objectStoreSnapshot `json:"snapshots"`
+}
+
+// objectStoreSnapshot is a snapshot stored in an object store.
+type objectStoreSnapshot struct {
+	Name      string `json:"name"`
+	SizeBytes int64  `json:"sizeBytes"`
+}
+
+// objectStoreEtcdSnapshotSink stores etcd snapshots, gzip compressed, in an object store implementing the etcd snapshot
+// object store contract:
+//   - PUT <url>/<namespace>/<KubeadmControlPlane name>/<snapshot name> stores a snapshot; the body is streamed, and the
+//     object store must make the snapshot visible only when the request completes successfully.
+//   - GET <url>/<namespace>/<KubeadmControlPlane name>/ lists the snapshots, as a JSON objectStoreSnapshotList.
+//   - GET <url>/<namespace>/<KubeadmControlPlane name>/<snapshot name> reads a snapshot.
+//   - DELETE <url>/<namespace>/<KubeadmControlPlane name>/<snapshot name> deletes a snapshot; 404 is not an error.
+//
+// All the requests are authenticated with the bearer token stored in the credentials Secret.
+// NOTE: Snapshots are stored atomically by the object store, so all the listed snapshots are complete.
+type objectStoreEtcdSnapshotSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// newObjectStoreEtcdSnapshotSink returns a sink for the object store, reading its credentials from the Secret in namespace.
+func newObjectStoreEtcdSnapshotSink(ctx context.Context, c client.Reader, namespace string, objectStore *controlplanev1.EtcdBackupObjectStoreSink) (*objectStoreEtcdSnapshotSink, error) {
+	if !strings.HasPrefix(objectStore.URL, "https://") {
+		return nil, errors.Errorf("failed to create etcd snapshot sink: object store URL %q must use https", objectStore.URL)
+	}
+
+	credentials := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: objectStore.CredentialsSecretName}, credentials); err != nil {
+		return nil, errors.Wrapf(err, "failed to get object store credentials Secret %s", objectStore.CredentialsSecretName)
+	}
+	token := strings.TrimSpace(string(credentials.Data[objectStoreTokenKey]))
+	if token == "" {
+		return nil, errors.Errorf("object store credentials Secret %s does not have the %s key", objectStore.CredentialsSecretName, objectStoreTokenKey)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caData, ok := credentials.Data[objectStoreCAKey]; ok {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caData) {
+			return nil, errors.Errorf("object store credentials Secret %s has an invalid %s key", objectStore.CredentialsSecretName, objectStoreCAKey)
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &objectStoreEtcdSnapshotSink{
+		url:    objectStore.URL,
+		token:  token,
+		client: &http.Client{Transport: transport},
+	}, nil
+}
+
+// Save streams the snapshot read from data, gzip compressed, to the object store, and returns the size of the
+// compressed snapshot; if the compressed snapshot exceeds maxSizeBytes, the request is aborted and
+// errEtcdSnapshotSizeLimitExceeded is returned.
+func (s *objectStoreEtcdSnapshotSink) Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string, data io.Reader, maxSizeBytes int64) (int64, error) {
+	snapshotURL, err := s.snapshotURL(kcp, name)
+	if err != nil {
+		return 0, err
+	}
+
+	reader, writer := io.Pipe()
+	limitWriter := &sizeLimitWriter{w: writer, remaining: maxSizeBytes}
+	compressErrCh := make(chan error, 1)
+	go func() {
+		gzipWriter := gzip.NewWriter(limitWriter)
+		_, err := io.Copy(gzipWriter, data)
+		if err == nil {
+			err = gzipWriter.Close()
+		}
+		// Closing the writer with a nil error completes the request body; any other error aborts the request.
+		_ = writer.CloseWithError(err)
+		compressErrCh <- err
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, snapshotURL, reader)
+	if err == nil {
+		req.Header.Set("Content-Type", "application/gzip")
+		err = s.do(req, false)
+	}
+	// Closing the reader unblocks the compressing goroutine in case the request completed before reading all the data.
+	_ = reader.CloseWithError(errors.New("etcd snapshot object store request completed"))
+	compressErr := <-compressErrCh
+
+	if compressErr != nil || err != nil {
+		// Object stores must not store snapshots which are not completely uploaded; the snapshot is deleted anyway
+		// in case the object store received all the data before the error.
+		return 0, kerrors.NewAggregate([]error{
+			errors.Wrap(kerrors.NewAggregate([]error{compressErr, err}), "failed to store etcd snapshot"),
+			s.Delete(ctx, kcp, name),
+		})
+	}
+	return maxSizeBytes - limitWriter.remaining, nil
+}
+
+// List returns the snapshots stored in the object store for the KubeadmControlPlane.
+func (s *objectStoreEtcdSnapshotSink) List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]storedEtcdSnapshot, error) {
+	listURL, err := url.JoinPath(s.url, kcp.Namespace, kcp.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse object store URL %q", s.url)
+	}
+	listURL += "/"
+
+	ctx, cancel := context.WithTimeout(ctx, objectStoreRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd snapshots")
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd snapshots")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(objectStoreResponseError(resp), "failed to list etcd snapshots")
+	}
+
+	snapshots := &objectStoreSnapshotList{}
+	if err := json.NewDecoder(resp.Body).Decode(snapshots); err != nil {
+		return nil, errors.Wrap(err, "failed to decode etcd snapshots listed by the object store")
+	}
+	ret := make([]storedEtcdSnapshot, 0, len(snapshots.Snapshots))
+	for _, snapshot := range snapshots.Snapshots {
+		ret = append(ret, storedEtcdSnapshot{
+			name:      snapshot.Name,
+			complete:  true,
+			sizeBytes: snapshot.SizeBytes,
+		})
+	}
+	return ret, nil
+}
+
+// Open returns a reader streaming the snapshot with the given name from the object store; the request is bound
+// to ctx, so the snapshot can be read for as long as ctx is not canceled.
+func (s *objectStoreEtcdSnapshotSink) Open(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) (io.ReadCloser, error) {
+	snapshotURL, err := s.snapshotURL(kcp, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, snapshotURL, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read etcd snapshot %s", name)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read etcd snapshot %s", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, errors.Wrapf(errEtcdSnapshotNotFound, "etcd snapshot %s does not exist in the object store", name)
+		}
+		return nil, errors.Wrapf(objectStoreResponseError(resp), "failed to read etcd snapshot %s", name)
+	}
+	return resp.Body, nil
+}
+
+// Delete deletes the snapshot with the given name from the object store.
+func (s *objectStoreEtcdSnapshotSink) Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error {
+	snapshotURL, err := s.snapshotURL(kcp, name)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The snapshot is deleted also when ctx is canceled, e.g. after a failed Save, so it is not left behind.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), objectStoreRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, snapshotURL, http.NoBody)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete etcd snapshot %s", name)
+	}
+	if err := s.do(req, true); err != nil {
+		return errors.Wrapf(err, "failed to delete etcd snapshot %s", name)
+	}
+	return nil
+}
+
+// snapshotURL returns the URL of a snapshot in the object store.
+func (s *objectStoreEtcdSnapshotSink) snapshotURL(kcp *controlplanev1.KubeadmControlPlane, name string) (string, error) {
+	snapshotURL, err := url.JoinPath(s.url, kcp.Namespace, kcp.Name, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse object store URL %q", s.url)
+	}
+	return snapshotURL, nil
+}
+
+// do sends an authenticated request to the object store, and returns an error if the response is not successful;
+// if allowNotFound is true, a 404 response is not considered an error.
+func (s *objectStoreEtcdSnapshotSink) do(req *http.Request, allowNotFound bool) error {
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if (resp.StatusCode < 200 || resp.StatusCode > 299) && (!allowNotFound || resp.StatusCode != http.StatusNotFound) {
+		return objectStoreResponseError(resp)
+	}
+	return nil
+}
+
+// objectStoreResponseError returns an error reporting the status and the beginning of the body of an unsuccessful response.
+func objectStoreResponseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, objectStoreMaxErrorBodySize))
+	return errors.Errorf("object store responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+// sizeLimitWriter is an io.Writer returning errEtcdSnapshotSizeLimitExceeded when more than remaining bytes are written.
+type sizeLimitWriter struct {
+	w         io.Writer
+	remaining int64
+}
+
+func (l *sizeLimitWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		return 0, errEtcdSnapshotSizeLimitExceeded
+	}
+	n, err := l.w.Write(p)
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// fakeObjectStore is an in-memory object store implementing the etcd snapshot object store contract.
+type fakeObjectStore struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+	token   string
+}
+
+func (f *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/etcd/")
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(key, "/"):
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		list := objectStoreSnapshotList{Snapshots: []objectStoreSnapshot{}}
+		for objectKey, data := range f.objects {
+			if name, ok := strings.CutPrefix(objectKey, key); ok && !strings.Contains(name, "/") {
+				list.Snapshots = append(list.Snapshots, objectStoreSnapshot{Name: name, SizeBytes: int64(len(data))})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	case r.Method == http.MethodGet:
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		data, ok := f.objects[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodPut:
+		// The snapshot is stored only if it is completely uploaded.
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.objects[key] = data
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete:
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		if _, ok := f.objects[key]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Objects returns a copy of the objects stored in the object store.
+func (f *fakeObjectStore) Objects() map[string][]byte {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return maps.Clone(f.objects)
+}
+
+// newFakeObjectStore starts a fakeObjectStore, and returns the etcd backup sink and the credentials Secret to use it.
+func newFakeObjectStore(t *testing.T, namespace string) (*fakeObjectStore, controlplanev1.EtcdBackupSink, *corev1.Secret) {
+	t.Helper()
+
+	objectStore := &fakeObjectStore{objects: map[string][]byte{}, token: "object-store-token"}
+	server := httptest.NewTLSServer(objectStore)
+	t.Cleanup(server.Close)
+
+	sink := controlplanev1.EtcdBackupSink{
+		Type: controlplanev1.ObjectStoreEtcdBackupSinkType,
+		ObjectStore: &controlplanev1.EtcdBackupObjectStoreSink{
+			URL:                   server.URL + "/etcd",
+			CredentialsSecretName: "object-store-credentials",
+		},
+	}
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "object-store-credentials",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			objectStoreTokenKey: []byte(objectStore.token),
+			objectStoreCAKey:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		},
+	}
+	return objectStore, sink, credentials
+}
+
+func TestObjectStoreEtcdSnapshotSink(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	otherKCP := kcp.DeepCopy()
+	otherKCP.Name = "bar"
+
+	objectStore, sinkSpec, credentials := newFakeObjectStore(t, kcp.Namespace)
+	sink, err := newObjectStoreEtcdSnapshotSink(ctx, newFakeClient(credentials), kcp.Namespace, sinkSpec.ObjectStore)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	snapshotData := make([]byte, 3*etcdSnapshotSecretChunkSize)
+	_, err = rand.Read(snapshotData)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	maxSizeBytes := controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes
+	sizeBytes, err := sink.Save(ctx, kcp, "foo-etcd-1", bytes.NewReader(snapshotData), maxSizeBytes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sink.Save(ctx, kcp, "foo-etcd-2", strings.NewReader("small"), maxSizeBytes)).To(BeNumerically(">", 0))
+	g.Expect(sink.Save(ctx, otherKCP, "bar-etcd-1", strings.NewReader("other"), maxSizeBytes)).To(BeNumerically(">", 0))
+
+	// A snapshot exceeding the maximum size is not stored.
+	_, err = sink.Save(ctx, kcp, "foo-etcd-3", bytes.NewReader(snapshotData), 2*etcdSnapshotSecretChunkSize)
+	g.Expect(errors.Is(err, errEtcdSnapshotSizeLimitExceeded)).To(BeTrue())
+
+	objects := objectStore.Objects()
+	compressed := objects["default/foo/foo-etcd-1"]
+	gzipReader, err := gzip.NewReader(bytes.NewReader(compressed))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(io.ReadAll(gzipReader)).To(Equal(snapshotData))
+	g.Expect(sizeBytes).To(Equal(int64(len(compressed))))
+
+	snapshots, err := sink.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(ConsistOf(
+		storedEtcdSnapshot{name: "foo-etcd-1", complete: true, sizeBytes: int64(len(compressed))},
+		storedEtcdSnapshot{name: "foo-etcd-2", complete: true, sizeBytes: int64(len(objects["default/foo/foo-etcd-2"]))},
+	))
+
+	g.Expect(sink.Delete(ctx, kcp, "foo-etcd-1")).To(Succeed())
+	// Deleting a snapshot which does not exist is not an error.
+	g.Expect(sink.Delete(ctx, kcp, "foo-etcd-1")).To(Succeed())
+	g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-2"}))
+	g.Expect(etcdSnapshotNames(ctx, g, sink, otherKCP)).To(Equal([]string{"bar-etcd-1"}))
+
+	// Requests fail if the object store rejects the credentials.
+	sink.token = "wrong-token"
+	_, err = sink.List(ctx, kcp)
+	g.Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+	_, err = sink.Save(ctx, kcp, "foo-etcd-4", strings.NewReader("snapshot"), maxSizeBytes)
+	g.Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+}
+
+func TestNewObjectStoreEtcdSnapshotSink(t *testing.T) {
+	_, sinkSpec, credentials := newFakeObjectStore(t, metav1.NamespaceDefault)
+
+	t.Run("fails if the credentials Secret does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := newObjectStoreEtcdSnapshotSink(ctx, newFakeClient(), metav1.NamespaceDefault, sinkSpec.ObjectStore)
+		g.Expect(err).To(MatchError(ContainSubstring("failed to get object store credentials Secret object-store-credentials")))
+	})
+
+	t.Run("fails if the credentials Secret does not have a token", func(t *testing.T) {
+		g := NewWithT(t)
+
+		noToken := credentials.DeepCopy()
+		delete(noToken.Data, objectStoreTokenKey)
+		_, err := newObjectStoreEtcdSnapshotSink(ctx, newFakeClient(noToken), metav1.NamespaceDefault, sinkSpec.ObjectStore)
+		g.Expect(err).To(MatchError(ContainSubstring("does not have the token key")))
+	})
+
+	t.Run("fails if the CA of the credentials Secret is not valid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		invalidCA := credentials.DeepCopy()
+		invalidCA.Data[objectStoreCAKey] = []byte("not a certificate")
+		_, err := newObjectStoreEtcdSnapshotSink(ctx, newFakeClient(invalidCA), metav1.NamespaceDefault, sinkSpec.ObjectStore)
+		g.Expect(err).To(MatchError(ContainSubstring("has an invalid ca.crt key")))
+	})
+
+	t.Run("fails if the URL does not use https", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objectStore := sinkSpec.ObjectStore.DeepCopy()
+		objectStore.URL = strings.Replace(objectStore.URL, "https://", "http://", 1)
+		_, err := newObjectStoreEtcdSnapshotSink(ctx, newFakeClient(credentials), metav1.NamespaceDefault, objectStore)
+		g.Expect(err).To(MatchError(ContainSubstring("must use https")))
+	})
+}
+
+func TestReconcileEtcdBackupWithObjectStore(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.31.0",
+			EtcdBackup: &controlplanev1.EtcdBackupPolicy{
+				IntervalSeconds: 3600,
+				MaxSnapshots:    ptr.To[int32](1),
+			},
+		},
+	}
+	objectStore, sinkSpec, credentials := newFakeObjectStore(t, kcp.Namespace)
+	kcp.Spec.EtcdBackup.Sink = sinkSpec
+	objectStore.objects["default/foo/foo-etcd-20200101000000"] = []byte("old snapshot")
+
+	workloadCluster := &fakeWorkloadCluster{
+		EtcdSnapshotData:     []byte("snapshot"),
+		EtcdSnapshotRevision: 42,
+	}
+	managementCluster := &fakeManagementCluster{
+		Workload: workloadCluster,
+	}
+	r := &KubeadmControlPlaneReconciler{
+		Client:            newFakeClient(kcp.DeepCopy(), credentials),
+		managementCluster: managementCluster,
+	}
+	controlPlane := &internal.ControlPlane{
+		KCP:     kcp,
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}},
+	}
+	controlPlane.InjectTestManagementCluster(managementCluster)
+
+	_, err := r.reconcileEtcdBackup(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	waitForEtcdSnapshot(g, r, kcp)
+
+	res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+	g.Expect(conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition)).To(BeTrue())
+
+	snapshot := kcp.Status.EtcdBackup.LastSuccessfulSnapshot
+	g.Expect(snapshot).ToNot(BeNil())
+	g.Expect(snapshot.Revision).To(Equal(int64(42)))
+	// The oldest snapshot exceeding maxSnapshots is deleted.
+	g.Expect(objectStore.Objects()).To(HaveLen(1))
+	g.Expect(objectStore.Objects()).To(HaveKey("default/foo/" + snapshot.Name))
+
+	// Snapshots are deleted from the object store when the KubeadmControlPlane is deleted.
+	res, err = r.reconcileEtcdBackupDelete(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.IsZero()).To(BeTrue())
+	g.Expect(objectStore.Objects()).To(BeEmpty())
+}
@@ -0,0 +1,573 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEtcdBackup(t *testing.T) {
+	newKCP := func() *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "KubeadmControlPlane",
+				APIVersion: controlplanev1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: metav1.NamespaceDefault,
+				UID:       "kcp-uid",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.31.0",
+				EtcdBackup: &controlplanev1.EtcdBackupPolicy{
+					IntervalSeconds: 3600,
+					MaxSnapshots:    ptr.To[int32](2),
+					Sink:            controlplanev1.EtcdBackupSink{Type: controlplanev1.SecretEtcdBackupSinkType},
+				},
+			},
+		}
+	}
+	setup := func(kcp *controlplanev1.KubeadmControlPlane, workloadCluster *fakeWorkloadCluster, objs ...client.Object) (*KubeadmControlPlaneReconciler, *internal.ControlPlane, client.Client) {
+		fakeClient := newFakeClient(append(objs, kcp.DeepCopy())...)
+		managementCluster := &fakeManagementCluster{
+			Workload: workloadCluster,
+		}
+		r := &KubeadmControlPlaneReconciler{
+			Client:            fakeClient,
+			managementCluster: managementCluster,
+		}
+		controlPlane := &internal.ControlPlane{
+			KCP:     kcp,
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}},
+		}
+		controlPlane.InjectTestManagementCluster(managementCluster)
+		return r, controlPlane, fakeClient
+	}
+	// A random snapshot does not compress, so it is split across multiple Secrets.
+	snapshotData := make([]byte, 2*etcdSnapshotSecretChunkSize)
+	_, err := rand.Read(snapshotData)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	t.Run("does nothing if the etcd backup policy is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.EtcdBackup = nil
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane, _ := setup(kcp, workloadCluster)
+
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(0))
+		g.Expect(kcp.Status.EtcdBackup).To(BeNil())
+	})
+
+	t.Run("does nothing if etcd is external", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{Endpoints: []string{"https://etcd:2379"}}},
+		}
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane, _ := setup(kcp, workloadCluster)
+
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(0))
+	})
+
+	t.Run("requeues if the next snapshot is not due yet", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdBackup = &controlplanev1.EtcdBackupStatus{
+			LastSuccessfulSnapshot: &controlplanev1.EtcdSnapshot{
+				Name:      "foo-etcd-snapshot",
+				Timestamp: metav1.NewTime(time.Now().Add(-30 * time.Minute)),
+			},
+		}
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane, _ := setup(kcp, workloadCluster)
+
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically("~", 30*time.Minute, time.Minute))
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(0))
+	})
+
+	t.Run("takes a snapshot in background and deletes the snapshots exceeding maxSnapshots when it completes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdBackup = &controlplanev1.EtcdBackupStatus{
+			LastSuccessfulSnapshot: &controlplanev1.EtcdSnapshot{
+				Name:      "foo-etcd-20200102000000",
+				Timestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+		}
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdSnapshotData:     snapshotData,
+			EtcdSnapshotRevision: 42,
+		}
+		r, controlPlane, fakeClient := setup(kcp, workloadCluster)
+
+		// Store the snapshots taken before.
+		sink := &secretEtcdSnapshotSink{Client: fakeClient}
+		for _, name := range []string{"foo-etcd-20200101000000", "foo-etcd-20200102000000"} {
+			g.Expect(sink.Save(ctx, kcp, name, strings.NewReader(name), controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)).To(BeNumerically(">", 0))
+		}
+
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdSnapshotProgressInterval))
+		inProgress := kcp.Status.EtcdBackup.SnapshotInProgress
+		g.Expect(inProgress).ToNot(BeNil())
+		g.Expect(inProgress.Name).To(HavePrefix("foo-etcd-"))
+		g.Expect(inProgress.StartTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		g.Expect(kcp.Status.EtcdBackup.LastSuccessfulSnapshot.Name).To(Equal("foo-etcd-20200102000000"))
+
+		waitForEtcdSnapshot(g, r, kcp)
+
+		res, err = r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(1))
+		g.Expect(r.etcdSnapshots.Get(kcp).reported).To(BeTrue())
+		g.Expect(kcp.Status.EtcdBackup.SnapshotInProgress).To(BeNil())
+		g.Expect(conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition)).To(BeTrue())
+
+		snapshot := kcp.Status.EtcdBackup.LastSuccessfulSnapshot
+		g.Expect(snapshot).ToNot(BeNil())
+		g.Expect(snapshot.Name).To(Equal(inProgress.Name))
+		g.Expect(snapshot.Timestamp).To(Equal(inProgress.StartTime))
+		g.Expect(snapshot.Revision).To(Equal(int64(42)))
+		// The size of the snapshot is the size of the compressed snapshot stored in the sink.
+		storedSnapshots, err := sink.List(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(storedSnapshots).To(ContainElement(storedEtcdSnapshot{name: snapshot.Name, complete: true, sizeBytes: snapshot.SizeBytes}))
+
+		g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-20200102000000", snapshot.Name}))
+
+		g.Expect(readEtcdSnapshotSecrets(ctx, g, fakeClient, kcp, snapshot.Name)).To(Equal(snapshotData))
+
+		// The next snapshot is taken after interval.
+		res, err = r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(1))
+	})
+
+	t.Run("reports the progress of the snapshot being taken", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane, _ := setup(kcp, workloadCluster)
+
+		startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		operation := &etcdSnapshotOperation{
+			name:      "foo-etcd-20200101000000",
+			startTime: startTime,
+			done:      make(chan struct{}),
+		}
+		operation.savedBytes.Store(1024)
+		r.etcdSnapshots.Set(kcp, operation)
+
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdSnapshotProgressInterval))
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(0))
+		g.Expect(kcp.Status.EtcdBackup.SnapshotInProgress).To(Equal(&controlplanev1.EtcdSnapshotProgress{
+			Name:       "foo-etcd-20200101000000",
+			StartTime:  startTime,
+			SavedBytes: 1024,
+		}))
+	})
+
+	t.Run("deletes interrupted snapshots and takes a new one", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdBackup = &controlplanev1.EtcdBackupStatus{
+			SnapshotInProgress: &controlplanev1.EtcdSnapshotProgress{
+				Name:      "foo-etcd-20200101000000",
+				StartTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+		}
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdSnapshotData: []byte("snapshot"),
+		}
+		r, controlPlane, fakeClient := setup(kcp, workloadCluster)
+
+		// Store a chunk of the interrupted snapshots; the first chunk is not created until the snapshot completes.
+		// NOTE: The status of the KubeadmControlPlane does not report all the interrupted snapshots, e.g. when
+		// the controller restarted before the status was patched.
+		for _, name := range []string{"foo-etcd-20200101000000", "foo-etcd-20200102000000"} {
+			chunkWriter := &etcdSnapshotChunkWriter{ctx: ctx, client: fakeClient, kcp: kcp, name: name}
+			g.Expect(chunkWriter.createChunk(1, []byte("chunk"), nil)).To(Succeed())
+		}
+		// Store a complete snapshot.
+		sink := &secretEtcdSnapshotSink{Client: fakeClient}
+		g.Expect(sink.Save(ctx, kcp, "foo-etcd-20200103000000", strings.NewReader("snapshot"), controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)).To(BeNumerically(">", 0))
+
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdSnapshotProgressInterval))
+		g.Expect(kcp.Status.EtcdBackup.SnapshotInProgress).ToNot(BeNil())
+		g.Expect(kcp.Status.EtcdBackup.SnapshotInProgress.Name).ToNot(Equal("foo-etcd-20200101000000"))
+
+		waitForEtcdSnapshot(g, r, kcp)
+
+		g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-20200103000000", r.etcdSnapshots.Get(kcp).name}))
+	})
+
+	t.Run("does not store the snapshot if taking it fails", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdSnapshotErr: errors.New("failed to connect to etcd"),
+		}
+		r, controlPlane, fakeClient := setup(kcp, workloadCluster)
+
+		_, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		waitForEtcdSnapshot(g, r, kcp)
+
+		// The failure is surfaced in the EtcdBackupSucceeded condition, and the snapshot is retried after interval.
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(1))
+		g.Expect(kcp.Status.EtcdBackup.SnapshotInProgress).To(BeNil())
+		g.Expect(kcp.Status.EtcdBackup.LastSuccessfulSnapshot).To(BeNil())
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition)
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdBackupFailedReason))
+		g.Expect(condition.Message).To(ContainSubstring("failed to connect to etcd"))
+
+		secrets := &corev1.SecretList{}
+		g.Expect(fakeClient.List(ctx, secrets, client.HasLabels{controlplanev1.EtcdSnapshotLabel})).To(Succeed())
+		g.Expect(secrets.Items).To(BeEmpty())
+	})
+
+	t.Run("deletes the oldest retained snapshots when the new snapshot does not fit into maxTotalSizeBytes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.EtcdBackup.MaxSnapshots = ptr.To[int32](3)
+		kcp.Spec.EtcdBackup.MaxTotalSizeBytes = ptr.To[int64](etcdSnapshotSecretChunkSize)
+		// Random data cannot be compressed, so the new snapshot is bigger than the snapshots taken before.
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdSnapshotData: snapshotData[:etcdSnapshotSecretChunkSize/2],
+		}
+		r, controlPlane, fakeClient := setup(kcp, workloadCluster)
+
+		// Store the snapshots taken before.
+		sink := &secretEtcdSnapshotSink{Client: fakeClient}
+		for _, name := range []string{"foo-etcd-20200101000000", "foo-etcd-20200102000000"} {
+			g.Expect(sink.Save(ctx, kcp, name, bytes.NewReader(snapshotData[:etcdSnapshotSecretChunkSize/4]), controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)).To(BeNumerically(">", 0))
+		}
+
+		// The new snapshot is expected to be as big as the newest snapshot, so no snapshot is deleted, but
+		// the new snapshot does not fit into the space left by the retained snapshots.
+		_, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		waitForEtcdSnapshot(g, r, kcp)
+		_, err = r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(kcp.Status.EtcdBackup.LastSuccessfulSnapshot).To(BeNil())
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition)
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdBackupSizeLimitExceededReason))
+		g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-20200101000000", "foo-etcd-20200102000000"}))
+
+		// When the next snapshot is due, the oldest snapshot is deleted to make room for the new snapshot.
+		r.etcdSnapshots.Get(kcp).startTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		_, err = r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-20200102000000"}))
+		waitForEtcdSnapshot(g, r, kcp)
+		_, err = r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition)).To(BeTrue())
+		snapshot := kcp.Status.EtcdBackup.LastSuccessfulSnapshot
+		g.Expect(snapshot).ToNot(BeNil())
+		g.Expect(snapshot.SizeBytes).To(BeNumerically("<=", etcdSnapshotSecretChunkSize))
+		g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-20200102000000", snapshot.Name}))
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(2))
+	})
+
+	t.Run("does not take a snapshot if a single snapshot exceeds maxTotalSizeBytes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.EtcdBackup.MaxTotalSizeBytes = ptr.To[int64](etcdSnapshotSecretChunkSize)
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane, fakeClient := setup(kcp, workloadCluster)
+
+		sink := &secretEtcdSnapshotSink{Client: fakeClient}
+		g.Expect(sink.Save(ctx, kcp, "foo-etcd-20200101000000", bytes.NewReader(snapshotData), controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)).To(BeNumerically(">", 0))
+
+		res, err := r.reconcileEtcdBackup(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(time.Hour))
+		g.Expect(r.etcdSnapshots.Get(kcp)).To(BeNil())
+		g.Expect(workloadCluster.saveEtcdSnapshotCalled).To(Equal(0))
+		g.Expect(conditions.GetReason(kcp, controlplanev1.KubeadmControlPlaneEtcdBackupSucceededCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdBackupSizeLimitExceededReason))
+		// The snapshot exceeding maxTotalSizeBytes is not deleted, because the new snapshot would not fit anyway.
+		g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-20200101000000"}))
+	})
+}
+
+// etcdSnapshotNames returns the sorted names of the complete snapshots stored by the sink.
+func etcdSnapshotNames(ctx context.Context, g *WithT, sink etcdSnapshotSink, kcp *controlplanev1.KubeadmControlPlane) []string {
+	snapshots, err := sink.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	names := []string{}
+	for _, snapshot := range snapshots {
+		g.Expect(snapshot.complete).To(BeTrue(), "etcd snapshot %s is not complete", snapshot.name)
+		names = append(names, snapshot.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// waitForEtcdSnapshot waits for the etcd snapshot being taken in background for the KubeadmControlPlane to complete.
+func waitForEtcdSnapshot(g *WithT, r *KubeadmControlPlaneReconciler, kcp *controlplanev1.KubeadmControlPlane) {
+	operation := r.etcdSnapshots.Get(kcp)
+	g.Expect(operation).ToNot(BeNil())
+	g.Eventually(operation.Done, 10*time.Second).Should(BeTrue())
+}
+
+func TestSecretEtcdSnapshotSink(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "kcp-uid",
+		},
+	}
+	otherKCP := kcp.DeepCopy()
+	otherKCP.Name = "bar"
+	otherKCP.UID = "other-kcp-uid"
+
+	sink := &secretEtcdSnapshotSink{Client: newFakeClient()}
+
+	snapshotData := make([]byte, 3*etcdSnapshotSecretChunkSize)
+	_, err := rand.Read(snapshotData)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	maxSizeBytes := controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes
+	sizeBytes, err := sink.Save(ctx, kcp, "foo-etcd-1", bytes.NewReader(snapshotData), maxSizeBytes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sink.Save(ctx, kcp, "foo-etcd-2", strings.NewReader("small"), maxSizeBytes)).To(BeNumerically(">", 0))
+	g.Expect(sink.Save(ctx, otherKCP, "bar-etcd-1", strings.NewReader("other"), maxSizeBytes)).To(BeNumerically(">", 0))
+
+	// A snapshot exceeding the maximum size is not stored.
+	_, err = sink.Save(ctx, kcp, "foo-etcd-3", bytes.NewReader(snapshotData), 2*etcdSnapshotSecretChunkSize)
+	g.Expect(errors.Is(err, errEtcdSnapshotSizeLimitExceeded)).To(BeTrue())
+
+	secrets := &corev1.SecretList{}
+	g.Expect(sink.Client.List(ctx, secrets, client.MatchingLabels{controlplanev1.EtcdSnapshotLabel: "foo-etcd-1"})).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(4))
+	compressedSize := int64(0)
+	for _, s := range secrets.Items {
+		g.Expect(s.Labels).ToNot(HaveKey(clusterv1.ClusterNameLabel))
+		g.Expect(s.OwnerReferences).To(BeEmpty())
+		if s.Name == "foo-etcd-1-0" {
+			g.Expect(s.Annotations).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotChunksAnnotation, "4"))
+		} else {
+			g.Expect(s.Annotations).ToNot(HaveKey(controlplanev1.EtcdSnapshotChunksAnnotation))
+		}
+		g.Expect(s.Annotations).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotChunkSizeAnnotation, fmt.Sprint(len(s.Data[bootstrapv1.EtcdSnapshotDataKey]))))
+		compressedSize += int64(len(s.Data[bootstrapv1.EtcdSnapshotDataKey]))
+	}
+	g.Expect(sizeBytes).To(Equal(compressedSize))
+	g.Expect(readEtcdSnapshotSecrets(ctx, g, sink.Client, kcp, "foo-etcd-1")).To(Equal(snapshotData))
+	g.Expect(readEtcdSnapshotSecrets(ctx, g, sink.Client, kcp, "foo-etcd-2")).To(Equal([]byte("small")))
+
+	snapshots, err := sink.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(HaveLen(2))
+	g.Expect(snapshots).To(ContainElement(storedEtcdSnapshot{name: "foo-etcd-1", complete: true, sizeBytes: compressedSize}))
+	g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-1", "foo-etcd-2"}))
+
+	// The size of the snapshots is read from the annotations of the Secrets, or from their data if the annotation is not set.
+	secret := &corev1.Secret{}
+	g.Expect(sink.Client.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: "foo-etcd-1-1"}, secret)).To(Succeed())
+	chunkSize := int64(len(secret.Data[bootstrapv1.EtcdSnapshotDataKey]))
+	secret.Annotations[controlplanev1.EtcdSnapshotChunkSizeAnnotation] = fmt.Sprint(chunkSize + 1)
+	g.Expect(sink.Client.Update(ctx, secret)).To(Succeed())
+	snapshots, err = sink.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(ContainElement(storedEtcdSnapshot{name: "foo-etcd-1", complete: true, sizeBytes: compressedSize + 1}))
+	delete(secret.Annotations, controlplanev1.EtcdSnapshotChunkSizeAnnotation)
+	g.Expect(sink.Client.Update(ctx, secret)).To(Succeed())
+	snapshots, err = sink.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(ContainElement(storedEtcdSnapshot{name: "foo-etcd-1", complete: true, sizeBytes: compressedSize}))
+
+	// A snapshot is not complete until all its Secrets are stored.
+	secret = &corev1.Secret{}
+	g.Expect(sink.Client.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: "foo-etcd-1-2"}, secret)).To(Succeed())
+	g.Expect(sink.Client.Delete(ctx, secret)).To(Succeed())
+	snapshots, err = sink.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(HaveLen(2))
+	for _, snapshot := range snapshots {
+		g.Expect(snapshot.complete).To(Equal(snapshot.name != "foo-etcd-1"))
+	}
+
+	g.Expect(sink.Delete(ctx, kcp, "foo-etcd-1")).To(Succeed())
+	g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-2"}))
+
+	g.Expect(etcdSnapshotNames(ctx, g, sink, otherKCP)).To(Equal([]string{"bar-etcd-1"}))
+}
+
+func TestReconcileEtcdBackupDelete(t *testing.T) {
+	newKCP := func(deletionPolicy controlplanev1.EtcdBackupDeletionPolicy) *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				EtcdBackup: &controlplanev1.EtcdBackupPolicy{
+					IntervalSeconds: 3600,
+					DeletionPolicy:  deletionPolicy,
+					Sink:            controlplanev1.EtcdBackupSink{Type: controlplanev1.SecretEtcdBackupSinkType},
+				},
+			},
+		}
+	}
+	setup := func(g *WithT, kcp *controlplanev1.KubeadmControlPlane) (*KubeadmControlPlaneReconciler, *secretEtcdSnapshotSink) {
+		fakeClient := newFakeClient()
+		sink := &secretEtcdSnapshotSink{Client: fakeClient}
+		g.Expect(sink.Save(ctx, kcp, "foo-etcd-20200101000000", strings.NewReader("snapshot"), controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)).To(BeNumerically(">", 0))
+		chunkWriter := &etcdSnapshotChunkWriter{ctx: ctx, client: fakeClient, kcp: kcp, name: "foo-etcd-20200102000000"}
+		g.Expect(chunkWriter.createChunk(1, []byte("chunk"), nil)).To(Succeed())
+		return &KubeadmControlPlaneReconciler{Client: fakeClient}, sink
+	}
+
+	t.Run("deletes the snapshots", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP("")
+		r, sink := setup(g, kcp)
+
+		res, err := r.reconcileEtcdBackupDelete(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		snapshots, err := sink.List(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(snapshots).To(BeEmpty())
+	})
+
+	t.Run("retains the complete snapshots if deletionPolicy is Retain", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP(controlplanev1.RetainEtcdBackupDeletionPolicy)
+		r, sink := setup(g, kcp)
+
+		res, err := r.reconcileEtcdBackupDelete(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		g.Expect(etcdSnapshotNames(ctx, g, sink, kcp)).To(Equal([]string{"foo-etcd-20200101000000"}))
+	})
+
+	t.Run("waits for the snapshot being taken to complete", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP("")
+		r, sink := setup(g, kcp)
+		operation := &etcdSnapshotOperation{
+			name: "foo-etcd-20200102000000",
+			done: make(chan struct{}),
+		}
+		r.etcdSnapshots.Set(kcp, operation)
+
+		res, err := r.reconcileEtcdBackupDelete(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdSnapshotProgressInterval))
+		snapshots, err := sink.List(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(snapshots).To(HaveLen(2))
+
+		close(operation.done)
+
+		res, err = r.reconcileEtcdBackupDelete(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(r.etcdSnapshots.Get(kcp)).To(BeNil())
+		snapshots, err = sink.List(ctx, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(snapshots).To(BeEmpty())
+	})
+}
+
+// readEtcdSnapshotSecrets reassembles and decompresses a snapshot stored by the secretEtcdSnapshotSink.
+func readEtcdSnapshotSecrets(ctx context.Context, g *WithT, c client.Client, kcp *controlplanev1.KubeadmControlPlane, name string) []byte {
+	chunk := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: name + "-0"}, chunk)).To(Succeed())
+	chunks := chunk.Annotations[controlplanev1.EtcdSnapshotChunksAnnotation]
+
+	compressed := &bytes.Buffer{}
+	for i := 0; fmt.Sprint(i) != chunks; i++ {
+		g.Expect(c.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: fmt.Sprintf("%s-%d", name, i)}, chunk)).To(Succeed())
+		compressed.Write(chunk.Data[bootstrapv1.EtcdSnapshotDataKey])
+	}
+
+	gzipReader, err := gzip.NewReader(compressed)
+	g.Expect(err).ToNot(HaveOccurred())
+	data, err := io.ReadAll(gzipReader)
+	g.Expect(err).ToNot(HaveOccurred())
+	return data
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+const (
+	// etcdDefragmentationRequeueAfter is how long to wait before checking again if an etcd member has to be
+	// defragmented, after a member has been defragmented.
+	etcdDefragmentationRequeueAfter = 20 * time.Second
+
+	// etcdDefragmentationTimeout is the maximum amount of time the defragmentation of an etcd member can take.
+	etcdDefragmentationTimeout = 5 * time.Minute
+
+	// etcdDefragmentationProgressInterval is how often KCP checks if the defragmentation of an etcd member completed.
+	etcdDefragmentationProgressInterval = 10 * time.Second
+
+	// etcdDefragmentationMinInterval is the minimum amount of time between two defragmentations of the same etcd member.
+	// NOTE: This prevents from defragmenting a member over and over, e.g. when the NOSPACE alarm is raised again
+	// because the database of the member is full of data in use.
+	etcdDefragmentationMinInterval = 1 * time.Hour
+)
+
+// etcdDefragmentationTracker tracks the defragmentations of etcd members running in background, by KubeadmControlPlane.
+// NOTE: The zero value is ready to use.
+type etcdDefragmentationTracker struct {
+	lock             sync.Mutex
+	defragmentations map[types.NamespacedName]*etcdDefragmentationOperation
+}
+
+// Get returns the defragmentation running for the KubeadmControlPlane, if any.
+func (t *etcdDefragmentationTracker) Get(kcp *controlplanev1.KubeadmControlPlane) *etcdDefragmentationOperation {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.defragmentations[client.ObjectKeyFromObject(kcp)]
+}
+
+// Set tracks a defragmentation running for the KubeadmControlPlane.
+func (t *etcdDefragmentationTracker) Set(kcp *controlplanev1.KubeadmControlPlane, operation *etcdDefragmentationOperation) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.defragmentations == nil {
+		t.defragmentations = map[types.NamespacedName]*etcdDefragmentationOperation{}
+	}
+	t.defragmentations[client.ObjectKeyFromObject(kcp)] = operation
+}
+
+// Delete stops tracking the defragmentation running for the KubeadmControlPlane.
+func (t *etcdDefragmentationTracker) Delete(kcp *controlplanev1.KubeadmControlPlane) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.defragmentations, client.ObjectKeyFromObject(kcp))
+}
+
+// etcdDefragmentationOperation is the defragmentation of an etcd member running in background.
+type etcdDefragmentationOperation struct {
+	nodeName string
+
+	// done is closed when the defragmentation completes; err must be read only after done is closed.
+	done chan struct{}
+	err  error
+}
+
+// Done returns true if the defragmentation completed, either successfully or not.
+func (o *etcdDefragmentationOperation) Done() bool {
+	select {
+	case <-o.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitForRunningEtcdDefragmentation returns a non-zero result while the defragmentation of an etcd member is running
+// in background, so operations which might lead to lose etcd quorum, like scale down or rollout, are deferred.
+func (r *KubeadmControlPlaneReconciler) waitForRunningEtcdDefragmentation(ctx context.Context, controlPlane *internal.ControlPlane) ctrl.Result {
+	operation := r.etcdDefragmentations.Get(controlPlane.KCP)
+	if operation == nil || operation.Done() {
+		return ctrl.Result{}
+	}
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Waiting for the defragmentation of etcd member hosted on Node %s to complete", operation.nodeName))
+	return ctrl.Result{RequeueAfter: etcdDefragmentationProgressInterval}
+}
+
+// reconcileEtcdDefragmentation reports the size of the database of the etcd members, and it defragments
+// one etcd member at a time when required by the etcd defragmentation policy.
+// Defragmentations run in background, so they do not block other KCP operations while the member is defragmented.
+// NOTE: This func is expected to be called only when the control plane is stable, i.e. no rollout, scale up,
+// scale down or remediation in progress.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdDefragmentation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	// Wait for the running defragmentation to complete, and record its outcome, before starting another one;
+	// this also applies if the etcd defragmentation policy has been removed in the meantime.
+	if operation := r.etcdDefragmentations.Get(kcp); operation != nil {
+		if !operation.Done() {
+			log.V(4).Info(fmt.Sprintf("Waiting for the defragmentation of etcd member hosted on Node %s to complete", operation.nodeName))
+			return ctrl.Result{RequeueAfter: etcdDefragmentationProgressInterval}, nil
+		}
+
+		r.etcdDefragmentations.Delete(kcp)
+		if operation.err != nil {
+			r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdDefragmentation", "Failed to defragment etcd member hosted on Node %s: %v", operation.nodeName, operation.err)
+			return ctrl.Result{}, errors.Wrapf(operation.err, "failed to defragment etcd member hosted on Node %s", operation.nodeName)
+		}
+		log.Info(fmt.Sprintf("Defragmented etcd member hosted on Node %s", operation.nodeName))
+		for i := range kcp.Status.EtcdMembers {
+			if kcp.Status.EtcdMembers[i].Name == operation.nodeName {
+				kcp.Status.EtcdMembers[i].LastDefragmentationTime = ptr.To(metav1.Now())
+			}
+		}
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+
+	policy := kcp.Spec.EtcdDefragmentation
+	if policy == nil || !controlPlane.IsEtcdManaged() {
+		kcp.Status.EtcdMembers = nil
+		return ctrl.Result{}, nil
+	}
+
+	// A member does not serve requests while it is being defragmented, so members are defragmented only if
+	// all the Machines are hosting a member of the etcd cluster; otherwise defragmenting a member might lead
+	// to lose quorum.
+	machines := controlPlane.Machines.Filter(collections.HasNode(), collections.Not(collections.HasDeletionTimestamp))
+	if len(machines) != len(controlPlane.Machines) || !controlPlane.EtcdMembersAndMachinesAreMatching {
+		log.V(4).Info("Skipping etcd defragmentation, etcd members and Machines are not matching")
+		return ctrl.Result{}, nil
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create client to workload cluster")
+	}
+
+	previousMembers := map[string]controlplanev1.EtcdMemberStatus{}
+	for _, member := range kcp.Status.EtcdMembers {
+		previousMembers[member.Name] = member
+	}
+
+	nodeNames := make([]string, 0, len(machines))
+	for _, machine := range machines {
+		nodeNames = append(nodeNames, machine.Status.NodeRef.Name)
+	}
+	sort.Strings(nodeNames)
+
+	memberStatuses := map[string]*etcd.MemberStatus{}
+	members := make([]controlplanev1.EtcdMemberStatus, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		memberStatus, err := workloadCluster.GetEtcdMemberStatus(ctx, nodeName)
+		if err != nil {
+			// A member which cannot be reached must not block the defragmentation of the other members, e.g. of
+			// a member which raised a NOSPACE alarm; the last known status of the member is preserved, but the member
+			// is not defragmented.
+			log.Error(err, fmt.Sprintf("Failed to get the status of etcd member hosted on Node %s, skipping it", nodeName))
+			if previousMember, ok := previousMembers[nodeName]; ok {
+				members = append(members, previousMember)
+			}
+			continue
+		}
+		memberStatuses[nodeName] = memberStatus
+		members = append(members, controlplanev1.EtcdMemberStatus{
+			Name:                    nodeName,
+			DBSizeBytes:             memberStatus.DBSize,
+			DBSizeInUseBytes:        memberStatus.DBSizeInUse,
+			LastDefragmentationTime: previousMembers[nodeName].LastDefragmentationTime,
+		})
+	}
+	kcp.Status.EtcdMembers = members
+
+	nodeName, reason := etcdMemberToDefragment(policy, members, memberStatuses, controlPlane.EtcdAlarms, time.Now())
+	if nodeName == "" {
+		return ctrl.Result{}, nil
+	}
+
+	operation := &etcdDefragmentationOperation{
+		nodeName: nodeName,
+		done:     make(chan struct{}),
+	}
+	r.etcdDefragmentations.Set(kcp, operation)
+	go func() {
+		defer close(operation.done)
+		// The defragmentation must not be canceled when the reconcile completes.
+		ctx, cancel := context.WithTimeoutCause(context.WithoutCancel(ctx), etcdDefragmentationTimeout, errors.New("etcd defragmentation timeout expired"))
+		defer cancel()
+		operation.err = workloadCluster.DefragmentEtcdMember(ctx, nodeName)
+	}()
+	log.Info(fmt.Sprintf("Defragmenting etcd member hosted on Node %s: %s", nodeName, reason))
+
+	return ctrl.Result{RequeueAfter: etcdDefragmentationProgressInterval}, nil
+}
+
+// etcdMemberToDefragment returns the name of the Node hosting the etcd member to be defragmented, if any, and
+// the reason why it has to be defragmented.
+// Members which raised a NOSPACE alarm are defragmented first; then members exceeding the fragmentation threshold.
+// In both cases followers are defragmented before the leader, so leadership changes are minimized.
+func etcdMemberToDefragment(policy *controlplanev1.EtcdDefragmentationPolicy, members []controlplanev1.EtcdMemberStatus, memberStatuses map[string]*etcd.MemberStatus, alarms []etcd.MemberAlarm, now time.Time) (string, string) {
+	noSpaceAlarms := map[uint64]bool{}
+	for _, alarm := range alarms {
+		if alarm.Type == etcd.AlarmNoSpace {
+			noSpaceAlarms[alarm.MemberID] = true
+		}
+	}
+
+	var candidates []string
+	reasons := map[string]string{}
+	for _, member := range members {
+		memberStatus, ok := memberStatuses[member.Name]
+		if !ok {
+			continue
+		}
+		if member.LastDefragmentationTime != nil && now.Sub(member.LastDefragmentationTime.Time) < etcdDefragmentationMinInterval {
+			continue
+		}
+
+		if noSpaceAlarms[memberStatus.MemberID] {
+			candidates = append(candidates, member.Name)
+			reasons[member.Name] = "member raised a NOSPACE alarm"
+			continue
+		}
+
+		if member.DBSizeBytes <= 0 || member.DBSizeBytes < ptr.Deref(policy.MinDBSizeBytes, 0) {
+			continue
+		}
+		if (member.DBSizeBytes-member.DBSizeInUseBytes)*100 > int64(policy.FragmentationThresholdPercent)*member.DBSizeBytes {
+			candidates = append(candidates, member.Name)
+			reasons[member.Name] = fmt.Sprintf("%d bytes of %d bytes of the database are not in use", member.DBSizeBytes-member.DBSizeInUseBytes, member.DBSizeBytes)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		iAlarm := noSpaceAlarms[memberStatuses[candidates[i]].MemberID]
+		jAlarm := noSpaceAlarms[memberStatuses[candidates[j]].MemberID]
+		if iAlarm != jAlarm {
+			return iAlarm
+		}
+		return !memberStatuses[candidates[i]].IsLeader && memberStatuses[candidates[j]].IsLeader
+	})
+	return candidates[0], reasons[candidates[0]]
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+func TestReconcileEtcdDefragmentation(t *testing.T) {
+	newKCP := func() *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.31.0",
+				EtcdDefragmentation: &controlplanev1.EtcdDefragmentationPolicy{
+					FragmentationThresholdPercent: 50,
+				},
+			},
+		}
+	}
+	newMachine := func(name string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Status: clusterv1.MachineStatus{
+				NodeRef: &clusterv1.MachineNodeReference{Name: name},
+			},
+		}
+	}
+	setup := func(kcp *controlplanev1.KubeadmControlPlane, workloadCluster *fakeWorkloadCluster, machines ...*clusterv1.Machine) (*KubeadmControlPlaneReconciler, *internal.ControlPlane) {
+		managementCluster := &fakeManagementCluster{
+			Workload: workloadCluster,
+		}
+		r := &KubeadmControlPlaneReconciler{
+			managementCluster: managementCluster,
+			recorder:          record.NewFakeRecorder(32),
+		}
+		controlPlane := &internal.ControlPlane{
+			KCP:                               kcp,
+			Cluster:                           &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}},
+			Machines:                          collections.FromMachines(machines...),
+			EtcdMembersAndMachinesAreMatching: true,
+		}
+		controlPlane.InjectTestManagementCluster(managementCluster)
+		return r, controlPlane
+	}
+
+	t.Run("does nothing and removes the status of the members if the etcd defragmentation policy is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.EtcdDefragmentation = nil
+		kcp.Status.EtcdMembers = []controlplanev1.EtcdMemberStatus{{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 10}}
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"))
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+		g.Expect(kcp.Status.EtcdMembers).To(BeNil())
+	})
+
+	t.Run("does nothing if etcd is external", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{Endpoints: []string{"https://etcd:2379"}}},
+		}
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"))
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+		g.Expect(kcp.Status.EtcdMembers).To(BeNil())
+	})
+
+	t.Run("does nothing if etcd members and Machines are not matching", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"))
+		controlPlane.EtcdMembersAndMachinesAreMatching = false
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+	})
+
+	t.Run("does nothing if a Machine does not have a Node yet", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{}
+		provisioning := newMachine("m2")
+		provisioning.Status.NodeRef = nil
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"), provisioning)
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+	})
+
+	t.Run("skips members whose status cannot be read and defragments the others", func(t *testing.T) {
+		g := NewWithT(t)
+
+		lastDefragmentationTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		kcp := newKCP()
+		kcp.Status.EtcdMembers = []controlplanev1.EtcdMemberStatus{
+			{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 10, LastDefragmentationTime: &lastDefragmentationTime},
+		}
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberStatuses: map[string]*etcd.MemberStatus{
+				"m2": {MemberID: 2, DBSize: 100, DBSizeInUse: 10},
+			},
+		}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"), newMachine("m2"))
+		controlPlane.EtcdAlarms = []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}}
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdDefragmentationProgressInterval))
+		// The last known status of the member which cannot be reached is preserved.
+		g.Expect(kcp.Status.EtcdMembers).To(Equal([]controlplanev1.EtcdMemberStatus{
+			{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 10, LastDefragmentationTime: &lastDefragmentationTime},
+			{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 10},
+		}))
+
+		waitForEtcdDefragmentation(g, r, kcp)
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{"m2"}))
+	})
+
+	t.Run("reports the status of the members and does not defragment members below the threshold", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberStatuses: map[string]*etcd.MemberStatus{
+				"m1": {MemberID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 80},
+				"m2": {MemberID: 2, DBSize: 100, DBSizeInUse: 60},
+			},
+		}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m2"), newMachine("m1"))
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+		g.Expect(r.etcdDefragmentations.Get(kcp)).To(BeNil())
+		g.Expect(kcp.Status.EtcdMembers).To(Equal([]controlplanev1.EtcdMemberStatus{
+			{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 80},
+			{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 60},
+		}))
+	})
+
+	t.Run("defragments one member at a time in background and requeues", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberStatuses: map[string]*etcd.MemberStatus{
+				"m1": {MemberID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 10},
+				"m2": {MemberID: 2, DBSize: 100, DBSizeInUse: 10},
+			},
+		}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"), newMachine("m2"))
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdDefragmentationProgressInterval))
+		waitForEtcdDefragmentation(g, r, kcp)
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{"m2"}))
+		g.Expect(kcp.Status.EtcdMembers).To(HaveLen(2))
+		g.Expect(kcp.Status.EtcdMembers[1].LastDefragmentationTime).To(BeNil())
+
+		// The next reconcile records the outcome of the defragmentation and requeues.
+		res, err = r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+		g.Expect(r.etcdDefragmentations.Get(kcp)).To(BeNil())
+		g.Expect(kcp.Status.EtcdMembers[0].LastDefragmentationTime).To(BeNil())
+		g.Expect(kcp.Status.EtcdMembers[1].LastDefragmentationTime).ToNot(BeNil())
+
+		// The next reconcile defragments the other member, and it preserves the last defragmentation time.
+		res, err = r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdDefragmentationProgressInterval))
+		waitForEtcdDefragmentation(g, r, kcp)
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{"m2", "m1"}))
+
+		res, err = r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+		g.Expect(kcp.Status.EtcdMembers[0].LastDefragmentationTime).ToNot(BeNil())
+		g.Expect(kcp.Status.EtcdMembers[1].LastDefragmentationTime).ToNot(BeNil())
+	})
+
+	t.Run("waits for the running defragmentation to complete", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberStatuses: map[string]*etcd.MemberStatus{
+				"m1": {MemberID: 1, DBSize: 100, DBSizeInUse: 10},
+			},
+		}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"))
+		operation := &etcdDefragmentationOperation{nodeName: "m1", done: make(chan struct{})}
+		r.etcdDefragmentations.Set(kcp, operation)
+
+		res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdDefragmentationProgressInterval))
+		g.Expect(r.etcdDefragmentations.Get(kcp)).To(Equal(operation))
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+	})
+
+	t.Run("returns an error if it fails to defragment a member", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberStatuses: map[string]*etcd.MemberStatus{
+				"m1": {MemberID: 1, DBSize: 100, DBSizeInUse: 10},
+			},
+			EtcdDefragmentationErr: errors.New("failed to defragment"),
+		}
+		r, controlPlane := setup(kcp, workloadCluster, newMachine("m1"))
+
+		_, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		waitForEtcdDefragmentation(g, r, kcp)
+
+		_, err = r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(r.etcdDefragmentations.Get(kcp)).To(BeNil())
+		g.Expect(kcp.Status.EtcdMembers).To(HaveLen(1))
+		g.Expect(kcp.Status.EtcdMembers[0].LastDefragmentationTime).To(BeNil())
+	})
+}
+
+func TestWaitForRunningEtcdDefragmentation(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := &controlplanev1.KubeadmControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}}
+	r := &KubeadmControlPlaneReconciler{}
+	controlPlane := &internal.ControlPlane{KCP: kcp}
+
+	// No defragmentation running.
+	g.Expect(r.waitForRunningEtcdDefragmentation(ctx, controlPlane)).To(Equal(ctrl.Result{}))
+
+	// Defragmentation running.
+	operation := &etcdDefragmentationOperation{nodeName: "m1", done: make(chan struct{})}
+	r.etcdDefragmentations.Set(kcp, operation)
+	g.Expect(r.waitForRunningEtcdDefragmentation(ctx, controlPlane).RequeueAfter).To(Equal(etcdDefragmentationProgressInterval))
+
+	// Defragmentation completed, but its outcome not yet recorded.
+	close(operation.done)
+	g.Expect(r.waitForRunningEtcdDefragmentation(ctx, controlPlane)).To(Equal(ctrl.Result{}))
+}
+
+// waitForEtcdDefragmentation waits for the defragmentation running in background for the KubeadmControlPlane to complete.
+func waitForEtcdDefragmentation(g *WithT, r *KubeadmControlPlaneReconciler, kcp *controlplanev1.KubeadmControlPlane) {
+	operation := r.etcdDefragmentations.Get(kcp)
+	g.Expect(operation).ToNot(BeNil())
+	g.Eventually(operation.Done, 10*time.Second).Should(BeTrue())
+}
+
+func TestEtcdMemberToDefragment(t *testing.T) {
+	now := time.Now()
+	recently := &metav1.Time{Time: now.Add(-10 * time.Minute)}
+	longAgo := &metav1.Time{Time: now.Add(-2 * etcdDefragmentationMinInterval)}
+
+	memberStatuses := map[string]*etcd.MemberStatus{
+		"m1": {MemberID: 1, IsLeader: true},
+		"m2": {MemberID: 2},
+		"m3": {MemberID: 3},
+	}
+
+	tests := []struct {
+		name             string
+		policy           *controlplanev1.EtcdDefragmentationPolicy
+		members          []controlplanev1.EtcdMemberStatus
+		alarms           []etcd.MemberAlarm
+		expectedNodeName string
+	}{
+		{
+			name:   "no member exceeds the threshold",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 50},
+				{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 100},
+				{Name: "m3", DBSizeBytes: 0, DBSizeInUseBytes: 0},
+			},
+			expectedNodeName: "",
+		},
+		{
+			name:   "a member exceeds the threshold",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 50},
+				{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 100},
+				{Name: "m3", DBSizeBytes: 100, DBSizeInUseBytes: 49},
+			},
+			expectedNodeName: "m3",
+		},
+		{
+			name:   "followers are defragmented before the leader",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 10},
+				{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 10},
+				{Name: "m3", DBSizeBytes: 100, DBSizeInUseBytes: 10},
+			},
+			expectedNodeName: "m2",
+		},
+		{
+			name:   "the leader is defragmented if it is the only member exceeding the threshold",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 10},
+				{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 100},
+				{Name: "m3", DBSizeBytes: 100, DBSizeInUseBytes: 100},
+			},
+			expectedNodeName: "m1",
+		},
+		{
+			name:   "members smaller than minDBSizeBytes are not defragmented",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50, MinDBSizeBytes: ptr.To[int64](1000)},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 1000, DBSizeInUseBytes: 10},
+				{Name: "m2", DBSizeBytes: 999, DBSizeInUseBytes: 10},
+				{Name: "m3", DBSizeBytes: 100, DBSizeInUseBytes: 10},
+			},
+			expectedNodeName: "m1",
+		},
+		{
+			name:   "members recently defragmented are not defragmented again",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 10, LastDefragmentationTime: longAgo},
+				{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 10, LastDefragmentationTime: recently},
+				{Name: "m3", DBSizeBytes: 100, DBSizeInUseBytes: 10, LastDefragmentationTime: recently},
+			},
+			expectedNodeName: "m1",
+		},
+		{
+			name:   "members with a NOSPACE alarm are defragmented first, regardless of their database size",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50, MinDBSizeBytes: ptr.To[int64](1000)},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 100},
+				{Name: "m2", DBSizeBytes: 1000, DBSizeInUseBytes: 10},
+				{Name: "m3", DBSizeBytes: 1000, DBSizeInUseBytes: 10},
+			},
+			alarms: []etcd.MemberAlarm{
+				{MemberID: 2, Type: etcd.AlarmCorrupt},
+				{MemberID: 1, Type: etcd.AlarmNoSpace},
+			},
+			expectedNodeName: "m1",
+		},
+		{
+			name:   "members with a NOSPACE alarm recently defragmented are not defragmented again",
+			policy: &controlplanev1.EtcdDefragmentationPolicy{FragmentationThresholdPercent: 50},
+			members: []controlplanev1.EtcdMemberStatus{
+				{Name: "m1", DBSizeBytes: 100, DBSizeInUseBytes: 100, LastDefragmentationTime: recently},
+				{Name: "m2", DBSizeBytes: 100, DBSizeInUseBytes: 100},
+				{Name: "m3", DBSizeBytes: 100, DBSizeInUseBytes: 100},
+			},
+			alarms: []etcd.MemberAlarm{
+				{MemberID: 1, Type: etcd.AlarmNoSpace},
+			},
+			expectedNodeName: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			nodeName, reason := etcdMemberToDefragment(tt.policy, tt.members, memberStatuses, tt.alarms, now)
+			g.Expect(nodeName).To(Equal(tt.expectedNodeName))
+			if tt.expectedNodeName != "" {
+				g.Expect(reason).ToNot(BeEmpty())
+			}
+		})
+	}
+}
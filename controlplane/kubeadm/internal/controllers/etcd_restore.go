@@ -0,0 +1,358 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	clog "sigs.k8s.io/cluster-api/util/log"
+)
+
+const (
+	// etcdRestoreRequeueAfter is how long to wait before checking again the progress of a restore of etcd.
+	etcdRestoreRequeueAfter = 20 * time.Second
+)
+
+// reconcileEtcdRestore drives the restore of the etcd cluster from a snapshot. A restore deletes all the control plane
+// Machines, then it creates a single Machine restoring the etcd data directory from the snapshot before running
+// kubeadm init, and finally it lets KCP scale up the control plane to the desired number of replicas.
+// NOTE: While Machines are being deleted and the etcd cluster is being restored, the other KCP operations are blocked,
+// because they all require a working etcd cluster.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdRestore(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	restore := kcp.Status.EtcdRestore
+	if restore == nil || restore.Phase == controlplanev1.EtcdRestoreCompletedPhase {
+		if !shouldStartEtcdRestore(kcp, time.Now()) {
+			setEtcdNotRestoringCondition(kcp, "")
+			return ctrl.Result{}, nil
+		}
+
+		if !controlPlane.IsEtcdManaged() {
+			setEtcdNotRestoringCondition(kcp, "Restoring etcd is not supported when using an external etcd")
+			return ctrl.Result{}, nil
+		}
+
+		if kcp.Status.Initialization == nil || !kcp.Status.Initialization.ControlPlaneInitialized {
+			setEtcdNotRestoringCondition(kcp, "Waiting for the control plane to be initialized")
+			return ctrl.Result{}, nil
+		}
+
+		if rotation := kcp.Status.CertificateAuthorityRotation; rotation != nil && rotation.Phase != controlplanev1.CertificateAuthorityRotationCompletedPhase {
+			setEtcdNotRestoringCondition(kcp, "Waiting for the rotation of certificate authorities to complete")
+			return ctrl.Result{}, nil
+		}
+
+		// To prevent data loss, etcd is restored only if it is known to be not healthy, e.g. because it lost quorum;
+		// in particular, etcd is not restored when its health is unknown, e.g. because KCP cannot connect to the workload cluster.
+		if !conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition) {
+			setEtcdNotRestoringCondition(kcp, "Restoring etcd is allowed only when the etcd cluster is not healthy")
+			return ctrl.Result{}, nil
+		}
+
+		// Snapshots downloaded from a URL are verified by the Machine restoring etcd.
+		if kcp.Spec.EtcdRestore.SnapshotURL == nil {
+			problem, err := r.etcdSnapshotForRestoreProblem(ctx, kcp, kcp.Spec.EtcdRestore.SnapshotName)
+			if err != nil {
+				setEtcdRestoringInternalErrorCondition(kcp)
+				return ctrl.Result{}, err
+			}
+			if problem != "" {
+				setEtcdNotRestoringCondition(kcp, problem)
+				return ctrl.Result{}, nil
+			}
+		}
+
+		log.Info(fmt.Sprintf("Starting restore of etcd from snapshot %s", kcp.Spec.EtcdRestore.SnapshotName))
+		kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+			SnapshotName: kcp.Spec.EtcdRestore.SnapshotName,
+			Phase:        controlplanev1.EtcdRestoreDeletingMachinesPhase,
+			StartTime:    metav1.Now(),
+		}
+		restore = kcp.Status.EtcdRestore
+	}
+
+	if restore.Phase == controlplanev1.EtcdRestoreDeletingMachinesPhase {
+		if err := r.deleteMachinesForEtcdRestore(ctx, controlPlane); err != nil {
+			setEtcdRestoringInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		if len(controlPlane.Machines) > 0 {
+			setEtcdRestoringCondition(kcp, fmt.Sprintf("waiting for Machines to be deleted: %s", clog.StringListToString(controlPlane.Machines.Names())))
+			return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+		}
+		restore.Phase = controlplanev1.EtcdRestoreRestoringPhase
+	}
+
+	if restore.Phase == controlplanev1.EtcdRestoreRestoringPhase {
+		if len(controlPlane.Machines) == 0 {
+			return r.createMachineForEtcdRestore(ctx, controlPlane)
+		}
+
+		// If the Machine restoring etcd is deleted, e.g. because it failed, wait for it to go away and create a new one.
+		for _, machine := range controlPlane.Machines.Filter(collections.HasDeletionTimestamp) {
+			if err := r.prepareMachineForDeletionDuringEtcdRestore(ctx, machine); err != nil {
+				setEtcdRestoringInternalErrorCondition(kcp)
+				return ctrl.Result{}, err
+			}
+		}
+		machines := controlPlane.Machines.Filter(collections.Not(collections.HasDeletionTimestamp))
+		if len(machines) == 0 {
+			setEtcdRestoringCondition(kcp, fmt.Sprintf("waiting for Machines to be deleted: %s", clog.StringListToString(controlPlane.Machines.Names())))
+			return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+		}
+
+		machine := machines.Oldest()
+		if machine.Status.NodeRef == nil {
+			setEtcdRestoringCondition(kcp, fmt.Sprintf("waiting for Machine %s to restore etcd", machine.Name))
+			return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+		}
+
+		// The snapshot contains the Nodes of the control plane Machines deleted in the previous phase; those Nodes
+		// must be deleted, otherwise they are reported as control plane Nodes without a corresponding Machine.
+		workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+		if err != nil {
+			setEtcdRestoringInternalErrorCondition(kcp)
+			return ctrl.Result{}, errors.Wrap(err, "failed to create client to workload cluster")
+		}
+		deletedNodes, err := workloadCluster.DeleteControlPlaneNodesNotIn(ctx, []string{machine.Status.NodeRef.Name})
+		if err != nil {
+			setEtcdRestoringInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		if len(deletedNodes) > 0 {
+			log.Info(fmt.Sprintf("Deleted control plane Nodes restored from the etcd snapshot: %s", clog.StringListToString(deletedNodes)))
+		}
+
+		// The Machine already downloaded the snapshot, so the snapshot must not be served anymore.
+		if err := r.deleteEtcdRestoreToken(ctx, kcp); err != nil {
+			setEtcdRestoringInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		restore.Phase = controlplanev1.EtcdRestoreScalingUpPhase
+	}
+
+	// Scaling up to the desired number of replicas is performed by the usual KCP operations; the restore is completed
+	// when all the desired Machines have a Node and the etcd cluster is healthy.
+	machines := controlPlane.Machines.Filter(collections.Not(collections.HasDeletionTimestamp))
+	if len(machines) != int(*kcp.Spec.Replicas) || len(machines.Filter(collections.HasNode())) != len(machines) ||
+		!conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition) {
+		setEtcdRestoringCondition(kcp, fmt.Sprintf("waiting for the control plane to be scaled up to %d replicas and etcd to be healthy", *kcp.Spec.Replicas))
+		return ctrl.Result{}, nil
+	}
+
+	log.Info(fmt.Sprintf("Restore of etcd from snapshot %s completed", restore.SnapshotName))
+	restore.Phase = controlplanev1.EtcdRestoreCompletedPhase
+	restore.CompletionTime = ptr.To(metav1.Now())
+	setEtcdNotRestoringCondition(kcp, "")
+	return ctrl.Result{}, nil
+}
+
+// shouldStartEtcdRestore returns true if spec.etcdRestore.restoreAfter expired and no restore started after it.
+func shouldStartEtcdRestore(kcp *controlplanev1.KubeadmControlPlane, now time.Time) bool {
+	if kcp.Spec.EtcdRestore == nil {
+		return false
+	}
+	restoreAfter := kcp.Spec.EtcdRestore.RestoreAfter
+	if !restoreAfter.Time.Before(now) {
+		return false
+	}
+	restore := kcp.Status.EtcdRestore
+	return restore == nil || restore.StartTime.Before(&restoreAfter)
+}
+
+// etcdRestoreSnapshotURL returns the URL of the snapshot being restored, if the snapshot is not stored by KCP.
+func etcdRestoreSnapshotURL(kcp *controlplanev1.KubeadmControlPlane) *bootstrapv1.EtcdSnapshotURL {
+	if kcp.Spec.EtcdRestore == nil || kcp.Status.EtcdRestore == nil || kcp.Spec.EtcdRestore.SnapshotName != kcp.Status.EtcdRestore.SnapshotName {
+		return nil
+	}
+	return kcp.Spec.EtcdRestore.SnapshotURL.DeepCopy()
+}
+
+// etcdSnapshotForRestoreProblem returns a message describing why a snapshot stored by KCP cannot be restored, if any.
+// NOTE: Only the metadata of the snapshot is read; the snapshot is read when creating the Machine restoring etcd.
+func (r *KubeadmControlPlaneReconciler) etcdSnapshotForRestoreProblem(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshotName string) (string, error) {
+	if r.EtcdSnapshotServerURL == "" {
+		return etcdSnapshotServerDisabledMessage, nil
+	}
+
+	sink, err := r.etcdSnapshotSink(ctx, kcp)
+	if err != nil {
+		return "", err
+	}
+	snapshots, err := sink.List(ctx, kcp)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list etcd snapshots")
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.name != snapshotName {
+			continue
+		}
+		if !snapshot.complete {
+			return fmt.Sprintf("etcd snapshot %s is not completely stored", snapshotName), nil
+		}
+		return "", nil
+	}
+	return fmt.Sprintf("etcd snapshot %s does not exist", snapshotName), nil
+}
+
+// deleteMachinesForEtcdRestore deletes all the control plane Machines.
+func (r *KubeadmControlPlaneReconciler) deleteMachinesForEtcdRestore(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, machine := range controlPlane.Machines {
+		if err := r.prepareMachineForDeletionDuringEtcdRestore(ctx, machine); err != nil {
+			return err
+		}
+
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Client.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete control plane Machine %s", klog.KObj(machine))
+		}
+		// Note: We intentionally log after Delete because we want this log line to show up only after DeletionTimestamp has been set.
+		log.WithValues(controlPlane.StatusToLogKeyAndValues(nil, machine)...).
+			Info("Deleting Machine (etcd restore)")
+	}
+	return nil
+}
+
+// prepareMachineForDeletionDuringEtcdRestore ensures a control plane Machine can be deleted while etcd is restored.
+// NOTE: Nodes are not drained, volumes are not waited to be detached and etcd members are not removed (by removing
+// the KCP pre-terminate hook), because all those operations require a working control plane.
+func (r *KubeadmControlPlaneReconciler) prepareMachineForDeletionDuringEtcdRestore(ctx context.Context, machine *clusterv1.Machine) error {
+	_, hasHook := machine.Annotations[controlplanev1.PreTerminateHookCleanupAnnotation]
+	_, excludeDraining := machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]
+	_, excludeWaitForVolumeDetach := machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation]
+	if !hasHook && excludeDraining && excludeWaitForVolumeDetach {
+		return nil
+	}
+
+	machineOriginal := machine.DeepCopy()
+	if machine.Annotations == nil {
+		machine.Annotations = map[string]string{}
+	}
+	delete(machine.Annotations, controlplanev1.PreTerminateHookCleanupAnnotation)
+	machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation] = ""
+	machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation] = ""
+	if err := r.Client.Patch(ctx, machine, client.MergeFrom(machineOriginal)); err != nil {
+		return errors.Wrapf(err, "failed to prepare control plane Machine %s for deletion", klog.KObj(machine))
+	}
+	return nil
+}
+
+// createMachineForEtcdRestore creates the control plane Machine restoring the etcd data directory from the snapshot.
+func (r *KubeadmControlPlaneReconciler) createMachineForEtcdRestore(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	// Snapshots stored by KCP are downloaded by the Machine from the etcd snapshot server, so they are never
+	// embedded into the bootstrap data.
+	snapshotURL := etcdRestoreSnapshotURL(kcp)
+	if snapshotURL == nil {
+		var problem string
+		var err error
+		snapshotURL, problem, err = r.etcdSnapshotServerURLForRestore(ctx, controlPlane)
+		if err != nil {
+			setEtcdRestoringInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		if problem != "" {
+			setEtcdRestoringCondition(kcp, problem)
+			return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+		}
+	}
+
+	bootstrapSpec := controlPlane.InitialControlPlaneConfig()
+	bootstrapSpec.EtcdRestore = &bootstrapv1.EtcdRestoreSource{SnapshotURL: snapshotURL}
+
+	parsedVersion, err := semver.ParseTolerant(kcp.Spec.Version)
+	if err != nil {
+		setEtcdRestoringInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", kcp.Spec.Version)
+	}
+	internal.DefaultFeatureGates(bootstrapSpec, parsedVersion)
+
+	fd, err := controlPlane.NextFailureDomainForScaleUp(ctx)
+	if err != nil {
+		setEtcdRestoringInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+
+	newMachine, err := r.cloneConfigsAndGenerateMachine(ctx, controlPlane.Cluster, kcp, bootstrapSpec, fd)
+	if err != nil {
+		setEtcdRestoringInternalErrorCondition(kcp)
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdRestore", "Failed to create control plane Machine restoring etcd for cluster %s control plane: %v", klog.KObj(controlPlane.Cluster), err)
+		return ctrl.Result{}, errors.Wrap(err, "failed to create control plane Machine restoring etcd")
+	}
+
+	log.WithValues(controlPlane.StatusToLogKeyAndValues(newMachine, nil)...).
+		Info("Machine created (etcd restore)",
+			"Machine", klog.KObj(newMachine),
+			newMachine.Spec.InfrastructureRef.Kind, klog.KRef(newMachine.Namespace, newMachine.Spec.InfrastructureRef.Name),
+			newMachine.Spec.Bootstrap.ConfigRef.Kind, klog.KRef(newMachine.Namespace, newMachine.Spec.Bootstrap.ConfigRef.Name))
+
+	setEtcdRestoringCondition(kcp, fmt.Sprintf("waiting for Machine %s to restore etcd", newMachine.Name))
+	return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+}
+
+func setEtcdRestoringCondition(kcp *controlplanev1.KubeadmControlPlane, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoringReason,
+		Message: fmt.Sprintf("Phase %s in progress, %s", kcp.Status.EtcdRestore.Phase, message),
+	})
+}
+
+func setEtcdNotRestoringCondition(kcp *controlplanev1.KubeadmControlPlane, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdNotRestoringReason,
+		Message: message,
+	})
+}
+
+func setEtcdRestoringInternalErrorCondition(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoringInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
@@ -0,0 +1,597 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestShouldStartEtcdRestore(t *testing.T) {
+	now := time.Now()
+	restoreAfter := metav1.NewTime(now.Add(-time.Hour))
+
+	tests := []struct {
+		name     string
+		spec     *controlplanev1.EtcdRestore
+		status   *controlplanev1.EtcdRestoreStatus
+		expected bool
+	}{
+		{
+			name:     "no restore requested",
+			expected: false,
+		},
+		{
+			name:     "restoreAfter not expired yet",
+			spec:     &controlplanev1.EtcdRestore{SnapshotName: "snapshot", RestoreAfter: metav1.NewTime(now.Add(time.Hour))},
+			expected: false,
+		},
+		{
+			name:     "restoreAfter expired and no previous restore",
+			spec:     &controlplanev1.EtcdRestore{SnapshotName: "snapshot", RestoreAfter: restoreAfter},
+			expected: true,
+		},
+		{
+			name: "restoreAfter expired and previous restore started before restoreAfter",
+			spec: &controlplanev1.EtcdRestore{SnapshotName: "snapshot", RestoreAfter: restoreAfter},
+			status: &controlplanev1.EtcdRestoreStatus{
+				Phase:     controlplanev1.EtcdRestoreCompletedPhase,
+				StartTime: metav1.NewTime(now.Add(-2 * time.Hour)),
+			},
+			expected: true,
+		},
+		{
+			name: "restoreAfter expired and previous restore started after restoreAfter",
+			spec: &controlplanev1.EtcdRestore{SnapshotName: "snapshot", RestoreAfter: restoreAfter},
+			status: &controlplanev1.EtcdRestoreStatus{
+				Phase:     controlplanev1.EtcdRestoreCompletedPhase,
+				StartTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+			},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec:   controlplanev1.KubeadmControlPlaneSpec{EtcdRestore: tt.spec},
+				Status: controlplanev1.KubeadmControlPlaneStatus{EtcdRestore: tt.status},
+			}
+			g.Expect(shouldStartEtcdRestore(kcp, now)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestEtcdSnapshotForRestoreProblem(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	// Random data cannot be compressed, so the compressed snapshot is stored in more than one Secret.
+	bigSnapshotData := make([]byte, 2*etcdSnapshotSecretChunkSize)
+	_, err := rand.Read(bigSnapshotData)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name                  string
+		etcdSnapshotServerURL string
+		snapshots             map[string][]byte
+		deleteChunk           string
+		expectProblem         string
+	}{
+		{
+			name:          "etcd snapshot server not enabled",
+			snapshots:     map[string][]byte{"snapshot": []byte("data")},
+			expectProblem: etcdSnapshotServerDisabledMessage,
+		},
+		{
+			name:                  "snapshot does not exist",
+			etcdSnapshotServerURL: "https://kcp.example.com",
+			snapshots:             map[string][]byte{"other-snapshot": []byte("data")},
+			expectProblem:         "etcd snapshot snapshot does not exist",
+		},
+		{
+			name:                  "snapshot not completely stored",
+			etcdSnapshotServerURL: "https://kcp.example.com",
+			snapshots:             map[string][]byte{"snapshot": bigSnapshotData},
+			deleteChunk:           "snapshot-1",
+			expectProblem:         "etcd snapshot snapshot is not completely stored",
+		},
+		{
+			name:                  "valid snapshot",
+			etcdSnapshotServerURL: "https://kcp.example.com",
+			snapshots:             map[string][]byte{"snapshot": []byte("data")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := newFakeClient()
+			for name, data := range tt.snapshots {
+				saveEtcdSnapshotForRestore(g, fakeClient, kcp, name, data)
+			}
+			if tt.deleteChunk != "" {
+				g.Expect(fakeClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: kcp.Namespace, Name: tt.deleteChunk}})).To(Succeed())
+			}
+			r := &KubeadmControlPlaneReconciler{
+				Client:                fakeClient,
+				EtcdSnapshotServerURL: tt.etcdSnapshotServerURL,
+			}
+
+			problem, err := r.etcdSnapshotForRestoreProblem(ctx, kcp, "snapshot")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(problem).To(Equal(tt.expectProblem))
+		})
+	}
+}
+
+func TestReconcileEtcdRestore(t *testing.T) {
+	newKCP := func() *controlplanev1.KubeadmControlPlane {
+		kcp := &controlplanev1.KubeadmControlPlane{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "KubeadmControlPlane",
+				APIVersion: controlplanev1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: metav1.NamespaceDefault,
+				UID:       "kcp-uid",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version:  "v1.31.0",
+				Replicas: ptr.To[int32](3),
+				EtcdRestore: &controlplanev1.EtcdRestore{
+					SnapshotName: "snapshot",
+					RestoreAfter: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			},
+			Status: controlplanev1.KubeadmControlPlaneStatus{
+				Initialization: &controlplanev1.KubeadmControlPlaneInitializationStatus{ControlPlaneInitialized: true},
+			},
+		}
+		conditions.Set(kcp, metav1.Condition{Type: controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition, Status: metav1.ConditionFalse})
+		return kcp
+	}
+	newMachine := func(name string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  metav1.NamespaceDefault,
+				Finalizers: []string{clusterv1.MachineFinalizer},
+				Annotations: map[string]string{
+					controlplanev1.PreTerminateHookCleanupAnnotation: "",
+				},
+			},
+			Status: clusterv1.MachineStatus{NodeRef: &clusterv1.MachineNodeReference{Name: name}},
+		}
+	}
+	setup := func(kcp *controlplanev1.KubeadmControlPlane, workloadCluster *fakeWorkloadCluster, machines []*clusterv1.Machine) (*KubeadmControlPlaneReconciler, *internal.ControlPlane, client.Client) {
+		objs := []client.Object{}
+		for _, m := range machines {
+			objs = append(objs, m.DeepCopy())
+		}
+		fakeClient := newFakeClient(append(objs, kcp.DeepCopy())...)
+		managementCluster := &fakeManagementCluster{
+			Machines: collections.FromMachines(machines...),
+			Workload: workloadCluster,
+		}
+		r := &KubeadmControlPlaneReconciler{
+			Client:                fakeClient,
+			managementCluster:     managementCluster,
+			recorder:              record.NewFakeRecorder(32),
+			EtcdSnapshotServerURL: "https://kcp.example.com",
+		}
+		controlPlane := &internal.ControlPlane{
+			KCP:      kcp,
+			Cluster:  &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}},
+			Machines: collections.FromMachines(machines...),
+		}
+		controlPlane.InjectTestManagementCluster(managementCluster)
+		saveEtcdSnapshotForRestore(NewWithT(t), fakeClient, kcp, "snapshot", []byte("data"))
+		return r, controlPlane, fakeClient
+	}
+
+	t.Run("does nothing if a restore is not requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.EtcdRestore = nil
+		r, controlPlane, _ := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m1")})
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore).To(BeNil())
+		g.Expect(conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(BeTrue())
+	})
+
+	t.Run("does not start a restore if etcd is healthy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		conditions.Set(kcp, metav1.Condition{Type: controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition, Status: metav1.ConditionTrue})
+		r, controlPlane, _ := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m1")})
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore).To(BeNil())
+		g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal("Restoring etcd is allowed only when the etcd cluster is not healthy"))
+	})
+
+	t.Run("does not start a restore if etcd health is unknown", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		conditions.Set(kcp, metav1.Condition{Type: controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition, Status: metav1.ConditionUnknown})
+		r, controlPlane, fakeClient := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m1")})
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore).To(BeNil())
+		g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal("Restoring etcd is allowed only when the etcd cluster is not healthy"))
+
+		machine := &clusterv1.Machine{}
+		g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "m1"}, machine)).To(Succeed())
+		g.Expect(machine.DeletionTimestamp.IsZero()).To(BeTrue())
+	})
+
+	t.Run("does not start a restore if the snapshot does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.EtcdRestore.SnapshotName = "other-snapshot"
+		r, controlPlane, _ := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m1")})
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore).To(BeNil())
+		g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal("etcd snapshot other-snapshot does not exist"))
+	})
+
+	t.Run("does not start a restore if the etcd snapshot server is not enabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		r, controlPlane, _ := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m1")})
+		r.EtcdSnapshotServerURL = ""
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore).To(BeNil())
+		g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(etcdSnapshotServerDisabledMessage))
+	})
+
+	t.Run("does not start a restore while rotating certificate authorities", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.CertificateAuthorityRotation = &controlplanev1.CertificateAuthorityRotationStatus{
+			Phase: controlplanev1.CertificateAuthorityRotationDistributingTrustBundlePhase,
+		}
+		r, controlPlane, _ := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m1")})
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore).To(BeNil())
+	})
+
+	t.Run("starts a restore by deleting all the Machines", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		machines := []*clusterv1.Machine{newMachine("m1"), newMachine("m2"), newMachine("m3")}
+		r, controlPlane, fakeClient := setup(kcp, &fakeWorkloadCluster{}, machines)
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(kcp.Status.EtcdRestore).ToNot(BeNil())
+		g.Expect(kcp.Status.EtcdRestore.SnapshotName).To(Equal("snapshot"))
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreDeletingMachinesPhase))
+		g.Expect(conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(BeTrue())
+
+		for _, m := range machines {
+			machine := &clusterv1.Machine{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(m), machine)).To(Succeed())
+			g.Expect(machine.DeletionTimestamp.IsZero()).To(BeFalse())
+			g.Expect(machine.Annotations).ToNot(HaveKey(controlplanev1.PreTerminateHookCleanupAnnotation))
+			g.Expect(machine.Annotations).To(HaveKey(clusterv1.ExcludeNodeDrainingAnnotation))
+			g.Expect(machine.Annotations).To(HaveKey(clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation))
+		}
+	})
+
+	t.Run("starts a restore from a snapshot URL without reading the etcd backup sink", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Spec.EtcdRestore.SnapshotName = "other-snapshot"
+		kcp.Spec.EtcdRestore.SnapshotURL = &bootstrapv1.EtcdSnapshotURL{
+			URL:    "https://example.com/snapshot.db.gz",
+			SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		}
+		r, controlPlane, _ := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m1")})
+		r.EtcdSnapshotServerURL = ""
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(kcp.Status.EtcdRestore).ToNot(BeNil())
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreDeletingMachinesPhase))
+	})
+
+	t.Run("moves to the Restoring phase when all the Machines are deleted", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+			SnapshotName: "snapshot",
+			Phase:        controlplanev1.EtcdRestoreDeletingMachinesPhase,
+			StartTime:    metav1.Now(),
+		}
+		// NOTE: The snapshot is deleted while restoring, so the Machine restoring etcd cannot be created.
+		r, controlPlane, fakeClient := setup(kcp, &fakeWorkloadCluster{}, nil)
+		g.Expect((&secretEtcdSnapshotSink{Client: fakeClient}).Delete(ctx, kcp, "snapshot")).To(Succeed())
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreRestoringPhase))
+		g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal("Phase Restoring in progress, etcd snapshot snapshot cannot be restored: Secret snapshot-0 does not exist: etcd snapshot not found"))
+	})
+
+	t.Run("waits for the Machine restoring etcd to have a Node", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+			SnapshotName: "snapshot",
+			Phase:        controlplanev1.EtcdRestoreRestoringPhase,
+			StartTime:    metav1.Now(),
+		}
+		machine := newMachine("m4")
+		machine.Status.NodeRef = nil
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane, _ := setup(kcp, workloadCluster, []*clusterv1.Machine{machine})
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreRestoringPhase))
+		g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal("Phase Restoring in progress, waiting for Machine m4 to restore etcd"))
+		g.Expect(workloadCluster.deletedControlPlaneNodesNotIn).To(BeNil())
+	})
+
+	t.Run("waits for a deleted Machine restoring etcd to go away", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+			SnapshotName: "snapshot",
+			Phase:        controlplanev1.EtcdRestoreRestoringPhase,
+			StartTime:    metav1.Now(),
+		}
+		machine := newMachine("m4")
+		machine.Status.NodeRef = nil
+		r, controlPlane, fakeClient := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{machine})
+		g.Expect(fakeClient.Delete(ctx, machine.DeepCopy())).To(Succeed())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+		controlPlane.Machines = collections.FromMachines(machine)
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreRestoringPhase))
+		g.Expect(conditions.GetMessage(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal("Phase Restoring in progress, waiting for Machines to be deleted: m4"))
+
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+		g.Expect(machine.Annotations).ToNot(HaveKey(controlplanev1.PreTerminateHookCleanupAnnotation))
+	})
+
+	t.Run("deletes stale Nodes and moves to the ScalingUp phase when the Machine restoring etcd has a Node", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+			SnapshotName: "snapshot",
+			Phase:        controlplanev1.EtcdRestoreRestoringPhase,
+			StartTime:    metav1.Now(),
+		}
+		workloadCluster := &fakeWorkloadCluster{}
+		r, controlPlane, fakeClient := setup(kcp, workloadCluster, []*clusterv1.Machine{newMachine("m4")})
+		_, err := r.createEtcdRestoreToken(ctx, controlPlane, "snapshot", time.Now().Add(etcdRestoreTokenTTL))
+		g.Expect(err).ToNot(HaveOccurred())
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreScalingUpPhase))
+		g.Expect(conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(BeTrue())
+		g.Expect(workloadCluster.deletedControlPlaneNodesNotIn).To(Equal([]string{"m4"}))
+
+		// The snapshot must not be served anymore once it is restored.
+		err = fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: etcdRestoreTokenSecretName(kcp.Name)}, &corev1.Secret{})
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	t.Run("completes the restore when the control plane is scaled up and etcd is healthy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := newKCP()
+		kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+			SnapshotName: "snapshot",
+			Phase:        controlplanev1.EtcdRestoreScalingUpPhase,
+			StartTime:    metav1.Now(),
+		}
+		conditions.Set(kcp, metav1.Condition{Type: controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition, Status: metav1.ConditionTrue})
+		r, controlPlane, _ := setup(kcp, &fakeWorkloadCluster{}, []*clusterv1.Machine{newMachine("m4"), newMachine("m5"), newMachine("m6")})
+
+		res, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreCompletedPhase))
+		g.Expect(kcp.Status.EtcdRestore.CompletionTime).ToNot(BeNil())
+		g.Expect(conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(BeTrue())
+
+		// A completed restore is not started again.
+		res, err = r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(kcp.Status.EtcdRestore.Phase).To(Equal(controlplanev1.EtcdRestoreCompletedPhase))
+	})
+}
+
+func TestKubeadmControlPlaneReconciler_createMachineForEtcdRestore(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace, err := env.CreateNamespace(ctx, "test-kcp-reconciler-etcdrestore")
+	g.Expect(err).ToNot(HaveOccurred())
+	defer func() {
+		g.Expect(env.Delete(ctx, namespace)).To(Succeed())
+	}()
+
+	cluster, kcp, genericInfrastructureMachineTemplate := createClusterWithControlPlane(namespace.Name)
+	g.Expect(env.CreateAndWait(ctx, genericInfrastructureMachineTemplate, client.FieldOwner("manager"))).To(Succeed())
+	kcp.UID = types.UID(util.RandomString(10))
+	kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+		SnapshotName: "snapshot",
+		Phase:        controlplanev1.EtcdRestoreRestoringPhase,
+		StartTime:    metav1.Now(),
+	}
+	compressedSnapshot := saveEtcdSnapshotForRestore(g, env, kcp, "snapshot", []byte("data"))
+
+	r := &KubeadmControlPlaneReconciler{
+		Client:                env,
+		recorder:              record.NewFakeRecorder(32),
+		EtcdSnapshotServerURL: "https://kcp.example.com",
+		managementClusterUncached: &fakeManagementCluster{
+			Management: &internal.Management{Client: env},
+			Workload:   &fakeWorkloadCluster{},
+		},
+	}
+	controlPlane := &internal.ControlPlane{
+		Cluster: cluster,
+		KCP:     kcp,
+	}
+
+	result, err := r.createMachineForEtcdRestore(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+
+	machineList := &clusterv1.MachineList{}
+	g.Expect(env.GetAPIReader().List(ctx, machineList, client.InNamespace(cluster.Namespace))).To(Succeed())
+	g.Expect(machineList.Items).To(HaveLen(1))
+
+	kubeadmConfig := &bootstrapv1.KubeadmConfig{}
+	bootstrapRef := machineList.Items[0].Spec.Bootstrap.ConfigRef
+	g.Expect(env.GetAPIReader().Get(ctx, client.ObjectKey{Namespace: machineList.Items[0].Namespace, Name: bootstrapRef.Name}, kubeadmConfig)).To(Succeed())
+	// The snapshot must be downloaded from the etcd snapshot server, and not embedded into the bootstrap data.
+	g.Expect(kubeadmConfig.Spec.EtcdRestore).ToNot(BeNil())
+	g.Expect(kubeadmConfig.Spec.EtcdRestore.SnapshotURL).ToNot(BeNil())
+	g.Expect(kubeadmConfig.Spec.EtcdRestore.SnapshotURL.URL).To(HavePrefix(fmt.Sprintf("https://kcp.example.com/etcd-snapshots/%s/%s/snapshot?token=", kcp.Namespace, kcp.Name)))
+	g.Expect(kubeadmConfig.Spec.EtcdRestore.SnapshotURL.SHA256).To(Equal(fmt.Sprintf("%x", sha256.Sum256(compressedSnapshot))))
+	g.Expect(kubeadmConfig.Spec.JoinConfiguration).To(BeNil())
+}
+
+func TestEtcdRestoreSnapshotURL(t *testing.T) {
+	snapshotURL := &bootstrapv1.EtcdSnapshotURL{
+		URL:    "https://example.com/snapshot.db.gz",
+		SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	tests := []struct {
+		name string
+		spec *controlplanev1.EtcdRestore
+		want *bootstrapv1.EtcdSnapshotURL
+	}{
+		{
+			name: "no URL if spec.etcdRestore is not set",
+			spec: nil,
+			want: nil,
+		},
+		{
+			name: "no URL if the snapshot is stored by KCP",
+			spec: &controlplanev1.EtcdRestore{SnapshotName: "snapshot"},
+			want: nil,
+		},
+		{
+			name: "no URL if spec.etcdRestore refers to another snapshot",
+			spec: &controlplanev1.EtcdRestore{SnapshotName: "other-snapshot", SnapshotURL: snapshotURL},
+			want: nil,
+		},
+		{
+			name: "URL of the snapshot being restored",
+			spec: &controlplanev1.EtcdRestore{SnapshotName: "snapshot", SnapshotURL: snapshotURL},
+			want: snapshotURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{EtcdRestore: tt.spec},
+				Status: controlplanev1.KubeadmControlPlaneStatus{
+					EtcdRestore: &controlplanev1.EtcdRestoreStatus{SnapshotName: "snapshot"},
+				},
+			}
+			g.Expect(etcdRestoreSnapshotURL(kcp)).To(Equal(tt.want))
+		})
+	}
+}
+
+// saveEtcdSnapshotForRestore stores a snapshot with the secretEtcdSnapshotSink, and returns the compressed snapshot.
+func saveEtcdSnapshotForRestore(g *WithT, c client.Client, kcp *controlplanev1.KubeadmControlPlane, name string, data []byte) []byte {
+	sink := &secretEtcdSnapshotSink{Client: c}
+	g.Expect(sink.Save(ctx, kcp, name, bytes.NewReader(data), controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)).To(BeNumerically(">", 0))
+
+	snapshot, err := sink.Open(ctx, kcp, name)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer snapshot.Close()
+	compressed, err := io.ReadAll(snapshot)
+	g.Expect(err).ToNot(HaveOccurred())
+	return compressed
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+)
+
+const (
+	// etcdSnapshotServerPath is the path the etcd snapshot server serves snapshots at, as
+	// <etcdSnapshotServerPath><namespace>/<KubeadmControlPlane name>/<snapshot name>?token=<token>.
+	etcdSnapshotServerPath = "/etcd-snapshots/"
+
+	// etcdRestoreTokenTTL is how long the URL given to the Machine restoring etcd can be used to download the snapshot.
+	// NOTE: A new URL is generated every time a Machine restoring etcd is created, e.g. after the previous one failed.
+	etcdRestoreTokenTTL = 1 * time.Hour
+
+	// etcdRestoreTokenKey is the key of the token in the etcd restore token Secret.
+	etcdRestoreTokenKey = "token"
+
+	// etcdRestoreTokenSnapshotKey is the key of the name of the snapshot the token gives access to in the etcd
+	// restore token Secret.
+	etcdRestoreTokenSnapshotKey = "snapshot"
+
+	// etcdRestoreTokenExpirationKey is the key of the RFC3339 expiration time of the token in the etcd restore token Secret.
+	etcdRestoreTokenExpirationKey = "expiration"
+
+	// etcdSnapshotServerDisabledMessage reports that a snapshot stored by KCP cannot be restored because the etcd
+	// snapshot server is not enabled.
+	etcdSnapshotServerDisabledMessage = "restoring etcd from snapshots stored by KCP requires the etcd snapshot server to be enabled, otherwise spec.etcdRestore.snapshotURL must be set"
+)
+
+// etcdRestoreTokenSecretName returns the name of the Secret storing the token which gives the Machine restoring etcd
+// access to the snapshot served by the etcd snapshot server.
+func etcdRestoreTokenSecretName(kcpName string) string {
+	return fmt.Sprintf("%s-etcd-restore-token", kcpName)
+}
+
+// etcdSnapshotServerURLForRestore returns a short-lived URL of the etcd snapshot server serving the snapshot being
+// restored, together with the checksum of the snapshot; the snapshot is read once from the etcd backup sink to
+// compute its checksum.
+// If the snapshot cannot be served, e.g. because it does not exist, a message describing the problem is returned.
+func (r *KubeadmControlPlaneReconciler) etcdSnapshotServerURLForRestore(ctx context.Context, controlPlane *internal.ControlPlane) (*bootstrapv1.EtcdSnapshotURL, string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	snapshotName := kcp.Status.EtcdRestore.SnapshotName
+
+	if r.EtcdSnapshotServerURL == "" {
+		return nil, etcdSnapshotServerDisabledMessage, nil
+	}
+
+	sink, err := r.etcdSnapshotSink(ctx, kcp)
+	if err != nil {
+		return nil, "", err
+	}
+	snapshot, err := sink.Open(ctx, kcp, snapshotName)
+	if err != nil {
+		if errors.Is(err, errEtcdSnapshotNotFound) {
+			return nil, fmt.Sprintf("etcd snapshot %s cannot be restored: %v", snapshotName, err), nil
+		}
+		return nil, "", errors.Wrapf(err, "failed to read etcd snapshot %s", snapshotName)
+	}
+	defer snapshot.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, snapshot); err != nil {
+		if errors.Is(err, errEtcdSnapshotNotFound) {
+			return nil, fmt.Sprintf("etcd snapshot %s cannot be restored: %v", snapshotName, err), nil
+		}
+		return nil, "", errors.Wrapf(err, "failed to read etcd snapshot %s", snapshotName)
+	}
+
+	var caBundle []byte
+	if r.EtcdSnapshotServerCAFile != "" {
+		if caBundle, err = os.ReadFile(r.EtcdSnapshotServerCAFile); err != nil {
+			return nil, "", errors.Wrap(err, "failed to read the CA bundle of the etcd snapshot server")
+		}
+	}
+
+	snapshotURL, err := url.JoinPath(r.EtcdSnapshotServerURL, etcdSnapshotServerPath, kcp.Namespace, kcp.Name, snapshotName)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to parse etcd snapshot server URL %q", r.EtcdSnapshotServerURL)
+	}
+	token, err := r.createEtcdRestoreToken(ctx, controlPlane, snapshotName, time.Now().Add(etcdRestoreTokenTTL))
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(fmt.Sprintf("Generated URL to download etcd snapshot %s from the etcd snapshot server", snapshotName), "expiresIn", etcdRestoreTokenTTL)
+
+	return &bootstrapv1.EtcdSnapshotURL{
+		URL:      snapshotURL + "?" + url.Values{"token": []string{token}}.Encode(),
+		SHA256:   hex.EncodeToString(hash.Sum(nil)),
+		CABundle: caBundle,
+	}, "", nil
+}
+
+// createEtcdRestoreToken generates a new random token giving access to the snapshot until expiration, replacing
+// the previous token, if any.
+// NOTE: The token Secret is owned by the KubeadmControlPlane, so it is garbage collected if the KubeadmControlPlane
+// is deleted during a restore.
+func (r *KubeadmControlPlaneReconciler) createEtcdRestoreToken(ctx context.Context, controlPlane *internal.ControlPlane, snapshotName string, expiration time.Time) (string, error) {
+	kcp := controlPlane.KCP
+	if err := r.deleteEtcdRestoreToken(ctx, kcp); err != nil {
+		return "", err
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", errors.Wrap(err, "failed to generate etcd restore token")
+	}
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kcp.Namespace,
+			Name:      etcdRestoreTokenSecretName(kcp.Name),
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: controlPlane.Cluster.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind)),
+			},
+		},
+		Data: map[string][]byte{
+			etcdRestoreTokenKey:           []byte(token),
+			etcdRestoreTokenSnapshotKey:   []byte(snapshotName),
+			etcdRestoreTokenExpirationKey: []byte(expiration.UTC().Format(time.RFC3339)),
+		},
+		Type: clusterv1.ClusterSecretType,
+	}
+	if err := r.Client.Create(ctx, tokenSecret); err != nil {
+		return "", errors.Wrapf(err, "failed to create Secret %s", tokenSecret.Name)
+	}
+	return token, nil
+}
+
+// deleteEtcdRestoreToken deletes the token giving access to the snapshot being restored, if any.
+func (r *KubeadmControlPlaneReconciler) deleteEtcdRestoreToken(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error {
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kcp.Namespace,
+			Name:      etcdRestoreTokenSecretName(kcp.Name),
+		},
+	}
+	if err := r.Client.Delete(ctx, tokenSecret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete Secret %s", tokenSecret.Name)
+	}
+	return nil
+}
+
+// isEtcdRestoreTokenValid returns true if token gives access to the snapshot of the KubeadmControlPlane at time now.
+// NOTE: Tokens are read from the API server, so they can be verified by any replica of KCP.
+func (r *KubeadmControlPlaneReconciler) isEtcdRestoreTokenValid(ctx context.Context, namespace, kcpName, snapshotName, token string, now time.Time) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	tokenSecret := &corev1.Secret{}
+	tokenSecretKey := client.ObjectKey{Namespace: namespace, Name: etcdRestoreTokenSecretName(kcpName)}
+	if err := r.Client.Get(ctx, tokenSecretKey, tokenSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get Secret %s", tokenSecretKey.Name)
+	}
+
+	expiration, err := time.Parse(time.RFC3339, string(tokenSecret.Data[etcdRestoreTokenExpirationKey]))
+	if err != nil || !now.Before(expiration) {
+		return false, nil
+	}
+	if string(tokenSecret.Data[etcdRestoreTokenSnapshotKey]) != snapshotName {
+		return false, nil
+	}
+	return subtle.ConstantTimeCompare(tokenSecret.Data[etcdRestoreTokenKey], []byte(token)) == 1, nil
+}
+
+// serveEtcdSnapshot serves the snapshot being restored to the Machine restoring etcd. Snapshots are streamed,
+// gzip compressed, from the etcd backup sink, e.g. reassembling the chunks stored in Secrets, so they are never
+// kept in memory nor embedded into the bootstrap data of the Machine.
+// Requests must have the token generated when the Machine was created, and which is valid for etcdRestoreTokenTTL.
+func (r *KubeadmControlPlaneReconciler) serveEtcdSnapshot(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	if req.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, etcdSnapshotServerPath), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		http.NotFound(w, req)
+		return
+	}
+	namespace, kcpName, snapshotName := parts[0], parts[1], parts[2]
+	log := ctrl.LoggerFrom(ctx).WithValues("KubeadmControlPlane", klog.KRef(namespace, kcpName), "snapshot", snapshotName)
+
+	valid, err := r.isEtcdRestoreTokenValid(ctx, namespace, kcpName, snapshotName, req.URL.Query().Get("token"), time.Now())
+	if err != nil {
+		log.Error(err, "Failed to verify etcd restore token")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !valid {
+		log.Info("Refused to serve etcd snapshot: the etcd restore token is not valid or it expired")
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: kcpName}, kcp); err != nil {
+		log.Error(err, "Failed to get KubeadmControlPlane")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	sink, err := r.etcdSnapshotSink(ctx, kcp)
+	if err != nil {
+		log.Error(err, "Failed to create etcd snapshot sink")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	snapshot, err := sink.Open(ctx, kcp, snapshotName)
+	if err != nil {
+		if errors.Is(err, errEtcdSnapshotNotFound) {
+			log.Info(fmt.Sprintf("Refused to serve etcd snapshot: %v", err))
+			http.NotFound(w, req)
+			return
+		}
+		log.Error(err, "Failed to read etcd snapshot")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer snapshot.Close()
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.WriteHeader(http.StatusOK)
+	// NOTE: If the snapshot cannot be read completely, the response is truncated, and the Machine does not restore
+	// the snapshot because its checksum does not match.
+	n, err := io.Copy(w, snapshot)
+	if err != nil {
+		log.Error(err, "Failed to serve etcd snapshot", "sentBytes", n)
+		return
+	}
+	log.Info("Served etcd snapshot", "sentBytes", n)
+}
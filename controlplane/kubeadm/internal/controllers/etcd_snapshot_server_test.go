@@ -0,0 +1,339 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+)
+
+func TestServeEtcdSnapshot(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "kcp-uid",
+		},
+	}
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}}
+
+	tests := []struct {
+		name           string
+		tokenSnapshot  string
+		tokenExpiresIn time.Duration
+		method         string
+		path           string
+		token          *string
+		expectStatus   int
+	}{
+		{
+			name:           "serves the snapshot",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/foo/snapshot",
+			expectStatus:   http.StatusOK,
+		},
+		{
+			name:           "refuses methods other than GET",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodPut,
+			path:           "/etcd-snapshots/default/foo/snapshot",
+			expectStatus:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "refuses invalid paths",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/foo",
+			expectStatus:   http.StatusNotFound,
+		},
+		{
+			name:           "refuses requests without a token",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/foo/snapshot",
+			token:          ptr.To(""),
+			expectStatus:   http.StatusForbidden,
+		},
+		{
+			name:           "refuses requests with a wrong token",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/foo/snapshot",
+			token:          ptr.To("wrong-token"),
+			expectStatus:   http.StatusForbidden,
+		},
+		{
+			name:           "refuses requests with an expired token",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: -time.Minute,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/foo/snapshot",
+			expectStatus:   http.StatusForbidden,
+		},
+		{
+			name:           "refuses requests for another snapshot",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/foo/other-snapshot",
+			expectStatus:   http.StatusForbidden,
+		},
+		{
+			name:           "refuses requests for another KubeadmControlPlane",
+			tokenSnapshot:  "snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/bar/snapshot",
+			expectStatus:   http.StatusForbidden,
+		},
+		{
+			name:           "returns not found if the snapshot does not exist",
+			tokenSnapshot:  "missing-snapshot",
+			tokenExpiresIn: time.Hour,
+			method:         http.MethodGet,
+			path:           "/etcd-snapshots/default/foo/missing-snapshot",
+			expectStatus:   http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := newFakeClient(kcp.DeepCopy())
+			compressedSnapshot := saveEtcdSnapshotForRestore(g, fakeClient, kcp, "snapshot", []byte("data"))
+			r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+			token, err := r.createEtcdRestoreToken(ctx, &internal.ControlPlane{KCP: kcp, Cluster: cluster}, tt.tokenSnapshot, time.Now().Add(tt.tokenExpiresIn))
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.token != nil {
+				token = *tt.token
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path+"?"+url.Values{"token": []string{token}}.Encode(), http.NoBody).WithContext(ctx)
+			resp := httptest.NewRecorder()
+			r.serveEtcdSnapshot(resp, req)
+
+			g.Expect(resp.Code).To(Equal(tt.expectStatus))
+			if tt.expectStatus == http.StatusOK {
+				g.Expect(resp.Header().Get("Content-Type")).To(Equal("application/gzip"))
+				g.Expect(resp.Body.Bytes()).To(Equal(compressedSnapshot))
+			}
+		})
+	}
+}
+
+func TestEtcdRestoreTokenLifecycle(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "kcp-uid",
+		},
+	}
+	controlPlane := &internal.ControlPlane{
+		KCP:     kcp,
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: metav1.NamespaceDefault}},
+	}
+	fakeClient := newFakeClient(kcp.DeepCopy())
+	r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+	now := time.Now()
+
+	firstToken, err := r.createEtcdRestoreToken(ctx, controlPlane, "snapshot", now.Add(etcdRestoreTokenTTL))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(firstToken).ToNot(BeEmpty())
+
+	tokenSecret := &corev1.Secret{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: "foo-etcd-restore-token"}, tokenSecret)).To(Succeed())
+	g.Expect(tokenSecret.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "cluster"))
+	g.Expect(tokenSecret.OwnerReferences).To(HaveLen(1))
+	g.Expect(tokenSecret.OwnerReferences[0].UID).To(Equal(kcp.UID))
+
+	valid, err := r.isEtcdRestoreTokenValid(ctx, kcp.Namespace, kcp.Name, "snapshot", firstToken, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(valid).To(BeTrue())
+	valid, err = r.isEtcdRestoreTokenValid(ctx, kcp.Namespace, kcp.Name, "snapshot", firstToken, now.Add(etcdRestoreTokenTTL+time.Second))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(valid).To(BeFalse())
+
+	// A new token, e.g. for a new Machine restoring etcd, replaces the previous one.
+	secondToken, err := r.createEtcdRestoreToken(ctx, controlPlane, "snapshot", now.Add(etcdRestoreTokenTTL))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(secondToken).ToNot(Equal(firstToken))
+	valid, err = r.isEtcdRestoreTokenValid(ctx, kcp.Namespace, kcp.Name, "snapshot", firstToken, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(valid).To(BeFalse())
+	valid, err = r.isEtcdRestoreTokenValid(ctx, kcp.Namespace, kcp.Name, "snapshot", secondToken, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(valid).To(BeTrue())
+
+	g.Expect(r.deleteEtcdRestoreToken(ctx, kcp)).To(Succeed())
+	valid, err = r.isEtcdRestoreTokenValid(ctx, kcp.Namespace, kcp.Name, "snapshot", secondToken, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(valid).To(BeFalse())
+	// Deleting a token which does not exist is not an error.
+	g.Expect(r.deleteEtcdRestoreToken(ctx, kcp)).To(Succeed())
+}
+
+// TestEtcdRestoreFromSavedSnapshot restores a snapshot spanning multiple Secret chunks, written by saveEtcdSnapshot,
+// as the Machine restoring etcd does: it downloads the snapshot from the etcd snapshot server using the URL and the
+// CA bundle generated for its bootstrap config, verifies the checksum and decompresses it.
+func TestEtcdRestoreFromSavedSnapshot(t *testing.T) {
+	// Random data cannot be compressed, so the compressed snapshot is bigger than one Secret chunk.
+	snapshotData := make([]byte, 2*etcdSnapshotSecretChunkSize+1024)
+	_, err := rand.Read(snapshotData)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name       string
+		objectSink bool
+	}{
+		{
+			name: "snapshot stored in Secrets",
+		},
+		{
+			name:       "snapshot stored in an object store",
+			objectSink: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: metav1.NamespaceDefault,
+					UID:       "kcp-uid",
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdBackup: &controlplanev1.EtcdBackupPolicy{
+						Sink: controlplanev1.EtcdBackupSink{Type: controlplanev1.SecretEtcdBackupSinkType},
+					},
+				},
+			}
+			objs := []client.Object{}
+			if tt.objectSink {
+				_, sinkSpec, credentials := newFakeObjectStore(t, kcp.Namespace)
+				kcp.Spec.EtcdBackup.Sink = sinkSpec
+				objs = append(objs, credentials)
+			}
+			fakeClient := newFakeClient(append(objs, kcp.DeepCopy())...)
+
+			// Start the etcd snapshot server.
+			r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+			mux := http.NewServeMux()
+			mux.Handle(etcdSnapshotServerPath, http.HandlerFunc(r.serveEtcdSnapshot))
+			server := httptest.NewTLSServer(mux)
+			t.Cleanup(server.Close)
+			r.EtcdSnapshotServerURL = server.URL
+			r.EtcdSnapshotServerCAFile = filepath.Join(t.TempDir(), "ca.crt")
+			g.Expect(os.WriteFile(r.EtcdSnapshotServerCAFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)).To(Succeed())
+
+			// Take the snapshot.
+			sink, err := r.etcdSnapshotSink(ctx, kcp)
+			g.Expect(err).ToNot(HaveOccurred())
+			operation := &etcdSnapshotOperation{name: "foo-etcd-20250101000000", done: make(chan struct{})}
+			workloadCluster := &fakeWorkloadCluster{EtcdSnapshotData: snapshotData, EtcdSnapshotRevision: 42}
+			revision, sizeBytes, err := saveEtcdSnapshot(ctx, workloadCluster, sink, kcp, operation, controlplanev1.DefaultEtcdBackupMaxTotalSizeBytes)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(revision).To(Equal(int64(42)))
+			g.Expect(sizeBytes).To(BeNumerically(">", etcdSnapshotSecretChunkSize))
+			if !tt.objectSink {
+				snapshotSecrets := &corev1.SecretList{}
+				g.Expect(fakeClient.List(ctx, snapshotSecrets, client.HasLabels{controlplanev1.EtcdSnapshotLabel})).To(Succeed())
+				g.Expect(len(snapshotSecrets.Items)).To(BeNumerically(">", 1))
+			}
+
+			// Generate the URL for the Machine restoring etcd.
+			kcp.Status.EtcdRestore = &controlplanev1.EtcdRestoreStatus{
+				SnapshotName: operation.name,
+				Phase:        controlplanev1.EtcdRestoreRestoringPhase,
+				StartTime:    metav1.Now(),
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP:     kcp,
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}},
+			}
+			problem, err := r.etcdSnapshotForRestoreProblem(ctx, kcp, operation.name)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(problem).To(BeEmpty())
+			snapshotURL, problem, err := r.etcdSnapshotServerURLForRestore(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(problem).To(BeEmpty())
+			g.Expect(snapshotURL).ToNot(BeNil())
+			g.Expect(snapshotURL.URL).To(HavePrefix(server.URL + "/etcd-snapshots/default/foo/foo-etcd-20250101000000?token="))
+
+			// Download, verify and decompress the snapshot as the Machine restoring etcd does.
+			rootCAs := x509.NewCertPool()
+			g.Expect(rootCAs.AppendCertsFromPEM(snapshotURL.CABundle)).To(BeTrue())
+			machineClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}}}
+			resp, err := machineClient.Get(snapshotURL.URL)
+			g.Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			compressedSnapshot, err := io.ReadAll(resp.Body)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(int64(len(compressedSnapshot))).To(Equal(sizeBytes))
+
+			checksum := sha256.Sum256(compressedSnapshot)
+			g.Expect(hex.EncodeToString(checksum[:])).To(Equal(snapshotURL.SHA256))
+			gzipReader, err := gzip.NewReader(bytes.NewReader(compressedSnapshot))
+			g.Expect(err).ToNot(HaveOccurred())
+			restoredData, err := io.ReadAll(gzipReader)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(restoredData).To(Equal(snapshotData))
+
+			// The snapshot is not served anymore once the restore moved on.
+			g.Expect(r.deleteEtcdRestoreToken(ctx, kcp)).To(Succeed())
+			resp, err = machineClient.Get(snapshotURL.URL)
+			g.Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+		})
+	}
+}
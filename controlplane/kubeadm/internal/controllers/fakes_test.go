@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -49,7 +50,7 @@ func (f *fakeManagementCluster) List(ctx context.Context, list client.ObjectList
 	return f.Reader.List(ctx, list, opts...)
 }
 
-func (f *fakeManagementCluster) GetWorkloadCluster(_ context.Context, _ client.ObjectKey) (internal.WorkloadCluster, error) {
+func (f *fakeManagementCluster) GetWorkloadCluster(_ context.Context, _ client.ObjectKey, _ bootstrapv1.EncryptionAlgorithmType) (internal.WorkloadCluster, error) {
 	return f.Workload, f.WorkloadErr
 }
 
@@ -72,9 +73,18 @@ type fakeWorkloadCluster struct {
 	Status                     internal.ClusterStatus
 	EtcdMembersResult          []string
 	APIServerCertificateExpiry *time.Time
+	EtcdSnapshotData           []byte
+	EtcdSnapshotRevision       int64
+	EtcdSnapshotErr            error
+	EtcdMemberStatuses         map[string]*etcd.MemberStatus
+	EtcdDefragmentationErr     error
 
 	forwardEtcdLeadershipCalled      int
 	removeEtcdMemberForMachineCalled int
+	saveEtcdSnapshotCalled           int
+	deletedControlPlaneNodesNotIn    []string
+	defragmentedEtcdMembers          []string
+	clusterInfoCertificateAuthority  []byte
 }
 
 func (f *fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error {
@@ -118,6 +128,43 @@ func (f *fakeWorkloadCluster) UpdateClusterConfiguration(context.Context, semver
 	return nil
 }
 
+func (f *fakeWorkloadCluster) UpdateClusterInfoCertificateAuthority(_ context.Context, caData []byte) error {
+	f.clusterInfoCertificateAuthority = caData
+	return nil
+}
+
+func (f *fakeWorkloadCluster) DeleteControlPlaneNodesNotIn(_ context.Context, nodeNames []string) ([]string, error) {
+	f.deletedControlPlaneNodesNotIn = nodeNames
+	return nil, nil
+}
+
+func (f *fakeWorkloadCluster) SaveEtcdSnapshot(_ context.Context, writer io.Writer) (int64, error) {
+	f.saveEtcdSnapshotCalled++
+	if f.EtcdSnapshotErr != nil {
+		return 0, f.EtcdSnapshotErr
+	}
+	if _, err := writer.Write(f.EtcdSnapshotData); err != nil {
+		return 0, err
+	}
+	return f.EtcdSnapshotRevision, nil
+}
+
+func (f *fakeWorkloadCluster) GetEtcdMemberStatus(_ context.Context, nodeName string) (*etcd.MemberStatus, error) {
+	status, ok := f.EtcdMemberStatuses[nodeName]
+	if !ok {
+		return nil, errors.Errorf("failed to get status of the etcd member hosted on Node %s", nodeName)
+	}
+	return status, nil
+}
+
+func (f *fakeWorkloadCluster) DefragmentEtcdMember(_ context.Context, nodeName string) error {
+	if f.EtcdDefragmentationErr != nil {
+		return f.EtcdDefragmentationErr
+	}
+	f.defragmentedEtcdMembers = append(f.defragmentedEtcdMembers, nodeName)
+	return nil
+}
+
 type fakeMigrator struct {
 	migrateCalled    bool
 	migrateErr       error
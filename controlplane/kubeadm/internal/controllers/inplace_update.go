@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/internal/util/inplace"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+// tryInPlaceUpdate tries to roll out changes to the KubeadmControlPlane by updating an outdated Machine in-place
+// instead of replacing it. Only one Machine at a time is updated in-place, and only if Runtime Extensions
+// implementing the CanUpdateMachine hook can apply all the changes to the Machine.
+// If true is returned, the in-place update of a Machine is either in progress or has been started, and the rollout
+// must not proceed with replacing Machines.
+func (r *KubeadmControlPlaneReconciler) tryInPlaceUpdate(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Resume in-place updates which have been interrupted before the Machine controller was signaled to call the
+	// UpdateMachine hook, e.g. because of an error or a controller restart; otherwise those Machines would be stuck forever.
+	if machines := controlPlane.Machines.Filter(inplace.IsUpdateInProgress, collections.Not(inplace.IsUpdateStarted)); len(machines) > 0 {
+		machine := machines.Oldest()
+		log := log.WithValues("Machine", klog.KObj(machine))
+		ctx := ctrl.LoggerInto(ctx, log)
+
+		current, desired, err := r.computeMachineObjectsForInPlaceUpdate(ctx, controlPlane, machine)
+		if err != nil {
+			return ctrl.Result{}, true, err
+		}
+		log.Info("Resuming in-place update of control plane Machine")
+		if err := inplace.StartUpdate(ctx, r.Client, current, desired); err != nil {
+			return ctrl.Result{}, true, err
+		}
+		return ctrl.Result{}, true, nil
+	}
+
+	// Wait for Machines being updated in-place before picking up the next Machine.
+	if machines := controlPlane.Machines.Filter(inplace.IsUpdateInProgress); len(machines) > 0 {
+		log.Info(fmt.Sprintf("Waiting for in-place update of Machines %s to complete", machines.Names()))
+		return ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}, true, nil
+	}
+
+	// Do not start in-place updates while a Machine is being replaced.
+	if int32(controlPlane.Machines.Len()) > *controlPlane.KCP.Spec.Replicas || controlPlane.HasDeletingMachine() {
+		return ctrl.Result{}, false, nil
+	}
+
+	machines := controlPlane.MachinesEligibleForInPlaceUpdate()
+	if len(machines) == 0 {
+		return ctrl.Result{}, false, nil
+	}
+	machine := machines.Oldest()
+	log = log.WithValues("Machine", klog.KObj(machine))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	current, desired, err := r.computeMachineObjectsForInPlaceUpdate(ctx, controlPlane, machine)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+	canUpdate, message, err := inplace.CanUpdateMachine(ctx, r.RuntimeClient, current, desired)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+	if !canUpdate {
+		log.V(4).Info(fmt.Sprintf("Machine cannot be updated in-place: %s", message))
+		return ctrl.Result{}, false, nil
+	}
+
+	// Run preflight checks ensuring the control plane is stable before proceeding with the in-place update; if not, wait.
+	if result, err := r.preflightChecks(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, true, err
+	}
+
+	log.Info("Starting in-place update of control plane Machine")
+	if err := inplace.StartUpdate(ctx, r.Client, current, desired); err != nil {
+		return ctrl.Result{}, true, err
+	}
+	r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, "InPlaceUpdateStarted", "Started in-place update of Machine %s", machine.Name)
+	return ctrl.Result{}, true, nil
+}
+
+// computeMachineObjectsForInPlaceUpdate returns the current objects of a Machine and the corresponding objects
+// with the desired state according to the KubeadmControlPlane spec.
+func (r *KubeadmControlPlaneReconciler) computeMachineObjectsForInPlaceUpdate(ctx context.Context, controlPlane *internal.ControlPlane, machine *clusterv1.Machine) (*inplace.MachineObjects, *inplace.MachineObjects, error) {
+	kcp := controlPlane.KCP
+
+	current, err := inplace.GetMachineObjects(ctx, r.Client, machine)
+	if err != nil {
+		return nil, nil, err
+	}
+	desired := current.DeepCopy()
+
+	// Compute the desired Machine.
+	desired.Machine.Spec.Version = &kcp.Spec.Version
+	if _, ok := machine.Annotations[controlplanev1.KubeadmClusterConfigurationAnnotation]; ok {
+		clusterConfigurationAnnotation, err := internal.ClusterConfigurationToMachineAnnotationValue(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration)
+		if err != nil {
+			return nil, nil, err
+		}
+		desired.Machine.Annotations[controlplanev1.KubeadmClusterConfigurationAnnotation] = clusterConfigurationAnnotation
+	}
+
+	// Compute the desired InfrastructureMachine.
+	infraTemplate, err := external.GetObjectFromContractVersionedRef(ctx, r.Client, &kcp.Spec.MachineTemplate.InfrastructureRef, kcp.Namespace)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to compute desired InfrastructureMachine for Machine %s", klog.KObj(machine))
+	}
+	desired.InfraMachine, err = inplace.ComputeDesiredObjectFromTemplate(current.InfraMachine, infraTemplate)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to compute desired InfrastructureMachine for Machine %s", klog.KObj(machine))
+	}
+
+	// Compute the desired KubeadmConfig.
+	// NOTE: Both current and desired KubeadmConfig are converted from the typed object, so they can be compared
+	// without being affected by differences in serialization.
+	if current.BootstrapConfig != nil {
+		kubeadmConfig := &bootstrapv1.KubeadmConfig{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(current.BootstrapConfig.Object, kubeadmConfig); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to convert KubeadmConfig %s", klog.KObj(current.BootstrapConfig))
+		}
+		if current.BootstrapConfig, err = kubeadmConfigToUnstructured(kubeadmConfig); err != nil {
+			return nil, nil, err
+		}
+
+		desiredKubeadmConfig := kubeadmConfig.DeepCopy()
+		desiredKubeadmConfig.Spec = *desiredKubeadmConfigSpec(controlPlane, kubeadmConfig)
+		if desired.BootstrapConfig, err = kubeadmConfigToUnstructured(desiredKubeadmConfig); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return current, desired, nil
+}
+
+// desiredKubeadmConfigSpec returns the KubeadmConfigSpec that KCP would use when creating the given KubeadmConfig.
+func desiredKubeadmConfigSpec(controlPlane *internal.ControlPlane, kubeadmConfig *bootstrapv1.KubeadmConfig) *bootstrapv1.KubeadmConfigSpec {
+	// The InitConfiguration is set only on the KubeadmConfig of the Machine which initialized the control plane.
+	if kubeadmConfig.Spec.InitConfiguration != nil {
+		return controlPlane.InitialControlPlaneConfig()
+	}
+
+	spec := controlPlane.JoinControlPlaneConfig()
+	// Discovery is relevant only for the join process, preserve the current value.
+	if spec.JoinConfiguration != nil && kubeadmConfig.Spec.JoinConfiguration != nil {
+		spec.JoinConfiguration.Discovery = kubeadmConfig.Spec.JoinConfiguration.Discovery
+	}
+	return spec
+}
+
+func kubeadmConfigToUnstructured(kubeadmConfig *bootstrapv1.KubeadmConfig) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(kubeadmConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert KubeadmConfig %s", klog.KObj(kubeadmConfig))
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(bootstrapv1.GroupVersion.WithKind("KubeadmConfig"))
+	return u, nil
+}
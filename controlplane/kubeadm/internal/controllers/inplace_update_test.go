@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/internal/util/inplace"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/contract"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func TestKubeadmControlPlaneReconciler_tryInPlaceUpdate(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	canUpdateMachineGVH, err := catalog.GroupVersionHook(runtimehooksv1.CanUpdateMachine)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+
+	versionPatch := &runtimehooksv1.CanUpdateMachineResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		MachinePatch: runtimehooksv1.Patch{
+			PatchType: runtimehooksv1.JSONPatchType,
+			Patch:     []byte(`[{"op":"replace","path":"/spec/version","value":"v1.32.0"}]`),
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		extensions           []string
+		annotations          map[string]string
+		wantResult           ctrl.Result
+		wantUpdating         bool
+		wantVersion          string
+		wantUpdateInProgress bool
+		wantUpdateStarted    bool
+	}{
+		{
+			name:                 "start the in-place update if Runtime Extensions can update the Machine",
+			extensions:           []string{"version"},
+			wantUpdating:         true,
+			wantVersion:          "v1.32.0",
+			wantUpdateInProgress: true,
+			wantUpdateStarted:    true,
+		},
+		{
+			name:        "fall back to rollout if Runtime Extensions cannot update the Machine",
+			wantVersion: "v1.31.0",
+		},
+		{
+			name: "wait for in-place update in progress",
+			annotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+				runtimev1.PendingHooksAnnotation:     "UpdateMachine",
+			},
+			wantResult:           ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+			wantUpdating:         true,
+			wantVersion:          "v1.31.0",
+			wantUpdateInProgress: true,
+			wantUpdateStarted:    true,
+		},
+		{
+			// The controller crashed after setting the UpdateInProgressAnnotation, but before marking the UpdateMachine hook as pending.
+			name: "resume in-place update interrupted before the UpdateMachine hook was marked as pending",
+			annotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+			},
+			wantUpdating:         true,
+			wantVersion:          "v1.32.0",
+			wantUpdateInProgress: true,
+			wantUpdateStarted:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			infraTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra-template").Build()
+			infraMachine := builder.InfrastructureMachine(metav1.NamespaceDefault, "one").Build()
+			infraMachine.SetAnnotations(map[string]string{
+				clusterv1.TemplateClonedFromNameAnnotation:      infraTemplate.GetName(),
+				clusterv1.TemplateClonedFromGroupKindAnnotation: infraTemplate.GroupVersionKind().GroupKind().String(),
+			})
+			kubeadmConfig := &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "one",
+					Namespace: metav1.NamespaceDefault,
+				},
+			}
+			m := machine("one", func(m *clusterv1.Machine) {
+				m.Annotations = tt.annotations
+				m.Spec.Version = ptr.To("v1.31.0")
+				m.Spec.InfrastructureRef = clusterv1.ContractVersionedObjectReference{
+					APIGroup: builder.InfrastructureGroupVersion.Group,
+					Kind:     builder.GenericInfrastructureMachineKind,
+					Name:     "one",
+				}
+				m.Spec.Bootstrap.ConfigRef = &clusterv1.ContractVersionedObjectReference{
+					APIGroup: bootstrapv1.GroupVersion.Group,
+					Kind:     "KubeadmConfig",
+					Name:     "one",
+				}
+			})
+			setMachineHealthy(m)
+
+			kubeadmConfigCRD := &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: contract.CalculateCRDName(bootstrapv1.GroupVersion.Group, "KubeadmConfig"),
+					Labels: map[string]string{
+						clusterv1.GroupVersion.String(): bootstrapv1.GroupVersion.Version,
+					},
+				},
+			}
+			fakeClient := newFakeClient(builder.GenericInfrastructureMachineCRD.DeepCopy(), builder.GenericInfrastructureMachineTemplateCRD.DeepCopy(),
+				kubeadmConfigCRD, infraTemplate, infraMachine, kubeadmConfig, m)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kcp",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Replicas: ptr.To[int32](1),
+					Version:  "v1.32.0",
+					MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+						InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+							APIGroup: builder.InfrastructureGroupVersion.Group,
+							Kind:     builder.GenericInfrastructureMachineTemplateKind,
+							Name:     infraTemplate.GetName(),
+						},
+					},
+				},
+			}
+			setKCPHealthy(kcp)
+
+			r := &KubeadmControlPlaneReconciler{
+				recorder:            record.NewFakeRecorder(32),
+				Client:              fakeClient,
+				SecretCachingClient: fakeClient,
+				RuntimeClient: fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithGetAllExtensionResponses(map[runtimecatalog.GroupVersionHook][]string{
+						canUpdateMachineGVH: tt.extensions,
+					}).
+					WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+						"version": versionPatch,
+					}).
+					Build(),
+				managementCluster: &fakeManagementCluster{
+					Workload: &fakeWorkloadCluster{},
+				},
+			}
+			controlPlane, err := internal.NewControlPlane(ctx, r.managementCluster, fakeClient, &clusterv1.Cluster{}, kcp, collections.FromMachines(m))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			result, updating, err := r.tryInPlaceUpdate(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(BeComparableTo(tt.wantResult))
+			g.Expect(updating).To(Equal(tt.wantUpdating))
+
+			gotMachine := &clusterv1.Machine{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(m), gotMachine)).To(Succeed())
+			g.Expect(gotMachine.Spec.Version).To(Equal(ptr.To(tt.wantVersion)))
+			g.Expect(inplace.IsUpdateInProgress(gotMachine)).To(Equal(tt.wantUpdateInProgress))
+			g.Expect(inplace.IsUpdateStarted(gotMachine)).To(Equal(tt.wantUpdateStarted))
+		})
+	}
+}
@@ -18,15 +18,20 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/collections"
 )
 
@@ -37,9 +42,12 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 ) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
-	if controlPlane.KCP.Spec.RolloutStrategy == nil || controlPlane.KCP.Spec.RolloutStrategy.RollingUpdate == nil {
+	if controlPlane.KCP.Spec.RolloutStrategy == nil {
 		return ctrl.Result{}, errors.New("rolloutStrategy is not set")
 	}
+	if controlPlane.KCP.Spec.RolloutStrategy.Type == controlplanev1.RollingUpdateStrategyType && controlPlane.KCP.Spec.RolloutStrategy.RollingUpdate == nil {
+		return ctrl.Result{}, errors.New("rolloutStrategy.rollingUpdate is not set")
+	}
 
 	// TODO: handle reconciliation of etcd members and kubeadm config in case they get out of sync with cluster
 
@@ -70,7 +78,8 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 			workloadCluster.UpdateFeatureGatesInKubeadmConfigMap(controlPlane.KCP.Spec.KubeadmConfigSpec, parsedVersion),
 			workloadCluster.UpdateAPIServerInKubeadmConfigMap(controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer),
 			workloadCluster.UpdateControllerManagerInKubeadmConfigMap(controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.ControllerManager),
-			workloadCluster.UpdateSchedulerInKubeadmConfigMap(controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.Scheduler))
+			workloadCluster.UpdateSchedulerInKubeadmConfigMap(controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.Scheduler),
+			workloadCluster.UpdateEncryptionAlgorithmInKubeadmConfigMap(controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.EncryptionAlgorithm))
 
 		// Etcd local and external are mutually exclusive and they cannot be switched, once set.
 		if controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local != nil {
@@ -87,9 +96,15 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 		return ctrl.Result{}, err
 	}
 
+	// Prefer updating Machines in-place over replacing them, if possible.
+	if feature.Gates.Enabled(feature.InPlaceUpdates) {
+		if result, updating, err := r.tryInPlaceUpdate(ctx, controlPlane); err != nil || updating {
+			return result, err
+		}
+	}
+
 	switch controlPlane.KCP.Spec.RolloutStrategy.Type {
 	case controlplanev1.RollingUpdateStrategyType:
-		// RolloutStrategy is currently defaulted and validated to be RollingUpdate
 		// We can ignore MaxUnavailable because we are enforcing health checks before we get here.
 		maxNodes := *controlPlane.KCP.Spec.Replicas + int32(controlPlane.KCP.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntValue())
 		if int32(controlPlane.Machines.Len()) < maxNodes {
@@ -97,8 +112,117 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 			return r.scaleUpControlPlane(ctx, controlPlane)
 		}
 		return r.scaleDownControlPlane(ctx, controlPlane, machinesRequireUpgrade)
+	case controlplanev1.ScaleInFirstStrategyType:
+		// ScaleInFirst never creates more machines than spec.replicas; the replacement for an outdated machine
+		// is created only after the outdated machine has been removed.
+		if int32(controlPlane.Machines.Len()) < *controlPlane.KCP.Spec.Replicas {
+			// scaleUp ensures that we don't continue scaling up while waiting for Machines to have NodeRefs
+			return r.scaleUpControlPlane(ctx, controlPlane)
+		}
+		return r.scaleInFirstControlPlane(ctx, controlPlane, machinesRequireUpgrade)
 	default:
-		logger.Info("RolloutStrategy type is not set to RollingUpdateStrategyType, unable to determine the strategy for rolling out machines")
+		logger.Info("RolloutStrategy type is not set to a supported value, unable to determine the strategy for rolling out machines", "type", controlPlane.KCP.Spec.RolloutStrategy.Type)
 		return ctrl.Result{}, nil
 	}
 }
+
+// scaleInFirstControlPlane removes an outdated control plane machine without creating its replacement first.
+// Given that the etcd cluster temporarily runs with one member less, before deleting the machine KCP:
+// - checks that the etcd cluster retains quorum without the member hosted on the machine
+// - moves etcd leadership away from the machine
+// - removes the etcd member, so the etcd cluster size is reduced before the replacement joins.
+func (r *KubeadmControlPlaneReconciler) scaleInFirstControlPlane(
+	ctx context.Context,
+	controlPlane *internal.ControlPlane,
+	outdatedMachines collections.Machines,
+) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// If etcd is not managed by KCP, there is no need for additional etcd checks.
+	if !controlPlane.IsEtcdManaged() {
+		return r.scaleDownControlPlane(ctx, controlPlane, outdatedMachines)
+	}
+
+	// Pick the Machine that we should scale down.
+	machineToDelete, err := selectMachineForScaleDown(ctx, controlPlane, outdatedMachines)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to select machine for scale down")
+	}
+	if machineToDelete == nil {
+		logger.Info("Failed to pick control plane Machine to delete")
+		return ctrl.Result{}, errors.New("failed to pick control plane Machine to delete")
+	}
+
+	// Run preflight checks ensuring the control plane is stable before proceeding with a scale down operation; if not, wait.
+	// Given that we're scaling down, we can exclude the machineToDelete from the preflight checks.
+	if result, err := r.preflightChecks(ctx, controlPlane, machineToDelete); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to create client to workload cluster")
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create client to workload cluster")
+	}
+
+	etcdMembers, err := workloadCluster.EtcdMembers(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get etcd members for workload cluster %s", controlPlane.Cluster.Name)
+	}
+
+	// If the etcd member for the machineToDelete has already been removed, e.g. because a previous attempt to delete
+	// the machine failed, skip directly to machine deletion.
+	if machineToDelete.Status.NodeRef != nil && slices.Contains(etcdMembers, machineToDelete.Status.NodeRef.Name) {
+		// Never remove a member if the etcd cluster is already running with less members than spec.replicas, e.g. because
+		// the replacement of a previously removed member did not join yet; this prevents the etcd cluster from shrinking
+		// more than one member below the desired size.
+		if int32(len(etcdMembers)) < *controlPlane.KCP.Spec.Replicas {
+			logger.Info(fmt.Sprintf("Waiting for the etcd cluster to have at least %d members before removing an outdated control plane Machine", *controlPlane.KCP.Spec.Replicas),
+				"etcdMembers", etcdMembers)
+			controlPlane.PreflightCheckResults.EtcdClusterNotHealthy = true
+			return ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}, nil
+		}
+
+		// Check that the etcd cluster retains quorum after removing the member hosted on the machineToDelete; if not, wait.
+		canSafelyScaleIn, err := r.canSafelyRemoveEtcdMember(ctx, controlPlane, machineToDelete)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !canSafelyScaleIn {
+			logger.Info("Waiting for the etcd cluster to be able to retain quorum before removing an outdated control plane Machine", "Machine", klog.KObj(machineToDelete))
+			r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "ScaleInFirstBlocked",
+				"Removing control plane Machine %s would cause the loss of etcd quorum, waiting for the etcd cluster to become healthy", machineToDelete.Name)
+			controlPlane.PreflightCheckResults.EtcdClusterNotHealthy = true
+			return ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}, nil
+		}
+
+		// If etcd leadership is on the machine that is about to be deleted, move it to the newest of the remaining members.
+		etcdLeaderCandidate := controlPlane.Machines.Difference(collections.FromMachines(machineToDelete)).Newest()
+		if err := workloadCluster.ForwardEtcdLeadership(ctx, machineToDelete, etcdLeaderCandidate); err != nil {
+			logger.Error(err, "Failed to move leadership to candidate machine", "candidate", etcdLeaderCandidate.Name)
+			return ctrl.Result{}, err
+		}
+
+		// Remove the etcd member before deleting the machine, so the replacement machine will join an etcd cluster
+		// which is not counting on the member going away for quorum.
+		// NOTE: the kcp-cleanup hook will be a no-op for this machine, given that the etcd member has already been removed.
+		if err := workloadCluster.RemoveEtcdMemberForMachine(ctx, machineToDelete); err != nil {
+			logger.Error(err, "Failed to remove etcd member for machine", "Machine", klog.KObj(machineToDelete))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.Client.Delete(ctx, machineToDelete); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete control plane machine")
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "FailedScaleDown",
+			"Failed to delete control plane Machine %s for cluster %s control plane: %v", machineToDelete.Name, klog.KObj(controlPlane.Cluster), err)
+		return ctrl.Result{}, err
+	}
+	// Note: We intentionally log after Delete because we want this log line to show up only after DeletionTimestamp has been set.
+	// Also, setting DeletionTimestamp doesn't mean the Machine is actually deleted (deletion takes some time).
+	logger.WithValues(controlPlane.StatusToLogKeyAndValues(nil, machineToDelete)...).
+		Info("Deleting Machine (scale in first)", "Machine", klog.KObj(machineToDelete))
+
+	// Requeue the control plane, in case there are additional operations to perform
+	return ctrl.Result{Requeue: true}, nil
+}
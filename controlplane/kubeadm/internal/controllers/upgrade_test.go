@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
@@ -269,6 +270,115 @@ func TestKubeadmControlPlaneReconciler_RolloutStrategy_ScaleDown(t *testing.T) {
 	g.Expect(remainingMachines.Items).To(HaveLen(2))
 }
 
+func TestKubeadmControlPlaneReconciler_scaleInFirstControlPlane(t *testing.T) {
+	// newControlPlane returns a control plane with three healthy Machines; given that the fake client sets increasing
+	// creation timestamps, Machine "one" is the oldest and thus the one selected for scale in.
+	newControlPlane := func(g *WithT, fakeClient client.Client, etcdMembers []string) (*KubeadmControlPlaneReconciler, *internal.ControlPlane, *fakeWorkloadCluster) {
+		machines := collections.Machines{}
+		for _, name := range []string{"one", "two", "three"} {
+			m := machine(name)
+			setMachineHealthy(m)
+			m.Status.NodeRef = &clusterv1.MachineNodeReference{Name: fmt.Sprintf("node-%s", name)}
+			g.Expect(fakeClient.Create(ctx, m)).To(Succeed())
+			machines.Insert(m)
+		}
+
+		workloadCluster := &fakeWorkloadCluster{EtcdMembersResult: etcdMembers}
+		r := &KubeadmControlPlaneReconciler{
+			recorder:            record.NewFakeRecorder(32),
+			Client:              fakeClient,
+			SecretCachingClient: fakeClient,
+			managementCluster: &fakeManagementCluster{
+				Workload: workloadCluster,
+			},
+		}
+
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Replicas: ptr.To[int32](3),
+				Version:  "v1.19.1",
+				RolloutStrategy: &controlplanev1.RolloutStrategy{
+					Type: controlplanev1.ScaleInFirstStrategyType,
+				},
+			},
+		}
+		setKCPHealthy(kcp)
+		controlPlane := &internal.ControlPlane{
+			KCP:      kcp,
+			Cluster:  &clusterv1.Cluster{},
+			Machines: machines,
+		}
+		controlPlane.InjectTestManagementCluster(r.managementCluster)
+		return r, controlPlane, workloadCluster
+	}
+
+	t.Run("removes the etcd member and deletes the outdated Machine if etcd retains quorum", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		r, controlPlane, workloadCluster := newControlPlane(g, fakeClient, []string{"node-one", "node-two", "node-three"})
+
+		result, err := r.scaleInFirstControlPlane(ctx, controlPlane, controlPlane.Machines)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(BeComparableTo(ctrl.Result{Requeue: true}))
+		g.Expect(workloadCluster.forwardEtcdLeadershipCalled).To(Equal(1))
+		g.Expect(workloadCluster.removeEtcdMemberForMachineCalled).To(Equal(1))
+
+		controlPlaneMachines := clusterv1.MachineList{}
+		g.Expect(fakeClient.List(ctx, &controlPlaneMachines)).To(Succeed())
+		g.Expect(controlPlaneMachines.Items).To(HaveLen(2))
+	})
+
+	t.Run("does not remove the etcd member if the etcd cluster has less members than replicas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		r, controlPlane, workloadCluster := newControlPlane(g, fakeClient, []string{"node-one", "node-two"})
+
+		result, err := r.scaleInFirstControlPlane(ctx, controlPlane, controlPlane.Machines)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(BeComparableTo(ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}))
+		g.Expect(workloadCluster.removeEtcdMemberForMachineCalled).To(Equal(0))
+		g.Expect(controlPlane.PreflightCheckResults.EtcdClusterNotHealthy).To(BeTrue())
+
+		controlPlaneMachines := clusterv1.MachineList{}
+		g.Expect(fakeClient.List(ctx, &controlPlaneMachines)).To(Succeed())
+		g.Expect(controlPlaneMachines.Items).To(HaveLen(3))
+	})
+
+	t.Run("does not remove the etcd member if etcd would lose quorum", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		r, controlPlane, workloadCluster := newControlPlane(g, fakeClient, []string{"node-one", "node-two", "node-three", "node-four", "node-five"})
+
+		result, err := r.scaleInFirstControlPlane(ctx, controlPlane, controlPlane.Machines)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(BeComparableTo(ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}))
+		g.Expect(workloadCluster.removeEtcdMemberForMachineCalled).To(Equal(0))
+
+		controlPlaneMachines := clusterv1.MachineList{}
+		g.Expect(fakeClient.List(ctx, &controlPlaneMachines)).To(Succeed())
+		g.Expect(controlPlaneMachines.Items).To(HaveLen(3))
+	})
+
+	t.Run("deletes the outdated Machine if its etcd member has already been removed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		r, controlPlane, workloadCluster := newControlPlane(g, fakeClient, []string{"node-two", "node-three"})
+
+		result, err := r.scaleInFirstControlPlane(ctx, controlPlane, controlPlane.Machines)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(BeComparableTo(ctrl.Result{Requeue: true}))
+		g.Expect(workloadCluster.removeEtcdMemberForMachineCalled).To(Equal(0))
+
+		controlPlaneMachines := clusterv1.MachineList{}
+		g.Expect(fakeClient.List(ctx, &controlPlaneMachines)).To(Succeed())
+		g.Expect(controlPlaneMachines.Items).To(HaveLen(2))
+	})
+}
+
 type machineOpt func(*clusterv1.Machine)
 
 func machine(name string, opts ...machineOpt) *clusterv1.Machine {
@@ -19,6 +19,7 @@ package etcd
 import (
 	"context"
 	"crypto/tls"
+	"io"
 	"net"
 	"time"
 
@@ -39,12 +40,15 @@ type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context) (*clientv3.MemberListResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
 	MoveLeader(ctx context.Context, id uint64) (*clientv3.MoveLeaderResponse, error)
+	Snapshot(ctx context.Context) (io.ReadCloser, error)
 	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
 }
 
@@ -66,6 +70,21 @@ type MemberAlarm struct {
 	Type AlarmType
 }
 
+// MemberStatus represents the status of the etcd member a client is connected to.
+type MemberStatus struct {
+	// MemberID is the ID of the member.
+	MemberID uint64
+
+	// IsLeader is true if the member is the leader of the cluster.
+	IsLeader bool
+
+	// DBSize is the size of the database of the member, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the database of the member which is in use, in bytes.
+	DBSizeInUse int64
+}
+
 // AlarmType defines the type of alarm for etcd.
 type AlarmType int32
 
@@ -261,3 +280,67 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// DisarmAlarm disarms the given alarm raised by a cluster member.
+func (c *Client) DisarmAlarm(ctx context.Context, alarm MemberAlarm) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	_, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: alarm.MemberID,
+		Alarm:    etcdserverpb.AlarmType(alarm.Type),
+	})
+	return errors.Wrapf(err, "failed to disarm etcd alarm %s for member: %v", AlarmTypeName[alarm.Type], alarm.MemberID)
+}
+
+// MemberStatus retrieves the status of the etcd member the client is connected to.
+func (c *Client) MemberStatus(ctx context.Context) (*MemberStatus, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	status, err := c.EtcdClient.Status(ctx, c.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get etcd status")
+	}
+
+	memberID := status.Header.GetMemberId()
+	return &MemberStatus{
+		MemberID:    memberID,
+		IsLeader:    memberID == status.Leader,
+		DBSize:      status.DbSize,
+		DBSizeInUse: status.DbSizeInUse,
+	}, nil
+}
+
+// Defragment defragments the database of the etcd member the client is connected to.
+// NOTE: The call timeout does not apply, because the time required to defragment a member depends on the size
+// of the etcd database; callers are expected to bound ctx accordingly.
+func (c *Client) Defragment(ctx context.Context) error {
+	_, err := c.EtcdClient.Defragment(ctx, c.Endpoint)
+	return errors.Wrapf(err, "failed to defragment etcd member: %s", c.Endpoint)
+}
+
+// Snapshot streams a point-in-time snapshot of the etcd member the client is connected to into w,
+// and returns the revision of the etcd store at the time the snapshot was requested.
+// NOTE: The call timeout applies only to retrieving the revision, because the time required to stream
+// a snapshot depends on the size of the etcd database; callers are expected to bound ctx accordingly.
+func (c *Client) Snapshot(ctx context.Context, w io.Writer) (int64, error) {
+	statusCtx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	status, err := c.EtcdClient.Status(statusCtx, c.Endpoint)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get etcd status")
+	}
+
+	snapshot, err := c.EtcdClient.Snapshot(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to take etcd snapshot")
+	}
+	defer snapshot.Close()
+
+	if _, err := io.Copy(w, snapshot); err != nil {
+		return 0, errors.Wrap(err, "failed to read etcd snapshot")
+	}
+	return status.Header.GetRevision(), nil
+}
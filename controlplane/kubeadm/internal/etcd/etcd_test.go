@@ -17,6 +17,7 @@ limitations under the License.
 package etcd
 
 import (
+	"bytes"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -61,6 +62,15 @@ func TestEtcdMembers_WithErrors(t *testing.T) {
 
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).To(HaveOccurred())
+
+	_, err = client.Snapshot(ctx, &bytes.Buffer{})
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.Defragment(ctx)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})
+	g.Expect(err).To(HaveOccurred())
 }
 
 func TestEtcdMembers_WithSuccess(t *testing.T) {
@@ -93,3 +103,92 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).ToNot(HaveOccurred())
 }
+
+func TestEtcdSnapshot(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeEtcdClient := &etcdfake.FakeEtcdClient{
+		EtcdEndpoints: []string{"https://etcd-instance:2379"},
+		StatusResponse: &clientv3.StatusResponse{
+			Header: &etcdserverpb.ResponseHeader{Revision: 42},
+		},
+		SnapshotData: []byte("snapshot"),
+	}
+
+	client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	snapshot := &bytes.Buffer{}
+	revision, err := client.Snapshot(ctx, snapshot)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(revision).To(Equal(int64(42)))
+	g.Expect(snapshot.String()).To(Equal("snapshot"))
+}
+
+func TestEtcdMemberStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusResponse *clientv3.StatusResponse
+		expectedStatus *MemberStatus
+	}{
+		{
+			name: "leader",
+			statusResponse: &clientv3.StatusResponse{
+				Header:      &etcdserverpb.ResponseHeader{MemberId: 1234},
+				Leader:      1234,
+				DbSize:      100,
+				DbSizeInUse: 40,
+			},
+			expectedStatus: &MemberStatus{MemberID: 1234, IsLeader: true, DBSize: 100, DBSizeInUse: 40},
+		},
+		{
+			name: "follower",
+			statusResponse: &clientv3.StatusResponse{
+				Header:      &etcdserverpb.ResponseHeader{MemberId: 1234},
+				Leader:      5678,
+				DbSize:      100,
+				DbSizeInUse: 100,
+			},
+			expectedStatus: &MemberStatus{MemberID: 1234, IsLeader: false, DBSize: 100, DBSizeInUse: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeEtcdClient := &etcdfake.FakeEtcdClient{
+				EtcdEndpoints:  []string{"https://etcd-instance:2379"},
+				StatusResponse: tt.statusResponse,
+			}
+
+			client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			status, err := client.MemberStatus(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(status).To(Equal(tt.expectedStatus))
+		})
+	}
+}
+
+func TestEtcdDefragmentAndDisarmAlarm(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeEtcdClient := &etcdfake.FakeEtcdClient{
+		EtcdEndpoints:      []string{"https://etcd-instance:2379"},
+		StatusResponse:     &clientv3.StatusResponse{},
+		AlarmResponse:      &clientv3.AlarmResponse{},
+		DefragmentResponse: &clientv3.DefragmentResponse{},
+	}
+
+	client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = client.Defragment(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeEtcdClient.Defragmented).To(Equal("https://etcd-instance:2379"))
+
+	err = client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeEtcdClient.DisarmedAlarm).To(Equal(&clientv3.AlarmMember{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}))
+}
@@ -97,6 +97,13 @@ func UpToDate(machine *clusterv1.Machine, kcp *controlplanev1.KubeadmControlPlan
 		conditionMessages = append(conditionMessages, "KubeadmControlPlane spec.rolloutAfter expired")
 	}
 
+	// Machines that must be rolled out because of a rotation of the certificate authorities of the cluster
+	// (a rotation is in progress and the machine was created before the current phase of the rotation started).
+	if collections.ShouldRolloutAfter(reconciliationTime, certificateAuthorityRotationPhaseStartTime(kcp))(machine) {
+		logMessages = append(logMessages, "certificate authority rotation in progress")
+		conditionMessages = append(conditionMessages, "Certificate authority rotation in progress")
+	}
+
 	// Machines that do not match with KCP config.
 	matches, specLogMessages, specConditionMessages, err := matchesMachineSpec(infraConfigs, machineConfigs, kcp, machine)
 	if err != nil {
@@ -114,6 +121,16 @@ func UpToDate(machine *clusterv1.Machine, kcp *controlplanev1.KubeadmControlPlan
 	return true, nil, nil, nil
 }
 
+// certificateAuthorityRotationPhaseStartTime returns the start time of the current phase of the rotation
+// of the certificate authorities of the cluster, or nil if a rotation is not in progress.
+func certificateAuthorityRotationPhaseStartTime(kcp *controlplanev1.KubeadmControlPlane) *metav1.Time {
+	rotation := kcp.Status.CertificateAuthorityRotation
+	if rotation == nil || rotation.Phase == controlplanev1.CertificateAuthorityRotationCompletedPhase {
+		return nil
+	}
+	return &rotation.PhaseStartTime
+}
+
 // matchesTemplateClonedFrom checks if a Machine has a corresponding infrastructure machine that
 // matches a given KCP infra template and if it doesn't match returns the reason why.
 // Note: Differences to the labels and annotations on the infrastructure machine are not considered for matching
@@ -362,6 +379,11 @@ func cleanupConfigFields(kcpConfig *bootstrapv1.KubeadmConfigSpec, machineConfig
 	kcpConfig.ClusterConfiguration = nil
 	machineConfig.Spec.ClusterConfiguration = nil
 
+	// EtcdRestore is set by KCP only on the Machine restoring etcd from a snapshot, and it is relevant only for
+	// the bootstrap of that Machine, so we are cleaning up from the reflect.DeepEqual comparison.
+	kcpConfig.EtcdRestore = nil
+	machineConfig.Spec.EtcdRestore = nil
+
 	// If KCP JoinConfiguration is not present, set machine JoinConfiguration to nil (nothing can trigger rollout here).
 	// NOTE: this is required because CABPK applies an empty joinConfiguration in case no one is provided.
 	if kcpConfig.JoinConfiguration == nil {
@@ -195,12 +195,13 @@ func TestMatchClusterConfiguration(t *testing.T) {
 		g.Expect(match).To(BeFalse())
 		g.Expect(diff).To(BeComparableTo(`&v1beta2.ClusterConfiguration{
     ... // 3 identical fields
-    Scheduler:       {},
-    DNS:             {},
--   CertificatesDir: "bar",
-+   CertificatesDir: "foo",
-    ImageRepository: "",
-    FeatureGates:    nil,
+    Scheduler:           {},
+    DNS:                 {},
+-   CertificatesDir:     "bar",
++   CertificatesDir:     "foo",
+    ImageRepository:     "",
+    FeatureGates:        nil,
+    EncryptionAlgorithm: "",
   }`))
 	})
 	t.Run("Return true if cluster configuration is nil (special case)", func(t *testing.T) {
@@ -371,6 +372,23 @@ func TestCleanupConfigFields(t *testing.T) {
 		g.Expect(kcpConfig.ClusterConfiguration).To(BeNil())
 		g.Expect(machineConfig.Spec.ClusterConfiguration).To(BeNil())
 	})
+	t.Run("EtcdRestore gets removed from MachineConfig", func(t *testing.T) {
+		g := NewWithT(t)
+		kcpConfig := &bootstrapv1.KubeadmConfigSpec{}
+		machineConfig := &bootstrapv1.KubeadmConfig{
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				EtcdRestore: &bootstrapv1.EtcdRestoreSource{ // Machine restoring etcd gets EtcdRestore from KCP
+					SnapshotURL: &bootstrapv1.EtcdSnapshotURL{
+						URL:    "https://kcp.example.com/etcd-snapshots/default/foo/snapshot?token=token",
+						SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+					},
+				},
+			},
+		}
+		cleanupConfigFields(kcpConfig, machineConfig)
+		g.Expect(kcpConfig.EtcdRestore).To(BeNil())
+		g.Expect(machineConfig.Spec.EtcdRestore).To(BeNil())
+	})
 	t.Run("JoinConfiguration gets removed from MachineConfig if it was not derived by KCPConfig", func(t *testing.T) {
 		g := NewWithT(t)
 		kcpConfig := &bootstrapv1.KubeadmConfigSpec{
@@ -667,7 +685,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
     },
     JoinConfiguration: nil,
     Files:             nil,
-    ... // 10 identical fields
+    ... // 11 identical fields
   }`))
 	})
 	t.Run("returns true if JoinConfiguration is equal", func(t *testing.T) {
@@ -789,7 +807,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
     },
     Files:     nil,
     DiskSetup: nil,
-    ... // 9 identical fields
+    ... // 10 identical fields
   }`))
 	})
 	t.Run("returns false if some other configurations are not equal", func(t *testing.T) {
@@ -849,7 +867,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 +   Files:                []v1beta2.File{},
     DiskSetup:            nil,
     Mounts:               nil,
-    ... // 8 identical fields
+    ... // 9 identical fields
   }`))
 	})
 }
@@ -907,12 +925,13 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
 		g.Expect(match).To(BeFalse())
 		g.Expect(reason).To(BeComparableTo(`Machine KubeadmConfig ClusterConfiguration is outdated: diff: &v1beta2.ClusterConfiguration{
     ... // 3 identical fields
-    Scheduler:       {},
-    DNS:             {},
--   CertificatesDir: "bar",
-+   CertificatesDir: "foo",
-    ImageRepository: "",
-    FeatureGates:    nil,
+    Scheduler:           {},
+    DNS:                 {},
+-   CertificatesDir:     "bar",
++   CertificatesDir:     "foo",
+    ImageRepository:     "",
+    FeatureGates:        nil,
+    EncryptionAlgorithm: "",
   }`))
 	})
 	t.Run("returns true if InitConfiguration is equal", func(t *testing.T) {
@@ -1034,7 +1053,7 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
     },
     JoinConfiguration: nil,
     Files:             nil,
-    ... // 10 identical fields
+    ... // 11 identical fields
   }`))
 	})
 	t.Run("returns true if JoinConfiguration is equal", func(t *testing.T) {
@@ -1156,7 +1175,7 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
     },
     Files:     nil,
     DiskSetup: nil,
-    ... // 9 identical fields
+    ... // 10 identical fields
   }`))
 	})
 	t.Run("returns false if some other configurations are not equal", func(t *testing.T) {
@@ -1216,7 +1235,7 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
 +   Files:                []v1beta2.File{},
     DiskSetup:            nil,
     Mounts:               nil,
-    ... // 8 identical fields
+    ... // 9 identical fields
   }`))
 	})
 	t.Run("should match on labels and annotations", func(t *testing.T) {
@@ -1637,6 +1656,42 @@ func TestUpToDate(t *testing.T) {
 			expectLogMessages:       []string{"rolloutAfter expired"},
 			expectConditionMessages: []string{"KubeadmControlPlane spec.rolloutAfter expired"},
 		},
+		{
+			name: "certificate authority rotation in progress",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Status.CertificateAuthorityRotation = &controlplanev1.CertificateAuthorityRotationStatus{
+					Phase:          controlplanev1.CertificateAuthorityRotationSwitchingSigningCAPhase,
+					StartTime:      metav1.Time{Time: reconciliationTime.Add(-1 * 24 * time.Hour)}, // one day ago
+					PhaseStartTime: metav1.Time{Time: reconciliationTime.Add(-1 * time.Hour)},      // one hour ago
+				}
+				return kcp
+			}(),
+			machine:                 defaultMachine, // created two days ago
+			infraConfigs:            defaultInfraConfigs,
+			machineConfigs:          defaultMachineConfigs,
+			expectUptoDate:          false,
+			expectLogMessages:       []string{"certificate authority rotation in progress"},
+			expectConditionMessages: []string{"Certificate authority rotation in progress"},
+		},
+		{
+			name: "certificate authority rotation completed",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Status.CertificateAuthorityRotation = &controlplanev1.CertificateAuthorityRotationStatus{
+					Phase:          controlplanev1.CertificateAuthorityRotationCompletedPhase,
+					StartTime:      metav1.Time{Time: reconciliationTime.Add(-1 * 24 * time.Hour)}, // one day ago
+					PhaseStartTime: metav1.Time{Time: reconciliationTime.Add(-1 * time.Hour)},      // one hour ago
+				}
+				return kcp
+			}(),
+			machine:                 defaultMachine, // created two days ago
+			infraConfigs:            defaultInfraConfigs,
+			machineConfigs:          defaultMachineConfigs,
+			expectUptoDate:          true,
+			expectLogMessages:       nil,
+			expectConditionMessages: nil,
+		},
 		{
 			name: "kubernetes version does not match",
 			kcp: func() *controlplanev1.KubeadmControlPlane {
@@ -1662,7 +1717,7 @@ func TestUpToDate(t *testing.T) {
 			infraConfigs:            defaultInfraConfigs,
 			machineConfigs:          defaultMachineConfigs,
 			expectUptoDate:          false,
-			expectLogMessages:       []string{"Machine KubeadmConfig ClusterConfiguration is outdated: diff: &v1beta2.ClusterConfiguration{\n    ... // 3 identical fields\n    Scheduler:       {},\n    DNS:             {},\n-   CertificatesDir: \"foo\",\n+   CertificatesDir: \"bar\",\n    ImageRepository: \"\",\n    FeatureGates:    nil,\n  }"},
+			expectLogMessages:       []string{"Machine KubeadmConfig ClusterConfiguration is outdated: diff: &v1beta2.ClusterConfiguration{\n    ... // 3 identical fields\n    Scheduler:           {},\n    DNS:                 {},\n-   CertificatesDir:     \"foo\",\n+   CertificatesDir:     \"bar\",\n    ImageRepository:     \"\",\n    FeatureGates:        nil,\n    EncryptionAlgorithm: \"\",\n  }"},
 			expectConditionMessages: []string{"KubeadmConfig is not up-to-date"},
 		},
 		{
@@ -169,6 +169,7 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, kubeadmConfigSpec, clusterConfiguration, "dns"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, "dns", "*"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, "imageRepository"},
+		{spec, kubeadmConfigSpec, clusterConfiguration, "encryptionAlgorithm"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, featureGates},
 		{spec, kubeadmConfigSpec, clusterConfiguration, featureGates, "*"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, apiServer},
@@ -237,6 +238,14 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, "rolloutBefore", "*"},
 		{spec, "rolloutStrategy"},
 		{spec, "rolloutStrategy", "*"},
+		{spec, "certificateAuthorityRotation"},
+		{spec, "certificateAuthorityRotation", "*"},
+		{spec, "etcdBackup"},
+		{spec, "etcdBackup", "*"},
+		{spec, "etcdRestore"},
+		{spec, "etcdRestore", "*"},
+		{spec, "etcdDefragmentation"},
+		{spec, "etcdDefragmentation", "*"},
 	}
 
 	oldK, ok := oldObj.(*controlplanev1.KubeadmControlPlane)
@@ -340,6 +349,69 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, p
 		}
 	}
 
+	if externalEtcd && s.EtcdBackup != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("etcdBackup"),
+				"cannot be set when etcd is external",
+			),
+		)
+	}
+
+	if s.EtcdBackup != nil {
+		sinkPath := pathPrefix.Child("etcdBackup", "sink")
+		switch {
+		case s.EtcdBackup.Sink.Type == controlplanev1.ObjectStoreEtcdBackupSinkType && s.EtcdBackup.Sink.ObjectStore == nil:
+			allErrs = append(
+				allErrs,
+				field.Required(
+					sinkPath.Child("objectStore"),
+					"must be set when type is ObjectStore",
+				),
+			)
+		case s.EtcdBackup.Sink.Type != controlplanev1.ObjectStoreEtcdBackupSinkType && s.EtcdBackup.Sink.ObjectStore != nil:
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					sinkPath.Child("objectStore"),
+					"can only be set when type is ObjectStore",
+				),
+			)
+		}
+	}
+
+	if externalEtcd && s.EtcdRestore != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("etcdRestore"),
+				"cannot be set when etcd is external",
+			),
+		)
+	}
+
+	if externalEtcd && s.EtcdDefragmentation != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("etcdDefragmentation"),
+				"cannot be set when etcd is external",
+			),
+		)
+	}
+
+	// KubeadmConfigSpec.EtcdRestore is set by KCP on the Machine restoring etcd, use spec.etcdRestore instead.
+	if s.KubeadmConfigSpec.EtcdRestore != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("kubeadmConfigSpec", "etcdRestore"),
+				"cannot be set, use spec.etcdRestore instead",
+			),
+		)
+	}
+
 	if s.MachineTemplate.InfrastructureRef.APIGroup == "" {
 		allErrs = append(
 			allErrs,
@@ -410,20 +482,36 @@ func validateRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy, re
 		return allErrs
 	}
 
-	if rolloutStrategy.Type != controlplanev1.RollingUpdateStrategyType {
+	switch rolloutStrategy.Type {
+	case controlplanev1.RollingUpdateStrategyType:
+		allErrs = append(allErrs, validateRollingUpdate(rolloutStrategy.RollingUpdate, replicas, pathPrefix)...)
+	case controlplanev1.ScaleInFirstStrategyType:
+		allErrs = append(allErrs, validateScaleInFirst(rolloutStrategy, replicas, pathPrefix)...)
+	default:
 		allErrs = append(
 			allErrs,
-			field.Required(
+			field.NotSupported(
 				pathPrefix.Child("type"),
-				"only RollingUpdateStrategyType is supported",
+				rolloutStrategy.Type,
+				[]string{string(controlplanev1.RollingUpdateStrategyType), string(controlplanev1.ScaleInFirstStrategyType)},
 			),
 		)
 	}
 
+	return allErrs
+}
+
+func validateRollingUpdate(rollingUpdate *controlplanev1.RollingUpdate, replicas *int32, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rollingUpdate == nil {
+		return allErrs
+	}
+
 	ios1 := intstr.FromInt(1)
 	ios0 := intstr.FromInt(0)
 
-	if rolloutStrategy.RollingUpdate.MaxSurge.IntValue() == ios0.IntValue() && (replicas != nil && *replicas < int32(3)) {
+	if rollingUpdate.MaxSurge.IntValue() == ios0.IntValue() && (replicas != nil && *replicas < int32(3)) {
 		allErrs = append(
 			allErrs,
 			field.Required(
@@ -433,7 +521,7 @@ func validateRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy, re
 		)
 	}
 
-	if rolloutStrategy.RollingUpdate.MaxSurge.IntValue() != ios1.IntValue() && rolloutStrategy.RollingUpdate.MaxSurge.IntValue() != ios0.IntValue() {
+	if rollingUpdate.MaxSurge.IntValue() != ios1.IntValue() && rollingUpdate.MaxSurge.IntValue() != ios0.IntValue() {
 		allErrs = append(
 			allErrs,
 			field.Required(
@@ -446,6 +534,34 @@ func validateRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy, re
 	return allErrs
 }
 
+func validateScaleInFirst(rolloutStrategy *controlplanev1.RolloutStrategy, replicas *int32, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rolloutStrategy.RollingUpdate != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("rollingUpdate"),
+				fmt.Sprintf("cannot be set when type is %s", controlplanev1.ScaleInFirstStrategyType),
+			),
+		)
+	}
+
+	// Removing a member before its replacement is created must not cause the loss of etcd quorum; this requires
+	// at least 3 members, because a 2 members etcd cluster can't tolerate any failure.
+	if replicas != nil && *replicas < int32(3) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("type"),
+				fmt.Sprintf("when type is %s, replica count needs to be at least 3", controlplanev1.ScaleInFirstStrategyType),
+			),
+		)
+	}
+
+	return allErrs
+}
+
 func validateNamingStrategy(machineNamingStrategy *controlplanev1.MachineNamingStrategy, pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
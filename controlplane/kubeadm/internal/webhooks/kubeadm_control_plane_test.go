@@ -112,6 +112,22 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	val := intstr.FromString("1")
 	stringMaxSurge.Spec.RolloutStrategy.RollingUpdate.MaxSurge = &val
 
+	scaleInFirst := valid.DeepCopy()
+	scaleInFirst.Spec.Replicas = ptr.To[int32](3)
+	scaleInFirst.Spec.RolloutStrategy = &controlplanev1.RolloutStrategy{
+		Type: controlplanev1.ScaleInFirstStrategyType,
+	}
+
+	scaleInFirstWithRollingUpdate := valid.DeepCopy()
+	scaleInFirstWithRollingUpdate.Spec.Replicas = ptr.To[int32](3)
+	scaleInFirstWithRollingUpdate.Spec.RolloutStrategy.Type = controlplanev1.ScaleInFirstStrategyType
+
+	scaleInFirstSingleReplica := scaleInFirst.DeepCopy()
+	scaleInFirstSingleReplica.Spec.Replicas = ptr.To[int32](1)
+
+	invalidRolloutStrategyType := valid.DeepCopy()
+	invalidRolloutStrategyType.Spec.RolloutStrategy.Type = "OnDelete"
+
 	missingReplicas := valid.DeepCopy()
 	missingReplicas.Spec.Replicas = nil
 
@@ -130,6 +146,58 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 		},
 	}
 
+	etcdBackup := valid.DeepCopy()
+	etcdBackup.Spec.EtcdBackup = &controlplanev1.EtcdBackupPolicy{
+		IntervalSeconds: 3600,
+		Sink:            controlplanev1.EtcdBackupSink{Type: controlplanev1.SecretEtcdBackupSinkType},
+	}
+
+	etcdBackupExternalEtcd := evenReplicasExternalEtcd.DeepCopy()
+	etcdBackupExternalEtcd.Spec.EtcdBackup = etcdBackup.Spec.EtcdBackup.DeepCopy()
+
+	etcdBackupObjectStore := valid.DeepCopy()
+	etcdBackupObjectStore.Spec.EtcdBackup = &controlplanev1.EtcdBackupPolicy{
+		IntervalSeconds: 3600,
+		Sink: controlplanev1.EtcdBackupSink{
+			Type: controlplanev1.ObjectStoreEtcdBackupSinkType,
+			ObjectStore: &controlplanev1.EtcdBackupObjectStoreSink{
+				URL:                   "https://object-store.example.com/etcd",
+				CredentialsSecretName: "object-store-credentials",
+			},
+		},
+	}
+
+	etcdBackupObjectStoreMissing := etcdBackupObjectStore.DeepCopy()
+	etcdBackupObjectStoreMissing.Spec.EtcdBackup.Sink.ObjectStore = nil
+
+	etcdBackupSecretWithObjectStore := etcdBackupObjectStore.DeepCopy()
+	etcdBackupSecretWithObjectStore.Spec.EtcdBackup.Sink.Type = controlplanev1.SecretEtcdBackupSinkType
+
+	etcdRestore := valid.DeepCopy()
+	etcdRestore.Spec.EtcdRestore = &controlplanev1.EtcdRestore{
+		SnapshotName: "test-etcd-20250101000000",
+		RestoreAfter: metav1.Now(),
+	}
+
+	etcdRestoreExternalEtcd := evenReplicasExternalEtcd.DeepCopy()
+	etcdRestoreExternalEtcd.Spec.EtcdRestore = etcdRestore.Spec.EtcdRestore.DeepCopy()
+
+	etcdDefragmentation := valid.DeepCopy()
+	etcdDefragmentation.Spec.EtcdDefragmentation = &controlplanev1.EtcdDefragmentationPolicy{
+		FragmentationThresholdPercent: 50,
+	}
+
+	etcdDefragmentationExternalEtcd := evenReplicasExternalEtcd.DeepCopy()
+	etcdDefragmentationExternalEtcd.Spec.EtcdDefragmentation = etcdDefragmentation.Spec.EtcdDefragmentation.DeepCopy()
+
+	etcdRestoreInKubeadmConfigSpec := valid.DeepCopy()
+	etcdRestoreInKubeadmConfigSpec.Spec.KubeadmConfigSpec.EtcdRestore = &bootstrapv1.EtcdRestoreSource{
+		SnapshotURL: &bootstrapv1.EtcdSnapshotURL{
+			URL:    "https://example.com/snapshot.db.gz",
+			SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+	}
+
 	validVersion := valid.DeepCopy()
 	validVersion.Spec.Version = "v1.16.6"
 
@@ -202,6 +270,56 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: false,
 			kcp:       evenReplicasExternalEtcd,
 		},
+		{
+			name:      "should allow etcd backups when using stacked etcd",
+			expectErr: false,
+			kcp:       etcdBackup,
+		},
+		{
+			name:      "should return error when setting etcd backups when using external etcd",
+			expectErr: true,
+			kcp:       etcdBackupExternalEtcd,
+		},
+		{
+			name:      "should allow etcd backups stored in an object store",
+			expectErr: false,
+			kcp:       etcdBackupObjectStore,
+		},
+		{
+			name:      "should return error when the object store of the etcd backup sink is not set for the ObjectStore sink",
+			expectErr: true,
+			kcp:       etcdBackupObjectStoreMissing,
+		},
+		{
+			name:      "should return error when the object store of the etcd backup sink is set for the Secret sink",
+			expectErr: true,
+			kcp:       etcdBackupSecretWithObjectStore,
+		},
+		{
+			name:      "should allow etcd restore when using stacked etcd",
+			expectErr: false,
+			kcp:       etcdRestore,
+		},
+		{
+			name:      "should return error when setting etcd restore when using external etcd",
+			expectErr: true,
+			kcp:       etcdRestoreExternalEtcd,
+		},
+		{
+			name:      "should allow etcd defragmentation when using stacked etcd",
+			expectErr: false,
+			kcp:       etcdDefragmentation,
+		},
+		{
+			name:      "should return error when setting etcd defragmentation when using external etcd",
+			expectErr: true,
+			kcp:       etcdDefragmentationExternalEtcd,
+		},
+		{
+			name:      "should return error when setting etcdRestore in kubeadmConfigSpec",
+			expectErr: true,
+			kcp:       etcdRestoreInKubeadmConfigSpec,
+		},
 		{
 			name:      "should succeed when given a valid semantic version with prepended 'v'",
 			expectErr: false,
@@ -232,6 +350,26 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: false,
 			kcp:       stringMaxSurge,
 		},
+		{
+			name:      "should succeed when rolloutStrategy is ScaleInFirst",
+			expectErr: false,
+			kcp:       scaleInFirst,
+		},
+		{
+			name:      "should return error when rolloutStrategy is ScaleInFirst and rollingUpdate is set",
+			expectErr: true,
+			kcp:       scaleInFirstWithRollingUpdate,
+		},
+		{
+			name:      "should return error when rolloutStrategy is ScaleInFirst and replicas is less than 3",
+			expectErr: true,
+			kcp:       scaleInFirstSingleReplica,
+		},
+		{
+			name:      "should return error when rolloutStrategy type is not supported",
+			expectErr: true,
+			kcp:       invalidRolloutStrategyType,
+		},
 		{
 			name:      "should return error when given an invalid rolloutBefore.certificatesExpiryDays value",
 			expectErr: true,
@@ -594,6 +732,9 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	featureGates := before.DeepCopy()
 	featureGates.Spec.KubeadmConfigSpec.ClusterConfiguration.FeatureGates = map[string]bool{"a feature gate": true}
 
+	encryptionAlgorithm := before.DeepCopy()
+	encryptionAlgorithm.Spec.KubeadmConfigSpec.ClusterConfiguration.EncryptionAlgorithm = bootstrapv1.EncryptionAlgorithmECDSAP256
+
 	externalEtcd := before.DeepCopy()
 	externalEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External = &bootstrapv1.ExternalEtcd{
 		KeyFile: "some key file",
@@ -673,6 +814,30 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	unsetRolloutBefore := before.DeepCopy()
 	unsetRolloutBefore.Spec.RolloutBefore = nil
 
+	setCertificateAuthorityRotation := before.DeepCopy()
+	setCertificateAuthorityRotation.Spec.CertificateAuthorityRotation = &controlplanev1.CertificateAuthorityRotation{
+		RotateAfter: metav1.Now(),
+	}
+
+	setEtcdBackup := before.DeepCopy()
+	setEtcdBackup.Spec.EtcdBackup = &controlplanev1.EtcdBackupPolicy{
+		IntervalSeconds: 3600,
+		MaxSnapshots:    ptr.To[int32](5),
+		Sink:            controlplanev1.EtcdBackupSink{Type: controlplanev1.SecretEtcdBackupSinkType},
+	}
+
+	setEtcdRestore := before.DeepCopy()
+	setEtcdRestore.Spec.EtcdRestore = &controlplanev1.EtcdRestore{
+		SnapshotName: "test-etcd-20250101000000",
+		RestoreAfter: metav1.Now(),
+	}
+
+	setEtcdDefragmentation := before.DeepCopy()
+	setEtcdDefragmentation.Spec.EtcdDefragmentation = &controlplanev1.EtcdDefragmentationPolicy{
+		FragmentationThresholdPercent: 50,
+		MinDBSizeBytes:                ptr.To[int64](100 * 1024 * 1024),
+	}
+
 	invalidIgnitionConfiguration := before.DeepCopy()
 	invalidIgnitionConfiguration.Spec.KubeadmConfigSpec.Ignition = &bootstrapv1.IgnitionSpec{}
 
@@ -918,6 +1083,12 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       featureGates,
 		},
+		{
+			name:      "should succeed when making a change to the cluster config's encryptionAlgorithm",
+			expectErr: false,
+			before:    before,
+			kcp:       encryptionAlgorithm,
+		},
 		{
 			name:      "should succeed when making a change to the cluster config's local etcd's configuration localDataDir field",
 			expectErr: false,
@@ -1044,6 +1215,30 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       unsetRolloutBefore,
 		},
+		{
+			name:      "should allow setting certificateAuthorityRotation",
+			expectErr: false,
+			before:    before,
+			kcp:       setCertificateAuthorityRotation,
+		},
+		{
+			name:      "should allow setting etcdBackup",
+			expectErr: false,
+			before:    before,
+			kcp:       setEtcdBackup,
+		},
+		{
+			name:      "should allow setting etcdRestore",
+			expectErr: false,
+			before:    before,
+			kcp:       setEtcdRestore,
+		},
+		{
+			name:      "should allow setting etcdDefragmentation",
+			expectErr: false,
+			before:    before,
+			kcp:       setEtcdDefragmentation,
+		},
 		{
 			name:                  "should return error when Ignition configuration is invalid",
 			enableIgnitionFeature: true,
@@ -20,11 +20,11 @@ import (
 	"context"
 	"crypto"
 	"crypto/rand"
-	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 	"time"
@@ -94,6 +94,7 @@ type WorkloadCluster interface {
 	UpdateAPIServerInKubeadmConfigMap(apiServer bootstrapv1.APIServer) func(*bootstrapv1.ClusterConfiguration)
 	UpdateControllerManagerInKubeadmConfigMap(controllerManager bootstrapv1.ControlPlaneComponent) func(*bootstrapv1.ClusterConfiguration)
 	UpdateSchedulerInKubeadmConfigMap(scheduler bootstrapv1.ControlPlaneComponent) func(*bootstrapv1.ClusterConfiguration)
+	UpdateEncryptionAlgorithmInKubeadmConfigMap(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) func(*bootstrapv1.ClusterConfiguration)
 	UpdateKubeProxyImageInfo(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error
 	UpdateCoreDNS(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error
 	RemoveEtcdMemberForMachine(ctx context.Context, machine *clusterv1.Machine) error
@@ -101,8 +102,17 @@ type WorkloadCluster interface {
 	AllowClusterAdminPermissions(ctx context.Context, version semver.Version) error
 	UpdateClusterConfiguration(ctx context.Context, version semver.Version, mutators ...func(*bootstrapv1.ClusterConfiguration)) error
 
+	// Certificate authority rotation related tasks.
+	UpdateClusterInfoCertificateAuthority(ctx context.Context, caData []byte) error
+
 	// State recovery tasks.
 	ReconcileEtcdMembersAndControlPlaneNodes(ctx context.Context, members []*etcd.Member, nodeNames []string) ([]string, error)
+	SaveEtcdSnapshot(ctx context.Context, writer io.Writer) (int64, error)
+	DeleteControlPlaneNodesNotIn(ctx context.Context, nodeNames []string) ([]string, error)
+
+	// Etcd maintenance tasks.
+	GetEtcdMemberStatus(ctx context.Context, nodeName string) (*etcd.MemberStatus, error)
+	DefragmentEtcdMember(ctx context.Context, nodeName string) error
 }
 
 // Workload defines operations on workload clusters.
@@ -219,6 +229,13 @@ func (w *Workload) UpdateSchedulerInKubeadmConfigMap(scheduler bootstrapv1.Contr
 	}
 }
 
+// UpdateEncryptionAlgorithmInKubeadmConfigMap updates the encryption algorithm in kubeadm config map.
+func (w *Workload) UpdateEncryptionAlgorithmInKubeadmConfigMap(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) func(*bootstrapv1.ClusterConfiguration) {
+	return func(c *bootstrapv1.ClusterConfiguration) {
+		c.EncryptionAlgorithm = encryptionAlgorithm
+	}
+}
+
 // UpdateClusterConfiguration gets the ClusterConfiguration kubeadm-config ConfigMap, converts it to the
 // Cluster API representation, and then applies a mutation func; if changes are detected, the
 // data are converted back into the Kubeadm API version in use for the target Kubernetes version and the
@@ -308,6 +325,30 @@ func (w *Workload) ClusterStatus(ctx context.Context) (ClusterStatus, error) {
 	return status, nil
 }
 
+// DeleteControlPlaneNodesNotIn deletes the control plane Nodes not in nodeNames, and returns the names of the deleted Nodes.
+// This is required e.g. after etcd has been restored from a snapshot, because the snapshot contains the Nodes of
+// control plane Machines which do not exist anymore.
+func (w *Workload) DeleteControlPlaneNodesNotIn(ctx context.Context, nodeNames []string) ([]string, error) {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list control plane nodes")
+	}
+
+	keep := sets.New(nodeNames...)
+	deletedNodes := []string{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if keep.Has(node.Name) {
+			continue
+		}
+		if err := w.Client.Delete(ctx, node); err != nil && !apierrors.IsNotFound(err) {
+			return deletedNodes, errors.Wrapf(err, "failed to delete Node %s", node.Name)
+		}
+		deletedNodes = append(deletedNodes, node.Name)
+	}
+	return deletedNodes, nil
+}
+
 // GetAPIServerCertificateExpiry returns the certificate expiry of the apiserver on the given node.
 func (w *Workload) GetAPIServerCertificateExpiry(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error) {
 	// Create a proxy.
@@ -369,7 +410,7 @@ func calculateAPIServerPort(config *bootstrapv1.KubeadmConfig) int32 {
 	return 6443
 }
 
-func generateClientCert(caCertEncoded, caKeyEncoded []byte, clientKey *rsa.PrivateKey) (tls.Certificate, error) {
+func generateClientCert(caCertEncoded, caKeyEncoded []byte, clientKey crypto.Signer) (tls.Certificate, error) {
 	caCert, err := certs.DecodeCertPEM(caCertEncoded)
 	if err != nil {
 		return tls.Certificate{}, err
@@ -382,10 +423,14 @@ func generateClientCert(caCertEncoded, caKeyEncoded []byte, clientKey *rsa.Priva
 	if err != nil {
 		return tls.Certificate{}, err
 	}
-	return tls.X509KeyPair(certs.EncodeCertPEM(x509Cert), certs.EncodePrivateKeyPEM(clientKey))
+	clientKeyEncoded, err := certs.EncodePrivateKeyPEMFromSigner(clientKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certs.EncodeCertPEM(x509Cert), clientKeyEncoded)
 }
 
-func newClientCert(caCert *x509.Certificate, key *rsa.PrivateKey, caKey crypto.Signer) (*x509.Certificate, error) {
+func newClientCert(caCert *x509.Certificate, key crypto.Signer, caKey crypto.Signer) (*x509.Certificate, error) {
 	cfg := certs.Config{
 		CommonName: "cluster-api.x-k8s.io",
 	}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+const (
+	clusterInfoConfigMapName = "cluster-info"
+	clusterInfoKubeconfigKey = "kubeconfig"
+)
+
+// UpdateClusterInfoCertificateAuthority sets the certificate authority data in the kubeconfig stored in the
+// kube-public/cluster-info ConfigMap, which is used by kubeadm join to discover and validate the cluster CA.
+// NOTE: The JWS signatures of the kubeconfig are refreshed by the bootstrap signer in kube-controller-manager.
+func (w *Workload) UpdateClusterInfoCertificateAuthority(ctx context.Context, caData []byte) error {
+	clusterInfo := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: metav1.NamespacePublic, Name: clusterInfoConfigMapName}
+	if err := w.Client.Get(ctx, key, clusterInfo); err != nil {
+		return errors.Wrapf(err, "failed to get %s ConfigMap", key)
+	}
+
+	config, err := clientcmd.Load([]byte(clusterInfo.Data[clusterInfoKubeconfigKey]))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the kubeconfig in %s ConfigMap", key)
+	}
+	if len(config.Clusters) == 0 {
+		return errors.Errorf("failed to find clusters in the kubeconfig in %s ConfigMap", key)
+	}
+
+	changed := false
+	for _, cluster := range config.Clusters {
+		if !bytes.Equal(cluster.CertificateAuthorityData, caData) {
+			cluster.CertificateAuthorityData = caData
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	out, err := clientcmd.Write(*config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize the kubeconfig in %s ConfigMap", key)
+	}
+
+	patchHelper, err := patch.NewHelper(clusterInfo, w.Client)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create patch helper for %s ConfigMap", key)
+	}
+	clusterInfo.Data[clusterInfoKubeconfigKey] = string(out)
+	if err := patchHelper.Patch(ctx, clusterInfo); err != nil {
+		return errors.Wrapf(err, "failed to update %s ConfigMap", key)
+	}
+	return nil
+}
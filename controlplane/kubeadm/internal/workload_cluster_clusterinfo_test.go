@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateClusterInfoCertificateAuthority(t *testing.T) {
+	clusterInfoKubeconfig := func(caData []byte) string {
+		out, err := clientcmd.Write(clientcmdapi.Config{
+			Clusters: map[string]*clientcmdapi.Cluster{
+				"": {
+					Server:                   "https://test.local:6443",
+					CertificateAuthorityData: caData,
+				},
+			},
+		})
+		if err != nil {
+			panic(err)
+		}
+		return string(out)
+	}
+
+	tests := []struct {
+		name       string
+		objs       []client.Object
+		caData     []byte
+		expectErr  bool
+		expectData map[string]string
+	}{
+		{
+			name:      "fails if cluster-info is missing",
+			caData:    []byte("new-ca"),
+			expectErr: true,
+		},
+		{
+			name: "updates the certificate authority and preserves the signatures",
+			objs: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterInfoConfigMapName,
+					Namespace: metav1.NamespacePublic,
+				},
+				Data: map[string]string{
+					clusterInfoKubeconfigKey: clusterInfoKubeconfig([]byte("old-ca")),
+					"jws-kubeconfig-abcdef":  "signature",
+				},
+			}},
+			caData: []byte("new-ca"),
+			expectData: map[string]string{
+				clusterInfoKubeconfigKey: clusterInfoKubeconfig([]byte("new-ca")),
+				"jws-kubeconfig-abcdef":  "signature",
+			},
+		},
+		{
+			name: "no-op if the certificate authority is already up to date",
+			objs: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterInfoConfigMapName,
+					Namespace: metav1.NamespacePublic,
+				},
+				Data: map[string]string{
+					clusterInfoKubeconfigKey: clusterInfoKubeconfig([]byte("new-ca")),
+				},
+			}},
+			caData: []byte("new-ca"),
+			expectData: map[string]string{
+				clusterInfoKubeconfigKey: clusterInfoKubeconfig([]byte("new-ca")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			w := &Workload{
+				Client: fakeClient,
+			}
+			err := w.UpdateClusterInfoCertificateAuthority(ctx, tt.caData)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			clusterInfo := &corev1.ConfigMap{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespacePublic, Name: clusterInfoConfigMapName}, clusterInfo)).To(Succeed())
+			g.Expect(clusterInfo.Data).To(Equal(tt.expectData))
+		})
+	}
+}
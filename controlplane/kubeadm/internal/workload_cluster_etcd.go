@@ -18,6 +18,7 @@ package internal
 
 import (
 	"context"
+	"io"
 
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -182,6 +183,79 @@ func (w *Workload) ForwardEtcdLeadership(ctx context.Context, machine *clusterv1
 	return nil
 }
 
+// SaveEtcdSnapshot streams a point-in-time snapshot of the etcd cluster into w, and returns the revision of the snapshot.
+// The snapshot is taken from the first available etcd member, so the leader is not burdened if possible.
+func (w *Workload) SaveEtcdSnapshot(ctx context.Context, writer io.Writer) (int64, error) {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list control plane nodes")
+	}
+	nodeNames := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	revision, err := etcdClient.Snapshot(ctx, writer)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to save etcd snapshot")
+	}
+	return revision, nil
+}
+
+// GetEtcdMemberStatus returns the status of the etcd member hosted on the given Node, e.g. the size of its database.
+func (w *Workload) GetEtcdMemberStatus(ctx context.Context, nodeName string) (*etcd.MemberStatus, error) {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.MemberStatus(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get status of the etcd member hosted on Node %s", nodeName)
+	}
+	return status, nil
+}
+
+// DefragmentEtcdMember defragments the database of the etcd member hosted on the given Node.
+// After a successful defragmentation, the NOSPACE alarm raised by the member, if any, is disarmed.
+// NOTE: A member does not serve requests while it is being defragmented, so members should be defragmented one at a time.
+func (w *Workload) DefragmentEtcdMember(ctx context.Context, nodeName string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.MemberStatus(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get status of the etcd member hosted on Node %s", nodeName)
+	}
+
+	if err := etcdClient.Defragment(ctx); err != nil {
+		return errors.Wrapf(err, "failed to defragment the etcd member hosted on Node %s", nodeName)
+	}
+
+	alarms, err := etcdClient.Alarms(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list etcd alarms using etcd client")
+	}
+	for _, alarm := range alarms {
+		if alarm.MemberID != status.MemberID || alarm.Type != etcd.AlarmNoSpace {
+			continue
+		}
+		if err := etcdClient.DisarmAlarm(ctx, alarm); err != nil {
+			return errors.Wrapf(err, "failed to disarm the alarm raised by the etcd member hosted on Node %s", nodeName)
+		}
+	}
+	return nil
+}
+
 // EtcdMemberStatus contains status information for a single etcd member.
 type EtcdMemberStatus struct {
 	Name       string
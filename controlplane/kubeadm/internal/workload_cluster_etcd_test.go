@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -639,6 +640,193 @@ func TestForwardEtcdLeadership(t *testing.T) {
 	})
 }
 
+func TestSaveEtcdSnapshot(t *testing.T) {
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		k8sClient           client.Client
+		expectRevision      int64
+		expectSnapshot      string
+		expectErr           bool
+	}{
+		{
+			name:      "returns an error if it can't retrieve the list of control plane nodes",
+			k8sClient: &fakeClient{listErr: errors.New("failed to list nodes")},
+			expectErr: true,
+		},
+		{
+			name:                "returns an error if it can't create an etcd client",
+			k8sClient:           &fakeClient{},
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no etcdClient")},
+			expectErr:           true,
+		},
+		{
+			name:      "returns an error if it fails to take the snapshot",
+			k8sClient: &fakeClient{},
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						StatusResponse: &clientv3.StatusResponse{},
+						ErrorResponse:  errors.New("cannot take snapshot"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "saves the snapshot",
+			k8sClient: &fakeClient{list: &corev1.NodeList{
+				Items: []corev1.Node{nodeNamed("machine-node")},
+			}},
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						StatusResponse: &clientv3.StatusResponse{
+							Header: &pb.ResponseHeader{Revision: 42},
+						},
+						SnapshotData: []byte("snapshot"),
+					},
+				},
+			},
+			expectRevision: 42,
+			expectSnapshot: "snapshot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w := &Workload{
+				Client:              tt.k8sClient,
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			snapshot := &bytes.Buffer{}
+			revision, err := w.SaveEtcdSnapshot(ctx, snapshot)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(revision).To(Equal(tt.expectRevision))
+			g.Expect(snapshot.String()).To(Equal(tt.expectSnapshot))
+		})
+	}
+}
+
+func TestGetEtcdMemberStatus(t *testing.T) {
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		expectStatus        *etcd.MemberStatus
+		expectErr           bool
+	}{
+		{
+			name:                "returns an error if it can't create an etcd client",
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no etcdClient")},
+			expectErr:           true,
+		},
+		{
+			name: "returns the status of the member",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						StatusResponse: &clientv3.StatusResponse{
+							Header:      &pb.ResponseHeader{MemberId: 1234},
+							Leader:      1234,
+							DbSize:      100,
+							DbSizeInUse: 40,
+						},
+					},
+				},
+			},
+			expectStatus: &etcd.MemberStatus{MemberID: 1234, IsLeader: true, DBSize: 100, DBSizeInUse: 40},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w := &Workload{
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			status, err := w.GetEtcdMemberStatus(ctx, "machine-node")
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(status).To(Equal(tt.expectStatus))
+		})
+	}
+}
+
+func TestDefragmentEtcdMember(t *testing.T) {
+	tests := []struct {
+		name                string
+		etcdClient          *fake2.FakeEtcdClient
+		etcdClientGenerator *fakeEtcdClientGenerator
+		expectDisarmedAlarm *clientv3.AlarmMember
+		expectErr           bool
+	}{
+		{
+			name:                "returns an error if it can't create an etcd client",
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no etcdClient")},
+			expectErr:           true,
+		},
+		{
+			name: "returns an error if it fails to defragment the member",
+			etcdClient: &fake2.FakeEtcdClient{
+				StatusResponse: &clientv3.StatusResponse{Header: &pb.ResponseHeader{MemberId: 1234}},
+				ErrorResponse:  errors.New("cannot defragment"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "defragments the member without alarms",
+			etcdClient: &fake2.FakeEtcdClient{
+				StatusResponse:     &clientv3.StatusResponse{Header: &pb.ResponseHeader{MemberId: 1234}},
+				DefragmentResponse: &clientv3.DefragmentResponse{},
+				AlarmResponse:      &clientv3.AlarmResponse{},
+			},
+		},
+		{
+			name: "defragments the member and disarms its NOSPACE alarm",
+			etcdClient: &fake2.FakeEtcdClient{
+				StatusResponse:     &clientv3.StatusResponse{Header: &pb.ResponseHeader{MemberId: 1234}},
+				DefragmentResponse: &clientv3.DefragmentResponse{},
+				AlarmResponse: &clientv3.AlarmResponse{
+					Alarms: []*pb.AlarmMember{
+						{MemberID: 5678, Alarm: pb.AlarmType_NOSPACE},
+						{MemberID: 1234, Alarm: pb.AlarmType_CORRUPT},
+						{MemberID: 1234, Alarm: pb.AlarmType_NOSPACE},
+					},
+				},
+			},
+			expectDisarmedAlarm: &clientv3.AlarmMember{MemberID: 1234, Alarm: pb.AlarmType_NOSPACE},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			etcdClientGenerator := tt.etcdClientGenerator
+			if etcdClientGenerator == nil {
+				etcdClientGenerator = &fakeEtcdClientGenerator{
+					forNodesClient: &etcd.Client{Endpoint: "etcd-machine-node", EtcdClient: tt.etcdClient},
+				}
+			}
+			w := &Workload{
+				etcdClientGenerator: etcdClientGenerator,
+			}
+			err := w.DefragmentEtcdMember(ctx, "machine-node")
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tt.etcdClient.Defragmented).To(Equal("etcd-machine-node"))
+			g.Expect(tt.etcdClient.DisarmedAlarm).To(Equal(tt.expectDisarmedAlarm))
+		})
+	}
+}
+
 func TestReconcileEtcdMembersAndControlPlaneNodes(t *testing.T) {
 	node1 := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -90,6 +90,47 @@ func TestGetControlPlaneNodes(t *testing.T) {
 	}
 }
 
+func TestDeleteControlPlaneNodesNotIn(t *testing.T) {
+	g := NewWithT(t)
+
+	controlPlaneNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					labelNodeRoleControlPlane: "",
+				},
+			},
+		}
+	}
+	workerNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-node",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		controlPlaneNode("control-plane-node-1"),
+		controlPlaneNode("control-plane-node-2"),
+		controlPlaneNode("control-plane-node-3"),
+		workerNode,
+	).Build()
+
+	w := &Workload{
+		Client: fakeClient,
+	}
+	deletedNodes, err := w.DeleteControlPlaneNodesNotIn(ctx, []string{"control-plane-node-2"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deletedNodes).To(ConsistOf("control-plane-node-1", "control-plane-node-3"))
+
+	nodes := &corev1.NodeList{}
+	g.Expect(fakeClient.List(ctx, nodes)).To(Succeed())
+	var actualNodes []string
+	for _, n := range nodes.Items {
+		actualNodes = append(actualNodes, n.Name)
+	}
+	g.Expect(actualNodes).To(ConsistOf("control-plane-node-2", "worker-node"))
+}
+
 func TestUpdateKubeProxyImageInfo(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -806,6 +847,86 @@ func TestUpdateSchedulerInKubeadmConfigMap(t *testing.T) {
 	}
 }
 
+func TestUpdateEncryptionAlgorithmInKubeadmConfigMap(t *testing.T) {
+	tests := []struct {
+		name                     string
+		clusterConfigurationData string
+		newEncryptionAlgorithm   bootstrapv1.EncryptionAlgorithmType
+		wantClusterConfiguration string
+	}{
+		{
+			name: "it should set the encryption algorithm",
+			clusterConfigurationData: utilyaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta4
+				kind: ClusterConfiguration
+				`),
+			newEncryptionAlgorithm: bootstrapv1.EncryptionAlgorithmECDSAP256,
+			wantClusterConfiguration: utilyaml.Raw(`
+				apiServer: {}
+				apiVersion: kubeadm.k8s.io/v1beta4
+				controllerManager: {}
+				dns: {}
+				encryptionAlgorithm: ECDSA-P256
+				etcd: {}
+				kind: ClusterConfiguration
+				kubernetesVersion: v1.31.0
+				networking: {}
+				proxy: {}
+				scheduler: {}
+				`),
+		},
+		{
+			name: "it should reset the encryption algorithm",
+			clusterConfigurationData: utilyaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta4
+				kind: ClusterConfiguration
+				encryptionAlgorithm: ECDSA-P256
+				`),
+			newEncryptionAlgorithm: "",
+			wantClusterConfiguration: utilyaml.Raw(`
+				apiServer: {}
+				apiVersion: kubeadm.k8s.io/v1beta4
+				controllerManager: {}
+				dns: {}
+				etcd: {}
+				kind: ClusterConfiguration
+				kubernetesVersion: v1.31.0
+				networking: {}
+				proxy: {}
+				scheduler: {}
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      kubeadmConfigKey,
+					Namespace: metav1.NamespaceSystem,
+				},
+				Data: map[string]string{
+					clusterConfigurationKey: tt.clusterConfigurationData,
+				},
+			}).Build()
+
+			w := &Workload{
+				Client: fakeClient,
+			}
+			err := w.UpdateClusterConfiguration(ctx, semver.MustParse("1.31.0"), w.UpdateEncryptionAlgorithmInKubeadmConfigMap(tt.newEncryptionAlgorithm))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			var actualConfig corev1.ConfigMap
+			g.Expect(w.Client.Get(
+				ctx,
+				client.ObjectKey{Name: kubeadmConfigKey, Namespace: metav1.NamespaceSystem},
+				&actualConfig,
+			)).To(Succeed())
+			g.Expect(actualConfig.Data[clusterConfigurationKey]).Should(Equal(tt.wantClusterConfiguration), cmp.Diff(tt.wantClusterConfiguration, actualConfig.Data[clusterConfigurationKey]))
+		})
+	}
+}
+
 func TestClusterStatus(t *testing.T) {
 	node1 := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -22,7 +22,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	goruntime "runtime"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -54,22 +56,30 @@ import (
 	controlplanev1beta1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/crdmigrator"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	kubeadmcontrolplanecontrollers "sigs.k8s.io/cluster-api/controlplane/kubeadm/controllers"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	kcpwebhooks "sigs.k8s.io/cluster-api/controlplane/kubeadm/webhooks"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	runtimecontrollers "sigs.k8s.io/cluster-api/exp/runtime/controllers"
 	"sigs.k8s.io/cluster-api/feature"
 	controlplanev1alpha3 "sigs.k8s.io/cluster-api/internal/api/controlplane/kubeadm/v1alpha3"
 	controlplanev1alpha4 "sigs.k8s.io/cluster-api/internal/api/controlplane/kubeadm/v1alpha4"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	"sigs.k8s.io/cluster-api/util/apiwarnings"
 	"sigs.k8s.io/cluster-api/util/flags"
 	"sigs.k8s.io/cluster-api/version"
 )
 
 var (
+	catalog        = runtimecatalog.New()
 	scheme         = runtime.NewScheme()
 	setupLog       = ctrl.Log.WithName("setup")
 	controllerName = "cluster-api-kubeadm-control-plane-manager"
@@ -102,6 +112,9 @@ var (
 	skipCRDMigrationPhases         []string
 	etcdDialTimeout                time.Duration
 	etcdCallTimeout                time.Duration
+	etcdSnapshotServerPort         int
+	etcdSnapshotServerURL          string
+	etcdSnapshotServerCertDir      string
 )
 
 func init() {
@@ -113,6 +126,10 @@ func init() {
 	_ = controlplanev1.AddToScheme(scheme)
 	_ = bootstrapv1.AddToScheme(scheme)
 	_ = apiextensionsv1.AddToScheme(scheme)
+	_ = runtimev1.AddToScheme(scheme)
+
+	// Register the RuntimeHook types into the catalog.
+	_ = runtimehooksv1.AddToCatalog(catalog)
 }
 
 // InitFlags initializes the flags.
@@ -192,6 +209,15 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&etcdCallTimeout, "etcd-call-timeout-duration", etcd.DefaultCallTimeout,
 		"Duration that the etcd client waits at most for read and write operations to etcd.")
 
+	fs.IntVar(&etcdSnapshotServerPort, "etcd-snapshot-server-port", 0,
+		"Port of the server serving the etcd snapshots stored by KCP to the Machines restoring etcd. If 0, the server is disabled and etcd can be restored only from spec.etcdRestore.snapshotURL.")
+
+	fs.StringVar(&etcdSnapshotServerURL, "etcd-snapshot-server-url", "",
+		"The https URL the etcd snapshot server is reachable at from the Machines of workload clusters, e.g. https://kcp-etcd-snapshots.example.com. Required if --etcd-snapshot-server-port is set.")
+
+	fs.StringVar(&etcdSnapshotServerCertDir, "etcd-snapshot-server-cert-dir", "/tmp/k8s-etcd-snapshot-server/serving-certs/",
+		"Etcd snapshot server cert dir, containing tls.crt and tls.key; if it contains ca.crt, Machines use it to verify the server.")
+
 	flags.AddManagerOptions(fs, &managerOptions)
 
 	feature.MutableGates.AddFlag(fs)
@@ -240,12 +266,33 @@ func main() {
 		os.Exit(1)
 	}
 
+	// In-place updates require Runtime Extensions implementing the in-place update hooks.
+	if feature.Gates.Enabled(feature.InPlaceUpdates) && !feature.Gates.Enabled(feature.RuntimeSDK) {
+		setupLog.Error(errors.Errorf("%s feature flag requires the %s feature flag to be enabled", feature.InPlaceUpdates, feature.RuntimeSDK), "Unable to start manager")
+		os.Exit(1)
+	}
+
 	tlsOptions, metricsOptions, err := flags.GetManagerOptions(managerOptions)
 	if err != nil {
 		setupLog.Error(err, "Unable to start manager: invalid flags")
 		os.Exit(1)
 	}
 
+	var etcdSnapshotServer webhook.Server
+	if etcdSnapshotServerPort != 0 {
+		if !strings.HasPrefix(etcdSnapshotServerURL, "https://") {
+			setupLog.Error(errors.Errorf("--etcd-snapshot-server-url must be an https URL when --etcd-snapshot-server-port is set"), "Unable to start manager")
+			os.Exit(1)
+		}
+		etcdSnapshotServer = webhook.NewServer(
+			webhook.Options{
+				Port:    etcdSnapshotServerPort,
+				CertDir: etcdSnapshotServerCertDir,
+				TLSOpts: tlsOptions,
+			},
+		)
+	}
+
 	var watchNamespaces map[string]cache.Config
 	if watchNamespace != "" {
 		watchNamespaces = map[string]cache.Config{
@@ -320,7 +367,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupChecks(mgr)
-	setupReconcilers(ctx, mgr)
+	setupReconcilers(ctx, mgr, etcdSnapshotServer)
 	setupWebhooks(ctx, mgr)
 
 	setupLog.Info("Starting manager", "version", version.Get().String())
@@ -342,7 +389,7 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
-func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
+func setupReconcilers(ctx context.Context, mgr ctrl.Manager, etcdSnapshotServer webhook.Server) {
 	secretCachingClient, err := client.New(mgr.GetConfig(), client.Options{
 		HTTPClient: mgr.GetHTTPClient(),
 		Cache: &client.CacheOptions{
@@ -420,20 +467,59 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
+	var runtimeClient runtimeclient.Client
+	if feature.Gates.Enabled(feature.RuntimeSDK) && feature.Gates.Enabled(feature.InPlaceUpdates) {
+		// This is the creation of the runtimeClient used to call in-place update hooks.
+		runtimeClient = internalruntimeclient.New(internalruntimeclient.Options{
+			Catalog:  catalog,
+			Registry: runtimeregistry.New(),
+			Client:   mgr.GetClient(),
+		})
+
+		// ExtensionConfigs are reconciled by the core CAPI controller manager; KCP only registers them
+		// into the registry of its own runtimeClient.
+		if err := (&runtimecontrollers.ExtensionConfigReconciler{
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			RuntimeClient:    runtimeClient,
+			WatchFilterValue: watchFilterValue,
+			ReadOnly:         true,
+		}).SetupWithManager(ctx, mgr, concurrency(1), nil); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ExtensionConfig")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&kubeadmcontrolplanecontrollers.KubeadmControlPlaneReconciler{
 		Client:                      mgr.GetClient(),
 		SecretCachingClient:         secretCachingClient,
 		ClusterCache:                clusterCache,
+		RuntimeClient:               runtimeClient,
 		WatchFilterValue:            watchFilterValue,
 		EtcdDialTimeout:             etcdDialTimeout,
 		EtcdCallTimeout:             etcdCallTimeout,
 		RemoteConditionsGracePeriod: remoteConditionsGracePeriod,
+		EtcdSnapshotServer:          etcdSnapshotServer,
+		EtcdSnapshotServerURL:       etcdSnapshotServerURL,
+		EtcdSnapshotServerCAFile:    etcdSnapshotServerCAFile(),
 	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeadmControlPlane")
 		os.Exit(1)
 	}
 }
 
+// etcdSnapshotServerCAFile returns the path of the CA bundle of the etcd snapshot server, if any.
+func etcdSnapshotServerCAFile() string {
+	if etcdSnapshotServerPort == 0 {
+		return ""
+	}
+	caFile := filepath.Join(etcdSnapshotServerCertDir, "ca.crt")
+	if _, err := os.Stat(caFile); err != nil {
+		return ""
+	}
+	return caFile
+}
+
 func setupWebhooks(ctx context.Context, mgr ctrl.Manager) {
 	// Setup the func to retrieve apiVersion for a GroupKind for conversion webhooks.
 	apiVersionGetter := func(gk schema.GroupKind) (string, error) {
@@ -92,3 +92,10 @@ type Client interface {
 	// CallExtension calls the ExtensionHandler with the given name.
 	CallExtension(ctx context.Context, hook runtimecatalog.Hook, forObject metav1.Object, name string, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject, opts ...CallExtensionOption) error
 }
+
+// ExtensionLister can be optionally implemented by a Client to list the ExtensionHandlers registered for a hook.
+// Note: ExtensionLister is not part of the Client interface, so existing implementations of Client are not required to implement it.
+type ExtensionLister interface {
+	// GetAllExtensions gets the names of all the ExtensionHandlers registered for the hook that apply to the given object.
+	GetAllExtensions(ctx context.Context, hook runtimecatalog.Hook, forObject metav1.Object) ([]string, error)
+}
@@ -36,6 +36,10 @@ type ExtensionConfigReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// ReadOnly configures the reconciler to only register ExtensionConfigs into the registry of the RuntimeClient,
+	// without performing discovery and without writing to ExtensionConfigs.
+	ReadOnly bool
 }
 
 func (r *ExtensionConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options, partialSecretCache cache.Cache) error {
@@ -44,5 +48,6 @@ func (r *ExtensionConfigReconciler) SetupWithManager(ctx context.Context, mgr ct
 		APIReader:        r.APIReader,
 		RuntimeClient:    r.RuntimeClient,
 		WatchFilterValue: r.WatchFilterValue,
+		ReadOnly:         r.ReadOnly,
 	}).SetupWithManager(ctx, mgr, options, partialSecretCache)
 }
@@ -61,6 +61,12 @@ type Reconciler struct {
 	RuntimeClient runtimeclient.Client
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// ReadOnly configures the Reconciler to only register ExtensionConfigs into the registry of the RuntimeClient,
+	// without performing discovery and without writing to ExtensionConfigs.
+	// This allows controllers running outside the core CAPI controller manager to call Runtime Extensions,
+	// while the core CAPI controller manager remains the only one reconciling ExtensionConfigs.
+	ReadOnly bool
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options, partialSecretCache cache.Cache) error {
@@ -69,9 +75,13 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "extensionconfig")
-	err := ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&runtimev1.ExtensionConfig{}).
-		WatchesRawSource(source.Kind(
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue))
+	// Note: In read-only mode the CABundle is not injected, so there is no need to watch Secrets.
+	if !r.ReadOnly {
+		b = b.WatchesRawSource(source.Kind(
 			partialSecretCache,
 			&metav1.PartialObjectMetadata{
 				TypeMeta: metav1.TypeMeta{
@@ -83,24 +93,25 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 				r.secretToExtensionConfig,
 			),
 			predicates.TypedResourceIsChanged[*metav1.PartialObjectMetadata](mgr.GetScheme(), predicateLog),
-		)).
-		WithOptions(options).
-		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue)).
-		Complete(r)
-	if err != nil {
+		))
+	}
+	if err := b.Complete(r); err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
-	if err := indexByExtensionInjectCAFromSecretName(ctx, mgr); err != nil {
-		return errors.Wrap(err, "failed setting up with a controller manager")
+	if !r.ReadOnly {
+		if err := indexByExtensionInjectCAFromSecretName(ctx, mgr); err != nil {
+			return errors.Wrap(err, "failed setting up with a controller manager")
+		}
 	}
 
 	// warmupRunnable will attempt to sync the RuntimeSDK registry with existing ExtensionConfig objects to ensure extensions
 	// are discovered before controllers begin reconciling.
-	err = mgr.Add(&warmupRunnable{
+	err := mgr.Add(&warmupRunnable{
 		Client:        r.Client,
 		APIReader:     r.APIReader,
 		RuntimeClient: r.RuntimeClient,
+		ReadOnly:      r.ReadOnly,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed adding warmupRunnable to controller manager")
@@ -132,6 +143,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	// In read-only mode, register the ExtensionConfig as discovered by the core CAPI controller manager.
+	if r.ReadOnly {
+		if !extensionConfig.DeletionTimestamp.IsZero() {
+			return r.reconcileDelete(ctx, extensionConfig)
+		}
+		log.V(4).Info("Registering ExtensionConfig information into registry")
+		if err := r.RuntimeClient.Register(extensionConfig); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to register ExtensionConfig %s/%s", extensionConfig.Namespace, extensionConfig.Name)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// Copy to avoid modifying the original extensionConfig.
 	original := extensionConfig.DeepCopy()
 
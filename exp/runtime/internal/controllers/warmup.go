@@ -44,6 +44,7 @@ type warmupRunnable struct {
 	Client         client.Client
 	APIReader      client.Reader
 	RuntimeClient  runtimeclient.Client
+	ReadOnly       bool
 	warmupTimeout  time.Duration
 	warmupInterval time.Duration
 }
@@ -73,7 +74,7 @@ func (r *warmupRunnable) Start(ctx context.Context) error {
 	defer cancel()
 
 	err := wait.PollUntilContextTimeout(ctx, r.warmupInterval, r.warmupTimeout, true, func(ctx context.Context) (done bool, err error) {
-		if err = warmupRegistry(ctx, r.Client, r.APIReader, r.RuntimeClient); err != nil {
+		if err = warmupRegistry(ctx, r.Client, r.APIReader, r.RuntimeClient, r.ReadOnly); err != nil {
 			log.Error(err, "ExtensionConfig registry warmup failed")
 			return false, nil
 		}
@@ -89,7 +90,8 @@ func (r *warmupRunnable) Start(ctx context.Context) error {
 
 // warmupRegistry attempts to discover all existing ExtensionConfigs and patch their status with discovered Handlers.
 // It warms up the registry by passing it the up-to-date list of ExtensionConfigs.
-func warmupRegistry(ctx context.Context, client client.Client, reader client.Reader, runtimeClient runtimeclient.Client) error {
+// In read-only mode, the registry is warmed up with the ExtensionConfigs as discovered by the core CAPI controller manager.
+func warmupRegistry(ctx context.Context, client client.Client, reader client.Reader, runtimeClient runtimeclient.Client, readOnly bool) error {
 	log := ctrl.LoggerFrom(ctx)
 
 	var errs []error
@@ -99,6 +101,14 @@ func warmupRegistry(ctx context.Context, client client.Client, reader client.Rea
 		return errors.Wrapf(err, "failed to list ExtensionConfigs")
 	}
 
+	if readOnly {
+		if err := runtimeClient.WarmUp(&extensionConfigList); err != nil {
+			return err
+		}
+		log.Info("The extension registry is warmed up")
+		return nil
+	}
+
 	for i := range extensionConfigList.Items {
 		extensionConfig := &extensionConfigList.Items[i]
 		original := extensionConfig.DeepCopy()
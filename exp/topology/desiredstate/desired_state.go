@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -59,14 +60,29 @@ type Generator interface {
 	Generate(ctx context.Context, s *scope.Scope) (*scope.ClusterState, error)
 }
 
+// GeneratorOption is a configuration option supplied to NewGenerator.
+type GeneratorOption func(*generator)
+
+// WithFeatureGates sets the feature gates used by the generator.
+// If not set, the process-wide feature.Gates are used.
+func WithFeatureGates(featureGates featuregate.FeatureGate) GeneratorOption {
+	return func(g *generator) {
+		g.featureGates = featureGates
+	}
+}
+
 // NewGenerator creates a new generator to generate desired state.
-func NewGenerator(client client.Client, clusterCache clustercache.ClusterCache, runtimeClient runtimeclient.Client) Generator {
-	return &generator{
+func NewGenerator(client client.Client, clusterCache clustercache.ClusterCache, runtimeClient runtimeclient.Client, options ...GeneratorOption) Generator {
+	g := &generator{
 		Client:        client,
 		ClusterCache:  clusterCache,
 		RuntimeClient: runtimeClient,
-		patchEngine:   patches.NewEngine(runtimeClient),
 	}
+	for _, o := range options {
+		o(g)
+	}
+	g.patchEngine = patches.NewEngine(runtimeClient, g.getFeatureGates())
+	return g
 }
 
 // generator is a generator to generate desired state.
@@ -78,10 +94,25 @@ type generator struct {
 
 	RuntimeClient runtimeclient.Client
 
+	// featureGates are used to check if the RuntimeSDK feature is enabled.
+	// If nil, feature.Gates is used.
+	featureGates featuregate.FeatureGate
+
 	// patchEngine is used to apply patches during computeDesiredState.
 	patchEngine patches.Engine
 }
 
+func (g *generator) getFeatureGates() featuregate.FeatureGate {
+	if g.featureGates == nil {
+		return feature.Gates
+	}
+	return g.featureGates
+}
+
+func (g *generator) runtimeSDKEnabled() bool {
+	return g.getFeatureGates().Enabled(feature.RuntimeSDK)
+}
+
 // Generate computes the desired state of the cluster topology.
 // NOTE: We are assuming all the required objects are provided as input; also, in case of any error,
 // the entire compute operation will fail. This might be improved in the future if support for reconciling
@@ -524,7 +555,7 @@ func (g *generator) computeControlPlaneVersion(ctx context.Context, s *scope.Sco
 		// is required when updating the TopologyReconciled condition on the cluster.
 
 		// Call the AfterControlPlaneUpgrade now that the control plane is upgraded.
-		if feature.Gates.Enabled(feature.RuntimeSDK) {
+		if g.runtimeSDKEnabled() {
 			// Call the hook only if we are tracking the intent to do so. If it is not tracked it means we don't need to call the
 			// hook because we didn't go through an upgrade or we already called the hook after the upgrade.
 			if hooks.IsPending(runtimehooksv1.AfterControlPlaneUpgrade, s.Current.Cluster) {
@@ -570,7 +601,7 @@ func (g *generator) computeControlPlaneVersion(ctx context.Context, s *scope.Sco
 		return *currentVersion, nil
 	}
 
-	if feature.Gates.Enabled(feature.RuntimeSDK) {
+	if g.runtimeSDKEnabled() {
 		var hookAnnotations []string
 		for key := range s.Current.Cluster.Annotations {
 			if strings.HasPrefix(key, clusterv1.BeforeClusterUpgradeHookAnnotationPrefix) {
@@ -77,6 +77,18 @@ const (
 	//
 	// alpha: v1.10
 	PriorityQueue featuregate.Feature = "PriorityQueue"
+
+	// InPlaceUpdates is a feature gate for the in-place update of Machines functionality.
+	// Note: this feature requires the RuntimeSDK feature gate to be enabled as well.
+	//
+	// alpha: v1.11
+	InPlaceUpdates featuregate.Feature = "InPlaceUpdates"
+
+	// MachineDrainEvictionCoordination is a feature gate that controls if the Machine controller serializes
+	// evictions of Pods covered by the same PodDisruptionBudget across Machines of a Cluster which are drained concurrently.
+	//
+	// alpha: v1.11
+	MachineDrainEvictionCoordination featuregate.Feature = "MachineDrainEvictionCoordination"
 )
 
 func init() {
@@ -91,8 +103,10 @@ var defaultClusterAPIFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	MachinePool:               {Default: true, PreRelease: featuregate.Beta},
 	MachineSetPreflightChecks: {Default: true, PreRelease: featuregate.Beta},
 	MachineWaitForVolumeDetachConsiderVolumeAttachments: {Default: true, PreRelease: featuregate.Beta},
-	PriorityQueue:                  {Default: false, PreRelease: featuregate.Alpha},
-	ClusterTopology:                {Default: false, PreRelease: featuregate.Alpha},
-	KubeadmBootstrapFormatIgnition: {Default: false, PreRelease: featuregate.Alpha},
-	RuntimeSDK:                     {Default: false, PreRelease: featuregate.Alpha},
+	PriorityQueue:                    {Default: false, PreRelease: featuregate.Alpha},
+	ClusterTopology:                  {Default: false, PreRelease: featuregate.Alpha},
+	KubeadmBootstrapFormatIgnition:   {Default: false, PreRelease: featuregate.Alpha},
+	RuntimeSDK:                       {Default: false, PreRelease: featuregate.Alpha},
+	InPlaceUpdates:                   {Default: false, PreRelease: featuregate.Alpha},
+	MachineDrainEvictionCoordination: {Default: false, PreRelease: featuregate.Alpha},
 }
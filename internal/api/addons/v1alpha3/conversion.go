@@ -21,6 +21,7 @@ import (
 	apimachineryconversion "k8s.io/apimachinery/pkg/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	addonsv1beta1 "sigs.k8s.io/cluster-api/api/addons/v1beta1"
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	clusterv1alpha3 "sigs.k8s.io/cluster-api/internal/api/core/v1alpha3"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
@@ -50,6 +51,7 @@ func (src *ClusterResourceSet) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Status.Conditions = restored.Status.Conditions
+	addonsv1beta1.RestoreResourceRefs(restored.Spec.Resources, dst.Spec.Resources)
 
 	return nil
 }
@@ -90,6 +92,7 @@ func (src *ClusterResourceSetBinding) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Spec.ClusterName = restored.Spec.ClusterName
+	addonsv1beta1.RestoreResourceBindings(&restored.Spec, &dst.Spec)
 	return nil
 }
 
@@ -114,6 +117,11 @@ func Convert_v1beta2_ClusterResourceSetBindingSpec_To_v1alpha3_ClusterResourceSe
 	return autoConvert_v1beta2_ClusterResourceSetBindingSpec_To_v1alpha3_ClusterResourceSetBindingSpec(in, out, s)
 }
 
+func Convert_v1beta2_ResourceBinding_To_v1alpha3_ResourceBinding(in *addonsv1.ResourceBinding, out *ResourceBinding, s apimachineryconversion.Scope) error {
+	// LastError and Objects do not exist in ResourceBinding v1alpha3 API.
+	return autoConvert_v1beta2_ResourceBinding_To_v1alpha3_ResourceBinding(in, out, s)
+}
+
 func Convert_v1beta2_ClusterResourceSetStatus_To_v1alpha3_ClusterResourceSetStatus(in *addonsv1.ClusterResourceSetStatus, out *ClusterResourceSetStatus, s apimachineryconversion.Scope) error {
 	// V1Beta2 was added in v1beta1
 	return autoConvert_v1beta2_ClusterResourceSetStatus_To_v1alpha3_ClusterResourceSetStatus(in, out, s)
@@ -128,3 +136,26 @@ func Convert_v1_Condition_To_v1alpha3_Condition(in *metav1.Condition, out *clust
 func Convert_v1alpha3_Condition_To_v1_Condition(in *clusterv1alpha3.Condition, out *metav1.Condition, s apimachineryconversion.Scope) error {
 	return clusterv1alpha3.Convert_v1alpha3_Condition_To_v1_Condition(in, out, s)
 }
+
+func Convert_Pointer_v1alpha3_ResourceSetBinding_To_Pointer_v1beta2_ResourceSetBinding(in **ResourceSetBinding, out **addonsv1.ResourceSetBinding, s apimachineryconversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = &addonsv1.ResourceSetBinding{}
+	return Convert_v1alpha3_ResourceSetBinding_To_v1beta2_ResourceSetBinding(*in, *out, s)
+}
+
+func Convert_Pointer_v1beta2_ResourceSetBinding_To_Pointer_v1alpha3_ResourceSetBinding(in **addonsv1.ResourceSetBinding, out **ResourceSetBinding, s apimachineryconversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = &ResourceSetBinding{}
+	return Convert_v1beta2_ResourceSetBinding_To_v1alpha3_ResourceSetBinding(*in, *out, s)
+}
+
+func Convert_v1beta2_ResourceRef_To_v1alpha3_ResourceRef(in *addonsv1.ResourceRef, out *ResourceRef, s apimachineryconversion.Scope) error {
+	// Format, HelmChart and Kustomization do not exist in ResourceRef v1alpha3 API.
+	return autoConvert_v1beta2_ResourceRef_To_v1alpha3_ResourceRef(in, out, s)
+}
@@ -110,6 +110,14 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.AvailableReplicas = restored.Status.AvailableReplicas
 		dst.Status.ReadyReplicas = restored.Status.ReadyReplicas
 		dst.Status.UpToDateReplicas = restored.Status.UpToDateReplicas
+		dst.Spec.CertificateAuthorityRotation = restored.Spec.CertificateAuthorityRotation
+		dst.Status.CertificateAuthorityRotation = restored.Status.CertificateAuthorityRotation
+		dst.Spec.EtcdBackup = restored.Spec.EtcdBackup
+		dst.Spec.EtcdRestore = restored.Spec.EtcdRestore
+		dst.Spec.EtcdDefragmentation = restored.Spec.EtcdDefragmentation
+		dst.Status.EtcdBackup = restored.Status.EtcdBackup
+		dst.Status.EtcdRestore = restored.Status.EtcdRestore
+		dst.Status.EtcdMembers = restored.Status.EtcdMembers
 	}
 
 	// Override restored data with timeouts values already existing in v1beta1 but in other structs.
@@ -477,6 +477,9 @@ func (src *MachineHealthCheck) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.UnhealthyRange != nil {
 		dst.Spec.UnhealthyRange = restored.Spec.UnhealthyRange
 	}
+	dst.Spec.UnhealthyMachineConditions = restored.Spec.UnhealthyMachineConditions
+	dst.Spec.RemediationRateLimit = restored.Spec.RemediationRateLimit
+	dst.Status.Remediations = restored.Status.Remediations
 	dst.Status.Conditions = restored.Status.Conditions
 
 	return nil
@@ -562,6 +562,9 @@ func (src *MachineHealthCheck) ConvertTo(dstRaw conversion.Hub) error {
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
+	dst.Spec.UnhealthyMachineConditions = restored.Spec.UnhealthyMachineConditions
+	dst.Spec.RemediationRateLimit = restored.Spec.RemediationRateLimit
+	dst.Status.Remediations = restored.Status.Remediations
 	dst.Status.Conditions = restored.Status.Conditions
 
 	return nil
@@ -32,6 +32,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -72,6 +73,10 @@ type Reconciler struct {
 	// RuntimeClient is a client for calling runtime extensions.
 	RuntimeClient runtimeclient.Client
 
+	// FeatureGates are the feature gates used by the reconciler.
+	// If nil, feature.Gates is used.
+	FeatureGates featuregate.FeatureGate
+
 	// discoverVariablesCache is used to temporarily store the response of a DiscoveryVariables call for
 	// a specific runtime extension/settings combination.
 	discoverVariablesCache cache.Cache[runtimeclient.CallExtensionCacheEntry]
@@ -81,7 +86,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	if r.Client == nil {
 		return errors.New("Client must not be nil")
 	}
-	if feature.Gates.Enabled(feature.RuntimeSDK) && r.RuntimeClient == nil {
+	if r.runtimeSDKEnabled() && r.RuntimeClient == nil {
 		return errors.New("RuntimeClient must not be nil")
 	}
 
@@ -105,6 +110,13 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	return nil
 }
 
+func (r *Reconciler) runtimeSDKEnabled() bool {
+	if r.FeatureGates == nil {
+		return feature.Gates.Enabled(feature.RuntimeSDK)
+	}
+	return r.FeatureGates.Enabled(feature.RuntimeSDK)
+}
+
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ctrl.Result, reterr error) {
 	clusterClass := &clusterv1.ClusterClass{}
 	if err := r.Client.Get(ctx, req.NamespacedName, clusterClass); err != nil {
@@ -306,7 +318,7 @@ func (r *Reconciler) reconcileVariables(ctx context.Context, s *scope) (ctrl.Res
 
 	// If RuntimeSDK is enabled call the DiscoverVariables hook for all associated Runtime Extensions and add the variables
 	// to the ClusterClass status.
-	if feature.Gates.Enabled(feature.RuntimeSDK) {
+	if r.runtimeSDKEnabled() {
 		for _, patch := range clusterClass.Spec.Patches {
 			if patch.External == nil || patch.External.DiscoverVariablesExtension == nil {
 				continue
@@ -318,9 +330,14 @@ func (r *Reconciler) reconcileVariables(ctx context.Context, s *scope) (ctrl.Res
 			// many ClusterClasses using the same runtime extension/settings combination.
 			// This also mitigates spikes when ClusterClass re-syncs happen or when changes to the ExtensionConfig are applied.
 			// DiscoverVariables is expected to return a "static" response and usually there are few ExtensionConfigs in a mgmt cluster.
+			// NOTE: The cache is not set when the reconciler is not set up with a manager, e.g. when it is used
+			// by clusterctl to preview changes to a ClusterClass.
+			var opts []runtimeclient.CallExtensionOption
+			if r.discoverVariablesCache != nil {
+				opts = append(opts, runtimeclient.WithCaching{Cache: r.discoverVariablesCache, CacheKeyFunc: cacheKeyFunc})
+			}
 			resp := &runtimehooksv1.DiscoverVariablesResponse{}
-			err := r.RuntimeClient.CallExtension(ctx, runtimehooksv1.DiscoverVariables, clusterClass, *patch.External.DiscoverVariablesExtension, req, resp,
-				runtimeclient.WithCaching{Cache: r.discoverVariablesCache, CacheKeyFunc: cacheKeyFunc})
+			err := r.RuntimeClient.CallExtension(ctx, runtimehooksv1.DiscoverVariables, clusterClass, *patch.External.DiscoverVariablesExtension, req, resp, opts...)
 			if err != nil {
 				errs = append(errs, errors.Wrapf(err, "failed to call DiscoverVariables for patch %s", patch.Name))
 				continue
@@ -30,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/component-base/featuregate"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -1192,6 +1193,176 @@ func TestReconciler_reconcileVariables(t *testing.T) {
 	}
 }
 
+func TestReconciler_reconcileVariablesCaching(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithPatches([]clusterv1.ClusterClassPatch{
+			{
+				Name: "patch1",
+				External: &clusterv1.ExternalPatchDefinition{
+					DiscoverVariablesExtension: ptr.To("variables-one"),
+				},
+			},
+		}).
+		Build()
+	patchResponse := &runtimehooksv1.DiscoverVariablesResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+		Variables: []clusterv1beta1.ClusterClassVariable{
+			{
+				Name: "location",
+				Schema: clusterv1beta1.VariableSchema{
+					OpenAPIV3Schema: clusterv1beta1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		cache       cache.Cache[runtimeclient.CallExtensionCacheEntry]
+		wantCaching bool
+	}{
+		{
+			name:        "DiscoverVariables responses are cached when the reconciler is set up with a manager",
+			cache:       cache.New[runtimeclient.CallExtensionCacheEntry](cache.DefaultTTL),
+			wantCaching: true,
+		},
+		{
+			name:        "DiscoverVariables responses are not cached when the reconciler is not set up with a manager",
+			wantCaching: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			runtimeClient := &recordingRuntimeClient{
+				Client: fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+						"variables-one": patchResponse,
+					}).
+					WithCatalog(catalog).
+					Build(),
+			}
+			r := &Reconciler{
+				RuntimeClient:          runtimeClient,
+				discoverVariablesCache: tt.cache,
+			}
+
+			s := &scope{
+				clusterClass: clusterClass.DeepCopy(),
+			}
+			_, err := r.reconcileVariables(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(s.variableDiscoveryError).ToNot(HaveOccurred())
+			g.Expect(s.clusterClass.Status.Variables).To(HaveLen(1))
+
+			g.Expect(runtimeClient.callExtensionOptions).To(HaveLen(1))
+			options := &runtimeclient.CallExtensionOptions{}
+			for _, opt := range runtimeClient.callExtensionOptions[0] {
+				opt.ApplyToOptions(options)
+			}
+			g.Expect(options.WithCaching).To(Equal(tt.wantCaching))
+		})
+	}
+}
+
+func TestReconciler_reconcileVariablesFeatureGates(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithPatches([]clusterv1.ClusterClassPatch{
+			{
+				Name: "patch1",
+				External: &clusterv1.ExternalPatchDefinition{
+					DiscoverVariablesExtension: ptr.To("variables-one"),
+				},
+			},
+		}).
+		Build()
+	patchResponse := &runtimehooksv1.DiscoverVariablesResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+		Variables: []clusterv1beta1.ClusterClassVariable{
+			{
+				Name: "location",
+				Schema: clusterv1beta1.VariableSchema{
+					OpenAPIV3Schema: clusterv1beta1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+
+	runtimeSDKEnabled := feature.MutableGates.DeepCopy()
+	if err := runtimeSDKEnabled.Set(fmt.Sprintf("%s=true", feature.RuntimeSDK)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		featureGates  featuregate.FeatureGate
+		wantVariables int
+	}{
+		{
+			name:          "DiscoverVariables is not called if RuntimeSDK is disabled in the process-wide feature gates",
+			wantVariables: 0,
+		},
+		{
+			name:          "DiscoverVariables is called if RuntimeSDK is enabled in the feature gates of the reconciler",
+			featureGates:  runtimeSDKEnabled,
+			wantVariables: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(feature.Gates.Enabled(feature.RuntimeSDK)).To(BeFalse())
+
+			r := &Reconciler{
+				RuntimeClient: fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+						"variables-one": patchResponse,
+					}).
+					WithCatalog(catalog).
+					Build(),
+				FeatureGates: tt.featureGates,
+			}
+
+			s := &scope{
+				clusterClass: clusterClass.DeepCopy(),
+			}
+			_, err := r.reconcileVariables(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(s.variableDiscoveryError).ToNot(HaveOccurred())
+			g.Expect(s.clusterClass.Status.Variables).To(HaveLen(tt.wantVariables))
+		})
+	}
+}
+
+// recordingRuntimeClient is a runtime client recording the options of the CallExtension calls.
+type recordingRuntimeClient struct {
+	runtimeclient.Client
+	callExtensionOptions [][]runtimeclient.CallExtensionOption
+}
+
+func (c *recordingRuntimeClient) CallExtension(ctx context.Context, hook runtimecatalog.Hook, forObject metav1.Object, name string, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject, opts ...runtimeclient.CallExtensionOption) error {
+	c.callExtensionOptions = append(c.callExtensionOptions, opts)
+	return c.Client.CallExtension(ctx, hook, forObject, name, request, response, opts...)
+}
+
 func TestReconciler_extensionConfigToClusterClass(t *testing.T) {
 	firstExtConfig := &runtimev1.ExtensionConfig{
 		ObjectMeta: metav1.ObjectMeta{
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -56,6 +57,15 @@ import (
 // ErrSecretTypeNotSupported signals that a Secret is not supported.
 var ErrSecretTypeNotSupported = errors.New("unsupported secret type")
 
+const (
+	// clusterResourceSetManagerName is the field manager used to apply resources with the ServerSideApply strategy.
+	clusterResourceSetManagerName = "capi-clusterresourceset"
+
+	// serverSideApplyResyncPeriod is the period after which resources of ClusterResourceSets with the ServerSideApply
+	// strategy are re-applied, to detect and correct drift in the workload clusters.
+	serverSideApplyResyncPeriod = 5 * time.Minute
+)
+
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;patch;update
 // +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -215,6 +225,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, kerrors.NewAggregate(errs)
 	}
 
+	// Periodically re-apply resources with the ServerSideApply strategy, to detect and correct drift in the workload clusters.
+	if addonsv1.ClusterResourceSetStrategy(clusterResourceSet.Spec.Strategy) == addonsv1.ClusterResourceSetStrategyServerSideApply && len(clusters) > 0 {
+		return ctrl.Result{RequeueAfter: serverSideApplyResyncPeriod}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -300,6 +315,8 @@ func (r *Reconciler) getClustersByClusterResourceSetSelector(ctx context.Context
 // It applies resources best effort and continue on scenarios like: unsupported resource types, failure during creation, missing resources.
 // In Reconcile strategy, resources are re-applied to a particular cluster when their definition changes. The hash in ClusterResourceSetBinding is used to check
 // if a resource has changed or not.
+// In ServerSideApply strategy, resources are applied to a particular cluster using server-side apply when their definition changes and periodically, to correct drift.
+// The objects applied from each resource are recorded in ClusterResourceSetBinding, and objects which are removed from the ClusterResourceSet are deleted from the cluster.
 // TODO: If a resource already exists in the cluster but not applied by ClusterResourceSet, the resource will be updated ?
 func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) (rerr error) {
 	log := ctrl.LoggerFrom(ctx, "Cluster", klog.KObj(cluster))
@@ -397,7 +414,22 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		return errors.Wrapf(err, "failed to retrieve the Service for Kubernetes API Server of the cluster %s/%s", cluster.Namespace, cluster.Name)
 	}
 
-	// Iterate all resources and apply them to the cluster and update the resource status in the ClusterResourceSetBinding object.
+	helmOptions, err := r.getHelmRenderOptions(ctx, cluster, clusterResourceSet)
+	if err != nil {
+		v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.RetrievingResourceFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		conditions.Set(clusterResourceSet, metav1.Condition{
+			Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  addonsv1.ClusterResourceSetResourcesAppliedInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return err
+	}
+
+	isServerSideApply := addonsv1.ClusterResourceSetStrategy(clusterResourceSet.Spec.Strategy) == addonsv1.ClusterResourceSetStrategyServerSideApply
+
+	// Compute the objects to be applied from all resources.
+	resourceScopes := make([]resourceReconcileScope, len(clusterResourceSet.Spec.Resources))
 	for i, resource := range clusterResourceSet.Spec.Resources {
 		unstructuredObj := objList[i]
 		if unstructuredObj == nil {
@@ -405,23 +437,55 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 			continue
 		}
 
-		resourceScope, err := reconcileScopeForResource(clusterResourceSet, resource, resourceSetBinding, unstructuredObj)
+		resourceScope, err := reconcileScopeForResource(clusterResourceSet, resource, resourceSetBinding, unstructuredObj, cluster, helmOptions)
 		if err != nil {
-			resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
+			// Only errors rendering templates, Helm charts or kustomizations are surfaced as rendering failures.
+			if isRenderError(err) {
+				log.Error(err, "Failed to render ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
+				v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.RenderingResourceFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+				conditions.Set(clusterResourceSet, metav1.Condition{
+					Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  addonsv1.ClusterResourceSetResourcesAppliedRenderingFailedReason,
+					Message: fmt.Sprintf("Failed to render %s %s for Cluster %s: %s", resource.Kind, resource.Name, cluster.Name, err.Error()),
+				})
+			}
+
+			resourceBinding := addonsv1.ResourceBinding{
 				ResourceRef:     resource,
 				Hash:            "",
 				Applied:         false,
 				LastAppliedTime: &metav1.Time{Time: time.Now().UTC()},
-			})
+			}
+			if isServerSideApply {
+				// Keep track of the objects applied previously, so they can be deleted once the resource can be applied again.
+				if previousResourceBinding := resourceSetBinding.GetResource(resource); previousResourceBinding != nil {
+					resourceBinding.Objects = previousResourceBinding.Objects
+				}
+				resourceBinding.LastError = truncateError(err)
+			}
+			resourceSetBinding.SetBinding(resourceBinding)
 
 			errList = append(errList, err)
 			continue
 		}
+		resourceScopes[i] = resourceScope
+	}
 
-		if !resourceScope.needsApply() {
+	var protectedObjs sets.Set[addonsv1.ResourceBindingObject]
+	if isServerSideApply {
+		protectedObjs = protectedObjects(clusterResourceSet, clusterResourceSetBinding, resourceSetBinding, resourceScopes)
+	}
+
+	// Iterate all resources and apply them to the cluster and update the resource status in the ClusterResourceSetBinding object.
+	for i, resource := range clusterResourceSet.Spec.Resources {
+		resourceScope := resourceScopes[i]
+		if resourceScope == nil || !resourceScope.needsApply() {
 			continue
 		}
 
+		previousResourceBinding := resourceSetBinding.GetResource(resource)
+
 		// Set status in ClusterResourceSetBinding in case of early continue due to a failure.
 		// Set only when resource is retrieved successfully.
 		resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
@@ -434,8 +498,10 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		// Apply all values in the key-value pair of the resource to the cluster.
 		// As there can be multiple key-value pairs in a resource, each value may have multiple objects in it.
 		isSuccessful := true
+		var applyErr error
 		if err := resourceScope.apply(ctx, remoteClient); err != nil {
 			isSuccessful = false
+			applyErr = err
 			log.Error(err, "Failed to apply ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
 			v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.ApplyFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
 			conditions.Set(clusterResourceSet, metav1.Condition{
@@ -447,13 +513,34 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 			errList = append(errList, err)
 		}
 
-		resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
+		resourceBinding := addonsv1.ResourceBinding{
 			ResourceRef:     resource,
 			Hash:            resourceScope.hash(),
 			Applied:         isSuccessful,
 			LastAppliedTime: &metav1.Time{Time: time.Now().UTC()},
-		})
+		}
+		if isServerSideApply {
+			var previousObjs []addonsv1.ResourceBindingObject
+			if previousResourceBinding != nil {
+				previousObjs = previousResourceBinding.Objects
+			}
+			pruneErr := reconcileResourceObjects(ctx, remoteClient, &resourceBinding, previousObjs, resourceScope.objs(), applyErr, protectedObjs)
+			if pruneErr != nil {
+				log.Error(pruneErr, "Failed to delete objects removed from ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
+				errList = append(errList, pruneErr)
+			}
+		}
+		resourceSetBinding.SetBinding(resourceBinding)
 	}
+
+	// Delete the objects of resources which have been removed from the ClusterResourceSet.
+	if isServerSideApply {
+		if err := pruneRemovedResources(ctx, remoteClient, clusterResourceSet, resourceSetBinding, protectedObjs); err != nil {
+			log.Error(err, "Failed to delete objects of resources removed from ClusterResourceSet")
+			errList = append(errList, err)
+		}
+	}
+
 	if len(errList) > 0 {
 		return kerrors.NewAggregate(errList)
 	}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"context"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+// defaultHelmReleaseNamespace is the namespace of the release used to render a Helm chart, if not set.
+const defaultHelmReleaseNamespace = "default"
+
+// helmRenderOptions are the options used to render Helm charts for a cluster.
+type helmRenderOptions struct {
+	// kubernetesVersion is the Kubernetes version of the cluster, available in templates as `{{ .Capabilities.KubeVersion }}`.
+	// If not set, the default Kubernetes version of Helm is used, like `helm template` does.
+	kubernetesVersion string
+}
+
+// getHelmRenderOptions returns the options used to render the Helm charts of a ClusterResourceSet for a cluster.
+// The Kubernetes version of the cluster is read from the topology, if any, otherwise from the control plane.
+func (r *Reconciler) getHelmRenderOptions(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) (helmRenderOptions, error) {
+	options := helmRenderOptions{}
+	if !slices.ContainsFunc(clusterResourceSet.Spec.Resources, func(resource addonsv1.ResourceRef) bool {
+		return addonsv1.ClusterResourceSetResourceFormat(resource.Format) == addonsv1.HelmChartClusterResourceSetResourceFormat
+	}) {
+		return options, nil
+	}
+
+	switch {
+	case cluster.Spec.Topology != nil && cluster.Spec.Topology.Version != "":
+		options.kubernetesVersion = cluster.Spec.Topology.Version
+	case cluster.Spec.ControlPlaneRef != nil:
+		controlPlane, err := external.GetObjectFromContractVersionedRef(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+		if err != nil && !apierrors.IsNotFound(errors.Cause(err)) {
+			return options, errors.Wrapf(err, "failed to get ControlPlane %s", klog.KRef(cluster.Namespace, cluster.Spec.ControlPlaneRef.Name))
+		}
+		if err == nil {
+			version, err := contract.ControlPlane().Version().Get(controlPlane)
+			if err != nil && !errors.Is(err, contract.ErrFieldNotFound) {
+				return options, errors.Wrapf(err, "failed to get the version of ControlPlane %s", klog.KObj(controlPlane))
+			}
+			if err == nil {
+				options.kubernetesVersion = *version
+			}
+		}
+	}
+	return options, nil
+}
+
+// renderHelmChart renders a Helm chart like `helm template` does.
+// The values of the chart are merged with the values rendered from the values template of the resource.
+// CRDs of the chart and of its dependencies are rendered first, while hooks and tests are not rendered,
+// because they are expected to be run by Helm at specific points of the release lifecycle.
+func renderHelmChart(resourceRef addonsv1.ResourceRef, files map[string][]byte, data *templateData, options helmRenderOptions) ([]byte, error) {
+	root, err := helmChartRoot(files)
+	if err != nil {
+		return nil, err
+	}
+
+	bufferedFiles := []*loader.BufferedFile{}
+	for name, content := range files {
+		if !strings.HasPrefix(name, root) {
+			continue
+		}
+		bufferedFiles = append(bufferedFiles, &loader.BufferedFile{Name: strings.TrimPrefix(name, root), Data: content})
+	}
+	helmChart, err := loader.LoadFiles(bufferedFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load chart")
+	}
+
+	overrides := map[string]interface{}{}
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      resourceRef.Name,
+		Namespace: defaultHelmReleaseNamespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	if resourceRef.HelmChart != nil {
+		if resourceRef.HelmChart.ReleaseName != "" {
+			releaseOptions.Name = resourceRef.HelmChart.ReleaseName
+		}
+		if resourceRef.HelmChart.ReleaseNamespace != "" {
+			releaseOptions.Namespace = resourceRef.HelmChart.ReleaseNamespace
+		}
+		if resourceRef.HelmChart.ValuesTemplate != "" {
+			renderedValues, err := renderTemplate("valuesTemplate", resourceRef.HelmChart.ValuesTemplate, data)
+			if err != nil {
+				return nil, err
+			}
+			if err := yaml.Unmarshal(renderedValues, &overrides); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse rendered values: %q", string(renderedValues))
+			}
+		}
+	}
+
+	capabilities := chartutil.DefaultCapabilities.Copy()
+	if options.kubernetesVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(options.kubernetesVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse Kubernetes version %q", options.kubernetesVersion)
+		}
+		capabilities.KubeVersion = *kubeVersion
+		if helmChart.Metadata.KubeVersion != "" && !chartutil.IsCompatibleRange(helmChart.Metadata.KubeVersion, capabilities.KubeVersion.String()) {
+			return nil, errors.Errorf("chart requires kubeVersion %s, which is incompatible with Kubernetes version %s", helmChart.Metadata.KubeVersion, capabilities.KubeVersion.String())
+		}
+	}
+
+	if err := chartutil.ProcessDependenciesWithMerge(helmChart, overrides); err != nil {
+		return nil, errors.Wrap(err, "failed to process chart dependencies")
+	}
+	values, err := chartutil.ToRenderValues(helmChart, overrides, releaseOptions, capabilities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute values")
+	}
+
+	// The chart is rendered without access to the cluster, like `helm template` does, so the lookup function returns
+	// empty objects; this ensures the rendered manifests, and thus the hash of the resource, only depend on the inputs.
+	rendered, err := (&engine.Engine{}).Render(helmChart, values)
+	if err != nil {
+		return nil, err
+	}
+	for name := range rendered {
+		if strings.HasSuffix(name, "NOTES.txt") {
+			delete(rendered, name)
+		}
+	}
+
+	// Hooks, including test hooks, are dropped by SortManifests.
+	_, sortedManifests, err := releaseutil.SortManifests(rendered, capabilities.APIVersions, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse rendered manifests")
+	}
+
+	manifests := [][]byte{}
+	for _, crd := range helmChartCRDs(helmChart) {
+		manifests = append(manifests, crd.File.Data)
+	}
+	for _, manifest := range sortedManifests {
+		// Tests are run by `helm test`, so they are not rendered even if they are not annotated as hooks.
+		if isHelmTestManifest(manifest.Name) {
+			continue
+		}
+		manifests = append(manifests, []byte(manifest.Content))
+	}
+
+	return utilyaml.JoinYaml(manifests...), nil
+}
+
+// helmChartRoot returns the directory of the chart in files, i.e. the directory with the Chart.yaml file.
+// The chart can be at the root of files or in a top-level directory, like in archives created by `helm package`.
+func helmChartRoot(files map[string][]byte) (string, error) {
+	if _, ok := files["Chart.yaml"]; ok {
+		return "", nil
+	}
+	roots := []string{}
+	for name := range files {
+		if dir, base := path.Split(name); base == "Chart.yaml" && strings.Count(dir, "/") == 1 {
+			roots = append(roots, dir)
+		}
+	}
+	switch len(roots) {
+	case 0:
+		return "", errors.New("Chart.yaml not found")
+	case 1:
+		return roots[0], nil
+	default:
+		sort.Strings(roots)
+		return "", errors.Errorf("multiple charts found: %s", strings.Join(roots, ", "))
+	}
+}
+
+// helmChartCRDs returns the CRDs of a chart and of its dependencies, sorted by file name.
+func helmChartCRDs(helmChart *chart.Chart) []chart.CRD {
+	crds := helmChart.CRDObjects()
+	sort.SliceStable(crds, func(i, j int) bool {
+		return crds[i].Filename < crds[j].Filename
+	})
+	return crds
+}
+
+// isHelmTestManifest returns true if the manifest is rendered from the templates/tests directory of a chart.
+func isHelmTestManifest(name string) bool {
+	return strings.Contains("/"+name, "/templates/tests/")
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/chartutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestRenderHelmChart(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"region": "eu"},
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Version: "v1.33.1",
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "mtu", Value: apiextensionsv1.JSON{Raw: []byte(`1450`)}},
+				},
+			},
+		},
+	}
+	options := helmRenderOptions{kubernetesVersion: "v1.33.1"}
+
+	chartFiles := map[string][]byte{
+		"cni/Chart.yaml": []byte(`apiVersion: v2
+name: cni
+version: 1.2.3
+appVersion: 4.5.6
+`),
+		"cni/values.yaml": []byte(`image:
+  repository: registry.example.com/cni
+  tag: ""
+mtu: 1500
+region: ""
+debug: true
+`),
+		"cni/crds/crd.yaml": []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: networks.cni.example.com
+`),
+		"cni/templates/_helpers.tpl": []byte(`{{- define "cni.labels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+`),
+		"cni/templates/NOTES.txt": []byte(`Thank you for installing {{ .Chart.Name }}.`),
+		"cni/templates/configmap.yaml": []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-config
+  namespace: {{ .Release.Namespace }}
+  labels:
+    {{- include "cni.labels" . | nindent 4 }}
+data:
+  mtu: {{ .Values.mtu | quote }}
+  region: {{ .Values.region | quote }}
+  image: "{{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}"
+  debug: "{{ .Values.debug }}"
+  missing: "{{ .Values.missing }}"
+  kubeVersion: {{ .Capabilities.KubeVersion.Version | quote }}
+  policyV1: {{ .Capabilities.APIVersions.Has "policy/v1" | quote }}
+  config: {{ .Files.Get "config.txt" | quote }}
+`),
+		"cni/templates/disabled.yaml": []byte(`{{- if .Values.disabled }}
+apiVersion: v1
+kind: Secret
+metadata:
+  name: disabled
+{{- end }}
+`),
+		"cni/config.txt": []byte(`some config`),
+	}
+
+	t.Run("renders the chart with the values template", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resourceRef := addonsv1.ResourceRef{
+			Name:   "cni",
+			Kind:   "ConfigMap",
+			Format: string(addonsv1.HelmChartClusterResourceSetResourceFormat),
+			HelmChart: &addonsv1.HelmChartSource{
+				ReleaseName:      "my-cni",
+				ReleaseNamespace: "kube-system",
+				ValuesTemplate: `mtu: {{ .Variables.mtu }}
+region: {{ .Cluster.Labels.region }}
+debug: null
+`,
+			},
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		rendered, err := renderHelmChart(resourceRef, chartFiles, data, options)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		objs, err := objsFromYamlData([][]byte{rendered})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs).To(HaveLen(2))
+		g.Expect(objs[0].GetKind()).To(Equal("CustomResourceDefinition"))
+		g.Expect(objs[1].GetKind()).To(Equal("ConfigMap"))
+		g.Expect(objs[1].GetName()).To(Equal("my-cni-config"))
+		g.Expect(objs[1].GetNamespace()).To(Equal("kube-system"))
+		g.Expect(objs[1].GetLabels()).To(Equal(map[string]string{
+			"app.kubernetes.io/name":     "cni",
+			"app.kubernetes.io/instance": "my-cni",
+		}))
+		configMapData, _, err := unstructured.NestedStringMap(objs[1].Object, "data")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(configMapData).To(Equal(map[string]string{
+			"mtu":         "1450",
+			"region":      "eu",
+			"image":       "registry.example.com/cni:4.5.6",
+			"debug":       "",
+			"missing":     "",
+			"kubeVersion": "v1.33.1",
+			"policyV1":    "true",
+			"config":      "some config",
+		}))
+	})
+
+	t.Run("uses defaults without helmChart", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resourceRef := addonsv1.ResourceRef{
+			Name:   "cni",
+			Kind:   "ConfigMap",
+			Format: string(addonsv1.HelmChartClusterResourceSetResourceFormat),
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		rendered, err := renderHelmChart(resourceRef, chartFiles, data, options)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		objs, err := objsFromYamlData([][]byte{rendered})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs).To(HaveLen(2))
+		g.Expect(objs[1].GetName()).To(Equal("cni-config"))
+		g.Expect(objs[1].GetNamespace()).To(Equal(defaultHelmReleaseNamespace))
+	})
+
+	t.Run("renders chart dependencies", func(t *testing.T) {
+		g := NewWithT(t)
+
+		files := map[string][]byte{
+			"Chart.yaml": []byte(`apiVersion: v2
+name: cni
+version: 1.0.0
+dependencies:
+- name: other
+  version: 1.0.0
+- name: disabled
+  version: 1.0.0
+  condition: disabled.enabled
+`),
+			"values.yaml":                           []byte("other:\n  name: from-parent\ndisabled:\n  enabled: false\n"),
+			"templates/configmap.yaml":              []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\n"),
+			"charts/other/Chart.yaml":               []byte("apiVersion: v2\nname: other\nversion: 1.0.0\n"),
+			"charts/other/values.yaml":              []byte("name: default\n"),
+			"charts/other/crds/crd.yaml":            []byte("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: others.example.com\n"),
+			"charts/other/templates/configmap.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Values.name }}\n"),
+			"charts/disabled/Chart.yaml":            []byte("apiVersion: v2\nname: disabled\nversion: 1.0.0\n"),
+			"charts/disabled/templates/secret.yaml": []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: disabled\n"),
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		rendered, err := renderHelmChart(addonsv1.ResourceRef{Name: "cni"}, files, data, options)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		objs, err := objsFromYamlData([][]byte{rendered})
+		g.Expect(err).ToNot(HaveOccurred())
+		names := []string{}
+		for _, obj := range objs {
+			names = append(names, obj.GetKind()+"/"+obj.GetName())
+		}
+		g.Expect(names).To(ConsistOf("CustomResourceDefinition/others.example.com", "ConfigMap/cni", "ConfigMap/from-parent"))
+		g.Expect(names[0]).To(Equal("CustomResourceDefinition/others.example.com"))
+	})
+
+	t.Run("does not render hooks and tests", func(t *testing.T) {
+		g := NewWithT(t)
+
+		files := map[string][]byte{
+			"Chart.yaml":               []byte("apiVersion: v2\nname: cni\nversion: 1.0.0\n"),
+			"templates/configmap.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\n"),
+			"templates/job.yaml": []byte(`apiVersion: batch/v1
+kind: Job
+metadata:
+  name: pre-install
+  annotations:
+    helm.sh/hook: pre-install
+`),
+			"templates/tests/test-connection.yaml": []byte("apiVersion: v1\nkind: Pod\nmetadata:\n  name: test-connection\n"),
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		rendered, err := renderHelmChart(addonsv1.ResourceRef{Name: "cni"}, files, data, options)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		objs, err := objsFromYamlData([][]byte{rendered})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs).To(HaveLen(1))
+		g.Expect(objs[0].GetName()).To(Equal("cni"))
+	})
+
+	t.Run("uses the default Kubernetes version of Helm if the version of the cluster is not known", func(t *testing.T) {
+		g := NewWithT(t)
+
+		files := map[string][]byte{
+			"Chart.yaml":               []byte("apiVersion: v2\nname: cni\nversion: 1.0.0\n"),
+			"templates/configmap.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\ndata:\n  kubeVersion: {{ .Capabilities.KubeVersion.Version | quote }}\n"),
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		rendered, err := renderHelmChart(addonsv1.ResourceRef{Name: "cni"}, files, data, helmRenderOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		objs, err := objsFromYamlData([][]byte{rendered})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs).To(HaveLen(1))
+		kubeVersion, _, err := unstructured.NestedString(objs[0].Object, "data", "kubeVersion")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(kubeVersion).To(Equal(chartutil.DefaultCapabilities.KubeVersion.Version))
+	})
+
+	t.Run("renders lookup as empty objects, without reading from the cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		files := map[string][]byte{
+			"Chart.yaml":               []byte("apiVersion: v2\nname: cni\nversion: 1.0.0\n"),
+			"templates/configmap.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\ndata:\n  namespace: {{ (lookup \"v1\" \"Namespace\" \"\" \"kube-system\").metadata | default \"not found\" | quote }}\n"),
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		rendered, err := renderHelmChart(addonsv1.ResourceRef{Name: "cni"}, files, data, options)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		objs, err := objsFromYamlData([][]byte{rendered})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs).To(HaveLen(1))
+		namespace, _, err := unstructured.NestedString(objs[0].Object, "data", "namespace")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(namespace).To(Equal("not found"))
+	})
+
+	t.Run("fails if the chart is not compatible with the Kubernetes version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		files := map[string][]byte{
+			"Chart.yaml":               []byte("apiVersion: v2\nname: cni\nversion: 1.0.0\nkubeVersion: \">= 1.34.0-0\"\n"),
+			"templates/configmap.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\n"),
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = renderHelmChart(addonsv1.ResourceRef{Name: "cni"}, files, data, options)
+		g.Expect(err).To(MatchError(ContainSubstring("chart requires kubeVersion >= 1.34.0-0")))
+	})
+
+	t.Run("fails if a required value is missing", func(t *testing.T) {
+		g := NewWithT(t)
+
+		files := map[string][]byte{
+			"Chart.yaml":               []byte("apiVersion: v2\nname: cni\nversion: 1.0.0\n"),
+			"templates/configmap.yaml": []byte(`name: {{ required "name is required" .Values.name }}`),
+		}
+		data, err := newTemplateData(cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = renderHelmChart(addonsv1.ResourceRef{Name: "cni"}, files, data, options)
+		g.Expect(err).To(MatchError(ContainSubstring("name is required")))
+	})
+}
+
+func TestHelmChartRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		wantRoot string
+		wantErr  string
+	}{
+		{
+			name:     "chart at the root",
+			files:    []string{"Chart.yaml", "templates/cm.yaml"},
+			wantRoot: "",
+		},
+		{
+			name:     "chart in a directory",
+			files:    []string{"cni/Chart.yaml", "cni/templates/cm.yaml", "cni/charts/other/Chart.yaml"},
+			wantRoot: "cni/",
+		},
+		{
+			name:    "no chart",
+			files:   []string{"templates/cm.yaml"},
+			wantErr: "Chart.yaml not found",
+		},
+		{
+			name:    "multiple charts",
+			files:   []string{"cni/Chart.yaml", "csi/Chart.yaml"},
+			wantErr: "multiple charts found: cni/, csi/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			files := map[string][]byte{}
+			for _, name := range tt.files {
+				files[name] = []byte{}
+			}
+			root, err := helmChartRoot(files)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(root).To(Equal(tt.wantRoot))
+		})
+	}
+}
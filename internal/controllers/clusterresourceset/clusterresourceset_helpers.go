@@ -24,16 +24,22 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 	"sort"
 	"unicode"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -45,6 +51,9 @@ import (
 
 var jsonListPrefix = []byte("[")
 
+// maxLastErrorLength is the maximum length of ResourceBinding.LastError.
+const maxLastErrorLength = 10240
+
 // objsFromYamlData parses a collection of yaml documents into Unstructured objects.
 // The returned objects are sorted for creation priority within the objects defined
 // in the same document. The flattening of the documents preserves the original order.
@@ -225,3 +234,144 @@ func ensureKubernetesServiceCreated(ctx context.Context, client client.Client) e
 	}
 	return nil
 }
+
+// resourceBindingObjects returns the ResourceBindingObjects identifying the given objects.
+func resourceBindingObjects(objs []unstructured.Unstructured) []addonsv1.ResourceBindingObject {
+	resourceBindingObjs := []addonsv1.ResourceBindingObject{}
+	seen := sets.Set[addonsv1.ResourceBindingObject]{}
+	for _, obj := range objs {
+		resourceBindingObj := addonsv1.ResourceBindingObject{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		}
+		if seen.Has(resourceBindingObj) {
+			continue
+		}
+		seen.Insert(resourceBindingObj)
+		resourceBindingObjs = append(resourceBindingObjs, resourceBindingObj)
+	}
+	return resourceBindingObjs
+}
+
+// protectedObjects returns the objects which must not be deleted when pruning objects of a ClusterResourceSet
+// with the ServerSideApply strategy, i.e. the objects which are still defined by one of the resources of the
+// ClusterResourceSet and the objects applied by other ClusterResourceSets.
+// Note: If a resource could not be read, the objects previously applied from this resource are protected.
+func protectedObjects(clusterResourceSet *addonsv1.ClusterResourceSet, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding, resourceSetBinding *addonsv1.ResourceSetBinding, resourceScopes []resourceReconcileScope) sets.Set[addonsv1.ResourceBindingObject] {
+	protected := sets.Set[addonsv1.ResourceBindingObject]{}
+	for i, resource := range clusterResourceSet.Spec.Resources {
+		if resourceScopes[i] != nil {
+			protected.Insert(resourceBindingObjects(resourceScopes[i].objs())...)
+			continue
+		}
+		if resourceBinding := resourceSetBinding.GetResource(resource); resourceBinding != nil {
+			protected.Insert(resourceBinding.Objects...)
+		}
+	}
+
+	for _, binding := range clusterResourceSetBinding.Spec.Bindings {
+		if binding == nil || binding.ClusterResourceSetName == clusterResourceSet.Name {
+			continue
+		}
+		for _, resourceBinding := range binding.Resources {
+			protected.Insert(resourceBinding.Objects...)
+		}
+	}
+	return protected
+}
+
+// reconcileResourceObjects records the objects applied from a resource in the ResourceBinding and deletes the objects
+// which have been applied previously from the resource but which are not defined by the resource anymore.
+func reconcileResourceObjects(ctx context.Context, c client.Client, resourceBinding *addonsv1.ResourceBinding, previousObjs []addonsv1.ResourceBindingObject, objs []unstructured.Unstructured, applyErr error, protected sets.Set[addonsv1.ResourceBindingObject]) error {
+	desiredObjs := resourceBindingObjects(objs)
+	desired := sets.New(desiredObjs...)
+
+	staleObjs := []addonsv1.ResourceBindingObject{}
+	for _, obj := range previousObjs {
+		if !desired.Has(obj) {
+			staleObjs = append(staleObjs, obj)
+		}
+	}
+
+	if applyErr != nil {
+		// Keep track of the objects applied previously, so they are deleted once the resource is applied successfully.
+		resourceBinding.LastError = truncateError(applyErr)
+		resourceBinding.Objects = append(desiredObjs, staleObjs...)
+		return nil
+	}
+
+	remainingObjs, err := deleteObjects(ctx, c, staleObjs, protected)
+	resourceBinding.Objects = append(desiredObjs, remainingObjs...)
+	if err != nil {
+		resourceBinding.LastError = truncateError(err)
+	}
+	return err
+}
+
+// pruneRemovedResources deletes the objects applied from resources which have been removed from the ClusterResourceSet
+// and removes the resources from the ResourceSetBinding.
+func pruneRemovedResources(ctx context.Context, c client.Client, clusterResourceSet *addonsv1.ClusterResourceSet, resourceSetBinding *addonsv1.ResourceSetBinding, protected sets.Set[addonsv1.ResourceBindingObject]) error {
+	errList := []error{}
+	resources := []addonsv1.ResourceBinding{}
+	for _, resourceBinding := range resourceSetBinding.Resources {
+		if slices.ContainsFunc(clusterResourceSet.Spec.Resources, func(resource addonsv1.ResourceRef) bool {
+			return reflect.DeepEqual(resource, resourceBinding.ResourceRef)
+		}) {
+			resources = append(resources, resourceBinding)
+			continue
+		}
+
+		remainingObjs, err := deleteObjects(ctx, c, resourceBinding.Objects, protected)
+		if err != nil {
+			// Keep the resource until all its objects have been deleted.
+			resourceBinding.Objects = remainingObjs
+			resourceBinding.LastError = truncateError(err)
+			resources = append(resources, resourceBinding)
+			errList = append(errList, err)
+		}
+	}
+	resourceSetBinding.Resources = resources
+
+	return kerrors.NewAggregate(errList)
+}
+
+// deleteObjects deletes the given objects, except protected objects, and returns the objects which could not be deleted.
+func deleteObjects(ctx context.Context, c client.Client, objs []addonsv1.ResourceBindingObject, protected sets.Set[addonsv1.ResourceBindingObject]) ([]addonsv1.ResourceBindingObject, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	errList := []error{}
+	remainingObjs := []addonsv1.ResourceBindingObject{}
+	for _, obj := range objs {
+		if protected.Has(obj) {
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(obj.APIVersion)
+		u.SetKind(obj.Kind)
+		u.SetNamespace(obj.Namespace)
+		u.SetName(obj.Name)
+		if err := c.Delete(ctx, u); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			errList = append(errList, errors.Wrapf(err, "deleting object %s %s", u.GroupVersionKind(), klog.KObj(u)))
+			remainingObjs = append(remainingObjs, obj)
+			continue
+		}
+		log.Info("Deleted object removed from ClusterResourceSet", obj.Kind, klog.KObj(u))
+	}
+
+	return remainingObjs, kerrors.NewAggregate(errList)
+}
+
+// truncateError returns the error message, truncated to the maximum length of ResourceBinding.LastError.
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > maxLastErrorLength {
+		return msg[:maxLastErrorLength-3] + "..."
+	}
+	return msg
+}
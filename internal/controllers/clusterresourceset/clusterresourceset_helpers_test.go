@@ -22,10 +22,13 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -271,3 +274,128 @@ func TestEnsureKubernetesServiceCreated(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileResourceObjects(t *testing.T) {
+	cm1 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm1"}
+	cm2 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm2"}
+	cm3 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm3"}
+
+	tests := []struct {
+		name              string
+		previousObjs      []addonsv1.ResourceBindingObject
+		objs              []addonsv1.ResourceBindingObject
+		applyErr          error
+		protected         []addonsv1.ResourceBindingObject
+		wantObjs          []addonsv1.ResourceBindingObject
+		wantLastError     string
+		wantExistingNames []string
+	}{
+		{
+			name:              "records applied objects",
+			objs:              []addonsv1.ResourceBindingObject{cm1, cm2},
+			wantObjs:          []addonsv1.ResourceBindingObject{cm1, cm2},
+			wantExistingNames: []string{"cm1", "cm2", "cm3"},
+		},
+		{
+			name:              "deletes objects removed from the resource",
+			previousObjs:      []addonsv1.ResourceBindingObject{cm1, cm2, cm3},
+			objs:              []addonsv1.ResourceBindingObject{cm1},
+			wantObjs:          []addonsv1.ResourceBindingObject{cm1},
+			wantExistingNames: []string{"cm1"},
+		},
+		{
+			name:              "does not delete protected objects",
+			previousObjs:      []addonsv1.ResourceBindingObject{cm1, cm2, cm3},
+			objs:              []addonsv1.ResourceBindingObject{cm1},
+			protected:         []addonsv1.ResourceBindingObject{cm3},
+			wantObjs:          []addonsv1.ResourceBindingObject{cm1},
+			wantExistingNames: []string{"cm1", "cm3"},
+		},
+		{
+			name:              "keeps objects removed from the resource if apply failed",
+			previousObjs:      []addonsv1.ResourceBindingObject{cm1, cm2},
+			objs:              []addonsv1.ResourceBindingObject{cm1},
+			applyErr:          errors.New("failed to apply"),
+			wantObjs:          []addonsv1.ResourceBindingObject{cm1, cm2},
+			wantLastError:     "failed to apply",
+			wantExistingNames: []string{"cm1", "cm2", "cm3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithObjects(
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cm1"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cm2"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cm3"}},
+			).Build()
+
+			objs := []unstructured.Unstructured{}
+			for _, obj := range tt.objs {
+				u := unstructured.Unstructured{}
+				u.SetAPIVersion(obj.APIVersion)
+				u.SetKind(obj.Kind)
+				u.SetNamespace(obj.Namespace)
+				u.SetName(obj.Name)
+				objs = append(objs, u)
+			}
+
+			resourceBinding := &addonsv1.ResourceBinding{}
+			err := reconcileResourceObjects(ctx, c, resourceBinding, tt.previousObjs, objs, tt.applyErr, sets.New(tt.protected...))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(resourceBinding.Objects).To(Equal(tt.wantObjs))
+			g.Expect(resourceBinding.LastError).To(Equal(tt.wantLastError))
+
+			configMaps := &corev1.ConfigMapList{}
+			g.Expect(c.List(ctx, configMaps)).To(Succeed())
+			existingNames := []string{}
+			for _, cm := range configMaps.Items {
+				existingNames = append(existingNames, cm.Name)
+			}
+			g.Expect(existingNames).To(ConsistOf(tt.wantExistingNames))
+		})
+	}
+}
+
+func TestPruneRemovedResources(t *testing.T) {
+	g := NewWithT(t)
+
+	cm1 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm1"}
+	cm2 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm2"}
+	cm3 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm3"}
+	missing := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "missing"}
+
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cm1"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cm2"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cm3"}},
+	).Build()
+
+	keptResource := addonsv1.ResourceRef{Name: "kept", Kind: "ConfigMap"}
+	removedResource := addonsv1.ResourceRef{Name: "removed", Kind: "ConfigMap"}
+	clusterResourceSet := &addonsv1.ClusterResourceSet{
+		Spec: addonsv1.ClusterResourceSetSpec{
+			Resources: []addonsv1.ResourceRef{keptResource},
+		},
+	}
+	resourceSetBinding := &addonsv1.ResourceSetBinding{
+		Resources: []addonsv1.ResourceBinding{
+			{ResourceRef: keptResource, Objects: []addonsv1.ResourceBindingObject{cm1}},
+			{ResourceRef: removedResource, Objects: []addonsv1.ResourceBindingObject{cm2, cm3, missing}},
+		},
+	}
+
+	// cm3 is protected, e.g. because it is applied by another ClusterResourceSet.
+	g.Expect(pruneRemovedResources(ctx, c, clusterResourceSet, resourceSetBinding, sets.New(cm1, cm3))).To(Succeed())
+	g.Expect(resourceSetBinding.Resources).To(HaveLen(1))
+	g.Expect(resourceSetBinding.Resources[0].ResourceRef).To(Equal(keptResource))
+
+	configMaps := &corev1.ConfigMapList{}
+	g.Expect(c.List(ctx, configMaps)).To(Succeed())
+	existingNames := []string{}
+	for _, cm := range configMaps.Items {
+		existingNames = append(existingNames, cm.Name)
+	}
+	g.Expect(existingNames).To(ConsistOf("cm1", "cm3"))
+}
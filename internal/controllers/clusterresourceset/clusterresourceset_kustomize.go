@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+)
+
+// kustomizationPathFields are the fields of a kustomization which contain paths, either as a string or a list of strings.
+var kustomizationPathFields = sets.New(
+	"resources", "components", "bases", "crds", "configurations", "generators", "transformers", "validators",
+	"patchesStrategicMerge", "path", "files", "envs", "env",
+)
+
+// kustomizationSourceFields are the fields of a kustomization which may contain directories, which
+// kustomize otherwise tries to clone from remote git repositories.
+var kustomizationSourceFields = []string{"resources", "components", "bases"}
+
+// buildKustomization builds the kustomization in a resource, similar to `kustomize build`.
+// Before building, the kustomization file is rendered as a template, so it can be customized for the cluster.
+// Note: Kustomizations are built in memory, and loading resources from outside of the resource, e.g. from remote
+// git repositories or URLs, is not allowed.
+func buildKustomization(resourceRef addonsv1.ResourceRef, files map[string][]byte, data *templateData) ([]byte, error) {
+	dir := "/"
+	if resourceRef.Kustomization != nil && resourceRef.Kustomization.Path != "" {
+		dir = path.Clean("/" + resourceRef.Kustomization.Path)
+	}
+
+	fSys := filesys.MakeFsInMemory()
+	for name, content := range files {
+		if err := fSys.WriteFile(path.Join("/", name), content); err != nil {
+			return nil, errors.Wrapf(err, "failed to write file %s", name)
+		}
+	}
+
+	kustomizationFile := ""
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if fSys.Exists(path.Join(dir, name)) {
+			kustomizationFile = path.Join(dir, name)
+			break
+		}
+	}
+	if kustomizationFile == "" {
+		return nil, errors.Errorf("kustomization file not found in %s", dir)
+	}
+
+	content, err := fSys.ReadFile(kustomizationFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", kustomizationFile)
+	}
+	rendered, err := renderTemplate(kustomizationFile, string(content), data)
+	if err != nil {
+		return nil, err
+	}
+	if err := fSys.WriteFile(kustomizationFile, rendered); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", kustomizationFile)
+	}
+
+	if err := validateKustomizationSources(fSys); err != nil {
+		return nil, err
+	}
+
+	options := krusty.MakeDefaultOptions()
+	// Allow overlays to reference bases in other directories of the resource; the file system only contains
+	// the files of the resource.
+	options.LoadRestrictions = types.LoadRestrictionsNone
+	resMap, err := krusty.MakeKustomizer(options).Run(fSys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return resMap.AsYaml()
+}
+
+// validateKustomizationSources validates that all the kustomizations in the file system only reference
+// files and directories which exist in the file system.
+func validateKustomizationSources(fSys filesys.FileSystem) error {
+	kustomizationFiles := []string{}
+	err := fSys.Walk("/", func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && slices.Contains(konfig.RecognizedKustomizationFileNames(), path.Base(name)) {
+			kustomizationFiles = append(kustomizationFiles, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(kustomizationFiles)
+
+	for _, name := range kustomizationFiles {
+		content, err := fSys.ReadFile(name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", name)
+		}
+		kustomization := map[string]interface{}{}
+		if err := yaml.Unmarshal(content, &kustomization); err != nil {
+			return errors.Wrapf(err, "failed to parse %s", name)
+		}
+
+		if remote := findRemotePath(kustomization, ""); remote != "" {
+			return errors.Errorf("%s references %q: remote sources are not supported", name, remote)
+		}
+		for _, field := range kustomizationSourceFields {
+			sources, _ := kustomization[field].([]interface{})
+			for _, source := range sources {
+				s, ok := source.(string)
+				if !ok {
+					continue
+				}
+				if !fSys.Exists(path.Join(path.Dir(name), s)) {
+					return errors.Errorf("%s references %q, which does not exist: remote sources are not supported", name, s)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findRemotePath returns the first URL found in the path fields of a kustomization.
+func findRemotePath(value interface{}, field string) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if remote := findRemotePath(v[key], key); remote != "" {
+				return remote
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if remote := findRemotePath(item, field); remote != "" {
+				return remote
+			}
+		}
+	case string:
+		if !kustomizationPathFields.Has(field) {
+			return ""
+		}
+		// Generator files can be specified as key=path.
+		p := v
+		if i := strings.Index(p, "="); i >= 0 && (field == "files" || field == "envs" || field == "env") {
+			p = p[i+1:]
+		}
+		if u, err := url.Parse(p); err == nil && u.Scheme != "" && u.Host != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestBuildKustomization(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"region": "eu"},
+		},
+	}
+
+	baseFiles := map[string]string{
+		"base/kustomization.yaml": `resources:
+- configmap.yaml
+`,
+		"base/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cni-config
+data:
+  mtu: "1500"
+`,
+	}
+
+	tests := []struct {
+		name          string
+		files         map[string]string
+		kustomization *addonsv1.KustomizationSource
+		wantName      string
+		wantNamespace string
+		wantLabels    map[string]string
+		wantErr       string
+	}{
+		{
+			name:     "builds the kustomization at the root",
+			files:    map[string]string{"kustomization.yaml": "resources:\n- base\n", "base/kustomization.yaml": baseFiles["base/kustomization.yaml"], "base/configmap.yaml": baseFiles["base/configmap.yaml"]},
+			wantName: "cni-config",
+		},
+		{
+			name: "builds an overlay templated for the cluster",
+			files: map[string]string{
+				"overlays/prod/kustomization.yaml": `resources:
+- ../../base
+namespace: kube-system
+namePrefix: {{ .Cluster.Name }}-
+labels:
+- pairs:
+    region: {{ .Cluster.Labels.region }}
+`,
+				"base/kustomization.yaml": baseFiles["base/kustomization.yaml"],
+				"base/configmap.yaml":     baseFiles["base/configmap.yaml"],
+			},
+			kustomization: &addonsv1.KustomizationSource{Path: "overlays/prod"},
+			wantName:      "cluster1-cni-config",
+			wantNamespace: "kube-system",
+			wantLabels:    map[string]string{"region": "eu"},
+		},
+		{
+			name:    "fails without kustomization file",
+			files:   map[string]string{"configmap.yaml": baseFiles["base/configmap.yaml"]},
+			wantErr: "kustomization file not found in /",
+		},
+		{
+			name: "fails for remote resources",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- https://example.com/manifests.yaml\n",
+			},
+			wantErr: `references "https://example.com/manifests.yaml": remote sources are not supported`,
+		},
+		{
+			name: "fails for remote generator files",
+			files: map[string]string{
+				"kustomization.yaml": "configMapGenerator:\n- name: config\n  files:\n  - config=https://example.com/config\n",
+			},
+			wantErr: `references "config=https://example.com/config": remote sources are not supported`,
+		},
+		{
+			name: "fails for remote git repositories",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- github.com/example/manifests//base?ref=v1.0.0\n",
+			},
+			wantErr: `references "github.com/example/manifests//base?ref=v1.0.0", which does not exist: remote sources are not supported`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			files := map[string][]byte{}
+			for name, content := range tt.files {
+				files[name] = []byte(content)
+			}
+			data, err := newTemplateData(cluster)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			resourceRef := addonsv1.ResourceRef{
+				Name:          "cni",
+				Kind:          "ConfigMap",
+				Format:        string(addonsv1.KustomizationClusterResourceSetResourceFormat),
+				Kustomization: tt.kustomization,
+			}
+			built, err := buildKustomization(resourceRef, files, data)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			objs, err := objsFromYamlData([][]byte{built})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(objs).To(HaveLen(1))
+			g.Expect(objs[0].GetName()).To(Equal(tt.wantName))
+			g.Expect(objs[0].GetNamespace()).To(Equal(tt.wantNamespace))
+			g.Expect(objs[0].GetLabels()).To(Equal(tt.wantLabels))
+		})
+	}
+}
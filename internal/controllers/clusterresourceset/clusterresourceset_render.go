@@ -0,0 +1,311 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// maxResourceFilesSize is the maximum size of the files read from a resource, including the content of archives.
+const maxResourceFilesSize = 50 * 1024 * 1024
+
+// renderError is returned when the templates, the Helm chart or the kustomization of a resource cannot be rendered.
+type renderError struct {
+	err error
+}
+
+func (e *renderError) Error() string {
+	return e.err.Error()
+}
+
+func (e *renderError) Unwrap() error {
+	return e.err
+}
+
+// isRenderError returns true if err is or wraps a renderError.
+func isRenderError(err error) bool {
+	var renderErr *renderError
+	return errors.As(err, &renderErr)
+}
+
+// renderResource returns the data of a resource to be applied to a cluster.
+// Raw resources are returned normalized, while templates, Helm charts and kustomizations are rendered for the cluster.
+func renderResource(resourceRef addonsv1.ResourceRef, resource *unstructured.Unstructured, cluster *clusterv1.Cluster, helmOptions helmRenderOptions) ([][]byte, error) {
+	switch addonsv1.ClusterResourceSetResourceFormat(resourceRef.Format) {
+	case "", addonsv1.RawClusterResourceSetResourceFormat:
+		return normalizeData(resource)
+	case addonsv1.TemplateClusterResourceSetResourceFormat:
+		files, err := resourceFiles(resource)
+		if err != nil {
+			return nil, err
+		}
+		data, err := newTemplateData(cluster)
+		if err != nil {
+			return nil, &renderError{err: err}
+		}
+		// Since maps are not ordered, we need to order them to get the same hash at each reconcile.
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		dataList := make([][]byte, 0, len(names))
+		for _, name := range names {
+			rendered, err := renderTemplate(name, string(files[name]), data)
+			if err != nil {
+				return nil, &renderError{err: errors.Wrapf(err, "failed to render resource %s", klog.KObj(resource))}
+			}
+			dataList = append(dataList, rendered)
+		}
+		return dataList, nil
+	case addonsv1.HelmChartClusterResourceSetResourceFormat:
+		files, err := resourceFiles(resource)
+		if err != nil {
+			return nil, err
+		}
+		data, err := newTemplateData(cluster)
+		if err != nil {
+			return nil, &renderError{err: err}
+		}
+		rendered, err := renderHelmChart(resourceRef, files, data, helmOptions)
+		if err != nil {
+			return nil, &renderError{err: errors.Wrapf(err, "failed to render Helm chart from resource %s", klog.KObj(resource))}
+		}
+		return [][]byte{rendered}, nil
+	case addonsv1.KustomizationClusterResourceSetResourceFormat:
+		files, err := resourceFiles(resource)
+		if err != nil {
+			return nil, err
+		}
+		data, err := newTemplateData(cluster)
+		if err != nil {
+			return nil, &renderError{err: err}
+		}
+		built, err := buildKustomization(resourceRef, files, data)
+		if err != nil {
+			return nil, &renderError{err: errors.Wrapf(err, "failed to build kustomization from resource %s", klog.KObj(resource))}
+		}
+		return [][]byte{built}, nil
+	default:
+		return nil, errors.Errorf("unsupported resource format: %q", resourceRef.Format)
+	}
+}
+
+// templateData is the data available to the templates rendered for a cluster.
+type templateData struct {
+	Cluster   clusterTemplateData
+	Variables map[string]interface{}
+}
+
+// clusterTemplateData is the data of a Cluster available to templates.
+type clusterTemplateData struct {
+	Name                 string
+	Namespace            string
+	Labels               map[string]string
+	Annotations          map[string]string
+	ClusterNetwork       clusterNetworkTemplateData
+	ControlPlaneEndpoint clusterv1.APIEndpoint
+}
+
+// clusterNetworkTemplateData is the network configuration of a Cluster available to templates.
+// Note: Pods and Services are rendered as a comma separated list of CIDRs, e.g. `{{ .Cluster.ClusterNetwork.Pods }}`,
+// while single CIDRs can be accessed with `{{ index .Cluster.ClusterNetwork.Pods.CIDRBlocks 0 }}`.
+type clusterNetworkTemplateData struct {
+	APIServerPort int32
+	Services      clusterv1.NetworkRanges
+	Pods          clusterv1.NetworkRanges
+	ServiceDomain string
+}
+
+// newTemplateData returns the data available to the templates rendered for a cluster.
+// Note: The values of the topology variables are converted to their Go types, so they can be used in templates
+// like this: `{{ .Variables.cni.mtu }}`.
+func newTemplateData(cluster *clusterv1.Cluster) (*templateData, error) {
+	data := &templateData{
+		Cluster: clusterTemplateData{
+			Name:                 cluster.Name,
+			Namespace:            cluster.Namespace,
+			Labels:               cluster.Labels,
+			Annotations:          cluster.Annotations,
+			ControlPlaneEndpoint: cluster.Spec.ControlPlaneEndpoint,
+		},
+		Variables: map[string]interface{}{},
+	}
+	if clusterNetwork := cluster.Spec.ClusterNetwork; clusterNetwork != nil {
+		data.Cluster.ClusterNetwork = clusterNetworkTemplateData{
+			APIServerPort: ptr.Deref(clusterNetwork.APIServerPort, 0),
+			Services:      ptr.Deref(clusterNetwork.Services, clusterv1.NetworkRanges{}),
+			Pods:          ptr.Deref(clusterNetwork.Pods, clusterv1.NetworkRanges{}),
+			ServiceDomain: clusterNetwork.ServiceDomain,
+		}
+	}
+	if cluster.Spec.Topology == nil {
+		return data, nil
+	}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		var value interface{}
+		if err := json.Unmarshal(variable.Value.Raw, &value); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal value of variable %q", variable.Name)
+		}
+		data.Variables[variable.Name] = value
+	}
+	return data, nil
+}
+
+// newTemplate returns a new template supporting the Sprig functions.
+// Note: Missing values are rendered as empty strings, consistently with Helm.
+func newTemplate(name string) *template.Template {
+	return template.New(name).Funcs(sprig.HermeticTxtFuncMap()).Option("missingkey=zero")
+}
+
+// executeTemplate executes a template with the given data.
+func executeTemplate(tpl *template.Template, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return []byte(strings.ReplaceAll(buf.String(), "<no value>", "")), nil
+}
+
+// renderTemplate parses and executes a template with the given data.
+func renderTemplate(name, text string, data interface{}) ([]byte, error) {
+	tpl, err := newTemplate(name).Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse template %s", name)
+	}
+	rendered, err := executeTemplate(tpl, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render template %s", name)
+	}
+	return rendered, nil
+}
+
+// resourceFiles returns the files in a resource, indexed by their path.
+// Each key of the resource is a file, except keys with the .tgz or .tar.gz suffix, which are extracted as tar archives.
+func resourceFiles(resource *unstructured.Unstructured) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	size := 0
+
+	addFile := func(key string, content []byte) error {
+		if !strings.HasSuffix(key, ".tgz") && !strings.HasSuffix(key, ".tar.gz") {
+			size += len(content)
+			if size > maxResourceFilesSize {
+				return errors.Errorf("files in resource %s exceed the maximum size of %d bytes", klog.KObj(resource), maxResourceFilesSize)
+			}
+			files[key] = content
+			return nil
+		}
+		if err := extractArchive(content, files, &size); err != nil {
+			return errors.Wrapf(err, "failed to extract archive %s from resource %s", key, klog.KObj(resource))
+		}
+		return nil
+	}
+
+	data, _, err := unstructured.NestedStringMap(resource.UnstructuredContent(), "data")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get data field from resource %s", klog.KObj(resource))
+	}
+	for key, val := range data {
+		content := []byte(val)
+		// If the resource is a Secret, data needs to be decoded.
+		if resource.GetKind() == string(addonsv1.SecretClusterResourceSetResourceKind) {
+			if content, err = base64.StdEncoding.DecodeString(val); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode value for field %s in data from resource %s", key, klog.KObj(resource))
+			}
+		}
+		if err := addFile(key, content); err != nil {
+			return nil, err
+		}
+	}
+
+	// ConfigMaps store binary data, e.g. archives, in binaryData.
+	binaryData, _, err := unstructured.NestedStringMap(resource.UnstructuredContent(), "binaryData")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get binaryData field from resource %s", klog.KObj(resource))
+	}
+	for key, val := range binaryData {
+		content, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode value for field %s in binaryData from resource %s", key, klog.KObj(resource))
+		}
+		if err := addFile(key, content); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.Errorf("no files found in resource %s", klog.KObj(resource))
+	}
+	return files, nil
+}
+
+// extractArchive extracts the regular files of a gzipped tar archive into files.
+func extractArchive(archive []byte, files map[string][]byte, size *int) error {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.Errorf("invalid file path %q", header.Name)
+		}
+
+		*size += int(header.Size)
+		if *size > maxResourceFilesSize {
+			return errors.Errorf("files exceed the maximum size of %d bytes", maxResourceFilesSize)
+		}
+		content, err := io.ReadAll(io.LimitReader(tarReader, header.Size))
+		if err != nil {
+			return err
+		}
+		files[name] = content
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestResourceFiles(t *testing.T) {
+	archive := newArchive(t, map[string]string{
+		"chart/Chart.yaml":           "name: chart",
+		"./chart/templates/cm.yaml":  "kind: ConfigMap",
+		"chart/templates/other.yaml": "kind: Secret",
+	})
+
+	tests := []struct {
+		name      string
+		resource  map[string]interface{}
+		wantFiles map[string]string
+		wantErr   string
+	}{
+		{
+			name: "ConfigMap with data and binaryData",
+			resource: map[string]interface{}{
+				"kind": "ConfigMap",
+				"data": map[string]interface{}{
+					"kustomization.yaml": "resources: []",
+				},
+				"binaryData": map[string]interface{}{
+					"chart.tgz": base64.StdEncoding.EncodeToString(archive),
+				},
+			},
+			wantFiles: map[string]string{
+				"kustomization.yaml":         "resources: []",
+				"chart/Chart.yaml":           "name: chart",
+				"chart/templates/cm.yaml":    "kind: ConfigMap",
+				"chart/templates/other.yaml": "kind: Secret",
+			},
+		},
+		{
+			name: "Secret with data",
+			resource: map[string]interface{}{
+				"kind": "Secret",
+				"data": map[string]interface{}{
+					"kustomization.yaml": base64.StdEncoding.EncodeToString([]byte("resources: []")),
+					"chart.tar.gz":       base64.StdEncoding.EncodeToString(archive),
+				},
+			},
+			wantFiles: map[string]string{
+				"kustomization.yaml":         "resources: []",
+				"chart/Chart.yaml":           "name: chart",
+				"chart/templates/cm.yaml":    "kind: ConfigMap",
+				"chart/templates/other.yaml": "kind: Secret",
+			},
+		},
+		{
+			name: "archive with a file outside of the archive root",
+			resource: map[string]interface{}{
+				"kind": "ConfigMap",
+				"binaryData": map[string]interface{}{
+					"chart.tgz": base64.StdEncoding.EncodeToString(newArchive(t, map[string]string{
+						"../escape.yaml": "kind: ConfigMap",
+					})),
+				},
+			},
+			wantErr: "invalid file path",
+		},
+		{
+			name: "invalid archive",
+			resource: map[string]interface{}{
+				"kind": "ConfigMap",
+				"binaryData": map[string]interface{}{
+					"chart.tgz": base64.StdEncoding.EncodeToString([]byte("not an archive")),
+				},
+			},
+			wantErr: "failed to extract archive chart.tgz",
+		},
+		{
+			name: "no files",
+			resource: map[string]interface{}{
+				"kind": "ConfigMap",
+			},
+			wantErr: "no files found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			files, err := resourceFiles(&unstructured.Unstructured{Object: tt.resource})
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotFiles := map[string]string{}
+			for name, content := range files {
+				gotFiles[name] = string(content)
+			}
+			g.Expect(gotFiles).To(Equal(tt.wantFiles))
+		})
+	}
+}
+
+func TestNewTemplateData(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"cni": "calico"},
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "mtu", Value: apiextensionsv1.JSON{Raw: []byte(`1450`)}},
+					{Name: "cni", Value: apiextensionsv1.JSON{Raw: []byte(`{"encapsulation":"VXLAN"}`)}},
+				},
+			},
+		},
+	}
+
+	data, err := newTemplateData(cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rendered, err := renderTemplate("test", "{{ .Cluster.Namespace }}/{{ .Cluster.Name }} {{ .Cluster.Labels.cni }} {{ .Variables.mtu }} {{ .Variables.cni.encapsulation }} {{ .Variables.missing }}", data)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(rendered)).To(Equal("default/cluster1 calico 1450 VXLAN "))
+}
+
+func TestRenderResource(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	t.Run("Raw resources are normalized", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resource := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind": "ConfigMap",
+			"data": map[string]interface{}{
+				"b": "kind: Secret",
+				"a": "kind: ConfigMap",
+			},
+		}}
+		data, err := renderResource(addonsv1.ResourceRef{Name: "raw", Kind: "ConfigMap"}, resource, cluster, helmRenderOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(Equal([][]byte{[]byte("kind: ConfigMap"), []byte("kind: Secret")}))
+	})
+
+	t.Run("Template resources are rendered for the cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resource := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind": "Secret",
+			"data": map[string]interface{}{
+				"b": base64.StdEncoding.EncodeToString([]byte("kind: Secret\nmetadata:\n  name: {{ .Cluster.Name }}-b")),
+				"a": base64.StdEncoding.EncodeToString([]byte("kind: ConfigMap\nmetadata:\n  name: {{ .Cluster.Name }}-a")),
+			},
+		}}
+		resourceRef := addonsv1.ResourceRef{Name: "template", Kind: "Secret", Format: string(addonsv1.TemplateClusterResourceSetResourceFormat)}
+		data, err := renderResource(resourceRef, resource, cluster, helmRenderOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(Equal([][]byte{
+			[]byte("kind: ConfigMap\nmetadata:\n  name: cluster1-a"),
+			[]byte("kind: Secret\nmetadata:\n  name: cluster1-b"),
+		}))
+	})
+
+	t.Run("Template resources fail to render", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resource := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind": "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "template",
+				"namespace": metav1.NamespaceDefault,
+			},
+			"data": map[string]interface{}{
+				"cm.yaml": "kind: ConfigMap\nmetadata:\n  name: {{ .Cluster.Name",
+			},
+		}}
+		resourceRef := addonsv1.ResourceRef{Name: "template", Kind: "ConfigMap", Format: string(addonsv1.TemplateClusterResourceSetResourceFormat)}
+		_, err := renderResource(resourceRef, resource, cluster, helmRenderOptions{})
+		g.Expect(err).To(MatchError(ContainSubstring("failed to render resource default/template: failed to parse template cm.yaml")))
+		g.Expect(isRenderError(err)).To(BeTrue())
+	})
+
+	t.Run("Raw resources with invalid data are not rendering failures", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resource := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind": "ConfigMap",
+		}}
+		_, err := renderResource(addonsv1.ResourceRef{Name: "raw", Kind: "ConfigMap"}, resource, cluster, helmRenderOptions{})
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(isRenderError(err)).To(BeFalse())
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := renderResource(addonsv1.ResourceRef{Name: "raw", Kind: "ConfigMap", Format: "Jsonnet"}, &unstructured.Unstructured{}, cluster, helmRenderOptions{})
+		g.Expect(err).To(MatchError(ContainSubstring("unsupported resource format")))
+		g.Expect(isRenderError(err)).To(BeFalse())
+	})
+}
+
+// newArchive returns a gzipped tar archive with the given files.
+func newArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
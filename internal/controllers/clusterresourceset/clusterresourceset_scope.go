@@ -18,6 +18,7 @@ package clusterresourceset
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
 // resourceReconcileScope contains the scope for a CRS's resource
@@ -40,6 +42,8 @@ type resourceReconcileScope interface {
 	// hash returns a computed hash of the defined objects in the resource. It is consistent
 	// between runs.
 	hash() string
+	// objs returns the objects defined by the resource.
+	objs() []unstructured.Unstructured
 }
 
 func reconcileScopeForResource(
@@ -47,8 +51,10 @@ func reconcileScopeForResource(
 	resourceRef addonsv1.ResourceRef,
 	resourceSetBinding *addonsv1.ResourceSetBinding,
 	resource *unstructured.Unstructured,
+	cluster *clusterv1.Cluster,
+	helmOptions helmRenderOptions,
 ) (resourceReconcileScope, error) {
-	normalizedData, err := normalizeData(resource)
+	normalizedData, err := renderResource(resourceRef, resource, cluster, helmOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +88,8 @@ func newResourceReconcileScope(
 		return &reconcileApplyOnceScope{base}, nil
 	case addonsv1.ClusterResourceSetStrategyReconcile:
 		return &reconcileStrategyScope{base}, nil
+	case addonsv1.ClusterResourceSetStrategyServerSideApply:
+		return &reconcileServerSideApplyScope{base}, nil
 	default:
 		return nil, errors.Errorf("unsupported or empty resource strategy: %q", clusterResourceSet.Spec.Strategy)
 	}
@@ -171,6 +179,35 @@ func (r *reconcileApplyOnceScope) applyObj(ctx context.Context, c client.Client,
 	return nil
 }
 
+type reconcileServerSideApplyScope struct {
+	baseResourceReconcileScope
+}
+
+func (r *reconcileServerSideApplyScope) needsApply() bool {
+	resourceBinding := r.resourceSetBinding.GetResource(r.resourceRef)
+
+	// Note: Resources are periodically re-applied to detect and correct drift in the cluster.
+	return resourceBinding == nil || !resourceBinding.Applied || resourceBinding.Hash != r.computedHash ||
+		resourceBinding.LastAppliedTime == nil || time.Since(resourceBinding.LastAppliedTime.Time) >= serverSideApplyResyncPeriod
+}
+
+func (r *reconcileServerSideApplyScope) apply(ctx context.Context, c client.Client) error {
+	return apply(ctx, c, r.applyObj, r.objs())
+}
+
+func (r *reconcileServerSideApplyScope) applyObj(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(clusterResourceSetManagerName), client.ForceOwnership); err != nil {
+		return errors.Wrapf(
+			err,
+			"applying object %s %s",
+			obj.GroupVersionKind(),
+			klog.KObj(obj),
+		)
+	}
+
+	return nil
+}
+
 type applyObj func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error
 
 // apply reconciles unstructured objects using applyObj and aggregates the error if present.
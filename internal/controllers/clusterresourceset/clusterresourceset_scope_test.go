@@ -19,6 +19,7 @@ package clusterresourceset
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -249,3 +250,88 @@ func TestReconcileApplyOnceScopeApplyObj(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileServerSideApplyScopeNeedsApply(t *testing.T) {
+	resourceRef := addonsv1.ResourceRef{
+		Name: "cp",
+		Kind: "ConfigMap",
+	}
+
+	tests := []struct {
+		name            string
+		resourceBinding *addonsv1.ResourceBinding
+		want            bool
+	}{
+		{
+			name: "no ResourceBinding",
+			want: true,
+		},
+		{
+			name: "not applied ResourceBinding",
+			resourceBinding: &addonsv1.ResourceBinding{
+				ResourceRef:     resourceRef,
+				Applied:         false,
+				Hash:            "111",
+				LastAppliedTime: &metav1.Time{Time: time.Now()},
+			},
+			want: true,
+		},
+		{
+			name: "applied ResourceBinding and different hash",
+			resourceBinding: &addonsv1.ResourceBinding{
+				ResourceRef:     resourceRef,
+				Applied:         true,
+				Hash:            "222",
+				LastAppliedTime: &metav1.Time{Time: time.Now()},
+			},
+			want: true,
+		},
+		{
+			name: "applied ResourceBinding and same hash, applied recently",
+			resourceBinding: &addonsv1.ResourceBinding{
+				ResourceRef:     resourceRef,
+				Applied:         true,
+				Hash:            "111",
+				LastAppliedTime: &metav1.Time{Time: time.Now().Add(-1 * time.Minute)},
+			},
+			want: false,
+		},
+		{
+			name: "applied ResourceBinding and same hash, resync period expired",
+			resourceBinding: &addonsv1.ResourceBinding{
+				ResourceRef:     resourceRef,
+				Applied:         true,
+				Hash:            "111",
+				LastAppliedTime: &metav1.Time{Time: time.Now().Add(-serverSideApplyResyncPeriod)},
+			},
+			want: true,
+		},
+		{
+			name: "applied ResourceBinding and same hash, without last applied time",
+			resourceBinding: &addonsv1.ResourceBinding{
+				ResourceRef: resourceRef,
+				Applied:     true,
+				Hash:        "111",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewWithT(t)
+
+			resourceSetBinding := &addonsv1.ResourceSetBinding{}
+			if tt.resourceBinding != nil {
+				resourceSetBinding.Resources = []addonsv1.ResourceBinding{*tt.resourceBinding}
+			}
+			scope := &reconcileServerSideApplyScope{
+				baseResourceReconcileScope: baseResourceReconcileScope{
+					resourceSetBinding: resourceSetBinding,
+					resourceRef:        resourceRef,
+					computedHash:       "111",
+				},
+			}
+			gs.Expect(scope.needsApply()).To(Equal(tt.want))
+		})
+	}
+}
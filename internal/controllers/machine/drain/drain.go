@@ -32,7 +32,9 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -61,6 +63,20 @@ type Helper struct {
 	// DeletionTimeStamp > N seconds. This can be used e.g. when a Node is unreachable
 	// and the Pods won't drain because of that.
 	SkipWaitForDeleteTimeoutSeconds int
+
+	// NodeDrainStartTime is the time when the drain of the Node started.
+	// NodeDrainStartTime is used to evaluate the drain timeouts defined in MachineDrainRules;
+	// if it is not set, drain timeouts are ignored.
+	NodeDrainStartTime *metav1.Time
+
+	// EvictionCoordinator is used to serialize evictions of Pods covered by the same PodDisruptionBudget across
+	// Machines of a Cluster which are drained concurrently.
+	// If it is not set, evictions are not coordinated.
+	EvictionCoordinator *EvictionCoordinator
+
+	// Cluster and Machine identify the Machine which is drained towards the EvictionCoordinator.
+	Cluster client.ObjectKey
+	Machine string
 }
 
 // CordonNode cordons a Node.
@@ -162,9 +178,55 @@ func (d *Helper) GetPodsForEviction(ctx context.Context, cluster *clusterv1.Clus
 		return nil, errors.Wrapf(kerrors.NewAggregate(errs), "failed to get Pods for eviction")
 	}
 
+	if d.EvictionCoordinator != nil {
+		if err := d.setPodDisruptionBudgets(ctx, list); err != nil {
+			return nil, errors.Wrapf(err, "failed to get Pods for eviction")
+		}
+	}
+
 	return list, nil
 }
 
+// setPodDisruptionBudgets sets the PodDisruptionBudgets covering the Pods which have to be evicted.
+func (d *Helper) setPodDisruptionBudgets(ctx context.Context, list *PodDeleteList) error {
+	// List all PodDisruptionBudgets.
+	// Note: PodDisruptionBudgets will be cached in the ClusterCache to avoid having to read them on every Reconcile.
+	// Note: Because we are using the cache we don't have to use pagination.
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	if err := d.RemoteClient.List(ctx, pdbList); err != nil {
+		return errors.Wrapf(err, "failed to list PodDisruptionBudgets")
+	}
+
+	pdbSelectors := map[string]labels.Selector{}
+	pdbsByNamespace := map[string][]string{}
+	for _, pdb := range pdbList.Items {
+		// Note: A PodDisruptionBudget with a nil selector does not cover any Pods.
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		key := klog.KObj(&pdb).String()
+		pdbSelectors[key] = selector
+		pdbsByNamespace[pdb.Namespace] = append(pdbsByNamespace[pdb.Namespace], key)
+	}
+
+	for i, pd := range list.items {
+		if pd.Status.DrainBehavior != clusterv1.MachineDrainRuleDrainBehaviorDrain {
+			continue
+		}
+		for _, pdb := range pdbsByNamespace[pd.Pod.Namespace] {
+			if pdbSelectors[pdb].Matches(labels.Set(pd.Pod.Labels)) {
+				list.items[i].Status.PodDisruptionBudgets = append(list.items[i].Status.PodDisruptionBudgets, pdb)
+			}
+		}
+		sort.Strings(list.items[i].Status.PodDisruptionBudgets)
+	}
+	return nil
+}
+
 func (d *Helper) getMatchingMachineDrainRules(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) ([]*clusterv1.MachineDrainRule, error) {
 	// List all MachineDrainRules.
 	machineDrainRuleList := &clusterv1.MachineDrainRuleList{}
@@ -273,6 +335,18 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 			fmt.Sprintf("%s/%s", podDeleteList.items[j].Pod.GetNamespace(), podDeleteList.items[j].Pod.GetName())
 	})
 
+	// Skip Pods for which the drain timeout is reached and the MachineDrainRule defines to skip them on timeout.
+	// Note: This is done before computing the minimum order, so skipped Pods don't block Pods with a higher order.
+	for i, pd := range podDeleteList.items {
+		if !d.drainTimeoutReached(pd) || pd.Status.DrainTimeout.OnTimeout != clusterv1.MachineDrainRuleOnTimeoutActionSkip ||
+			(pd.Status.DrainBehavior != clusterv1.MachineDrainRuleDrainBehaviorDrain && pd.Status.DrainBehavior != clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted) {
+			continue
+		}
+		podDeleteList.items[i].Status.DrainBehavior = clusterv1.MachineDrainRuleDrainBehaviorSkip
+		podDeleteList.items[i].Status.Reason = PodDeleteStatusTypeWarning
+		podDeleteList.items[i].Status.Message = fmt.Sprintf("drain timeout of MachineDrainRule %s reached", pd.Status.DrainTimeout.MachineDrainRule)
+	}
+
 	// Get the minimum order of all existing Pods.
 	// Note: We are only going to evict or wait for termination of Pods with the minimum order.
 	// This could also mean that we don't evict any additional Pods in this call, if there are still Pods with
@@ -285,8 +359,13 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 	var podsToBeIgnored []PodDelete
 	var podsToWaitCompletedNow []PodDelete
 	var podsToWaitCompletedLater []PodDelete
+	var podsToForceDelete []PodDelete
 	for _, pod := range podDeleteList.items {
 		switch {
+		case (pod.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorDrain || pod.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted) &&
+			ptr.Deref(pod.Status.DrainOrder, 0) == minDrainOrder &&
+			d.drainTimeoutReached(pod) && pod.Status.DrainTimeout.OnTimeout == clusterv1.MachineDrainRuleOnTimeoutActionForceDelete:
+			podsToForceDelete = append(podsToForceDelete, pod)
 		case pod.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorDrain && pod.Pod.DeletionTimestamp.IsZero():
 			if ptr.Deref(pod.Status.DrainOrder, 0) == minDrainOrder {
 				podsToTriggerEvictionNow = append(podsToTriggerEvictionNow, pod)
@@ -306,12 +385,20 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 		}
 	}
 
+	// Only evict Pods if no other Machine of the Cluster is currently evicting Pods covered by the same PodDisruptionBudgets.
+	var podsWaitingForPodDisruptionBudget []PodDisruptionBudgetWait
+	if d.EvictionCoordinator != nil {
+		podsToTriggerEvictionNow, podsWaitingForPodDisruptionBudget = d.coordinateEvictions(podsToTriggerEvictionNow, podsWithDeletionTimestamp)
+	}
+
 	log.Info("Drain not completed yet, there are still Pods on the Node that have to be drained",
 		"podsToTriggerEvictionNow", podDeleteListToString(podsToTriggerEvictionNow, 5),
 		"podsToTriggerEvictionLater", podDeleteListToString(podsToTriggerEvictionLater, 5),
 		"podsWithDeletionTimestamp", podDeleteListToString(podsWithDeletionTimestamp, 5),
 		"podsToWaitCompletedNow", podDeleteListToString(podsToWaitCompletedNow, 5),
 		"podsToWaitCompletedLater", podDeleteListToString(podsToWaitCompletedLater, 5),
+		"podsToForceDelete", podDeleteListToString(podsToForceDelete, 5),
+		"podsWaitingForPodDisruptionBudget", podDisruptionBudgetWaitsToString(podsWaitingForPodDisruptionBudget, 5),
 	)
 
 	// Trigger evictions for at most 10s. We'll continue on the next reconcile if we hit the timeout.
@@ -320,7 +407,8 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 	defer cancel()
 
 	res := EvictionResult{
-		PodsFailedEviction: map[string][]*corev1.Pod{},
+		PodsFailedEviction:                map[string][]*corev1.Pod{},
+		PodsWaitingForPodDisruptionBudget: podsWaitingForPodDisruptionBudget,
 	}
 
 	for _, pd := range podsToBeIgnored {
@@ -402,6 +490,25 @@ evictionLoop:
 		}
 	}
 
+	for _, pd := range podsToForceDelete {
+		log := ctrl.LoggerFrom(ctx, "Pod", klog.KObj(pd.Pod))
+
+		log.V(4).Info(fmt.Sprintf("Force deleting Pod, because the drain timeout of MachineDrainRule %s is reached", pd.Status.DrainTimeout.MachineDrainRule))
+		err := d.RemoteClient.Delete(ctx, pd.Pod, client.GracePeriodSeconds(0))
+		switch {
+		case err == nil:
+			log.V(4).Info("Pod force deletion successfully triggered")
+			res.PodsDeletionTimestampSet = append(res.PodsDeletionTimestampSet, pd.Pod)
+		case apierrors.IsNotFound(err):
+			log.V(4).Info("Force deletion not needed, Pod doesn't exist anymore")
+			res.PodsNotFound = append(res.PodsNotFound, pd.Pod)
+		default:
+			log.V(4).Info("Error when force deleting Pod", "err", err)
+			msg := fmt.Sprintf("force deletion failed: %v", err)
+			res.PodsFailedEviction[msg] = append(res.PodsFailedEviction[msg], pd.Pod)
+		}
+	}
+
 	for _, pd := range podsToTriggerEvictionLater {
 		res.PodsToTriggerEvictionLater = append(res.PodsToTriggerEvictionLater, pd.Pod)
 	}
@@ -412,6 +519,89 @@ evictionLoop:
 		res.PodsToWaitCompletedLater = append(res.PodsToWaitCompletedLater, pd.Pod)
 	}
 
+	res.MachineDrainRules = d.machineDrainRulesProgress(podDeleteList.items, res.PodsNotFound)
+
+	return res
+}
+
+// coordinateEvictions acquires the PodDisruptionBudgets covering the Pods of the Machine via the EvictionCoordinator.
+// It returns the Pods which can be evicted now and the Pods which have to wait because another Machine is evicting
+// Pods covered by the same PodDisruptionBudget. PodDisruptionBudgets which are not required anymore are released.
+func (d *Helper) coordinateEvictions(podsToTriggerEvictionNow, podsWithDeletionTimestamp []PodDelete) ([]PodDelete, []PodDisruptionBudgetWait) {
+	acquired := sets.Set[string]{}
+
+	// Pods with deletionTimestamp are still consuming the disruptions allowed by their PodDisruptionBudgets,
+	// so the Machine keeps the PodDisruptionBudgets until the Pods are removed from the Node.
+	for _, pd := range podsWithDeletionTimestamp {
+		if _, _, ok := d.EvictionCoordinator.TryAcquire(d.Cluster, d.Machine, pd.Status.PodDisruptionBudgets); ok {
+			acquired.Insert(pd.Status.PodDisruptionBudgets...)
+		}
+	}
+
+	var podsToEvict []PodDelete
+	waitsByPodDisruptionBudget := map[string]*PodDisruptionBudgetWait{}
+	for _, pd := range podsToTriggerEvictionNow {
+		pdb, holder, ok := d.EvictionCoordinator.TryAcquire(d.Cluster, d.Machine, pd.Status.PodDisruptionBudgets)
+		if ok {
+			acquired.Insert(pd.Status.PodDisruptionBudgets...)
+			podsToEvict = append(podsToEvict, pd)
+			continue
+		}
+		wait, ok := waitsByPodDisruptionBudget[pdb]
+		if !ok {
+			wait = &PodDisruptionBudgetWait{
+				PodDisruptionBudget: pdb,
+				Machine:             holder,
+			}
+			waitsByPodDisruptionBudget[pdb] = wait
+		}
+		wait.Pods = append(wait.Pods, pd.Pod)
+	}
+
+	d.EvictionCoordinator.Release(d.Cluster, d.Machine, acquired)
+
+	var waits []PodDisruptionBudgetWait
+	for _, pdb := range slices.Sorted(maps.Keys(waitsByPodDisruptionBudget)) {
+		waits = append(waits, *waitsByPodDisruptionBudget[pdb])
+	}
+	return podsToEvict, waits
+}
+
+// drainTimeoutReached returns true if the Pod has a drain timeout and the timeout is reached.
+func (d *Helper) drainTimeoutReached(pd PodDelete) bool {
+	if pd.Status.DrainTimeout == nil || d.NodeDrainStartTime == nil {
+		return false
+	}
+	return time.Since(d.NodeDrainStartTime.Time) >= pd.Status.DrainTimeout.Timeout
+}
+
+// machineDrainRulesProgress computes the drain progress of the Pods for each MachineDrainRule defining a drain timeout.
+func (d *Helper) machineDrainRulesProgress(pds []PodDelete, podsNotFound []*corev1.Pod) []MachineDrainRuleProgress {
+	progressByRule := map[string]*MachineDrainRuleProgress{}
+	for _, pd := range pds {
+		if pd.Status.DrainTimeout == nil || slices.Contains(podsNotFound, pd.Pod) {
+			continue
+		}
+		progress, ok := progressByRule[pd.Status.DrainTimeout.MachineDrainRule]
+		if !ok {
+			progress = &MachineDrainRuleProgress{
+				Name:           pd.Status.DrainTimeout.MachineDrainRule,
+				Timeout:        pd.Status.DrainTimeout.Timeout,
+				OnTimeout:      pd.Status.DrainTimeout.OnTimeout,
+				TimeoutReached: d.drainTimeoutReached(pd),
+			}
+			progressByRule[progress.Name] = progress
+		}
+		progress.Pods = append(progress.Pods, pd.Pod)
+	}
+	if len(progressByRule) == 0 {
+		return nil
+	}
+
+	res := []MachineDrainRuleProgress{}
+	for _, name := range slices.Sorted(maps.Keys(progressByRule)) {
+		res = append(res, *progressByRule[name])
+	}
 	return res
 }
 
@@ -458,13 +648,53 @@ type EvictionResult struct {
 	PodsToWaitCompletedLater   []*corev1.Pod
 	PodsNotFound               []*corev1.Pod
 	PodsIgnored                []*corev1.Pod
+
+	// PodsWaitingForPodDisruptionBudget reports the Pods which are not evicted yet because other Machines
+	// of the Cluster are evicting Pods covered by the same PodDisruptionBudgets.
+	PodsWaitingForPodDisruptionBudget []PodDisruptionBudgetWait
+
+	// MachineDrainRules reports the drain progress for each MachineDrainRule defining a drain timeout
+	// which applies to Pods that are still on the Node.
+	MachineDrainRules []MachineDrainRuleProgress
+}
+
+// PodDisruptionBudgetWait reports the Pods which are waiting to be evicted because another Machine is evicting
+// Pods covered by the same PodDisruptionBudget.
+type PodDisruptionBudgetWait struct {
+	// PodDisruptionBudget is the PodDisruptionBudget the Pods are waiting for, in the format <namespace>/<name>.
+	PodDisruptionBudget string
+
+	// Machine is the name of the Machine which is currently evicting Pods covered by the PodDisruptionBudget.
+	Machine string
+
+	// Pods are the Pods which are waiting to be evicted.
+	Pods []*corev1.Pod
+}
+
+// MachineDrainRuleProgress reports the drain progress of the Pods to which a MachineDrainRule defining
+// a drain timeout applies.
+type MachineDrainRuleProgress struct {
+	// Name is the name of the MachineDrainRule.
+	Name string
+
+	// Pods are the Pods to which the MachineDrainRule applies which are still on the Node.
+	Pods []*corev1.Pod
+
+	// Timeout is the drain timeout defined by the MachineDrainRule.
+	Timeout time.Duration
+
+	// OnTimeout is the action taken when the timeout is reached.
+	OnTimeout clusterv1.MachineDrainRuleOnTimeoutAction
+
+	// TimeoutReached is true if the drain timeout is reached.
+	TimeoutReached bool
 }
 
 // DrainCompleted returns if a Node is entirely drained, i.e. if all relevant Pods have gone away.
 func (r EvictionResult) DrainCompleted() bool {
 	return len(r.PodsDeletionTimestampSet) == 0 && len(r.PodsFailedEviction) == 0 &&
 		len(r.PodsToTriggerEvictionLater) == 0 && len(r.PodsToWaitCompletedLater) == 0 &&
-		len(r.PodsToWaitCompletedNow) == 0
+		len(r.PodsToWaitCompletedNow) == 0 && len(r.PodsWaitingForPodDisruptionBudget) == 0
 }
 
 // ConditionMessage returns a condition message for the case where a drain is not completed.
@@ -520,6 +750,16 @@ func (r EvictionResult) ConditionMessage(nodeDrainStartTime *metav1.Time) string
 			}
 		}
 	}
+	for _, wait := range r.PodsWaitingForPodDisruptionBudget {
+		kind := "Pod"
+		if len(wait.Pods) > 1 {
+			kind = "Pods"
+		}
+		// Note: the code computing stale warning for the machine deleting condition is making assumptions on the format/content of this message.
+		// Same applies for other conditions where deleting is involved, e.g. MachineSet's Deleting and ScalingDown condition.
+		conditionMessage = fmt.Sprintf("%s\n* %s %s: waiting for Machine %s to complete evictions of Pods covered by PodDisruptionBudget %s",
+			conditionMessage, kind, PodListToString(wait.Pods, 3), wait.Machine, wait.PodDisruptionBudget)
+	}
 	if len(r.PodsToWaitCompletedNow) > 0 {
 		kind := "Pod"
 		if len(r.PodsToWaitCompletedNow) > 1 {
@@ -538,6 +778,27 @@ func (r EvictionResult) ConditionMessage(nodeDrainStartTime *metav1.Time) string
 		conditionMessage = fmt.Sprintf("%s\nAfter above Pods have been removed from the Node, waiting for the following Pods to complete without eviction: %s",
 			conditionMessage, PodListToString(r.PodsToWaitCompletedLater, 3))
 	}
+	for _, mdr := range r.MachineDrainRules {
+		kind := "Pod"
+		if len(mdr.Pods) > 1 {
+			kind = "Pods"
+		}
+		timeoutTime := nodeDrainStartTime.Add(mdr.Timeout).Format(time.RFC3339)
+		switch {
+		case !mdr.TimeoutReached:
+			conditionMessage = fmt.Sprintf("%s\n* MachineDrainRule %s: waiting for %s %s to be removed from the Node, timeout at %s (onTimeout: %s)",
+				conditionMessage, mdr.Name, kind, PodListToString(mdr.Pods, 3), timeoutTime, mdr.OnTimeout)
+		case mdr.OnTimeout == clusterv1.MachineDrainRuleOnTimeoutActionSkip:
+			conditionMessage = fmt.Sprintf("%s\n* MachineDrainRule %s: timeout reached at %s, skipped %s %s",
+				conditionMessage, mdr.Name, timeoutTime, kind, PodListToString(mdr.Pods, 3))
+		case mdr.OnTimeout == clusterv1.MachineDrainRuleOnTimeoutActionForceDelete:
+			conditionMessage = fmt.Sprintf("%s\n* MachineDrainRule %s: timeout reached at %s, force deleting %s %s",
+				conditionMessage, mdr.Name, timeoutTime, kind, PodListToString(mdr.Pods, 3))
+		default:
+			conditionMessage = fmt.Sprintf("%s\n* MachineDrainRule %s: timeout reached at %s, still waiting for %s %s to be removed from the Node",
+				conditionMessage, mdr.Name, timeoutTime, kind, PodListToString(mdr.Pods, 3))
+		}
+	}
 	return conditionMessage
 }
 
@@ -548,6 +809,13 @@ func podDeleteListToString(podList []PodDelete, n int) string {
 	}, n)
 }
 
+// podDisruptionBudgetWaitsToString returns a comma-separated list of the first n entries of the PodDisruptionBudgetWait list.
+func podDisruptionBudgetWaitsToString(waits []PodDisruptionBudgetWait, n int) string {
+	return clog.ListToString(waits, func(wait PodDisruptionBudgetWait) string {
+		return fmt.Sprintf("%s (Machine %s)", wait.PodDisruptionBudget, wait.Machine)
+	}, n)
+}
+
 // PodListToString returns a comma-separated list of the first n entries of the Pod list.
 func PodListToString(podList []*corev1.Pod, n int) string {
 	return clog.ListToString(podList, func(p *corev1.Pod) string {
@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -1719,6 +1720,362 @@ func TestEvictPods(t *testing.T) {
 	}
 }
 
+func TestEvictPodsWithDrainTimeouts(t *testing.T) {
+	drainTimeout := func(name string, timeout time.Duration, onTimeout clusterv1.MachineDrainRuleOnTimeoutAction) *DrainTimeout {
+		return &DrainTimeout{MachineDrainRule: name, Timeout: timeout, OnTimeout: onTimeout}
+	}
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		podDeleteList      *PodDeleteList
+		wantEvictionResult EvictionResult
+		wantForceDeleted   []string
+	}{
+		{
+			name: "Timeout not reached",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: pod("pod-1-pdb-violated"),
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout:  drainTimeout("mdr", time.Hour, clusterv1.MachineDrainRuleOnTimeoutActionForceDelete),
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			wantEvictionResult: EvictionResult{
+				PodsFailedEviction: map[string][]*corev1.Pod{
+					"Cannot evict pod as it would violate the pod's disruption budget.": {pod("pod-1-pdb-violated")},
+				},
+				MachineDrainRules: []MachineDrainRuleProgress{
+					{
+						Name:      "mdr",
+						Pods:      []*corev1.Pod{pod("pod-1-pdb-violated")},
+						Timeout:   time.Hour,
+						OnTimeout: clusterv1.MachineDrainRuleOnTimeoutActionForceDelete,
+					},
+				},
+			},
+		},
+		{
+			name: "Timeout reached with onTimeout Block",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: pod("pod-1-pdb-violated"),
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout:  drainTimeout("mdr", time.Minute, clusterv1.MachineDrainRuleOnTimeoutActionBlock),
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			wantEvictionResult: EvictionResult{
+				PodsFailedEviction: map[string][]*corev1.Pod{
+					"Cannot evict pod as it would violate the pod's disruption budget.": {pod("pod-1-pdb-violated")},
+				},
+				MachineDrainRules: []MachineDrainRuleProgress{
+					{
+						Name:           "mdr",
+						Pods:           []*corev1.Pod{pod("pod-1-pdb-violated")},
+						Timeout:        time.Minute,
+						OnTimeout:      clusterv1.MachineDrainRuleOnTimeoutActionBlock,
+						TimeoutReached: true,
+					},
+				},
+			},
+		},
+		{
+			name: "Timeout reached with onTimeout Skip, Pods with higher order are evicted",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: pod("pod-1-wait-completed"),
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout:  drainTimeout("mdr", time.Minute, clusterv1.MachineDrainRuleOnTimeoutActionSkip),
+						Reason:        PodDeleteStatusTypeWaitCompleted,
+					},
+				},
+				{
+					Pod: pod("pod-2-to-trigger-eviction-successfully"),
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](10),
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			wantEvictionResult: EvictionResult{
+				PodsFailedEviction:       map[string][]*corev1.Pod{},
+				PodsIgnored:              []*corev1.Pod{pod("pod-1-wait-completed")},
+				PodsDeletionTimestampSet: []*corev1.Pod{pod("pod-2-to-trigger-eviction-successfully")},
+				MachineDrainRules: []MachineDrainRuleProgress{
+					{
+						Name:           "mdr",
+						Pods:           []*corev1.Pod{pod("pod-1-wait-completed")},
+						Timeout:        time.Minute,
+						OnTimeout:      clusterv1.MachineDrainRuleOnTimeoutActionSkip,
+						TimeoutReached: true,
+					},
+				},
+			},
+		},
+		{
+			name: "Timeout reached with onTimeout ForceDelete, only Pods with the minimum order are force deleted",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: pod("pod-1-pdb-violated"),
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout:  drainTimeout("mdr-a", time.Minute, clusterv1.MachineDrainRuleOnTimeoutActionForceDelete),
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: pod("pod-2-higher-order"),
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](10),
+						DrainTimeout:  drainTimeout("mdr-b", time.Minute, clusterv1.MachineDrainRuleOnTimeoutActionForceDelete),
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			wantEvictionResult: EvictionResult{
+				PodsFailedEviction:         map[string][]*corev1.Pod{},
+				PodsDeletionTimestampSet:   []*corev1.Pod{pod("pod-1-pdb-violated")},
+				PodsToTriggerEvictionLater: []*corev1.Pod{pod("pod-2-higher-order")},
+				MachineDrainRules: []MachineDrainRuleProgress{
+					{
+						Name:           "mdr-a",
+						Pods:           []*corev1.Pod{pod("pod-1-pdb-violated")},
+						Timeout:        time.Minute,
+						OnTimeout:      clusterv1.MachineDrainRuleOnTimeoutActionForceDelete,
+						TimeoutReached: true,
+					},
+					{
+						Name:           "mdr-b",
+						Pods:           []*corev1.Pod{pod("pod-2-higher-order")},
+						Timeout:        time.Minute,
+						OnTimeout:      clusterv1.MachineDrainRuleOnTimeoutActionForceDelete,
+						TimeoutReached: true,
+					},
+				},
+			},
+			wantForceDeleted: []string{"pod-1-pdb-violated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			forceDeleted := []string{}
+			fakeClient := interceptor.NewClient(fake.NewClientBuilder().Build(), interceptor.Funcs{
+				SubResourceCreate: func(_ context.Context, _ client.Client, subResourceName string, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+					g.Expect(subResourceName).To(Equal("eviction"))
+					if obj.GetName() == "pod-2-to-trigger-eviction-successfully" {
+						return nil
+					}
+					return &apierrors.StatusError{
+						ErrStatus: metav1.Status{
+							Status:  metav1.StatusFailure,
+							Code:    http.StatusTooManyRequests,
+							Reason:  metav1.StatusReasonTooManyRequests,
+							Message: "Cannot evict pod as it would violate the pod's disruption budget.",
+						},
+					}
+				},
+				Delete: func(_ context.Context, _ client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					deleteOpts := &client.DeleteOptions{}
+					deleteOpts.ApplyOptions(opts)
+					g.Expect(deleteOpts.GracePeriodSeconds).To(Equal(ptr.To[int64](0)))
+					forceDeleted = append(forceDeleted, obj.GetName())
+					return nil
+				},
+			})
+
+			drainer := &Helper{
+				RemoteClient:       fakeClient,
+				NodeDrainStartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			}
+
+			gotEvictionResult := drainer.EvictPods(context.Background(), tt.podDeleteList)
+			g.Expect(gotEvictionResult).To(BeComparableTo(tt.wantEvictionResult))
+			g.Expect(forceDeleted).To(ConsistOf(tt.wantForceDeleted))
+		})
+	}
+}
+
+func TestEvictPodsWithEvictionCoordinator(t *testing.T) {
+	g := NewWithT(t)
+
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+			},
+		}
+	}
+	podWithDeletionTimestamp := func(name string) *corev1.Pod {
+		p := pod(name)
+		p.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		return p
+	}
+	podDelete := func(p *corev1.Pod, pdbs ...string) PodDelete {
+		return PodDelete{
+			Pod: p,
+			Status: PodDeleteStatus{
+				DrainBehavior:        clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				Reason:               PodDeleteStatusTypeOkay,
+				PodDisruptionBudgets: pdbs,
+			},
+		}
+	}
+
+	evicted := []string{}
+	fakeClient := interceptor.NewClient(fake.NewClientBuilder().Build(), interceptor.Funcs{
+		SubResourceCreate: func(_ context.Context, _ client.Client, subResourceName string, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+			g.Expect(subResourceName).To(Equal("eviction"))
+			evicted = append(evicted, obj.GetName())
+			return nil
+		},
+	})
+
+	cluster := client.ObjectKey{Namespace: "default", Name: "cluster-1"}
+	coordinator := NewEvictionCoordinator(time.Minute)
+	drainer1 := &Helper{
+		RemoteClient:        fakeClient,
+		EvictionCoordinator: coordinator,
+		Cluster:             cluster,
+		Machine:             "machine-1",
+	}
+	drainer2 := &Helper{
+		RemoteClient:        fakeClient,
+		EvictionCoordinator: coordinator,
+		Cluster:             cluster,
+		Machine:             "machine-2",
+	}
+
+	// machine-1 evicts its Pod covered by pdb-a.
+	gotEvictionResult := drainer1.EvictPods(context.Background(), &PodDeleteList{items: []PodDelete{
+		podDelete(pod("pod-1-pdb-a"), "test-namespace/pdb-a"),
+	}})
+	g.Expect(gotEvictionResult).To(BeComparableTo(EvictionResult{
+		PodsFailedEviction:       map[string][]*corev1.Pod{},
+		PodsDeletionTimestampSet: []*corev1.Pod{pod("pod-1-pdb-a")},
+	}))
+	g.Expect(evicted).To(ConsistOf("pod-1-pdb-a"))
+
+	// machine-2 has to wait for machine-1 before evicting its Pod covered by pdb-a,
+	// Pods which are not covered by pdb-a are evicted.
+	evicted = []string{}
+	gotEvictionResult = drainer2.EvictPods(context.Background(), &PodDeleteList{items: []PodDelete{
+		podDelete(pod("pod-2-pdb-a"), "test-namespace/pdb-a"),
+		podDelete(pod("pod-3-pdb-b"), "test-namespace/pdb-b"),
+		podDelete(pod("pod-4-no-pdb")),
+	}})
+	g.Expect(gotEvictionResult).To(BeComparableTo(EvictionResult{
+		PodsFailedEviction:       map[string][]*corev1.Pod{},
+		PodsDeletionTimestampSet: []*corev1.Pod{pod("pod-3-pdb-b"), pod("pod-4-no-pdb")},
+		PodsWaitingForPodDisruptionBudget: []PodDisruptionBudgetWait{
+			{
+				PodDisruptionBudget: "test-namespace/pdb-a",
+				Machine:             "machine-1",
+				Pods:                []*corev1.Pod{pod("pod-2-pdb-a")},
+			},
+		},
+	}))
+	g.Expect(evicted).To(ConsistOf("pod-3-pdb-b", "pod-4-no-pdb"))
+
+	// machine-1 keeps pdb-a while its Pod is terminating.
+	evicted = []string{}
+	drainer1.EvictPods(context.Background(), &PodDeleteList{items: []PodDelete{
+		podDelete(podWithDeletionTimestamp("pod-1-pdb-a"), "test-namespace/pdb-a"),
+	}})
+	gotEvictionResult = drainer2.EvictPods(context.Background(), &PodDeleteList{items: []PodDelete{
+		podDelete(pod("pod-2-pdb-a"), "test-namespace/pdb-a"),
+	}})
+	g.Expect(gotEvictionResult.PodsWaitingForPodDisruptionBudget).To(HaveLen(1))
+	g.Expect(evicted).To(BeEmpty())
+
+	// After the Pod of machine-1 has been removed from the Node, machine-2 can evict its Pod covered by pdb-a.
+	drainer1.EvictPods(context.Background(), &PodDeleteList{})
+	gotEvictionResult = drainer2.EvictPods(context.Background(), &PodDeleteList{items: []PodDelete{
+		podDelete(pod("pod-2-pdb-a"), "test-namespace/pdb-a"),
+	}})
+	g.Expect(gotEvictionResult).To(BeComparableTo(EvictionResult{
+		PodsFailedEviction:       map[string][]*corev1.Pod{},
+		PodsDeletionTimestampSet: []*corev1.Pod{pod("pod-2-pdb-a")},
+	}))
+	g.Expect(evicted).To(ConsistOf("pod-2-pdb-a"))
+}
+
+func Test_setPodDisruptionBudgets(t *testing.T) {
+	g := NewWithT(t)
+
+	pdb := func(namespace, name string, selector *metav1.LabelSelector) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: selector,
+			},
+		}
+	}
+	podDelete := func(namespace, name string, podLabels map[string]string, drainBehavior clusterv1.MachineDrainRuleDrainBehavior) PodDelete {
+		return PodDelete{
+			Pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    podLabels,
+				},
+			},
+			Status: PodDeleteStatus{
+				DrainBehavior: drainBehavior,
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		pdb("test-namespace", "pdb-app-a", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}),
+		pdb("test-namespace", "pdb-tier-frontend", &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}),
+		pdb("test-namespace", "pdb-no-selector", nil),
+		pdb("other-namespace", "pdb-app-a", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}),
+	).Build()
+
+	list := &PodDeleteList{items: []PodDelete{
+		podDelete("test-namespace", "pod-1-app-a-frontend", map[string]string{"app": "a", "tier": "frontend"}, clusterv1.MachineDrainRuleDrainBehaviorDrain),
+		podDelete("test-namespace", "pod-2-app-b", map[string]string{"app": "b"}, clusterv1.MachineDrainRuleDrainBehaviorDrain),
+		podDelete("test-namespace", "pod-3-app-a-skipped", map[string]string{"app": "a"}, clusterv1.MachineDrainRuleDrainBehaviorSkip),
+		podDelete("other-namespace", "pod-4-app-a", map[string]string{"app": "a"}, clusterv1.MachineDrainRuleDrainBehaviorDrain),
+	}}
+
+	drainer := &Helper{
+		RemoteClient: fakeClient,
+	}
+	g.Expect(drainer.setPodDisruptionBudgets(context.Background(), list)).To(Succeed())
+
+	g.Expect(list.items[0].Status.PodDisruptionBudgets).To(Equal([]string{"test-namespace/pdb-app-a", "test-namespace/pdb-tier-frontend"}))
+	g.Expect(list.items[1].Status.PodDisruptionBudgets).To(BeEmpty())
+	g.Expect(list.items[2].Status.PodDisruptionBudgets).To(BeEmpty())
+	g.Expect(list.items[3].Status.PodDisruptionBudgets).To(Equal([]string{"other-namespace/pdb-app-a"}))
+}
+
 func TestEvictionResult_ConditionMessage(t *testing.T) {
 	g := NewWithT(t)
 
@@ -2032,6 +2389,109 @@ After above Pods have been removed from the Node, waiting for the following Pods
 * Pod pod-5-to-trigger-eviction-some-other-error: failed to evict Pod, some other error 5
 * 4 Pods with other issues`,
 		},
+		{
+			name: "Compute condition message with MachineDrainRules progress correctly",
+			evictionResult: EvictionResult{
+				PodsDeletionTimestampSet: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-database",
+						},
+					},
+				},
+				PodsToWaitCompletedNow: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-job",
+						},
+					},
+				},
+				MachineDrainRules: []MachineDrainRuleProgress{
+					{
+						Name: "mdr-database",
+						Pods: []*corev1.Pod{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "pod-1-database",
+								},
+							},
+						},
+						Timeout:        5 * time.Minute,
+						OnTimeout:      clusterv1.MachineDrainRuleOnTimeoutActionForceDelete,
+						TimeoutReached: true,
+					},
+					{
+						Name: "mdr-job",
+						Pods: []*corev1.Pod{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "pod-2-job",
+								},
+							},
+						},
+						Timeout:   time.Hour,
+						OnTimeout: clusterv1.MachineDrainRuleOnTimeoutActionSkip,
+					},
+					{
+						Name: "mdr-stateful",
+						Pods: []*corev1.Pod{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "pod-3-stateful",
+								},
+							},
+						},
+						Timeout:        time.Minute,
+						OnTimeout:      clusterv1.MachineDrainRuleOnTimeoutActionBlock,
+						TimeoutReached: true,
+					},
+				},
+			},
+			wantConditionMessage: `Drain not completed yet (started at 2024-10-09T16:13:59Z):
+* Pod pod-1-database: deletionTimestamp set, but still not removed from the Node
+* Pod pod-2-job: waiting for completion
+* MachineDrainRule mdr-database: timeout reached at 2024-10-09T16:18:59Z, force deleting Pod pod-1-database
+* MachineDrainRule mdr-job: waiting for Pod pod-2-job to be removed from the Node, timeout at 2024-10-09T17:13:59Z (onTimeout: Skip)
+* MachineDrainRule mdr-stateful: timeout reached at 2024-10-09T16:14:59Z, still waiting for Pod pod-3-stateful to be removed from the Node`,
+		},
+		{
+			name: "Compute condition message with Pods waiting for PodDisruptionBudgets correctly",
+			evictionResult: EvictionResult{
+				PodsFailedEviction: map[string][]*corev1.Pod{},
+				PodsWaitingForPodDisruptionBudget: []PodDisruptionBudgetWait{
+					{
+						PodDisruptionBudget: "test-namespace/pdb-a",
+						Machine:             "machine-1",
+						Pods: []*corev1.Pod{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "pod-1-pdb-a",
+								},
+							},
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "pod-2-pdb-a",
+								},
+							},
+						},
+					},
+					{
+						PodDisruptionBudget: "test-namespace/pdb-b",
+						Machine:             "machine-2",
+						Pods: []*corev1.Pod{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "pod-3-pdb-b",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantConditionMessage: `Drain not completed yet (started at 2024-10-09T16:13:59Z):
+* Pods pod-1-pdb-a, pod-2-pdb-a: waiting for Machine machine-1 to complete evictions of Pods covered by PodDisruptionBudget test-namespace/pdb-a
+* Pod pod-3-pdb-b: waiting for Machine machine-2 to complete evictions of Pods covered by PodDisruptionBudget test-namespace/pdb-b`,
+		},
 	}
 
 	nodeDrainStartTime, err := time.Parse(time.RFC3339, "2024-10-09T16:13:59Z")
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EvictionCoordinator coordinates evictions of Pods covered by PodDisruptionBudgets across Machines of a Cluster
+// which are drained concurrently, e.g. when a MachineDeployment with maxUnavailable > 1 is scaled down.
+// For every PodDisruptionBudget in a Cluster, only one Machine at a time is allowed to evict Pods covered by
+// the PodDisruptionBudget; the Machine keeps the PodDisruptionBudget until all its Pods covered by the
+// PodDisruptionBudget have been removed from its Node. Other Machines have to wait, so they don't race on the
+// disruptions allowed by the PodDisruptionBudget and run into long loops of failed evictions.
+// Note: A Machine has to renew the PodDisruptionBudgets it holds at least once per ttl, otherwise they are released.
+// This ensures PodDisruptionBudgets are not held forever, e.g. if a Machine is deleted while draining.
+type EvictionCoordinator struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lock   sync.Mutex
+	leases map[podDisruptionBudgetKey]evictionLease
+}
+
+// podDisruptionBudgetKey identifies a PodDisruptionBudget in a Cluster.
+type podDisruptionBudgetKey struct {
+	cluster             client.ObjectKey
+	podDisruptionBudget string
+}
+
+// evictionLease records which Machine currently holds a PodDisruptionBudget.
+type evictionLease struct {
+	machine   string
+	renewTime time.Time
+}
+
+// NewEvictionCoordinator creates a new EvictionCoordinator.
+// ttl is the duration after which PodDisruptionBudgets that have not been renewed are released.
+func NewEvictionCoordinator(ttl time.Duration) *EvictionCoordinator {
+	return &EvictionCoordinator{
+		ttl:    ttl,
+		now:    time.Now,
+		leases: map[podDisruptionBudgetKey]evictionLease{},
+	}
+}
+
+// TryAcquire tries to acquire the given PodDisruptionBudgets for a Machine, or to renew them if the Machine
+// already holds them.
+// Either all or none of the PodDisruptionBudgets are acquired; if one of them is held by another Machine,
+// TryAcquire returns the PodDisruptionBudget and the name of the Machine holding it.
+// Note: Acquiring all PodDisruptionBudgets of a Pod at once ensures Machines never hold a PodDisruptionBudget
+// while waiting for another one, which could otherwise lead to deadlocks.
+func (c *EvictionCoordinator) TryAcquire(cluster client.ObjectKey, machine string, podDisruptionBudgets []string) (blockingPodDisruptionBudget, holder string, acquired bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for _, pdb := range podDisruptionBudgets {
+		lease, ok := c.leases[podDisruptionBudgetKey{cluster: cluster, podDisruptionBudget: pdb}]
+		if ok && lease.machine != machine && now.Sub(lease.renewTime) < c.ttl {
+			return pdb, lease.machine, false
+		}
+	}
+
+	for _, pdb := range podDisruptionBudgets {
+		c.leases[podDisruptionBudgetKey{cluster: cluster, podDisruptionBudget: pdb}] = evictionLease{
+			machine:   machine,
+			renewTime: now,
+		}
+	}
+	return "", "", true
+}
+
+// Release releases all PodDisruptionBudgets held by a Machine, except the ones in keep.
+// Release also drops expired leases of all Clusters, so the EvictionCoordinator doesn't grow indefinitely.
+func (c *EvictionCoordinator) Release(cluster client.ObjectKey, machine string, keep sets.Set[string]) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for key, lease := range c.leases {
+		if now.Sub(lease.renewTime) >= c.ttl {
+			delete(c.leases, key)
+			continue
+		}
+		if key.cluster == cluster && lease.machine == machine && !keep.Has(key.podDisruptionBudget) {
+			delete(c.leases, key)
+		}
+	}
+}
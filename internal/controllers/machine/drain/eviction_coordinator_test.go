@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestEvictionCoordinator(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster1 := client.ObjectKey{Namespace: "default", Name: "cluster-1"}
+	cluster2 := client.ObjectKey{Namespace: "default", Name: "cluster-2"}
+
+	now := time.Now()
+	c := NewEvictionCoordinator(time.Minute)
+	c.now = func() time.Time { return now }
+
+	// Pods without PodDisruptionBudgets can always be evicted.
+	_, _, ok := c.TryAcquire(cluster1, "machine-1", nil)
+	g.Expect(ok).To(BeTrue())
+
+	// machine-1 acquires pdb-a.
+	_, _, ok = c.TryAcquire(cluster1, "machine-1", []string{"ns/pdb-a"})
+	g.Expect(ok).To(BeTrue())
+
+	// machine-1 can renew pdb-a.
+	_, _, ok = c.TryAcquire(cluster1, "machine-1", []string{"ns/pdb-a"})
+	g.Expect(ok).To(BeTrue())
+
+	// machine-2 has to wait for pdb-a, and it doesn't acquire pdb-b as it is acquired together with pdb-a.
+	pdb, holder, ok := c.TryAcquire(cluster1, "machine-2", []string{"ns/pdb-a", "ns/pdb-b"})
+	g.Expect(ok).To(BeFalse())
+	g.Expect(pdb).To(Equal("ns/pdb-a"))
+	g.Expect(holder).To(Equal("machine-1"))
+	_, _, ok = c.TryAcquire(cluster1, "machine-3", []string{"ns/pdb-b"})
+	g.Expect(ok).To(BeTrue())
+	c.Release(cluster1, "machine-3", nil)
+
+	// PodDisruptionBudgets are tracked per Cluster.
+	_, _, ok = c.TryAcquire(cluster2, "machine-2", []string{"ns/pdb-a"})
+	g.Expect(ok).To(BeTrue())
+
+	// Releasing PodDisruptionBudgets which are kept does not release them.
+	c.Release(cluster1, "machine-1", sets.New[string]("ns/pdb-a"))
+	_, _, ok = c.TryAcquire(cluster1, "machine-2", []string{"ns/pdb-a"})
+	g.Expect(ok).To(BeFalse())
+
+	// Other Machines cannot release PodDisruptionBudgets.
+	c.Release(cluster1, "machine-2", nil)
+	_, _, ok = c.TryAcquire(cluster1, "machine-2", []string{"ns/pdb-a"})
+	g.Expect(ok).To(BeFalse())
+
+	// After machine-1 released pdb-a, machine-2 can acquire it.
+	c.Release(cluster1, "machine-1", nil)
+	_, _, ok = c.TryAcquire(cluster1, "machine-2", []string{"ns/pdb-a", "ns/pdb-b"})
+	g.Expect(ok).To(BeTrue())
+
+	// PodDisruptionBudgets which are not renewed within the ttl are released.
+	now = now.Add(time.Minute)
+	_, _, ok = c.TryAcquire(cluster1, "machine-1", []string{"ns/pdb-a"})
+	g.Expect(ok).To(BeTrue())
+
+	// Expired leases are dropped on Release.
+	now = now.Add(time.Minute)
+	c.Release(cluster1, "machine-3", nil)
+	g.Expect(c.leases).To(BeEmpty())
+}
@@ -113,10 +113,30 @@ type PodDeleteStatus struct {
 	// DrainOrder is only used if DrainBehavior is "Drain".
 	DrainOrder *int32
 
+	// DrainTimeout defines the drain timeout of a Pod.
+	// DrainTimeout is only set if the MachineDrainRule which applies to the Pod defines a timeout.
+	DrainTimeout *DrainTimeout
+
+	// PodDisruptionBudgets are the PodDisruptionBudgets covering the Pod, in the format <namespace>/<name>.
+	// PodDisruptionBudgets is only set if evictions are coordinated via an EvictionCoordinator.
+	PodDisruptionBudgets []string
+
 	Reason  string
 	Message string
 }
 
+// DrainTimeout defines the drain timeout of a Pod, as defined by the MachineDrainRule which applies to the Pod.
+type DrainTimeout struct {
+	// MachineDrainRule is the name of the MachineDrainRule defining the timeout.
+	MachineDrainRule string
+
+	// Timeout is the maximum time to wait for the Pod to be removed from the Node, measured from the start of the drain.
+	Timeout time.Duration
+
+	// OnTimeout is the action taken when the timeout is reached.
+	OnTimeout clusterv1.MachineDrainRuleOnTimeoutAction
+}
+
 // PodFilter takes a pod and returns a PodDeleteStatus.
 type PodFilter func(context.Context, *corev1.Pod) PodDeleteStatus
 
@@ -316,13 +336,17 @@ func (d *Helper) machineDrainRulesFilter(machineDrainRules []*clusterv1.MachineD
 			log := ctrl.LoggerFrom(ctx, "Pod", klog.KObj(pod))
 			switch mdr.Spec.Drain.Behavior {
 			case clusterv1.MachineDrainRuleDrainBehaviorDrain:
-				return MakePodDeleteStatusOkayWithOrder(mdr.Spec.Drain.Order)
+				status := MakePodDeleteStatusOkayWithOrder(mdr.Spec.Drain.Order)
+				status.DrainTimeout = drainTimeoutFromMachineDrainRule(mdr)
+				return status
 			case clusterv1.MachineDrainRuleDrainBehaviorSkip:
 				log.V(4).Info(fmt.Sprintf("Skip evicting Pod, because MachineDrainRule %s with behavior %s applies to the Pod", mdr.Name, clusterv1.MachineDrainRuleDrainBehaviorSkip))
 				return MakePodDeleteStatusSkip()
 			case clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted:
 				log.V(4).Info(fmt.Sprintf("Skip evicting Pod, because MachineDrainRule %s with behavior %s applies to the Pod", mdr.Name, clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted))
-				return MakePodDeleteStatusWaitCompleted()
+				status := MakePodDeleteStatusWaitCompleted()
+				status.DrainTimeout = drainTimeoutFromMachineDrainRule(mdr)
+				return status
 			default:
 				return MakePodDeleteStatusWithError(
 					fmt.Sprintf("MachineDrainRule %q has unknown spec.drain.behavior: %q",
@@ -335,6 +359,22 @@ func (d *Helper) machineDrainRulesFilter(machineDrainRules []*clusterv1.MachineD
 	}
 }
 
+// drainTimeoutFromMachineDrainRule returns the DrainTimeout defined by a MachineDrainRule, if any.
+func drainTimeoutFromMachineDrainRule(mdr *clusterv1.MachineDrainRule) *DrainTimeout {
+	if mdr.Spec.Drain.TimeoutSeconds == nil {
+		return nil
+	}
+	onTimeout := mdr.Spec.Drain.OnTimeout
+	if onTimeout == "" {
+		onTimeout = clusterv1.MachineDrainRuleOnTimeoutActionBlock
+	}
+	return &DrainTimeout{
+		MachineDrainRule: mdr.Name,
+		Timeout:          time.Duration(*mdr.Spec.Drain.TimeoutSeconds) * time.Second,
+		OnTimeout:        onTimeout,
+	}
+}
+
 // machineDrainRuleAppliesToPod evaluates if a MachineDrainRule applies to a Pod.
 func machineDrainRuleAppliesToPod(mdr *clusterv1.MachineDrainRule, pod *corev1.Pod, namespace *corev1.Namespace) bool {
 	// If pods is empty, the MachineDrainRule applies to all Pods.
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -216,6 +217,61 @@ func Test_machineDrainRuleAppliesToPod(t *testing.T) {
 	}
 }
 
+func Test_drainTimeoutFromMachineDrainRule(t *testing.T) {
+	tests := []struct {
+		name             string
+		drain            clusterv1.MachineDrainRuleDrainConfig
+		wantDrainTimeout *DrainTimeout
+	}{
+		{
+			name: "no timeout",
+			drain: clusterv1.MachineDrainRuleDrainConfig{
+				Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+			},
+		},
+		{
+			name: "timeout without onTimeout defaults to Block",
+			drain: clusterv1.MachineDrainRuleDrainConfig{
+				Behavior:       clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				TimeoutSeconds: ptr.To[int32](60),
+			},
+			wantDrainTimeout: &DrainTimeout{
+				MachineDrainRule: "mdr",
+				Timeout:          time.Minute,
+				OnTimeout:        clusterv1.MachineDrainRuleOnTimeoutActionBlock,
+			},
+		},
+		{
+			name: "timeout with onTimeout",
+			drain: clusterv1.MachineDrainRuleDrainConfig{
+				Behavior:       clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted,
+				TimeoutSeconds: ptr.To[int32](300),
+				OnTimeout:      clusterv1.MachineDrainRuleOnTimeoutActionForceDelete,
+			},
+			wantDrainTimeout: &DrainTimeout{
+				MachineDrainRule: "mdr",
+				Timeout:          5 * time.Minute,
+				OnTimeout:        clusterv1.MachineDrainRuleOnTimeoutActionForceDelete,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mdr := &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mdr",
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: tt.drain,
+				},
+			}
+			g.Expect(drainTimeoutFromMachineDrainRule(mdr)).To(BeComparableTo(tt.wantDrainTimeout))
+		})
+	}
+}
+
 func Test_matchesSelector(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -52,6 +52,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine/drain"
@@ -70,6 +71,7 @@ import (
 
 const (
 	drainRetryInterval               = time.Duration(20) * time.Second
+	evictionCoordinatorTTL           = time.Duration(2) * time.Minute
 	waitForVolumeDetachRetryInterval = time.Duration(20) * time.Second
 )
 
@@ -105,6 +107,9 @@ type Reconciler struct {
 	AdditionalSyncMachineLabels      []*regexp.Regexp
 	AdditionalSyncMachineAnnotations []*regexp.Regexp
 
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
+
 	controller      controller.Controller
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
@@ -118,6 +123,10 @@ type Reconciler struct {
 	// e.g. spamming workload clusters with eviction requests during Node drain.
 	reconcileDeleteCache cache.Cache[cache.ReconcileEntry]
 
+	// evictionCoordinator is used to serialize evictions of Pods covered by the same PodDisruptionBudget
+	// across Machines of a Cluster which are drained concurrently.
+	evictionCoordinator *drain.EvictionCoordinator
+
 	predicateLog *logr.Logger
 }
 
@@ -129,6 +138,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		// to have some buffer.
 		return errors.New("Client, APIReader and ClusterCache must not be nil and RemoteConditionsGracePeriod must not be < 2m")
 	}
+	if (feature.Gates.Enabled(feature.RuntimeSDK) || feature.Gates.Enabled(feature.InPlaceUpdates)) && r.RuntimeClient == nil {
+		return errors.New("RuntimeClient must not be nil when the RuntimeSDK or the InPlaceUpdates feature gates are enabled")
+	}
 
 	r.predicateLog = ptr.To(ctrl.LoggerFrom(ctx).WithValues("controller", "machine"))
 	clusterToMachines, err := util.ClusterToTypedObjectsMapper(mgr.GetClient(), &clusterv1.MachineList{}, mgr.GetScheme())
@@ -187,6 +199,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		PredicateLogger: r.predicateLog,
 	}
 	r.reconcileDeleteCache = cache.New[cache.ReconcileEntry](cache.DefaultTTL)
+	if feature.Gates.Enabled(feature.MachineDrainEvictionCoordination) {
+		r.evictionCoordinator = drain.NewEvictionCoordinator(evictionCoordinatorTTL)
+	}
 	return nil
 }
 
@@ -282,7 +297,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	// Handle normal reconciliation loop.
-	return doReconcile(ctx, alwaysReconcile, s)
+	reconcileNormal := alwaysReconcile
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		reconcileNormal = append(reconcileNormal, r.reconcileLifecycleHooks)
+	}
+	if feature.Gates.Enabled(feature.InPlaceUpdates) {
+		reconcileNormal = append(reconcileNormal, r.reconcileInPlaceUpdate)
+	}
+	return doReconcile(ctx, reconcileNormal, s)
 }
 
 func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clusterv1.Machine, options ...patch.Option) error {
@@ -326,6 +348,7 @@ func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clust
 			clusterv1.MachineNodeReadyCondition,
 			clusterv1.MachineNodeHealthyCondition,
 			clusterv1.MachineDeletingCondition,
+			clusterv1.MachineUpdatingCondition,
 		}},
 	)
 
@@ -447,6 +470,8 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 				nodeName = m.Status.NodeRef.Name
 			}
 			log.Info("Skipping deletion of Kubernetes Node associated with Machine as it is not allowed", "Node", klog.KRef("", nodeName), "cause", err.Error())
+			// The Node is not drained, release the PodDisruptionBudgets held by the Machine if it was draining before.
+			r.releasePodDisruptionBudgets(cluster, m)
 		default:
 			s.deletingReason = clusterv1.MachineDeletingInternalErrorReason
 			s.deletingMessage = "Please check controller logs for errors" //nolint:goconst // Not making this a constant for now
@@ -471,6 +496,23 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 			s.deletingMessage = fmt.Sprintf("Waiting for pre-drain hooks to succeed (hooks: %s)", strings.Join(hooks, ","))
 			return ctrl.Result{}, nil
 		}
+
+		// BeforeMachineDrain Runtime hook
+		// Return early without error, will requeue after the time requested by Runtime Extensions.
+		if feature.Gates.Enabled(feature.RuntimeSDK) {
+			retryAfter, err := r.callBeforeMachineDrainHook(ctx, m)
+			if err != nil {
+				s.deletingReason = clusterv1.MachineDeletingInternalErrorReason
+				s.deletingMessage = "Failed to call BeforeMachineDrain hook, please check controller logs for errors"
+				return ctrl.Result{}, err
+			}
+			if retryAfter != 0 {
+				v1beta1conditions.MarkFalse(m, clusterv1.PreDrainDeleteHookSucceededV1Beta1Condition, clusterv1.WaitingExternalHookV1Beta1Reason, clusterv1.ConditionSeverityInfo, "")
+				s.deletingReason = clusterv1.MachineDeletingWaitingForPreDrainHookReason
+				s.deletingMessage = "Waiting for BeforeMachineDrain hook to succeed"
+				return ctrl.Result{RequeueAfter: retryAfter}, nil
+			}
+		}
 		v1beta1conditions.MarkTrue(m, clusterv1.PreDrainDeleteHookSucceededV1Beta1Condition)
 
 		// Drain node before deletion and issue a patch in order to make this operation visible to the users.
@@ -519,6 +561,11 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 			r.recorder.Eventf(m, corev1.EventTypeNormal, "SuccessfulDrainNode", "success draining Machine's node %q", m.Status.NodeRef.Name)
 		}
 
+		// Drain is completed or skipped, e.g. because NodeDrainTimeout is exceeded or the Machine is annotated to
+		// exclude the Node from draining; release the PodDisruptionBudgets held by the Machine, so other Machines
+		// don't have to wait for the leases to expire.
+		r.releasePodDisruptionBudgets(cluster, m)
+
 		// After node draining is completed, and if isNodeVolumeDetachingAllowed returns True, make sure all
 		// volumes are detached before proceeding to delete the Node.
 		// In case the node is unreachable, the detachment is skipped.
@@ -568,6 +615,23 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 		s.deletingMessage = fmt.Sprintf("Waiting for pre-terminate hooks to succeed (hooks: %s)", strings.Join(hooks, ","))
 		return ctrl.Result{}, nil
 	}
+
+	// BeforeMachineDelete Runtime hook
+	// Return early without error, will requeue after the time requested by Runtime Extensions.
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		retryAfter, err := r.callBeforeMachineDeleteHook(ctx, m)
+		if err != nil {
+			s.deletingReason = clusterv1.MachineDeletingInternalErrorReason
+			s.deletingMessage = "Failed to call BeforeMachineDelete hook, please check controller logs for errors"
+			return ctrl.Result{}, err
+		}
+		if retryAfter != 0 {
+			v1beta1conditions.MarkFalse(m, clusterv1.PreTerminateDeleteHookSucceededV1Beta1Condition, clusterv1.WaitingExternalHookV1Beta1Reason, clusterv1.ConditionSeverityInfo, "")
+			s.deletingReason = clusterv1.MachineDeletingWaitingForPreTerminateHookReason
+			s.deletingMessage = "Waiting for BeforeMachineDelete hook to succeed"
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		}
+	}
 	v1beta1conditions.MarkTrue(m, clusterv1.PreTerminateDeleteHookSucceededV1Beta1Condition)
 
 	infrastructureDeleted, err := r.reconcileDeleteInfrastructure(ctx, s)
@@ -822,6 +886,7 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 		if apierrors.IsNotFound(err) {
 			// If an admin deletes the node directly, we'll end up here.
 			log.Info("Could not find Node from Machine.status.nodeRef, skipping Node drain.")
+			r.releasePodDisruptionBudgets(cluster, machine)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, errors.Wrapf(err, "unable to get Node %s", nodeName)
@@ -832,6 +897,14 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 		RemoteClient:       remoteClient,
 		GracePeriodSeconds: -1,
 	}
+	if machine.Status.Deletion != nil {
+		drainer.NodeDrainStartTime = machine.Status.Deletion.NodeDrainStartTime
+	}
+	if r.evictionCoordinator != nil {
+		drainer.EvictionCoordinator = r.evictionCoordinator
+		drainer.Cluster = util.ObjectKey(cluster)
+		drainer.Machine = machine.Name
+	}
 
 	if noderefutil.IsNodeUnreachable(node) {
 		// Kubelet is unreachable, pods will never disappear.
@@ -868,6 +941,7 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 
 	podsToBeDrained := podDeleteList.Pods()
 	if len(podsToBeDrained) == 0 {
+		r.releasePodDisruptionBudgets(cluster, machine)
 		log.Info("Drain completed")
 		return ctrl.Result{}, nil
 	}
@@ -877,6 +951,7 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 	evictionResult := drainer.EvictPods(ctx, podDeleteList)
 
 	if evictionResult.DrainCompleted() {
+		r.releasePodDisruptionBudgets(cluster, machine)
 		log.Info("Drain completed, remaining Pods on the Node have been evicted")
 		return ctrl.Result{}, nil
 	}
@@ -902,6 +977,14 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 	return ctrl.Result{RequeueAfter: drainRetryInterval}, nil
 }
 
+// releasePodDisruptionBudgets releases all PodDisruptionBudgets held by the Machine, so other Machines of the Cluster
+// can continue with their evictions.
+func (r *Reconciler) releasePodDisruptionBudgets(cluster *clusterv1.Cluster, machine *clusterv1.Machine) {
+	if r.evictionCoordinator != nil {
+		r.evictionCoordinator.Release(util.ObjectKey(cluster), machine.Name, nil)
+	}
+}
+
 // shouldWaitForNodeVolumes returns true if node status still have volumes attached and the node is reachable
 // pod deletion and volume detach happen asynchronously, so pod could be deleted before volume detached from the node
 // this could cause issue for some storage provisioner, for example, vsphere-volume this is problematic
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/internal/util/inplace"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// reconcileInPlaceUpdate calls the UpdateMachine hook for Machines being updated in-place and sets the Updating condition.
+// Note: The in-place update is started by the controller owning the Machine, which sets the UpdateInProgressAnnotation,
+// updates Machine, InfrastructureMachine and BootstrapConfig to their desired state, and then marks the UpdateMachine hook as pending.
+func (r *Reconciler) reconcileInPlaceUpdate(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	m := s.machine
+
+	if !inplace.IsUpdateInProgress(m) {
+		// If the hook is still pending, the update completed but the Machine controller failed to
+		// mark the hook as done; complete the cleanup.
+		if hooks.IsPending(runtimehooksv1.UpdateMachine, m) {
+			if err := hooks.MarkAsDone(ctx, r.Client, m, runtimehooksv1.UpdateMachine); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		conditions.Set(m, metav1.Condition{
+			Type:   clusterv1.MachineUpdatingCondition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.MachineNotUpdatingReason,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	if !inplace.IsUpdateStarted(m) {
+		conditions.Set(m, metav1.Condition{
+			Type:    clusterv1.MachineUpdatingCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineInPlaceUpdatingReason,
+			Message: "Waiting for the in-place update to start",
+		})
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for InfrastructureMachine and BootstrapConfig to be read by the previous phases.
+	if s.infraMachine == nil || (m.Spec.Bootstrap.ConfigRef != nil && s.bootstrapConfig == nil) {
+		conditions.Set(m, metav1.Condition{
+			Type:    clusterv1.MachineUpdatingCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineInPlaceUpdatingReason,
+			Message: "Waiting for InfrastructureMachine and BootstrapConfig to be available",
+		})
+		return ctrl.Result{}, nil
+	}
+
+	desired, err := inplace.ToMachineUpdateObjects(&inplace.MachineObjects{
+		Machine:         m,
+		InfraMachine:    s.infraMachine,
+		BootstrapConfig: s.bootstrapConfig,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to call UpdateMachine hook")
+	}
+
+	hookRequest := &runtimehooksv1.UpdateMachineRequest{
+		Desired: *desired,
+	}
+	hookResponse := &runtimehooksv1.UpdateMachineResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.UpdateMachine, m, hookRequest, hookResponse); err != nil {
+		conditions.Set(m, metav1.Condition{
+			Type:    clusterv1.MachineUpdatingCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineInPlaceUpdateFailedReason,
+			Message: "Failed to call UpdateMachine hook, please check controller logs for errors",
+		})
+		return ctrl.Result{}, err
+	}
+
+	if hookResponse.RetryAfterSeconds != 0 {
+		message := "In-place update in progress"
+		if hookResponse.Message != "" {
+			message = hookResponse.Message
+		}
+		log.Info("Waiting for in-place update to complete", "message", hookResponse.Message)
+		conditions.Set(m, metav1.Condition{
+			Type:    clusterv1.MachineUpdatingCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineInPlaceUpdatingReason,
+			Message: message,
+		})
+		return ctrl.Result{RequeueAfter: time.Duration(hookResponse.RetryAfterSeconds) * time.Second}, nil
+	}
+
+	// The update is completed; remove the UpdateInProgressAnnotation before marking the hook as done,
+	// so the controller owning the Machine won't consider the Machine as an update that must be resumed.
+	patchHelper, err := patch.NewHelper(m, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	delete(m.Annotations, clusterv1.UpdateInProgressAnnotation)
+	if err := patchHelper.Patch(ctx, m); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to remove %s annotation", clusterv1.UpdateInProgressAnnotation)
+	}
+	if err := hooks.MarkAsDone(ctx, r.Client, m, runtimehooksv1.UpdateMachine); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("In-place update completed")
+
+	conditions.Set(m, metav1.Condition{
+		Type:   clusterv1.MachineUpdatingCondition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineNotUpdatingReason,
+	})
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func TestReconcileInPlaceUpdate(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	updateMachineGVH, err := catalog.GroupVersionHook(runtimehooksv1.UpdateMachine)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+
+	inProgressResponse := &runtimehooksv1.UpdateMachineResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse:    runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess, Message: "Updating kubelet"},
+			RetryAfterSeconds: 10,
+		},
+	}
+	completedResponse := &runtimehooksv1.UpdateMachineResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		},
+	}
+	failedResponse := &runtimehooksv1.UpdateMachineResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		response          runtimehooksv1.ResponseObject
+		wantResult        ctrl.Result
+		wantErr           bool
+		wantCondition     metav1.Condition
+		wantAnnotations   map[string]string
+		wantHookCallCount int
+	}{
+		{
+			name: "Machine not being updated",
+			wantCondition: metav1.Condition{
+				Type:   clusterv1.MachineUpdatingCondition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineNotUpdatingReason,
+			},
+		},
+		{
+			name: "Machine with the update in progress but not yet started",
+			annotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+			},
+			wantCondition: metav1.Condition{
+				Type:    clusterv1.MachineUpdatingCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineInPlaceUpdatingReason,
+				Message: "Waiting for the in-place update to start",
+			},
+			wantAnnotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+			},
+		},
+		{
+			name: "Machine being updated, Runtime Extensions still working",
+			annotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+				runtimev1.PendingHooksAnnotation:     "UpdateMachine",
+			},
+			response:   inProgressResponse,
+			wantResult: ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantCondition: metav1.Condition{
+				Type:    clusterv1.MachineUpdatingCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineInPlaceUpdatingReason,
+				Message: "Updating kubelet",
+			},
+			wantAnnotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+				runtimev1.PendingHooksAnnotation:     "UpdateMachine",
+			},
+			wantHookCallCount: 1,
+		},
+		{
+			name: "Machine being updated, Runtime Extensions completed the update",
+			annotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+				runtimev1.PendingHooksAnnotation:     "UpdateMachine",
+			},
+			response: completedResponse,
+			wantCondition: metav1.Condition{
+				Type:   clusterv1.MachineUpdatingCondition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineNotUpdatingReason,
+			},
+			wantHookCallCount: 1,
+		},
+		{
+			name: "Machine being updated, Runtime Extension failed",
+			annotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+				runtimev1.PendingHooksAnnotation:     "UpdateMachine",
+			},
+			response: failedResponse,
+			wantErr:  true,
+			wantCondition: metav1.Condition{
+				Type:    clusterv1.MachineUpdatingCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineInPlaceUpdateFailedReason,
+				Message: "Failed to call UpdateMachine hook, please check controller logs for errors",
+			},
+			wantAnnotations: map[string]string{
+				clusterv1.UpdateInProgressAnnotation: "",
+				runtimev1.PendingHooksAnnotation:     "UpdateMachine",
+			},
+			wantHookCallCount: 1,
+		},
+		{
+			name: "Machine with the update completed but the hook still pending",
+			annotations: map[string]string{
+				runtimev1.PendingHooksAnnotation: "UpdateMachine",
+			},
+			wantCondition: metav1.Condition{
+				Type:   clusterv1.MachineUpdatingCondition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineNotUpdatingReason,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "machine",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: tt.annotations,
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "cluster",
+				},
+			}
+			infraMachine := builder.InfrastructureMachine(metav1.NamespaceDefault, "infra-machine").Build()
+
+			c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(machine).Build()
+			runtimeClientBuilder := fakeruntimeclient.NewRuntimeClientBuilder().WithCatalog(catalog)
+			if tt.response != nil {
+				runtimeClientBuilder = runtimeClientBuilder.WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					updateMachineGVH: tt.response,
+				})
+			}
+			runtimeClient := runtimeClientBuilder.Build()
+
+			r := &Reconciler{
+				Client:        c,
+				RuntimeClient: runtimeClient,
+			}
+			s := &scope{
+				machine:      machine,
+				infraMachine: infraMachine,
+			}
+
+			res, err := r.reconcileInPlaceUpdate(ctx, s)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(res).To(Equal(tt.wantResult))
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.UpdateMachine)).To(Equal(tt.wantHookCallCount))
+
+			condition := conditions.Get(s.machine, clusterv1.MachineUpdatingCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(conditions.MatchCondition(tt.wantCondition, conditions.IgnoreLastTransitionTime(true)))
+
+			gotMachine := &clusterv1.Machine{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+			g.Expect(gotMachine.Annotations).To(BeComparableTo(tt.wantAnnotations))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// reconcileLifecycleHooks tracks the intent to call the Machine lifecycle hooks and calls the AfterMachineReady hook
+// once the Node of the Machine becomes ready.
+// Note: The BeforeMachineDrain and BeforeMachineDelete hooks are marked as pending while the Machine is not being deleted,
+// so they are called during deletion also for Machines created before the RuntimeSDK feature gate has been enabled.
+// The AfterMachineReady hook instead is marked as pending only before the Machine gets a Node, so it is called only once.
+// Hooks are marked as pending only if at least one Runtime Extension is registered for them, so Machines are not
+// patched when no Runtime Extension implements the Machine lifecycle hooks.
+func (r *Reconciler) reconcileLifecycleHooks(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	m := s.machine
+
+	candidateHooks := []runtimecatalog.Hook{}
+	if !hooks.IsPending(runtimehooksv1.BeforeMachineDrain, m) {
+		candidateHooks = append(candidateHooks, runtimehooksv1.BeforeMachineDrain)
+	}
+	if !hooks.IsPending(runtimehooksv1.BeforeMachineDelete, m) {
+		candidateHooks = append(candidateHooks, runtimehooksv1.BeforeMachineDelete)
+	}
+	if m.Status.NodeRef == nil && !hooks.IsPending(runtimehooksv1.AfterMachineReady, m) {
+		candidateHooks = append(candidateHooks, runtimehooksv1.AfterMachineReady)
+	}
+	pendingHooks := []runtimecatalog.Hook{}
+	for _, hook := range candidateHooks {
+		registered, err := r.hasRegisteredExtensions(ctx, hook, m)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if registered {
+			pendingHooks = append(pendingHooks, hook)
+		}
+	}
+	if len(pendingHooks) > 0 {
+		if err := hooks.MarkAsPending(ctx, r.Client, m, pendingHooks...); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if !hooks.IsPending(runtimehooksv1.AfterMachineReady, m) || s.node == nil || !util.IsNodeReady(s.node) {
+		return ctrl.Result{}, nil
+	}
+
+	v1beta1Machine, err := convertMachineForHook(m)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	hookRequest := &runtimehooksv1.AfterMachineReadyRequest{
+		Machine: *v1beta1Machine,
+	}
+	hookResponse := &runtimehooksv1.AfterMachineReadyResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterMachineReady, m, hookRequest, hookResponse); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := hooks.MarkAsDone(ctx, r.Client, m, runtimehooksv1.AfterMachineReady); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(4).Info(fmt.Sprintf("Called %s hook", runtimecatalog.HookName(runtimehooksv1.AfterMachineReady)))
+	return ctrl.Result{}, nil
+}
+
+// hasRegisteredExtensions returns true if at least one Runtime Extension is registered for the hook; if the RuntimeClient
+// cannot list Runtime Extensions, Runtime Extensions are assumed to be registered.
+func (r *Reconciler) hasRegisteredExtensions(ctx context.Context, hook runtimecatalog.Hook, m *clusterv1.Machine) (bool, error) {
+	extensionLister, ok := r.RuntimeClient.(runtimeclient.ExtensionLister)
+	if !ok {
+		return true, nil
+	}
+	extensionHandlers, err := extensionLister.GetAllExtensions(ctx, hook, m)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get Runtime Extensions registered for the %s hook", runtimecatalog.HookName(hook))
+	}
+	return len(extensionHandlers) > 0, nil
+}
+
+// callBeforeMachineDrainHook calls the BeforeMachineDrain hook, if pending, and returns the time after which
+// the hook must be called again if Runtime Extensions are blocking the drain of the Node.
+func (r *Reconciler) callBeforeMachineDrainHook(ctx context.Context, m *clusterv1.Machine) (time.Duration, error) {
+	if !hooks.IsPending(runtimehooksv1.BeforeMachineDrain, m) {
+		return 0, nil
+	}
+
+	v1beta1Machine, err := convertMachineForHook(m)
+	if err != nil {
+		return 0, err
+	}
+	hookRequest := &runtimehooksv1.BeforeMachineDrainRequest{
+		Machine: *v1beta1Machine,
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineDrainResponse{}
+	return r.callBlockingMachineHook(ctx, runtimehooksv1.BeforeMachineDrain, m, hookRequest, hookResponse)
+}
+
+// callBeforeMachineDeleteHook calls the BeforeMachineDelete hook, if pending, and returns the time after which
+// the hook must be called again if Runtime Extensions are blocking the deletion of the infrastructure.
+func (r *Reconciler) callBeforeMachineDeleteHook(ctx context.Context, m *clusterv1.Machine) (time.Duration, error) {
+	if !hooks.IsPending(runtimehooksv1.BeforeMachineDelete, m) {
+		return 0, nil
+	}
+
+	v1beta1Machine, err := convertMachineForHook(m)
+	if err != nil {
+		return 0, err
+	}
+	hookRequest := &runtimehooksv1.BeforeMachineDeleteRequest{
+		Machine: *v1beta1Machine,
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineDeleteResponse{}
+	return r.callBlockingMachineHook(ctx, runtimehooksv1.BeforeMachineDelete, m, hookRequest, hookResponse)
+}
+
+func (r *Reconciler) callBlockingMachineHook(ctx context.Context, hook runtimecatalog.Hook, m *clusterv1.Machine, hookRequest runtimehooksv1.RequestObject, hookResponse runtimehooksv1.RetryResponseObject) (time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if err := r.RuntimeClient.CallAllExtensions(ctx, hook, m, hookRequest, hookResponse); err != nil {
+		return 0, err
+	}
+	if hookResponse.GetRetryAfterSeconds() != 0 {
+		log.Info(fmt.Sprintf("Deletion of Machine is blocked by %s hook", runtimecatalog.HookName(hook)))
+		return time.Duration(hookResponse.GetRetryAfterSeconds()) * time.Second, nil
+	}
+	if err := hooks.MarkAsDone(ctx, r.Client, m, hook); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func convertMachineForHook(m *clusterv1.Machine) (*clusterv1beta1.Machine, error) {
+	v1beta1Machine := &clusterv1beta1.Machine{}
+	if err := clusterv1beta1.Convert_v1beta2_Machine_To_v1beta1_Machine(m, v1beta1Machine, nil); err != nil {
+		return nil, errors.Wrap(err, "error converting Machine to v1beta1 Machine")
+	}
+	return v1beta1Machine, nil
+}
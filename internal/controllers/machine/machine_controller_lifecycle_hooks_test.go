@@ -0,0 +1,342 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+)
+
+func TestReconcileLifecycleHooks(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	afterMachineReadyGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterMachineReady)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+	beforeMachineDrainGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDrain)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+	beforeMachineDeleteGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDelete)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+	allExtensions := map[runtimecatalog.GroupVersionHook][]string{
+		afterMachineReadyGVH:   {"extension"},
+		beforeMachineDrainGVH:  {"extension"},
+		beforeMachineDeleteGVH: {"extension"},
+	}
+
+	successResponse := &runtimehooksv1.AfterMachineReadyResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+	}
+	failureResponse := &runtimehooksv1.AfterMachineReadyResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+	}
+
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	notReadyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		nodeRef           *clusterv1.MachineNodeReference
+		node              *corev1.Node
+		extensions        map[runtimecatalog.GroupVersionHook][]string
+		response          runtimehooksv1.ResponseObject
+		wantErr           bool
+		wantPendingHooks  string
+		wantHookCallCount int
+	}{
+		{
+			name:             "Mark all hooks as pending for a Machine without a Node",
+			extensions:       allExtensions,
+			wantPendingHooks: "AfterMachineReady,BeforeMachineDelete,BeforeMachineDrain",
+		},
+		{
+			name:             "Do not mark hooks as pending if no Runtime Extensions are registered for them",
+			extensions:       nil,
+			wantPendingHooks: "",
+		},
+		{
+			name: "Mark as pending only the hooks with registered Runtime Extensions",
+			extensions: map[runtimecatalog.GroupVersionHook][]string{
+				beforeMachineDrainGVH: {"extension"},
+			},
+			wantPendingHooks: "BeforeMachineDrain",
+		},
+		{
+			name:             "Do not mark AfterMachineReady as pending for a Machine which already has a Node",
+			extensions:       allExtensions,
+			nodeRef:          &clusterv1.MachineNodeReference{Name: "node"},
+			node:             readyNode,
+			wantPendingHooks: "BeforeMachineDelete,BeforeMachineDrain",
+		},
+		{
+			name: "Do not call AfterMachineReady hook if the Node is not ready",
+			annotations: map[string]string{
+				runtimev1.PendingHooksAnnotation: "AfterMachineReady,BeforeMachineDelete,BeforeMachineDrain",
+			},
+			extensions:       allExtensions,
+			nodeRef:          &clusterv1.MachineNodeReference{Name: "node"},
+			node:             notReadyNode,
+			response:         successResponse,
+			wantPendingHooks: "AfterMachineReady,BeforeMachineDelete,BeforeMachineDrain",
+		},
+		{
+			name: "Call AfterMachineReady hook when the Node is ready",
+			annotations: map[string]string{
+				runtimev1.PendingHooksAnnotation: "AfterMachineReady,BeforeMachineDelete,BeforeMachineDrain",
+			},
+			extensions:        allExtensions,
+			nodeRef:           &clusterv1.MachineNodeReference{Name: "node"},
+			node:              readyNode,
+			response:          successResponse,
+			wantPendingHooks:  "BeforeMachineDelete,BeforeMachineDrain",
+			wantHookCallCount: 1,
+		},
+		{
+			name: "Keep AfterMachineReady hook pending if the call fails",
+			annotations: map[string]string{
+				runtimev1.PendingHooksAnnotation: "AfterMachineReady,BeforeMachineDelete,BeforeMachineDrain",
+			},
+			extensions:        allExtensions,
+			nodeRef:           &clusterv1.MachineNodeReference{Name: "node"},
+			node:              readyNode,
+			response:          failureResponse,
+			wantErr:           true,
+			wantPendingHooks:  "AfterMachineReady,BeforeMachineDelete,BeforeMachineDrain",
+			wantHookCallCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "machine",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: tt.annotations,
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "cluster",
+				},
+				Status: clusterv1.MachineStatus{
+					NodeRef: tt.nodeRef,
+				},
+			}
+
+			c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(machine).Build()
+			runtimeClientBuilder := fakeruntimeclient.NewRuntimeClientBuilder().WithCatalog(catalog).WithGetAllExtensionResponses(tt.extensions)
+			if tt.response != nil {
+				runtimeClientBuilder = runtimeClientBuilder.WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					afterMachineReadyGVH: tt.response,
+				})
+			}
+			runtimeClient := runtimeClientBuilder.Build()
+
+			r := &Reconciler{
+				Client:        c,
+				RuntimeClient: runtimeClient,
+			}
+			s := &scope{
+				machine: machine,
+				node:    tt.node,
+			}
+
+			_, err := r.reconcileLifecycleHooks(ctx, s)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.AfterMachineReady)).To(Equal(tt.wantHookCallCount))
+
+			gotMachine := &clusterv1.Machine{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+			if tt.wantPendingHooks == "" {
+				g.Expect(gotMachine.Annotations).ToNot(HaveKey(runtimev1.PendingHooksAnnotation))
+				return
+			}
+			g.Expect(gotMachine.Annotations).To(HaveKeyWithValue(runtimev1.PendingHooksAnnotation, tt.wantPendingHooks))
+		})
+	}
+}
+
+func TestCallBeforeMachineDrainAndDeleteHooks(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	beforeMachineDrainGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDrain)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+	beforeMachineDeleteGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDelete)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+
+	blockingDrainResponse := &runtimehooksv1.BeforeMachineDrainResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse:    runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+			RetryAfterSeconds: 10,
+		},
+	}
+	nonBlockingDrainResponse := &runtimehooksv1.BeforeMachineDrainResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		},
+	}
+	blockingDeleteResponse := &runtimehooksv1.BeforeMachineDeleteResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse:    runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+			RetryAfterSeconds: 5,
+		},
+	}
+	nonBlockingDeleteResponse := &runtimehooksv1.BeforeMachineDeleteResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		},
+	}
+	failedDeleteResponse := &runtimehooksv1.BeforeMachineDeleteResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		pendingHooks         string
+		drainResponse        runtimehooksv1.ResponseObject
+		deleteResponse       runtimehooksv1.ResponseObject
+		wantDrainRetryAfter  time.Duration
+		wantDeleteRetryAfter time.Duration
+		wantErr              bool
+		wantPendingHooks     string
+		wantDrainCallCount   int
+		wantDeleteCallCount  int
+	}{
+		{
+			name:           "Do not call hooks which are not pending",
+			drainResponse:  blockingDrainResponse,
+			deleteResponse: blockingDeleteResponse,
+		},
+		{
+			name:                 "Hooks blocking deletion",
+			pendingHooks:         "BeforeMachineDelete,BeforeMachineDrain",
+			drainResponse:        blockingDrainResponse,
+			deleteResponse:       blockingDeleteResponse,
+			wantDrainRetryAfter:  10 * time.Second,
+			wantDeleteRetryAfter: 5 * time.Second,
+			wantPendingHooks:     "BeforeMachineDelete,BeforeMachineDrain",
+			wantDrainCallCount:   1,
+			wantDeleteCallCount:  1,
+		},
+		{
+			name:                "Hooks not blocking deletion are marked as done",
+			pendingHooks:        "BeforeMachineDelete,BeforeMachineDrain",
+			drainResponse:       nonBlockingDrainResponse,
+			deleteResponse:      nonBlockingDeleteResponse,
+			wantDrainCallCount:  1,
+			wantDeleteCallCount: 1,
+		},
+		{
+			name:                "Hook failing is kept pending",
+			pendingHooks:        "BeforeMachineDelete,BeforeMachineDrain",
+			drainResponse:       nonBlockingDrainResponse,
+			deleteResponse:      failedDeleteResponse,
+			wantErr:             true,
+			wantPendingHooks:    "BeforeMachineDelete",
+			wantDrainCallCount:  1,
+			wantDeleteCallCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "machine",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "cluster",
+				},
+			}
+			if tt.pendingHooks != "" {
+				machine.Annotations = map[string]string{runtimev1.PendingHooksAnnotation: tt.pendingHooks}
+			}
+
+			c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(machine).Build()
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					beforeMachineDrainGVH:  tt.drainResponse,
+					beforeMachineDeleteGVH: tt.deleteResponse,
+				}).
+				Build()
+
+			r := &Reconciler{
+				Client:        c,
+				RuntimeClient: runtimeClient,
+			}
+
+			drainRetryAfter, err := r.callBeforeMachineDrainHook(ctx, machine)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(drainRetryAfter).To(Equal(tt.wantDrainRetryAfter))
+
+			deleteRetryAfter, err := r.callBeforeMachineDeleteHook(ctx, machine)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(deleteRetryAfter).To(Equal(tt.wantDeleteRetryAfter))
+
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeMachineDrain)).To(Equal(tt.wantDrainCallCount))
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeMachineDelete)).To(Equal(tt.wantDeleteCallCount))
+
+			gotMachine := &clusterv1.Machine{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+			g.Expect(gotMachine.Annotations[runtimev1.PendingHooksAnnotation]).To(Equal(tt.wantPendingHooks))
+		})
+	}
+}
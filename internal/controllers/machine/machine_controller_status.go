@@ -705,7 +705,8 @@ func calculateDeletingConditionForSummary(machine *clusterv1.Machine) conditions
 			msg = fmt.Sprintf("Machine deletion in progress since more than 15m, stage: %s", deletingCondition.Reason)
 			if deletingCondition.Reason == clusterv1.MachineDeletingDrainingNodeReason && time.Since(machine.Status.Deletion.NodeDrainStartTime.Time) > 5*time.Minute {
 				delayReasons := []string{}
-				if strings.Contains(deletingCondition.Message, "cannot evict pod as it would violate the pod's disruption budget.") {
+				if strings.Contains(deletingCondition.Message, "cannot evict pod as it would violate the pod's disruption budget.") ||
+					strings.Contains(deletingCondition.Message, "to complete evictions of Pods covered by PodDisruptionBudget") {
 					delayReasons = append(delayReasons, "PodDisruptionBudgets")
 				}
 				if strings.Contains(deletingCondition.Message, "deletionTimestamp set, but still not removed from the Node") {
@@ -50,6 +50,7 @@ import (
 	externalfake "sigs.k8s.io/cluster-api/controllers/external/fake"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/controllers/machine/drain"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/cache"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -1772,7 +1773,11 @@ func TestDrainNode(t *testing.T) {
 				Client:               c,
 				ClusterCache:         clustercache.NewFakeClusterCache(remoteClient, client.ObjectKeyFromObject(testCluster)),
 				reconcileDeleteCache: cache.New[cache.ReconcileEntry](cache.DefaultTTL),
+				evictionCoordinator:  drain.NewEvictionCoordinator(time.Hour),
 			}
+			// Simulate a PodDisruptionBudget acquired by the Machine in a previous drain attempt.
+			_, _, ok := r.evictionCoordinator.TryAcquire(client.ObjectKeyFromObject(testCluster), testMachine.Name, []string{"test-namespace/pdb-1"})
+			g.Expect(ok).To(BeTrue())
 
 			testMachine.Status.NodeRef = &clusterv1.MachineNodeReference{
 				Name: tt.nodeName,
@@ -1816,6 +1821,12 @@ func TestDrainNode(t *testing.T) {
 				g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(tt.node), gotNode)).To(Succeed())
 				g.Expect(gotNode.Spec.Unschedulable).To(BeTrue())
 			}
+
+			// If the drain is completed, PodDisruptionBudgets held by the Machine should be released.
+			if res.IsZero() && err == nil {
+				_, _, ok := r.evictionCoordinator.TryAcquire(client.ObjectKeyFromObject(testCluster), "other-machine", []string{"test-namespace/pdb-1"})
+				g.Expect(ok).To(BeTrue())
+			}
 		})
 	}
 }
@@ -3445,7 +3456,11 @@ func TestNodeDeletion(t *testing.T) {
 				recorder:                 record.NewFakeRecorder(10),
 				nodeDeletionRetryTimeout: 10 * time.Millisecond,
 				reconcileDeleteCache:     cache.New[cache.ReconcileEntry](cache.DefaultTTL),
+				evictionCoordinator:      drain.NewEvictionCoordinator(time.Hour),
 			}
+			// Simulate a PodDisruptionBudget acquired by the Machine before the Node was excluded from draining.
+			_, _, ok := r.evictionCoordinator.TryAcquire(client.ObjectKeyFromObject(&testCluster), m.Name, []string{"test-namespace/pdb-1"})
+			g.Expect(ok).To(BeTrue())
 
 			cluster := testCluster.DeepCopy()
 			if tc.clusterDeleted {
@@ -3470,6 +3485,10 @@ func TestNodeDeletion(t *testing.T) {
 				}
 			}
 			g.Expect(s.deletingReason).To(Equal(tc.expectDeletingReason))
+
+			// PodDisruptionBudgets held by the Machine should be released, because the Node is not drained.
+			_, _, ok = r.evictionCoordinator.TryAcquire(client.ObjectKeyFromObject(&testCluster), "other-machine", []string{"test-namespace/pdb-1"})
+			g.Expect(ok).To(BeTrue())
 		})
 	}
 }
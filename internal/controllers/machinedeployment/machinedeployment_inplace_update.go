@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/internal/util/inplace"
+	"sigs.k8s.io/cluster-api/internal/util/ssa"
+)
+
+// updateMachineSetInPlace tries to roll out changes to the MachineDeployment template by updating the template of the
+// existing MachineSet instead of creating a new MachineSet; the MachineSet controller then takes care of updating Machines in-place.
+// The MachineSet is updated only if:
+// - the MachineDeployment uses the RollingUpdate strategy and there is only one MachineSet with replicas,
+// - changes are limited to version, bootstrap config template and infrastructure template, and the rollout is not triggered by rolloutAfter,
+// - Runtime Extensions implementing the CanUpdateMachine hook can apply the changes to a Machine of the MachineSet.
+// If the MachineSet cannot be updated in-place, nil is returned and the rollout continues by creating a new MachineSet.
+func (r *Reconciler) updateMachineSetInPlace(ctx context.Context, md *clusterv1.MachineDeployment, oldMSs []*clusterv1.MachineSet, reconciliationTime *metav1.Time) (*clusterv1.MachineSet, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !mdutil.IsRollingUpdate(md) {
+		return nil, nil
+	}
+
+	var ms *clusterv1.MachineSet
+	for _, oldMS := range oldMSs {
+		if ptr.Deref(oldMS.Spec.Replicas, 0) == 0 {
+			continue
+		}
+		if ms != nil {
+			return nil, nil
+		}
+		ms = oldMS
+	}
+	if ms == nil || !ms.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	// Rollouts triggered by rolloutAfter are expected to replace Machines.
+	upToDate, _, _ := mdutil.MachineTemplateUpToDate(&ms.Spec.Template, &md.Spec.Template)
+	if upToDate {
+		return nil, nil
+	}
+	if md.Spec.RolloutAfter != nil && !reconciliationTime.Before(md.Spec.RolloutAfter) && ms.CreationTimestamp.Before(md.Spec.RolloutAfter) {
+		return nil, nil
+	}
+
+	// Only changes to version, bootstrap and infrastructure can be applied in-place.
+	currentTemplate := mdutil.MachineTemplateDeepCopyRolloutFields(&ms.Spec.Template)
+	desiredTemplate := mdutil.MachineTemplateDeepCopyRolloutFields(&md.Spec.Template)
+	for _, t := range []*clusterv1.MachineTemplateSpec{currentTemplate, desiredTemplate} {
+		t.Spec.Version = nil
+		t.Spec.Bootstrap.ConfigRef = nil
+		t.Spec.InfrastructureRef = clusterv1.ContractVersionedObjectReference{}
+	}
+	if !reflect.DeepEqual(currentTemplate, desiredTemplate) {
+		return nil, nil
+	}
+
+	// Check if the changes can be applied in-place to a Machine of the MachineSet.
+	machine, err := r.getMachineForInPlaceUpdateCheck(ctx, ms)
+	if err != nil || machine == nil {
+		return nil, err
+	}
+	current, err := inplace.GetMachineObjects(ctx, r.Client, machine)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := inplace.ComputeDesiredMachineObjectsFromTemplate(ctx, r.Client, current, &md.Spec.Template.Spec)
+	if err != nil {
+		return nil, err
+	}
+	canUpdate, message, err := inplace.CanUpdateMachine(ctx, r.RuntimeClient, current, desired)
+	if err != nil {
+		return nil, err
+	}
+	if !canUpdate {
+		log.V(4).Info(fmt.Sprintf("MachineSet %s cannot be updated in-place: %s", ms.Name, message), "MachineSet", klog.KObj(ms))
+		return nil, nil
+	}
+
+	// Update the template of the MachineSet.
+	updatedMS, err := r.computeDesiredMachineSet(ctx, md, ms, oldMSs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update MachineSet %s in-place", klog.KObj(ms))
+	}
+	updatedMS.Spec.Template.Spec.Version = md.Spec.Template.Spec.Version
+	updatedMS.Spec.Template.Spec.Bootstrap = *md.Spec.Template.Spec.Bootstrap.DeepCopy()
+	updatedMS.Spec.Template.Spec.InfrastructureRef = md.Spec.Template.Spec.InfrastructureRef
+
+	// The MachineSet serves a new revision of the MachineDeployment; the revision served so far is recorded
+	// on the MachineSet, so it is kept in the rollout history and it is possible to roll back to it.
+	if err := mdutil.AddInPlaceUpdateRevision(ms, updatedMS); err != nil {
+		return nil, errors.Wrapf(err, "failed to update MachineSet %s in-place", klog.KObj(ms))
+	}
+	updatedMS.Annotations[clusterv1.RevisionAnnotation] = strconv.FormatInt(mdutil.MaxRevision(ctx, oldMSs)+1, 10)
+
+	if err := ssa.Patch(ctx, r.Client, machineDeploymentManagerName, updatedMS, ssa.WithCachingProxy{Cache: r.ssaCache, Original: ms}); err != nil {
+		r.recorder.Eventf(md, corev1.EventTypeWarning, "FailedUpdate", "Failed to update MachineSet %s in-place: %v", klog.KObj(updatedMS), err)
+		return nil, errors.Wrapf(err, "failed to update MachineSet %s in-place", klog.KObj(updatedMS))
+	}
+	log.Info("MachineSet updated in-place", "MachineSet", klog.KObj(updatedMS))
+	r.recorder.Eventf(md, corev1.EventTypeNormal, "SuccessfulUpdateInPlace", "Updated MachineSet %s in-place", klog.KObj(updatedMS))
+	return updatedMS, nil
+}
+
+// replaceMachinesNotUpdatableInPlace replaces the Machines of a MachineSet updated in-place which have been marked by the
+// MachineSet controller because they cannot be updated in-place. Machines are replaced by scaling the MachineSet up and then down
+// again, so maxSurge and maxUnavailable are respected; when scaling down, the MachineSet deletes the marked Machines first.
+// If true is returned, the replacement of Machines is in progress and the MachineSet must not be scaled by the rollout.
+func (r *Reconciler) replaceMachinesNotUpdatableInPlace(ctx context.Context, md *clusterv1.MachineDeployment, oldMSs []*clusterv1.MachineSet, newMS *clusterv1.MachineSet) (bool, error) {
+	if !feature.Gates.Enabled(feature.InPlaceUpdates) || !mdutil.IsRollingUpdate(md) || mdutil.GetReplicaCountForMachineSets(oldMSs) > 0 {
+		return false, nil
+	}
+
+	machines, err := r.getMachinesForMachineSet(ctx, newMS)
+	if err != nil {
+		return false, err
+	}
+	toReplace, deleting := int32(0), 0
+	for _, m := range machines {
+		if _, ok := m.Annotations[clusterv1.UpdateNotPossibleAnnotation]; !ok {
+			continue
+		}
+		if !m.DeletionTimestamp.IsZero() {
+			deleting++
+			continue
+		}
+		toReplace++
+	}
+	if toReplace == 0 && deleting == 0 {
+		return false, nil
+	}
+
+	// Wait for the Machines being deleted to go away before replacing other Machines.
+	if deleting > 0 {
+		return true, nil
+	}
+
+	replicas := ptr.Deref(md.Spec.Replicas, 0)
+	msReplicas := ptr.Deref(newMS.Spec.Replicas, 0)
+	availableReplicas := ptr.Deref(newMS.Status.AvailableReplicas, 0)
+
+	if maxSurge := mdutil.MaxSurge(*md); maxSurge > 0 {
+		// Create new Machines first, then scale down once the new Machines are available.
+		if surgedReplicas := replicas + min(toReplace, maxSurge); msReplicas < surgedReplicas {
+			return true, r.scaleMachineSet(ctx, newMS, surgedReplicas, md)
+		}
+		if availableReplicas < msReplicas {
+			return true, nil
+		}
+		return true, r.scaleMachineSet(ctx, newMS, replicas, md)
+	}
+
+	// Delete Machines first, then scale up again once the Machines are gone.
+	if msReplicas < replicas {
+		return true, r.scaleMachineSet(ctx, newMS, replicas, md)
+	}
+	if availableReplicas < replicas {
+		return true, nil
+	}
+	return true, r.scaleMachineSet(ctx, newMS, replicas-min(toReplace, max(mdutil.MaxUnavailable(*md), 1)), md)
+}
+
+// getMachineForInPlaceUpdateCheck returns a Machine of the MachineSet which is not being deleted.
+func (r *Reconciler) getMachineForInPlaceUpdateCheck(ctx context.Context, ms *clusterv1.MachineSet) (*clusterv1.Machine, error) {
+	machines, err := r.getMachinesForMachineSet(ctx, ms)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range machines {
+		if m.DeletionTimestamp.IsZero() {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// getMachinesForMachineSet returns the Machines controlled by the MachineSet, sorted by name.
+func (r *Reconciler) getMachinesForMachineSet(ctx context.Context, ms *clusterv1.MachineSet) ([]*clusterv1.Machine, error) {
+	selectorMap, err := metav1.LabelSelectorAsMap(&ms.Spec.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert MachineSet %s label selector to a map", klog.KObj(ms))
+	}
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machineList, client.InNamespace(ms.Namespace), client.MatchingLabels(selectorMap)); err != nil {
+		return nil, errors.Wrap(err, "failed to list Machines")
+	}
+
+	machines := []*clusterv1.Machine{}
+	for i := range machineList.Items {
+		m := &machineList.Items[i]
+		if !metav1.IsControlledBy(m, ms) {
+			continue
+		}
+		machines = append(machines, m)
+	}
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].Name < machines[j].Name
+	})
+	return machines, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+func TestReplaceMachinesNotUpdatableInPlace(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.InPlaceUpdates, true)
+
+	tests := []struct {
+		name                string
+		maxSurge            int32
+		maxUnavailable      int32
+		msReplicas          int32
+		availableReplicas   int32
+		markedMachines      int
+		deletingMachines    int
+		wantReplacing       bool
+		wantMachineSetScale int32
+	}{
+		{
+			name:                "no Machines to replace",
+			maxSurge:            1,
+			msReplicas:          3,
+			availableReplicas:   3,
+			wantReplacing:       false,
+			wantMachineSetScale: 3,
+		},
+		{
+			name:                "scale up respecting maxSurge",
+			maxSurge:            1,
+			msReplicas:          3,
+			availableReplicas:   3,
+			markedMachines:      2,
+			wantReplacing:       true,
+			wantMachineSetScale: 4,
+		},
+		{
+			name:                "wait for new Machines to be available before scaling down",
+			maxSurge:            1,
+			msReplicas:          4,
+			availableReplicas:   3,
+			markedMachines:      2,
+			wantReplacing:       true,
+			wantMachineSetScale: 4,
+		},
+		{
+			name:                "scale down when new Machines are available",
+			maxSurge:            1,
+			msReplicas:          4,
+			availableReplicas:   4,
+			markedMachines:      2,
+			wantReplacing:       true,
+			wantMachineSetScale: 3,
+		},
+		{
+			name:                "wait for marked Machines being deleted",
+			maxSurge:            1,
+			msReplicas:          3,
+			availableReplicas:   3,
+			markedMachines:      1,
+			deletingMachines:    1,
+			wantReplacing:       true,
+			wantMachineSetScale: 3,
+		},
+		{
+			name:                "scale down respecting maxUnavailable when maxSurge is 0",
+			maxUnavailable:      1,
+			msReplicas:          3,
+			availableReplicas:   3,
+			markedMachines:      2,
+			wantReplacing:       true,
+			wantMachineSetScale: 2,
+		},
+		{
+			name:                "scale up after marked Machines are gone when maxSurge is 0",
+			maxUnavailable:      1,
+			msReplicas:          2,
+			availableReplicas:   2,
+			markedMachines:      1,
+			wantReplacing:       true,
+			wantMachineSetScale: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "md",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: ptr.To[int32](3),
+					Strategy: &clusterv1.MachineDeploymentStrategy{
+						Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+						RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+							MaxSurge:       ptr.To(intstr.FromInt32(tt.maxSurge)),
+							MaxUnavailable: ptr.To(intstr.FromInt32(tt.maxUnavailable)),
+						},
+					},
+				},
+			}
+			ms := &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ms",
+					Namespace: metav1.NamespaceDefault,
+					UID:       "ms-uid",
+				},
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: ptr.To(tt.msReplicas),
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"md": "md"}},
+				},
+				Status: clusterv1.MachineSetStatus{
+					AvailableReplicas: ptr.To(tt.availableReplicas),
+				},
+			}
+
+			objs := []client.Object{md, ms}
+			for i := range tt.markedMachines + tt.deletingMachines {
+				machine := &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            fmt.Sprintf("machine-%d", i),
+						Namespace:       metav1.NamespaceDefault,
+						Labels:          map[string]string{"md": "md"},
+						Annotations:     map[string]string{clusterv1.UpdateNotPossibleAnnotation: ""},
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ms, clusterv1.GroupVersion.WithKind("MachineSet"))},
+					},
+				}
+				if i >= tt.markedMachines {
+					machine.DeletionTimestamp = ptr.To(metav1.Now())
+					machine.Finalizers = []string{"test"}
+				}
+				objs = append(objs, machine)
+			}
+
+			r := &Reconciler{
+				Client:   fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(objs...).Build(),
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			replacing, err := r.replaceMachinesNotUpdatableInPlace(ctx, md, nil, ms)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(replacing).To(Equal(tt.wantReplacing))
+
+			gotMS := &clusterv1.MachineSet{}
+			g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(ms), gotMS)).To(Succeed())
+			g.Expect(*gotMS.Spec.Replicas).To(Equal(tt.wantMachineSetScale))
+		})
+	}
+}
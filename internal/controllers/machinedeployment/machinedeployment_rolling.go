@@ -47,6 +47,15 @@ func (r *Reconciler) rolloutRolling(ctx context.Context, md *clusterv1.MachineDe
 
 	allMSs := append(oldMSs, newMS)
 
+	// Replace Machines which cannot be updated in-place, if any.
+	replacing, err := r.replaceMachinesNotUpdatableInPlace(ctx, md, oldMSs, newMS)
+	if err != nil {
+		return err
+	}
+	if replacing {
+		return r.syncDeploymentStatus(allMSs, newMS, md)
+	}
+
 	// Scale up, if we can.
 	if err := r.reconcileNewMachineSet(ctx, allMSs, newMS, md); err != nil {
 		return err
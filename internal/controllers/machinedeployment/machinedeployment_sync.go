@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
 	"sigs.k8s.io/cluster-api/internal/util/hash"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
@@ -89,6 +90,17 @@ func (r *Reconciler) getAllMachineSetsAndSyncRevision(ctx context.Context, md *c
 		return nil, nil, err
 	}
 
+	// If an old MachineSet has been updated in-place to become the new MachineSet, drop it from the list of old MachineSets.
+	if newMS != nil {
+		oldMSs := make([]*clusterv1.MachineSet, 0, len(allOldMSs))
+		for _, ms := range allOldMSs {
+			if ms.UID != newMS.UID {
+				oldMSs = append(oldMSs, ms)
+			}
+		}
+		allOldMSs = oldMSs
+	}
+
 	return newMS, allOldMSs, nil
 }
 
@@ -129,6 +141,18 @@ func (r *Reconciler) getNewMachineSet(ctx context.Context, md *clusterv1.Machine
 		return nil, errors.New("cannot create a new MachineSet when templates do not exist")
 	}
 
+	// If possible, update the existing MachineSet and its Machines in-place instead of creating a new MachineSet.
+	if feature.Gates.Enabled(feature.InPlaceUpdates) {
+		updatedMS, err := r.updateMachineSetInPlace(ctx, md, oldMSs, reconciliationTime)
+		if err != nil {
+			return nil, err
+		}
+		if updatedMS != nil {
+			mdutil.SetDeploymentRevision(md, updatedMS.Annotations[clusterv1.RevisionAnnotation])
+			return updatedMS, nil
+		}
+	}
+
 	// Create a new MachineSet and wait until the new MachineSet exists in the cache.
 	newMS, err := r.createMachineSetAndWait(ctx, md, oldMSs, createReason)
 	if err != nil {
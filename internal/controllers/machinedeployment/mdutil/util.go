@@ -19,6 +19,7 @@ package mdutil
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
@@ -146,12 +147,82 @@ func Revision(obj runtime.Object) (int64, error) {
 	return strconv.ParseInt(v, 10, 64)
 }
 
+// maxInPlaceUpdateRevisions is the maximum number of revisions recorded on a MachineSet updated in-place.
+const maxInPlaceUpdateRevisions = 10
+
+// InPlaceUpdateRevision is a revision served by a MachineSet before it has been updated in-place.
+// Only the fields which can be updated in-place are recorded, all the other fields of the Machine
+// template are the same as in the current template of the MachineSet.
+type InPlaceUpdateRevision struct {
+	Revision          int64                                      `json:"revision"`
+	Version           *string                                    `json:"version,omitempty"`
+	Bootstrap         clusterv1.Bootstrap                        `json:"bootstrap"`
+	InfrastructureRef clusterv1.ContractVersionedObjectReference `json:"infrastructureRef"`
+}
+
+// MachineTemplate returns the Machine template of the revision, computed from the current template of the MachineSet.
+func (r InPlaceUpdateRevision) MachineTemplate(ms *clusterv1.MachineSet) *clusterv1.MachineTemplateSpec {
+	template := ms.Spec.Template.DeepCopy()
+	template.Spec.Version = nil
+	if r.Version != nil {
+		template.Spec.Version = ptr.To(*r.Version)
+	}
+	template.Spec.Bootstrap = *r.Bootstrap.DeepCopy()
+	template.Spec.InfrastructureRef = r.InfrastructureRef
+	return template
+}
+
+// InPlaceUpdateRevisions returns the revisions served by a MachineSet before it has been updated in-place, sorted by revision.
+func InPlaceUpdateRevisions(ms *clusterv1.MachineSet) ([]InPlaceUpdateRevision, error) {
+	v, ok := ms.Annotations[clusterv1.InPlaceUpdateRevisionsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	revisions := []InPlaceUpdateRevision{}
+	if err := json.Unmarshal([]byte(v), &revisions); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse annotation %s on MachineSet %s", clusterv1.InPlaceUpdateRevisionsAnnotation, klog.KObj(ms))
+	}
+	return revisions, nil
+}
+
+// AddInPlaceUpdateRevision records the current revision and Machine template of a MachineSet which is going to be updated in-place
+// into the annotations of the updated MachineSet; only the latest maxInPlaceUpdateRevisions revisions are kept.
+func AddInPlaceUpdateRevision(ms, updatedMS *clusterv1.MachineSet) error {
+	revision, err := Revision(ms)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse revision of MachineSet %s", klog.KObj(ms))
+	}
+	revisions, err := InPlaceUpdateRevisions(ms)
+	if err != nil {
+		return err
+	}
+	revisions = append(revisions, InPlaceUpdateRevision{
+		Revision:          revision,
+		Version:           ms.Spec.Template.Spec.Version,
+		Bootstrap:         *ms.Spec.Template.Spec.Bootstrap.DeepCopy(),
+		InfrastructureRef: ms.Spec.Template.Spec.InfrastructureRef,
+	})
+	if len(revisions) > maxInPlaceUpdateRevisions {
+		revisions = revisions[len(revisions)-maxInPlaceUpdateRevisions:]
+	}
+	b, err := json.Marshal(revisions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal annotation %s for MachineSet %s", clusterv1.InPlaceUpdateRevisionsAnnotation, klog.KObj(ms))
+	}
+	if updatedMS.Annotations == nil {
+		updatedMS.Annotations = map[string]string{}
+	}
+	updatedMS.Annotations[clusterv1.InPlaceUpdateRevisionsAnnotation] = string(b)
+	return nil
+}
+
 var annotationsToSkip = map[string]bool{
-	corev1.LastAppliedConfigAnnotation:  true,
-	clusterv1.RevisionAnnotation:        true,
-	revisionHistoryAnnotation:           true,
-	clusterv1.DesiredReplicasAnnotation: true,
-	clusterv1.MaxReplicasAnnotation:     true,
+	corev1.LastAppliedConfigAnnotation:         true,
+	clusterv1.RevisionAnnotation:               true,
+	revisionHistoryAnnotation:                  true,
+	clusterv1.InPlaceUpdateRevisionsAnnotation: true,
+	clusterv1.DesiredReplicasAnnotation:        true,
+	clusterv1.MaxReplicasAnnotation:            true,
 
 	// Exclude the conversion annotation, to avoid infinite loops between the conversion webhook
 	// and the MachineDeployment controller syncing the annotations between a MachineDeployment
@@ -231,6 +302,11 @@ func ComputeMachineSetAnnotations(ctx context.Context, deployment *clusterv1.Mac
 			annotations[revisionHistoryAnnotation] = revisionHistory
 		}
 
+		// Ensure we preserve the revisions served by the MachineSet before in-place updates.
+		if inPlaceUpdateRevisions, ok := newMS.Annotations[clusterv1.InPlaceUpdateRevisionsAnnotation]; ok {
+			annotations[clusterv1.InPlaceUpdateRevisionsAnnotation] = inPlaceUpdateRevisions
+		}
+
 		// If the revision changes then add the old revision to the revision history annotation
 		if currentRevisionExists && currentRevision != newRevision {
 			oldRevisions := strings.Split(revisionHistory, ",")
@@ -1049,6 +1049,24 @@ func TestComputeMachineSetAnnotations(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "Calculating annotations for a existing MachineSet - ms has been updated in-place",
+			deployment: &deployment,
+			oldMSs:     nil,
+			ms: func() *clusterv1.MachineSet {
+				ms := machineSetWithRevisionAndHistory("2", "")
+				ms.Annotations[clusterv1.InPlaceUpdateRevisionsAnnotation] = `[{"revision":1}]`
+				return ms
+			}(),
+			want: map[string]string{
+				"key1":                       "value1",
+				clusterv1.RevisionAnnotation: "2",
+				clusterv1.InPlaceUpdateRevisionsAnnotation: `[{"revision":1}]`,
+				clusterv1.DesiredReplicasAnnotation:        "3",
+				clusterv1.MaxReplicasAnnotation:            "4",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1079,6 +1097,46 @@ func machineSetWithRevisionAndHistory(revision string, revisionHistory string) *
 	return ms
 }
 
+func TestAddInPlaceUpdateRevision(t *testing.T) {
+	g := NewWithT(t)
+
+	ms := machineSetWithRevisionAndHistory("1", "")
+	ms.Spec.Template.Spec = clusterv1.MachineSpec{
+		ClusterName: "test",
+		Version:     ptr.To("v1.30.0"),
+		Bootstrap: clusterv1.Bootstrap{
+			ConfigRef: &clusterv1.ContractVersionedObjectReference{Kind: "KubeadmConfigTemplate", Name: "bootstrap-1"},
+		},
+		InfrastructureRef: clusterv1.ContractVersionedObjectReference{Kind: "DockerMachineTemplate", Name: "infra-1"},
+	}
+	previousTemplate := ms.Spec.Template.DeepCopy()
+
+	// Update the MachineSet in-place many times, the oldest revisions are dropped.
+	for i := 2; i <= maxInPlaceUpdateRevisions+2; i++ {
+		updatedMS := ms.DeepCopy()
+		updatedMS.Annotations[clusterv1.RevisionAnnotation] = fmt.Sprintf("%d", i)
+		updatedMS.Spec.Template.Spec.Version = ptr.To(fmt.Sprintf("v1.30.%d", i))
+		updatedMS.Spec.Template.Spec.Bootstrap.ConfigRef.Name = fmt.Sprintf("bootstrap-%d", i)
+		updatedMS.Spec.Template.Spec.InfrastructureRef.Name = fmt.Sprintf("infra-%d", i)
+		g.Expect(AddInPlaceUpdateRevision(ms, updatedMS)).To(Succeed())
+		ms = updatedMS
+	}
+
+	revisions, err := InPlaceUpdateRevisions(ms)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(revisions).To(HaveLen(maxInPlaceUpdateRevisions))
+	g.Expect(revisions[0].Revision).To(Equal(int64(2)))
+	g.Expect(revisions[maxInPlaceUpdateRevisions-1].Revision).To(Equal(int64(maxInPlaceUpdateRevisions + 1)))
+
+	// The Machine template of a revision is computed from the current template of the MachineSet.
+	ms.Spec.Template.Spec.MinReadySeconds = ptr.To[int32](10)
+	previousTemplate.Spec.Version = ptr.To("v1.30.2")
+	previousTemplate.Spec.Bootstrap.ConfigRef.Name = "bootstrap-2"
+	previousTemplate.Spec.InfrastructureRef.Name = "infra-2"
+	previousTemplate.Spec.MinReadySeconds = ptr.To[int32](10)
+	g.Expect(revisions[0].MachineTemplate(ms)).To(BeComparableTo(previousTemplate))
+}
+
 func TestReplicasAnnotationsNeedUpdate(t *testing.T) {
 	desiredReplicas := fmt.Sprintf("%d", int32(10))
 	maxReplicas := fmt.Sprintf("%d", int32(20))
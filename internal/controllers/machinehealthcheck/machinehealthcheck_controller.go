@@ -64,6 +64,9 @@ const (
 	// EventRemediationRestricted is emitted in case when machine remediation
 	// is restricted by remediation circuit shorting logic.
 	EventRemediationRestricted string = "RemediationRestricted"
+	// EventRemediationDeferred is emitted in case the remediation of a machine
+	// is deferred by the remediation rate limit.
+	EventRemediationDeferred string = "RemediationDeferred"
 
 	maxUnhealthyKeyLog     = "maxUnhealthy"
 	unhealthyTargetsKeyLog = "unhealthyTargets"
@@ -241,6 +244,9 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 	healthy, unhealthy, nextCheckTimes := r.healthCheckTargets(targets, logger, metav1.Duration{Duration: time.Duration(*nodeStartupTimeout) * time.Second})
 	m.Status.CurrentHealthy = int32(len(healthy))
 
+	// drop remediations which are not relevant anymore for the remediation rate limit
+	pruneRemediations(m, time.Now())
+
 	// check MHC current health against MaxUnhealthy
 	remediationAllowed, remediationCount, err := isAllowedRemediation(m)
 	if err != nil {
@@ -350,9 +356,30 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 		Reason: clusterv1.MachineHealthCheckRemediationAllowedReason,
 	})
 
-	errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
+	deferred, errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
 	errList = append(errList, r.patchHealthyTargets(ctx, logger, healthy, m)...)
 
+	if len(deferred) > 0 {
+		message := fmt.Sprintf("Remediation of %d unhealthy machines is deferred by remediationRateLimit", len(deferred))
+		v1beta1conditions.Set(m, &clusterv1.Condition{
+			Type:     clusterv1.RemediationAllowedV1Beta1Condition,
+			Status:   corev1.ConditionFalse,
+			Severity: clusterv1.ConditionSeverityWarning,
+			Reason:   clusterv1.RemediationRateLimitedV1Beta1Reason,
+			Message:  message,
+		})
+
+		conditions.Set(m, metav1.Condition{
+			Type:    clusterv1.MachineHealthCheckRemediationAllowedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineHealthCheckRemediationRateLimitedReason,
+			Message: message,
+		})
+
+		// Requeue when the remediation of the first deferred machine is allowed.
+		nextCheckTimes = append(nextCheckTimes, deferred...)
+	}
+
 	// handle update errors
 	if len(errList) > 0 {
 		logger.V(3).Info("Error(s) marking machine, requeuing")
@@ -413,13 +440,40 @@ func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger
 }
 
 // patchUnhealthyTargets patches machines with MachineOwnerRemediatedCondition for remediation.
-func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logger, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) []error {
+// It returns for each machine whose remediation is deferred by the remediation rate limit
+// the duration after which the remediation is allowed.
+func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logger, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) ([]time.Duration, []error) {
 	// mark for remediation
 	errList := []error{}
+	deferred := []time.Duration{}
 	for _, t := range unhealthy {
 		logger := logger.WithValues("Machine", klog.KObj(t.Machine), "Node", klog.KObj(t.Node))
 		condition := conditions.Get(t.Machine, clusterv1.MachineHealthCheckSucceededCondition)
 
+		// deferRemediation checks if the remediation of the machine has to be deferred because of the remediation rate limit.
+		// Note: the remediation is recorded only after it has been successfully triggered, so failed attempts
+		// do not count against the remediation rate limit.
+		deferRemediation := func() bool {
+			delay, message := remediationDelay(m, remediationOwner(t.Machine), time.Now())
+			if delay > 0 {
+				logger.Info("Machine has failed health check, but remediation is deferred by remediationRateLimit", "reason", condition.Reason, "message", condition.Message, "deferredBy", delay.Truncate(time.Second).String())
+				r.recorder.Eventf(
+					t.Machine,
+					corev1.EventTypeWarning,
+					EventRemediationDeferred,
+					"Remediation of Machine %s is deferred by %s for %s: %s",
+					klog.KObj(t.Machine),
+					klog.KObj(t.MHC),
+					delay.Truncate(time.Second).String(),
+					message,
+				)
+				deferred = append(deferred, delay)
+				return true
+			}
+			return false
+		}
+		remediationTriggered := false
+
 		if annotations.IsPaused(cluster, t.Machine) {
 			logger.Info("Machine has failed health check, but machine is paused so skipping remediation", "reason", condition.Reason, "message", condition.Message)
 		} else {
@@ -427,7 +481,14 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 				// If external remediation request already exists,
 				// return early
 				if r.externalRemediationRequestExists(ctx, m, t.Machine.Name) {
-					return errList
+					return deferred, errList
+				}
+
+				if deferRemediation() {
+					if err := r.patchUnhealthyTarget(ctx, t); err != nil {
+						errList = append(errList, err)
+					}
+					continue
 				}
 
 				cloneOwnerRef := &metav1.OwnerReference{
@@ -448,7 +509,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 						Message: fmt.Sprintf("Error retrieving remediation template %s %s", m.Spec.RemediationTemplate.Kind, klog.KRef(m.Namespace, m.Spec.RemediationTemplate.Name)),
 					})
 					errList = append(errList, errors.Wrapf(err, "error retrieving remediation template %v %q for machine %q in namespace %q within cluster %q", m.Spec.RemediationTemplate.GroupVersionKind(), m.Spec.RemediationTemplate.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName))
-					return errList
+					return deferred, errList
 				}
 
 				generateTemplateInput := &external.GenerateTemplateInput{
@@ -461,7 +522,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 				to, err := external.GenerateTemplate(generateTemplateInput)
 				if err != nil {
 					errList = append(errList, errors.Wrapf(err, "failed to create template for remediation request %v %q for machine %q in namespace %q within cluster %q", m.Spec.RemediationTemplate.GroupVersionKind(), m.Spec.RemediationTemplate.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName))
-					return errList
+					return deferred, errList
 				}
 
 				// Set the Remediation Request to match the Machine name, the name is used to
@@ -484,8 +545,9 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 						Message: "Please check controller logs for errors",
 					})
 					errList = append(errList, errors.Wrapf(err, "error creating remediation request for machine %q in namespace %q within cluster %q", t.Machine.Name, t.Machine.Namespace, t.Machine.Spec.ClusterName))
-					return errList
+					return deferred, errList
 				}
+				recordRemediation(m, t.Machine, time.Now())
 
 				conditions.Set(t.Machine, metav1.Condition{
 					Type:   clusterv1.MachineExternallyRemediatedCondition,
@@ -493,6 +555,17 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 					Reason: clusterv1.MachineExternallyRemediatedWaitingForRemediationReason,
 				})
 			} else if t.Machine.DeletionTimestamp.IsZero() { // Only setting the OwnerRemediated conditions when machine is not already in deletion.
+				// Check the remediation rate limit only if a new remediation is triggered.
+				if ownerRemediatedCondition := conditions.Get(t.Machine, clusterv1.MachineOwnerRemediatedCondition); ownerRemediatedCondition == nil || ownerRemediatedCondition.Status == metav1.ConditionTrue {
+					if deferRemediation() {
+						if err := r.patchUnhealthyTarget(ctx, t); err != nil {
+							errList = append(errList, err)
+						}
+						continue
+					}
+					remediationTriggered = true
+				}
+
 				logger.Info("Machine has failed health check, marking for remediation", "reason", condition.Reason, "message", condition.Message)
 				// NOTE: MHC is responsible for creating MachineOwnerRemediatedCondition if missing or to trigger another remediation if the previous one is completed;
 				// instead, if a remediation is in already progress, the remediation owner is responsible for completing the process and MHC should not overwrite the condition.
@@ -511,21 +584,13 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 			}
 		}
 
-		patchOpts := []patch.Option{
-			patch.WithOwnedV1Beta1Conditions{Conditions: []clusterv1.ConditionType{
-				clusterv1.MachineHealthCheckSucceededV1Beta1Condition,
-				// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
-			}},
-			patch.WithOwnedConditions{Conditions: []string{
-				clusterv1.MachineHealthCheckSucceededCondition,
-				// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
-				// (Same for ExternallyRemediated condition)
-			}},
-		}
-		if err := t.patchHelper.Patch(ctx, t.Machine, patchOpts...); err != nil {
-			errList = append(errList, errors.Wrapf(err, "failed to patch unhealthy machine status for machine: %s/%s", t.Machine.Namespace, t.Machine.Name))
+		if err := r.patchUnhealthyTarget(ctx, t); err != nil {
+			errList = append(errList, err)
 			continue
 		}
+		if remediationTriggered {
+			recordRemediation(m, t.Machine, time.Now())
+		}
 		r.recorder.Eventf(
 			t.Machine,
 			corev1.EventTypeNormal,
@@ -535,7 +600,26 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 			klog.KObj(t.MHC),
 		)
 	}
-	return errList
+	return deferred, errList
+}
+
+// patchUnhealthyTarget patches the conditions of an unhealthy machine.
+func (r *Reconciler) patchUnhealthyTarget(ctx context.Context, t healthCheckTarget) error {
+	patchOpts := []patch.Option{
+		patch.WithOwnedV1Beta1Conditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.MachineHealthCheckSucceededV1Beta1Condition,
+			// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
+		}},
+		patch.WithOwnedConditions{Conditions: []string{
+			clusterv1.MachineHealthCheckSucceededCondition,
+			// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
+			// (Same for ExternallyRemediated condition)
+		}},
+	}
+	if err := t.patchHelper.Patch(ctx, t.Machine, patchOpts...); err != nil {
+		return errors.Wrapf(err, "failed to patch unhealthy machine status for machine: %s/%s", t.Machine.Namespace, t.Machine.Name)
+	}
+	return nil
 }
 
 // clusterToMachineHealthCheck maps events from Cluster objects to
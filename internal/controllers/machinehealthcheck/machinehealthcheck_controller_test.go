@@ -2821,7 +2821,8 @@ func TestPatchTargets(t *testing.T) {
 	}
 
 	// Target with wrong patch helper will fail but the other one will be patched.
-	g.Expect(r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target1, target3}, defaultCluster, mhc)).ToNot(BeEmpty())
+	_, errList := r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target1, target3}, defaultCluster, mhc)
+	g.Expect(errList).ToNot(BeEmpty())
 	g.Expect(cl.Get(ctx, client.ObjectKey{Name: machine2.Name, Namespace: machine2.Namespace}, machine2)).ToNot(HaveOccurred())
 	g.Expect(v1beta1conditions.Get(machine2, clusterv1.MachineOwnerRemediatedV1Beta1Condition).Status).To(Equal(corev1.ConditionFalse))
 	g.Expect(conditions.Get(machine2, clusterv1.MachineOwnerRemediatedCondition).Status).To(Equal(metav1.ConditionFalse))
@@ -2829,3 +2830,150 @@ func TestPatchTargets(t *testing.T) {
 	// Target with wrong patch helper will fail but the other one will be patched.
 	g.Expect(r.patchHealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target1, target3}, mhc)).ToNot(BeEmpty())
 }
+
+func TestPatchUnhealthyTargetsWithRemediationRateLimit(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	clusterName := testClusterName
+	defaultCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+		},
+	}
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, clusterName, labels)
+	mhc.Spec.RemediationRateLimit = &clusterv1.MachineHealthCheckRemediationRateLimit{
+		MaxRemediations: 1,
+		WindowSeconds:   3600,
+	}
+
+	machine1 := newTestMachine("machine1", namespace, clusterName, "nodeName", labels)
+	conditions.Set(machine1, metav1.Condition{
+		Type:   clusterv1.MachineHealthCheckSucceededCondition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineHealthCheckUnhealthyNodeReason,
+	})
+	machine2 := machine1.DeepCopy()
+	machine2.Name = "machine2"
+
+	cl := fake.NewClientBuilder().WithObjects(
+		machine1,
+		machine2,
+		mhc,
+	).WithStatusSubresource(&clusterv1.MachineHealthCheck{}, &clusterv1.Machine{}).Build()
+	r := &Reconciler{
+		Client:   cl,
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	targets := []healthCheckTarget{}
+	for _, machine := range []*clusterv1.Machine{machine1, machine2} {
+		patchHelper, err := patch.NewHelper(machine, cl)
+		g.Expect(err).ToNot(HaveOccurred())
+		targets = append(targets, healthCheckTarget{
+			MHC:         mhc,
+			Machine:     machine,
+			patchHelper: patchHelper,
+			Node:        &corev1.Node{},
+		})
+	}
+
+	// The first machine is remediated, while the remediation of the second machine is deferred.
+	deferred, errList := r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), targets, defaultCluster, mhc)
+	g.Expect(errList).To(BeEmpty())
+	g.Expect(deferred).To(HaveLen(1))
+	g.Expect(deferred[0]).To(BeNumerically("~", time.Hour, time.Minute))
+	g.Expect(mhc.Status.Remediations).To(HaveLen(1))
+	g.Expect(mhc.Status.Remediations[0].MachineName).To(Equal(machine1.Name))
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(machine1), machine1)).To(Succeed())
+	g.Expect(conditions.Get(machine1, clusterv1.MachineOwnerRemediatedCondition)).ToNot(BeNil())
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(machine2), machine2)).To(Succeed())
+	g.Expect(conditions.Get(machine2, clusterv1.MachineOwnerRemediatedCondition)).To(BeNil())
+
+	// Remediations in progress do not count against the rate limit.
+	patchHelper, err := patch.NewHelper(machine1, cl)
+	g.Expect(err).ToNot(HaveOccurred())
+	target := healthCheckTarget{
+		MHC:         mhc,
+		Machine:     machine1,
+		patchHelper: patchHelper,
+		Node:        &corev1.Node{},
+	}
+	deferred, errList = r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target}, defaultCluster, mhc)
+	g.Expect(errList).To(BeEmpty())
+	g.Expect(deferred).To(BeEmpty())
+	g.Expect(mhc.Status.Remediations).To(HaveLen(1))
+}
+
+func TestPatchUnhealthyTargetsWithRemediationRateLimitPatchFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	clusterName := testClusterName
+	defaultCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+		},
+	}
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, clusterName, labels)
+	mhc.Spec.RemediationRateLimit = &clusterv1.MachineHealthCheckRemediationRateLimit{
+		MaxRemediations: 1,
+		WindowSeconds:   3600,
+	}
+
+	machine := newTestMachine("machine1", namespace, clusterName, "nodeName", labels)
+	conditions.Set(machine, metav1.Condition{
+		Type:   clusterv1.MachineHealthCheckSucceededCondition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineHealthCheckUnhealthyNodeReason,
+	})
+
+	cl := fake.NewClientBuilder().WithObjects(
+		machine,
+		mhc,
+	).WithStatusSubresource(&clusterv1.MachineHealthCheck{}, &clusterv1.Machine{}).Build()
+	r := &Reconciler{
+		Client:   cl,
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	// To make the patch fail, create patchHelper with a different client.
+	fakeMachine := machine.DeepCopy()
+	fakeMachine.Name = "fake"
+	patchHelper, err := patch.NewHelper(fakeMachine, fake.NewClientBuilder().WithObjects(fakeMachine).Build())
+	g.Expect(err).ToNot(HaveOccurred())
+	target := healthCheckTarget{
+		MHC:         mhc,
+		Machine:     machine.DeepCopy(),
+		patchHelper: patchHelper,
+		Node:        &corev1.Node{},
+	}
+
+	// Failing to mark the machine for remediation does not count against the rate limit.
+	deferred, errList := r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target}, defaultCluster, mhc)
+	g.Expect(errList).ToNot(BeEmpty())
+	g.Expect(deferred).To(BeEmpty())
+	g.Expect(mhc.Status.Remediations).To(BeEmpty())
+
+	// The next attempt is not deferred and records the remediation.
+	patchHelper, err = patch.NewHelper(machine, cl)
+	g.Expect(err).ToNot(HaveOccurred())
+	target = healthCheckTarget{
+		MHC:         mhc,
+		Machine:     machine,
+		patchHelper: patchHelper,
+		Node:        &corev1.Node{},
+	}
+	deferred, errList = r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target}, defaultCluster, mhc)
+	g.Expect(errList).To(BeEmpty())
+	g.Expect(deferred).To(BeEmpty())
+	g.Expect(mhc.Status.Remediations).To(HaveLen(1))
+	g.Expect(mhc.Status.Remediations[0].MachineName).To(Equal(machine.Name))
+}
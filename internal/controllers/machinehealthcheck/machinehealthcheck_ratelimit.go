@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// pruneRemediations removes the remediations which are not relevant anymore for the remediationRateLimit
+// from the status of the MachineHealthCheck.
+func pruneRemediations(m *clusterv1.MachineHealthCheck, now time.Time) {
+	if m.Spec.RemediationRateLimit == nil {
+		m.Status.Remediations = nil
+		return
+	}
+
+	window := time.Duration(m.Spec.RemediationRateLimit.WindowSeconds) * time.Second
+	remediations := []clusterv1.MachineHealthCheckRemediation{}
+	for _, remediation := range m.Status.Remediations {
+		if remediation.Time.Add(window).After(now) {
+			remediations = append(remediations, remediation)
+		}
+	}
+	if len(remediations) == 0 {
+		remediations = nil
+	}
+	m.Status.Remediations = remediations
+}
+
+// remediationDelay returns how long the remediation of a machine with the given owner has to be deferred
+// according to the remediationRateLimit of the MachineHealthCheck, with a message explaining why.
+// A delay of 0 means the machine can be remediated now.
+// Note: remediations must be pruned with pruneRemediations before calling this func.
+func remediationDelay(m *clusterv1.MachineHealthCheck, owner string, now time.Time) (time.Duration, string) {
+	rateLimit := m.Spec.RemediationRateLimit
+	if rateLimit == nil {
+		return 0, ""
+	}
+	window := time.Duration(rateLimit.WindowSeconds) * time.Second
+
+	var delay time.Duration
+	var message string
+
+	// Defer remediation if maxRemediations machines have been remediated within the window.
+	// Remediations are recorded in chronological order, so the window moves as soon as the oldest
+	// of the last maxRemediations remediations exits from it.
+	if len(m.Status.Remediations) >= int(rateLimit.MaxRemediations) {
+		oldest := m.Status.Remediations[len(m.Status.Remediations)-int(rateLimit.MaxRemediations)]
+		if d := oldest.Time.Add(window).Sub(now); d > delay {
+			delay = d
+			message = fmt.Sprintf("%d machines have been remediated in the last %s (maxRemediations: %d)", len(m.Status.Remediations), window, rateLimit.MaxRemediations)
+		}
+	}
+
+	// Defer remediation if a machine with the same owner has been remediated recently.
+	if rateLimit.Backoff != nil && owner != "" {
+		var count int
+		var last *clusterv1.MachineHealthCheckRemediation
+		for i := range m.Status.Remediations {
+			if m.Status.Remediations[i].Owner == owner {
+				count++
+				last = &m.Status.Remediations[i]
+			}
+		}
+		if last != nil {
+			backoff := backoffDelay(rateLimit, count)
+			if d := last.Time.Add(backoff).Sub(now); d > delay {
+				delay = d
+				message = fmt.Sprintf("%d machines of %s have been remediated in the last %s (backoff: %s)", count, owner, window, backoff)
+			}
+		}
+	}
+
+	if delay <= 0 {
+		return 0, ""
+	}
+	return delay, message
+}
+
+// backoffDelay returns the minimum delay between the last remediation of a machine and the next one, after count
+// machines with the same owner have been remediated within the window.
+func backoffDelay(rateLimit *clusterv1.MachineHealthCheckRemediationRateLimit, count int) time.Duration {
+	maxDelay := time.Duration(ptr.Deref(rateLimit.Backoff.MaxDelaySeconds, rateLimit.WindowSeconds)) * time.Second
+	delay := time.Duration(rateLimit.Backoff.InitialDelaySeconds) * time.Second
+	for i := 1; i < count; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return min(delay, maxDelay)
+}
+
+// recordRemediation records the remediation of a machine in the status of the MachineHealthCheck.
+// Note: Remediations are only recorded if remediationRateLimit is set.
+func recordRemediation(m *clusterv1.MachineHealthCheck, machine *clusterv1.Machine, now time.Time) {
+	if m.Spec.RemediationRateLimit == nil {
+		return
+	}
+	m.Status.Remediations = append(m.Status.Remediations, clusterv1.MachineHealthCheckRemediation{
+		MachineName: machine.Name,
+		Owner:       remediationOwner(machine),
+		Time:        metav1.NewTime(now),
+	})
+}
+
+// remediationOwner returns the owner used to group the remediations of a machine, in the format <Kind>/<name>.
+// Machines which are part of a MachineDeployment are grouped by MachineDeployment, so remediations
+// are still grouped when a MachineDeployment is rolled out.
+func remediationOwner(machine *clusterv1.Machine) string {
+	if name, ok := machine.Labels[clusterv1.MachineDeploymentNameLabel]; ok && name != "" {
+		return fmt.Sprintf("MachineDeployment/%s", name)
+	}
+	if ref := metav1.GetControllerOf(machine); ref != nil {
+		return fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+	}
+	return ""
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestPruneRemediations(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name                 string
+		rateLimit            *clusterv1.MachineHealthCheckRemediationRateLimit
+		remediations         []clusterv1.MachineHealthCheckRemediation
+		expectedRemediations []clusterv1.MachineHealthCheckRemediation
+	}{
+		{
+			name: "remediations are dropped without rate limit",
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Time: metav1.NewTime(now.Add(-time.Minute))},
+			},
+			expectedRemediations: nil,
+		},
+		{
+			name:      "remediations outside of the window are dropped",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 1, WindowSeconds: 600},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Time: metav1.NewTime(now.Add(-20 * time.Minute))},
+				{MachineName: "m2", Time: metav1.NewTime(now.Add(-5 * time.Minute))},
+			},
+			expectedRemediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m2", Time: metav1.NewTime(now.Add(-5 * time.Minute))},
+			},
+		},
+		{
+			name:      "all remediations outside of the window",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 1, WindowSeconds: 600},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Time: metav1.NewTime(now.Add(-20 * time.Minute))},
+			},
+			expectedRemediations: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.MachineHealthCheck{
+				Spec:   clusterv1.MachineHealthCheckSpec{RemediationRateLimit: tt.rateLimit},
+				Status: clusterv1.MachineHealthCheckStatus{Remediations: tt.remediations},
+			}
+			pruneRemediations(m, now)
+			g.Expect(m.Status.Remediations).To(Equal(tt.expectedRemediations))
+		})
+	}
+}
+
+func TestRemediationDelay(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		rateLimit       *clusterv1.MachineHealthCheckRemediationRateLimit
+		remediations    []clusterv1.MachineHealthCheckRemediation
+		owner           string
+		expectedDelay   time.Duration
+		expectedMessage string
+	}{
+		{
+			name:          "no rate limit",
+			owner:         "MachineDeployment/md1",
+			expectedDelay: 0,
+		},
+		{
+			name:      "within maxRemediations",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 2, WindowSeconds: 600},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-5 * time.Minute))},
+			},
+			owner:         "MachineDeployment/md1",
+			expectedDelay: 0,
+		},
+		{
+			name:      "maxRemediations reached",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 2, WindowSeconds: 600},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-8 * time.Minute))},
+				{MachineName: "m2", Owner: "MachineDeployment/md2", Time: metav1.NewTime(now.Add(-5 * time.Minute))},
+				{MachineName: "m3", Owner: "MachineDeployment/md2", Time: metav1.NewTime(now.Add(-1 * time.Minute))},
+			},
+			owner:           "MachineDeployment/md1",
+			expectedDelay:   5 * time.Minute,
+			expectedMessage: "3 machines have been remediated in the last 10m0s (maxRemediations: 2)",
+		},
+		{
+			name: "backoff after the first remediation of the owner",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 10, WindowSeconds: 3600, Backoff: &clusterv1.MachineHealthCheckRemediationBackoff{
+				InitialDelaySeconds: 600,
+			}},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-4 * time.Minute))},
+				{MachineName: "m2", Owner: "MachineDeployment/md2", Time: metav1.NewTime(now.Add(-1 * time.Minute))},
+			},
+			owner:           "MachineDeployment/md1",
+			expectedDelay:   6 * time.Minute,
+			expectedMessage: "1 machines of MachineDeployment/md1 have been remediated in the last 1h0m0s (backoff: 10m0s)",
+		},
+		{
+			name: "backoff is doubled for every remediation of the owner",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 10, WindowSeconds: 3600, Backoff: &clusterv1.MachineHealthCheckRemediationBackoff{
+				InitialDelaySeconds: 300,
+			}},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-30 * time.Minute))},
+				{MachineName: "m2", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-20 * time.Minute))},
+				{MachineName: "m3", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-10 * time.Minute))},
+			},
+			owner:           "MachineDeployment/md1",
+			expectedDelay:   10 * time.Minute,
+			expectedMessage: "3 machines of MachineDeployment/md1 have been remediated in the last 1h0m0s (backoff: 20m0s)",
+		},
+		{
+			name: "backoff is capped by maxDelaySeconds",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 10, WindowSeconds: 3600, Backoff: &clusterv1.MachineHealthCheckRemediationBackoff{
+				InitialDelaySeconds: 300,
+				MaxDelaySeconds:     ptr.To[int32](900),
+			}},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-30 * time.Minute))},
+				{MachineName: "m2", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-20 * time.Minute))},
+				{MachineName: "m3", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-10 * time.Minute))},
+			},
+			owner:           "MachineDeployment/md1",
+			expectedDelay:   5 * time.Minute,
+			expectedMessage: "3 machines of MachineDeployment/md1 have been remediated in the last 1h0m0s (backoff: 15m0s)",
+		},
+		{
+			name: "backoff has expired",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 10, WindowSeconds: 3600, Backoff: &clusterv1.MachineHealthCheckRemediationBackoff{
+				InitialDelaySeconds: 300,
+			}},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Owner: "MachineDeployment/md1", Time: metav1.NewTime(now.Add(-10 * time.Minute))},
+			},
+			owner:         "MachineDeployment/md1",
+			expectedDelay: 0,
+		},
+		{
+			name: "no backoff for machines without owner",
+			rateLimit: &clusterv1.MachineHealthCheckRemediationRateLimit{MaxRemediations: 10, WindowSeconds: 3600, Backoff: &clusterv1.MachineHealthCheckRemediationBackoff{
+				InitialDelaySeconds: 300,
+			}},
+			remediations: []clusterv1.MachineHealthCheckRemediation{
+				{MachineName: "m1", Time: metav1.NewTime(now.Add(-1 * time.Minute))},
+			},
+			owner:         "",
+			expectedDelay: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.MachineHealthCheck{
+				Spec:   clusterv1.MachineHealthCheckSpec{RemediationRateLimit: tt.rateLimit},
+				Status: clusterv1.MachineHealthCheckStatus{Remediations: tt.remediations},
+			}
+			delay, message := remediationDelay(m, tt.owner, now)
+			g.Expect(delay).To(BeNumerically("~", tt.expectedDelay, time.Second))
+			g.Expect(message).To(Equal(tt.expectedMessage))
+		})
+	}
+}
+
+func TestRemediationOwner(t *testing.T) {
+	tests := []struct {
+		name          string
+		machine       *clusterv1.Machine
+		expectedOwner string
+	}{
+		{
+			name: "machine of a MachineDeployment",
+			machine: &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{clusterv1.MachineDeploymentNameLabel: "md1"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "MachineSet", Name: "md1-abcde", Controller: ptr.To(true)},
+				},
+			}},
+			expectedOwner: "MachineDeployment/md1",
+		},
+		{
+			name: "machine of a KubeadmControlPlane",
+			machine: &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "KubeadmControlPlane", Name: "cp1", Controller: ptr.To(true)},
+				},
+			}},
+			expectedOwner: "KubeadmControlPlane/cp1",
+		},
+		{
+			name:          "machine without owner",
+			machine:       &clusterv1.Machine{},
+			expectedOwner: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(remediationOwner(tt.machine)).To(Equal(tt.expectedOwner))
+		})
+	}
+}
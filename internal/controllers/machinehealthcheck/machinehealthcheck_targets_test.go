@@ -392,6 +392,58 @@ func TestHealthCheckTargets(t *testing.T) {
 	machineAnnotationRemediationCondition := newFailedHealthCheckV1Beta1Condition(clusterv1.HasRemediateMachineAnnotationV1Beta1Reason, annotationRemediationMsg)
 	machineAnnotationRemediationV1Beta2Condition := newFailedHealthCheckCondition(clusterv1.MachineHealthCheckHasRemediateAnnotationReason, annotationRemediationV1Beta2Msg)
 
+	// Create a test MHC with machine conditions
+	testMHCMachineConditions := testMHC.DeepCopy()
+	testMHCMachineConditions.Spec.UnhealthyMachineConditions = []clusterv1.UnhealthyMachineCondition{
+		{
+			Type:           clusterv1.MachineInfrastructureReadyCondition,
+			Status:         metav1.ConditionFalse,
+			TimeoutSeconds: timeoutForUnhealthyNodeConditions,
+		},
+	}
+
+	// Target for when the machine infrastructure has not been ready for shorter than the timeout, and the node has not yet started
+	testMachineInfraNotReady200 := testMachineCreated400s.DeepCopy()
+	conditions.Set(testMachineInfraNotReady200, metav1.Condition{Type: clusterv1.MachineInfrastructureReadyCondition, Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-200 * time.Second))})
+	machineInfraNotReady200 := healthCheckTarget{
+		Cluster: cluster,
+		MHC:     testMHCMachineConditions,
+		Machine: testMachineInfraNotReady200,
+		Node:    nil,
+	}
+
+	// Target for when the machine infrastructure has not been ready for longer than the timeout, and the node has not yet started
+	testMachineInfraNotReady400 := testMachineCreated400s.DeepCopy()
+	conditions.Set(testMachineInfraNotReady400, metav1.Condition{Type: clusterv1.MachineInfrastructureReadyCondition, Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-400 * time.Second))})
+	machineInfraNotReady400 := healthCheckTarget{
+		Cluster: cluster,
+		MHC:     testMHCMachineConditions,
+		Machine: testMachineInfraNotReady400,
+		Node:    nil,
+	}
+	machineInfraNotReady400Condition := newFailedHealthCheckV1Beta1Condition(clusterv1.UnhealthyMachineConditionV1Beta1Reason, "Condition InfrastructureReady on machine is reporting status False for more than %s", (time.Duration(timeoutForUnhealthyNodeConditions) * time.Second).String())
+	machineInfraNotReady400V1Beta2Condition := newFailedHealthCheckCondition(clusterv1.MachineHealthCheckUnhealthyMachineReason, "Health check failed: Condition InfrastructureReady on Machine is reporting status False for more than %s", (time.Duration(timeoutForUnhealthyNodeConditions) * time.Second).String())
+
+	// Target for when the machine infrastructure has not been ready for longer than the timeout, and the node is healthy
+	machineInfraNotReady400NodeHealthy := healthCheckTarget{
+		Cluster:     cluster,
+		MHC:         testMHCMachineConditions,
+		Machine:     testMachineInfraNotReady400.DeepCopy(),
+		Node:        testNodeHealthy,
+		nodeMissing: false,
+	}
+
+	// Target for when the machine infrastructure has been ready and the node is healthy
+	testMachineInfraReady := testMachine.DeepCopy()
+	conditions.Set(testMachineInfraReady, metav1.Condition{Type: clusterv1.MachineInfrastructureReadyCondition, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Now().Add(-400 * time.Second))})
+	machineInfraReadyNodeHealthy := healthCheckTarget{
+		Cluster:     cluster,
+		MHC:         testMHCMachineConditions,
+		Machine:     testMachineInfraReady,
+		Node:        testNodeHealthy,
+		nodeMissing: false,
+	}
+
 	testCases := []struct {
 		desc                                     string
 		targets                                  []healthCheckTarget
@@ -492,6 +544,38 @@ func TestHealthCheckTargets(t *testing.T) {
 			expectedNeedsRemediationV1Beta2Condition: []metav1.Condition{nodeGoneAwayV1Beta2Condition},
 			expectedNextCheckTimes:                   []time.Duration{},
 		},
+		{
+			desc:                     "when a machine condition has been unhealthy for shorter than the timeout and the node has not yet started",
+			targets:                  []healthCheckTarget{machineInfraNotReady200},
+			expectedHealthy:          []healthCheckTarget{},
+			expectedNeedsRemediation: []healthCheckTarget{},
+			expectedNextCheckTimes:   []time.Duration{100 * time.Second},
+		},
+		{
+			desc:                                     "when a machine condition has been unhealthy for longer than the timeout and the node has not yet started",
+			targets:                                  []healthCheckTarget{machineInfraNotReady400},
+			expectedHealthy:                          []healthCheckTarget{},
+			expectedNeedsRemediation:                 []healthCheckTarget{machineInfraNotReady400},
+			expectedNeedsRemediationCondition:        []clusterv1.Condition{machineInfraNotReady400Condition},
+			expectedNeedsRemediationV1Beta2Condition: []metav1.Condition{machineInfraNotReady400V1Beta2Condition},
+			expectedNextCheckTimes:                   []time.Duration{},
+		},
+		{
+			desc:                                     "when a machine condition has been unhealthy for longer than the timeout and the node is healthy",
+			targets:                                  []healthCheckTarget{machineInfraNotReady400NodeHealthy},
+			expectedHealthy:                          []healthCheckTarget{},
+			expectedNeedsRemediation:                 []healthCheckTarget{machineInfraNotReady400NodeHealthy},
+			expectedNeedsRemediationCondition:        []clusterv1.Condition{machineInfraNotReady400Condition},
+			expectedNeedsRemediationV1Beta2Condition: []metav1.Condition{machineInfraNotReady400V1Beta2Condition},
+			expectedNextCheckTimes:                   []time.Duration{},
+		},
+		{
+			desc:                     "when machine conditions and the node are healthy",
+			targets:                  []healthCheckTarget{machineInfraReadyNodeHealthy},
+			expectedHealthy:          []healthCheckTarget{machineInfraReadyNodeHealthy},
+			expectedNeedsRemediation: []healthCheckTarget{},
+			expectedNextCheckTimes:   []time.Duration{},
+		},
 		{
 			desc:                              "health check with empty unhealthy conditions and node",
 			targets:                           []healthCheckTarget{nodeEmptyConditions},
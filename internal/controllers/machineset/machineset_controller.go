@@ -48,6 +48,8 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
@@ -99,6 +101,9 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
+
 	ssaCache ssa.Cache
 	recorder record.EventRecorder
 }
@@ -107,6 +112,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	if r.Client == nil || r.APIReader == nil || r.ClusterCache == nil {
 		return errors.New("Client, APIReader and ClusterCache must not be nil")
 	}
+	if feature.Gates.Enabled(feature.InPlaceUpdates) && r.RuntimeClient == nil {
+		return errors.New("RuntimeClient must not be nil when the InPlaceUpdates feature gate is enabled")
+	}
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "machineset")
 	clusterToMachineSets, err := util.ClusterToTypedObjectsMapper(mgr.GetClient(), &clusterv1.MachineSetList{}, mgr.GetScheme())
@@ -259,6 +267,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 
 	reconcileNormal := append(alwaysReconcile,
 		wrapErrMachineSetReconcileFunc(r.reconcileUnhealthyMachines, "failed to reconcile unhealthy machines"),
+	)
+	if feature.Gates.Enabled(feature.InPlaceUpdates) {
+		reconcileNormal = append(reconcileNormal,
+			wrapErrMachineSetReconcileFunc(r.reconcileInPlaceUpdates, "failed to reconcile in-place updates"),
+		)
+	}
+	reconcileNormal = append(reconcileNormal,
 		wrapErrMachineSetReconcileFunc(r.syncMachines, "failed to sync Machines"),
 		wrapErrMachineSetReconcileFunc(r.syncReplicas, "failed to sync replicas"),
 	)
@@ -276,6 +291,10 @@ type scope struct {
 	owningMachineDeployment                   *clusterv1.MachineDeployment
 	scaleUpPreflightCheckErrMessages          []string
 	reconciliationTime                        time.Time
+
+	// machinesNotUpToDateInPlace contains, for Machines not yet updated in-place to the MachineSet template,
+	// a message describing the state of the in-place update.
+	machinesNotUpToDateInPlace map[string]string
 }
 
 type machineSetReconcileFunc func(ctx context.Context, s *scope) (ctrl.Result, error)
@@ -501,7 +520,7 @@ func (r *Reconciler) syncMachines(ctx context.Context, s *scope) (ctrl.Result, e
 	for i := range machines {
 		m := machines[i]
 
-		upToDateCondition := newMachineUpToDateCondition(s)
+		upToDateCondition := newMachineUpToDateCondition(s, m)
 
 		// If the machine is already being deleted, we only need to sync
 		// the subset of fields that impact tearing down a machine
@@ -606,7 +625,7 @@ func (r *Reconciler) syncMachines(ctx context.Context, s *scope) (ctrl.Result, e
 	return ctrl.Result{}, nil
 }
 
-func newMachineUpToDateCondition(s *scope) *metav1.Condition {
+func newMachineUpToDateCondition(s *scope, machine *clusterv1.Machine) *metav1.Condition {
 	// If the current MachineSet is a stand-alone MachineSet, the MachineSet controller does not set an up-to-date condition
 	// on Machines, allowing tools managing higher level abstractions to set this condition.
 	// This is also consistent with the fact that the MachineSet controller primarily takes care of the number of Machine
@@ -641,6 +660,13 @@ func newMachineUpToDateCondition(s *scope) *metav1.Condition {
 		}
 	}
 
+	// If the MachineSet template has been updated in-place by the MachineDeployment controller, Machines are up-to-date
+	// only after they have been updated in-place too.
+	if message, ok := s.machinesNotUpToDateInPlace[machine.Name]; ok {
+		upToDate = false
+		conditionMessages = append(conditionMessages, message)
+	}
+
 	if !upToDate {
 		for i := range conditionMessages {
 			conditionMessages[i] = fmt.Sprintf("* %s", conditionMessages[i])
@@ -942,6 +968,12 @@ func (r *Reconciler) computeDesiredMachine(machineSet *clusterv1.MachineSet, exi
 	// Set Annotations
 	desiredMachine.Annotations = machineAnnotationsFromMachineSet(machineSet)
 
+	// If in-place updates are enabled, the version of existing Machines of a MachineSet owned by a MachineDeployment
+	// is changed only as part of an in-place update.
+	if existingMachine != nil && feature.Gates.Enabled(feature.InPlaceUpdates) && isDeploymentChild(machineSet) {
+		desiredMachine.Spec.Version = existingMachine.Spec.Version
+	}
+
 	// Set all other in-place mutable fields.
 	desiredMachine.Spec.ReadinessGates = machineSet.Spec.Template.Spec.ReadinessGates
 	desiredMachine.Spec.NodeDrainTimeoutSeconds = machineSet.Spec.Template.Spec.NodeDrainTimeoutSeconds
@@ -453,7 +453,8 @@ func aggregateStaleMachines(machines []*clusterv1.Machine) string {
 				deletingCondition.Status == metav1.ConditionTrue &&
 				deletingCondition.Reason == clusterv1.MachineDeletingDrainingNodeReason &&
 				machine.Status.Deletion != nil && time.Since(machine.Status.Deletion.NodeDrainStartTime.Time) > 5*time.Minute {
-				if strings.Contains(deletingCondition.Message, "cannot evict pod as it would violate the pod's disruption budget.") {
+				if strings.Contains(deletingCondition.Message, "cannot evict pod as it would violate the pod's disruption budget.") ||
+					strings.Contains(deletingCondition.Message, "to complete evictions of Pods covered by PodDisruptionBudget") {
 					delayReasons.Insert("PodDisruptionBudgets")
 				}
 				if strings.Contains(deletingCondition.Message, "deletionTimestamp set, but still not removed from the Node") {
@@ -3029,7 +3029,7 @@ func TestNewMachineUpToDateCondition(t *testing.T) {
 				reconciliationTime:      reconciliationTime,
 			}
 
-			condition := newMachineUpToDateCondition(s)
+			condition := newMachineUpToDateCondition(s, &clusterv1.Machine{})
 			if tt.expectCondition != nil {
 				g.Expect(condition).ToNot(BeNil())
 				g.Expect(*condition).To(conditions.MatchCondition(*tt.expectCondition, conditions.IgnoreLastTransitionTime(true)))
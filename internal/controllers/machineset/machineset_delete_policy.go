@@ -50,6 +50,9 @@ func oldestDeletePriority(machine *clusterv1.Machine) deletePriority {
 	if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
 		return shouldDelete
 	}
+	if _, ok := machine.Annotations[clusterv1.UpdateNotPossibleAnnotation]; ok {
+		return shouldDelete
+	}
 	if !isMachineHealthy(machine) {
 		return betterDelete
 	}
@@ -70,6 +73,9 @@ func newestDeletePriority(machine *clusterv1.Machine) deletePriority {
 	if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
 		return shouldDelete
 	}
+	if _, ok := machine.Annotations[clusterv1.UpdateNotPossibleAnnotation]; ok {
+		return shouldDelete
+	}
 	if !isMachineHealthy(machine) {
 		return betterDelete
 	}
@@ -83,6 +89,9 @@ func randomDeletePolicy(machine *clusterv1.Machine) deletePriority {
 	if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
 		return shouldDelete
 	}
+	if _, ok := machine.Annotations[clusterv1.UpdateNotPossibleAnnotation]; ok {
+		return shouldDelete
+	}
 	if !isMachineHealthy(machine) {
 		return betterDelete
 	}
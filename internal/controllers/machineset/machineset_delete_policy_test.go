@@ -50,6 +50,10 @@ func TestMachineToDelete(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{clusterv1.DeleteMachineAnnotation: ""}},
 		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
 	}
+	updateNotPossibleMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{clusterv1.UpdateNotPossibleAnnotation: ""}},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
 	deleteMachineWithoutNodeRef := &clusterv1.Machine{}
 	nodeHealthyConditionFalseMachine := &clusterv1.Machine{
 		Status: clusterv1.MachineStatus{
@@ -213,6 +217,18 @@ func TestMachineToDelete(t *testing.T) {
 				deleteMachineWithMachineAnnotation,
 			},
 		},
+		{
+			desc: "func=randomDeletePolicy, UpdateNotPossibleAnnotation, diff=1",
+			diff: 1,
+			machines: []*clusterv1.Machine{
+				betterDeleteMachine,
+				updateNotPossibleMachine,
+				betterDeleteMachine,
+			},
+			expect: []*clusterv1.Machine{
+				updateNotPossibleMachine,
+			},
+		},
 		{
 			desc: "func=randomDeletePolicy, MachineWithNoNodeRef, diff=1",
 			diff: 1,
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/internal/util/inplace"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// machineUpdateNotPossibleMessage is the message reported for Machines waiting to be replaced by the
+// MachineDeployment rollout because they cannot be updated in-place.
+const machineUpdateNotPossibleMessage = "Waiting for replacement, the Machine cannot be updated in-place"
+
+// reconcileInPlaceUpdates brings Machines of a MachineSet owned by a MachineDeployment up-to-date with the MachineSet
+// template, which is changed by the MachineDeployment controller when it determines that a rollout can be performed in-place.
+// Machines are updated in-place if the Runtime Extensions implementing the CanUpdateMachine hook can apply all the changes,
+// otherwise they are marked with the UpdateNotPossibleAnnotation and they are replaced by the MachineDeployment rollout,
+// which respects maxSurge and maxUnavailable.
+// Note: The number of Machines being updated in-place at the same time is limited by the MachineDeployment's maxUnavailable.
+func (r *Reconciler) reconcileInPlaceUpdates(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	machineSet := s.machineSet
+
+	if s.owningMachineDeployment == nil || !s.getAndAdoptMachinesForMachineSetSucceeded {
+		return ctrl.Result{}, nil
+	}
+
+	// Sort machines to ensure stable results.
+	machines := make([]*clusterv1.Machine, len(s.machines))
+	copy(machines, s.machines)
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].Name < machines[j].Name
+	})
+
+	s.machinesNotUpToDateInPlace = map[string]string{}
+	inProgress := 0
+	outdated := []*inplace.MachineObjects{}
+	for _, m := range machines {
+		if !m.DeletionTimestamp.IsZero() {
+			inProgress++
+			continue
+		}
+		if inplace.IsUpdateStarted(m) {
+			inProgress++
+			s.machinesNotUpToDateInPlace[m.Name] = "In-place update in progress"
+			continue
+		}
+		if _, ok := m.Annotations[clusterv1.UpdateNotPossibleAnnotation]; ok {
+			s.machinesNotUpToDateInPlace[m.Name] = machineUpdateNotPossibleMessage
+			continue
+		}
+
+		current, err := inplace.GetMachineObjects(ctx, r.Client, m)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !inplace.IsUpdateInProgress(m) && inplace.IsUpToDateWithTemplate(current, &machineSet.Spec.Template.Spec) {
+			continue
+		}
+		s.machinesNotUpToDateInPlace[m.Name] = "Waiting for in-place update"
+		outdated = append(outdated, current)
+	}
+
+	maxInProgress := max(int(mdutil.MaxUnavailable(*s.owningMachineDeployment)), 1)
+	for _, current := range outdated {
+		// Always resume updates that have been started previously, no matter of maxUnavailable.
+		if inProgress >= maxInProgress && !inplace.IsUpdateInProgress(current.Machine) {
+			continue
+		}
+		inProgress++
+
+		machine := current.Machine
+		log := log.WithValues("Machine", klog.KObj(machine))
+
+		desired, err := inplace.ComputeDesiredMachineObjectsFromTemplate(ctx, r.Client, current, &machineSet.Spec.Template.Spec)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		canUpdate, message, err := inplace.CanUpdateMachine(ctx, r.RuntimeClient, current, desired)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !canUpdate {
+			// Leave the replacement of the Machine to the MachineDeployment rollout, so maxSurge and maxUnavailable are respected.
+			log.Info(fmt.Sprintf("Marking Machine for replacement because it cannot be updated in-place: %s", message))
+			patchHelper, err := patch.NewHelper(machine, r.Client)
+			if err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "failed to mark Machine %s for replacement", klog.KObj(machine))
+			}
+			if machine.Annotations == nil {
+				machine.Annotations = map[string]string{}
+			}
+			machine.Annotations[clusterv1.UpdateNotPossibleAnnotation] = ""
+			if err := patchHelper.Patch(ctx, machine); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "failed to mark Machine %s for replacement", klog.KObj(machine))
+			}
+			s.machinesNotUpToDateInPlace[machine.Name] = machineUpdateNotPossibleMessage
+			r.recorder.Eventf(machineSet, corev1.EventTypeNormal, "InPlaceUpdateNotPossible", "Machine %q cannot be updated in-place and will be replaced: %s", machine.Name, message)
+			continue
+		}
+
+		log.Info("Starting in-place update of Machine")
+		if err := inplace.StartUpdate(ctx, r.Client, current, desired); err != nil {
+			return ctrl.Result{}, err
+		}
+		s.machinesNotUpToDateInPlace[machine.Name] = "In-place update in progress"
+		r.recorder.Eventf(machineSet, corev1.EventTypeNormal, "InPlaceUpdateStarted", "Started in-place update of machine %q", machine.Name)
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/internal/util/inplace"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func TestReconcileInPlaceUpdates(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	canUpdateMachineGVH, err := catalog.GroupVersionHook(runtimehooksv1.CanUpdateMachine)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+
+	versionPatch := &runtimehooksv1.CanUpdateMachineResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		MachinePatch: runtimehooksv1.Patch{
+			PatchType: runtimehooksv1.JSONPatchType,
+			Patch:     []byte(`[{"op":"replace","path":"/spec/version","value":"v1.32.0"}]`),
+		},
+	}
+
+	infraTemplateGroupKind := fmt.Sprintf("%s.%s", builder.GenericInfrastructureMachineTemplateKind, builder.InfrastructureGroupVersion.Group)
+
+	tests := []struct {
+		name                  string
+		extensions            []string
+		wantUpdatedMachines   []string
+		wantMarkedMachines    []string
+		wantUntouchedMachines []string
+	}{
+		{
+			name:                  "update Machines in-place, respecting maxUnavailable",
+			extensions:            []string{"version"},
+			wantUpdatedMachines:   []string{"machine-1"},
+			wantUntouchedMachines: []string{"machine-2"},
+		},
+		{
+			name:                  "mark Machines which cannot be updated in-place for replacement by the MachineDeployment",
+			wantMarkedMachines:    []string{"machine-1"},
+			wantUntouchedMachines: []string{"machine-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "md",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: ptr.To[int32](2),
+					Strategy: &clusterv1.MachineDeploymentStrategy{
+						Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+						RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+							MaxSurge:       ptr.To(intstr.FromInt32(0)),
+							MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+						},
+					},
+				},
+			}
+			ms := &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ms",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSetSpec{
+					ClusterName: "cluster",
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							Version: ptr.To("v1.32.0"),
+							InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+								APIGroup: builder.InfrastructureGroupVersion.Group,
+								Kind:     builder.GenericInfrastructureMachineTemplateKind,
+								Name:     "infra-template",
+							},
+						},
+					},
+				},
+			}
+			infraTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra-template").Build()
+
+			objs := []client.Object{builder.GenericInfrastructureMachineCRD.DeepCopy(), builder.GenericInfrastructureMachineTemplateCRD.DeepCopy(), infraTemplate}
+			machines := []*clusterv1.Machine{}
+			for _, name := range []string{"machine-1", "machine-2"} {
+				infraMachine := builder.InfrastructureMachine(metav1.NamespaceDefault, name).Build()
+				infraMachine.SetAnnotations(map[string]string{
+					clusterv1.TemplateClonedFromNameAnnotation:      "infra-template",
+					clusterv1.TemplateClonedFromGroupKindAnnotation: infraTemplateGroupKind,
+				})
+				machine := &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: metav1.NamespaceDefault,
+					},
+					Spec: clusterv1.MachineSpec{
+						ClusterName: "cluster",
+						Version:     ptr.To("v1.31.0"),
+						InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+							APIGroup: builder.InfrastructureGroupVersion.Group,
+							Kind:     builder.GenericInfrastructureMachineKind,
+							Name:     name,
+						},
+					},
+				}
+				objs = append(objs, infraMachine, machine)
+				machines = append(machines, machine)
+			}
+
+			c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(objs...).Build()
+			r := &Reconciler{
+				Client: c,
+				RuntimeClient: fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithGetAllExtensionResponses(map[runtimecatalog.GroupVersionHook][]string{
+						canUpdateMachineGVH: tt.extensions,
+					}).
+					WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+						"version": versionPatch,
+					}).
+					Build(),
+				recorder: record.NewFakeRecorder(32),
+			}
+			s := &scope{
+				machineSet:              ms,
+				owningMachineDeployment: md,
+				machines:                machines,
+				getAndAdoptMachinesForMachineSetSucceeded: true,
+			}
+
+			_, err := r.reconcileInPlaceUpdates(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			for _, name := range tt.wantUpdatedMachines {
+				machine := &clusterv1.Machine{}
+				g.Expect(c.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, machine)).To(Succeed())
+				g.Expect(machine.Spec.Version).To(Equal(ptr.To("v1.32.0")))
+				g.Expect(inplace.IsUpdateStarted(machine)).To(BeTrue())
+				g.Expect(s.machinesNotUpToDateInPlace).To(HaveKeyWithValue(name, "In-place update in progress"))
+			}
+			for _, name := range tt.wantMarkedMachines {
+				machine := &clusterv1.Machine{}
+				g.Expect(c.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, machine)).To(Succeed())
+				g.Expect(machine.DeletionTimestamp.IsZero()).To(BeTrue())
+				g.Expect(machine.Spec.Version).To(Equal(ptr.To("v1.31.0")))
+				g.Expect(machine.Annotations).To(HaveKey(clusterv1.UpdateNotPossibleAnnotation))
+				g.Expect(s.machinesNotUpToDateInPlace).To(HaveKeyWithValue(name, machineUpdateNotPossibleMessage))
+			}
+			for _, name := range tt.wantUntouchedMachines {
+				machine := &clusterv1.Machine{}
+				g.Expect(c.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, machine)).To(Succeed())
+				g.Expect(machine.Spec.Version).To(Equal(ptr.To("v1.31.0")))
+				g.Expect(inplace.IsUpdateInProgress(machine)).To(BeFalse())
+				g.Expect(s.machinesNotUpToDateInPlace).To(HaveKeyWithValue(name, "Waiting for in-place update"))
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -80,6 +81,10 @@ type Reconciler struct {
 
 	RuntimeClient runtimeclient.Client
 
+	// FeatureGates are the feature gates used by the reconciler.
+	// If nil, feature.Gates is used.
+	FeatureGates featuregate.FeatureGate
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -97,7 +102,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		return errors.New("Client, APIReader and ClusterCache must not be nil")
 	}
 
-	if feature.Gates.Enabled(feature.RuntimeSDK) && r.RuntimeClient == nil {
+	if r.runtimeSDKEnabled() && r.RuntimeClient == nil {
 		return errors.New("RuntimeClient must not be nil")
 	}
 
@@ -152,12 +157,19 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		Scheme:          mgr.GetScheme(),
 		PredicateLogger: &predicateLog,
 	}
-	r.desiredStateGenerator = desiredstate.NewGenerator(r.Client, r.ClusterCache, r.RuntimeClient)
+	r.desiredStateGenerator = desiredstate.NewGenerator(r.Client, r.ClusterCache, r.RuntimeClient, desiredstate.WithFeatureGates(r.FeatureGates))
 	r.recorder = mgr.GetEventRecorderFor("topology/cluster-controller")
 	r.ssaCache = ssa.NewCache("topology/cluster")
 	return nil
 }
 
+func (r *Reconciler) runtimeSDKEnabled() bool {
+	if r.FeatureGates == nil {
+		return feature.Gates.Enabled(feature.RuntimeSDK)
+	}
+	return r.FeatureGates.Enabled(feature.RuntimeSDK)
+}
+
 func clusterChangeIsRelevant(scheme *runtime.Scheme, logger logr.Logger) predicate.Funcs {
 	dropNotRelevant := func(cluster *clusterv1.Cluster) *clusterv1.Cluster {
 		c := cluster.DeepCopy()
@@ -317,11 +329,57 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result, error) {
 	var err error
 
+	// Gets the blueprint and the current state of the Cluster and store them in the request scope.
+	if err := r.getBlueprintAndCurrentState(ctx, s); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The cluster topology is yet to be created. Call the BeforeClusterCreate hook before proceeding.
+	if r.runtimeSDKEnabled() {
+		res, err := r.callBeforeClusterCreateHook(ctx, s)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !res.IsZero() {
+			return res, nil
+		}
+	}
+
+	// Setup watches for InfrastructureCluster and ControlPlane CRs when they exist.
+	if err := r.setupDynamicWatches(ctx, s); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error creating dynamic watch")
+	}
+
+	// Computes the desired state of the Cluster and store it in the request scope.
+	s.Desired, err = r.desiredStateGenerator.Generate(ctx, s)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error computing the desired state of the Cluster topology")
+	}
+
+	// Reconciles current and desired state of the Cluster
+	if err := r.reconcileState(ctx, s); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error reconciling the Cluster topology")
+	}
+
+	// requeueAfter will not be 0 if any of the runtime hooks returns a blocking response.
+	requeueAfter := s.HookResponseTracker.AggregateRetryAfter()
+	if requeueAfter != 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getBlueprintAndCurrentState reads the ClusterClass of the Cluster, checks it is reconciled, defaults and validates
+// the Cluster variables and then stores the blueprint and the current state of the Cluster in the request scope.
+func (r *Reconciler) getBlueprintAndCurrentState(ctx context.Context, s *scope.Scope) error {
+	var err error
+
 	// Get ClusterClass.
 	clusterClass := &clusterv1.ClusterClass{}
 	key := s.Current.Cluster.GetClassKey()
 	if err := r.Client.Get(ctx, key, clusterClass); err != nil {
-		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve ClusterClass %s", key)
+		return errors.Wrapf(err, "failed to retrieve ClusterClass %s", key)
 	}
 
 	s.Blueprint.ClusterClass = clusterClass
@@ -331,66 +389,68 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 	// in the Cluster.
 	if !conditions.Has(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) ||
 		conditions.IsFalse(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) {
-		return ctrl.Result{}, errors.Errorf("ClusterClass is not successfully reconciled: status of %s condition on ClusterClass must be \"True\"", clusterv1.ClusterClassVariablesReadyCondition)
+		return errors.Errorf("ClusterClass is not successfully reconciled: status of %s condition on ClusterClass must be \"True\"", clusterv1.ClusterClassVariablesReadyCondition)
 	}
 	if clusterClass.GetGeneration() != clusterClass.Status.ObservedGeneration {
-		return ctrl.Result{}, errors.Errorf("ClusterClass is not successfully reconciled: ClusterClass.status.observedGeneration must be %d, but is %d", clusterClass.GetGeneration(), clusterClass.Status.ObservedGeneration)
+		return errors.Errorf("ClusterClass is not successfully reconciled: ClusterClass.status.observedGeneration must be %d, but is %d", clusterClass.GetGeneration(), clusterClass.Status.ObservedGeneration)
 	}
 
 	// Default and Validate the Cluster variables based on information from the ClusterClass.
 	// This step is needed as if the ClusterClass does not exist at Cluster creation some fields may not be defaulted or
 	// validated in the webhook.
 	if errs := webhooks.DefaultAndValidateVariables(ctx, s.Current.Cluster, nil, clusterClass); len(errs) > 0 {
-		return ctrl.Result{}, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), s.Current.Cluster.Name, errs)
+		return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), s.Current.Cluster.Name, errs)
 	}
 
 	// Gets the blueprint with the ClusterClass and the referenced templates
 	// and store it in the request scope.
 	s.Blueprint, err = r.getBlueprint(ctx, s.Current.Cluster, s.Blueprint.ClusterClass)
 	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "error reading the ClusterClass")
+		return errors.Wrap(err, "error reading the ClusterClass")
 	}
 
 	// Gets the current state of the Cluster and store it in the request scope.
 	s.Current, err = r.getCurrentState(ctx, s)
 	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "error reading current state of the Cluster topology")
+		return errors.Wrap(err, "error reading current state of the Cluster topology")
 	}
 
-	// The cluster topology is yet to be created. Call the BeforeClusterCreate hook before proceeding.
-	if feature.Gates.Enabled(feature.RuntimeSDK) {
-		res, err := r.callBeforeClusterCreateHook(ctx, s)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
-		if !res.IsZero() {
-			return res, nil
-		}
-	}
+	return nil
+}
 
-	// Setup watches for InfrastructureCluster and ControlPlane CRs when they exist.
-	if err := r.setupDynamicWatches(ctx, s); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "error creating dynamic watch")
+// ComputeDesiredState computes the desired state of the topology of the given Cluster without reconciling it,
+// and returns the request scope with the blueprint, the current state and the desired state of the Cluster.
+// It can be used to preview the effects of changes to a ClusterClass or to a Cluster topology, e.g. by clusterctl.
+// NOTE: The ClusterClass of the Cluster must be already reconciled. Clusters with MachinePools are supported only
+// if a ClusterCache is set, because computing the desired state of MachinePools requires access to the workload cluster.
+func (r *Reconciler) ComputeDesiredState(ctx context.Context, cluster *clusterv1.Cluster) (*scope.Scope, error) {
+	if cluster.Spec.Topology == nil {
+		return nil, errors.Errorf("Cluster %s does not use a managed topology", klog.KObj(cluster))
 	}
 
-	// Computes the desired state of the Cluster and store it in the request scope.
-	s.Desired, err = r.desiredStateGenerator.Generate(ctx, s)
-	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "error computing the desired state of the Cluster topology")
-	}
+	cluster = cluster.DeepCopy()
+	cluster.APIVersion = clusterv1.GroupVersion.String()
+	cluster.Kind = "Cluster"
 
-	// Reconciles current and desired state of the Cluster
-	if err := r.reconcileState(ctx, s); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "error reconciling the Cluster topology")
+	s := scope.New(cluster)
+	if err := r.getBlueprintAndCurrentState(ctx, s); err != nil {
+		return nil, err
+	}
+	if r.ClusterCache == nil && len(s.Current.MachinePools) > 0 {
+		return nil, errors.Errorf("computing the desired state of Cluster %s with MachinePools requires a ClusterCache", klog.KObj(cluster))
 	}
 
-	// requeueAfter will not be 0 if any of the runtime hooks returns a blocking response.
-	requeueAfter := s.HookResponseTracker.AggregateRetryAfter()
-	if requeueAfter != 0 {
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	generator := r.desiredStateGenerator
+	if generator == nil {
+		generator = desiredstate.NewGenerator(r.Client, r.ClusterCache, r.RuntimeClient, desiredstate.WithFeatureGates(r.FeatureGates))
 	}
 
-	return ctrl.Result{}, nil
+	var err error
+	s.Desired, err = generator.Generate(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing the desired state of the Cluster topology")
+	}
+	return s, nil
 }
 
 // setupDynamicWatches create watches for InfrastructureCluster and ControlPlane CRs when they exist.
@@ -518,7 +578,7 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Clu
 	// Call the BeforeClusterDelete hook if the 'ok-to-delete' annotation is not set
 	// and add the annotation to the cluster after receiving a successful non-blocking response.
 	log := ctrl.LoggerFrom(ctx)
-	if feature.Gates.Enabled(feature.RuntimeSDK) {
+	if r.runtimeSDKEnabled() {
 		if !hooks.IsOkToDelete(cluster) {
 			v1beta1Cluster := &clusterv1beta1.Cluster{}
 			if err := clusterv1beta1.Convert_v1beta2_Cluster_To_v1beta1_Cluster(cluster, v1beta1Cluster, nil); err != nil {
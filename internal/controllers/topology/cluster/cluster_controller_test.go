@@ -1673,3 +1673,122 @@ func TestClusterClassToCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestReconciler_ComputeDesiredState(t *testing.T) {
+	crds := []client.Object{
+		builder.GenericInfrastructureClusterTemplateCRD,
+		builder.GenericInfrastructureClusterCRD,
+		builder.GenericInfrastructureMachineTemplateCRD,
+		builder.GenericControlPlaneTemplateCRD,
+		builder.GenericControlPlaneCRD,
+		builder.GenericBootstrapConfigTemplateCRD,
+	}
+
+	infrastructureClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infraclustertemplate1").Build()
+	controlPlaneTemplate := builder.ControlPlaneTemplate(metav1.NamespaceDefault, "controlplanetemplate1").Build()
+	controlPlaneInfrastructureMachineTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "controlplaneinframachinetemplate1").Build()
+	workerInfrastructureMachineTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "workerinframachinetemplate1").Build()
+	workerBootstrapTemplate := builder.BootstrapTemplate(metav1.NamespaceDefault, "workerbootstraptemplate1").Build()
+	templates := []client.Object{
+		infrastructureClusterTemplate,
+		controlPlaneTemplate,
+		controlPlaneInfrastructureMachineTemplate,
+		workerInfrastructureMachineTemplate,
+		workerBootstrapTemplate,
+	}
+
+	newClusterClass := func() *clusterv1.ClusterClass {
+		return builder.ClusterClass(metav1.NamespaceDefault, clusterClassName1).
+			WithInfrastructureClusterTemplate(infrastructureClusterTemplate).
+			WithControlPlaneTemplate(controlPlaneTemplate).
+			WithControlPlaneInfrastructureMachineTemplate(controlPlaneInfrastructureMachineTemplate).
+			WithWorkerMachineDeploymentClasses(*builder.MachineDeploymentClass("workers").
+				WithInfrastructureTemplate(workerInfrastructureMachineTemplate).
+				WithBootstrapTemplate(workerBootstrapTemplate).
+				Build()).
+			Build()
+	}
+	reconciledClusterClass := newClusterClass()
+	conditions.Set(reconciledClusterClass, metav1.Condition{
+		Type:   clusterv1.ClusterClassVariablesReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ClusterClassVariablesReadyReason,
+	})
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, clusterName1).
+		WithTopology(builder.ClusterTopology().
+			WithClass(clusterClassName1).
+			WithVersion("v1.33.0").
+			WithMachineDeployment(builder.MachineDeploymentTopology("md1").WithClass("workers").WithReplicas(3).Build()).
+			Build()).
+		Build()
+
+	tests := []struct {
+		name         string
+		cluster      *clusterv1.Cluster
+		clusterClass *clusterv1.ClusterClass
+		wantErr      string
+	}{
+		{
+			name:         "fails for a Cluster without a managed topology",
+			cluster:      builder.Cluster(metav1.NamespaceDefault, clusterName2).Build(),
+			clusterClass: reconciledClusterClass,
+			wantErr:      "does not use a managed topology",
+		},
+		{
+			name:         "fails if the ClusterClass is not reconciled",
+			cluster:      cluster,
+			clusterClass: newClusterClass(),
+			wantErr:      "ClusterClass is not successfully reconciled",
+		},
+		{
+			name:         "computes the desired state without changing the Cluster topology",
+			cluster:      cluster,
+			clusterClass: reconciledClusterClass,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := []client.Object{tt.clusterClass}
+			objs = append(objs, crds...)
+			objs = append(objs, templates...)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(objs...).
+				Build()
+
+			// The reconciler is not set up with a manager, so the desired state generator is created on demand.
+			r := &Reconciler{
+				Client: fakeClient,
+			}
+			in := tt.cluster.DeepCopy()
+			s, err := r.ComputeDesiredState(ctx, in)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			// The Cluster in input is not changed.
+			g.Expect(in).To(Equal(tt.cluster))
+
+			g.Expect(s.Blueprint.ClusterClass.Name).To(Equal(clusterClassName1))
+			g.Expect(s.Current.Cluster.Name).To(Equal(tt.cluster.Name))
+			g.Expect(s.Desired.Cluster).ToNot(BeNil())
+			g.Expect(s.Desired.InfrastructureCluster).ToNot(BeNil())
+			g.Expect(s.Desired.ControlPlane.Object).ToNot(BeNil())
+			g.Expect(s.Desired.MachineDeployments).To(HaveKey("md1"))
+
+			// No objects of the Cluster topology are created.
+			machineDeployments := &clusterv1.MachineDeploymentList{}
+			g.Expect(fakeClient.List(ctx, machineDeployments)).To(Succeed())
+			g.Expect(machineDeployments.Items).To(BeEmpty())
+			controlPlanes := &unstructured.UnstructuredList{}
+			controlPlanes.SetGroupVersionKind(builder.ControlPlaneGroupVersion.WithKind(builder.GenericControlPlaneKind + "List"))
+			g.Expect(fakeClient.List(ctx, controlPlanes)).To(Succeed())
+			g.Expect(controlPlanes.Items).To(BeEmpty())
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -47,15 +48,18 @@ type Engine interface {
 }
 
 // NewEngine creates a new patch engine.
-func NewEngine(runtimeClient runtimeclient.Client) Engine {
+// The featureGates are used to check if external patches can be used.
+func NewEngine(runtimeClient runtimeclient.Client, featureGates featuregate.FeatureGate) Engine {
 	return &engine{
 		runtimeClient: runtimeClient,
+		featureGates:  featureGates,
 	}
 }
 
 // engine implements the Engine interface.
 type engine struct {
 	runtimeClient runtimeclient.Client
+	featureGates  featuregate.FeatureGate
 }
 
 // Apply applies patches to the desired state according to the patches from the ClusterClass, variables from the Cluster
@@ -96,7 +100,7 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 		log.V(5).Info("Applying patch to templates")
 
 		// Create patch generator for the current patch.
-		generator, err := createPatchGenerator(e.runtimeClient, &clusterClassPatch)
+		generator, err := createPatchGenerator(e.runtimeClient, e.featureGates, &clusterClassPatch)
 		if err != nil {
 			return err
 		}
@@ -133,7 +137,7 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 
 		log.V(5).Info("Validating topology")
 
-		validator := external.NewValidator(e.runtimeClient, &clusterClassPatch)
+		validator := external.NewValidator(e.runtimeClient, e.featureGates, &clusterClassPatch)
 
 		_, err := validator.Validate(ctx, desired.Cluster, validationRequest)
 		if err != nil {
@@ -402,20 +406,20 @@ func lookupMPTopology(topology *clusterv1.Topology, mpTopologyName string) (*clu
 // createPatchGenerator creates a patch generator for the given patch.
 // NOTE: Currently only inline JSON patches are supported; in the future we will add
 // external patches as well.
-func createPatchGenerator(runtimeClient runtimeclient.Client, patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
+func createPatchGenerator(runtimeClient runtimeclient.Client, featureGates featuregate.FeatureGate, patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
 	// Return a jsonPatchGenerator if there are PatchDefinitions in the patch.
 	if len(patch.Definitions) > 0 {
 		return inline.NewGenerator(patch), nil
 	}
 	// Return an externalPatchGenerator if there is an external configuration in the patch.
 	if patch.External != nil && patch.External.GeneratePatchesExtension != nil {
-		if !feature.Gates.Enabled(feature.RuntimeSDK) {
+		if !featureGates.Enabled(feature.RuntimeSDK) {
 			return nil, errors.Errorf("can not use external patch %q if RuntimeSDK feature flag is disabled", patch.Name)
 		}
 		if runtimeClient == nil {
 			return nil, errors.Errorf("failed to create patch generator for patch %q: runtimeClient is not set up", patch.Name)
 		}
-		return external.NewGenerator(runtimeClient, patch), nil
+		return external.NewGenerator(runtimeClient, featureGates, patch), nil
 	}
 
 	return nil, errors.Errorf("failed to create patch generator for patch %q", patch.Name)
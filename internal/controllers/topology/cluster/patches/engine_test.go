@@ -952,7 +952,7 @@ func TestApply(t *testing.T) {
 					WithCatalog(cat).
 					Build()
 			}
-			patchEngine := NewEngine(runtimeClient)
+			patchEngine := NewEngine(runtimeClient, feature.Gates)
 
 			if len(tt.patches) > 0 {
 				// Add the patches.
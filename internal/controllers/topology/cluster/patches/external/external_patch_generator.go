@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"k8s.io/component-base/featuregate"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -33,19 +34,21 @@ import (
 // externalPatchGenerator generates JSON patches for a GeneratePatchesRequest based on a ClusterClassPatch.
 type externalPatchGenerator struct {
 	runtimeClient runtimeclient.Client
+	featureGates  featuregate.FeatureGate
 	patch         *clusterv1.ClusterClassPatch
 }
 
 // NewGenerator returns a new external Generator from a given ClusterClassPatch object.
-func NewGenerator(runtimeClient runtimeclient.Client, patch *clusterv1.ClusterClassPatch) api.Generator {
+func NewGenerator(runtimeClient runtimeclient.Client, featureGates featuregate.FeatureGate, patch *clusterv1.ClusterClassPatch) api.Generator {
 	return &externalPatchGenerator{
 		runtimeClient: runtimeClient,
+		featureGates:  featureGates,
 		patch:         patch,
 	}
 }
 
 func (e externalPatchGenerator) Generate(ctx context.Context, forObject client.Object, req *runtimehooksv1.GeneratePatchesRequest) (*runtimehooksv1.GeneratePatchesResponse, error) {
-	if !feature.Gates.Enabled(feature.RuntimeSDK) {
+	if !e.featureGates.Enabled(feature.RuntimeSDK) {
 		return nil, errors.Errorf("can not use external patch %q if RuntimeSDK feature flag is disabled", *e.patch.External.GeneratePatchesExtension)
 	}
 
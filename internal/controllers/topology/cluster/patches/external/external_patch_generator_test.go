@@ -92,7 +92,7 @@ func TestExternalPatchGenerator_Generate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			externalPatchGenerator := NewGenerator(tt.runtimeClient, tt.patch)
+			externalPatchGenerator := NewGenerator(tt.runtimeClient, feature.Gates, tt.patch)
 			_, _ = externalPatchGenerator.Generate(ctx, &clusterv1.Cluster{}, tt.request)
 			tt.assertRequest(g, tt.runtimeClient.callExtensionRequest)
 		})
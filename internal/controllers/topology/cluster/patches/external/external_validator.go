@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"k8s.io/component-base/featuregate"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -33,19 +34,21 @@ import (
 // externalValidator validates templates.
 type externalValidator struct {
 	runtimeClient runtimeclient.Client
+	featureGates  featuregate.FeatureGate
 	patch         *clusterv1.ClusterClassPatch
 }
 
 // NewValidator returns a new external Validator from a given ClusterClassPatch object.
-func NewValidator(runtimeClient runtimeclient.Client, patch *clusterv1.ClusterClassPatch) api.Validator {
+func NewValidator(runtimeClient runtimeclient.Client, featureGates featuregate.FeatureGate, patch *clusterv1.ClusterClassPatch) api.Validator {
 	return &externalValidator{
 		runtimeClient: runtimeClient,
+		featureGates:  featureGates,
 		patch:         patch,
 	}
 }
 
 func (e externalValidator) Validate(ctx context.Context, forObject client.Object, req *runtimehooksv1.ValidateTopologyRequest) (*runtimehooksv1.ValidateTopologyResponse, error) {
-	if !feature.Gates.Enabled(feature.RuntimeSDK) {
+	if !e.featureGates.Enabled(feature.RuntimeSDK) {
 		return nil, errors.Errorf("can not use external patch %q if RuntimeSDK feature flag is disabled", *e.patch.External.ValidateTopologyExtension)
 	}
 
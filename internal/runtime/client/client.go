@@ -76,6 +76,8 @@ func New(options Options) runtimeclient.Client {
 
 var _ runtimeclient.Client = &client{}
 
+var _ runtimeclient.ExtensionLister = &client{}
+
 type client struct {
 	catalog  *runtimecatalog.Catalog
 	registry runtimeregistry.ExtensionRegistry
@@ -164,6 +166,34 @@ func (c *client) Unregister(extensionConfig *runtimev1.ExtensionConfig) error {
 	return nil
 }
 
+// GetAllExtensions gets the names of all the ExtensionHandlers registered for the hook.
+// ExtensionHandlers whose namespaceSelector does not match the namespace of forObject are not included.
+func (c *client) GetAllExtensions(ctx context.Context, hook runtimecatalog.Hook, forObject metav1.Object) ([]string, error) {
+	hookName := runtimecatalog.HookName(hook)
+	gvh, err := c.catalog.GroupVersionHook(hook)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get extension handlers for hook %q: failed to compute GroupVersionHook", hookName)
+	}
+
+	registrations, err := c.registry.List(gvh.GroupHook())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get extension handlers for hook %q", gvh.GroupHook())
+	}
+
+	names := []string{}
+	for _, registration := range registrations {
+		namespaceMatches, err := c.matchNamespace(ctx, registration.NamespaceSelector, forObject.GetNamespace())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get extension handlers for hook %q", gvh.GroupHook())
+		}
+		if !namespaceMatches {
+			continue
+		}
+		names = append(names, registration.Name)
+	}
+	return names, nil
+}
+
 // CallAllExtensions calls all the ExtensionHandlers registered for the hook.
 // The ExtensionHandlers are called sequentially. The function exits immediately after any of the ExtensionHandlers return an error.
 // This ensures we don't end up waiting for timeout from multiple unreachable Extensions.
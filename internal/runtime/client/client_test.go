@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -1135,6 +1136,100 @@ func TestClient_CallAllExtensions(t *testing.T) {
 	}
 }
 
+func TestClient_GetAllExtensions(t *testing.T) {
+	fooNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "foo",
+			Labels: map[string]string{corev1.LabelMetadataName: "foo"},
+		},
+	}
+	barNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "bar",
+			Labels: map[string]string{corev1.LabelMetadataName: "bar"},
+		},
+	}
+
+	extensionConfig := func(name string, namespaceSelector *metav1.LabelSelector, hooks ...string) runtimev1.ExtensionConfig {
+		ext := runtimev1.ExtensionConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: runtimev1.ExtensionConfigSpec{
+				ClientConfig: runtimev1.ClientConfig{
+					URL: ptr.To("https://127.0.0.1/"),
+				},
+				NamespaceSelector: namespaceSelector,
+			},
+		}
+		for _, hook := range hooks {
+			ext.Status.Handlers = append(ext.Status.Handlers, runtimev1.ExtensionHandler{
+				Name: fmt.Sprintf("%s.%s", strings.ToLower(hook), name),
+				RequestHook: runtimev1.GroupVersionHook{
+					APIVersion: fakev1alpha1.GroupVersion.String(),
+					Hook:       hook,
+				},
+			})
+		}
+		return ext
+	}
+
+	tests := []struct {
+		name                       string
+		registeredExtensionConfigs []runtimev1.ExtensionConfig
+		hook                       runtimecatalog.Hook
+		want                       []string
+	}{
+		{
+			name:                       "should return no ExtensionHandlers when none are registered for the hook",
+			registeredExtensionConfigs: []runtimev1.ExtensionConfig{extensionConfig("ext1", &metav1.LabelSelector{}, "SecondFakeHook")},
+			hook:                       fakev1alpha1.FakeHook,
+			want:                       []string{},
+		},
+		{
+			name: "should return the ExtensionHandlers registered for the hook",
+			registeredExtensionConfigs: []runtimev1.ExtensionConfig{
+				extensionConfig("ext1", &metav1.LabelSelector{}, "FakeHook", "SecondFakeHook"),
+				extensionConfig("ext2", &metav1.LabelSelector{}, "FakeHook"),
+			},
+			hook: fakev1alpha1.FakeHook,
+			want: []string{"fakehook.ext1", "fakehook.ext2"},
+		},
+		{
+			name: "should not return ExtensionHandlers which do not match the namespace of the object",
+			registeredExtensionConfigs: []runtimev1.ExtensionConfig{
+				extensionConfig("ext1", &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "foo"}}, "FakeHook"),
+				extensionConfig("ext2", &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "bar"}}, "FakeHook"),
+			},
+			hook: fakev1alpha1.FakeHook,
+			want: []string{"fakehook.ext1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cat := runtimecatalog.New()
+			_ = fakev1alpha1.AddToCatalog(cat)
+			c := New(Options{
+				Catalog:  cat,
+				Registry: registry(tt.registeredExtensionConfigs),
+				Client:   fake.NewClientBuilder().WithObjects(fooNamespace, barNamespace).Build(),
+			})
+
+			obj := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster",
+					Namespace: "foo",
+				},
+			}
+			got, err := c.(runtimeclient.ExtensionLister).GetAllExtensions(context.Background(), tt.hook, obj)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(ConsistOf(tt.want))
+		})
+	}
+}
+
 func Test_client_matchNamespace(t *testing.T) {
 	g := NewWithT(t)
 	foo := &corev1.Namespace{
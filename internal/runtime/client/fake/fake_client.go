@@ -36,6 +36,7 @@ type RuntimeClientBuilder struct {
 	catalog          *runtimecatalog.Catalog
 	callAllResponses map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject
 	callResponses    map[string]runtimehooksv1.ResponseObject
+	getAllResponses  map[runtimecatalog.GroupVersionHook][]string
 }
 
 // NewRuntimeClientBuilder returns a new builder for the fake runtime client.
@@ -61,6 +62,12 @@ func (f *RuntimeClientBuilder) WithCallExtensionResponses(responses map[string]r
 	return f
 }
 
+// WithGetAllExtensionResponses can be used to dictate the responses for GetAllExtensions.
+func (f *RuntimeClientBuilder) WithGetAllExtensionResponses(responses map[runtimecatalog.GroupVersionHook][]string) *RuntimeClientBuilder {
+	f.getAllResponses = responses
+	return f
+}
+
 // MarkReady can be used to mark the fake runtime client as either ready or not ready.
 func (f *RuntimeClientBuilder) MarkReady(ready bool) *RuntimeClientBuilder {
 	f.ready = ready
@@ -73,6 +80,7 @@ func (f *RuntimeClientBuilder) Build() *RuntimeClient {
 		isReady:          f.ready,
 		callAllResponses: f.callAllResponses,
 		callResponses:    f.callResponses,
+		getAllResponses:  f.getAllResponses,
 		catalog:          f.catalog,
 		callAllTracker:   map[string]int{},
 	}
@@ -80,16 +88,29 @@ func (f *RuntimeClientBuilder) Build() *RuntimeClient {
 
 var _ runtimeclient.Client = &RuntimeClient{}
 
+var _ runtimeclient.ExtensionLister = &RuntimeClient{}
+
 // RuntimeClient is a fake implementation of runtimeclient.Client.
 type RuntimeClient struct {
 	isReady          bool
 	catalog          *runtimecatalog.Catalog
 	callAllResponses map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject
 	callResponses    map[string]runtimehooksv1.ResponseObject
+	getAllResponses  map[runtimecatalog.GroupVersionHook][]string
 
 	callAllTracker map[string]int
 }
 
+// GetAllExtensions implements ExtensionLister.
+func (fc *RuntimeClient) GetAllExtensions(_ context.Context, hook runtimecatalog.Hook, _ metav1.Object) ([]string, error) {
+	gvh, err := fc.catalog.GroupVersionHook(hook)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute GVH")
+	}
+
+	return fc.getAllResponses[gvh], nil
+}
+
 // CallAllExtensions implements Client.
 func (fc *RuntimeClient) CallAllExtensions(ctx context.Context, hook runtimecatalog.Hook, _ metav1.Object, _ runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject) error {
 	defer func() {
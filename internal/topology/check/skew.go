@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/util/version"
+)
+
+// KubeletMinorVersionSkew is the number of minor versions a kubelet can be older than the control plane.
+// Kubernetes skew policy: https://kubernetes.io/releases/version-skew-policy/#kubelet
+const KubeletMinorVersionSkew = 3
+
+// KubernetesVersionSkew returns an error if the given worker version does not conform to the
+// Kubernetes version skew policy when joining a control plane with the given version.
+// => Worker minor version cannot be greater than the control plane minor version.
+// => Worker minor version cannot be outside of the supported skew.
+func KubernetesVersionSkew(controlPlaneVersion, workerVersion semver.Version) error {
+	if workerVersion.Major != controlPlaneVersion.Major {
+		return errors.Errorf("version %s has a different major version than the control plane version %s", workerVersion, controlPlaneVersion)
+	}
+	if workerVersion.Minor > controlPlaneVersion.Minor {
+		return errors.Errorf("version %s is higher than the control plane version %s", workerVersion, controlPlaneVersion)
+	}
+	if workerVersion.Minor+KubeletMinorVersionSkew < controlPlaneVersion.Minor {
+		return errors.Errorf("version %s is more than %d minor versions older than the control plane version %s", workerVersion, KubeletMinorVersionSkew, controlPlaneVersion)
+	}
+	return nil
+}
+
+// KubeadmVersionSkew returns an error if the given worker version does not conform to the
+// kubeadm version skew policy when joining a control plane with the given version.
+// => Worker version should match (major+minor) the control plane version.
+// kubeadm skew policy: https://kubernetes.io/docs/setup/production-environment/tools/kubeadm/create-cluster-kubeadm/#kubeadm-s-skew-against-kubeadm
+func KubeadmVersionSkew(controlPlaneVersion, workerVersion semver.Version) error {
+	if workerVersion.Major != controlPlaneVersion.Major || workerVersion.Minor != controlPlaneVersion.Minor {
+		return errors.Errorf("version %s does not have the same major+minor version as the control plane version %s", workerVersion, controlPlaneVersion)
+	}
+	return nil
+}
+
+// UpgradePath returns the sequence of versions a control plane has to go through to be upgraded
+// from the current version to the target version, given that the minor version can only be
+// increased by one at a time.
+// Intermediate steps only define the major and minor version; the last step is always the target version.
+// An empty path is returned if the current version is already the target version.
+func UpgradePath(currentVersion, targetVersion semver.Version) ([]semver.Version, error) {
+	if currentVersion.Major != targetVersion.Major {
+		return nil, errors.Errorf("upgrading from %s to %s is not supported: major versions are different", currentVersion, targetVersion)
+	}
+	cmp := version.Compare(targetVersion, currentVersion, version.WithoutPreReleases())
+	if cmp < 0 {
+		return nil, errors.Errorf("version cannot be decreased from %s to %s", currentVersion, targetVersion)
+	}
+	if cmp == 0 {
+		return nil, nil
+	}
+
+	path := []semver.Version{}
+	for minor := currentVersion.Minor + 1; minor < targetVersion.Minor; minor++ {
+		path = append(path, semver.Version{Major: targetVersion.Major, Minor: minor})
+	}
+	return append(path, targetVersion), nil
+}
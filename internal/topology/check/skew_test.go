@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	. "github.com/onsi/gomega"
+)
+
+func TestKubernetesVersionSkew(t *testing.T) {
+	tests := []struct {
+		name                string
+		controlPlaneVersion string
+		workerVersion       string
+		wantErr             bool
+	}{
+		{
+			name:                "pass if versions are the same",
+			controlPlaneVersion: "v1.31.2",
+			workerVersion:       "v1.31.0",
+		},
+		{
+			name:                "pass if the worker is within the supported skew",
+			controlPlaneVersion: "v1.31.2",
+			workerVersion:       "v1.28.5",
+		},
+		{
+			name:                "fail if the worker is outside of the supported skew",
+			controlPlaneVersion: "v1.31.2",
+			workerVersion:       "v1.27.5",
+			wantErr:             true,
+		},
+		{
+			name:                "fail if the worker is newer than the control plane",
+			controlPlaneVersion: "v1.31.2",
+			workerVersion:       "v1.32.0",
+			wantErr:             true,
+		},
+		{
+			name:                "pass if the control plane minor version is lower than the supported skew",
+			controlPlaneVersion: "v1.2.0",
+			workerVersion:       "v1.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := KubernetesVersionSkew(semver.MustParse(tt.controlPlaneVersion[1:]), semver.MustParse(tt.workerVersion[1:]))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestKubeadmVersionSkew(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(KubeadmVersionSkew(semver.MustParse("1.31.2"), semver.MustParse("1.31.0"))).To(Succeed())
+	g.Expect(KubeadmVersionSkew(semver.MustParse("1.31.2"), semver.MustParse("1.30.0"))).ToNot(Succeed())
+	g.Expect(KubeadmVersionSkew(semver.MustParse("1.31.2"), semver.MustParse("1.32.0"))).ToNot(Succeed())
+}
+
+func TestUpgradePath(t *testing.T) {
+	tests := []struct {
+		name           string
+		currentVersion string
+		targetVersion  string
+		want           []string
+		wantErr        bool
+	}{
+		{
+			name:           "no steps if already at the target version",
+			currentVersion: "1.31.2",
+			targetVersion:  "1.31.2",
+			want:           nil,
+		},
+		{
+			name:           "patch upgrade",
+			currentVersion: "1.31.2",
+			targetVersion:  "1.31.4",
+			want:           []string{"1.31.4"},
+		},
+		{
+			name:           "one minor upgrade",
+			currentVersion: "1.31.2",
+			targetVersion:  "1.32.1",
+			want:           []string{"1.32.1"},
+		},
+		{
+			name:           "upgrade across multiple minors",
+			currentVersion: "1.30.2",
+			targetVersion:  "1.33.1",
+			want:           []string{"1.31.0", "1.32.0", "1.33.1"},
+		},
+		{
+			name:           "fail on downgrade",
+			currentVersion: "1.31.2",
+			targetVersion:  "1.30.1",
+			wantErr:        true,
+		},
+		{
+			name:           "fail on major upgrade",
+			currentVersion: "1.31.2",
+			targetVersion:  "2.0.0",
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := UpgradePath(semver.MustParse(tt.currentVersion), semver.MustParse(tt.targetVersion))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			gotStrings := []string{}
+			for _, v := range got {
+				gotStrings = append(gotStrings, v.String())
+			}
+			if tt.want == nil {
+				g.Expect(got).To(BeEmpty())
+				return
+			}
+			g.Expect(gotStrings).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,468 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inplace implements utils for in-place updates of Machines.
+package inplace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// MachineObjects groups a Machine with its InfrastructureMachine and BootstrapConfig.
+type MachineObjects struct {
+	Machine         *clusterv1.Machine
+	InfraMachine    *unstructured.Unstructured
+	BootstrapConfig *unstructured.Unstructured
+}
+
+// DeepCopy returns a deep copy of MachineObjects.
+func (m *MachineObjects) DeepCopy() *MachineObjects {
+	if m == nil {
+		return nil
+	}
+	out := &MachineObjects{}
+	if m.Machine != nil {
+		out.Machine = m.Machine.DeepCopy()
+	}
+	if m.InfraMachine != nil {
+		out.InfraMachine = m.InfraMachine.DeepCopy()
+	}
+	if m.BootstrapConfig != nil {
+		out.BootstrapConfig = m.BootstrapConfig.DeepCopy()
+	}
+	return out
+}
+
+// GetMachineObjects returns the Machine together with its InfrastructureMachine and BootstrapConfig.
+func GetMachineObjects(ctx context.Context, c client.Reader, machine *clusterv1.Machine) (*MachineObjects, error) {
+	objs := &MachineObjects{Machine: machine}
+
+	infraMachine, err := external.GetObjectFromContractVersionedRef(ctx, c, &machine.Spec.InfrastructureRef, machine.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s %s", machine.Spec.InfrastructureRef.Kind, klog.KRef(machine.Namespace, machine.Spec.InfrastructureRef.Name))
+	}
+	objs.InfraMachine = infraMachine
+
+	if machine.Spec.Bootstrap.ConfigRef != nil {
+		bootstrapConfig, err := external.GetObjectFromContractVersionedRef(ctx, c, machine.Spec.Bootstrap.ConfigRef, machine.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %s %s", machine.Spec.Bootstrap.ConfigRef.Kind, klog.KRef(machine.Namespace, machine.Spec.Bootstrap.ConfigRef.Name))
+		}
+		objs.BootstrapConfig = bootstrapConfig
+	}
+	return objs, nil
+}
+
+// IsUpdateInProgress returns true if an in-place update of the Machine is in progress.
+func IsUpdateInProgress(machine *clusterv1.Machine) bool {
+	_, ok := machine.GetAnnotations()[clusterv1.UpdateInProgressAnnotation]
+	return ok
+}
+
+// IsUpdateStarted returns true if all the objects of the Machine have been updated and the
+// Machine controller has been signaled to call the UpdateMachine hook.
+func IsUpdateStarted(machine *clusterv1.Machine) bool {
+	return IsUpdateInProgress(machine) && hooks.IsPending(runtimehooksv1.UpdateMachine, machine)
+}
+
+// ComputeDesiredObject returns a copy of current with spec merged into the current spec.
+// Note: Fields which exist only in the current spec are preserved, e.g. providerID set by an infrastructure provider;
+// lists are replaced entirely.
+func ComputeDesiredObject(current *unstructured.Unstructured, spec map[string]interface{}) *unstructured.Unstructured {
+	desired := current.DeepCopy()
+	if len(spec) == 0 {
+		return desired
+	}
+	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	if desiredSpec == nil {
+		desiredSpec = map[string]interface{}{}
+	}
+	mergeMaps(desiredSpec, runtime.DeepCopyJSON(spec))
+	desired.Object["spec"] = desiredSpec
+	return desired
+}
+
+// ComputeDesiredObjectFromTemplate returns a copy of current with the spec of the template merged into the current spec,
+// and with the cloned-from annotations pointing to the template.
+func ComputeDesiredObjectFromTemplate(current, template *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	templateSpec, found, err := unstructured.NestedMap(template.Object, "spec", "template", "spec")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve spec.template.spec from %s %s", template.GetKind(), klog.KObj(template))
+	}
+	if !found {
+		templateSpec = map[string]interface{}{}
+	}
+
+	desired := ComputeDesiredObject(current, templateSpec)
+
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[clusterv1.TemplateClonedFromNameAnnotation] = template.GetName()
+	annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] = template.GroupVersionKind().GroupKind().String()
+	desired.SetAnnotations(annotations)
+	return desired, nil
+}
+
+// IsClonedFrom returns true if the object has been cloned from the template with the given GroupKind and name.
+// Note: Objects without cloned-from annotations, e.g. adopted objects, are considered as cloned from any template.
+func IsClonedFrom(obj *unstructured.Unstructured, groupKind, name string) bool {
+	clonedFromName, ok1 := obj.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
+	clonedFromGroupKind, ok2 := obj.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation]
+	if !ok1 || !ok2 {
+		return true
+	}
+	return clonedFromName == name && clonedFromGroupKind == groupKind
+}
+
+// IsUpToDateWithTemplate returns true if the Machine has the version of the given Machine template spec and if the
+// InfrastructureMachine and the BootstrapConfig have been cloned from the templates referenced by the Machine template spec.
+func IsUpToDateWithTemplate(current *MachineObjects, templateSpec *clusterv1.MachineSpec) bool {
+	if ptr.Deref(current.Machine.Spec.Version, "") != ptr.Deref(templateSpec.Version, "") {
+		return false
+	}
+
+	infraRef := templateSpec.InfrastructureRef
+	if !IsClonedFrom(current.InfraMachine, groupKind(infraRef), infraRef.Name) {
+		return false
+	}
+
+	bootstrapRef := templateSpec.Bootstrap.ConfigRef
+	if bootstrapRef != nil && current.BootstrapConfig != nil {
+		if !IsClonedFrom(current.BootstrapConfig, groupKind(*bootstrapRef), bootstrapRef.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// ComputeDesiredMachineObjectsFromTemplate computes the desired state of a Machine, of its InfrastructureMachine and
+// of its BootstrapConfig according to the given Machine template spec, e.g. the template of a MachineSet.
+func ComputeDesiredMachineObjectsFromTemplate(ctx context.Context, c client.Reader, current *MachineObjects, templateSpec *clusterv1.MachineSpec) (*MachineObjects, error) {
+	machine := current.Machine
+	desired := current.DeepCopy()
+	desired.Machine.Spec.Version = templateSpec.Version
+
+	infraTemplate, err := external.GetObjectFromContractVersionedRef(ctx, c, &templateSpec.InfrastructureRef, machine.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute desired InfrastructureMachine for Machine %s", klog.KObj(machine))
+	}
+	desired.InfraMachine, err = ComputeDesiredObjectFromTemplate(current.InfraMachine, infraTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute desired InfrastructureMachine for Machine %s", klog.KObj(machine))
+	}
+
+	if templateSpec.Bootstrap.ConfigRef != nil && current.BootstrapConfig != nil {
+		bootstrapTemplate, err := external.GetObjectFromContractVersionedRef(ctx, c, templateSpec.Bootstrap.ConfigRef, machine.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute desired BootstrapConfig for Machine %s", klog.KObj(machine))
+		}
+		desired.BootstrapConfig, err = ComputeDesiredObjectFromTemplate(current.BootstrapConfig, bootstrapTemplate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute desired BootstrapConfig for Machine %s", klog.KObj(machine))
+		}
+	}
+	return desired, nil
+}
+
+// CanUpdateMachine returns true if the changes from current to desired can be applied in-place.
+// CanUpdateMachine calls the CanUpdateMachine hook of all the registered Runtime Extensions; each Runtime Extension
+// receives the current objects with the patches returned by the previous Runtime Extensions already applied.
+// The Machine can be updated in-place if, after applying all the patches, the spec of all the objects
+// matches the desired spec. If the Machine cannot be updated in-place, a message describing why is returned.
+func CanUpdateMachine(ctx context.Context, runtimeClient runtimeclient.Client, current, desired *MachineObjects) (bool, string, error) {
+	if runtimeClient == nil {
+		return false, "RuntimeSDK is not enabled", nil
+	}
+
+	extensionLister, ok := runtimeClient.(runtimeclient.ExtensionLister)
+	if !ok {
+		return false, "the Runtime client does not support listing Runtime Extensions", nil
+	}
+	extensionHandlers, err := extensionLister.GetAllExtensions(ctx, runtimehooksv1.CanUpdateMachine, current.Machine)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to check if Machine %s can be updated in-place", klog.KObj(current.Machine))
+	}
+	if len(extensionHandlers) == 0 {
+		return false, "no Runtime Extensions implementing the CanUpdateMachine hook are registered", nil
+	}
+
+	currentObjects, err := ToMachineUpdateObjects(current)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to check if Machine %s can be updated in-place", klog.KObj(current.Machine))
+	}
+	desiredObjects, err := ToMachineUpdateObjects(desired)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to check if Machine %s can be updated in-place", klog.KObj(current.Machine))
+	}
+
+	for _, extensionHandler := range extensionHandlers {
+		request := &runtimehooksv1.CanUpdateMachineRequest{
+			Current: *currentObjects.DeepCopy(),
+			Desired: *desiredObjects.DeepCopy(),
+		}
+		response := &runtimehooksv1.CanUpdateMachineResponse{}
+		if err := runtimeClient.CallExtension(ctx, runtimehooksv1.CanUpdateMachine, current.Machine, extensionHandler, request, response); err != nil {
+			return false, "", errors.Wrapf(err, "failed to check if Machine %s can be updated in-place", klog.KObj(current.Machine))
+		}
+
+		if err := applyPatches(currentObjects, response); err != nil {
+			return false, "", errors.Wrapf(err, "failed to check if Machine %s can be updated in-place: failed to apply patches from %s", klog.KObj(current.Machine), extensionHandler)
+		}
+	}
+
+	notCovered, err := specsNotMatching(currentObjects, desiredObjects, current)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to check if Machine %s can be updated in-place", klog.KObj(current.Machine))
+	}
+	if len(notCovered) > 0 {
+		return false, fmt.Sprintf("changes to %s cannot be applied in-place by any Runtime Extension", strings.Join(notCovered, ", ")), nil
+	}
+	return true, "", nil
+}
+
+// StartUpdate starts the in-place update of a Machine by updating the Machine, the InfrastructureMachine and the BootstrapConfig
+// to their desired state and then by signaling to the Machine controller that the UpdateMachine hook must be called.
+// Note: The UpdateInProgressAnnotation is set on the Machine before any other change; this allows the controller owning the Machine
+// to resume the update in case of errors.
+func StartUpdate(ctx context.Context, c client.Client, current, desired *MachineObjects) error {
+	machine := current.Machine
+	if !IsUpdateInProgress(machine) {
+		patchHelper, err := patch.NewHelper(machine, c)
+		if err != nil {
+			return errors.Wrapf(err, "failed to start in-place update of Machine %s", klog.KObj(machine))
+		}
+		annotations := machine.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[clusterv1.UpdateInProgressAnnotation] = ""
+		machine.SetAnnotations(annotations)
+		if err := patchHelper.Patch(ctx, machine); err != nil {
+			return errors.Wrapf(err, "failed to start in-place update of Machine %s", klog.KObj(machine))
+		}
+	}
+
+	if err := patchObject(ctx, c, current.InfraMachine, desired.InfraMachine); err != nil {
+		return errors.Wrapf(err, "failed to start in-place update of Machine %s", klog.KObj(machine))
+	}
+	if current.BootstrapConfig != nil && desired.BootstrapConfig != nil {
+		if err := patchObject(ctx, c, current.BootstrapConfig, desired.BootstrapConfig); err != nil {
+			return errors.Wrapf(err, "failed to start in-place update of Machine %s", klog.KObj(machine))
+		}
+	}
+
+	desiredMachine := desired.Machine.DeepCopy()
+	annotations := desiredMachine.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[clusterv1.UpdateInProgressAnnotation] = ""
+	desiredMachine.SetAnnotations(annotations)
+	if err := patchObject(ctx, c, machine, desiredMachine); err != nil {
+		return errors.Wrapf(err, "failed to start in-place update of Machine %s", klog.KObj(machine))
+	}
+
+	if err := hooks.MarkAsPending(ctx, c, desiredMachine, runtimehooksv1.UpdateMachine); err != nil {
+		return errors.Wrapf(err, "failed to start in-place update of Machine %s", klog.KObj(machine))
+	}
+	desiredMachine.DeepCopyInto(machine)
+	return nil
+}
+
+// ToMachineUpdateObjects converts MachineObjects to the corresponding Runtime hook type.
+func ToMachineUpdateObjects(objs *MachineObjects) (*runtimehooksv1.MachineUpdateObjects, error) {
+	out := &runtimehooksv1.MachineUpdateObjects{}
+
+	v1beta1Machine := &clusterv1beta1.Machine{}
+	if err := clusterv1beta1.Convert_v1beta2_Machine_To_v1beta1_Machine(objs.Machine, v1beta1Machine, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to convert Machine to v1beta1 Machine")
+	}
+	v1beta1Machine.SetGroupVersionKind(clusterv1beta1.GroupVersion.WithKind("Machine"))
+	out.Machine = *v1beta1Machine
+
+	if objs.InfraMachine != nil {
+		raw, err := json.Marshal(objs.InfraMachine)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal %s", objs.InfraMachine.GetKind())
+		}
+		out.InfrastructureMachine = runtime.RawExtension{Raw: raw}
+	}
+	if objs.BootstrapConfig != nil {
+		raw, err := json.Marshal(objs.BootstrapConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal %s", objs.BootstrapConfig.GetKind())
+		}
+		out.BootstrapConfig = runtime.RawExtension{Raw: raw}
+	}
+	return out, nil
+}
+
+// applyPatches applies the patches from a CanUpdateMachineResponse to objs.
+func applyPatches(objs *runtimehooksv1.MachineUpdateObjects, response *runtimehooksv1.CanUpdateMachineResponse) error {
+	if response.MachinePatch.IsDefined() {
+		machineJSON, err := json.Marshal(objs.Machine)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal Machine")
+		}
+		patchedMachineJSON, err := applyPatch(machineJSON, response.MachinePatch)
+		if err != nil {
+			return errors.Wrap(err, "failed to apply patch to Machine")
+		}
+		patchedMachine := clusterv1beta1.Machine{}
+		if err := json.Unmarshal(patchedMachineJSON, &patchedMachine); err != nil {
+			return errors.Wrap(err, "failed to unmarshal patched Machine")
+		}
+		objs.Machine = patchedMachine
+	}
+
+	if response.InfrastructureMachinePatch.IsDefined() {
+		patched, err := applyPatch(objs.InfrastructureMachine.Raw, response.InfrastructureMachinePatch)
+		if err != nil {
+			return errors.Wrap(err, "failed to apply patch to InfrastructureMachine")
+		}
+		objs.InfrastructureMachine = runtime.RawExtension{Raw: patched}
+	}
+
+	if response.BootstrapConfigPatch.IsDefined() {
+		if len(objs.BootstrapConfig.Raw) == 0 {
+			return errors.New("failed to apply patch to BootstrapConfig: Machine does not have a BootstrapConfig")
+		}
+		patched, err := applyPatch(objs.BootstrapConfig.Raw, response.BootstrapConfigPatch)
+		if err != nil {
+			return errors.Wrap(err, "failed to apply patch to BootstrapConfig")
+		}
+		objs.BootstrapConfig = runtime.RawExtension{Raw: patched}
+	}
+	return nil
+}
+
+func applyPatch(in []byte, p runtimehooksv1.Patch) ([]byte, error) {
+	switch p.PatchType {
+	case runtimehooksv1.JSONPatchType:
+		jsonPatch, err := jsonpatch.DecodePatch(p.Patch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode JSON patch")
+		}
+		return jsonPatch.Apply(in)
+	case runtimehooksv1.JSONMergePatchType:
+		return jsonpatch.MergePatch(in, p.Patch)
+	default:
+		return nil, errors.Errorf("unknown patch type %q", p.PatchType)
+	}
+}
+
+// specsNotMatching returns the list of objects whose spec in current does not match the spec in desired.
+func specsNotMatching(current, desired *runtimehooksv1.MachineUpdateObjects, objs *MachineObjects) ([]string, error) {
+	notMatching := []string{}
+	if !reflect.DeepEqual(current.Machine.Spec, desired.Machine.Spec) {
+		notMatching = append(notMatching, "Machine")
+	}
+
+	infraMachineMatches, err := rawSpecsMatch(current.InfrastructureMachine, desired.InfrastructureMachine)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compare InfrastructureMachine")
+	}
+	if !infraMachineMatches {
+		notMatching = append(notMatching, objs.InfraMachine.GetKind())
+	}
+
+	bootstrapConfigMatches, err := rawSpecsMatch(current.BootstrapConfig, desired.BootstrapConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compare BootstrapConfig")
+	}
+	if !bootstrapConfigMatches {
+		notMatching = append(notMatching, objs.BootstrapConfig.GetKind())
+	}
+	return notMatching, nil
+}
+
+func rawSpecsMatch(current, desired runtime.RawExtension) (bool, error) {
+	if len(current.Raw) == 0 && len(desired.Raw) == 0 {
+		return true, nil
+	}
+	currentSpec, err := specFromRaw(current.Raw)
+	if err != nil {
+		return false, err
+	}
+	desiredSpec, err := specFromRaw(desired.Raw)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(currentSpec, desiredSpec), nil
+}
+
+func specFromRaw(raw []byte) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal object")
+	}
+	return obj["spec"], nil
+}
+
+// patchObject patches current to desired.
+func patchObject(ctx context.Context, c client.Client, current, desired client.Object) error {
+	patchHelper, err := patch.NewHelper(current, c)
+	if err != nil {
+		return err
+	}
+	if err := patchHelper.Patch(ctx, desired); err != nil {
+		return errors.Wrapf(err, "failed to patch %s %s", desired.GetObjectKind().GroupVersionKind().Kind, klog.KObj(desired))
+	}
+	return nil
+}
+
+func groupKind(ref clusterv1.ContractVersionedObjectReference) string {
+	return schema.GroupKind{Group: ref.APIGroup, Kind: ref.Kind}.String()
+}
+
+// mergeMaps recursively merges src into dst; values in src take precedence over values in dst.
+func mergeMaps(dst, src map[string]interface{}) {
+	for k, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[k] = srcValue
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inplace
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+var ctx = ctrl.SetupSignalHandler()
+
+func TestComputeDesiredObjectFromTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	current := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": builder.InfrastructureGroupVersion.String(),
+		"kind":       builder.GenericInfrastructureMachineKind,
+		"metadata": map[string]interface{}{
+			"name":      "infra-machine",
+			"namespace": metav1.NamespaceDefault,
+			"annotations": map[string]interface{}{
+				clusterv1.TemplateClonedFromNameAnnotation:      "old-template",
+				clusterv1.TemplateClonedFromGroupKindAnnotation: "GenericInfrastructureMachineTemplate.infrastructure.cluster.x-k8s.io",
+			},
+		},
+		"spec": map[string]interface{}{
+			"providerID": "test://id-1",
+			"image":      "old-image",
+			"nested": map[string]interface{}{
+				"a": "old",
+				"b": "keep",
+			},
+			"list": []interface{}{"a", "b"},
+		},
+	}}
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": builder.InfrastructureGroupVersion.String(),
+		"kind":       builder.GenericInfrastructureMachineTemplateKind,
+		"metadata": map[string]interface{}{
+			"name":      "new-template",
+			"namespace": metav1.NamespaceDefault,
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"image": "new-image",
+					"nested": map[string]interface{}{
+						"a": "new",
+					},
+					"list": []interface{}{"c"},
+				},
+			},
+		},
+	}}
+
+	desired, err := ComputeDesiredObjectFromTemplate(current, template)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(desired.Object["spec"]).To(Equal(map[string]interface{}{
+		"providerID": "test://id-1",
+		"image":      "new-image",
+		"nested": map[string]interface{}{
+			"a": "new",
+			"b": "keep",
+		},
+		"list": []interface{}{"c"},
+	}))
+	g.Expect(desired.GetAnnotations()).To(HaveKeyWithValue(clusterv1.TemplateClonedFromNameAnnotation, "new-template"))
+	g.Expect(desired.GetAnnotations()).To(HaveKeyWithValue(clusterv1.TemplateClonedFromGroupKindAnnotation, "GenericInfrastructureMachineTemplate.infrastructure.cluster.x-k8s.io"))
+	g.Expect(IsClonedFrom(desired, "GenericInfrastructureMachineTemplate.infrastructure.cluster.x-k8s.io", "new-template")).To(BeTrue())
+
+	// Current must not be changed.
+	g.Expect(current.Object["spec"].(map[string]interface{})["image"]).To(Equal("old-image"))
+	g.Expect(IsClonedFrom(current, "GenericInfrastructureMachineTemplate.infrastructure.cluster.x-k8s.io", "new-template")).To(BeFalse())
+}
+
+func TestCanUpdateMachine(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	canUpdateMachineGVH, err := catalog.GroupVersionHook(runtimehooksv1.CanUpdateMachine)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+
+	current := &MachineObjects{
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "machine",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: "cluster",
+				Version:     ptr.To("v1.31.0"),
+			},
+		},
+		InfraMachine: builder.InfrastructureMachine(metav1.NamespaceDefault, "infra-machine").Build(),
+	}
+	_ = unstructured.SetNestedField(current.InfraMachine.Object, "old-image", "spec", "image")
+
+	desired := current.DeepCopy()
+	desired.Machine.Spec.Version = ptr.To("v1.32.0")
+	_ = unstructured.SetNestedField(desired.InfraMachine.Object, "new-image", "spec", "image")
+
+	versionPatch := &runtimehooksv1.CanUpdateMachineResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		MachinePatch: runtimehooksv1.Patch{
+			PatchType: runtimehooksv1.JSONPatchType,
+			Patch:     []byte(`[{"op":"replace","path":"/spec/version","value":"v1.32.0"}]`),
+		},
+	}
+	imagePatch := &runtimehooksv1.CanUpdateMachineResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		InfrastructureMachinePatch: runtimehooksv1.Patch{
+			PatchType: runtimehooksv1.JSONMergePatchType,
+			Patch:     []byte(`{"spec":{"image":"new-image"}}`),
+		},
+	}
+	noPatch := &runtimehooksv1.CanUpdateMachineResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+	}
+
+	tests := []struct {
+		name             string
+		extensions       []string
+		responses        map[string]runtimehooksv1.ResponseObject
+		wantCanUpdate    bool
+		wantMessage      string
+		wantErr          bool
+		nilRuntimeClient bool
+	}{
+		{
+			name:             "cannot update without RuntimeClient",
+			nilRuntimeClient: true,
+			wantCanUpdate:    false,
+			wantMessage:      "RuntimeSDK is not enabled",
+		},
+		{
+			name:          "cannot update if no extensions are registered",
+			wantCanUpdate: false,
+			wantMessage:   "no Runtime Extensions implementing the CanUpdateMachine hook are registered",
+		},
+		{
+			name:       "cannot update if extensions do not cover all the changes",
+			extensions: []string{"version", "no-patch"},
+			responses: map[string]runtimehooksv1.ResponseObject{
+				"version":  versionPatch,
+				"no-patch": noPatch,
+			},
+			wantCanUpdate: false,
+			wantMessage:   "changes to GenericInfrastructureMachine cannot be applied in-place by any Runtime Extension",
+		},
+		{
+			name:       "can update if extensions together cover all the changes",
+			extensions: []string{"version", "image"},
+			responses: map[string]runtimehooksv1.ResponseObject{
+				"version": versionPatch,
+				"image":   imagePatch,
+			},
+			wantCanUpdate: true,
+		},
+		{
+			name:       "fails if an extension fails",
+			extensions: []string{"version", "failure"},
+			responses: map[string]runtimehooksv1.ResponseObject{
+				"version": versionPatch,
+				"failure": &runtimehooksv1.CanUpdateMachineResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var runtimeClient *fakeruntimeclient.RuntimeClient
+			if !tt.nilRuntimeClient {
+				runtimeClient = fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithGetAllExtensionResponses(map[runtimecatalog.GroupVersionHook][]string{
+						canUpdateMachineGVH: tt.extensions,
+					}).
+					WithCallExtensionResponses(tt.responses).
+					Build()
+			}
+
+			var canUpdate bool
+			var message string
+			var err error
+			if tt.nilRuntimeClient {
+				canUpdate, message, err = CanUpdateMachine(ctx, nil, current, desired)
+			} else {
+				canUpdate, message, err = CanUpdateMachine(ctx, runtimeClient, current, desired)
+			}
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(canUpdate).To(Equal(tt.wantCanUpdate))
+			g.Expect(message).To(Equal(tt.wantMessage))
+		})
+	}
+}
+
+func TestStartUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	machine := &clusterv1.Machine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Machine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "cluster",
+			Version:     ptr.To("v1.31.0"),
+		},
+	}
+	infraMachine := builder.InfrastructureMachine(metav1.NamespaceDefault, "infra-machine").Build()
+	_ = unstructured.SetNestedField(infraMachine.Object, "old-image", "spec", "image")
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine, infraMachine).Build()
+
+	current := &MachineObjects{
+		Machine:      machine.DeepCopy(),
+		InfraMachine: infraMachine.DeepCopy(),
+	}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), current.Machine)).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(infraMachine), current.InfraMachine)).To(Succeed())
+
+	desired := current.DeepCopy()
+	desired.Machine.Spec.Version = ptr.To("v1.32.0")
+	_ = unstructured.SetNestedField(desired.InfraMachine.Object, "new-image", "spec", "image")
+
+	g.Expect(IsUpdateInProgress(current.Machine)).To(BeFalse())
+	g.Expect(StartUpdate(ctx, c, current, desired)).To(Succeed())
+	g.Expect(IsUpdateStarted(current.Machine)).To(BeTrue())
+
+	gotMachine := &clusterv1.Machine{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+	g.Expect(gotMachine.Spec.Version).To(Equal(ptr.To("v1.32.0")))
+	g.Expect(gotMachine.Annotations).To(HaveKey(clusterv1.UpdateInProgressAnnotation))
+	g.Expect(gotMachine.Annotations).To(HaveKeyWithValue(runtimev1.PendingHooksAnnotation, "UpdateMachine"))
+	g.Expect(IsUpdateStarted(gotMachine)).To(BeTrue())
+
+	gotInfraMachine := infraMachine.DeepCopy()
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(infraMachine), gotInfraMachine)).To(Succeed())
+	image, _, _ := unstructured.NestedString(gotInfraMachine.Object, "spec", "image")
+	g.Expect(image).To(Equal("new-image"))
+}
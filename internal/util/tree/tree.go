@@ -19,7 +19,6 @@ package tree
 import (
 	"fmt"
 	"io"
-	"os"
 	"regexp"
 	"sort"
 	"strings"
@@ -78,9 +77,9 @@ func PrintObjectTree(tree *tree.ObjectTree, w io.Writer) {
 
 // PrintObjectTreeV1Beta1 prints the cluster status to stdout.
 // Note: this function is exposed only for usage in clusterctl and Cluster API E2E tests.
-func PrintObjectTreeV1Beta1(tree *tree.ObjectTree) {
+func PrintObjectTreeV1Beta1(tree *tree.ObjectTree, w io.Writer) {
 	// Creates the output table
-	tbl := tablewriter.NewWriter(os.Stdout)
+	tbl := tablewriter.NewWriter(w)
 	tbl.SetHeader([]string{"NAME", "READY", "SEVERITY", "REASON", "SINCE", "MESSAGE"})
 
 	formatTableTreeV1Beta1(tbl)
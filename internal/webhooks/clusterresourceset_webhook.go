@@ -19,8 +19,12 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"path"
 	"reflect"
+	"strings"
+	"text/template"
 
+	"github.com/Masterminds/sprig/v3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -133,9 +137,51 @@ func (webhook *ClusterResourceSet) validate(oldCRS, newCRS *addonsv1.ClusterReso
 		)
 	}
 
+	for i, resource := range newCRS.Spec.Resources {
+		allErrs = append(allErrs, validateResourceRef(resource, field.NewPath("spec", "resources").Index(i))...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 
 	return apierrors.NewInvalid(addonsv1.GroupVersion.WithKind("ClusterResourceSet").GroupKind(), newCRS.Name, allErrs)
 }
+
+func validateResourceRef(resource addonsv1.ResourceRef, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	format := addonsv1.ClusterResourceSetResourceFormat(resource.Format)
+	if resource.HelmChart != nil && format != addonsv1.HelmChartClusterResourceSetResourceFormat {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(fldPath.Child("helmChart"), "can be set only if format is HelmChart"),
+		)
+	}
+	if resource.Kustomization != nil && format != addonsv1.KustomizationClusterResourceSetResourceFormat {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(fldPath.Child("kustomization"), "can be set only if format is Kustomization"),
+		)
+	}
+
+	if resource.HelmChart != nil && resource.HelmChart.ValuesTemplate != "" {
+		if _, err := template.New("valuesTemplate").Funcs(sprig.HermeticTxtFuncMap()).Parse(resource.HelmChart.ValuesTemplate); err != nil {
+			allErrs = append(
+				allErrs,
+				field.Invalid(fldPath.Child("helmChart", "valuesTemplate"), resource.HelmChart.ValuesTemplate, fmt.Sprintf("template must be a valid Go template: %v", err)),
+			)
+		}
+	}
+
+	if resource.Kustomization != nil && resource.Kustomization.Path != "" {
+		if p := resource.Kustomization.Path; path.IsAbs(p) || path.Clean(p) == ".." || strings.HasPrefix(path.Clean(p), "../") {
+			allErrs = append(
+				allErrs,
+				field.Invalid(fldPath.Child("kustomization", "path"), p, "path must be relative and must not reference parent directories"),
+			)
+		}
+	}
+
+	return allErrs
+}
@@ -210,3 +210,102 @@ func TestClusterResourceSetSelectorNotEmptyValidation(t *testing.T) {
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(err.Error()).To(ContainSubstring("selector must not be empty"))
 }
+
+func TestClusterResourceSetResourcesValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		resource  addonsv1.ResourceRef
+		expectErr string
+	}{
+		{
+			name:     "should not return error for a raw resource",
+			resource: addonsv1.ResourceRef{Name: "cni", Kind: "ConfigMap"},
+		},
+		{
+			name: "should not return error for a valid HelmChart resource",
+			resource: addonsv1.ResourceRef{
+				Name:   "cni",
+				Kind:   "ConfigMap",
+				Format: string(addonsv1.HelmChartClusterResourceSetResourceFormat),
+				HelmChart: &addonsv1.HelmChartSource{
+					ValuesTemplate: "clusterName: {{ .Cluster.Name }}",
+				},
+			},
+		},
+		{
+			name: "should not return error for a valid Kustomization resource",
+			resource: addonsv1.ResourceRef{
+				Name:   "cni",
+				Kind:   "Secret",
+				Format: string(addonsv1.KustomizationClusterResourceSetResourceFormat),
+				Kustomization: &addonsv1.KustomizationSource{
+					Path: "overlays/production",
+				},
+			},
+		},
+		{
+			name: "should return error if helmChart is set without HelmChart format",
+			resource: addonsv1.ResourceRef{
+				Name:      "cni",
+				Kind:      "ConfigMap",
+				HelmChart: &addonsv1.HelmChartSource{},
+			},
+			expectErr: "can be set only if format is HelmChart",
+		},
+		{
+			name: "should return error if kustomization is set without Kustomization format",
+			resource: addonsv1.ResourceRef{
+				Name:          "cni",
+				Kind:          "ConfigMap",
+				Format:        string(addonsv1.HelmChartClusterResourceSetResourceFormat),
+				Kustomization: &addonsv1.KustomizationSource{},
+			},
+			expectErr: "can be set only if format is Kustomization",
+		},
+		{
+			name: "should return error for an invalid values template",
+			resource: addonsv1.ResourceRef{
+				Name:   "cni",
+				Kind:   "ConfigMap",
+				Format: string(addonsv1.HelmChartClusterResourceSetResourceFormat),
+				HelmChart: &addonsv1.HelmChartSource{
+					ValuesTemplate: "clusterName: {{ .Cluster.Name ",
+				},
+			},
+			expectErr: "template must be a valid Go template",
+		},
+		{
+			name: "should return error for a kustomization path referencing a parent directory",
+			resource: addonsv1.ResourceRef{
+				Name:   "cni",
+				Kind:   "ConfigMap",
+				Format: string(addonsv1.KustomizationClusterResourceSetResourceFormat),
+				Kustomization: &addonsv1.KustomizationSource{
+					Path: "overlays/../../base",
+				},
+			},
+			expectErr: "path must be relative and must not reference parent directories",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				Spec: addonsv1.ClusterResourceSetSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
+					Resources: []addonsv1.ResourceRef{tt.resource},
+				},
+			}
+			webhook := ClusterResourceSet{}
+			err := webhook.validate(nil, clusterResourceSet)
+			if tt.expectErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.expectErr)))
+			}
+		})
+	}
+}
@@ -339,6 +339,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// In-place updates require Runtime Extensions implementing the in-place update hooks.
+	if feature.Gates.Enabled(feature.InPlaceUpdates) && !feature.Gates.Enabled(feature.RuntimeSDK) {
+		setupLog.Error(errors.Errorf("%s feature flag requires the %s feature flag to be enabled", feature.InPlaceUpdates, feature.RuntimeSDK), "Unable to start manager")
+		os.Exit(1)
+	}
+
 	if err := version.CheckKubernetesVersion(restConfig, minVer); err != nil {
 		setupLog.Error(err, "Unable to start manager")
 		os.Exit(1)
@@ -669,6 +675,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		RemoteConditionsGracePeriod:      remoteConditionsGracePeriod,
 		AdditionalSyncMachineLabels:      additionalSyncMachineLabelRegexes,
 		AdditionalSyncMachineAnnotations: additionalSyncMachineAnnotationRegexes,
+		RuntimeClient:                    runtimeClient,
 	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "Machine")
 		os.Exit(1)
@@ -698,6 +705,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		ClusterCache:     clusterCache,
 		PreflightChecks:  machineSetPreflightChecksSet,
 		WatchFilterValue: watchFilterValue,
+		RuntimeClient:    runtimeClient,
 	}).SetupWithManager(ctx, mgr, concurrency(machineSetConcurrency)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "MachineSet")
 		os.Exit(1)
@@ -706,6 +714,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		Client:           mgr.GetClient(),
 		APIReader:        mgr.GetAPIReader(),
 		WatchFilterValue: watchFilterValue,
+		RuntimeClient:    runtimeClient,
 	}).SetupWithManager(ctx, mgr, concurrency(machineDeploymentConcurrency)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "MachineDeployment")
 		os.Exit(1)
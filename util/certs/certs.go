@@ -19,6 +19,8 @@ package certs
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -26,6 +28,8 @@ import (
 
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 )
 
 // NewPrivateKey creates an RSA private key.
@@ -34,6 +38,28 @@ func NewPrivateKey() (*rsa.PrivateKey, error) {
 	return pk, errors.WithStack(err)
 }
 
+// NewSigner creates a private key using the given encryption algorithm.
+// If the encryption algorithm is not set, an RSA private key with DefaultRSAKeySize is created.
+func NewSigner(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (crypto.Signer, error) {
+	var pk crypto.Signer
+	var err error
+	switch encryptionAlgorithm {
+	case "", bootstrapv1.EncryptionAlgorithmRSA2048:
+		pk, err = rsa.GenerateKey(rand.Reader, DefaultRSAKeySize)
+	case bootstrapv1.EncryptionAlgorithmRSA3072:
+		pk, err = rsa.GenerateKey(rand.Reader, 3072)
+	case bootstrapv1.EncryptionAlgorithmRSA4096:
+		pk, err = rsa.GenerateKey(rand.Reader, 4096)
+	case bootstrapv1.EncryptionAlgorithmECDSAP256:
+		pk, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case bootstrapv1.EncryptionAlgorithmECDSAP384:
+		pk, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	default:
+		return nil, errors.Errorf("unsupported encryption algorithm %q", encryptionAlgorithm)
+	}
+	return pk, errors.WithStack(err)
+}
+
 // EncodeCertPEM returns PEM-endcoded certificate data.
 func EncodeCertPEM(cert *x509.Certificate) []byte {
 	block := pem.Block{
@@ -53,6 +79,27 @@ func EncodePrivateKeyPEM(key *rsa.PrivateKey) []byte {
 	return pem.EncodeToMemory(&block)
 }
 
+// EncodePrivateKeyPEMFromSigner returns PEM-encoded private key data.
+// RSA keys are encoded using PKCS1, ECDSA keys using SEC 1, which are the formats also used by kubeadm.
+func EncodePrivateKeyPEMFromSigner(key crypto.Signer) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return EncodePrivateKeyPEM(k), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		block := pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}
+		return pem.EncodeToMemory(&block), nil
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", key)
+	}
+}
+
 // EncodePublicKeyPEM returns PEM-encoded public key data.
 func EncodePublicKeyPEM(key *rsa.PublicKey) ([]byte, error) {
 	der, err := x509.MarshalPKIXPublicKey(key)
@@ -66,6 +113,19 @@ func EncodePublicKeyPEM(key *rsa.PublicKey) ([]byte, error) {
 	return pem.EncodeToMemory(&block), nil
 }
 
+// EncodePublicKeyPEMFromSigner returns PEM-encoded public key data for the given private key.
+func EncodePublicKeyPEMFromSigner(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return []byte{}, errors.WithStack(err)
+	}
+	block := pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: der,
+	}
+	return pem.EncodeToMemory(&block), nil
+}
+
 // DecodeCertPEM attempts to return a decoded certificate or nil
 // if the encoded input does not contain a certificate.
 func DecodeCertPEM(encoded []byte) (*x509.Certificate, error) {
@@ -17,9 +17,14 @@ limitations under the License.
 package certs
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"testing"
 
 	. "github.com/onsi/gomega"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 )
 
 type decodeTest struct {
@@ -143,3 +148,73 @@ func TestDecodeCertPEM(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSigner(t *testing.T) {
+	cases := []struct {
+		name                string
+		encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+		expectRSAKeySize    int
+		expectCurve         elliptic.Curve
+		expectError         bool
+	}{
+		{
+			name:             "default to RSA 2048",
+			expectRSAKeySize: 2048,
+		},
+		{
+			name:                "RSA 2048",
+			encryptionAlgorithm: bootstrapv1.EncryptionAlgorithmRSA2048,
+			expectRSAKeySize:    2048,
+		},
+		{
+			name:                "RSA 3072",
+			encryptionAlgorithm: bootstrapv1.EncryptionAlgorithmRSA3072,
+			expectRSAKeySize:    3072,
+		},
+		{
+			name:                "ECDSA P256",
+			encryptionAlgorithm: bootstrapv1.EncryptionAlgorithmECDSAP256,
+			expectCurve:         elliptic.P256(),
+		},
+		{
+			name:                "ECDSA P384",
+			encryptionAlgorithm: bootstrapv1.EncryptionAlgorithmECDSAP384,
+			expectCurve:         elliptic.P384(),
+		},
+		{
+			name:                "return error for unsupported encryption algorithm",
+			encryptionAlgorithm: "Ed25519",
+			expectError:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			key, err := NewSigner(tc.encryptionAlgorithm)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			if tc.expectCurve != nil {
+				g.Expect(key).To(BeAssignableToTypeOf(&ecdsa.PrivateKey{}))
+				g.Expect(key.(*ecdsa.PrivateKey).Curve).To(Equal(tc.expectCurve))
+			} else {
+				g.Expect(key).To(BeAssignableToTypeOf(&rsa.PrivateKey{}))
+				g.Expect(key.(*rsa.PrivateKey).N.BitLen()).To(Equal(tc.expectRSAKeySize))
+			}
+
+			// Encoded keys must be readable back.
+			encoded, err := EncodePrivateKeyPEMFromSigner(key)
+			g.Expect(err).ToNot(HaveOccurred())
+			decoded, err := DecodePrivateKeyPEM(encoded)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(decoded).To(Equal(key))
+
+			_, err = EncodePublicKeyPEMFromSigner(key)
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
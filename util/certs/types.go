@@ -46,10 +46,16 @@ type Config struct {
 	Organization []string
 	AltNames     AltNames
 	Usages       []x509.ExtKeyUsage
+	// Duration is the lifespan of the certificate; if zero, DefaultCertDuration is used.
+	Duration time.Duration
+	// NotBefore is the time the certificate becomes valid; if zero, the NotBefore of the CA certificate is used.
+	NotBefore time.Time
+	// NotAfter is the time the certificate expires; if set, it takes precedence over Duration.
+	NotAfter time.Time
 }
 
 // NewSignedCert creates a signed certificate using the given CA certificate and key.
-func (cfg *Config) NewSignedCert(key *rsa.PrivateKey, caCert *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, error) {
+func (cfg *Config) NewSignedCert(key crypto.Signer, caCert *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate random integer for signed cerficate")
@@ -63,6 +69,19 @@ func (cfg *Config) NewSignedCert(key *rsa.PrivateKey, caCert *x509.Certificate,
 		return nil, errors.New("must specify at least one ExtKeyUsage")
 	}
 
+	duration := cfg.Duration
+	if duration == 0 {
+		duration = DefaultCertDuration
+	}
+	notBefore := cfg.NotBefore
+	if notBefore.IsZero() {
+		notBefore = caCert.NotBefore
+	}
+	notAfter := cfg.NotAfter
+	if notAfter.IsZero() {
+		notAfter = time.Now().Add(duration)
+	}
+
 	tmpl := x509.Certificate{
 		Subject: pkix.Name{
 			CommonName:   cfg.CommonName,
@@ -71,11 +90,15 @@ func (cfg *Config) NewSignedCert(key *rsa.PrivateKey, caCert *x509.Certificate,
 		DNSNames:     cfg.AltNames.DNSNames,
 		IPAddresses:  cfg.AltNames.IPs,
 		SerialNumber: serial,
-		NotBefore:    caCert.NotBefore,
-		NotAfter:     time.Now().Add(DefaultCertDuration).UTC(),
-		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		NotBefore:    notBefore.UTC(),
+		NotAfter:     notAfter.UTC(),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  cfg.Usages,
 	}
+	// Key encipherment is only meaningful for RSA keys.
+	if _, ok := key.Public().(*rsa.PublicKey); ok {
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
 
 	b, err := x509.CreateCertificate(rand.Reader, &tmpl, caCert, key.Public(), caKey)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
@@ -53,25 +55,107 @@ func FromSecret(ctx context.Context, c client.Reader, cluster client.ObjectKey)
 	return toKubeconfigBytes(out)
 }
 
+// User defines the identity of the client certificate of a Kubeconfig generated with NewForUser.
+type User struct {
+	// Name is the name of the user, set as CommonName of the client certificate.
+	Name string
+
+	// Groups are the groups of the user, set as Organization of the client certificate.
+	Groups []string
+
+	// TTL is the lifespan of the client certificate; if zero, certs.DefaultCertDuration is used.
+	// The lifespan is capped so the client certificate never expires after the CA certificate.
+	TTL time.Duration
+
+	// AllowSystemMasters allows the system:masters group in Groups; members of this group
+	// have full access to the cluster, bypassing RBAC.
+	AllowSystemMasters bool
+}
+
+// clientCertificateBackdate is subtracted from the current time when setting the NotBefore of the client
+// certificate of a Kubeconfig generated with NewForUser, to tolerate clock skew between machines.
+const clientCertificateBackdate = 5 * time.Minute
+
+// Option configures how a Kubeconfig is generated.
+type Option func(*options)
+
+type options struct {
+	encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+}
+
+// WithEncryptionAlgorithm sets the encryption algorithm used to generate the private key of the client certificate.
+// If not set, an RSA-2048 private key is generated.
+func WithEncryptionAlgorithm(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) Option {
+	return func(o *options) {
+		o.encryptionAlgorithm = encryptionAlgorithm
+	}
+}
+
 // New creates a new Kubeconfig using the cluster name and specified endpoint.
-func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Signer) (*api.Config, error) {
+func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Signer, opts ...Option) (*api.Config, error) {
 	cfg := &certs.Config{
 		CommonName:   "kubernetes-admin",
 		Organization: []string{"system:masters"},
 		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 	}
+	return newConfig(clusterName, endpoint, fmt.Sprintf("%s-admin", clusterName), cfg, caCert, caKey, opts...)
+}
 
-	clientKey, err := certs.NewPrivateKey()
+// NewForUser creates a new Kubeconfig using the cluster name and specified endpoint, with a client
+// certificate signed by the given CA for the given user.
+func NewForUser(clusterName, endpoint string, user User, caCert *x509.Certificate, caKey crypto.Signer, opts ...Option) (*api.Config, error) {
+	if user.Name == "" {
+		return nil, errors.New("user name must be specified")
+	}
+	if user.TTL < 0 {
+		return nil, errors.Errorf("invalid TTL %s, it must not be negative", user.TTL)
+	}
+	if !user.AllowSystemMasters && slices.Contains(user.Groups, "system:masters") {
+		return nil, errors.New("system:masters group grants full access to the cluster, it must be explicitly allowed")
+	}
+	now := time.Now()
+	ttl := user.TTL
+	if ttl == 0 {
+		ttl = certs.DefaultCertDuration
+	}
+	if !caCert.NotAfter.After(now) {
+		return nil, errors.Errorf("CA certificate expired at %s", caCert.NotAfter)
+	}
+	// The client certificate must not outlive the CA certificate.
+	notAfter := now.Add(ttl)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+	cfg := &certs.Config{
+		CommonName:   user.Name,
+		Organization: user.Groups,
+		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		NotBefore:    now.Add(-clientCertificateBackdate),
+		NotAfter:     notAfter,
+	}
+	return newConfig(clusterName, endpoint, user.Name, cfg, caCert, caKey, opts...)
+}
+
+func newConfig(clusterName, endpoint, userName string, cfg *certs.Config, caCert *x509.Certificate, caKey crypto.Signer, opts ...Option) (*api.Config, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	clientKey, err := certs.NewSigner(o.encryptionAlgorithm)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create private key")
 	}
+	clientKeyData, err := certs.EncodePrivateKeyPEMFromSigner(clientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode private key")
+	}
 
 	clientCert, err := cfg.NewSignedCert(clientKey, caCert, caKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to sign certificate")
 	}
 
-	userName := fmt.Sprintf("%s-admin", clusterName)
 	contextName := fmt.Sprintf("%s@%s", userName, clusterName)
 
 	return &api.Config{
@@ -89,7 +173,7 @@ func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Si
 		},
 		AuthInfos: map[string]*api.AuthInfo{
 			userName: {
-				ClientKeyData:         certs.EncodePrivateKeyPEM(clientKey),
+				ClientKeyData:         clientKeyData,
 				ClientCertificateData: certs.EncodeCertPEM(clientCert),
 			},
 		},
@@ -109,12 +193,12 @@ func CreateSecret(ctx context.Context, c client.Client, cluster *clusterv1.Clust
 }
 
 // CreateSecretWithOwner creates the Kubeconfig secret for the given cluster name, namespace, endpoint, and owner reference.
-func CreateSecretWithOwner(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, owner metav1.OwnerReference) error {
+func CreateSecretWithOwner(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, owner metav1.OwnerReference, opts ...Option) error {
 	server, err := url.JoinPath("https://", endpoint)
 	if err != nil {
 		return err
 	}
-	out, err := generateKubeconfig(ctx, c, clusterName, server)
+	out, err := generateKubeconfig(ctx, c, clusterName, server, opts...)
 	if err != nil {
 		return err
 	}
@@ -181,7 +265,7 @@ func NeedsClientCertRotation(configSecret *corev1.Secret, threshold time.Duratio
 }
 
 // RegenerateSecret creates and stores a new Kubeconfig in the given secret.
-func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret) error {
+func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret, opts ...Option) error {
 	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse secret name")
@@ -197,7 +281,7 @@ func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1
 	}
 	endpoint := config.Clusters[clusterName].Server
 	key := client.ObjectKey{Name: clusterName, Namespace: configSecret.Namespace}
-	out, err := generateKubeconfig(ctx, c, key, endpoint)
+	out, err := generateKubeconfig(ctx, c, key, endpoint, opts...)
 	if err != nil {
 		return err
 	}
@@ -205,33 +289,38 @@ func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1
 	return c.Update(ctx, configSecret)
 }
 
-func generateKubeconfig(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string) ([]byte, error) {
-	clusterCA, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.ClusterCA)
+// GenerateForUser generates a Kubeconfig for the given cluster name and endpoint, with a client
+// certificate for the given user signed by the cluster CA.
+func GenerateForUser(ctx context.Context, c client.Reader, clusterName client.ObjectKey, endpoint string, user User, opts ...Option) ([]byte, error) {
+	caData, cert, key, err := getClusterCA(ctx, c, clusterName)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil, ErrDependentCertificateNotFound
-		}
 		return nil, err
 	}
 
-	cert, err := certs.DecodeCertPEM(clusterCA.Data[secret.TLSCrtDataName])
+	cfg, err := NewForUser(clusterName.Name, endpoint, user, cert, key, opts...)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode CA Cert")
-	} else if cert == nil {
-		return nil, errors.New("certificate not found in config")
+		return nil, errors.Wrap(err, "failed to generate a kubeconfig")
 	}
+	cfg.Clusters[clusterName.Name].CertificateAuthorityData = caData
 
-	key, err := certs.DecodePrivateKeyPEM(clusterCA.Data[secret.TLSKeyDataName])
+	out, err := clientcmd.Write(*cfg)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode private key")
-	} else if key == nil {
-		return nil, errors.New("CA private key not found")
+		return nil, errors.Wrap(err, "failed to serialize config to yaml")
+	}
+	return out, nil
+}
+
+func generateKubeconfig(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, opts ...Option) ([]byte, error) {
+	caData, cert, key, err := getClusterCA(ctx, c, clusterName)
+	if err != nil {
+		return nil, err
 	}
 
-	cfg, err := New(clusterName.Name, endpoint, cert, key)
+	cfg, err := New(clusterName.Name, endpoint, cert, key, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate a kubeconfig")
 	}
+	cfg.Clusters[clusterName.Name].CertificateAuthorityData = caData
 
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
@@ -240,6 +329,34 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 	return out, nil
 }
 
+// getClusterCA returns the certificate data of the cluster CA, together with the parsed CA certificate and key used for signing.
+// NOTE: The certificate data could contain more than one certificate, e.g. during a rotation of the cluster CA; in this
+// case the first certificate is the one matching the CA key.
+func getClusterCA(ctx context.Context, c client.Reader, clusterName client.ObjectKey) ([]byte, *x509.Certificate, crypto.Signer, error) {
+	clusterCA, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.ClusterCA)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil, ErrDependentCertificateNotFound
+		}
+		return nil, nil, nil, err
+	}
+
+	cert, err := certs.DecodeCertPEM(clusterCA.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to decode CA Cert")
+	} else if cert == nil {
+		return nil, nil, nil, errors.New("certificate not found in config")
+	}
+
+	key, err := certs.DecodePrivateKeyPEM(clusterCA.Data[secret.TLSKeyDataName])
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to decode private key")
+	} else if key == nil {
+		return nil, nil, nil, errors.New("CA private key not found")
+	}
+	return clusterCA.Data[secret.TLSCrtDataName], cert, key, nil
+}
+
 func toKubeconfigBytes(out *corev1.Secret) ([]byte, error) {
 	data, ok := out.Data[secret.KubeconfigDataName]
 	if !ok {
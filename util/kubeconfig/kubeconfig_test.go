@@ -17,6 +17,8 @@ limitations under the License.
 package kubeconfig
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -34,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
@@ -177,6 +180,169 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithEncryptionAlgorithm(t *testing.T) {
+	g := NewWithT(t)
+
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	actualConfig, err := New("foo", "https://127.0.0.1:4003", caCert, caKey, WithEncryptionAlgorithm(bootstrapv1.EncryptionAlgorithmECDSAP256))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(actualConfig.AuthInfos).To(HaveKey("foo-admin"))
+
+	cert, err := certs.DecodeCertPEM(actualConfig.AuthInfos["foo-admin"].ClientCertificateData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cert.CheckSignatureFrom(caCert)).To(Succeed())
+	g.Expect(cert.PublicKey).To(BeAssignableToTypeOf(&ecdsa.PublicKey{}))
+	g.Expect(cert.PublicKey.(*ecdsa.PublicKey).Curve).To(Equal(elliptic.P256()))
+
+	key, err := certs.DecodePrivateKeyPEM(actualConfig.AuthInfos["foo-admin"].ClientKeyData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(key.Public()).To(Equal(cert.PublicKey))
+}
+
+func TestNewForUser(t *testing.T) {
+	caKey, err := certs.NewPrivateKey()
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+	// Simulate a CA created a while ago; the NotBefore of the client certificates must not depend on it.
+	caCert.NotBefore = caCert.NotBefore.Add(-24 * time.Hour)
+
+	testCases := []struct {
+		name        string
+		user        User
+		expectError bool
+	}{
+		{
+			name: "user with groups and TTL",
+			user: User{Name: "jane", Groups: []string{"devs", "ops"}, TTL: time.Hour},
+		},
+		{
+			name: "user without groups and TTL",
+			user: User{Name: "jane"},
+		},
+		{
+			name: "user with a TTL exceeding the lifespan of the CA",
+			user: User{Name: "jane", TTL: 48 * time.Hour},
+		},
+		{
+			name:        "fails without user name",
+			user:        User{Groups: []string{"devs"}},
+			expectError: true,
+		},
+		{
+			name: "user member of system:masters if explicitly allowed",
+			user: User{Name: "jane", Groups: []string{"system:masters"}, AllowSystemMasters: true},
+		},
+		{
+			name:        "fails for a user member of system:masters if not explicitly allowed",
+			user:        User{Name: "jane", Groups: []string{"devs", "system:masters"}},
+			expectError: true,
+		},
+		{
+			name:        "fails with negative TTL",
+			user:        User{Name: "jane", TTL: -time.Hour},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			actualConfig, err := NewForUser("foo", "https://127.0.0.1:4003", tc.user, caCert, caKey)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(actualConfig.CurrentContext).To(Equal("jane@foo"))
+			g.Expect(actualConfig.Contexts).To(BeComparableTo(map[string]*api.Context{
+				"jane@foo": {Cluster: "foo", AuthInfo: "jane"},
+			}))
+			g.Expect(actualConfig.AuthInfos).To(HaveKey("jane"))
+
+			cert, err := certs.DecodeCertPEM(actualConfig.AuthInfos["jane"].ClientCertificateData)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(cert.CheckSignatureFrom(caCert)).To(Succeed())
+			g.Expect(cert.Subject.CommonName).To(Equal(tc.user.Name))
+			g.Expect(cert.Subject.Organization).To(ConsistOf(tc.user.Groups))
+			g.Expect(cert.ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageClientAuth))
+
+			expectedTTL := tc.user.TTL
+			if expectedTTL == 0 {
+				expectedTTL = certs.DefaultCertDuration
+			}
+			expectedNotAfter := time.Now().Add(expectedTTL)
+			// NotAfter is capped at the NotAfter of the CA certificate.
+			if expectedNotAfter.After(caCert.NotAfter) {
+				g.Expect(cert.NotAfter).To(BeTemporally("~", caCert.NotAfter, time.Second))
+				expectedNotAfter = caCert.NotAfter
+			}
+			g.Expect(cert.NotAfter).To(BeTemporally("~", expectedNotAfter, time.Minute))
+			g.Expect(cert.NotAfter).ToNot(BeTemporally(">", caCert.NotAfter))
+			// NotBefore is not set from the CA certificate, but slightly backdated to tolerate clock skew.
+			g.Expect(cert.NotBefore).To(BeTemporally("~", time.Now().Add(-clientCertificateBackdate), time.Minute))
+		})
+	}
+
+	t.Run("fails if the CA certificate expired", func(t *testing.T) {
+		g := NewWithT(t)
+
+		expiredCACert := *caCert
+		expiredCACert.NotAfter = time.Now().Add(-time.Hour)
+		_, err := NewForUser("foo", "https://127.0.0.1:4003", User{Name: "jane"}, &expiredCACert, caKey)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestGenerateForUser(t *testing.T) {
+	g := NewWithT(t)
+
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(caKey),
+			secret.TLSCrtDataName: certs.EncodeCertPEM(caCert),
+		},
+	}
+	clusterName := client.ObjectKey{Name: "test1", Namespace: "test"}
+	user := User{Name: "jane", Groups: []string{"devs"}, TTL: time.Hour}
+
+	_, err = GenerateForUser(ctx, fake.NewClientBuilder().Build(), clusterName, "https://localhost:6443", user)
+	g.Expect(err).To(MatchError(ErrDependentCertificateNotFound))
+
+	out, err := GenerateForUser(ctx, fake.NewClientBuilder().WithObjects(caSecret).Build(), clusterName, "https://localhost:6443", user)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	clientConfig, err := clientcmd.NewClientConfigFromBytes(out)
+	g.Expect(err).ToNot(HaveOccurred())
+	restClient, err := clientConfig.ClientConfig()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restClient.CAData).To(Equal(certs.EncodeCertPEM(caCert)))
+	g.Expect(restClient.Host).To(Equal("https://localhost:6443"))
+
+	cert, err := certs.DecodeCertPEM(restClient.CertData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cert.Subject.CommonName).To(Equal("jane"))
+	g.Expect(cert.Subject.Organization).To(ConsistOf("devs"))
+}
+
 func TestGenerateSecretWithOwner(t *testing.T) {
 	g := NewWithT(t)
 
@@ -284,6 +450,59 @@ func TestCreateSecretWithOwner(t *testing.T) {
 	g.Expect(restClient.Host).To(Equal("https://localhost:6443"))
 }
 
+func TestCreateSecretWithOwnerWithCABundle(t *testing.T) {
+	g := NewWithT(t)
+
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	otherCAKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	otherCACert, err := getTestCACert(otherCAKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The CA bundle contains the CA used for signing first, and then another trusted CA.
+	caBundle := append(certs.EncodeCertPEM(caCert), certs.EncodeCertPEM(otherCACert)...)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(caKey),
+			secret.TLSCrtDataName: caBundle,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithObjects(caSecret).Build()
+
+	err = CreateSecretWithOwner(
+		ctx,
+		c,
+		client.ObjectKey{
+			Name:      "test1",
+			Namespace: "test",
+		},
+		"localhost:6443",
+		metav1.OwnerReference{},
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	s := &corev1.Secret{}
+	key := client.ObjectKey{Name: "test1-kubeconfig", Namespace: "test"}
+	g.Expect(c.Get(ctx, key, s)).To(Succeed())
+
+	config, err := clientcmd.Load(s.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Clusters["test1"].CertificateAuthorityData).To(Equal(caBundle))
+
+	clientCert, err := certs.DecodeCertPEM(config.AuthInfos["test1-admin"].ClientCertificateData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clientCert.CheckSignatureFrom(caCert)).To(Succeed())
+}
+
 func TestCreateSecretWithOwnerHasEndpointPrefixIsSlush(t *testing.T) {
 	g := NewWithT(t)
 
@@ -18,6 +18,7 @@ package secret
 
 import (
 	"context"
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -70,28 +71,37 @@ func NewCertificatesForInitialControlPlane(config *bootstrapv1.ClusterConfigurat
 		certificatesDir = config.CertificatesDir
 	}
 
+	var encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+	if config != nil {
+		encryptionAlgorithm = config.EncryptionAlgorithm
+	}
+
 	certificates := Certificates{
 		&Certificate{
-			Purpose:  ClusterCA,
-			CertFile: path.Join(certificatesDir, "ca.crt"),
-			KeyFile:  path.Join(certificatesDir, "ca.key"),
+			Purpose:             ClusterCA,
+			CertFile:            path.Join(certificatesDir, "ca.crt"),
+			KeyFile:             path.Join(certificatesDir, "ca.key"),
+			EncryptionAlgorithm: encryptionAlgorithm,
 		},
 		&Certificate{
-			Purpose:  ServiceAccount,
-			CertFile: path.Join(certificatesDir, "sa.pub"),
-			KeyFile:  path.Join(certificatesDir, "sa.key"),
+			Purpose:             ServiceAccount,
+			CertFile:            path.Join(certificatesDir, "sa.pub"),
+			KeyFile:             path.Join(certificatesDir, "sa.key"),
+			EncryptionAlgorithm: encryptionAlgorithm,
 		},
 		&Certificate{
-			Purpose:  FrontProxyCA,
-			CertFile: path.Join(certificatesDir, "front-proxy-ca.crt"),
-			KeyFile:  path.Join(certificatesDir, "front-proxy-ca.key"),
+			Purpose:             FrontProxyCA,
+			CertFile:            path.Join(certificatesDir, "front-proxy-ca.crt"),
+			KeyFile:             path.Join(certificatesDir, "front-proxy-ca.key"),
+			EncryptionAlgorithm: encryptionAlgorithm,
 		},
 	}
 
 	etcdCert := &Certificate{
-		Purpose:  EtcdCA,
-		CertFile: path.Join(certificatesDir, "etcd", "ca.crt"),
-		KeyFile:  path.Join(certificatesDir, "etcd", "ca.key"),
+		Purpose:             EtcdCA,
+		CertFile:            path.Join(certificatesDir, "etcd", "ca.crt"),
+		KeyFile:             path.Join(certificatesDir, "etcd", "ca.key"),
+		EncryptionAlgorithm: encryptionAlgorithm,
 	}
 
 	// TODO make sure all the fields are actually defined and return an error if not
@@ -324,6 +334,10 @@ type Certificate struct {
 	KeyPair           *certs.KeyPair
 	CertFile, KeyFile string
 	Secret            *corev1.Secret
+
+	// EncryptionAlgorithm is the encryption algorithm used when generating the key pair.
+	// If not set, RSA-2048 is used.
+	EncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
 }
 
 // Hashes hashes all the certificates stored in a CA certificate.
@@ -406,7 +420,7 @@ func (c *Certificate) Generate() error {
 		generator = generateServiceAccountKeys
 	}
 
-	kp, err := generator()
+	kp, err := generator(c.EncryptionAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -459,35 +473,43 @@ func secretToKeyPair(s *corev1.Secret) (*certs.KeyPair, error) {
 	}, nil
 }
 
-func generateCACert() (*certs.KeyPair, error) {
-	x509Cert, privKey, err := newCertificateAuthority()
+func generateCACert(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (*certs.KeyPair, error) {
+	x509Cert, privKey, err := newCertificateAuthority(encryptionAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	privKeyPEM, err := certs.EncodePrivateKeyPEMFromSigner(privKey)
 	if err != nil {
 		return nil, err
 	}
 	return &certs.KeyPair{
 		Cert: certs.EncodeCertPEM(x509Cert),
-		Key:  certs.EncodePrivateKeyPEM(privKey),
+		Key:  privKeyPEM,
 	}, nil
 }
 
-func generateServiceAccountKeys() (*certs.KeyPair, error) {
-	saCreds, err := certs.NewPrivateKey()
+func generateServiceAccountKeys(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (*certs.KeyPair, error) {
+	saCreds, err := certs.NewSigner(encryptionAlgorithm)
 	if err != nil {
 		return nil, err
 	}
-	saPub, err := certs.EncodePublicKeyPEM(&saCreds.PublicKey)
+	saPub, err := certs.EncodePublicKeyPEMFromSigner(saCreds)
+	if err != nil {
+		return nil, err
+	}
+	saKey, err := certs.EncodePrivateKeyPEMFromSigner(saCreds)
 	if err != nil {
 		return nil, err
 	}
 	return &certs.KeyPair{
 		Cert: saPub,
-		Key:  certs.EncodePrivateKeyPEM(saCreds),
+		Key:  saKey,
 	}, nil
 }
 
 // newCertificateAuthority creates new certificate and private key for the certificate authority.
-func newCertificateAuthority() (*x509.Certificate, *rsa.PrivateKey, error) {
-	key, err := certs.NewPrivateKey()
+func newCertificateAuthority(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (*x509.Certificate, crypto.Signer, error) {
+	key, err := certs.NewSigner(encryptionAlgorithm)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -501,7 +523,7 @@ func newCertificateAuthority() (*x509.Certificate, *rsa.PrivateKey, error) {
 }
 
 // newSelfSignedCACert creates a CA certificate.
-func newSelfSignedCACert(key *rsa.PrivateKey) (*x509.Certificate, error) {
+func newSelfSignedCACert(key crypto.Signer) (*x509.Certificate, error) {
 	cfg := certs.Config{
 		CommonName: "kubernetes",
 	}
@@ -516,12 +538,16 @@ func newSelfSignedCACert(key *rsa.PrivateKey) (*x509.Certificate, error) {
 		},
 		NotBefore:             now.Add(time.Minute * -5),
 		NotAfter:              now.Add(time.Hour * 24 * 365 * 10), // 10 years
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
 		MaxPathLenZero:        true,
 		BasicConstraintsValid: true,
 		MaxPathLen:            0,
 		IsCA:                  true,
 	}
+	// Key encipherment is only meaningful for RSA keys.
+	if _, ok := key.Public().(*rsa.PublicKey); ok {
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
 
 	b, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, key.Public(), key)
 	if err != nil {
@@ -17,11 +17,17 @@ limitations under the License.
 package secret_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 
 	. "github.com/onsi/gomega"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/secret"
 )
 
@@ -53,3 +59,65 @@ func TestNewControlPlaneJoinCertsAsFilesNotPanicsWhenEmpty(t *testing.T) {
 	certs := secret.NewControlPlaneJoinCerts(config)
 	g.Expect(certs.AsFiles()).To(BeEmpty())
 }
+
+func TestNewCertificatesForInitialControlPlaneGenerate(t *testing.T) {
+	tests := []struct {
+		name                string
+		encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+		expectPublicKey     func(g *WithT, publicKey any)
+	}{
+		{
+			name: "generates RSA keys by default",
+			expectPublicKey: func(g *WithT, publicKey any) {
+				g.Expect(publicKey).To(BeAssignableToTypeOf(&rsa.PublicKey{}))
+				g.Expect(publicKey.(*rsa.PublicKey).N.BitLen()).To(Equal(2048))
+			},
+		},
+		{
+			name:                "generates ECDSA keys",
+			encryptionAlgorithm: bootstrapv1.EncryptionAlgorithmECDSAP384,
+			expectPublicKey: func(g *WithT, publicKey any) {
+				g.Expect(publicKey).To(BeAssignableToTypeOf(&ecdsa.PublicKey{}))
+				g.Expect(publicKey.(*ecdsa.PublicKey).Curve).To(Equal(elliptic.P384()))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &bootstrapv1.ClusterConfiguration{
+				EncryptionAlgorithm: tt.encryptionAlgorithm,
+			}
+			certificates := secret.NewCertificatesForInitialControlPlane(config)
+			g.Expect(certificates.Generate()).To(Succeed())
+
+			for _, purpose := range []secret.Purpose{secret.ClusterCA, secret.EtcdCA, secret.FrontProxyCA} {
+				certificate := certificates.GetByPurpose(purpose)
+				g.Expect(certificate.Generated).To(BeTrue())
+
+				caCert, err := certs.DecodeCertPEM(certificate.KeyPair.Cert)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(caCert.IsCA).To(BeTrue())
+				tt.expectPublicKey(g, caCert.PublicKey)
+
+				caKey, err := certs.DecodePrivateKeyPEM(certificate.KeyPair.Key)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(caKey.Public()).To(Equal(caCert.PublicKey))
+			}
+
+			serviceAccount := certificates.GetByPurpose(secret.ServiceAccount)
+			g.Expect(serviceAccount.Generated).To(BeTrue())
+
+			block, _ := pem.Decode(serviceAccount.KeyPair.Cert)
+			g.Expect(block).ToNot(BeNil())
+			saPublicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+			g.Expect(err).ToNot(HaveOccurred())
+			tt.expectPublicKey(g, saPublicKey)
+
+			saKey, err := certs.DecodePrivateKeyPEM(serviceAccount.KeyPair.Key)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(saKey.Public()).To(Equal(saPublicKey))
+		})
+	}
+}